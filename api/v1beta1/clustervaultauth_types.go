@@ -0,0 +1,40 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster
+
+// ClusterVaultAuth is the Schema for the clustervaultauths API. It is the
+// cluster-scoped counterpart of VaultAuth, that can be referenced from any of
+// the namespaces in its Spec.AllowedNamespaces, with the clusterVaultAuthRef of
+// the secret resources. Its namespaced references, e.g. the VaultConnectionRef
+// and the VaultAuthGlobalRef, are relative to the Operator's namespace, and its
+// credentials, e.g. the ServiceAccount of the kubernetes method, are taken from
+// the namespace of the referring resource.
+type ClusterVaultAuth struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VaultAuthSpec   `json:"spec,omitempty"`
+	Status VaultAuthStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ClusterVaultAuthList contains a list of ClusterVaultAuth
+type ClusterVaultAuthList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterVaultAuth `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterVaultAuth{}, &ClusterVaultAuthList{})
+}
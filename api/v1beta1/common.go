@@ -5,6 +5,7 @@ package v1beta1
 
 import (
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // Destination provides the configuration that will be applied to the
@@ -20,16 +21,134 @@ type Destination struct {
 	// useful when migrating to VSO from a previous secret deployment strategy.
 	// +kubebuilder:default=false
 	Overwrite bool `json:"overwrite,omitempty"`
+	// OverwriteMode of the destination Secret's data. Replace makes the synced
+	// data the whole data of the Secret. Merge only writes the synced keys, and
+	// leaves the Secret's other keys untouched, e.g. those managed by another
+	// controller. In Merge mode, the keys that are no longer synced are only
+	// removed if the Operator is still their field manager, as tracked in the
+	// Secret's managedFields, and the data drift detection only applies to
+	// those keys. Ignored when Immutable or Secretless are set.
+	// +kubebuilder:validation:Enum={Replace,Merge}
+	// +kubebuilder:default=Replace
+	OverwriteMode string `json:"overwriteMode,omitempty"`
 	// Labels to apply to the Secret. Requires Create to be set to true.
+	// The labels are restored when they are modified out-of-band, as part of
+	// the Secret data drift detection.
 	Labels map[string]string `json:"labels,omitempty"`
 	// Annotations to apply to the Secret. Requires Create to be set to true.
+	// The annotations are restored when they are modified out-of-band, as part
+	// of the Secret data drift detection.
 	Annotations map[string]string `json:"annotations,omitempty"`
+	// ChecksumAnnotation adds the checksum of the Secret's data to its
+	// annotations, for change detection. The annotation's name includes the
+	// checksum algorithm, e.g. vso.secrets.hashicorp.com/checksum-sha256, which
+	// is set operator-wide with --checksum-algorithm. Requires Create to be set
+	// to true.
+	// +kubebuilder:default=false
+	ChecksumAnnotation bool `json:"checksumAnnotation,omitempty"`
+	// KeyMap renames the fields of the secret data to the keys that
+	// applications expect, e.g. password: POSTGRES_PASSWORD, without having to
+	// template the whole secret. The keys are the secret's field names, and the
+	// values are the K8s Secret data keys. The fields that are not mapped keep
+	// their name. Only the secret's fields are renamed, after the includes and
+	// excludes of the Transformation are applied; the keys of rendered
+	// templates are never renamed.
+	KeyMap map[string]string `json:"keyMap,omitempty"`
+	// Format renders the whole secret data into a single K8s Secret data key,
+	// as a YAML, JSON, or Java properties file, for applications that consume a
+	// mounted config file rather than environment variables. The nested objects
+	// of the secret data are retained. The file replaces the per-field keys, it
+	// is rendered after the includes, excludes, and KeyMap are applied, whereas
+	// the keys of the rendered templates are kept alongside it.
+	Format *DestinationFormat `json:"format,omitempty"`
+	// MetadataConfigMap writes the non-sensitive rendering metadata of the
+	// Secret into a companion ConfigMap, so that its provenance can be inspected
+	// without permission to get the Secret. Ignored when Immutable or Secretless
+	// are set.
+	MetadataConfigMap *MetadataConfigMap `json:"metadataConfigMap,omitempty"`
 	// Type of Kubernetes Secret. Requires Create to be set to true.
-	// Defaults to Opaque.
+	// Defaults to Opaque, or to kubernetes.io/dockerconfigjson when
+	// Transformation.DockerConfigJSON is set, or to kubernetes.io/basic-auth
+	// when Transformation.BasicAuth is set. Any type is supported, including
+	// custom types, e.g. argoproj.io/cluster. The keys that are required by the
+	// built-in types, e.g. tls.crt and tls.key for kubernetes.io/tls, are
+	// validated before the Secret is written.
 	Type v1.SecretType `json:"type,omitempty"`
 	// Transformation provides configuration for transforming the secret data before
 	// it is stored in the Destination.
 	Transformation Transformation `json:"transformation,omitempty"`
+	// CascadeDelete the Secrets that were synced outside the resource's namespace
+	// when the resource is deleted. Kubernetes garbage collection does not apply
+	// to those Secrets, since owner references cannot cross namespaces, so the
+	// Operator deletes them instead. Secrets in the resource's namespace are
+	// always garbage collected by Kubernetes.
+	// +kubebuilder:default=true
+	CascadeDelete bool `json:"cascadeDelete,omitempty"`
+	// DeletionPolicy of the Secrets that were created by the Operator, when the
+	// resource is deleted. Delete lets them be deleted with the resource, as per
+	// CascadeDelete for the Secrets outside the resource's namespace. Retain
+	// keeps the Secret in the resource's namespace, only its owner references
+	// are removed, so that it can be adopted by another resource with
+	// AdoptIfOwnerGone, the Secrets outside of it are handled as with Delete.
+	// Orphan keeps all the Secrets, and removes all the
+	// Operator's ownership metadata from them, so that they are no longer
+	// managed by the Operator.
+	// +kubebuilder:validation:Enum={Delete,Retain,Orphan}
+	// +kubebuilder:default=Delete
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
+	// AdoptIfOwnerGone allows the adoption of a pre-existing destination Secret
+	// that was created by the Operator for another resource, provided that this
+	// resource no longer exists. Requires Create to be set to true. Without it,
+	// such a Secret results in a DestinationConflict.
+	// +kubebuilder:default=false
+	AdoptIfOwnerGone bool `json:"adoptIfOwnerGone,omitempty"`
+	// Namespaces that the destination Secret is also synced to, in addition to
+	// the resource's namespace, e.g. to share a registry credential. Requires
+	// Create to be set to true. Each namespace must opt in to receive the
+	// Secret, by listing the resource's namespace, or "*", in its
+	// vso.secrets.hashicorp.com/allowedSourceNamespaces annotation, otherwise
+	// nothing is synced and the FanOutDenied condition is set. The Secrets that no longer need to be synced to
+	// a namespace are deleted, they are all deleted with the resource when
+	// CascadeDelete is true.
+	// +listType=set
+	Namespaces []string `json:"namespaces,omitempty"`
+	// NamespaceSelector selects the namespaces that the destination Secret is
+	// also synced to, in addition to Namespaces. The selected namespaces are
+	// re-evaluated on every sync, and they must opt in like those in
+	// Namespaces. Requires Create to be set to true.
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// Immutable creates the destination Secret with immutable set to true, for
+	// clusters that mandate immutable Secrets. Since the Secret's data cannot
+	// change, every rotation creates a new Secret that is named after Name,
+	// suffixed with a hash of its data, and the resource's
+	// vso.secrets.hashicorp.com/immutableSecretName annotation is updated to
+	// point to it. The previous Secret is retained until the next rotation,
+	// for the Pods that still reference it. Requires Create to be set to true,
+	// and cannot be combined with Namespaces, NamespaceSelector, or Secretless.
+	// +kubebuilder:default=false
+	Immutable bool `json:"immutable,omitempty"`
+	// Secretless delivers the rendered data to Pods running the secretless agent,
+	// rather than storing it in a Kubernetes Secret. This mode is experimental and
+	// requires the Operator to be started with --secretless-bind-address. When
+	// set, Create, Overwrite, Labels, Annotations, and Type are ignored, and any
+	// Secret previously synced for the resource is deleted.
+	// +kubebuilder:validation:Optional
+	Secretless *SecretlessDelivery `json:"secretless,omitempty"`
+}
+
+// SecretlessDelivery configures the experimental delivery of secret data
+// directly to Pods, bypassing Kubernetes Secrets entirely. The data is held in
+// the Operator's memory and served to the secretless agent over TLS.
+type SecretlessDelivery struct {
+	// ServiceAccounts that are allowed to fetch the data. The ServiceAccounts
+	// must be in the same namespace as the resource.
+	// +kubebuilder:validation:MinItems=1
+	ServiceAccounts []string `json:"serviceAccounts"`
+	// OneShot allows each Pod to fetch the data only once, e.g. the Pods of
+	// short-lived Jobs, which then hold the data in memory only. The Pods must
+	// authenticate with a ServiceAccount token that is bound to them, i.e. a
+	// projected token, so that they can be told apart.
+	OneShot bool `json:"oneShot,omitempty"`
 }
 
 // RolloutRestartTarget provides the configuration required to perform a
@@ -40,12 +159,30 @@ type Destination struct {
 // E.g. vso.secrets.hashicorp.com/restartedAt: "2023-03-23T13:39:31Z"
 //
 // Supported resources: Deployment, DaemonSet, StatefulSet, argo.Rollout
+//
+// Targets can be restarted in waves, ordered by their Wave number. The targets
+// of a wave are restarted together, and the next wave is only started once all
+// of them have completed their rollout. If a wave fails, or does not complete
+// within its timeout, then all the following waves are aborted. The outcome is
+// reported in the RolloutRestartComplete status condition.
 type RolloutRestartTarget struct {
 	// Kind of the resource
 	// +kubebuilder:validation:Enum={Deployment,DaemonSet,StatefulSet,argo.Rollout}
 	Kind string `json:"kind"`
 	// Name of the resource
 	Name string `json:"name"`
+	// Wave that the target belongs to. Waves are restarted in ascending order.
+	// Defaults to 0, so all targets are restarted at once unless waves are
+	// configured.
+	// +kubebuilder:validation:Minimum=0
+	Wave int32 `json:"wave,omitempty"`
+	// Timeout for the target's rollout to complete, before the next wave is
+	// started, in duration notation e.g. 30s, 1m, 24h. A wave's timeout is the
+	// longest timeout of its targets. Ignored for the last wave, since there is
+	// nothing to wait for. Defaults to 5m.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(s|m|h))$`
+	Timeout string `json:"timeout,omitempty"`
 }
 
 type Transformation struct {
@@ -72,6 +209,137 @@ type Transformation struct {
 	// command line flag. If set, the command line flag always takes precedence over
 	// this configuration.
 	ExcludeRaw bool `json:"excludeRaw,omitempty"`
+	// YAMLSplits split source secret data fields that contain multi-document YAML
+	// into a separate K8s Secret data key per document. The resulting keys are
+	// never filtered by Includes or Excludes, whereas the source field is, e.g. it
+	// can be omitted from the final K8s Secret data by excluding it.
+	// +listType=map
+	// +listMapKey=field
+	YAMLSplits []YAMLSplit `json:"yamlSplits,omitempty"`
+	// DockerConfigJSON renders registry credentials from the source secret data
+	// into the ".dockerconfigjson" K8s Secret data key, such that the destination
+	// Secret can be used as an imagePullSecret. The destination Secret's Type
+	// defaults to kubernetes.io/dockerconfigjson when it is set.
+	DockerConfigJSON *DockerConfigJSON `json:"dockerConfigJSON,omitempty"`
+	// BasicAuth maps the source secret data fields that hold the credentials to
+	// the "username" and "password" K8s Secret data keys, such that the
+	// destination Secret can be consumed as a basic-auth Secret, e.g. by Flux or
+	// Argo CD repositories. The destination Secret's Type defaults to
+	// kubernetes.io/basic-auth when it is set.
+	BasicAuth *BasicAuth `json:"basicAuth,omitempty"`
+	// Flatten expands the nested objects of the source secret data into
+	// top-level K8s Secret data keys, e.g. {"db": {"host": "x"}} into
+	// "db_host", instead of a single JSON encoded "db" key. The Includes and
+	// Excludes filters are applied to the flattened keys.
+	Flatten *Flatten `json:"flatten,omitempty"`
+	// Base64Decode lists the source secret data fields that hold base64 encoded
+	// binary data, e.g. keystores or images. They are decoded before being
+	// written to the K8s Secret, instead of being encoded twice. The fields are
+	// matched after Flatten, and before the KeyMap is applied.
+	// +listType=set
+	Base64Decode []string `json:"base64Decode,omitempty"`
+	// DotEnv renders all the synced key/values into a single dotenv formatted
+	// K8s Secret data key, e.g. ".env", such that the destination Secret can be
+	// mounted as a dotenv file. It is rendered last, from the final K8s Secret
+	// data, excluding _raw. The values are double-quoted, and escaped so that
+	// they are never interpolated.
+	DotEnv *DotEnv `json:"dotEnv,omitempty"`
+}
+
+// DotEnv configures the rendering of the K8s Secret data into a dotenv file.
+type DotEnv struct {
+	// Key is the K8s Secret data key of the dotenv file.
+	// +kubebuilder:default=.env
+	Key string `json:"key,omitempty"`
+	// Only retains the dotenv file, all the other K8s Secret data keys are
+	// omitted from the destination Secret, other than _raw.
+	Only bool `json:"only,omitempty"`
+}
+
+// MetadataConfigMap configures the companion ConfigMap of a destination Secret.
+// The ConfigMap holds the following keys: owner, the kind/name of the resource,
+// source, the Vault path the data is read from, version, the version of the
+// secret when it is known, rotatedAt, the RFC3339 time of the last change to
+// the Secret's data, and templateHash, the hash of the destination's
+// transformation configuration. It is deleted along with the resource.
+type MetadataConfigMap struct {
+	// Name of the ConfigMap. Defaults to the name of the Secret, suffixed with
+	// -metadata.
+	Name string `json:"name,omitempty"`
+}
+
+// DestinationFormat configures the rendering of the secret data into a single
+// file.
+type DestinationFormat struct {
+	// Type of the file. The nested keys of the properties files are joined with
+	// ".", and the array elements are suffixed with their index, e.g. "[0]".
+	// +kubebuilder:validation:Enum={yaml,json,properties}
+	Type string `json:"type"`
+	// Key is the K8s Secret data key of the file. Defaults to config.<type>,
+	// e.g. config.yaml.
+	Key string `json:"key,omitempty"`
+}
+
+// BasicAuth configures the mapping of the credentials to the keys of a
+// kubernetes.io/basic-auth Secret.
+type BasicAuth struct {
+	// UsernameKey is the source secret data field that holds the username.
+	// +kubebuilder:default=username
+	UsernameKey string `json:"usernameKey,omitempty"`
+	// PasswordKey is the source secret data field that holds the password, or
+	// the access token.
+	// +kubebuilder:default=password
+	PasswordKey string `json:"passwordKey,omitempty"`
+}
+
+// DockerConfigJSON configures the rendering of registry credentials into a
+// .dockerconfigjson payload. Exactly one of Server or ServerKey must be set.
+type DockerConfigJSON struct {
+	// Server is the registry server, e.g. "ghcr.io" or "https://index.docker.io/v1/".
+	Server string `json:"server,omitempty"`
+	// ServerKey is the source secret data field that holds the registry server.
+	ServerKey string `json:"serverKey,omitempty"`
+	// UsernameKey is the source secret data field that holds the username.
+	// +kubebuilder:default=username
+	UsernameKey string `json:"usernameKey,omitempty"`
+	// PasswordKey is the source secret data field that holds the password, or
+	// the access token.
+	// +kubebuilder:default=password
+	PasswordKey string `json:"passwordKey,omitempty"`
+	// EmailKey is the source secret data field that holds the email, it is
+	// omitted from the payload when empty.
+	EmailKey string `json:"emailKey,omitempty"`
+}
+
+// YAMLSplit splits a source secret data field that contains multi-document
+// YAML, like several kubeconfigs or K8s manifests, into a K8s Secret data key
+// per document.
+type YAMLSplit struct {
+	// Field of the source secret data that contains the multi-document YAML.
+	// +kubebuilder:validation:MinLength=1
+	Field string `json:"field"`
+	// KeyPath is a YAMLPath expression that is evaluated against each document, it
+	// must select a scalar value, which becomes the document's K8s Secret data key.
+	// e.g. "$.metadata.name", or "$.contexts[0].name". Every document must resolve
+	// to a unique key. Empty documents are ignored.
+	// +kubebuilder:validation:Pattern=`^\$?(\.[^.\[\]]+|\[[0-9]+\]|\['[^']+'\])+$`
+	KeyPath string `json:"keyPath"`
+	// KeyPrefix is prepended to every key selected by KeyPath.
+	KeyPrefix string `json:"keyPrefix,omitempty"`
+	// KeySuffix is appended to every key selected by KeyPath, e.g. ".yaml".
+	KeySuffix string `json:"keySuffix,omitempty"`
+}
+
+// Flatten configures the expansion of nested objects into top-level keys.
+type Flatten struct {
+	// Separator joins the keys of the nested objects, e.g. "db_host", or
+	// "db.host".
+	// +kubebuilder:validation:Enum={_,.}
+	// +kubebuilder:default=_
+	Separator string `json:"separator,omitempty"`
+	// UpperCase converts all the keys to upper case, e.g. "DB_HOST", as is
+	// customary for environment variables.
+	UpperCase bool `json:"upperCase,omitempty"`
 }
 
 // TransformationRef contains the configuration for accessing templates from an
@@ -124,3 +392,106 @@ type VaultClientMeta struct {
 	// any sensitive information.
 	ID string `json:"id,omitempty"`
 }
+
+// SyncResult is the outcome of a single secret sync attempt.
+// +kubebuilder:validation:Enum={Success,Failure}
+type SyncResult string
+
+const (
+	SyncResultSuccess SyncResult = "Success"
+	SyncResultFailure SyncResult = "Failure"
+)
+
+// ConditionTypeDestinationConflict is the type of the condition that is set on
+// syncable secret resources when their destination Secret exists, but was not
+// created by the resource.
+const ConditionTypeDestinationConflict = "DestinationConflict"
+
+// ConditionTypeFanOutDenied is the type of the condition that is set on
+// syncable secret resources when some of the namespaces that their destination
+// Secret should be synced to have not opted in to receive it.
+const ConditionTypeFanOutDenied = "FanOutDenied"
+
+// ConditionTypeRolloutRestartComplete is the type of the condition that
+// reports the outcome of the last rollout-restart of the RolloutRestartTargets,
+// when they are restarted in multiple waves.
+const ConditionTypeRolloutRestartComplete = "RolloutRestartComplete"
+
+// ConditionTypeCredentialsReady is the type of the condition that reports
+// whether the last issued credentials are ready to be used, when they are
+// subject to a propagation delay, e.g. MongoDB Atlas programmatic API keys and
+// their IP access list.
+const ConditionTypeCredentialsReady = "CredentialsReady"
+
+// ConditionTypePolicyDrift is the type of the condition that reports whether
+// the policies of the Vault tokens issued for a VaultAuth have drifted from the
+// expected policies.
+const ConditionTypePolicyDrift = "PolicyDrift"
+
+// ConditionTypeDegraded is the type of the condition that reports whether a
+// VaultAuth cannot provide a usable Vault token, e.g. when its static token has
+// expired.
+const ConditionTypeDegraded = "Degraded"
+
+// ConditionTypeServingStaleData is the type of the condition that reports
+// whether the last synced data is served, while Vault is unavailable.
+const ConditionTypeServingStaleData = "ServingStaleData"
+
+// ConditionTypeLeaseTooShort is the type of the condition that is set on
+// dynamic secret resources when the lease duration of their secret is too
+// short to be honored, e.g. it is less than the time needed to renew it.
+const ConditionTypeLeaseTooShort = "LeaseTooShort"
+
+// ConditionTypeIssuing is the type of the condition that reports whether the
+// secret is being issued by a slow secret engine, asynchronously from the
+// reconciliation of the resource.
+const ConditionTypeIssuing = "Issuing"
+
+// SyncMessage records the outcome of a single secret sync attempt. A bounded
+// history of these is kept in the resource's status so that recent sync
+// activity can be inspected without access to the operator's logs.
+type SyncMessage struct {
+	// Time of the sync attempt.
+	Time metav1.Time `json:"time"`
+	// Result of the sync attempt.
+	Result SyncResult `json:"result"`
+	// Reason for the result, this is the same reason that is set on the
+	// corresponding Kubernetes event.
+	Reason string `json:"reason"`
+	// Message providing additional details about the sync attempt.
+	Message string `json:"message,omitempty"`
+}
+
+// SyncProgress tracks the steps of a sync that writes several objects, e.g. the
+// destination Secret, then the rollout-restart of each target, such that a
+// partially applied sync is resumed from its failed step on retry, instead of
+// redoing the steps that already completed.
+type SyncProgress struct {
+	// SecretMAC of the data being synced. The progress is discarded whenever the
+	// data changes, since all the steps must then be redone.
+	SecretMAC string `json:"secretMAC"`
+	// PendingSteps of the sync, in order, e.g. "Secret", or
+	// "RolloutRestart/Deployment/app".
+	PendingSteps []string `json:"pendingSteps,omitempty"`
+}
+
+// VaultLeasedSecretStatus defines the observed state that is common to all
+// resources that sync leased credentials from a Vault secrets engine.
+type VaultLeasedSecretStatus struct {
+	// LastGeneration is the Generation of the last reconciled resource.
+	LastGeneration int64 `json:"lastGeneration"`
+	// LastRenewalTime of the last successful secret lease renewal.
+	LastRenewalTime int64 `json:"lastRenewalTime"`
+	// SecretLease for the Vault secret.
+	SecretLease VaultSecretLease `json:"secretLease"`
+	// VaultClientMeta contains the status of the Vault client and is used during
+	// resource reconciliation.
+	VaultClientMeta VaultClientMeta `json:"vaultClientMeta,omitempty"`
+	// LastSyncMessages contains the most recent sync attempts, ordered from the
+	// oldest to the newest. Only a bounded number of entries are retained.
+	LastSyncMessages []SyncMessage `json:"lastSyncMessages,omitempty"`
+	// Conditions hold the latest observations of the resource's state. The
+	// DestinationConflict condition is set when the destination Secret exists,
+	// but is not owned by the resource.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
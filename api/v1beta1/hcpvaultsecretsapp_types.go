@@ -81,6 +81,13 @@ type HCPVaultSecretsAppStatus struct {
 	// DynamicSecrets lists the last observed state of any dynamic secrets
 	// within the HCP Vault Secrets App
 	DynamicSecrets []HVSDynamicStatus `json:"dynamicSecrets,omitempty"`
+	// LastSyncMessages contains the most recent sync attempts, ordered from the
+	// oldest to the newest. Only a bounded number of entries are retained.
+	LastSyncMessages []SyncMessage `json:"lastSyncMessages,omitempty"`
+	// Conditions hold the latest observations of the resource's state. The
+	// DestinationConflict condition is set when the destination Secret exists,
+	// but is not owned by the resource.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -0,0 +1,97 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// SecretSyncLedgerSpec defines the desired state of SecretSyncLedger
+// +kubebuilder:validation:XValidation:rule="self.owner == oldSelf.owner",message="owner is immutable"
+// +kubebuilder:validation:XValidation:rule="(has(self.trimmedEntries) ? self.trimmedEntries : 0) >= (has(oldSelf.trimmedEntries) ? oldSelf.trimmedEntries : 0)",message="trimmedEntries cannot decrease"
+// +kubebuilder:validation:XValidation:rule="!has(oldSelf.entries) || oldSelf.entries.all(e, e.sequence <= (has(self.trimmedEntries) ? self.trimmedEntries : 0) || (has(self.entries) && e.sequence - (has(self.trimmedEntries) ? self.trimmedEntries : 0) <= size(self.entries) && self.entries[e.sequence - (has(self.trimmedEntries) ? self.trimmedEntries : 0) - 1] == e))",message="existing entries are immutable, they can only be trimmed"
+type SecretSyncLedgerSpec struct {
+	// Owner is the syncable secret resource whose syncs are recorded in the
+	// ledger.
+	Owner SyncLedgerOwner `json:"owner"`
+	// Entries of the ledger, ordered from the oldest to the newest. Each entry
+	// includes the hash of its predecessor, so that any modification of the
+	// ledger can be detected. Existing entries cannot be modified, new entries
+	// can only be appended, and the oldest ones trimmed.
+	// +kubebuilder:validation:MaxItems=1000
+	Entries []SyncLedgerEntry `json:"entries,omitempty"`
+	// TrimmedEntries is the number of the oldest entries that have been removed
+	// from the ledger in order to keep it within its maximum size. The first
+	// remaining entry's PreviousHash is the hash of the last trimmed entry.
+	TrimmedEntries int64 `json:"trimmedEntries,omitempty"`
+}
+
+// SyncLedgerOwner identifies the syncable secret resource that owns a
+// SecretSyncLedger. The ledger is deliberately not garbage collected along with
+// its owner, the ledger must outlive the resource. It is deleted by the
+// Operator once its owner no longer exists, and its last entry is older than the
+// retention period, see --sync-ledger-retention.
+type SyncLedgerOwner struct {
+	// APIVersion of the owner.
+	APIVersion string `json:"apiVersion"`
+	// Kind of the owner.
+	Kind string `json:"kind"`
+	// Name of the owner.
+	Name string `json:"name"`
+	// UID of the owner.
+	UID types.UID `json:"uid"`
+}
+
+// SyncLedgerEntry records a single successful sync of the owner's secret data.
+type SyncLedgerEntry struct {
+	// Sequence number of the entry, starting at 1.
+	Sequence int64 `json:"sequence"`
+	// Time of the sync.
+	Time metav1.Time `json:"time"`
+	// Generation of the owner that was synced.
+	Generation int64 `json:"generation"`
+	// Destination is the name of the Secret that the data was synced to.
+	// +kubebuilder:validation:MaxLength=253
+	Destination string `json:"destination"`
+	// DataMAC is the base64 encoded HMAC of the synced data. It identifies the
+	// version of the secret data that was delivered, without revealing it.
+	// +kubebuilder:validation:MaxLength=128
+	DataMAC string `json:"dataMAC"`
+	// PreviousHash is the Hash of the preceding entry, empty for the very first
+	// entry.
+	// +kubebuilder:validation:MaxLength=128
+	PreviousHash string `json:"previousHash,omitempty"`
+	// Hash is the hex encoded HMAC of all the other fields of the entry, keyed
+	// with the Operator's HMAC key, so that it cannot be recomputed by anyone
+	// who can write the ledger.
+	// +kubebuilder:validation:MaxLength=128
+	Hash string `json:"hash"`
+}
+
+// +kubebuilder:object:root=true
+
+// SecretSyncLedger is the Schema for the secretsyncledgers API. It is an
+// append-only, tamper-evident, record of the syncs of a single syncable secret
+// resource. Ledgers are only written by the Operator, when the sync ledger is
+// enabled.
+type SecretSyncLedger struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec SecretSyncLedgerSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SecretSyncLedgerList contains a list of SecretSyncLedger
+type SecretSyncLedgerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SecretSyncLedger `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SecretSyncLedger{}, &SecretSyncLedgerList{})
+}
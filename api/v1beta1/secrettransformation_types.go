@@ -4,6 +4,7 @@
 package v1beta1
 
 import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -44,6 +45,41 @@ type SecretTransformationSpec struct {
 	// applied before any inclusion patterns. To exclude all source secret data
 	// fields, you can configure the single pattern ".*".
 	Excludes []string `json:"excludes,omitempty"`
+	// Tests verify the K8s Secret data that is rendered from fixture source
+	// secret data, so that regressions of the Templates are caught before they
+	// are synced. They are run whenever the SecretTransformation is updated, a
+	// failing test marks it as invalid. Nothing is ever written by a test.
+	// +listType=map
+	// +listMapKey=name
+	Tests []SecretTransformationTest `json:"tests,omitempty"`
+}
+
+// SecretTransformationTest renders the SecretTransformation with its fixture
+// input, and compares the result to the expected K8s Secret data.
+type SecretTransformationTest struct {
+	// Name of the test.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Secrets is the fixture source secret data, available as .Secrets in the
+	// templates.
+	Secrets map[string]apiextensionsv1.JSON `json:"secrets,omitempty"`
+	// Metadata is the fixture source secret metadata, available as .Metadata
+	// in the templates.
+	Metadata map[string]apiextensionsv1.JSON `json:"metadata,omitempty"`
+	// Annotations available as .Annotations in the templates.
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// Labels available as .Labels in the templates.
+	Labels map[string]string `json:"labels,omitempty"`
+	// NamespaceLabels available as .Namespace.Labels in the templates.
+	NamespaceLabels map[string]string `json:"namespaceLabels,omitempty"`
+	// NamespaceAnnotations available as .Namespace.Annotations in the
+	// templates.
+	NamespaceAnnotations map[string]string `json:"namespaceAnnotations,omitempty"`
+	// Operator metadata available as .Operator in the templates.
+	Operator map[string]string `json:"operator,omitempty"`
+	// Expected maps the K8s Secret data keys to their expected value. The keys
+	// that are not listed are not checked.
+	Expected map[string]string `json:"expected"`
 }
 
 // SourceTemplate provides source templating configuration.
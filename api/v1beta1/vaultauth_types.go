@@ -19,7 +19,9 @@ type VaultAuthConfigKubernetes struct {
 	ServiceAccount string `json:"serviceAccount,omitempty"`
 	// TokenAudiences to include in the ServiceAccount token.
 	TokenAudiences []string `json:"audiences,omitempty"`
-	// TokenExpirationSeconds to set the ServiceAccount token.
+	// TokenExpirationSeconds to set the ServiceAccount token. The token is
+	// minted with the TokenRequest API, and it is reused for the following
+	// logins, until 80% of its lifetime has elapsed.
 	// +kubebuilder:default=600
 	// +kubebuilder:validation:Minimum=600
 	TokenExpirationSeconds int64 `json:"tokenExpirationSeconds,omitempty"`
@@ -77,7 +79,9 @@ type VaultAuthConfigJWT struct {
 	ServiceAccount string `json:"serviceAccount,omitempty"`
 	// TokenAudiences to include in the ServiceAccount token.
 	TokenAudiences []string `json:"audiences,omitempty"`
-	// TokenExpirationSeconds to set the ServiceAccount token.
+	// TokenExpirationSeconds to set the ServiceAccount token. The token is
+	// minted with the TokenRequest API, and it is reused for the following
+	// logins, until 80% of its lifetime has elapsed.
 	// +kubebuilder:default=600
 	// +kubebuilder:validation:Minimum=600
 	TokenExpirationSeconds int64 `json:"tokenExpirationSeconds,omitempty"`
@@ -129,7 +133,10 @@ type VaultAuthConfigAppRole struct {
 
 	// SecretRef is the name of a Kubernetes secret in the consumer's (VDS/VSS/PKI) namespace which
 	// provides the AppRole Role's SecretID. The secret must have a key named `id` which holds the
-	// AppRole Role's secretID.
+	// AppRole Role's secretID. Alternatively, the secret may have a key named `wrapped_id` which
+	// holds a response-wrapping token of the secretID, e.g. from
+	// `vault write -wrap-ttl=1h -f auth/approle/role/<role>/secret-id`, it is unwrapped on the next
+	// login, and the secretID is then written to the `id` key.
 	SecretRef string `json:"secretRef,omitempty"`
 }
 
@@ -248,7 +255,11 @@ func (a *VaultAuthConfigAWS) Validate() error {
 }
 
 // VaultAuthConfigGCP provides VaultAuth configuration options needed for
-// authenticating to Vault via a GCP AuthMethod, using workload identity
+// authenticating to Vault via a GCP AuthMethod, using workload identity. If
+// WorkloadIdentityServiceAccount is not set, the identity token of the
+// Operator's own GCP service account is fetched from the local metadata
+// server instead, i.e. that of the GCE instance, or that of the Operator's
+// workload identity on GKE.
 type VaultAuthConfigGCP struct {
 	// Vault role to use for authenticating
 	Role string `json:"role,omitempty"`
@@ -256,7 +267,10 @@ type VaultAuthConfigGCP struct {
 	// WorkloadIdentityServiceAccount is the name of a Kubernetes service
 	// account (in the same Kubernetes namespace as the Vault*Secret referencing
 	// this resource) which has been configured for workload identity in GKE.
-	// Should be annotated with "iam.gke.io/gcp-service-account".
+	// Should be annotated with "iam.gke.io/gcp-service-account". If not set,
+	// the identity token of the Operator's GCP service account is fetched from
+	// the local metadata server, this requires a Vault role of type gce, or of
+	// type iam when the Operator runs with workload identity.
 	WorkloadIdentityServiceAccount string `json:"workloadIdentityServiceAccount,omitempty"`
 
 	// GCP Region of the GKE cluster's identity provider. Defaults to the region
@@ -306,8 +320,239 @@ func (a *VaultAuthConfigGCP) Validate() error {
 	if a.Role == "" {
 		errs = errors.Join(fmt.Errorf("empty role"))
 	}
-	if a.WorkloadIdentityServiceAccount == "" {
-		errs = errors.Join(fmt.Errorf("empty workloadIdentityServiceAccount"))
+
+	return errs
+}
+
+// VaultAuthConfigAzure provides VaultAuth configuration options needed for
+// authenticating to Vault via an Azure AuthMethod. The Azure AD access token is
+// obtained with AKS workload identity, if WorkloadIdentityServiceAccount is
+// set. Otherwise, the managed identity of the Operator's node is used, its token
+// is fetched from the Azure Instance Metadata Service (IMDS).
+type VaultAuthConfigAzure struct {
+	// Vault role to use for authenticating
+	Role string `json:"role,omitempty"`
+
+	// WorkloadIdentityServiceAccount is the name of a Kubernetes service
+	// account (in the same Kubernetes namespace as the Vault*Secret referencing
+	// this resource) which has been configured for workload identity in AKS.
+	// Should be annotated with "azure.workload.identity/client-id". If not set,
+	// the token of the managed identity of the Operator's node is fetched from
+	// the Azure Instance Metadata Service.
+	WorkloadIdentityServiceAccount string `json:"workloadIdentityServiceAccount,omitempty"`
+
+	// Resource of the Azure AD access token, it must match the resource
+	// configured on the Vault auth method. Defaults to
+	// "https://management.azure.com/".
+	Resource string `json:"resource,omitempty"`
+
+	// TenantID of the workload identity's Azure AD application. Defaults to the
+	// "azure.workload.identity/tenant-id" annotation of the
+	// WorkloadIdentityServiceAccount.
+	TenantID string `json:"tenantID,omitempty"`
+
+	// ClientID of the workload identity's Azure AD application, or of the
+	// user-assigned managed identity of the node. Defaults to the
+	// "azure.workload.identity/client-id" annotation of the
+	// WorkloadIdentityServiceAccount, or to the node's system-assigned managed
+	// identity.
+	ClientID string `json:"clientID,omitempty"`
+
+	// SubscriptionID of the authenticating resource. Defaults to the
+	// subscription of the Operator's node, returned from the Azure Instance
+	// Metadata Service.
+	SubscriptionID string `json:"subscriptionID,omitempty"`
+
+	// ResourceGroupName of the authenticating resource. Defaults to the
+	// resource group of the Operator's node, returned from the Azure Instance
+	// Metadata Service.
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+
+	// VMName of the authenticating virtual machine. Defaults to the name of the
+	// Operator's node, when its managed identity is used.
+	VMName string `json:"vmName,omitempty"`
+
+	// VMSSName of the authenticating virtual machine scale set. Defaults to the
+	// scale set of the Operator's node, when its managed identity is used.
+	VMSSName string `json:"vmssName,omitempty"`
+}
+
+// Merge merges the other VaultAuthConfigAzure into a copy of the current. If
+// the current value is empty, it will be replaced by the other value. If the
+// merger is successful, the copy is returned.
+func (a *VaultAuthConfigAzure) Merge(other *VaultAuthConfigAzure) (*VaultAuthConfigAzure, error) {
+	c := a.DeepCopy()
+	if c.Role == "" {
+		c.Role = other.Role
+	}
+	if c.WorkloadIdentityServiceAccount == "" {
+		c.WorkloadIdentityServiceAccount = other.WorkloadIdentityServiceAccount
+	}
+	if c.Resource == "" {
+		c.Resource = other.Resource
+	}
+	if c.TenantID == "" {
+		c.TenantID = other.TenantID
+	}
+	if c.ClientID == "" {
+		c.ClientID = other.ClientID
+	}
+	if c.SubscriptionID == "" {
+		c.SubscriptionID = other.SubscriptionID
+	}
+	if c.ResourceGroupName == "" {
+		c.ResourceGroupName = other.ResourceGroupName
+	}
+	if c.VMName == "" {
+		c.VMName = other.VMName
+	}
+	if c.VMSSName == "" {
+		c.VMSSName = other.VMSSName
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Validate checks that the VaultAuthConfigAzure is valid. All validation
+// errors are returned.
+func (a *VaultAuthConfigAzure) Validate() error {
+	var errs error
+	if a.Role == "" {
+		errs = errors.Join(errs, fmt.Errorf("empty role"))
+	}
+	if a.VMName != "" && a.VMSSName != "" {
+		errs = errors.Join(errs, fmt.Errorf("vmName and vmssName are mutually exclusive"))
+	}
+
+	return errs
+}
+
+// VaultAuthConfigCert provides VaultAuth configuration options needed for
+// authenticating to Vault via a TLS Certificates AuthMethod. The client
+// certificate is presented on the login request only.
+type VaultAuthConfigCert struct {
+	// Name of the certificate role to authenticate against. If not set, Vault
+	// tries all the roles whose certificates match the client certificate.
+	Name string `json:"name,omitempty"`
+	// SecretRef is the name of a Kubernetes secret in the consumer's (VDS/VSS/PKI) namespace which
+	// provides the PEM encoded client certificate and its private key. The secret must have the
+	// keys `tls.crt` and `tls.key`, like a secret of type `kubernetes.io/tls`, or the keys
+	// `certificate` and `private_key`, like the destination secret of a VaultPKISecret. Whenever
+	// the certificate in the secret is rotated, the Vault client logs in again with the new one.
+	SecretRef string `json:"secretRef,omitempty"`
+}
+
+// Merge merges the other VaultAuthConfigCert into a copy of the current. If
+// the current value is empty, it will be replaced by the other value. If the
+// merger is successful, the copy is returned.
+func (a *VaultAuthConfigCert) Merge(other *VaultAuthConfigCert) (*VaultAuthConfigCert, error) {
+	c := a.DeepCopy()
+	if c.Name == "" {
+		c.Name = other.Name
+	}
+	if c.SecretRef == "" {
+		c.SecretRef = other.SecretRef
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Validate checks that the VaultAuthConfigCert is valid. All validation
+// errors are returned.
+func (a *VaultAuthConfigCert) Validate() error {
+	var errs error
+	if a.SecretRef == "" {
+		errs = errors.Join(errs, fmt.Errorf("empty secretRef"))
+	}
+
+	return errs
+}
+
+// VaultAuthConfigToken provides VaultAuth configuration options needed for
+// authenticating to Vault with a static Vault token, e.g. in air-gapped or
+// bootstrap scenarios, where no other auth method is available. No login is
+// done, the token is renewed for as long as it is renewable, and it is never
+// revoked by the Operator.
+type VaultAuthConfigToken struct {
+	// SecretRef is the name of a Kubernetes secret in the consumer's (VDS/VSS/PKI) namespace which
+	// provides the Vault token. The secret must have a key named `token` which holds the Vault
+	// token. Whenever the token in the secret is replaced, the Vault client switches to the new one.
+	// Alternatively, the secret may have a key named `wrapped_token` which holds a
+	// response-wrapping token of the Vault token, e.g. from `vault token create -wrap-ttl=1h`, it
+	// is unwrapped exactly once, on the next login, and the Vault token is then written to the
+	// `token` key.
+	SecretRef string `json:"secretRef,omitempty"`
+}
+
+// Merge merges the other VaultAuthConfigToken into a copy of the current. If
+// the current value is empty, it will be replaced by the other value. If the
+// merger is successful, the copy is returned.
+func (a *VaultAuthConfigToken) Merge(other *VaultAuthConfigToken) (*VaultAuthConfigToken, error) {
+	c := a.DeepCopy()
+	if c.SecretRef == "" {
+		c.SecretRef = other.SecretRef
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Validate checks that the VaultAuthConfigToken is valid. All validation
+// errors are returned.
+func (a *VaultAuthConfigToken) Validate() error {
+	var errs error
+	if a.SecretRef == "" {
+		errs = errors.Join(errs, fmt.Errorf("empty secretRef"))
+	}
+
+	return errs
+}
+
+// VaultAuthConfigUserPass provides VaultAuth configuration options needed for
+// authenticating to Vault with a username and password, via the LDAP, Userpass,
+// or Okta AuthMethods.
+type VaultAuthConfigUserPass struct {
+	// Username to authenticate as. If not set, the username is taken from the
+	// `username` key of the secret.
+	Username string `json:"username,omitempty"`
+	// SecretRef is the name of a Kubernetes secret in the consumer's (VDS/VSS/PKI) namespace which
+	// provides the password. The secret must have a key named `password` which holds the password,
+	// and a key named `username` which holds the username, unless Username is set.
+	SecretRef string `json:"secretRef,omitempty"`
+}
+
+// Merge merges the other VaultAuthConfigUserPass into a copy of the current.
+// If the current value is empty, it will be replaced by the other value. If
+// the merger is successful, the copy is returned.
+func (a *VaultAuthConfigUserPass) Merge(other *VaultAuthConfigUserPass) (*VaultAuthConfigUserPass, error) {
+	c := a.DeepCopy()
+	if c.Username == "" {
+		c.Username = other.Username
+	}
+	if c.SecretRef == "" {
+		c.SecretRef = other.SecretRef
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Validate checks that the VaultAuthConfigUserPass is valid. All validation
+// errors are returned.
+func (a *VaultAuthConfigUserPass) Validate() error {
+	var errs error
+	if a.SecretRef == "" {
+		errs = errors.Join(errs, fmt.Errorf("empty secretRef"))
 	}
 
 	return errs
@@ -386,7 +631,10 @@ type VaultAuthSpec struct {
 	VaultConnectionRef string `json:"vaultConnectionRef,omitempty"`
 	// VaultAuthGlobalRef.
 	VaultAuthGlobalRef *VaultAuthGlobalRef `json:"vaultAuthGlobalRef,omitempty"`
-	// Namespace to auth to in Vault
+	// Namespace to auth to in Vault. This only applies to the login request,
+	// the secret resources referring to this VaultAuth may set their own
+	// namespace, in which case their requests are sent to that namespace with the
+	// token obtained from this one.
 	Namespace string `json:"namespace,omitempty"`
 	// AllowedNamespaces Kubernetes Namespaces which are allow-listed for use with this AuthMethod.
 	// This field allows administrators to customize which Kubernetes namespaces are authorized to
@@ -398,13 +646,29 @@ type VaultAuthSpec struct {
 	// unset - disallow all namespaces except the Operator's the VaultAuthMethod's namespace, this
 	// is the default behavior.
 	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+	// AllowedNamespacesSelector selects the Kubernetes Namespaces, by their
+	// labels, which are allow-listed for use with this AuthMethod, in addition to
+	// those in AllowedNamespaces. An empty selector selects all namespaces.
+	AllowedNamespacesSelector *metav1.LabelSelector `json:"allowedNamespacesSelector,omitempty"`
 	// Method to use when authenticating to Vault.
-	// +kubebuilder:validation:Enum=kubernetes;jwt;appRole;aws;gcp
+	// +kubebuilder:validation:Enum=kubernetes;jwt;appRole;aws;gcp;azure;cert;token;ldap;userpass;okta
 	Method string `json:"method,omitempty"`
-	// Mount to use when authenticating to auth method.
+	// Mount to use when authenticating to auth method, it is not used by the
+	// token method.
 	Mount string `json:"mount,omitempty"`
-	// Params to use when authenticating to Vault
+	// Params to use when authenticating to Vault, they are included in the
+	// login request along with the auth method's own parameters, which they may
+	// not override. This allows for using auth plugins that require extra
+	// parameters. Each value is a Go template, with access to the following
+	// fields: .Namespace, the namespace of the authenticating ServiceAccount,
+	// .ServiceAccount, the ServiceAccount of the auth method, .Method, .Mount,
+	// and the .Labels and .Annotations of the VaultAuth. Invalid templates are
+	// rejected upon admission when the Operator's VaultAuth params webhook is
+	// enabled, otherwise the resource is reported as invalid in its status.
 	Params map[string]string `json:"params,omitempty"`
+	// LoginParams are the well-known parameters of the login request, they are
+	// included along with Params, which may not set them as well.
+	LoginParams *VaultAuthLoginParams `json:"loginParams,omitempty"`
 	// Headers to be included in all Vault requests.
 	Headers map[string]string `json:"headers,omitempty"`
 	// Kubernetes specific auth configuration, requires that the Method be set to `kubernetes`.
@@ -417,6 +681,18 @@ type VaultAuthSpec struct {
 	AWS *VaultAuthConfigAWS `json:"aws,omitempty"`
 	// GCP specific auth configuration, requires that Method be set to `gcp`.
 	GCP *VaultAuthConfigGCP `json:"gcp,omitempty"`
+	// Azure specific auth configuration, requires that Method be set to `azure`.
+	Azure *VaultAuthConfigAzure `json:"azure,omitempty"`
+	// Cert specific auth configuration, requires that Method be set to `cert`.
+	Cert *VaultAuthConfigCert `json:"cert,omitempty"`
+	// Token specific auth configuration, requires that Method be set to `token`.
+	Token *VaultAuthConfigToken `json:"token,omitempty"`
+	// LDAP specific auth configuration, requires that Method be set to `ldap`.
+	LDAP *VaultAuthConfigUserPass `json:"ldap,omitempty"`
+	// UserPass specific auth configuration, requires that Method be set to `userpass`.
+	UserPass *VaultAuthConfigUserPass `json:"userpass,omitempty"`
+	// Okta specific auth configuration, requires that Method be set to `okta`.
+	Okta *VaultAuthConfigUserPass `json:"okta,omitempty"`
 	// StorageEncryption provides the necessary configuration to encrypt the client storage cache.
 	// This should only be configured when client cache persistence with encryption is enabled.
 	// This is done by passing setting the manager's commandline argument
@@ -424,6 +700,75 @@ type VaultAuthSpec struct {
 	// be one VaultAuth configured with StorageEncryption in the Cluster, and it should have
 	// the label: cacheStorageEncryption=true
 	StorageEncryption *StorageEncryption `json:"storageEncryption,omitempty"`
+	// PolicyDriftCheck periodically compares the policies of the cached Vault
+	// tokens that were issued for this VaultAuth against the expected policies.
+	// Any drift is reported by the PolicyDrift condition, before it surfaces as
+	// permission denied errors on the resources that use this VaultAuth.
+	PolicyDriftCheck *VaultAuthPolicyDriftCheck `json:"policyDriftCheck,omitempty"`
+	// MaxConcurrentLogins limits the number of simultaneous logins to Vault with
+	// this VaultAuth, e.g. to avoid tripping Vault's rate limits, or the token
+	// review throttling of the auth method's backend, when the Operator restarts.
+	// Logins that exceed the limit wait for a slot to be released. The limit
+	// applies in addition to the manager's --max-concurrent-logins.
+	// No limit is applied when unset.
+	// +kubebuilder:validation:Minimum=1
+	MaxConcurrentLogins int `json:"maxConcurrentLogins,omitempty"`
+	// MFA configures the validation of Vault's Login MFA, for the auth methods
+	// that require it. Without it, the logins that require MFA fail.
+	MFA *VaultAuthMFA `json:"mfa,omitempty"`
+}
+
+// VaultAuthMFA configures the validation of Vault's Login MFA. For each of the
+// MFA constraints of a login, one of its methods is validated: a passcode
+// method, e.g. TOTP, if its passcode is found in the SecretRef, otherwise a
+// push method, e.g. Duo or Okta push, which is validated once the push has been
+// approved.
+type VaultAuthMFA struct {
+	// SecretRef is the name of a Kubernetes Secret, in the consumer's namespace,
+	// that holds the passcodes of the MFA methods that use one. Each passcode is
+	// keyed by the name of its MFA method, or else by its ID. The Secret is read
+	// on each login, so that its passcodes may be kept current.
+	SecretRef string `json:"secretRef,omitempty"`
+	// PushTimeout is the maximum duration to wait for the approval of the push
+	// methods, the validation is retried with backoff until then.
+	// +kubebuilder:default="2m"
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(s|m|h))$`
+	PushTimeout string `json:"pushTimeout,omitempty"`
+}
+
+// VaultAuthPolicyDriftCheck configures the detection of drift between the
+// policies attached to the Vault tokens and the expected policies. A token's
+// policies are set at login, so they drift whenever the auth method's role is
+// updated afterward, e.g. when one of its policies is removed or renamed in
+// Vault.
+type VaultAuthPolicyDriftCheck struct {
+	// ExpectedPolicies that should be attached to the tokens. If not set, the
+	// expected policies are read from the token_policies of the auth method's
+	// role, at auth/<mount>/role/<role>, which requires that the tokens are
+	// allowed to read the role. The default policy is always ignored.
+	ExpectedPolicies []string `json:"expectedPolicies,omitempty"`
+	// Interval between two checks, in duration notation e.g. 30s, 1m, 24h.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(s|m|h))$`
+	// +kubebuilder:default="5m"
+	Interval string `json:"interval,omitempty"`
+}
+
+// VaultAuthLoginParams are the parameters of the login request which allow for
+// requesting least-privilege, short-lived Vault tokens. They are passed through
+// to the auth method, which must support them. Each value is a Go template,
+// with access to the same fields as Params, e.g. for requesting the policies of
+// the consuming namespace only.
+type VaultAuthLoginParams struct {
+	// Audience to request, sent as the login request's audience parameter.
+	Audience []string `json:"audience,omitempty"`
+	// TokenTTL to request, in duration notation e.g. 30s, 1m, 24h. Sent as the
+	// login request's token_ttl parameter.
+	TokenTTL string `json:"tokenTTL,omitempty"`
+	// TokenPolicies to request, they should be a subset of the policies of the
+	// auth method's role. Sent as the login request's token_policies parameter.
+	TokenPolicies []string `json:"tokenPolicies,omitempty"`
 }
 
 // VaultAuthStatus defines the observed state of VaultAuth
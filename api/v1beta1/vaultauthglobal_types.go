@@ -28,7 +28,7 @@ type VaultAuthGlobalSpec struct {
 	// auth methods.
 	DefaultVaultNamespace string `json:"defaultVaultNamespace,omitempty"`
 	// DefaultAuthMethod to use when authenticating to Vault.
-	// +kubebuilder:validation:Enum=kubernetes;jwt;appRole;aws;gcp
+	// +kubebuilder:validation:Enum=kubernetes;jwt;appRole;aws;gcp;azure;cert;token;ldap;userpass;okta
 	DefaultAuthMethod string `json:"defaultAuthMethod,omitempty"`
 	// DefaultMount to use when authenticating to auth method. If not specified the mount of
 	// the auth method configured in Vault will be used.
@@ -47,6 +47,18 @@ type VaultAuthGlobalSpec struct {
 	AWS *VaultAuthGlobalConfigAWS `json:"aws,omitempty"`
 	// GCP specific auth configuration, requires that Method be set to `gcp`.
 	GCP *VaultAuthGlobalConfigGCP `json:"gcp,omitempty"`
+	// Azure specific auth configuration, requires that Method be set to `azure`.
+	Azure *VaultAuthGlobalConfigAzure `json:"azure,omitempty"`
+	// Cert specific auth configuration, requires that Method be set to `cert`.
+	Cert *VaultAuthGlobalConfigCert `json:"cert,omitempty"`
+	// Token specific auth configuration, requires that Method be set to `token`.
+	Token *VaultAuthGlobalConfigToken `json:"token,omitempty"`
+	// LDAP specific auth configuration, requires that Method be set to `ldap`.
+	LDAP *VaultAuthGlobalConfigUserPass `json:"ldap,omitempty"`
+	// UserPass specific auth configuration, requires that Method be set to `userpass`.
+	UserPass *VaultAuthGlobalConfigUserPass `json:"userpass,omitempty"`
+	// Okta specific auth configuration, requires that Method be set to `okta`.
+	Okta *VaultAuthGlobalConfigUserPass `json:"okta,omitempty"`
 }
 
 // VaultAuthGlobalStatus defines the observed state of VaultAuthGlobal
@@ -137,6 +149,50 @@ type VaultAuthGlobalConfigGCP struct {
 	Headers map[string]string `json:"headers,omitempty"`
 }
 
+type VaultAuthGlobalConfigAzure struct {
+	VaultAuthConfigAzure `json:",inline"`
+	// Namespace to auth to in Vault
+	Namespace string `json:"namespace,omitempty"`
+	// Mount to use when authenticating to auth method.
+	Mount string `json:"mount,omitempty"`
+	// Params to use when authenticating to Vault
+	Params map[string]string `json:"params,omitempty"`
+	// Headers to be included in all Vault requests.
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+type VaultAuthGlobalConfigCert struct {
+	VaultAuthConfigCert `json:",inline"`
+	// Namespace to auth to in Vault
+	Namespace string `json:"namespace,omitempty"`
+	// Mount to use when authenticating to auth method.
+	Mount string `json:"mount,omitempty"`
+	// Params to use when authenticating to Vault
+	Params map[string]string `json:"params,omitempty"`
+	// Headers to be included in all Vault requests.
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+type VaultAuthGlobalConfigToken struct {
+	VaultAuthConfigToken `json:",inline"`
+	// Namespace to auth to in Vault
+	Namespace string `json:"namespace,omitempty"`
+	// Headers to be included in all Vault requests.
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+type VaultAuthGlobalConfigUserPass struct {
+	VaultAuthConfigUserPass `json:",inline"`
+	// Namespace to auth to in Vault
+	Namespace string `json:"namespace,omitempty"`
+	// Mount to use when authenticating to auth method.
+	Mount string `json:"mount,omitempty"`
+	// Params to use when authenticating to Vault
+	Params map[string]string `json:"params,omitempty"`
+	// Headers to be included in all Vault requests.
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
 func init() {
 	SchemeBuilder.Register(&VaultAuthGlobal{}, &VaultAuthGlobalList{})
 }
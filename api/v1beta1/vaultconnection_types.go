@@ -17,6 +17,11 @@ type VaultConnectionSpec struct {
 	TLSServerName string `json:"tlsServerName,omitempty"`
 	// CACertSecretRef is the name of a Kubernetes secret containing the trusted PEM encoded CA certificate chain as `ca.crt`.
 	CACertSecretRef string `json:"caCertSecretRef,omitempty"`
+	// CACertConfigMapRef is the name of a Kubernetes ConfigMap containing the
+	// trusted PEM encoded CA certificate chain as `ca.crt`. When CACertSecretRef
+	// is also set, the certificates of both are trusted. Updates to either of
+	// them are picked up by the Vault clients of the connection.
+	CACertConfigMapRef string `json:"caCertConfigMapRef,omitempty"`
 	// SkipTLSVerify for TLS connections.
 	// +kubebuilder:default=false
 	SkipTLSVerify bool `json:"skipTLSVerify"`
@@ -25,12 +30,52 @@ type VaultConnectionSpec struct {
 	// +kubebuilder:validation:Type=string
 	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(s|m|h))$`
 	Timeout string `json:"timeout,omitempty"`
+	// AlternateAddresses of the same Vault cluster, e.g. those of its standby
+	// nodes. They are only used for hedged reads. Each address must only differ
+	// from Address by its scheme, host, and port.
+	AlternateAddresses []string `json:"alternateAddresses,omitempty"`
+	// HedgedReads configures the hedging of read requests. Requires
+	// AlternateAddresses to be set.
+	HedgedReads *HedgedReads `json:"hedgedReads,omitempty"`
+	// AllowedNamespaces Kubernetes Namespaces which are allow-listed for use with
+	// this VaultConnection. It restricts the namespaces of the VaultAuths that
+	// may reference this VaultConnection.
+	// Accepted values:
+	// []{"*"} - wildcard, all namespaces.
+	// []{"a", "b"} - list of namespaces.
+	// unset - allow all namespaces, unless AllowedNamespacesSelector is set, this
+	// is the default behavior.
+	// The VaultConnection's namespace is always allowed.
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+	// AllowedNamespacesSelector selects the Kubernetes Namespaces, by their
+	// labels, which are allow-listed for use with this VaultConnection, in
+	// addition to those in AllowedNamespaces. An empty selector selects all
+	// namespaces.
+	AllowedNamespacesSelector *metav1.LabelSelector `json:"allowedNamespacesSelector,omitempty"`
+}
+
+// HedgedReads configures the hedging of idempotent read requests. When a read
+// request to Vault has not completed after Delay, the same request is sent to
+// the next alternate address. The first successful response is used, and the
+// other requests are canceled. This reduces the tail latency of reads when
+// some of the Vault nodes are degraded.
+type HedgedReads struct {
+	// Delay after which a hedged request is sent to the next alternate address,
+	// in duration notation e.g. 100ms, 1s. A failed request is hedged right
+	// away.
+	// +kubebuilder:default="250ms"
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ms|s|m))$`
+	Delay string `json:"delay,omitempty"`
 }
 
 // VaultConnectionStatus defines the observed state of VaultConnection
 type VaultConnectionStatus struct {
 	// Valid auth mechanism.
 	Valid *bool `json:"valid"`
+	// CACertHash is the hash of the trusted CA certificates of the connection,
+	// from its CACertSecretRef and CACertConfigMapRef.
+	CACertHash string `json:"caCertHash,omitempty"`
 }
 
 // +kubebuilder:object:root=true
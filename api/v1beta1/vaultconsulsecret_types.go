@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VaultConsulSecretSpec defines the desired state of VaultConsulSecret
+type VaultConsulSecretSpec struct {
+	// VaultAuthRef to the VaultAuth resource, can be prefixed with a namespace,
+	// eg: `namespaceA/vaultAuthRefB`. If no namespace prefix is provided it will default to
+	// the namespace of the VaultAuth CR. If no value is specified for VaultAuthRef the Operator
+	// will default to the VaultAuth of the CR's namespace that is named `default`, or that is
+	// labeled `vso.hashicorp.com/default-vault-auth: "true"`, and then to the `default` VaultAuth
+	// configured in the operator's namespace.
+	VaultAuthRef string `json:"vaultAuthRef,omitempty"`
+	// ClusterVaultAuthRef to the cluster-scoped ClusterVaultAuth resource, it is mutually
+	// exclusive with VaultAuthRef. The ClusterVaultAuth must allow the namespace of this CR in
+	// its AllowedNamespaces.
+	ClusterVaultAuthRef string `json:"clusterVaultAuthRef,omitempty"`
+	// Namespace of the secrets engine mount in Vault. If not set, the namespace that's
+	// part of VaultAuth resource will be inferred.
+	Namespace string `json:"namespace,omitempty"`
+	// Mount path of the Consul secrets engine in Vault.
+	// +kubebuilder:default=consul
+	Mount string `json:"mount,omitempty"`
+	// Role in the Consul secrets engine that the ACL token will be generated for.
+	// +kubebuilder:validation:MinLength=1
+	Role string `json:"role"`
+	// RenewalPercent is the percent out of 100 of the lease duration when the
+	// lease is renewed. Defaults to 67 percent plus jitter.
+	// +kubebuilder:default=67
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=90
+	RenewalPercent int `json:"renewalPercent,omitempty"`
+	// Revoke the existing lease on resource deletion. Revoking the lease
+	// also deletes the ACL token from Consul.
+	Revoke bool `json:"revoke,omitempty"`
+	// RolloutRestartTargets should be configured whenever the application(s) consuming the Vault secret does
+	// not support dynamically reloading a rotated secret.
+	// In that case one, or more RolloutRestartTarget(s) can be configured here. The Operator will
+	// trigger a "rollout-restart" for each target whenever the Vault secret changes between reconciliation events.
+	// See RolloutRestartTarget for more details.
+	RolloutRestartTargets []RolloutRestartTarget `json:"rolloutRestartTargets,omitempty"`
+	// Destination provides configuration necessary for syncing the Vault secret to Kubernetes.
+	Destination Destination `json:"destination"`
+}
+
+// VaultConsulSecretStatus defines the observed state of VaultConsulSecret
+type VaultConsulSecretStatus struct {
+	VaultLeasedSecretStatus `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// VaultConsulSecret is the Schema for the vaultconsulsecrets API
+type VaultConsulSecret struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VaultConsulSecretSpec   `json:"spec,omitempty"`
+	Status VaultConsulSecretStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VaultConsulSecretList contains a list of VaultConsulSecret
+type VaultConsulSecretList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VaultConsulSecret `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VaultConsulSecret{}, &VaultConsulSecretList{})
+}
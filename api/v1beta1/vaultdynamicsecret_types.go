@@ -13,8 +13,14 @@ type VaultDynamicSecretSpec struct {
 	// VaultAuthRef to the VaultAuth resource, can be prefixed with a namespace,
 	// eg: `namespaceA/vaultAuthRefB`. If no namespace prefix is provided it will default to
 	// the namespace of the VaultAuth CR. If no value is specified for VaultAuthRef the Operator
-	// will default to the `default` VaultAuth, configured in the operator's namespace.
+	// will default to the VaultAuth of the CR's namespace that is named `default`, or that is
+	// labeled `vso.hashicorp.com/default-vault-auth: "true"`, and then to the `default` VaultAuth
+	// configured in the operator's namespace.
 	VaultAuthRef string `json:"vaultAuthRef,omitempty"`
+	// ClusterVaultAuthRef to the cluster-scoped ClusterVaultAuth resource, it is mutually
+	// exclusive with VaultAuthRef. The ClusterVaultAuth must allow the namespace of this CR in
+	// its AllowedNamespaces.
+	ClusterVaultAuthRef string `json:"clusterVaultAuthRef,omitempty"`
 	// Namespace of the secrets engine mount in Vault. If not set, the namespace that's
 	// part of VaultAuth resource will be inferred.
 	Namespace string `json:"namespace,omitempty"`
@@ -23,7 +29,8 @@ type VaultDynamicSecretSpec struct {
 	// RequestHTTPMethod to use when syncing Secrets from Vault.
 	// Setting a value here is not typically required.
 	// If left unset the Operator will make requests using the GET method.
-	// In the case where Params are specified the Operator will use the PUT method.
+	// In the case where Params or RequestData are specified the Operator will use
+	// the PUT method.
 	// Please consult https://developer.hashicorp.com/vault/docs/secrets if you are
 	// uncertain about what method to use.
 	// Of note, the Vault client treats PUT and POST as being equivalent.
@@ -40,6 +47,14 @@ type VaultDynamicSecretSpec struct {
 	// Please consult https://developer.hashicorp.com/vault/docs/secrets if you are
 	// uncertain about what 'params' should/can be set to.
 	Params map[string]string `json:"params,omitempty"`
+	// RequestData is the request body sent when requesting credentials/secrets, for
+	// endpoints that require a payload, like transit's sign or a role's parameters.
+	// Every value is a template that is rendered prior to each request, with the
+	// resource's .Name, .Namespace, .Annotations, and .Labels as its input, e.g.
+	// "{{ .Namespace }}.example.com". It is merged with Params, a key must not
+	// be in both. When RequestData is set the configured RequestHTTPMethod will be
+	// ignored. See RequestHTTPMethod for more details.
+	RequestData map[string]string `json:"requestData,omitempty"`
 	// RenewalPercent is the percent out of 100 of the lease duration when the
 	// lease is renewed. Defaults to 67 percent plus jitter.
 	// +kubebuilder:default=67
@@ -61,6 +76,13 @@ type VaultDynamicSecretSpec struct {
 	RolloutRestartTargets []RolloutRestartTarget `json:"rolloutRestartTargets,omitempty"`
 	// Destination provides configuration necessary for syncing the Vault secret to Kubernetes.
 	Destination Destination `json:"destination"`
+	// AdditionalDestinations are synced with the same Vault secret data as the
+	// Destination, without reading it again. Each one may have its own name,
+	// type, and transformation. Their names must be distinct from each other,
+	// and from the Destination's.
+	// +listType=map
+	// +listMapKey=name
+	AdditionalDestinations []Destination `json:"additionalDestinations,omitempty"`
 	// RefreshAfter a period of time for VSO to sync the source secret data, in
 	// duration notation e.g. 30s, 1m, 24h. This value only needs to be set when
 	// syncing from a secret's engine that does not provide a lease TTL in its
@@ -97,6 +119,13 @@ type VaultDynamicSecretStatus struct {
 	// VaultClientMeta contains the status of the Vault client and is used during
 	// resource reconciliation.
 	VaultClientMeta VaultClientMeta `json:"vaultClientMeta,omitempty"`
+	// LastSyncMessages contains the most recent sync attempts, ordered from the
+	// oldest to the newest. Only a bounded number of entries are retained.
+	LastSyncMessages []SyncMessage `json:"lastSyncMessages,omitempty"`
+	// Conditions hold the latest observations of the resource's state. The
+	// DestinationConflict condition is set when the destination Secret exists,
+	// but is not owned by the resource.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 type VaultSecretLease struct {
@@ -0,0 +1,108 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package v1beta1
+
+import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VaultGenericSecretSpec defines the desired state of VaultGenericSecret
+type VaultGenericSecretSpec struct {
+	// VaultAuthRef to the VaultAuth resource, can be prefixed with a namespace,
+	// eg: `namespaceA/vaultAuthRefB`. If no namespace prefix is provided it will default to
+	// the namespace of the VaultAuth CR. If no value is specified for VaultAuthRef the Operator
+	// will default to the VaultAuth of the CR's namespace that is named `default`, or that is
+	// labeled `vso.hashicorp.com/default-vault-auth: "true"`, and then to the `default` VaultAuth
+	// configured in the operator's namespace.
+	VaultAuthRef string `json:"vaultAuthRef,omitempty"`
+	// ClusterVaultAuthRef to the cluster-scoped ClusterVaultAuth resource, it is mutually
+	// exclusive with VaultAuthRef. The ClusterVaultAuth must allow the namespace of this CR in
+	// its AllowedNamespaces.
+	ClusterVaultAuthRef string `json:"clusterVaultAuthRef,omitempty"`
+	// Namespace of the secrets engine mount in Vault. If not set, the namespace that's
+	// part of VaultAuth resource will be inferred.
+	Namespace string `json:"namespace,omitempty"`
+	// Path in Vault to read the secret from, including the mount, e.g.
+	// my-plugin/creds/my-role
+	// +kubebuilder:validation:MinLength=1
+	Path string `json:"path"`
+	// Method is the HTTP method of the request sent to Vault. Use PUT, or POST
+	// for endpoints that require Params.
+	// +kubebuilder:validation:Enum={GET,PUT,POST}
+	// +kubebuilder:default=GET
+	Method string `json:"method,omitempty"`
+	// Params are sent along with the request, only used with the PUT and POST
+	// methods.
+	Params map[string]apiextensionsv1.JSON `json:"params,omitempty"`
+	// Fields select the response data that is synced. If not set, all the
+	// top-level fields of the response data are synced.
+	Fields []GenericSecretField `json:"fields,omitempty"`
+	// RenewalPercent is the percent out of 100 of the lease duration when the
+	// lease is renewed. Defaults to 67 percent plus jitter. Only applies to
+	// leased responses.
+	// +kubebuilder:default=67
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=90
+	RenewalPercent int `json:"renewalPercent,omitempty"`
+	// RefreshAfter a period of time, in duration notation e.g. 30s, 1m, 24h. Only
+	// applies to responses that are not leased, if not set they are only synced
+	// again when the resource is updated.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(s|m|h))$`
+	RefreshAfter string `json:"refreshAfter,omitempty"`
+	// Revoke the existing lease on resource deletion.
+	Revoke bool `json:"revoke,omitempty"`
+	// RolloutRestartTargets should be configured whenever the application(s) consuming the Vault secret does
+	// not support dynamically reloading a rotated secret.
+	// In that case one, or more RolloutRestartTarget(s) can be configured here. The Operator will
+	// trigger a "rollout-restart" for each target whenever the Vault secret changes between reconciliation events.
+	// See RolloutRestartTarget for more details.
+	RolloutRestartTargets []RolloutRestartTarget `json:"rolloutRestartTargets,omitempty"`
+	// Destination provides configuration necessary for syncing the Vault secret to Kubernetes.
+	Destination Destination `json:"destination"`
+}
+
+// GenericSecretField selects a single value from the Vault response's data.
+type GenericSecretField struct {
+	// Name of the field that holds the selected value. The selected fields are
+	// subject to the Destination's Transformation, like any other Vault secret
+	// data.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// JSONPath expression that is evaluated against the response's data, e.g.
+	// {.keys[0].value}. Values that are not strings are encoded as JSON.
+	// +kubebuilder:validation:MinLength=1
+	JSONPath string `json:"jsonPath"`
+}
+
+// VaultGenericSecretStatus defines the observed state of VaultGenericSecret
+type VaultGenericSecretStatus struct {
+	VaultLeasedSecretStatus `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// VaultGenericSecret is the Schema for the vaultgenericsecrets API
+type VaultGenericSecret struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VaultGenericSecretSpec   `json:"spec,omitempty"`
+	Status VaultGenericSecretStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VaultGenericSecretList contains a list of VaultGenericSecret
+type VaultGenericSecretList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VaultGenericSecret `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VaultGenericSecret{}, &VaultGenericSecretList{})
+}
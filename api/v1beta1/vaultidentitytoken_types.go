@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VaultIdentityTokenSpec defines the desired state of VaultIdentityToken
+type VaultIdentityTokenSpec struct {
+	// VaultAuthRef to the VaultAuth resource, can be prefixed with a namespace,
+	// eg: `namespaceA/vaultAuthRefB`. If no namespace prefix is provided it will default to
+	// the namespace of the VaultAuth CR. If no value is specified for VaultAuthRef the Operator
+	// will default to the VaultAuth of the CR's namespace that is named `default`, or that is
+	// labeled `vso.hashicorp.com/default-vault-auth: "true"`, and then to the `default` VaultAuth
+	// configured in the operator's namespace.
+	VaultAuthRef string `json:"vaultAuthRef,omitempty"`
+	// ClusterVaultAuthRef to the cluster-scoped ClusterVaultAuth resource, it is mutually
+	// exclusive with VaultAuthRef. The ClusterVaultAuth must allow the namespace of this CR in
+	// its AllowedNamespaces.
+	ClusterVaultAuthRef string `json:"clusterVaultAuthRef,omitempty"`
+	// Namespace of the identity token role in Vault. If not set, the namespace that's
+	// part of VaultAuth resource will be inferred.
+	Namespace string `json:"namespace,omitempty"`
+	// Role of the identity token, the token is generated from
+	// identity/oidc/token/:role. The Vault entity of the VaultAuth must be
+	// allowed to use the role.
+	// +kubebuilder:validation:MinLength=1
+	Role string `json:"role"`
+	// RenewalPercent is the percent out of 100 of the token's TTL when a new
+	// token is generated. Defaults to 67 percent plus jitter.
+	// +kubebuilder:default=67
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=90
+	RenewalPercent int `json:"renewalPercent,omitempty"`
+	// RolloutRestartTargets should be configured whenever the application(s) consuming the Vault secret does
+	// not support dynamically reloading a rotated secret.
+	// In that case one, or more RolloutRestartTarget(s) can be configured here. The Operator will
+	// trigger a "rollout-restart" for each target whenever the Vault secret changes between reconciliation events.
+	// See RolloutRestartTarget for more details.
+	RolloutRestartTargets []RolloutRestartTarget `json:"rolloutRestartTargets,omitempty"`
+	// Destination provides configuration necessary for syncing the Vault secret to Kubernetes.
+	// The Secret's data contains the token, its client_id, and its ttl.
+	Destination Destination `json:"destination"`
+}
+
+// VaultIdentityTokenStatus defines the observed state of VaultIdentityToken
+type VaultIdentityTokenStatus struct {
+	// VaultLeasedSecretStatus is shared with the leased secret resources.
+	// Identity tokens are not leased, the token's TTL is recorded as the
+	// SecretLease.LeaseDuration.
+	VaultLeasedSecretStatus `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// VaultIdentityToken is the Schema for the vaultidentitytokens API
+type VaultIdentityToken struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VaultIdentityTokenSpec   `json:"spec,omitempty"`
+	Status VaultIdentityTokenStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VaultIdentityTokenList contains a list of VaultIdentityToken
+type VaultIdentityTokenList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VaultIdentityToken `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VaultIdentityToken{}, &VaultIdentityTokenList{})
+}
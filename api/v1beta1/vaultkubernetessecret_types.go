@@ -0,0 +1,97 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VaultKubernetesSecretSpec defines the desired state of VaultKubernetesSecret
+type VaultKubernetesSecretSpec struct {
+	// VaultAuthRef to the VaultAuth resource, can be prefixed with a namespace,
+	// eg: `namespaceA/vaultAuthRefB`. If no namespace prefix is provided it will default to
+	// the namespace of the VaultAuth CR. If no value is specified for VaultAuthRef the Operator
+	// will default to the VaultAuth of the CR's namespace that is named `default`, or that is
+	// labeled `vso.hashicorp.com/default-vault-auth: "true"`, and then to the `default` VaultAuth
+	// configured in the operator's namespace.
+	VaultAuthRef string `json:"vaultAuthRef,omitempty"`
+	// ClusterVaultAuthRef to the cluster-scoped ClusterVaultAuth resource, it is mutually
+	// exclusive with VaultAuthRef. The ClusterVaultAuth must allow the namespace of this CR in
+	// its AllowedNamespaces.
+	ClusterVaultAuthRef string `json:"clusterVaultAuthRef,omitempty"`
+	// Namespace of the secrets engine mount in Vault. If not set, the namespace that's
+	// part of VaultAuth resource will be inferred.
+	Namespace string `json:"namespace,omitempty"`
+	// Mount path of the Kubernetes secrets engine in Vault.
+	// +kubebuilder:default=kubernetes
+	Mount string `json:"mount,omitempty"`
+	// Role in the Kubernetes secrets engine that the service account token will
+	// be generated for.
+	// +kubebuilder:validation:MinLength=1
+	Role string `json:"role"`
+	// KubernetesNamespace is the namespace, in the cluster that is managed by
+	// the secrets engine, in which the service account token is generated.
+	// +kubebuilder:validation:MinLength=1
+	KubernetesNamespace string `json:"kubernetesNamespace"`
+	// ClusterRoleBinding grants the generated role cluster-wide, rather than
+	// in KubernetesNamespace only. Only applies to roles that generate the
+	// Kubernetes role and its binding.
+	ClusterRoleBinding bool `json:"clusterRoleBinding,omitempty"`
+	// TTL of the service account token, in duration notation e.g. 30s, 1m, 24h.
+	// Defaults to the role's default TTL.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(s|m|h))$`
+	TTL string `json:"ttl,omitempty"`
+	// Audiences of the service account token. Defaults to the role's audiences.
+	Audiences []string `json:"audiences,omitempty"`
+	// RenewalPercent is the percent out of 100 of the token's TTL when a new
+	// token is requested. The tokens cannot be renewed, so they are always
+	// replaced before they expire. Defaults to 67 percent plus jitter.
+	// +kubebuilder:default=67
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=90
+	RenewalPercent int `json:"renewalPercent,omitempty"`
+	// Revoke the existing lease on resource deletion. Revoking the lease
+	// also deletes the objects that the secrets engine created for the token,
+	// e.g. its service account.
+	Revoke bool `json:"revoke,omitempty"`
+	// RolloutRestartTargets should be configured whenever the application(s) consuming the Vault secret does
+	// not support dynamically reloading a rotated secret.
+	// In that case one, or more RolloutRestartTarget(s) can be configured here. The Operator will
+	// trigger a "rollout-restart" for each target whenever the Vault secret changes between reconciliation events.
+	// See RolloutRestartTarget for more details.
+	RolloutRestartTargets []RolloutRestartTarget `json:"rolloutRestartTargets,omitempty"`
+	// Destination provides configuration necessary for syncing the Vault secret to Kubernetes.
+	Destination Destination `json:"destination"`
+}
+
+// VaultKubernetesSecretStatus defines the observed state of VaultKubernetesSecret
+type VaultKubernetesSecretStatus struct {
+	VaultLeasedSecretStatus `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// VaultKubernetesSecret is the Schema for the vaultkubernetessecrets API
+type VaultKubernetesSecret struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VaultKubernetesSecretSpec   `json:"spec,omitempty"`
+	Status VaultKubernetesSecretStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VaultKubernetesSecretList contains a list of VaultKubernetesSecret
+type VaultKubernetesSecretList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VaultKubernetesSecret `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VaultKubernetesSecret{}, &VaultKubernetesSecretList{})
+}
@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VaultLeaseAssignmentSpec defines the desired state of VaultLeaseAssignment
+type VaultLeaseAssignmentSpec struct {
+	// VaultDynamicSecretRef is the name of the VaultDynamicSecret whose lease
+	// is assigned, in the same namespace.
+	VaultDynamicSecretRef string `json:"vaultDynamicSecretRef"`
+	// LeaseID of the VaultDynamicSecret's current lease.
+	LeaseID string `json:"leaseID"`
+	// Increment to request when renewing the lease, in seconds.
+	Increment int `json:"increment"`
+	// RenewAfter is the time after which a standby replica takes over the
+	// renewal of the lease, when the leader has not renewed it in the meantime.
+	RenewAfter metav1.Time `json:"renewAfter"`
+}
+
+// VaultLeaseAssignmentStatus defines the observed state of VaultLeaseAssignment
+type VaultLeaseAssignmentStatus struct {
+	// Holder is the identity of the standby replica that last renewed the
+	// lease, or attempted to.
+	Holder string `json:"holder,omitempty"`
+	// LeaseID that was last renewed by the Holder.
+	LeaseID string `json:"leaseID,omitempty"`
+	// LeaseDuration returned by the last renewal, in seconds.
+	LeaseDuration int `json:"leaseDuration,omitempty"`
+	// RenewedAt is the time of the last successful renewal.
+	RenewedAt *metav1.Time `json:"renewedAt,omitempty"`
+	// Error of the last renewal attempt, empty if it succeeded.
+	Error string `json:"error,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Lease",type=string,JSONPath=`.spec.leaseID`
+// +kubebuilder:printcolumn:name="Renew After",type=date,JSONPath=`.spec.renewAfter`
+// +kubebuilder:printcolumn:name="Holder",type=string,JSONPath=`.status.holder`
+
+// VaultLeaseAssignment is the Schema for the vaultleaseassignments API. It
+// assigns the renewal of a VaultDynamicSecret's lease to the Operator's
+// standby replicas, should the leader fail to renew it in time. Assignments
+// are only written by the Operator, when standby renewals are enabled, and are
+// garbage collected along with their VaultDynamicSecret.
+type VaultLeaseAssignment struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VaultLeaseAssignmentSpec   `json:"spec,omitempty"`
+	Status VaultLeaseAssignmentStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VaultLeaseAssignmentList contains a list of VaultLeaseAssignment
+type VaultLeaseAssignmentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VaultLeaseAssignment `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VaultLeaseAssignment{}, &VaultLeaseAssignmentList{})
+}
@@ -17,8 +17,14 @@ type VaultPKISecretSpec struct {
 	// VaultAuthRef to the VaultAuth resource, can be prefixed with a namespace,
 	// eg: `namespaceA/vaultAuthRefB`. If no namespace prefix is provided it will default to
 	// the namespace of the VaultAuth CR. If no value is specified for VaultAuthRef the Operator
-	// will default to the `default` VaultAuth, configured in the operator's namespace.
+	// will default to the VaultAuth of the CR's namespace that is named `default`, or that is
+	// labeled `vso.hashicorp.com/default-vault-auth: "true"`, and then to the `default` VaultAuth
+	// configured in the operator's namespace.
 	VaultAuthRef string `json:"vaultAuthRef,omitempty"`
+	// ClusterVaultAuthRef to the cluster-scoped ClusterVaultAuth resource, it is mutually
+	// exclusive with VaultAuthRef. The ClusterVaultAuth must allow the namespace of this CR in
+	// its AllowedNamespaces.
+	ClusterVaultAuthRef string `json:"clusterVaultAuthRef,omitempty"`
 
 	// Namespace of the secrets engine mount in Vault. If not set, the namespace that's
 	// part of VaultAuth resource will be inferred.
@@ -60,8 +66,9 @@ type VaultPKISecretSpec struct {
 	// to Kubernetes. If the type is set to "kubernetes.io/tls", "tls.key" will
 	// be set to the "private_key" response from Vault, and "tls.crt" will be
 	// set to "certificate" + "ca_chain" from the Vault response ("issuing_ca"
-	// is used when "ca_chain" is empty). The "remove_roots_from_chain=true"
-	// option is used with Vault to exclude the root CA from the Vault response.
+	// is used when "ca_chain" is empty), and "ca.crt" will be set to
+	// "issuing_ca". The "remove_roots_from_chain=true" option is used with Vault
+	// to exclude the root CA from the Vault response.
 	Destination Destination `json:"destination"`
 
 	// CommonName to include in the request.
@@ -118,6 +125,97 @@ type VaultPKISecretSpec struct {
 	// ExcludeCNFromSans from DNS or Email Subject Alternate Names.
 	// Default: false
 	ExcludeCNFromSans bool `json:"excludeCNFromSans,omitempty"`
+
+	// PKCS12 packages the issued certificate, its private key, and its CA chain
+	// into a PKCS#12 keystore, for Java and Windows workloads. The keystore and
+	// its passphrase are added to the destination Secret's data.
+	PKCS12 *VaultPKISecretPKCS12 `json:"pkcs12,omitempty"`
+
+	// JKS packages the issued certificate, its private key, and its CA chain
+	// into a JKS keystore, and optionally the CA chain into a JKS truststore,
+	// for legacy JVM workloads. The keystores and their passphrase are added to
+	// the destination Secret's data.
+	JKS *VaultPKISecretJKS `json:"jks,omitempty"`
+
+	// Encodings add binary encodings of the issued certificate, its private
+	// key, or its CA certificates to the destination Secret's data, for
+	// workloads that do not accept PEM input.
+	// +listType=map
+	// +listMapKey=key
+	Encodings []VaultPKISecretEncoding `json:"encodings,omitempty"`
+}
+
+// VaultPKISecretEncoding configures a binary encoding of a field of the issued
+// certificate.
+type VaultPKISecretEncoding struct {
+	// Key is the K8s Secret data key of the encoded field.
+	Key string `json:"key"`
+	// Source is the field of the issued certificate to encode. The ca_chain
+	// falls back to the issuing CA, when Vault did not return any.
+	// +kubebuilder:validation:Enum={certificate,private_key,issuing_ca,ca_chain}
+	Source string `json:"source"`
+	// Format of the encoding. The der format is the raw DER of a single
+	// certificate, or of the PKCS#8 private key. The pkcs7 format is a
+	// certs-only PKCS#7 bundle of the certificates, where the certificate's
+	// bundle includes its CA chain. The private_key has no pkcs7 encoding.
+	// +kubebuilder:validation:Enum={der,pkcs7}
+	Format string `json:"format"`
+}
+
+// VaultPKISecretJKS configures the JKS keystore and truststore of the issued
+// certificate.
+type VaultPKISecretJKS struct {
+	// Key is the K8s Secret data key of the keystore.
+	// +kubebuilder:default=keystore.jks
+	Key string `json:"key,omitempty"`
+	// TruststoreKey is the K8s Secret data key of the truststore, which holds
+	// the certificates of the CA chain as trusted entries. The truststore is
+	// only added when it is set.
+	TruststoreKey string `json:"truststoreKey,omitempty"`
+	// PasswordKey is the K8s Secret data key of the passphrase of both the
+	// keystore, its private key, and the truststore.
+	// +kubebuilder:default=jks.password
+	PasswordKey string `json:"passwordKey,omitempty"`
+	// Alias of the certificate's entry in the keystore. Defaults to the
+	// certificate's CommonName. JKS aliases are always lowercase.
+	Alias string `json:"alias,omitempty"`
+	// PasswordSource is the Vault KV secret that holds the passphrase. A random
+	// passphrase is generated upon every issuance when it is not set.
+	PasswordSource *VaultPKISecretPKCS12PasswordSource `json:"passwordSource,omitempty"`
+}
+
+// VaultPKISecretPKCS12 configures the PKCS#12 keystore of the issued
+// certificate.
+type VaultPKISecretPKCS12 struct {
+	// Key is the K8s Secret data key of the keystore.
+	// +kubebuilder:default=keystore.p12
+	Key string `json:"key,omitempty"`
+	// PasswordKey is the K8s Secret data key of the keystore's passphrase.
+	// +kubebuilder:default=keystore.password
+	PasswordKey string `json:"passwordKey,omitempty"`
+	// Alias of the certificate's entry in the keystore. Defaults to the
+	// certificate's CommonName.
+	Alias string `json:"alias,omitempty"`
+	// PasswordSource is the Vault KV secret that holds the keystore's
+	// passphrase. A random passphrase is generated upon every issuance when it
+	// is not set.
+	PasswordSource *VaultPKISecretPKCS12PasswordSource `json:"passwordSource,omitempty"`
+}
+
+// VaultPKISecretPKCS12PasswordSource is the Vault KV secret that holds the
+// keystore's passphrase.
+type VaultPKISecretPKCS12PasswordSource struct {
+	// Mount of the KV secrets engine in Vault.
+	Mount string `json:"mount"`
+	// Path of the secret in Vault.
+	Path string `json:"path"`
+	// Type of the KV secrets engine.
+	// +kubebuilder:validation:Enum={kv-v1,kv-v2}
+	// +kubebuilder:default=kv-v2
+	Type string `json:"type,omitempty"`
+	// Field of the secret that holds the passphrase.
+	// +kubebuilder:default=password
+	Field string `json:"field,omitempty"`
 }
 
 // VaultPKISecretStatus defines the observed state of VaultPKISecret
@@ -138,6 +236,13 @@ type VaultPKISecretStatus struct {
 	SecretMAC string `json:"secretMAC,omitempty"`
 	Valid     *bool  `json:"valid"`
 	Error     string `json:"error"`
+	// LastSyncMessages contains the most recent sync attempts, ordered from the
+	// oldest to the newest. Only a bounded number of entries are retained.
+	LastSyncMessages []SyncMessage `json:"lastSyncMessages,omitempty"`
+	// Conditions hold the latest observations of the resource's state. The
+	// DestinationConflict condition is set when the destination Secret exists,
+	// but is not owned by the resource.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
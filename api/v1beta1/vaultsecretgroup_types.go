@@ -0,0 +1,100 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// SecretGroupConflictPolicyFail fails the sync when the same key is provided
+	// by more than one source.
+	SecretGroupConflictPolicyFail = "Fail"
+	// SecretGroupConflictPolicyFirstWins keeps the value of the first source that
+	// provides the key.
+	SecretGroupConflictPolicyFirstWins = "FirstWins"
+	// SecretGroupConflictPolicyLastWins keeps the value of the last source that
+	// provides the key.
+	SecretGroupConflictPolicyLastWins = "LastWins"
+)
+
+// VaultSecretGroupSpec defines the desired state of VaultSecretGroup
+type VaultSecretGroupSpec struct {
+	// Sources are the syncable secret resources whose destination Secret data is
+	// merged into the group's destination Secret, in order. The sources keep
+	// handling their own leases and rotations, the group is synced whenever
+	// any of their destination Secrets changes.
+	// +kubebuilder:validation:MinItems=1
+	Sources []SecretGroupSource `json:"sources"`
+	// ConflictPolicy controls what happens when the same key is provided by more
+	// than one source, after prefixing. Fail stops the sync, FirstWins keeps the
+	// value of the first source, and LastWins keeps the value of the last source,
+	// in the order of Sources.
+	// +kubebuilder:validation:Enum={Fail,FirstWins,LastWins}
+	// +kubebuilder:default=Fail
+	ConflictPolicy string `json:"conflictPolicy,omitempty"`
+	// RolloutRestartTargets should be configured whenever the application(s) consuming the Vault secret does
+	// not support dynamically reloading a rotated secret.
+	// In that case one, or more RolloutRestartTarget(s) can be configured here. The Operator will
+	// trigger a "rollout-restart" for each target whenever the Vault secret changes between reconciliation events.
+	// See RolloutRestartTarget for more details.
+	RolloutRestartTargets []RolloutRestartTarget `json:"rolloutRestartTargets,omitempty"`
+	// Destination provides configuration necessary for syncing the merged secret
+	// data to Kubernetes.
+	Destination Destination `json:"destination"`
+}
+
+// SecretGroupSource references a syncable secret resource in the
+// VaultSecretGroup's namespace.
+type SecretGroupSource struct {
+	// Kind of the resource, e.g. VaultStaticSecret.
+	// +kubebuilder:validation:Enum={VaultStaticSecret,VaultDynamicSecret,VaultPKISecret,HCPVaultSecretsApp,VaultConsulSecret,VaultNomadSecret,VaultLDAPSecret,VaultRabbitMQSecret,VaultTransitSecret,VaultKubernetesSecret,VaultTerraformCloudSecret,VaultGenericSecret,VaultWrappedSecret,VaultIdentityToken,VaultMongoDBAtlasSecret}
+	Kind string `json:"kind"`
+	// Name of the resource.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Prefix is prepended to every key of the source's data, e.g. "db_".
+	Prefix string `json:"prefix,omitempty"`
+}
+
+// VaultSecretGroupStatus defines the observed state of VaultSecretGroup
+type VaultSecretGroupStatus struct {
+	// LastGeneration is the Generation of the last reconciled resource.
+	LastGeneration int64 `json:"lastGeneration"`
+	// SecretMAC used when deciding whether the destination Secret should be
+	// synced.
+	SecretMAC string `json:"secretMAC,omitempty"`
+	// LastSyncMessages contains the most recent sync attempts, ordered from the
+	// oldest to the newest. Only a bounded number of entries are retained.
+	LastSyncMessages []SyncMessage `json:"lastSyncMessages,omitempty"`
+	// Conditions hold the latest observations of the resource's state. The
+	// DestinationConflict condition is set when the destination Secret exists,
+	// but is not owned by the resource.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// VaultSecretGroup is the Schema for the vaultsecretgroups API
+type VaultSecretGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VaultSecretGroupSpec   `json:"spec,omitempty"`
+	Status VaultSecretGroupStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VaultSecretGroupList contains a list of VaultSecretGroup
+type VaultSecretGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VaultSecretGroup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VaultSecretGroup{}, &VaultSecretGroupList{})
+}
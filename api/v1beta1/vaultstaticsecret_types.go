@@ -10,13 +10,28 @@ import (
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
+const (
+	// VaultStaticSecretPrefixModeMerge syncs all the secrets found under the
+	// prefix into the Destination Secret.
+	VaultStaticSecretPrefixModeMerge = "Merge"
+	// VaultStaticSecretPrefixModePerPath syncs each secret found under the
+	// prefix into its own Secret.
+	VaultStaticSecretPrefixModePerPath = "PerPath"
+)
+
 // VaultStaticSecretSpec defines the desired state of VaultStaticSecret
 type VaultStaticSecretSpec struct {
 	// VaultAuthRef to the VaultAuth resource, can be prefixed with a namespace,
 	// eg: `namespaceA/vaultAuthRefB`. If no namespace prefix is provided it will default to the
 	// namespace of the VaultAuth CR. If no value is specified for VaultAuthRef the Operator will
-	// default to the `default` VaultAuth, configured in the operator's namespace.
+	// default to the VaultAuth of the CR's namespace that is named `default`, or that is
+	// labeled `vso.hashicorp.com/default-vault-auth: "true"`, and then to the `default` VaultAuth
+	// configured in the operator's namespace.
 	VaultAuthRef string `json:"vaultAuthRef,omitempty"`
+	// ClusterVaultAuthRef to the cluster-scoped ClusterVaultAuth resource, it is mutually
+	// exclusive with VaultAuthRef. The ClusterVaultAuth must allow the namespace of this CR in
+	// its AllowedNamespaces.
+	ClusterVaultAuthRef string `json:"clusterVaultAuthRef,omitempty"`
 	// Namespace of the secrets engine mount in Vault. If not set, the namespace that's
 	// part of VaultAuth resource will be inferred.
 	Namespace string `json:"namespace,omitempty"`
@@ -25,9 +40,23 @@ type VaultStaticSecretSpec struct {
 	// Path of the secret in Vault, corresponds to the `path` parameter for,
 	// kv-v1: https://developer.hashicorp.com/vault/api-docs/secret/kv/kv-v1#read-secret
 	// kv-v2: https://developer.hashicorp.com/vault/api-docs/secret/kv/kv-v2#read-secret-version
+	// When Prefix is set, Path is the prefix under which the secrets are listed.
 	Path string `json:"path"`
+	// Paths of other secrets in Vault, of the same Mount and Type, whose data is
+	// merged with the data of the secret at Path, e.g. Path holds the data that
+	// is shared by all the apps, and Paths hold the app specific overrides.
+	// When a key is found in several secrets, the value of the last path takes
+	// precedence, and the value at Path has the lowest precedence. The objects
+	// are merged recursively, any other value is replaced. The latest version
+	// of each secret is read, Version only applies to Path. Cannot be combined
+	// with Prefix.
+	Paths []string `json:"paths,omitempty"`
+	// Prefix syncs all the secrets found under Path, instead of the secret at
+	// Path. This avoids having to create a VaultStaticSecret for each of them.
+	Prefix *VaultStaticSecretPrefix `json:"prefix,omitempty"`
 	// Version of the secret to fetch. Only valid for type kv-v2. Corresponds to version query parameter:
 	// https://developer.hashicorp.com/vault/api-docs/secret/kv/kv-v2#version
+	// Ignored when Prefix is set, the latest version of each secret is fetched.
 	// +kubebuilder:validation:Minimum=0
 	Version int `json:"version,omitempty"`
 	// Type of the Vault static secret
@@ -53,8 +82,60 @@ type VaultStaticSecretSpec struct {
 	RolloutRestartTargets []RolloutRestartTarget `json:"rolloutRestartTargets,omitempty"`
 	// Destination provides configuration necessary for syncing the Vault secret to Kubernetes.
 	Destination Destination `json:"destination"`
+	// AdditionalDestinations are synced with the same Vault secret data as the
+	// Destination, without reading it again. Each one may have its own name,
+	// type, and transformation. Their names must be distinct from each other,
+	// and from the Destination's.
+	// +listType=map
+	// +listMapKey=name
+	AdditionalDestinations []Destination `json:"additionalDestinations,omitempty"`
 	// SyncConfig configures sync behavior from Vault to VSO
 	SyncConfig *SyncConfig `json:"syncConfig,omitempty"`
+	// Seed the secret at Path from an existing Kubernetes Secret, when it does
+	// not exist in Vault yet. This eases the migration of the secrets whose only
+	// copy is held by Kubernetes. The seeding is done once, the resource is then
+	// synced from Vault like any other. Cannot be combined with Paths or Prefix.
+	// KV version 2 secrets are written with check-and-set, so that a secret
+	// written concurrently to Vault is never overwritten, the seeding is skipped
+	// and a SeedConflict event is recorded instead.
+	Seed *VaultStaticSecretSeed `json:"seed,omitempty"`
+}
+
+// VaultStaticSecretSeed configures the one-shot seeding of the secret at Path
+// from an existing Kubernetes Secret.
+type VaultStaticSecretSeed struct {
+	// SecretName of the Kubernetes Secret, in the resource's namespace, whose
+	// data is written to the secret at Path. It defaults to Destination.Name, in
+	// which case Destination.Overwrite must be set for the Secret to be synced
+	// from Vault afterward. The Secret must be explicitly labeled with
+	// vso.secrets.hashicorp.com/seed: "true", so that no other Secret is ever
+	// written to Vault. The secret at Path is never overwritten, if it already
+	// exists, nothing is seeded.
+	SecretName string `json:"secretName,omitempty"`
+}
+
+// VaultStaticSecretPrefix configures the sync of all the secrets found under a
+// path prefix.
+type VaultStaticSecretPrefix struct {
+	// Mode controls where the secrets are synced to. Merge syncs all of them into
+	// the Destination Secret, each key being prefixed with the secret's path
+	// relative to the prefix, with every '/' replaced by '_', e.g. the key
+	// "password" of the secret "app/db" becomes "app_db_password".
+	// PerPath syncs each secret into its own Secret, named after
+	// Destination.Name suffixed by the secret's relative path, lower-cased and
+	// with every '/' and '_' replaced by '-', e.g. "dest-app-db". Destination.Create
+	// must be set in that case, and the Secrets of the paths that are no longer
+	// found are deleted.
+	// +kubebuilder:validation:Enum={Merge,PerPath}
+	// +kubebuilder:default=Merge
+	Mode string `json:"mode,omitempty"`
+	// Recursive also syncs the secrets found in all the sub-folders of the
+	// prefix, otherwise only its direct children are synced.
+	Recursive bool `json:"recursive,omitempty"`
+	// Glob only syncs the secrets whose path relative to the prefix matches the
+	// pattern, e.g. "app-*". See https://pkg.go.dev/path#Match for the
+	// supported syntax.
+	Glob string `json:"glob,omitempty"`
 }
 
 // SyncConfig configures sync behavior from Vault to VSO
@@ -62,6 +143,26 @@ type SyncConfig struct {
 	// InstantUpdates is a flag to indicate that event-driven updates are
 	// enabled for this VaultStaticSecret
 	InstantUpdates bool `json:"instantUpdates,omitempty"`
+	// ServeStaleData keeps serving the last synced data when Vault is
+	// unavailable, e.g. it cannot be reached or it is sealed. The destination
+	// Secret is left untouched until Vault is available again, and the
+	// ServingStaleData condition reports for how long its data may have been
+	// stale. The staleness is also reported in the vso_stale_data_seconds
+	// metric.
+	ServeStaleData bool `json:"serveStaleData,omitempty"`
+	// ForceSyncAlways writes the destination Secret on every sync, even if its
+	// data has not changed according to the HMAC-based change detection. The
+	// RolloutRestartTargets are still only restarted when the data has changed.
+	// Only applies when HMACSecretData is set.
+	ForceSyncAlways bool `json:"forceSyncAlways,omitempty"`
+	// IgnoreFields are the keys of the destination Secret's data that are
+	// excluded from the HMAC-based change detection, e.g. a timestamp that
+	// changes on every read. A change to these fields alone never causes the
+	// destination Secret to be written, nor its RolloutRestartTargets to be
+	// restarted. The fields are also excluded from the _raw data. With a
+	// Prefix, the keys are those of the merged data, e.g. app_db_timestamp.
+	// +listType=set
+	IgnoreFields []string `json:"ignoreFields,omitempty"`
 }
 
 // VaultStaticSecretStatus defines the observed state of VaultStaticSecret
@@ -75,7 +176,30 @@ type VaultStaticSecretStatus struct {
 	//
 	// The SecretMac is also used to detect drift in the Destination Secret's Data.
 	// If drift is detected the data will be synced to the Destination.
+	//
+	// Deprecated: the SecretMAC is now kept in the vso-secret-macs Secret of the
+	// resource's namespace. It is only set when it could not be stored there, and
+	// it is cleared on the next sync.
 	SecretMAC string `json:"secretMAC,omitempty"`
+	// LastSyncMessages contains the most recent sync attempts, ordered from the
+	// oldest to the newest. Only a bounded number of entries are retained.
+	LastSyncMessages []SyncMessage `json:"lastSyncMessages,omitempty"`
+	// StaleSince is the time of the first failed attempt to read the secret from
+	// Vault, while it is unavailable, when SyncConfig.ServeStaleData is set.
+	// The destination Secret's data may have been stale since then.
+	StaleSince *metav1.Time `json:"staleSince,omitempty"`
+	// SyncProgress holds the steps of the last sync that are still pending after
+	// one of them failed. It is only set while a sync is partially applied.
+	SyncProgress *SyncProgress `json:"syncProgress,omitempty"`
+	// Seeded is set once the seeding configured by Spec.Seed is done, whether
+	// the secret was written to Vault, or it already existed. The resource is
+	// never seeded again.
+	Seeded bool `json:"seeded,omitempty"`
+	// Conditions hold the latest observations of the resource's state. The
+	// DestinationConflict condition is set when the destination Secret exists,
+	// but is not owned by the resource. The ServingStaleData condition is set
+	// when the last synced data is served while Vault is unavailable.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -0,0 +1,127 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VaultTransitSecretSpec defines the desired state of VaultTransitSecret
+type VaultTransitSecretSpec struct {
+	// VaultAuthRef to the VaultAuth resource, can be prefixed with a namespace,
+	// eg: `namespaceA/vaultAuthRefB`. If no namespace prefix is provided it will default to the
+	// namespace of the VaultAuth CR. If no value is specified for VaultAuthRef the Operator will
+	// default to the VaultAuth of the CR's namespace that is named `default`, or that is
+	// labeled `vso.hashicorp.com/default-vault-auth: "true"`, and then to the `default` VaultAuth
+	// configured in the operator's namespace.
+	VaultAuthRef string `json:"vaultAuthRef,omitempty"`
+	// ClusterVaultAuthRef to the cluster-scoped ClusterVaultAuth resource, it is mutually
+	// exclusive with VaultAuthRef. The ClusterVaultAuth must allow the namespace of this CR in
+	// its AllowedNamespaces.
+	ClusterVaultAuthRef string `json:"clusterVaultAuthRef,omitempty"`
+	// Namespace of the secrets engine mount in Vault. If not set, the namespace that's
+	// part of VaultAuth resource will be inferred.
+	Namespace string `json:"namespace,omitempty"`
+	// Mount path of the transit secrets engine in Vault.
+	// +kubebuilder:default=transit
+	Mount string `json:"mount,omitempty"`
+	// Key is the name of the transit key used to decrypt the Payloads.
+	// The Operator also needs read access to the key, e.g. transit/keys/<key>, in
+	// order to detect key rotations.
+	// +kubebuilder:validation:MinLength=1
+	Key string `json:"key"`
+	// Payloads to decrypt. The plaintext of each payload is stored in the
+	// destination Secret under the payload's Name.
+	// +kubebuilder:validation:MinItems=1
+	Payloads []TransitPayload `json:"payloads"`
+	// Rewrap the ciphertext of the Payloads that are sourced from a Secret or a
+	// ConfigMap whenever the transit key is rotated. The rewrapped ciphertext is
+	// written back to its source. Inline ciphertext is never rewrapped.
+	Rewrap bool `json:"rewrap,omitempty"`
+	// RefreshAfter a period of time, in duration notation e.g. 30s, 1m, 24h. The
+	// Payloads and the transit key's version are checked for changes each period.
+	// Defaults to 60s.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(s|m|h))$`
+	RefreshAfter string `json:"refreshAfter,omitempty"`
+	// RolloutRestartTargets should be configured whenever the application(s) consuming the Vault secret does
+	// not support dynamically reloading a rotated secret.
+	// In that case one, or more RolloutRestartTarget(s) can be configured here. The Operator will
+	// trigger a "rollout-restart" for each target whenever the decrypted data changes between
+	// reconciliation events. See RolloutRestartTarget for more details.
+	RolloutRestartTargets []RolloutRestartTarget `json:"rolloutRestartTargets,omitempty"`
+	// Destination provides configuration necessary for syncing the decrypted data to Kubernetes.
+	Destination Destination `json:"destination"`
+}
+
+// TransitPayload is a single ciphertext to decrypt with Vault's transit
+// secrets engine. Exactly one of Ciphertext or CiphertextFrom must be set.
+type TransitPayload struct {
+	// Name of the destination Secret's data key that holds the plaintext.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Ciphertext to decrypt, e.g. vault:v1:8SDd3WHDOjf7mq69CyCqYjBXAiQQAVZRkFM13ok481zoCmHnSeDX9vyf7w==
+	Ciphertext string `json:"ciphertext,omitempty"`
+	// CiphertextFrom references the Secret or ConfigMap that holds the ciphertext.
+	CiphertextFrom *TransitCiphertextSource `json:"ciphertextFrom,omitempty"`
+	// Context is the base64 encoded context for key derivation. Required if the
+	// transit key has derivation enabled.
+	Context string `json:"context,omitempty"`
+}
+
+// TransitCiphertextSource references a ciphertext that is stored in a Secret or
+// a ConfigMap, in the same namespace as the VaultTransitSecret.
+type TransitCiphertextSource struct {
+	// Kind of the source object.
+	// +kubebuilder:validation:Enum={Secret,ConfigMap}
+	Kind string `json:"kind"`
+	// Name of the source object.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Key in the source object's data that holds the ciphertext.
+	// +kubebuilder:validation:MinLength=1
+	Key string `json:"key"`
+}
+
+// VaultTransitSecretStatus defines the observed state of VaultTransitSecret
+type VaultTransitSecretStatus struct {
+	// LastGeneration is the Generation of the last reconciled resource.
+	LastGeneration int64 `json:"lastGeneration"`
+	// KeyVersion is the latest version of the transit key, as of the last sync.
+	KeyVersion int `json:"keyVersion,omitempty"`
+	// SecretMAC used when deciding whether the decrypted data should be synced.
+	SecretMAC string `json:"secretMAC,omitempty"`
+	// LastSyncMessages contains the most recent sync attempts, ordered from the
+	// oldest to the newest. Only a bounded number of entries are retained.
+	LastSyncMessages []SyncMessage `json:"lastSyncMessages,omitempty"`
+	// Conditions hold the latest observations of the resource's state. The
+	// DestinationConflict condition is set when the destination Secret exists,
+	// but is not owned by the resource.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// VaultTransitSecret is the Schema for the vaulttransitsecrets API
+type VaultTransitSecret struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VaultTransitSecretSpec   `json:"spec,omitempty"`
+	Status VaultTransitSecretStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VaultTransitSecretList contains a list of VaultTransitSecret
+type VaultTransitSecretList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VaultTransitSecret `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VaultTransitSecret{}, &VaultTransitSecretList{})
+}
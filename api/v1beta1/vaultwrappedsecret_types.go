@@ -0,0 +1,113 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VaultWrappedSecretSpec defines the desired state of VaultWrappedSecret
+type VaultWrappedSecretSpec struct {
+	// VaultAuthRef to the VaultAuth resource, can be prefixed with a namespace,
+	// eg: `namespaceA/vaultAuthRefB`. If no namespace prefix is provided it will default to
+	// the namespace of the VaultAuth CR. If no value is specified for VaultAuthRef the Operator
+	// will default to the VaultAuth of the CR's namespace that is named `default`, or that is
+	// labeled `vso.hashicorp.com/default-vault-auth: "true"`, and then to the `default` VaultAuth
+	// configured in the operator's namespace.
+	VaultAuthRef string `json:"vaultAuthRef,omitempty"`
+	// ClusterVaultAuthRef to the cluster-scoped ClusterVaultAuth resource, it is mutually
+	// exclusive with VaultAuthRef. The ClusterVaultAuth must allow the namespace of this CR in
+	// its AllowedNamespaces.
+	ClusterVaultAuthRef string `json:"clusterVaultAuthRef,omitempty"`
+	// Namespace in Vault that the wrapping token was created in. If not set, the
+	// namespace that's part of VaultAuth resource will be inferred.
+	Namespace string `json:"namespace,omitempty"`
+	// WrappingTokenRef references the Secret that holds the response-wrapping
+	// token, typically written by a CI system. Every wrapping token is only ever
+	// unwrapped once, a new token must be written to the Secret in order to
+	// deliver a new payload.
+	WrappingTokenRef WrappingTokenSource `json:"wrappingTokenRef"`
+	// CreationPath that the wrapping token is expected to have been created
+	// for, e.g. secret/data/app. When set, the token is looked up before it is
+	// unwrapped, and it is rejected if its creation path does not match. This
+	// guards against a token that was substituted in transit.
+	CreationPath string `json:"creationPath,omitempty"`
+	// RefreshAfter a period of time, in duration notation e.g. 30s, 1m, 24h. The
+	// WrappingTokenRef Secret is checked for a new token each period. Defaults
+	// to 60s.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(s|m|h))$`
+	RefreshAfter string `json:"refreshAfter,omitempty"`
+	// RolloutRestartTargets should be configured whenever the application(s) consuming the Vault secret does
+	// not support dynamically reloading a rotated secret.
+	// In that case one, or more RolloutRestartTarget(s) can be configured here. The Operator will
+	// trigger a "rollout-restart" for each target whenever a new payload is unwrapped.
+	// See RolloutRestartTarget for more details.
+	RolloutRestartTargets []RolloutRestartTarget `json:"rolloutRestartTargets,omitempty"`
+	// Destination provides configuration necessary for syncing the unwrapped payload to Kubernetes.
+	Destination Destination `json:"destination"`
+}
+
+// WrappingTokenSource references a response-wrapping token that is stored in a
+// Secret, in the same namespace as the VaultWrappedSecret.
+type WrappingTokenSource struct {
+	// SecretName of the Secret that holds the wrapping token.
+	// +kubebuilder:validation:MinLength=1
+	SecretName string `json:"secretName"`
+	// Key in the Secret's data that holds the wrapping token.
+	// +kubebuilder:default=token
+	Key string `json:"key,omitempty"`
+}
+
+// VaultWrappedSecretStatus defines the observed state of VaultWrappedSecret
+type VaultWrappedSecretStatus struct {
+	// LastGeneration is the Generation of the last reconciled resource.
+	LastGeneration int64 `json:"lastGeneration"`
+	// WrappingTokenMAC is the HMAC of the last wrapping token that was
+	// unwrapped. It marks the token as consumed, so that it is never unwrapped
+	// again.
+	WrappingTokenMAC string `json:"wrappingTokenMAC,omitempty"`
+	// UnwrapTime is the time, in seconds since the Unix epoch, at which the
+	// last wrapping token was unwrapped.
+	UnwrapTime int64 `json:"unwrapTime,omitempty"`
+	// PayloadSecretName is the name of the Secret that the Operator stores the
+	// unwrapped payload in. The destination Secret is always rendered from it,
+	// since the payload cannot be fetched from Vault again.
+	PayloadSecretName string `json:"payloadSecretName,omitempty"`
+	// SecretMAC used when deciding whether the destination Secret should be
+	// synced.
+	SecretMAC string `json:"secretMAC,omitempty"`
+	// LastSyncMessages contains the most recent sync attempts, ordered from the
+	// oldest to the newest. Only a bounded number of entries are retained.
+	LastSyncMessages []SyncMessage `json:"lastSyncMessages,omitempty"`
+	// Conditions hold the latest observations of the resource's state. The
+	// DestinationConflict condition is set when the destination Secret exists,
+	// but is not owned by the resource.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// VaultWrappedSecret is the Schema for the vaultwrappedsecrets API
+type VaultWrappedSecret struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VaultWrappedSecretSpec   `json:"spec,omitempty"`
+	Status VaultWrappedSecretStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VaultWrappedSecretList contains a list of VaultWrappedSecret
+type VaultWrappedSecretList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VaultWrappedSecret `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VaultWrappedSecret{}, &VaultWrappedSecretList{})
+}
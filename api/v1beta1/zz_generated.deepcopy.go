@@ -8,10 +8,85 @@
 package v1beta1
 
 import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BasicAuth) DeepCopyInto(out *BasicAuth) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BasicAuth.
+func (in *BasicAuth) DeepCopy() *BasicAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(BasicAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterVaultAuth) DeepCopyInto(out *ClusterVaultAuth) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterVaultAuth.
+func (in *ClusterVaultAuth) DeepCopy() *ClusterVaultAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterVaultAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterVaultAuth) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterVaultAuthList) DeepCopyInto(out *ClusterVaultAuthList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterVaultAuth, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterVaultAuthList.
+func (in *ClusterVaultAuthList) DeepCopy() *ClusterVaultAuthList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterVaultAuthList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterVaultAuthList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Destination) DeepCopyInto(out *Destination) {
 	*out = *in
@@ -29,7 +104,39 @@ func (in *Destination) DeepCopyInto(out *Destination) {
 			(*out)[key] = val
 		}
 	}
+	if in.KeyMap != nil {
+		in, out := &in.KeyMap, &out.KeyMap
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Format != nil {
+		in, out := &in.Format, &out.Format
+		*out = new(DestinationFormat)
+		**out = **in
+	}
+	if in.MetadataConfigMap != nil {
+		in, out := &in.MetadataConfigMap, &out.MetadataConfigMap
+		*out = new(MetadataConfigMap)
+		**out = **in
+	}
 	in.Transformation.DeepCopyInto(&out.Transformation)
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Secretless != nil {
+		in, out := &in.Secretless, &out.Secretless
+		*out = new(SecretlessDelivery)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Destination.
@@ -42,6 +149,81 @@ func (in *Destination) DeepCopy() *Destination {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DestinationFormat) DeepCopyInto(out *DestinationFormat) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DestinationFormat.
+func (in *DestinationFormat) DeepCopy() *DestinationFormat {
+	if in == nil {
+		return nil
+	}
+	out := new(DestinationFormat)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DockerConfigJSON) DeepCopyInto(out *DockerConfigJSON) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DockerConfigJSON.
+func (in *DockerConfigJSON) DeepCopy() *DockerConfigJSON {
+	if in == nil {
+		return nil
+	}
+	out := new(DockerConfigJSON)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DotEnv) DeepCopyInto(out *DotEnv) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DotEnv.
+func (in *DotEnv) DeepCopy() *DotEnv {
+	if in == nil {
+		return nil
+	}
+	out := new(DotEnv)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Flatten) DeepCopyInto(out *Flatten) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Flatten.
+func (in *Flatten) DeepCopy() *Flatten {
+	if in == nil {
+		return nil
+	}
+	out := new(Flatten)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GenericSecretField) DeepCopyInto(out *GenericSecretField) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GenericSecretField.
+func (in *GenericSecretField) DeepCopy() *GenericSecretField {
+	if in == nil {
+		return nil
+	}
+	out := new(GenericSecretField)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HCPAuth) DeepCopyInto(out *HCPAuth) {
 	*out = *in
@@ -254,6 +436,20 @@ func (in *HCPVaultSecretsAppStatus) DeepCopyInto(out *HCPVaultSecretsAppStatus)
 		*out = make([]HVSDynamicStatus, len(*in))
 		copy(*out, *in)
 	}
+	if in.LastSyncMessages != nil {
+		in, out := &in.LastSyncMessages, &out.LastSyncMessages
+		*out = make([]SyncMessage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HCPVaultSecretsAppStatus.
@@ -316,6 +512,21 @@ func (in *HVSSyncConfig) DeepCopy() *HVSSyncConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HedgedReads) DeepCopyInto(out *HedgedReads) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HedgedReads.
+func (in *HedgedReads) DeepCopy() *HedgedReads {
+	if in == nil {
+		return nil
+	}
+	out := new(HedgedReads)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MergeStrategy) DeepCopyInto(out *MergeStrategy) {
 	*out = *in
@@ -331,6 +542,36 @@ func (in *MergeStrategy) DeepCopy() *MergeStrategy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetadataConfigMap) DeepCopyInto(out *MetadataConfigMap) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetadataConfigMap.
+func (in *MetadataConfigMap) DeepCopy() *MetadataConfigMap {
+	if in == nil {
+		return nil
+	}
+	out := new(MetadataConfigMap)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitMQConnectionURI) DeepCopyInto(out *RabbitMQConnectionURI) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQConnectionURI.
+func (in *RabbitMQConnectionURI) DeepCopy() *RabbitMQConnectionURI {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitMQConnectionURI)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RolloutRestartTarget) DeepCopyInto(out *RolloutRestartTarget) {
 	*out = *in
@@ -346,6 +587,102 @@ func (in *RolloutRestartTarget) DeepCopy() *RolloutRestartTarget {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretGroupSource) DeepCopyInto(out *SecretGroupSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretGroupSource.
+func (in *SecretGroupSource) DeepCopy() *SecretGroupSource {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretGroupSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretSyncLedger) DeepCopyInto(out *SecretSyncLedger) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretSyncLedger.
+func (in *SecretSyncLedger) DeepCopy() *SecretSyncLedger {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretSyncLedger)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SecretSyncLedger) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretSyncLedgerList) DeepCopyInto(out *SecretSyncLedgerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SecretSyncLedger, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretSyncLedgerList.
+func (in *SecretSyncLedgerList) DeepCopy() *SecretSyncLedgerList {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretSyncLedgerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SecretSyncLedgerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretSyncLedgerSpec) DeepCopyInto(out *SecretSyncLedgerSpec) {
+	*out = *in
+	out.Owner = in.Owner
+	if in.Entries != nil {
+		in, out := &in.Entries, &out.Entries
+		*out = make([]SyncLedgerEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretSyncLedgerSpec.
+func (in *SecretSyncLedgerSpec) DeepCopy() *SecretSyncLedgerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretSyncLedgerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretTransformation) DeepCopyInto(out *SecretTransformation) {
 	*out = *in
@@ -430,6 +767,13 @@ func (in *SecretTransformationSpec) DeepCopyInto(out *SecretTransformationSpec)
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Tests != nil {
+		in, out := &in.Tests, &out.Tests
+		*out = make([]SecretTransformationTest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretTransformationSpec.
@@ -463,12 +807,103 @@ func (in *SecretTransformationStatus) DeepCopy() *SecretTransformationStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SourceTemplate) DeepCopyInto(out *SourceTemplate) {
+func (in *SecretTransformationTest) DeepCopyInto(out *SecretTransformationTest) {
 	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourceTemplate.
-func (in *SourceTemplate) DeepCopy() *SourceTemplate {
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make(map[string]apiextensionsv1.JSON, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = make(map[string]apiextensionsv1.JSON, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NamespaceLabels != nil {
+		in, out := &in.NamespaceLabels, &out.NamespaceLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NamespaceAnnotations != nil {
+		in, out := &in.NamespaceAnnotations, &out.NamespaceAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Operator != nil {
+		in, out := &in.Operator, &out.Operator
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Expected != nil {
+		in, out := &in.Expected, &out.Expected
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretTransformationTest.
+func (in *SecretTransformationTest) DeepCopy() *SecretTransformationTest {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretTransformationTest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretlessDelivery) DeepCopyInto(out *SecretlessDelivery) {
+	*out = *in
+	if in.ServiceAccounts != nil {
+		in, out := &in.ServiceAccounts, &out.ServiceAccounts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretlessDelivery.
+func (in *SecretlessDelivery) DeepCopy() *SecretlessDelivery {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretlessDelivery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SourceTemplate) DeepCopyInto(out *SourceTemplate) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourceTemplate.
+func (in *SourceTemplate) DeepCopy() *SourceTemplate {
 	if in == nil {
 		return nil
 	}
@@ -495,6 +930,11 @@ func (in *StorageEncryption) DeepCopy() *StorageEncryption {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SyncConfig) DeepCopyInto(out *SyncConfig) {
 	*out = *in
+	if in.IgnoreFields != nil {
+		in, out := &in.IgnoreFields, &out.IgnoreFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncConfig.
@@ -507,6 +947,73 @@ func (in *SyncConfig) DeepCopy() *SyncConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncLedgerEntry) DeepCopyInto(out *SyncLedgerEntry) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncLedgerEntry.
+func (in *SyncLedgerEntry) DeepCopy() *SyncLedgerEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncLedgerEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncLedgerOwner) DeepCopyInto(out *SyncLedgerOwner) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncLedgerOwner.
+func (in *SyncLedgerOwner) DeepCopy() *SyncLedgerOwner {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncLedgerOwner)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncMessage) DeepCopyInto(out *SyncMessage) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncMessage.
+func (in *SyncMessage) DeepCopy() *SyncMessage {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncMessage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncProgress) DeepCopyInto(out *SyncProgress) {
+	*out = *in
+	if in.PendingSteps != nil {
+		in, out := &in.PendingSteps, &out.PendingSteps
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncProgress.
+func (in *SyncProgress) DeepCopy() *SyncProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Template) DeepCopyInto(out *Template) {
 	*out = *in
@@ -564,6 +1071,36 @@ func (in *Transformation) DeepCopyInto(out *Transformation) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.YAMLSplits != nil {
+		in, out := &in.YAMLSplits, &out.YAMLSplits
+		*out = make([]YAMLSplit, len(*in))
+		copy(*out, *in)
+	}
+	if in.DockerConfigJSON != nil {
+		in, out := &in.DockerConfigJSON, &out.DockerConfigJSON
+		*out = new(DockerConfigJSON)
+		**out = **in
+	}
+	if in.BasicAuth != nil {
+		in, out := &in.BasicAuth, &out.BasicAuth
+		*out = new(BasicAuth)
+		**out = **in
+	}
+	if in.Flatten != nil {
+		in, out := &in.Flatten, &out.Flatten
+		*out = new(Flatten)
+		**out = **in
+	}
+	if in.Base64Decode != nil {
+		in, out := &in.Base64Decode, &out.Base64Decode
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DotEnv != nil {
+		in, out := &in.DotEnv, &out.DotEnv
+		*out = new(DotEnv)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Transformation.
@@ -596,6 +1133,41 @@ func (in *TransformationRef) DeepCopy() *TransformationRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransitCiphertextSource) DeepCopyInto(out *TransitCiphertextSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransitCiphertextSource.
+func (in *TransitCiphertextSource) DeepCopy() *TransitCiphertextSource {
+	if in == nil {
+		return nil
+	}
+	out := new(TransitCiphertextSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransitPayload) DeepCopyInto(out *TransitPayload) {
+	*out = *in
+	if in.CiphertextFrom != nil {
+		in, out := &in.CiphertextFrom, &out.CiphertextFrom
+		*out = new(TransitCiphertextSource)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransitPayload.
+func (in *TransitPayload) DeepCopy() *TransitPayload {
+	if in == nil {
+		return nil
+	}
+	out := new(TransitPayload)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VaultAuth) DeepCopyInto(out *VaultAuth) {
 	*out = *in
@@ -653,6 +1225,36 @@ func (in *VaultAuthConfigAppRole) DeepCopy() *VaultAuthConfigAppRole {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultAuthConfigAzure) DeepCopyInto(out *VaultAuthConfigAzure) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultAuthConfigAzure.
+func (in *VaultAuthConfigAzure) DeepCopy() *VaultAuthConfigAzure {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultAuthConfigAzure)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultAuthConfigCert) DeepCopyInto(out *VaultAuthConfigCert) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultAuthConfigCert.
+func (in *VaultAuthConfigCert) DeepCopy() *VaultAuthConfigCert {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultAuthConfigCert)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VaultAuthConfigGCP) DeepCopyInto(out *VaultAuthConfigGCP) {
 	*out = *in
@@ -708,6 +1310,36 @@ func (in *VaultAuthConfigKubernetes) DeepCopy() *VaultAuthConfigKubernetes {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultAuthConfigToken) DeepCopyInto(out *VaultAuthConfigToken) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultAuthConfigToken.
+func (in *VaultAuthConfigToken) DeepCopy() *VaultAuthConfigToken {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultAuthConfigToken)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultAuthConfigUserPass) DeepCopyInto(out *VaultAuthConfigUserPass) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultAuthConfigUserPass.
+func (in *VaultAuthConfigUserPass) DeepCopy() *VaultAuthConfigUserPass {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultAuthConfigUserPass)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VaultAuthGlobal) DeepCopyInto(out *VaultAuthGlobal) {
 	*out = *in
@@ -795,6 +1427,66 @@ func (in *VaultAuthGlobalConfigAppRole) DeepCopy() *VaultAuthGlobalConfigAppRole
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultAuthGlobalConfigAzure) DeepCopyInto(out *VaultAuthGlobalConfigAzure) {
+	*out = *in
+	out.VaultAuthConfigAzure = in.VaultAuthConfigAzure
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultAuthGlobalConfigAzure.
+func (in *VaultAuthGlobalConfigAzure) DeepCopy() *VaultAuthGlobalConfigAzure {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultAuthGlobalConfigAzure)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultAuthGlobalConfigCert) DeepCopyInto(out *VaultAuthGlobalConfigCert) {
+	*out = *in
+	out.VaultAuthConfigCert = in.VaultAuthConfigCert
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultAuthGlobalConfigCert.
+func (in *VaultAuthGlobalConfigCert) DeepCopy() *VaultAuthGlobalConfigCert {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultAuthGlobalConfigCert)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VaultAuthGlobalConfigGCP) DeepCopyInto(out *VaultAuthGlobalConfigGCP) {
 	*out = *in
@@ -886,12 +1578,65 @@ func (in *VaultAuthGlobalConfigKubernetes) DeepCopy() *VaultAuthGlobalConfigKube
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VaultAuthGlobalList) DeepCopyInto(out *VaultAuthGlobalList) {
+func (in *VaultAuthGlobalConfigToken) DeepCopyInto(out *VaultAuthGlobalConfigToken) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
+	out.VaultAuthConfigToken = in.VaultAuthConfigToken
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultAuthGlobalConfigToken.
+func (in *VaultAuthGlobalConfigToken) DeepCopy() *VaultAuthGlobalConfigToken {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultAuthGlobalConfigToken)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultAuthGlobalConfigUserPass) DeepCopyInto(out *VaultAuthGlobalConfigUserPass) {
+	*out = *in
+	out.VaultAuthConfigUserPass = in.VaultAuthConfigUserPass
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultAuthGlobalConfigUserPass.
+func (in *VaultAuthGlobalConfigUserPass) DeepCopy() *VaultAuthGlobalConfigUserPass {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultAuthGlobalConfigUserPass)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultAuthGlobalList) DeepCopyInto(out *VaultAuthGlobalList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
 		*out = make([]VaultAuthGlobal, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
@@ -989,6 +1734,36 @@ func (in *VaultAuthGlobalSpec) DeepCopyInto(out *VaultAuthGlobalSpec) {
 		*out = new(VaultAuthGlobalConfigGCP)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(VaultAuthGlobalConfigAzure)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cert != nil {
+		in, out := &in.Cert, &out.Cert
+		*out = new(VaultAuthGlobalConfigCert)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Token != nil {
+		in, out := &in.Token, &out.Token
+		*out = new(VaultAuthGlobalConfigToken)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LDAP != nil {
+		in, out := &in.LDAP, &out.LDAP
+		*out = new(VaultAuthGlobalConfigUserPass)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UserPass != nil {
+		in, out := &in.UserPass, &out.UserPass
+		*out = new(VaultAuthGlobalConfigUserPass)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Okta != nil {
+		in, out := &in.Okta, &out.Okta
+		*out = new(VaultAuthGlobalConfigUserPass)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultAuthGlobalSpec.
@@ -1048,6 +1823,66 @@ func (in *VaultAuthList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultAuthLoginParams) DeepCopyInto(out *VaultAuthLoginParams) {
+	*out = *in
+	if in.Audience != nil {
+		in, out := &in.Audience, &out.Audience
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TokenPolicies != nil {
+		in, out := &in.TokenPolicies, &out.TokenPolicies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultAuthLoginParams.
+func (in *VaultAuthLoginParams) DeepCopy() *VaultAuthLoginParams {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultAuthLoginParams)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultAuthMFA) DeepCopyInto(out *VaultAuthMFA) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultAuthMFA.
+func (in *VaultAuthMFA) DeepCopy() *VaultAuthMFA {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultAuthMFA)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultAuthPolicyDriftCheck) DeepCopyInto(out *VaultAuthPolicyDriftCheck) {
+	*out = *in
+	if in.ExpectedPolicies != nil {
+		in, out := &in.ExpectedPolicies, &out.ExpectedPolicies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultAuthPolicyDriftCheck.
+func (in *VaultAuthPolicyDriftCheck) DeepCopy() *VaultAuthPolicyDriftCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultAuthPolicyDriftCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VaultAuthSpec) DeepCopyInto(out *VaultAuthSpec) {
 	*out = *in
@@ -1061,6 +1896,11 @@ func (in *VaultAuthSpec) DeepCopyInto(out *VaultAuthSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.AllowedNamespacesSelector != nil {
+		in, out := &in.AllowedNamespacesSelector, &out.AllowedNamespacesSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Params != nil {
 		in, out := &in.Params, &out.Params
 		*out = make(map[string]string, len(*in))
@@ -1068,6 +1908,11 @@ func (in *VaultAuthSpec) DeepCopyInto(out *VaultAuthSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.LoginParams != nil {
+		in, out := &in.LoginParams, &out.LoginParams
+		*out = new(VaultAuthLoginParams)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Headers != nil {
 		in, out := &in.Headers, &out.Headers
 		*out = make(map[string]string, len(*in))
@@ -1100,11 +1945,51 @@ func (in *VaultAuthSpec) DeepCopyInto(out *VaultAuthSpec) {
 		*out = new(VaultAuthConfigGCP)
 		**out = **in
 	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(VaultAuthConfigAzure)
+		**out = **in
+	}
+	if in.Cert != nil {
+		in, out := &in.Cert, &out.Cert
+		*out = new(VaultAuthConfigCert)
+		**out = **in
+	}
+	if in.Token != nil {
+		in, out := &in.Token, &out.Token
+		*out = new(VaultAuthConfigToken)
+		**out = **in
+	}
+	if in.LDAP != nil {
+		in, out := &in.LDAP, &out.LDAP
+		*out = new(VaultAuthConfigUserPass)
+		**out = **in
+	}
+	if in.UserPass != nil {
+		in, out := &in.UserPass, &out.UserPass
+		*out = new(VaultAuthConfigUserPass)
+		**out = **in
+	}
+	if in.Okta != nil {
+		in, out := &in.Okta, &out.Okta
+		*out = new(VaultAuthConfigUserPass)
+		**out = **in
+	}
 	if in.StorageEncryption != nil {
 		in, out := &in.StorageEncryption, &out.StorageEncryption
 		*out = new(StorageEncryption)
 		**out = **in
 	}
+	if in.PolicyDriftCheck != nil {
+		in, out := &in.PolicyDriftCheck, &out.PolicyDriftCheck
+		*out = new(VaultAuthPolicyDriftCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MFA != nil {
+		in, out := &in.MFA, &out.MFA
+		*out = new(VaultAuthMFA)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultAuthSpec.
@@ -1228,6 +2113,26 @@ func (in *VaultConnectionSpec) DeepCopyInto(out *VaultConnectionSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.AlternateAddresses != nil {
+		in, out := &in.AlternateAddresses, &out.AlternateAddresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.HedgedReads != nil {
+		in, out := &in.HedgedReads, &out.HedgedReads
+		*out = new(HedgedReads)
+		**out = **in
+	}
+	if in.AllowedNamespaces != nil {
+		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedNamespacesSelector != nil {
+		in, out := &in.AllowedNamespacesSelector, &out.AllowedNamespacesSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultConnectionSpec.
@@ -1260,13 +2165,109 @@ func (in *VaultConnectionStatus) DeepCopy() *VaultConnectionStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultConsulSecret) DeepCopyInto(out *VaultConsulSecret) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultConsulSecret.
+func (in *VaultConsulSecret) DeepCopy() *VaultConsulSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultConsulSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VaultConsulSecret) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultConsulSecretList) DeepCopyInto(out *VaultConsulSecretList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VaultConsulSecret, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultConsulSecretList.
+func (in *VaultConsulSecretList) DeepCopy() *VaultConsulSecretList {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultConsulSecretList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VaultConsulSecretList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultConsulSecretSpec) DeepCopyInto(out *VaultConsulSecretSpec) {
+	*out = *in
+	if in.RolloutRestartTargets != nil {
+		in, out := &in.RolloutRestartTargets, &out.RolloutRestartTargets
+		*out = make([]RolloutRestartTarget, len(*in))
+		copy(*out, *in)
+	}
+	in.Destination.DeepCopyInto(&out.Destination)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultConsulSecretSpec.
+func (in *VaultConsulSecretSpec) DeepCopy() *VaultConsulSecretSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultConsulSecretSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultConsulSecretStatus) DeepCopyInto(out *VaultConsulSecretStatus) {
+	*out = *in
+	in.VaultLeasedSecretStatus.DeepCopyInto(&out.VaultLeasedSecretStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultConsulSecretStatus.
+func (in *VaultConsulSecretStatus) DeepCopy() *VaultConsulSecretStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultConsulSecretStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VaultDynamicSecret) DeepCopyInto(out *VaultDynamicSecret) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultDynamicSecret.
@@ -1329,12 +2330,26 @@ func (in *VaultDynamicSecretSpec) DeepCopyInto(out *VaultDynamicSecretSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.RequestData != nil {
+		in, out := &in.RequestData, &out.RequestData
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.RolloutRestartTargets != nil {
 		in, out := &in.RolloutRestartTargets, &out.RolloutRestartTargets
 		*out = make([]RolloutRestartTarget, len(*in))
 		copy(*out, *in)
 	}
 	in.Destination.DeepCopyInto(&out.Destination)
+	if in.AdditionalDestinations != nil {
+		in, out := &in.AdditionalDestinations, &out.AdditionalDestinations
+		*out = make([]Destination, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultDynamicSecretSpec.
@@ -1353,6 +2368,20 @@ func (in *VaultDynamicSecretStatus) DeepCopyInto(out *VaultDynamicSecretStatus)
 	out.SecretLease = in.SecretLease
 	out.StaticCredsMetaData = in.StaticCredsMetaData
 	out.VaultClientMeta = in.VaultClientMeta
+	if in.LastSyncMessages != nil {
+		in, out := &in.LastSyncMessages, &out.LastSyncMessages
+		*out = make([]SyncMessage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultDynamicSecretStatus.
@@ -1366,7 +2395,7 @@ func (in *VaultDynamicSecretStatus) DeepCopy() *VaultDynamicSecretStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VaultPKISecret) DeepCopyInto(out *VaultPKISecret) {
+func (in *VaultGenericSecret) DeepCopyInto(out *VaultGenericSecret) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -1374,18 +2403,18 @@ func (in *VaultPKISecret) DeepCopyInto(out *VaultPKISecret) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultPKISecret.
-func (in *VaultPKISecret) DeepCopy() *VaultPKISecret {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultGenericSecret.
+func (in *VaultGenericSecret) DeepCopy() *VaultGenericSecret {
 	if in == nil {
 		return nil
 	}
-	out := new(VaultPKISecret)
+	out := new(VaultGenericSecret)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VaultPKISecret) DeepCopyObject() runtime.Object {
+func (in *VaultGenericSecret) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1393,31 +2422,31 @@ func (in *VaultPKISecret) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VaultPKISecretList) DeepCopyInto(out *VaultPKISecretList) {
+func (in *VaultGenericSecretList) DeepCopyInto(out *VaultGenericSecretList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]VaultPKISecret, len(*in))
+		*out = make([]VaultGenericSecret, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultPKISecretList.
-func (in *VaultPKISecretList) DeepCopy() *VaultPKISecretList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultGenericSecretList.
+func (in *VaultGenericSecretList) DeepCopy() *VaultGenericSecretList {
 	if in == nil {
 		return nil
 	}
-	out := new(VaultPKISecretList)
+	out := new(VaultGenericSecretList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VaultPKISecretList) DeepCopyObject() runtime.Object {
+func (in *VaultGenericSecretList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1425,122 +2454,1431 @@ func (in *VaultPKISecretList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VaultPKISecretSpec) DeepCopyInto(out *VaultPKISecretSpec) {
+func (in *VaultGenericSecretSpec) DeepCopyInto(out *VaultGenericSecretSpec) {
 	*out = *in
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make(map[string]apiextensionsv1.JSON, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]GenericSecretField, len(*in))
+		copy(*out, *in)
+	}
 	if in.RolloutRestartTargets != nil {
 		in, out := &in.RolloutRestartTargets, &out.RolloutRestartTargets
 		*out = make([]RolloutRestartTarget, len(*in))
 		copy(*out, *in)
 	}
 	in.Destination.DeepCopyInto(&out.Destination)
-	if in.AltNames != nil {
-		in, out := &in.AltNames, &out.AltNames
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultGenericSecretSpec.
+func (in *VaultGenericSecretSpec) DeepCopy() *VaultGenericSecretSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultGenericSecretSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultGenericSecretStatus) DeepCopyInto(out *VaultGenericSecretStatus) {
+	*out = *in
+	in.VaultLeasedSecretStatus.DeepCopyInto(&out.VaultLeasedSecretStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultGenericSecretStatus.
+func (in *VaultGenericSecretStatus) DeepCopy() *VaultGenericSecretStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultGenericSecretStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultIdentityToken) DeepCopyInto(out *VaultIdentityToken) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultIdentityToken.
+func (in *VaultIdentityToken) DeepCopy() *VaultIdentityToken {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultIdentityToken)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VaultIdentityToken) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultIdentityTokenList) DeepCopyInto(out *VaultIdentityTokenList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VaultIdentityToken, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultIdentityTokenList.
+func (in *VaultIdentityTokenList) DeepCopy() *VaultIdentityTokenList {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultIdentityTokenList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VaultIdentityTokenList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultIdentityTokenSpec) DeepCopyInto(out *VaultIdentityTokenSpec) {
+	*out = *in
+	if in.RolloutRestartTargets != nil {
+		in, out := &in.RolloutRestartTargets, &out.RolloutRestartTargets
+		*out = make([]RolloutRestartTarget, len(*in))
+		copy(*out, *in)
+	}
+	in.Destination.DeepCopyInto(&out.Destination)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultIdentityTokenSpec.
+func (in *VaultIdentityTokenSpec) DeepCopy() *VaultIdentityTokenSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultIdentityTokenSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultIdentityTokenStatus) DeepCopyInto(out *VaultIdentityTokenStatus) {
+	*out = *in
+	in.VaultLeasedSecretStatus.DeepCopyInto(&out.VaultLeasedSecretStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultIdentityTokenStatus.
+func (in *VaultIdentityTokenStatus) DeepCopy() *VaultIdentityTokenStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultIdentityTokenStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultKubernetesSecret) DeepCopyInto(out *VaultKubernetesSecret) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultKubernetesSecret.
+func (in *VaultKubernetesSecret) DeepCopy() *VaultKubernetesSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultKubernetesSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VaultKubernetesSecret) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultKubernetesSecretList) DeepCopyInto(out *VaultKubernetesSecretList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VaultKubernetesSecret, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultKubernetesSecretList.
+func (in *VaultKubernetesSecretList) DeepCopy() *VaultKubernetesSecretList {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultKubernetesSecretList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VaultKubernetesSecretList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultKubernetesSecretSpec) DeepCopyInto(out *VaultKubernetesSecretSpec) {
+	*out = *in
+	if in.Audiences != nil {
+		in, out := &in.Audiences, &out.Audiences
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RolloutRestartTargets != nil {
+		in, out := &in.RolloutRestartTargets, &out.RolloutRestartTargets
+		*out = make([]RolloutRestartTarget, len(*in))
+		copy(*out, *in)
+	}
+	in.Destination.DeepCopyInto(&out.Destination)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultKubernetesSecretSpec.
+func (in *VaultKubernetesSecretSpec) DeepCopy() *VaultKubernetesSecretSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultKubernetesSecretSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultKubernetesSecretStatus) DeepCopyInto(out *VaultKubernetesSecretStatus) {
+	*out = *in
+	in.VaultLeasedSecretStatus.DeepCopyInto(&out.VaultLeasedSecretStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultKubernetesSecretStatus.
+func (in *VaultKubernetesSecretStatus) DeepCopy() *VaultKubernetesSecretStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultKubernetesSecretStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultLDAPSecret) DeepCopyInto(out *VaultLDAPSecret) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultLDAPSecret.
+func (in *VaultLDAPSecret) DeepCopy() *VaultLDAPSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultLDAPSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VaultLDAPSecret) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultLDAPSecretList) DeepCopyInto(out *VaultLDAPSecretList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VaultLDAPSecret, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultLDAPSecretList.
+func (in *VaultLDAPSecretList) DeepCopy() *VaultLDAPSecretList {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultLDAPSecretList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VaultLDAPSecretList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultLDAPSecretSpec) DeepCopyInto(out *VaultLDAPSecretSpec) {
+	*out = *in
+	if in.RolloutRestartTargets != nil {
+		in, out := &in.RolloutRestartTargets, &out.RolloutRestartTargets
+		*out = make([]RolloutRestartTarget, len(*in))
+		copy(*out, *in)
+	}
+	in.Destination.DeepCopyInto(&out.Destination)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultLDAPSecretSpec.
+func (in *VaultLDAPSecretSpec) DeepCopy() *VaultLDAPSecretSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultLDAPSecretSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultLDAPSecretStatus) DeepCopyInto(out *VaultLDAPSecretStatus) {
+	*out = *in
+	in.VaultLeasedSecretStatus.DeepCopyInto(&out.VaultLeasedSecretStatus)
+	out.StaticCredsMetaData = in.StaticCredsMetaData
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultLDAPSecretStatus.
+func (in *VaultLDAPSecretStatus) DeepCopy() *VaultLDAPSecretStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultLDAPSecretStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultLeaseAssignment) DeepCopyInto(out *VaultLeaseAssignment) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultLeaseAssignment.
+func (in *VaultLeaseAssignment) DeepCopy() *VaultLeaseAssignment {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultLeaseAssignment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VaultLeaseAssignment) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultLeaseAssignmentList) DeepCopyInto(out *VaultLeaseAssignmentList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VaultLeaseAssignment, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultLeaseAssignmentList.
+func (in *VaultLeaseAssignmentList) DeepCopy() *VaultLeaseAssignmentList {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultLeaseAssignmentList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VaultLeaseAssignmentList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultLeaseAssignmentSpec) DeepCopyInto(out *VaultLeaseAssignmentSpec) {
+	*out = *in
+	in.RenewAfter.DeepCopyInto(&out.RenewAfter)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultLeaseAssignmentSpec.
+func (in *VaultLeaseAssignmentSpec) DeepCopy() *VaultLeaseAssignmentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultLeaseAssignmentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultLeaseAssignmentStatus) DeepCopyInto(out *VaultLeaseAssignmentStatus) {
+	*out = *in
+	if in.RenewedAt != nil {
+		in, out := &in.RenewedAt, &out.RenewedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultLeaseAssignmentStatus.
+func (in *VaultLeaseAssignmentStatus) DeepCopy() *VaultLeaseAssignmentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultLeaseAssignmentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultLeasedSecretStatus) DeepCopyInto(out *VaultLeasedSecretStatus) {
+	*out = *in
+	out.SecretLease = in.SecretLease
+	out.VaultClientMeta = in.VaultClientMeta
+	if in.LastSyncMessages != nil {
+		in, out := &in.LastSyncMessages, &out.LastSyncMessages
+		*out = make([]SyncMessage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultLeasedSecretStatus.
+func (in *VaultLeasedSecretStatus) DeepCopy() *VaultLeasedSecretStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultLeasedSecretStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultMongoDBAtlasSecret) DeepCopyInto(out *VaultMongoDBAtlasSecret) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultMongoDBAtlasSecret.
+func (in *VaultMongoDBAtlasSecret) DeepCopy() *VaultMongoDBAtlasSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultMongoDBAtlasSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VaultMongoDBAtlasSecret) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultMongoDBAtlasSecretList) DeepCopyInto(out *VaultMongoDBAtlasSecretList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VaultMongoDBAtlasSecret, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultMongoDBAtlasSecretList.
+func (in *VaultMongoDBAtlasSecretList) DeepCopy() *VaultMongoDBAtlasSecretList {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultMongoDBAtlasSecretList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VaultMongoDBAtlasSecretList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultMongoDBAtlasSecretSpec) DeepCopyInto(out *VaultMongoDBAtlasSecretSpec) {
+	*out = *in
+	if in.RolloutRestartTargets != nil {
+		in, out := &in.RolloutRestartTargets, &out.RolloutRestartTargets
+		*out = make([]RolloutRestartTarget, len(*in))
+		copy(*out, *in)
+	}
+	in.Destination.DeepCopyInto(&out.Destination)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultMongoDBAtlasSecretSpec.
+func (in *VaultMongoDBAtlasSecretSpec) DeepCopy() *VaultMongoDBAtlasSecretSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultMongoDBAtlasSecretSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultMongoDBAtlasSecretStatus) DeepCopyInto(out *VaultMongoDBAtlasSecretStatus) {
+	*out = *in
+	in.VaultLeasedSecretStatus.DeepCopyInto(&out.VaultLeasedSecretStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultMongoDBAtlasSecretStatus.
+func (in *VaultMongoDBAtlasSecretStatus) DeepCopy() *VaultMongoDBAtlasSecretStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultMongoDBAtlasSecretStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultNomadSecret) DeepCopyInto(out *VaultNomadSecret) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultNomadSecret.
+func (in *VaultNomadSecret) DeepCopy() *VaultNomadSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultNomadSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VaultNomadSecret) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultNomadSecretList) DeepCopyInto(out *VaultNomadSecretList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VaultNomadSecret, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultNomadSecretList.
+func (in *VaultNomadSecretList) DeepCopy() *VaultNomadSecretList {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultNomadSecretList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VaultNomadSecretList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultNomadSecretSpec) DeepCopyInto(out *VaultNomadSecretSpec) {
+	*out = *in
+	if in.RolloutRestartTargets != nil {
+		in, out := &in.RolloutRestartTargets, &out.RolloutRestartTargets
+		*out = make([]RolloutRestartTarget, len(*in))
+		copy(*out, *in)
+	}
+	in.Destination.DeepCopyInto(&out.Destination)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultNomadSecretSpec.
+func (in *VaultNomadSecretSpec) DeepCopy() *VaultNomadSecretSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultNomadSecretSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultNomadSecretStatus) DeepCopyInto(out *VaultNomadSecretStatus) {
+	*out = *in
+	in.VaultLeasedSecretStatus.DeepCopyInto(&out.VaultLeasedSecretStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultNomadSecretStatus.
+func (in *VaultNomadSecretStatus) DeepCopy() *VaultNomadSecretStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultNomadSecretStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultPKISecret) DeepCopyInto(out *VaultPKISecret) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultPKISecret.
+func (in *VaultPKISecret) DeepCopy() *VaultPKISecret {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultPKISecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VaultPKISecret) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultPKISecretEncoding) DeepCopyInto(out *VaultPKISecretEncoding) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultPKISecretEncoding.
+func (in *VaultPKISecretEncoding) DeepCopy() *VaultPKISecretEncoding {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultPKISecretEncoding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultPKISecretJKS) DeepCopyInto(out *VaultPKISecretJKS) {
+	*out = *in
+	if in.PasswordSource != nil {
+		in, out := &in.PasswordSource, &out.PasswordSource
+		*out = new(VaultPKISecretPKCS12PasswordSource)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultPKISecretJKS.
+func (in *VaultPKISecretJKS) DeepCopy() *VaultPKISecretJKS {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultPKISecretJKS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultPKISecretList) DeepCopyInto(out *VaultPKISecretList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VaultPKISecret, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultPKISecretList.
+func (in *VaultPKISecretList) DeepCopy() *VaultPKISecretList {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultPKISecretList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VaultPKISecretList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultPKISecretPKCS12) DeepCopyInto(out *VaultPKISecretPKCS12) {
+	*out = *in
+	if in.PasswordSource != nil {
+		in, out := &in.PasswordSource, &out.PasswordSource
+		*out = new(VaultPKISecretPKCS12PasswordSource)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultPKISecretPKCS12.
+func (in *VaultPKISecretPKCS12) DeepCopy() *VaultPKISecretPKCS12 {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultPKISecretPKCS12)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultPKISecretPKCS12PasswordSource) DeepCopyInto(out *VaultPKISecretPKCS12PasswordSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultPKISecretPKCS12PasswordSource.
+func (in *VaultPKISecretPKCS12PasswordSource) DeepCopy() *VaultPKISecretPKCS12PasswordSource {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultPKISecretPKCS12PasswordSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultPKISecretSpec) DeepCopyInto(out *VaultPKISecretSpec) {
+	*out = *in
+	if in.RolloutRestartTargets != nil {
+		in, out := &in.RolloutRestartTargets, &out.RolloutRestartTargets
+		*out = make([]RolloutRestartTarget, len(*in))
+		copy(*out, *in)
+	}
+	in.Destination.DeepCopyInto(&out.Destination)
+	if in.AltNames != nil {
+		in, out := &in.AltNames, &out.AltNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.IPSans != nil {
 		in, out := &in.IPSans, &out.IPSans
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.URISans != nil {
-		in, out := &in.URISans, &out.URISans
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.URISans != nil {
+		in, out := &in.URISans, &out.URISans
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OtherSans != nil {
+		in, out := &in.OtherSans, &out.OtherSans
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.UserIDs != nil {
+		in, out := &in.UserIDs, &out.UserIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PKCS12 != nil {
+		in, out := &in.PKCS12, &out.PKCS12
+		*out = new(VaultPKISecretPKCS12)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JKS != nil {
+		in, out := &in.JKS, &out.JKS
+		*out = new(VaultPKISecretJKS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Encodings != nil {
+		in, out := &in.Encodings, &out.Encodings
+		*out = make([]VaultPKISecretEncoding, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultPKISecretSpec.
+func (in *VaultPKISecretSpec) DeepCopy() *VaultPKISecretSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultPKISecretSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultPKISecretStatus) DeepCopyInto(out *VaultPKISecretStatus) {
+	*out = *in
+	if in.Valid != nil {
+		in, out := &in.Valid, &out.Valid
+		*out = new(bool)
+		**out = **in
+	}
+	if in.LastSyncMessages != nil {
+		in, out := &in.LastSyncMessages, &out.LastSyncMessages
+		*out = make([]SyncMessage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultPKISecretStatus.
+func (in *VaultPKISecretStatus) DeepCopy() *VaultPKISecretStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultPKISecretStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultRabbitMQSecret) DeepCopyInto(out *VaultRabbitMQSecret) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultRabbitMQSecret.
+func (in *VaultRabbitMQSecret) DeepCopy() *VaultRabbitMQSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultRabbitMQSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VaultRabbitMQSecret) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultRabbitMQSecretList) DeepCopyInto(out *VaultRabbitMQSecretList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VaultRabbitMQSecret, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultRabbitMQSecretList.
+func (in *VaultRabbitMQSecretList) DeepCopy() *VaultRabbitMQSecretList {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultRabbitMQSecretList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VaultRabbitMQSecretList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultRabbitMQSecretSpec) DeepCopyInto(out *VaultRabbitMQSecretSpec) {
+	*out = *in
+	if in.RolloutRestartTargets != nil {
+		in, out := &in.RolloutRestartTargets, &out.RolloutRestartTargets
+		*out = make([]RolloutRestartTarget, len(*in))
+		copy(*out, *in)
+	}
+	if in.ConnectionURI != nil {
+		in, out := &in.ConnectionURI, &out.ConnectionURI
+		*out = new(RabbitMQConnectionURI)
+		**out = **in
+	}
+	in.Destination.DeepCopyInto(&out.Destination)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultRabbitMQSecretSpec.
+func (in *VaultRabbitMQSecretSpec) DeepCopy() *VaultRabbitMQSecretSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultRabbitMQSecretSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultRabbitMQSecretStatus) DeepCopyInto(out *VaultRabbitMQSecretStatus) {
+	*out = *in
+	in.VaultLeasedSecretStatus.DeepCopyInto(&out.VaultLeasedSecretStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultRabbitMQSecretStatus.
+func (in *VaultRabbitMQSecretStatus) DeepCopy() *VaultRabbitMQSecretStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultRabbitMQSecretStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultSecretGroup) DeepCopyInto(out *VaultSecretGroup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultSecretGroup.
+func (in *VaultSecretGroup) DeepCopy() *VaultSecretGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultSecretGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VaultSecretGroup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultSecretGroupList) DeepCopyInto(out *VaultSecretGroupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VaultSecretGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultSecretGroupList.
+func (in *VaultSecretGroupList) DeepCopy() *VaultSecretGroupList {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultSecretGroupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VaultSecretGroupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultSecretGroupSpec) DeepCopyInto(out *VaultSecretGroupSpec) {
+	*out = *in
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]SecretGroupSource, len(*in))
+		copy(*out, *in)
+	}
+	if in.RolloutRestartTargets != nil {
+		in, out := &in.RolloutRestartTargets, &out.RolloutRestartTargets
+		*out = make([]RolloutRestartTarget, len(*in))
+		copy(*out, *in)
+	}
+	in.Destination.DeepCopyInto(&out.Destination)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultSecretGroupSpec.
+func (in *VaultSecretGroupSpec) DeepCopy() *VaultSecretGroupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultSecretGroupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultSecretGroupStatus) DeepCopyInto(out *VaultSecretGroupStatus) {
+	*out = *in
+	if in.LastSyncMessages != nil {
+		in, out := &in.LastSyncMessages, &out.LastSyncMessages
+		*out = make([]SyncMessage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultSecretGroupStatus.
+func (in *VaultSecretGroupStatus) DeepCopy() *VaultSecretGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultSecretGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultSecretLease) DeepCopyInto(out *VaultSecretLease) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultSecretLease.
+func (in *VaultSecretLease) DeepCopy() *VaultSecretLease {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultSecretLease)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultStaticCredsMetaData) DeepCopyInto(out *VaultStaticCredsMetaData) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultStaticCredsMetaData.
+func (in *VaultStaticCredsMetaData) DeepCopy() *VaultStaticCredsMetaData {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultStaticCredsMetaData)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultStaticSecret) DeepCopyInto(out *VaultStaticSecret) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultStaticSecret.
+func (in *VaultStaticSecret) DeepCopy() *VaultStaticSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultStaticSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VaultStaticSecret) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultStaticSecretList) DeepCopyInto(out *VaultStaticSecretList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VaultStaticSecret, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultStaticSecretList.
+func (in *VaultStaticSecretList) DeepCopy() *VaultStaticSecretList {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultStaticSecretList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VaultStaticSecretList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultStaticSecretPrefix) DeepCopyInto(out *VaultStaticSecretPrefix) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultStaticSecretPrefix.
+func (in *VaultStaticSecretPrefix) DeepCopy() *VaultStaticSecretPrefix {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultStaticSecretPrefix)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultStaticSecretSeed) DeepCopyInto(out *VaultStaticSecretSeed) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultStaticSecretSeed.
+func (in *VaultStaticSecretSeed) DeepCopy() *VaultStaticSecretSeed {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultStaticSecretSeed)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultStaticSecretSpec) DeepCopyInto(out *VaultStaticSecretSpec) {
+	*out = *in
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Prefix != nil {
+		in, out := &in.Prefix, &out.Prefix
+		*out = new(VaultStaticSecretPrefix)
+		**out = **in
+	}
+	if in.HMACSecretData != nil {
+		in, out := &in.HMACSecretData, &out.HMACSecretData
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RolloutRestartTargets != nil {
+		in, out := &in.RolloutRestartTargets, &out.RolloutRestartTargets
+		*out = make([]RolloutRestartTarget, len(*in))
+		copy(*out, *in)
+	}
+	in.Destination.DeepCopyInto(&out.Destination)
+	if in.AdditionalDestinations != nil {
+		in, out := &in.AdditionalDestinations, &out.AdditionalDestinations
+		*out = make([]Destination, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SyncConfig != nil {
+		in, out := &in.SyncConfig, &out.SyncConfig
+		*out = new(SyncConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Seed != nil {
+		in, out := &in.Seed, &out.Seed
+		*out = new(VaultStaticSecretSeed)
+		**out = **in
 	}
-	if in.OtherSans != nil {
-		in, out := &in.OtherSans, &out.OtherSans
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultStaticSecretSpec.
+func (in *VaultStaticSecretSpec) DeepCopy() *VaultStaticSecretSpec {
+	if in == nil {
+		return nil
 	}
-	if in.UserIDs != nil {
-		in, out := &in.UserIDs, &out.UserIDs
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	out := new(VaultStaticSecretSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultStaticSecretStatus) DeepCopyInto(out *VaultStaticSecretStatus) {
+	*out = *in
+	if in.LastSyncMessages != nil {
+		in, out := &in.LastSyncMessages, &out.LastSyncMessages
+		*out = make([]SyncMessage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StaleSince != nil {
+		in, out := &in.StaleSince, &out.StaleSince
+		*out = (*in).DeepCopy()
+	}
+	if in.SyncProgress != nil {
+		in, out := &in.SyncProgress, &out.SyncProgress
+		*out = new(SyncProgress)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultPKISecretSpec.
-func (in *VaultPKISecretSpec) DeepCopy() *VaultPKISecretSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultStaticSecretStatus.
+func (in *VaultStaticSecretStatus) DeepCopy() *VaultStaticSecretStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VaultPKISecretSpec)
+	out := new(VaultStaticSecretStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VaultPKISecretStatus) DeepCopyInto(out *VaultPKISecretStatus) {
+func (in *VaultTerraformCloudSecret) DeepCopyInto(out *VaultTerraformCloudSecret) {
 	*out = *in
-	if in.Valid != nil {
-		in, out := &in.Valid, &out.Valid
-		*out = new(bool)
-		**out = **in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultTerraformCloudSecret.
+func (in *VaultTerraformCloudSecret) DeepCopy() *VaultTerraformCloudSecret {
+	if in == nil {
+		return nil
 	}
+	out := new(VaultTerraformCloudSecret)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultPKISecretStatus.
-func (in *VaultPKISecretStatus) DeepCopy() *VaultPKISecretStatus {
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VaultTerraformCloudSecret) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultTerraformCloudSecretList) DeepCopyInto(out *VaultTerraformCloudSecretList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VaultTerraformCloudSecret, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultTerraformCloudSecretList.
+func (in *VaultTerraformCloudSecretList) DeepCopy() *VaultTerraformCloudSecretList {
 	if in == nil {
 		return nil
 	}
-	out := new(VaultPKISecretStatus)
+	out := new(VaultTerraformCloudSecretList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VaultTerraformCloudSecretList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VaultSecretLease) DeepCopyInto(out *VaultSecretLease) {
+func (in *VaultTerraformCloudSecretSpec) DeepCopyInto(out *VaultTerraformCloudSecretSpec) {
 	*out = *in
+	if in.RolloutRestartTargets != nil {
+		in, out := &in.RolloutRestartTargets, &out.RolloutRestartTargets
+		*out = make([]RolloutRestartTarget, len(*in))
+		copy(*out, *in)
+	}
+	in.Destination.DeepCopyInto(&out.Destination)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultSecretLease.
-func (in *VaultSecretLease) DeepCopy() *VaultSecretLease {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultTerraformCloudSecretSpec.
+func (in *VaultTerraformCloudSecretSpec) DeepCopy() *VaultTerraformCloudSecretSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(VaultSecretLease)
+	out := new(VaultTerraformCloudSecretSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VaultStaticCredsMetaData) DeepCopyInto(out *VaultStaticCredsMetaData) {
+func (in *VaultTerraformCloudSecretStatus) DeepCopyInto(out *VaultTerraformCloudSecretStatus) {
 	*out = *in
+	in.VaultLeasedSecretStatus.DeepCopyInto(&out.VaultLeasedSecretStatus)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultStaticCredsMetaData.
-func (in *VaultStaticCredsMetaData) DeepCopy() *VaultStaticCredsMetaData {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultTerraformCloudSecretStatus.
+func (in *VaultTerraformCloudSecretStatus) DeepCopy() *VaultTerraformCloudSecretStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VaultStaticCredsMetaData)
+	out := new(VaultTerraformCloudSecretStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VaultStaticSecret) DeepCopyInto(out *VaultStaticSecret) {
+func (in *VaultTransitSecret) DeepCopyInto(out *VaultTransitSecret) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultStaticSecret.
-func (in *VaultStaticSecret) DeepCopy() *VaultStaticSecret {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultTransitSecret.
+func (in *VaultTransitSecret) DeepCopy() *VaultTransitSecret {
 	if in == nil {
 		return nil
 	}
-	out := new(VaultStaticSecret)
+	out := new(VaultTransitSecret)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VaultStaticSecret) DeepCopyObject() runtime.Object {
+func (in *VaultTransitSecret) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1548,31 +3886,31 @@ func (in *VaultStaticSecret) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VaultStaticSecretList) DeepCopyInto(out *VaultStaticSecretList) {
+func (in *VaultTransitSecretList) DeepCopyInto(out *VaultTransitSecretList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]VaultStaticSecret, len(*in))
+		*out = make([]VaultTransitSecret, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultStaticSecretList.
-func (in *VaultStaticSecretList) DeepCopy() *VaultStaticSecretList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultTransitSecretList.
+func (in *VaultTransitSecretList) DeepCopy() *VaultTransitSecretList {
 	if in == nil {
 		return nil
 	}
-	out := new(VaultStaticSecretList)
+	out := new(VaultTransitSecretList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VaultStaticSecretList) DeepCopyObject() runtime.Object {
+func (in *VaultTransitSecretList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1580,12 +3918,14 @@ func (in *VaultStaticSecretList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VaultStaticSecretSpec) DeepCopyInto(out *VaultStaticSecretSpec) {
+func (in *VaultTransitSecretSpec) DeepCopyInto(out *VaultTransitSecretSpec) {
 	*out = *in
-	if in.HMACSecretData != nil {
-		in, out := &in.HMACSecretData, &out.HMACSecretData
-		*out = new(bool)
-		**out = **in
+	if in.Payloads != nil {
+		in, out := &in.Payloads, &out.Payloads
+		*out = make([]TransitPayload, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.RolloutRestartTargets != nil {
 		in, out := &in.RolloutRestartTargets, &out.RolloutRestartTargets
@@ -1593,34 +3933,183 @@ func (in *VaultStaticSecretSpec) DeepCopyInto(out *VaultStaticSecretSpec) {
 		copy(*out, *in)
 	}
 	in.Destination.DeepCopyInto(&out.Destination)
-	if in.SyncConfig != nil {
-		in, out := &in.SyncConfig, &out.SyncConfig
-		*out = new(SyncConfig)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultTransitSecretSpec.
+func (in *VaultTransitSecretSpec) DeepCopy() *VaultTransitSecretSpec {
+	if in == nil {
+		return nil
 	}
+	out := new(VaultTransitSecretSpec)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultStaticSecretSpec.
-func (in *VaultStaticSecretSpec) DeepCopy() *VaultStaticSecretSpec {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultTransitSecretStatus) DeepCopyInto(out *VaultTransitSecretStatus) {
+	*out = *in
+	if in.LastSyncMessages != nil {
+		in, out := &in.LastSyncMessages, &out.LastSyncMessages
+		*out = make([]SyncMessage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultTransitSecretStatus.
+func (in *VaultTransitSecretStatus) DeepCopy() *VaultTransitSecretStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VaultStaticSecretSpec)
+	out := new(VaultTransitSecretStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VaultStaticSecretStatus) DeepCopyInto(out *VaultStaticSecretStatus) {
+func (in *VaultWrappedSecret) DeepCopyInto(out *VaultWrappedSecret) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultStaticSecretStatus.
-func (in *VaultStaticSecretStatus) DeepCopy() *VaultStaticSecretStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultWrappedSecret.
+func (in *VaultWrappedSecret) DeepCopy() *VaultWrappedSecret {
 	if in == nil {
 		return nil
 	}
-	out := new(VaultStaticSecretStatus)
+	out := new(VaultWrappedSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VaultWrappedSecret) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultWrappedSecretList) DeepCopyInto(out *VaultWrappedSecretList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VaultWrappedSecret, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultWrappedSecretList.
+func (in *VaultWrappedSecretList) DeepCopy() *VaultWrappedSecretList {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultWrappedSecretList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VaultWrappedSecretList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultWrappedSecretSpec) DeepCopyInto(out *VaultWrappedSecretSpec) {
+	*out = *in
+	out.WrappingTokenRef = in.WrappingTokenRef
+	if in.RolloutRestartTargets != nil {
+		in, out := &in.RolloutRestartTargets, &out.RolloutRestartTargets
+		*out = make([]RolloutRestartTarget, len(*in))
+		copy(*out, *in)
+	}
+	in.Destination.DeepCopyInto(&out.Destination)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultWrappedSecretSpec.
+func (in *VaultWrappedSecretSpec) DeepCopy() *VaultWrappedSecretSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultWrappedSecretSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultWrappedSecretStatus) DeepCopyInto(out *VaultWrappedSecretStatus) {
+	*out = *in
+	if in.LastSyncMessages != nil {
+		in, out := &in.LastSyncMessages, &out.LastSyncMessages
+		*out = make([]SyncMessage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultWrappedSecretStatus.
+func (in *VaultWrappedSecretStatus) DeepCopy() *VaultWrappedSecretStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultWrappedSecretStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WrappingTokenSource) DeepCopyInto(out *WrappingTokenSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WrappingTokenSource.
+func (in *WrappingTokenSource) DeepCopy() *WrappingTokenSource {
+	if in == nil {
+		return nil
+	}
+	out := new(WrappingTokenSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *YAMLSplit) DeepCopyInto(out *YAMLSplit) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new YAMLSplit.
+func (in *YAMLSplit) DeepCopy() *YAMLSplit {
+	if in == nil {
+		return nil
+	}
+	out := new(YAMLSplit)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -142,6 +142,49 @@ VaultAuthMethod Spec
     {{- if $cur.gcp.projectID }}
     projectID: {{ $cur.gcp.projectID }}
     {{- end }}
+  {{- else if eq $cur.method "azure" }}
+  azure:
+    role: {{ $cur.azure.role }}
+    {{- if $cur.azure.workloadIdentityServiceAccount }}
+    workloadIdentityServiceAccount: {{ $cur.azure.workloadIdentityServiceAccount }}
+    {{- end }}
+    {{- if $cur.azure.resource }}
+    resource: {{ $cur.azure.resource }}
+    {{- end }}
+    {{- if $cur.azure.tenantID }}
+    tenantID: {{ $cur.azure.tenantID }}
+    {{- end }}
+    {{- if $cur.azure.clientID }}
+    clientID: {{ $cur.azure.clientID }}
+    {{- end }}
+    {{- if $cur.azure.subscriptionID }}
+    subscriptionID: {{ $cur.azure.subscriptionID }}
+    {{- end }}
+    {{- if $cur.azure.resourceGroupName }}
+    resourceGroupName: {{ $cur.azure.resourceGroupName }}
+    {{- end }}
+    {{- if $cur.azure.vmName }}
+    vmName: {{ $cur.azure.vmName }}
+    {{- end }}
+    {{- if $cur.azure.vmssName }}
+    vmssName: {{ $cur.azure.vmssName }}
+    {{- end }}
+  {{- else if eq $cur.method "cert" }}
+  cert:
+    secretRef: {{ $cur.cert.secretRef }}
+    {{- if $cur.cert.name }}
+    name: {{ $cur.cert.name }}
+    {{- end }}
+  {{- else if eq $cur.method "token" }}
+  token:
+    secretRef: {{ $cur.token.secretRef }}
+  {{- else if has $cur.method (list "ldap" "userpass" "okta") }}
+  {{- $userPass := get $cur $cur.method }}
+  {{ $cur.method }}:
+    secretRef: {{ $userPass.secretRef }}
+    {{- if $userPass.username }}
+    username: {{ $userPass.username }}
+    {{- end }}
   {{- end }}
 {{- end}}
 
@@ -189,6 +232,28 @@ globalVaultAuthOptions configures the manager's --global-vault-auth-options flag
 {{- end -}}
 {{- end -}}
 
+{{/*
+featureGates configures the manager's --feature-gates flag. The StandbyRenewals
+gate is enabled by controller.manager.standbyRenewals, unless it is set in
+controller.manager.featureGates.
+*/}}
+{{- define "vso.featureGates" -}}
+{{- $gates := dict -}}
+{{- if .Values.controller.manager.standbyRenewals -}}
+{{- $_ := set $gates "StandbyRenewals" true -}}
+{{- end -}}
+{{- range $k, $v := .Values.controller.manager.featureGates -}}
+{{- $_ := set $gates $k $v -}}
+{{- end -}}
+{{- $opts := list -}}
+{{- range $k, $v := $gates -}}
+{{- $opts = mustAppend $opts (printf "%s=%t" $k $v) -}}
+{{- end -}}
+{{- if $opts -}}
+{{- $opts | join "," -}}
+{{- end -}}
+{{- end -}}
+
 {{/*
 backoffOnSecretSourceError provides the backoff options for the manager when a
 secret source error occurs.
@@ -0,0 +1,175 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// The secretless agent fetches the data synced by a syncable secret resource,
+// configured with spec.destination.secretless, directly from the Operator, and
+// writes it out to files in a directory shared with the workload's containers.
+// Run it with --once as an init container, and without it as a sidecar to pick
+// up secret rotations.
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/hashicorp/vault-secrets-operator/secretless"
+)
+
+const serviceAccountNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+func main() {
+	var serverURL string
+	var namespace string
+	var name string
+	var dir string
+	var tokenFile string
+	var caFile string
+	var certFile string
+	var keyFile string
+	var interval time.Duration
+	var once bool
+
+	flag.StringVar(&serverURL, "server-url", os.Getenv("VSO_SECRETLESS_SERVER_URL"),
+		"The base URL of the Operator's secretless server. "+
+			"Also set from environment variable VSO_SECRETLESS_SERVER_URL.")
+	flag.StringVar(&namespace, "namespace", os.Getenv("POD_NAMESPACE"),
+		"The namespace of the syncable secret resource, defaults to the Pod's namespace. "+
+			"Also set from environment variable POD_NAMESPACE.")
+	flag.StringVar(&name, "name", "",
+		"The destination name of the syncable secret resource.")
+	flag.StringVar(&dir, "dir", "/vso/secrets",
+		"The directory that the data files are written to.")
+	flag.StringVar(&tokenFile, "token-file", "/var/run/secrets/vso/token",
+		"The projected ServiceAccount token file, its audience must match the Operator's "+
+			"--secretless-token-audience. Set to the empty string to authenticate with "+
+			"--cert-file and --key-file only.")
+	flag.StringVar(&caFile, "ca-file", "",
+		"The CA certificate file used to verify the Operator's server certificate.")
+	flag.StringVar(&certFile, "cert-file", "",
+		"The client certificate file (X.509 SVID) for SPIFFE authentication.")
+	flag.StringVar(&keyFile, "key-file", "",
+		"The client key file for SPIFFE authentication.")
+	flag.DurationVar(&interval, "interval", time.Second*30,
+		"The interval between syncs.")
+	flag.BoolVar(&once, "once", false,
+		"Exit after the data has been written once.")
+
+	opts := zap.Options{}
+	opts.BindFlags(flag.CommandLine)
+	flag.Parse()
+
+	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+	logger := ctrl.Log.WithName("secretless-agent")
+
+	if namespace == "" {
+		if b, err := os.ReadFile(serviceAccountNamespaceFile); err == nil {
+			namespace = strings.TrimSpace(string(b))
+		}
+	}
+
+	if serverURL == "" || namespace == "" || name == "" {
+		logger.Error(errors.New("invalid options"),
+			"--server-url, --namespace, and --name are required")
+		os.Exit(1)
+	}
+
+	tlsConfig, err := newTLSConfig(caFile, certFile, keyFile)
+	if err != nil {
+		logger.Error(err, "Failed to setup TLS")
+		os.Exit(1)
+	}
+
+	agent := &secretless.Agent{
+		ServerURL: serverURL,
+		Namespace: namespace,
+		Name:      name,
+		Dir:       dir,
+		TokenFile: tokenFile,
+		Client: &http.Client{
+			Timeout: time.Second * 30,
+			Transport: &http.Transport{
+				TLSClientConfig: tlsConfig,
+			},
+		},
+		Interval: interval,
+		Logger:   logger,
+	}
+
+	ctx := ctrl.SetupSignalHandler()
+	if once {
+		if err := syncOnce(ctx, agent, interval); err != nil {
+			logger.Error(err, "Failed to sync")
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	if err := agent.Run(ctx); err != nil {
+		logger.Error(err, "Agent failed")
+		os.Exit(1)
+	}
+}
+
+// syncOnce retries the initial sync until it succeeds, since the Operator may
+// not have synced the data yet when the Pod starts.
+func syncOnce(ctx context.Context, agent *secretless.Agent, interval time.Duration) error {
+	for {
+		_, err := agent.Sync(ctx)
+		if err == nil {
+			return nil
+		}
+		agent.Logger.Error(err, "Sync failed, retrying", "interval", interval)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+func newTLSConfig(caFile, certFile, keyFile string) (*tls.Config, error) {
+	cfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if caFile != "" {
+		b, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(b) {
+			return nil, fmt.Errorf("no CA certificates found in %s", caFile)
+		}
+		cfg.RootCAs = pool
+	}
+
+	if (certFile == "") != (keyFile == "") {
+		return nil, errors.New("--cert-file and --key-file must be set together")
+	}
+
+	if certFile != "" {
+		// SVIDs are short-lived, so load them on every handshake.
+		cfg.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+			if err != nil {
+				return nil, err
+			}
+			return &cert, nil
+		}
+	}
+
+	return cfg, nil
+}
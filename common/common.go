@@ -8,12 +8,15 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -195,18 +198,100 @@ func isAllowedNamespace(obj ctrlclient.Object, targetNamespace string, allowed .
 	return false
 }
 
-func GetVaultAuthNamespaced(ctx context.Context, c ctrlclient.Client, obj ctrlclient.Object, globalOpts *GlobalVaultAuthOptions) (*secretsv1beta1.VaultAuth, error) {
-	authRef, err := getAuthRefNamespacedName(obj)
+// isAllowedVaultAuthNamespace returns true if targetNamespace is allowed by the
+// AllowedNamespaces, or the AllowedNamespacesSelector of spec, the VaultAuthSpec
+// of either a VaultAuth or a ClusterVaultAuth.
+func isAllowedVaultAuthNamespace(ctx context.Context, c ctrlclient.Client, obj ctrlclient.Object, spec *secretsv1beta1.VaultAuthSpec, targetNamespace string) (bool, error) {
+	if isAllowedNamespace(obj, targetNamespace, spec.AllowedNamespaces...) {
+		return true, nil
+	}
+	if spec.AllowedNamespacesSelector == nil {
+		return false, nil
+	}
+	return isSelectedNamespace(ctx, c, targetNamespace, spec.AllowedNamespacesSelector)
+}
+
+// isSelectedNamespace returns true if the labels of the Namespace namespace
+// match selector.
+func isSelectedNamespace(ctx context.Context, c ctrlclient.Client, namespace string, selector *v1.LabelSelector) (bool, error) {
+	sel, err := v1.LabelSelectorAsSelector(selector)
 	if err != nil {
-		return nil, err
+		return false, fmt.Errorf("invalid allowedNamespacesSelector: %w", err)
+	}
+
+	var ns corev1.Namespace
+	if err := c.Get(ctx, ctrlclient.ObjectKey{Name: namespace}, &ns); err != nil {
+		return false, fmt.Errorf("failed getting namespace %q, err=%w", namespace, err)
+	}
+
+	return sel.Matches(labels.Set(ns.Labels)), nil
+}
+
+// ValidateVaultConnectionNamespace returns a NamespaceNotAllowedError if the
+// VaultConnection connObj may not be referenced from targetNamespace. Unlike
+// VaultAuth, a VaultConnection allows all namespaces, unless either of its
+// AllowedNamespaces or AllowedNamespacesSelector is set.
+func ValidateVaultConnectionNamespace(ctx context.Context, c ctrlclient.Client, connObj *secretsv1beta1.VaultConnection, targetNamespace string) error {
+	spec := connObj.Spec
+	if targetNamespace == connObj.Namespace ||
+		(len(spec.AllowedNamespaces) == 0 && spec.AllowedNamespacesSelector == nil) ||
+		slices.Contains(spec.AllowedNamespaces, "*") ||
+		slices.Contains(spec.AllowedNamespaces, targetNamespace) {
+		return nil
 	}
 
-	authObj, err := GetVaultAuthWithRetry(ctx, c, authRef, defaultRetryDuration, defaultMaxRetries)
+	if spec.AllowedNamespacesSelector != nil {
+		selected, err := isSelectedNamespace(ctx, c, targetNamespace, spec.AllowedNamespacesSelector)
+		if err != nil {
+			return err
+		}
+		if selected {
+			return nil
+		}
+	}
+
+	return &NamespaceNotAllowedError{
+		TargetNS:  targetNamespace,
+		ObjRef:    ctrlclient.ObjectKeyFromObject(connObj),
+		RefKind:   "VaultConnection",
+		AllowedNS: spec.AllowedNamespaces,
+	}
+}
+
+func GetVaultAuthNamespaced(ctx context.Context, c ctrlclient.Client, obj ctrlclient.Object, globalOpts *GlobalVaultAuthOptions) (*secretsv1beta1.VaultAuth, error) {
+	m, err := NewSyncableSecretMetaData(obj)
 	if err != nil {
 		return nil, err
 	}
 
-	if !isAllowedNamespace(authObj, obj.GetNamespace(), authObj.Spec.AllowedNamespaces...) {
+	var authRef types.NamespacedName
+	var authObj *secretsv1beta1.VaultAuth
+	if m.ClusterAuthRef != "" {
+		if m.AuthRef != "" {
+			return nil, fmt.Errorf("vaultAuthRef and clusterVaultAuthRef are mutually exclusive")
+		}
+		return getClusterVaultAuthNamespaced(ctx, c, m.ClusterAuthRef, obj.GetNamespace(), globalOpts)
+	} else if m.AuthRef == "" {
+		authObj, err = FindVaultAuthDefault(ctx, c, obj.GetNamespace())
+		if err != nil {
+			return nil, err
+		}
+		authRef = client.ObjectKeyFromObject(authObj)
+	} else {
+		authRef, err = ParseResourceRef(m.AuthRef, obj.GetNamespace())
+		if err != nil {
+			return nil, err
+		}
+
+		authObj, err = GetVaultAuthWithRetry(ctx, c, authRef, defaultRetryDuration, defaultMaxRetries)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if allowed, err := isAllowedVaultAuthNamespace(ctx, c, authObj, &authObj.Spec, obj.GetNamespace()); err != nil {
+		return nil, err
+	} else if !allowed {
 		return nil, &NamespaceNotAllowedError{
 			TargetNS: obj.GetNamespace(),
 			ObjRef:   authRef,
@@ -457,6 +542,130 @@ func MergeInVaultAuthGlobal(ctx context.Context, c ctrlclient.Client, o *secrets
 			globalAuthParams = globalAuthMethod.Params
 			globalAuthHeaders = globalAuthMethod.Headers
 		}
+	case vaultcredsconsts.ProviderMethodAzure:
+		globalAuthMethod := gObj.Spec.Azure
+		mergeTargetAuthMethod := cObj.Spec.Azure
+		if mergeTargetAuthMethod == nil && globalAuthMethod == nil {
+			return nil, nil, &InvalidMergeError{
+				Err: fmt.Errorf("global auth method %s is not configured "+
+					"in VaultAuthGlobal %s", cObj.Spec.Method, authGlobalRef),
+			}
+		}
+
+		if globalAuthMethod != nil {
+			srcAuthMethod := globalAuthMethod.VaultAuthConfigAzure.DeepCopy()
+			if mergeTargetAuthMethod == nil {
+				cObj.Spec.Azure = srcAuthMethod
+			} else {
+				merged, err := mergeTargetAuthMethod.Merge(srcAuthMethod)
+				if err != nil {
+					return nil, nil, &InvalidMergeError{Err: err}
+				}
+				cObj.Spec.Azure = merged
+			}
+			if err := cObj.Spec.Azure.Validate(); err != nil {
+				return nil, nil, &InvalidMergeError{Err: err}
+			}
+			globalAuthMount = globalAuthMethod.Mount
+			globalAuthNamespace = globalAuthMethod.Namespace
+			globalAuthParams = globalAuthMethod.Params
+			globalAuthHeaders = globalAuthMethod.Headers
+		}
+	case vaultcredsconsts.ProviderMethodCert:
+		globalAuthMethod := gObj.Spec.Cert
+		mergeTargetAuthMethod := cObj.Spec.Cert
+		if mergeTargetAuthMethod == nil && globalAuthMethod == nil {
+			return nil, nil, &InvalidMergeError{
+				Err: fmt.Errorf("global auth method %s is not configured "+
+					"in VaultAuthGlobal %s", cObj.Spec.Method, authGlobalRef),
+			}
+		}
+
+		if globalAuthMethod != nil {
+			srcAuthMethod := globalAuthMethod.VaultAuthConfigCert.DeepCopy()
+			if mergeTargetAuthMethod == nil {
+				cObj.Spec.Cert = srcAuthMethod
+			} else {
+				merged, err := mergeTargetAuthMethod.Merge(srcAuthMethod)
+				if err != nil {
+					return nil, nil, &InvalidMergeError{Err: err}
+				}
+				cObj.Spec.Cert = merged
+			}
+			if err := cObj.Spec.Cert.Validate(); err != nil {
+				return nil, nil, &InvalidMergeError{Err: err}
+			}
+			globalAuthMount = globalAuthMethod.Mount
+			globalAuthNamespace = globalAuthMethod.Namespace
+			globalAuthParams = globalAuthMethod.Params
+			globalAuthHeaders = globalAuthMethod.Headers
+		}
+	case vaultcredsconsts.ProviderMethodToken:
+		globalAuthMethod := gObj.Spec.Token
+		mergeTargetAuthMethod := cObj.Spec.Token
+		if mergeTargetAuthMethod == nil && globalAuthMethod == nil {
+			return nil, nil, &InvalidMergeError{
+				Err: fmt.Errorf("global auth method %s is not configured "+
+					"in VaultAuthGlobal %s", cObj.Spec.Method, authGlobalRef),
+			}
+		}
+
+		if globalAuthMethod != nil {
+			srcAuthMethod := globalAuthMethod.VaultAuthConfigToken.DeepCopy()
+			if mergeTargetAuthMethod == nil {
+				cObj.Spec.Token = srcAuthMethod
+			} else {
+				merged, err := mergeTargetAuthMethod.Merge(srcAuthMethod)
+				if err != nil {
+					return nil, nil, &InvalidMergeError{Err: err}
+				}
+				cObj.Spec.Token = merged
+			}
+			if err := cObj.Spec.Token.Validate(); err != nil {
+				return nil, nil, &InvalidMergeError{Err: err}
+			}
+			globalAuthNamespace = globalAuthMethod.Namespace
+			globalAuthHeaders = globalAuthMethod.Headers
+		}
+	case vaultcredsconsts.ProviderMethodLDAP, vaultcredsconsts.ProviderMethodUserPass,
+		vaultcredsconsts.ProviderMethodOkta:
+		// the username and password methods share the same configuration.
+		var globalAuthMethod *secretsv1beta1.VaultAuthGlobalConfigUserPass
+		var mergeTargetAuthMethod **secretsv1beta1.VaultAuthConfigUserPass
+		switch cObj.Spec.Method {
+		case vaultcredsconsts.ProviderMethodLDAP:
+			globalAuthMethod, mergeTargetAuthMethod = gObj.Spec.LDAP, &cObj.Spec.LDAP
+		case vaultcredsconsts.ProviderMethodUserPass:
+			globalAuthMethod, mergeTargetAuthMethod = gObj.Spec.UserPass, &cObj.Spec.UserPass
+		default:
+			globalAuthMethod, mergeTargetAuthMethod = gObj.Spec.Okta, &cObj.Spec.Okta
+		}
+		if *mergeTargetAuthMethod == nil && globalAuthMethod == nil {
+			return nil, nil, &InvalidMergeError{
+				Err: fmt.Errorf("global auth method %s is not configured "+
+					"in VaultAuthGlobal %s", cObj.Spec.Method, authGlobalRef),
+			}
+		}
+
+		if globalAuthMethod != nil {
+			srcAuthMethod := globalAuthMethod.VaultAuthConfigUserPass.DeepCopy()
+			if *mergeTargetAuthMethod == nil {
+				*mergeTargetAuthMethod = srcAuthMethod
+			} else {
+				merged, err := (*mergeTargetAuthMethod).Merge(srcAuthMethod)
+				if err != nil {
+					return nil, nil, &InvalidMergeError{Err: err}
+				}
+				*mergeTargetAuthMethod = merged
+			}
+			if err := (*mergeTargetAuthMethod).Validate(); err != nil {
+				return nil, nil, &InvalidMergeError{Err: err}
+			}
+			globalAuthMount = globalAuthMethod.Mount
+			globalAuthNamespace = globalAuthMethod.Namespace
+			globalAuthParams = globalAuthMethod.Params
+			globalAuthHeaders = globalAuthMethod.Headers
+		}
 	default:
 		return nil, nil, &InvalidMergeError{
 			Err: fmt.Errorf(
@@ -468,7 +677,8 @@ func MergeInVaultAuthGlobal(ctx context.Context, c ctrlclient.Client, o *secrets
 
 	cObj.Spec.Mount = firstNonZeroLen(strLenFunc,
 		cObj.Spec.Mount, globalAuthMount, gObj.Spec.DefaultMount)
-	if cObj.Spec.Mount == "" {
+	// the token method does not log in, so it has no mount.
+	if cObj.Spec.Mount == "" && cObj.Spec.Method != vaultcredsconsts.ProviderMethodToken {
 		return nil, nil, &InvalidMergeError{
 			Err: fmt.Errorf(
 				"mount is not set in VaultAuth %s after merge with %s",
@@ -680,6 +890,19 @@ func FindVaultAuthByUID(ctx context.Context, c client.Client, namespace string,
 		}
 	}
 
+	// the VaultAuth may stand for a ClusterVaultAuth, see VaultAuthFromClusterVaultAuth.
+	if namespace == OperatorNamespace {
+		var clusterAuths secretsv1beta1.ClusterVaultAuthList
+		if err := c.List(ctx, &clusterAuths); err != nil {
+			return nil, err
+		}
+		for _, item := range clusterAuths.Items {
+			if item.GetUID() == uid && item.GetGeneration() == generation {
+				return VaultAuthFromClusterVaultAuth(&item), nil
+			}
+		}
+	}
+
 	return nil, fmt.Errorf("object not found")
 }
 
@@ -742,6 +965,28 @@ func GetVaultNamespace(obj client.Object) (string, error) {
 		ns = o.Spec.Namespace
 	case *secretsv1beta1.VaultDynamicSecret:
 		ns = o.Spec.Namespace
+	case *secretsv1beta1.VaultConsulSecret:
+		ns = o.Spec.Namespace
+	case *secretsv1beta1.VaultNomadSecret:
+		ns = o.Spec.Namespace
+	case *secretsv1beta1.VaultLDAPSecret:
+		ns = o.Spec.Namespace
+	case *secretsv1beta1.VaultRabbitMQSecret:
+		ns = o.Spec.Namespace
+	case *secretsv1beta1.VaultTransitSecret:
+		ns = o.Spec.Namespace
+	case *secretsv1beta1.VaultKubernetesSecret:
+		ns = o.Spec.Namespace
+	case *secretsv1beta1.VaultTerraformCloudSecret:
+		ns = o.Spec.Namespace
+	case *secretsv1beta1.VaultGenericSecret:
+		ns = o.Spec.Namespace
+	case *secretsv1beta1.VaultWrappedSecret:
+		ns = o.Spec.Namespace
+	case *secretsv1beta1.VaultIdentityToken:
+		ns = o.Spec.Namespace
+	case *secretsv1beta1.VaultMongoDBAtlasSecret:
+		ns = o.Spec.Namespace
 	default:
 		return "", fmt.Errorf("unsupported type %T", o)
 	}
@@ -773,7 +1018,13 @@ type SyncableSecretMetaData struct {
 	Namespace string
 	// Destination of the syncable-secret object. Maps to obj.Spec.Destination.
 	Destination *secretsv1beta1.Destination
-	AuthRef     string
+	// AdditionalDestinations of the syncable-secret object, if supported. Maps
+	// to obj.Spec.AdditionalDestinations.
+	AdditionalDestinations []secretsv1beta1.Destination
+	AuthRef                string
+	// ClusterAuthRef is the name of the ClusterVaultAuth, it is mutually
+	// exclusive with AuthRef.
+	ClusterAuthRef string
 }
 
 // NewSyncableSecretMetaData returns SyncableSecretMetaData if obj is a supported type.
@@ -789,24 +1040,99 @@ func NewSyncableSecretMetaData(obj ctrlclient.Object) (*SyncableSecretMetaData,
 	switch t := obj.(type) {
 	case *secretsv1beta1.VaultDynamicSecret:
 		meta.Destination = t.Spec.Destination.DeepCopy()
+		meta.AdditionalDestinations = t.Spec.AdditionalDestinations
 		meta.APIVersion = t.APIVersion
 		meta.Kind = t.Kind
 		meta.AuthRef = t.Spec.VaultAuthRef
+		meta.ClusterAuthRef = t.Spec.ClusterVaultAuthRef
 	case *secretsv1beta1.VaultStaticSecret:
 		meta.Destination = t.Spec.Destination.DeepCopy()
+		meta.AdditionalDestinations = t.Spec.AdditionalDestinations
 		meta.APIVersion = t.APIVersion
 		meta.Kind = t.Kind
 		meta.AuthRef = t.Spec.VaultAuthRef
+		meta.ClusterAuthRef = t.Spec.ClusterVaultAuthRef
 	case *secretsv1beta1.VaultPKISecret:
 		meta.Destination = t.Spec.Destination.DeepCopy()
 		meta.APIVersion = t.APIVersion
 		meta.Kind = t.Kind
 		meta.AuthRef = t.Spec.VaultAuthRef
+		meta.ClusterAuthRef = t.Spec.ClusterVaultAuthRef
 	case *secretsv1beta1.HCPVaultSecretsApp:
 		meta.Destination = t.Spec.Destination.DeepCopy()
 		meta.APIVersion = t.APIVersion
 		meta.Kind = t.Kind
 		meta.AuthRef = t.Spec.HCPAuthRef
+	case *secretsv1beta1.VaultConsulSecret:
+		meta.Destination = t.Spec.Destination.DeepCopy()
+		meta.APIVersion = t.APIVersion
+		meta.Kind = t.Kind
+		meta.AuthRef = t.Spec.VaultAuthRef
+		meta.ClusterAuthRef = t.Spec.ClusterVaultAuthRef
+	case *secretsv1beta1.VaultNomadSecret:
+		meta.Destination = t.Spec.Destination.DeepCopy()
+		meta.APIVersion = t.APIVersion
+		meta.Kind = t.Kind
+		meta.AuthRef = t.Spec.VaultAuthRef
+		meta.ClusterAuthRef = t.Spec.ClusterVaultAuthRef
+	case *secretsv1beta1.VaultLDAPSecret:
+		meta.Destination = t.Spec.Destination.DeepCopy()
+		meta.APIVersion = t.APIVersion
+		meta.Kind = t.Kind
+		meta.AuthRef = t.Spec.VaultAuthRef
+		meta.ClusterAuthRef = t.Spec.ClusterVaultAuthRef
+	case *secretsv1beta1.VaultRabbitMQSecret:
+		meta.Destination = t.Spec.Destination.DeepCopy()
+		meta.APIVersion = t.APIVersion
+		meta.Kind = t.Kind
+		meta.AuthRef = t.Spec.VaultAuthRef
+		meta.ClusterAuthRef = t.Spec.ClusterVaultAuthRef
+	case *secretsv1beta1.VaultTransitSecret:
+		meta.Destination = t.Spec.Destination.DeepCopy()
+		meta.APIVersion = t.APIVersion
+		meta.Kind = t.Kind
+		meta.AuthRef = t.Spec.VaultAuthRef
+		meta.ClusterAuthRef = t.Spec.ClusterVaultAuthRef
+	case *secretsv1beta1.VaultKubernetesSecret:
+		meta.Destination = t.Spec.Destination.DeepCopy()
+		meta.APIVersion = t.APIVersion
+		meta.Kind = t.Kind
+		meta.AuthRef = t.Spec.VaultAuthRef
+		meta.ClusterAuthRef = t.Spec.ClusterVaultAuthRef
+	case *secretsv1beta1.VaultTerraformCloudSecret:
+		meta.Destination = t.Spec.Destination.DeepCopy()
+		meta.APIVersion = t.APIVersion
+		meta.Kind = t.Kind
+		meta.AuthRef = t.Spec.VaultAuthRef
+		meta.ClusterAuthRef = t.Spec.ClusterVaultAuthRef
+	case *secretsv1beta1.VaultGenericSecret:
+		meta.Destination = t.Spec.Destination.DeepCopy()
+		meta.APIVersion = t.APIVersion
+		meta.Kind = t.Kind
+		meta.AuthRef = t.Spec.VaultAuthRef
+		meta.ClusterAuthRef = t.Spec.ClusterVaultAuthRef
+	case *secretsv1beta1.VaultWrappedSecret:
+		meta.Destination = t.Spec.Destination.DeepCopy()
+		meta.APIVersion = t.APIVersion
+		meta.Kind = t.Kind
+		meta.AuthRef = t.Spec.VaultAuthRef
+		meta.ClusterAuthRef = t.Spec.ClusterVaultAuthRef
+	case *secretsv1beta1.VaultIdentityToken:
+		meta.Destination = t.Spec.Destination.DeepCopy()
+		meta.APIVersion = t.APIVersion
+		meta.Kind = t.Kind
+		meta.AuthRef = t.Spec.VaultAuthRef
+		meta.ClusterAuthRef = t.Spec.ClusterVaultAuthRef
+	case *secretsv1beta1.VaultMongoDBAtlasSecret:
+		meta.Destination = t.Spec.Destination.DeepCopy()
+		meta.APIVersion = t.APIVersion
+		meta.Kind = t.Kind
+		meta.AuthRef = t.Spec.VaultAuthRef
+		meta.ClusterAuthRef = t.Spec.ClusterVaultAuthRef
+	case *secretsv1beta1.VaultSecretGroup:
+		meta.Destination = t.Spec.Destination.DeepCopy()
+		meta.APIVersion = t.APIVersion
+		meta.Kind = t.Kind
 	default:
 		return nil, fmt.Errorf("unsupported type %T", t)
 	}
@@ -845,6 +1171,104 @@ func FindVaultAuthGlobalDefault(ctx context.Context, c client.Client, namespaces
 	}
 }
 
+// getClusterVaultAuthNamespaced returns the VaultAuth that stands for the
+// ClusterVaultAuth name, for the objects in namespace.
+func getClusterVaultAuthNamespaced(ctx context.Context, c client.Client, name, namespace string, globalOpts *GlobalVaultAuthOptions) (*secretsv1beta1.VaultAuth, error) {
+	var obj secretsv1beta1.ClusterVaultAuth
+	objKey := types.NamespacedName{Name: name}
+	if err := getWithRetry(ctx, c, objKey, &obj, defaultRetryDuration, defaultMaxRetries); err != nil {
+		return nil, err
+	}
+
+	if allowed, err := isAllowedVaultAuthNamespace(ctx, c, &obj, &obj.Spec, namespace); err != nil {
+		return nil, err
+	} else if !allowed {
+		return nil, &NamespaceNotAllowedError{
+			TargetNS: namespace,
+			ObjRef:   objKey,
+			RefKind:  "ClusterVaultAuth",
+		}
+	}
+
+	authObj, _, err := MergeInVaultAuthGlobal(ctx, c, VaultAuthFromClusterVaultAuth(&obj), globalOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	return authObj, nil
+}
+
+// VaultAuthFromClusterVaultAuth returns the VaultAuth that stands for the
+// ClusterVaultAuth o. It is in the Operator's namespace, so that the namespaced
+// references of o are resolved there, and it has the same UID and generation
+// as o, so that the Vault clients for o are pruned whenever o changes.
+func VaultAuthFromClusterVaultAuth(o *secretsv1beta1.ClusterVaultAuth) *secretsv1beta1.VaultAuth {
+	authObj := &secretsv1beta1.VaultAuth{
+		ObjectMeta: v1.ObjectMeta{
+			Name:              o.Name,
+			Namespace:         OperatorNamespace,
+			UID:               o.UID,
+			Generation:        o.Generation,
+			ResourceVersion:   o.ResourceVersion,
+			Labels:            o.Labels,
+			Annotations:       o.Annotations,
+			DeletionTimestamp: o.DeletionTimestamp,
+		},
+		Spec:   *o.Spec.DeepCopy(),
+		Status: *o.Status.DeepCopy(),
+	}
+	// the allowed namespaces have already been checked against o.
+	authObj.Spec.AllowedNamespaces = nil
+	return authObj
+}
+
+// FindVaultAuthDefault returns the default VaultAuth of namespace, for the
+// objects that do not reference one. It is the VaultAuth named default in
+// namespace, or else the one that is labeled with consts.LabelDefaultVaultAuth,
+// and finally the default VaultAuth of the Operator's namespace.
+func FindVaultAuthDefault(ctx context.Context, c client.Client, namespace string) (*secretsv1beta1.VaultAuth, error) {
+	if namespace != "" && namespace != OperatorNamespace {
+		var obj secretsv1beta1.VaultAuth
+		objKey := types.NamespacedName{Namespace: namespace, Name: consts.NameDefault}
+		if err := c.Get(ctx, objKey, &obj); err == nil {
+			return &obj, nil
+		} else if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+
+		var list secretsv1beta1.VaultAuthList
+		if err := c.List(ctx, &list, client.InNamespace(namespace),
+			client.MatchingLabels{consts.LabelDefaultVaultAuth: "true"}); err != nil {
+			return nil, err
+		}
+		switch len(list.Items) {
+		case 0:
+		case 1:
+			return &list.Items[0], nil
+		default:
+			names := make([]string, 0, len(list.Items))
+			for _, o := range list.Items {
+				names = append(names, o.Name)
+			}
+			slices.Sort(names)
+			return nil, fmt.Errorf("multiple VaultAuths are labeled %s=true in namespace %s: %v",
+				consts.LabelDefaultVaultAuth, namespace, names)
+		}
+	}
+
+	objKey := types.NamespacedName{Namespace: OperatorNamespace, Name: consts.NameDefault}
+	obj, err := GetVaultAuthWithRetry(ctx, c, objKey, defaultRetryDuration, defaultMaxRetries)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, &DefaultVaultAuthNotFoundError{
+				Namespaces: []string{namespace, OperatorNamespace},
+			}
+		}
+		return nil, err
+	}
+	return obj, nil
+}
+
 // GlobalVaultAuthOptions provides options for controlling the handling of
 // VaultAuth and VaultAuthGlobal objects.
 type GlobalVaultAuthOptions struct {
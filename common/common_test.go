@@ -1710,3 +1710,275 @@ func Test_MergeInVaultAuthGlobal(t *testing.T) {
 		})
 	}
 }
+
+func TestFindVaultAuthDefault(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	newAuth := func(namespace, name string, isDefault bool) *secretsv1beta1.VaultAuth {
+		o := &secretsv1beta1.VaultAuth{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      name,
+			},
+		}
+		if isDefault {
+			o.Labels = map[string]string{consts.LabelDefaultVaultAuth: "true"}
+		}
+		return o
+	}
+
+	tests := []struct {
+		name      string
+		namespace string
+		objs      []client.Object
+		want      types.NamespacedName
+		wantErr   string
+	}{
+		{
+			name:      "named-default",
+			namespace: "foo",
+			objs: []client.Object{
+				newAuth("foo", "default", false),
+				newAuth("foo", "labeled", true),
+				newAuth(OperatorNamespace, "default", false),
+			},
+			want: types.NamespacedName{Namespace: "foo", Name: "default"},
+		},
+		{
+			name:      "labeled",
+			namespace: "foo",
+			objs: []client.Object{
+				newAuth("foo", "other", false),
+				newAuth("foo", "labeled", true),
+				newAuth("bar", "default", false),
+				newAuth(OperatorNamespace, "default", false),
+			},
+			want: types.NamespacedName{Namespace: "foo", Name: "labeled"},
+		},
+		{
+			name:      "operator-default",
+			namespace: "foo",
+			objs: []client.Object{
+				newAuth("foo", "other", false),
+				newAuth(OperatorNamespace, "default", false),
+			},
+			want: types.NamespacedName{Namespace: OperatorNamespace, Name: "default"},
+		},
+		{
+			name:      "multiple-labeled",
+			namespace: "foo",
+			objs: []client.Object{
+				newAuth("foo", "b", true),
+				newAuth("foo", "a", true),
+				newAuth(OperatorNamespace, "default", false),
+			},
+			wantErr: fmt.Sprintf("multiple VaultAuths are labeled %s=true in namespace foo: [a b]",
+				consts.LabelDefaultVaultAuth),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testutils.NewFakeClientBuilder().WithObjects(tt.objs...).Build()
+			got, err := FindVaultAuthDefault(ctx, c, tt.namespace)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, client.ObjectKeyFromObject(got))
+		})
+	}
+}
+
+func TestGetVaultAuthNamespaced_clusterVaultAuth(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	cva := &secretsv1beta1.ClusterVaultAuth{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "cluster",
+			UID:        "cluster-uid",
+			Generation: 2,
+		},
+		Spec: secretsv1beta1.VaultAuthSpec{
+			Method:            "kubernetes",
+			Mount:             "kubernetes",
+			AllowedNamespaces: []string{"foo"},
+			Kubernetes: &secretsv1beta1.VaultAuthConfigKubernetes{
+				Role:           "role",
+				ServiceAccount: "default",
+			},
+		},
+	}
+	c := testutils.NewFakeClientBuilder().WithObjects(cva).Build()
+
+	newObj := func(namespace, authRef, clusterAuthRef string) *secretsv1beta1.VaultStaticSecret {
+		return &secretsv1beta1.VaultStaticSecret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      "vss",
+			},
+			Spec: secretsv1beta1.VaultStaticSecretSpec{
+				VaultAuthRef:        authRef,
+				ClusterVaultAuthRef: clusterAuthRef,
+			},
+		}
+	}
+
+	got, err := GetVaultAuthNamespaced(ctx, c, newObj("foo", "", "cluster"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, OperatorNamespace, got.Namespace)
+	assert.Equal(t, "cluster", got.Name)
+	assert.Equal(t, cva.UID, got.UID)
+	assert.Equal(t, cva.Generation, got.Generation)
+	assert.Equal(t, cva.Spec.Kubernetes, got.Spec.Kubernetes)
+	assert.Empty(t, got.Spec.AllowedNamespaces)
+
+	_, err = GetVaultAuthNamespaced(ctx, c, newObj("bar", "", "cluster"), nil)
+	assert.EqualError(t, err, (&NamespaceNotAllowedError{
+		TargetNS: "bar",
+		ObjRef:   types.NamespacedName{Name: "cluster"},
+		RefKind:  "ClusterVaultAuth",
+	}).Error())
+
+	_, err = GetVaultAuthNamespaced(ctx, c, newObj("foo", "auth", "cluster"), nil)
+	assert.EqualError(t, err, "vaultAuthRef and clusterVaultAuthRef are mutually exclusive")
+}
+
+func TestValidateVaultConnectionNamespace(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	c := testutils.NewFakeClientBuilder().WithObjects(
+		&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "tenant",
+				Labels: map[string]string{"vso.hashicorp.com/tier": "privileged"},
+			},
+		},
+		&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "other",
+			},
+		},
+	).Build()
+
+	selector := &metav1.LabelSelector{
+		MatchLabels: map[string]string{"vso.hashicorp.com/tier": "privileged"},
+	}
+	tests := []struct {
+		name      string
+		spec      secretsv1beta1.VaultConnectionSpec
+		namespace string
+		wantErr   bool
+	}{
+		{
+			name:      "unset",
+			namespace: "other",
+		},
+		{
+			name:      "same-namespace",
+			spec:      secretsv1beta1.VaultConnectionSpec{AllowedNamespaces: []string{"tenant"}},
+			namespace: OperatorNamespace,
+		},
+		{
+			name:      "wildcard",
+			spec:      secretsv1beta1.VaultConnectionSpec{AllowedNamespaces: []string{"*"}},
+			namespace: "other",
+		},
+		{
+			name:      "listed",
+			spec:      secretsv1beta1.VaultConnectionSpec{AllowedNamespaces: []string{"tenant"}},
+			namespace: "tenant",
+		},
+		{
+			name:      "not-listed",
+			spec:      secretsv1beta1.VaultConnectionSpec{AllowedNamespaces: []string{"tenant"}},
+			namespace: "other",
+			wantErr:   true,
+		},
+		{
+			name:      "selected",
+			spec:      secretsv1beta1.VaultConnectionSpec{AllowedNamespacesSelector: selector},
+			namespace: "tenant",
+		},
+		{
+			name:      "not-selected",
+			spec:      secretsv1beta1.VaultConnectionSpec{AllowedNamespacesSelector: selector},
+			namespace: "other",
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			connObj := &secretsv1beta1.VaultConnection{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: OperatorNamespace,
+					Name:      "default",
+				},
+				Spec: tt.spec,
+			}
+			err := ValidateVaultConnectionNamespace(ctx, c, connObj, tt.namespace)
+			if tt.wantErr {
+				var nsErr *NamespaceNotAllowedError
+				assert.ErrorAs(t, err, &nsErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestGetVaultAuthNamespaced_allowedNamespacesSelector(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	authObj := &secretsv1beta1.VaultAuth{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: OperatorNamespace,
+			Name:      "privileged",
+		},
+		Spec: secretsv1beta1.VaultAuthSpec{
+			Method: "kubernetes",
+			Mount:  "kubernetes",
+			AllowedNamespacesSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"vso.hashicorp.com/tier": "privileged"},
+			},
+		},
+	}
+	c := testutils.NewFakeClientBuilder().WithObjects(
+		authObj,
+		&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "tenant",
+				Labels: map[string]string{"vso.hashicorp.com/tier": "privileged"},
+			},
+		},
+		&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "other",
+			},
+		},
+	).Build()
+
+	newObj := func(namespace string) *secretsv1beta1.VaultStaticSecret {
+		return &secretsv1beta1.VaultStaticSecret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      "vss",
+			},
+			Spec: secretsv1beta1.VaultStaticSecretSpec{
+				VaultAuthRef: OperatorNamespace + "/privileged",
+			},
+		}
+	}
+
+	got, err := GetVaultAuthNamespaced(ctx, c, newObj("tenant"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, client.ObjectKeyFromObject(authObj), client.ObjectKeyFromObject(got))
+
+	_, err = GetVaultAuthNamespaced(ctx, c, newObj("other"), nil)
+	var nsErr *NamespaceNotAllowedError
+	assert.ErrorAs(t, err, &nsErr)
+}
@@ -23,4 +23,8 @@ const (
 	AWSSessionToken    = "session_token"
 
 	AnnotationResync = "vso.hashicorp.com/resync"
+
+	// LabelDefaultVaultAuth marks the VaultAuth that is the default of its
+	// namespace, when it is not named default.
+	LabelDefaultVaultAuth = "vso.hashicorp.com/default-vault-auth"
 )
@@ -9,6 +9,7 @@ const (
 	ReasonInvalidResourceRef         = "InvalidResourceRef"
 	ReasonK8sClientError             = "K8sClientError"
 	ReasonRolloutRestartFailed       = "RolloutRestartFailed"
+	ReasonRolloutRestartAborted      = "RolloutRestartAborted"
 	ReasonRolloutRestartTriggered    = "RolloutRestartTriggered"
 	ReasonRolloutRestartUnsupported  = "RolloutRestartUnsupported"
 	ReasonSecretLeaseRenewal         = "SecretLeaseRenewal"
@@ -17,6 +18,9 @@ const (
 	ReasonSecretRotated              = "SecretRotated"
 	ReasonSecretSync                 = "SecretSync"
 	ReasonSecretSyncError            = "SecretSyncError"
+	ReasonDestinationConflict        = "DestinationConflict"
+	ReasonFanOutDenied               = "FanOutDenied"
+	ReasonLeaseTooShort              = "LeaseTooShort"
 	ReasonSecretSynced               = "SecretSynced"
 	ReasonStatusUpdateError          = "StatusUpdateError"
 	ReasonUnrecoverable              = "Unrecoverable"
@@ -24,6 +28,13 @@ const (
 	ReasonHVSClientConfigError       = "HVSClientConfigError"
 	ReasonVaultClientError           = "VaultClientError"
 	ReasonVaultStaticSecret          = "VaultStaticSecretError"
+	ReasonVaultTransitSecret         = "VaultTransitSecretError"
+	ReasonVaultWrappedSecret         = "VaultWrappedSecretError"
+	ReasonVaultSecretGroup           = "VaultSecretGroupError"
+	ReasonSecretGroupConflict        = "SecretGroupConflict"
+	ReasonWrappingTokenUnwrapped     = "WrappingTokenUnwrapped"
+	ReasonWrappingTokenInvalid       = "WrappingTokenInvalid"
+	ReasonCiphertextRewrapped        = "CiphertextRewrapped"
 	ReasonHVSSecret                  = "HVSSecretError"
 	ReasonSecretDataDrift            = "SecretDataDrift"
 	ReasonInexistentDestination      = "InexistentDestination"
@@ -39,4 +50,24 @@ const (
 	ReasonVaultClientConfigChanged   = "VaultClientConfigChanged"
 	ReasonEventWatcherError          = "EventWatcherError"
 	ReasonEventWatcherStarted        = "EventWatcherStarted"
+	ReasonClockSkewDetected          = "ClockSkewDetected"
+	ReasonCredentialsPropagating     = "CredentialsPropagating"
+	ReasonCredentialsPropagated      = "CredentialsPropagated"
+	ReasonRolloutRestartDeferred     = "RolloutRestartDeferred"
+	ReasonRolloutRestartCoalesced    = "RolloutRestartCoalesced"
+	ReasonPolicyDriftDetected        = "PolicyDriftDetected"
+	ReasonPolicyDriftUnknown         = "PolicyDriftUnknown"
+	ReasonNoPolicyDrift              = "NoPolicyDrift"
+	ReasonConfigDriftDetected        = "ConfigDriftDetected"
+	ReasonServingStaleData           = "ServingStaleData"
+	ReasonVaultAvailable             = "VaultAvailable"
+	ReasonIssuanceInProgress         = "IssuanceInProgress"
+	ReasonIssuanceComplete           = "IssuanceComplete"
+	ReasonSecretSeeded               = "SecretSeeded"
+	ReasonSeedError                  = "SeedError"
+	ReasonSeedConflict               = "SeedConflict"
+	ReasonOwnershipRepaired          = "OwnershipRepaired"
+	ReasonStaticTokenValid           = "StaticTokenValid"
+	ReasonStaticTokenInvalid         = "StaticTokenInvalid"
+	ReasonStaticTokenUnknown         = "StaticTokenUnknown"
 )
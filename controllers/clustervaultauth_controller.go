@@ -0,0 +1,183 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"golang.org/x/crypto/blake2b"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/common"
+	"github.com/hashicorp/vault-secrets-operator/consts"
+	"github.com/hashicorp/vault-secrets-operator/internal/metrics"
+	"github.com/hashicorp/vault-secrets-operator/vault"
+)
+
+const clusterVaultAuthFinalizer = "clustervaultauth.secrets.hashicorp.com/finalizer"
+
+// ClusterVaultAuthReconciler reconciles a ClusterVaultAuth object
+type ClusterVaultAuthReconciler struct {
+	client.Client
+	Scheme        *runtime.Scheme
+	Recorder      record.EventRecorder
+	ClientFactory vault.CachingClientFactory
+	// GlobalVaultAuthOptions is a struct that contains global VaultAuth options.
+	GlobalVaultAuthOptions *common.GlobalVaultAuthOptions
+}
+
+// +kubebuilder:rbac:groups=secrets.hashicorp.com,resources=clustervaultauths,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=secrets.hashicorp.com,resources=clustervaultauths/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=secrets.hashicorp.com,resources=clustervaultauths/finalizers,verbs=update
+
+// Reconcile reconciles the secretsv1beta1.ClusterVaultAuth resource.
+// Each reconciliation will validate the resource's configuration, it is
+// validated as the VaultAuth that stands for it, in the Operator's namespace.
+//
+// Upon deletion of the resource, it will prune all referent Vault Client(s).
+func (r *ClusterVaultAuthReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	o := &secretsv1beta1.ClusterVaultAuth{}
+	if err := r.Client.Get(ctx, req.NamespacedName, o); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+
+		logger.Error(err, "Failed to get ClusterVaultAuth resource", "resource", req.NamespacedName)
+		return ctrl.Result{}, err
+	}
+
+	if o.GetDeletionTimestamp() != nil {
+		logger.Info("Got deletion timestamp", "obj", o)
+		metrics.DeleteResourceStatus("clustervaultauth", o)
+		return r.handleFinalizer(ctx, o)
+	}
+
+	var errs error
+	authObj := common.VaultAuthFromClusterVaultAuth(o)
+	if authObj.Spec.VaultAuthGlobalRef != nil {
+		mObj, _, err := common.MergeInVaultAuthGlobal(ctx, r.Client, authObj, r.GlobalVaultAuthOptions)
+		if err != nil {
+			errs = errors.Join(errs, err)
+		} else {
+			authObj = mObj
+		}
+	}
+
+	connName, err := common.GetConnectionNamespacedName(authObj)
+	if err != nil {
+		errs = errors.Join(errs, err)
+	} else if connObj, err := common.GetVaultConnectionWithRetry(ctx, r.Client, connName, time.Millisecond*500, 60); err != nil {
+		errs = errors.Join(errs, err)
+		logger.Error(err, "Failed to find VaultConnectionRef")
+	} else if err := common.ValidateVaultConnectionNamespace(ctx, r.Client, connObj, authObj.Namespace); err != nil {
+		errs = errors.Join(errs, err)
+		logger.Error(err, "Invalid VaultConnectionRef")
+	}
+
+	if err := vault.ValidateLoginParams(authObj); err != nil {
+		errs = errors.Join(errs, err)
+	}
+
+	b, err := json.Marshal(o.Spec)
+	var specHash string
+	if err == nil {
+		specHash = fmt.Sprintf("%x", blake2b.Sum256(b))
+	} else {
+		errs = errors.Join(errs, err)
+	}
+
+	if errs == nil {
+		pruneAll := specHash != "" && o.Status.SpecHash != "" && specHash != o.Status.SpecHash
+		// prune the referent Clients of the older generations of self, see
+		// VaultAuthReconciler.Reconcile().
+		if _, err := r.ClientFactory.Prune(ctx, r.Client, authObj, vault.CachingClientFactoryPruneRequest{
+			FilterFunc: func(cur, other client.Object) bool {
+				if pruneAll {
+					return filterAllCacheRefs(cur, other)
+				}
+				return filterOldCacheRefs(cur, other)
+			},
+			PruneStorage: true,
+		}); err != nil {
+			errs = errors.Join(errs, err)
+		}
+	}
+
+	o.Status.SpecHash = specHash
+
+	var horizon time.Duration
+	if errs != nil {
+		o.Status.Valid = ptr.To(false)
+		o.Status.Error = errs.Error()
+		horizon = computeHorizonWithJitter(requeueDurationOnError)
+	} else {
+		o.Status.Valid = ptr.To(true)
+		o.Status.Error = ""
+	}
+
+	metrics.SetResourceStatus("clustervaultauth", o, ptr.Deref(o.Status.Valid, false))
+	if err := r.Status().Update(ctx, o); err != nil {
+		logger.Error(err, "Failed to update the resource's status")
+		return ctrl.Result{}, err
+	}
+	if _, err := maybeAddFinalizer(ctx, r.Client, o, clusterVaultAuthFinalizer); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if errs == nil {
+		r.Recorder.Event(o, corev1.EventTypeNormal, consts.ReasonAccepted,
+			"Successfully handled ClusterVaultAuth resource request")
+	} else {
+		logger.Error(errs, "Failed to handle ClusterVaultAuth resource request", "horizon", horizon)
+		r.Recorder.Eventf(o, corev1.EventTypeWarning, consts.ReasonAccepted,
+			"Failed to handle ClusterVaultAuth resource request: err=%s", errs)
+	}
+
+	return ctrl.Result{
+		RequeueAfter: horizon,
+	}, nil
+}
+
+func (r *ClusterVaultAuthReconciler) handleFinalizer(ctx context.Context, o *secretsv1beta1.ClusterVaultAuth) (ctrl.Result, error) {
+	if controllerutil.ContainsFinalizer(o, clusterVaultAuthFinalizer) {
+		if _, err := r.ClientFactory.Prune(ctx, r.Client, common.VaultAuthFromClusterVaultAuth(o),
+			vault.CachingClientFactoryPruneRequest{
+				FilterFunc:          filterAllCacheRefs,
+				PruneStorage:        true,
+				SkipClientCallbacks: true,
+			}); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		controllerutil.RemoveFinalizer(o, clusterVaultAuthFinalizer)
+		if err := r.Update(ctx, o); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ClusterVaultAuthReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&secretsv1beta1.ClusterVaultAuth{}).
+		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		Complete(r)
+}
@@ -5,12 +5,16 @@ package controllers
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math/rand"
 	"time"
 
 	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -18,19 +22,31 @@ import (
 	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
 	"github.com/hashicorp/vault-secrets-operator/common"
 	"github.com/hashicorp/vault-secrets-operator/consts"
+	"github.com/hashicorp/vault-secrets-operator/helpers"
+	"github.com/hashicorp/vault-secrets-operator/internal/clockskew"
+	"github.com/hashicorp/vault-secrets-operator/internal/issuance"
 )
 
 var (
 	_ error = (*LeaseTruncatedError)(nil)
+	_ error = (*LeaseTooShortError)(nil)
 	// random is not cryptographically secure, should not be used in any crypto
 	// type of operations.
 	random                 = rand.New(rand.NewSource(int64(time.Now().Nanosecond())))
 	requeueDurationOnError = time.Second * 5
 	// used by monkey patching unit tests
 	nowFunc = time.Now
+	// clockSkewTracker provides the safety margin that is applied to renewals
+	// that are scheduled from a remote clock.
+	clockSkewTracker = clockskew.DefaultTracker
 )
 
-const renewalPercentCap = 90
+const (
+	renewalPercentCap = 90
+	// syncMessagesMaxEntries is the maximum number of entries retained in a
+	// resource's Status.LastSyncMessages.
+	syncMessagesMaxEntries = 5
+)
 
 type empty struct{}
 
@@ -46,6 +62,18 @@ func (l *LeaseTruncatedError) Error() string {
 		l.Expected, l.Actual)
 }
 
+// LeaseTooShortError indicates that the lease duration of a secret is too short
+// to be honored, i.e. the secret would expire before it could be renewed.
+type LeaseTooShortError struct {
+	Duration time.Duration
+	Minimum  time.Duration
+}
+
+func (l *LeaseTooShortError) Error() string {
+	return fmt.Sprintf("lease duration %s is less than the minimum of %s, "+
+		"the secret would expire before it could be renewed", l.Duration, l.Minimum)
+}
+
 // computeMaxJitter with max as 10% of the duration, and jitter a random amount
 // between 0-10%
 func computeMaxJitter(duration time.Duration) (maxHorizon float64, jitter uint64) {
@@ -135,6 +163,18 @@ func RemoveAllFinalizers(ctx context.Context, c client.Client, log logr.Logger)
 	// * VaultDynamicSecret
 	// * VaultStaticSecret <- not currently implemented
 	// * VaultPKISecret
+	// * VaultConsulSecret
+	// * VaultNomadSecret
+	// * VaultLDAPSecret
+	// * VaultRabbitMQSecret
+	// * VaultTransitSecret
+	// * VaultKubernetesSecret
+	// * VaultTerraformCloudSecret
+	// * VaultGenericSecret
+	// * VaultWrappedSecret
+	// * VaultIdentityToken
+	// * VaultMongoDBAtlasSecret
+	// * VaultSecretGroup
 
 	vamList := &secretsv1beta1.VaultAuthList{}
 	err := c.List(ctx, vamList, opts...)
@@ -163,6 +203,90 @@ func RemoveAllFinalizers(ctx context.Context, c client.Client, log logr.Logger)
 		log.Error(err, "Unable to list VaultPKISecret resources")
 	}
 	removeFinalizers(ctx, c, log, vpkiList)
+
+	vcsList := &secretsv1beta1.VaultConsulSecretList{}
+	err = c.List(ctx, vcsList, opts...)
+	if err != nil {
+		log.Error(err, "Unable to list VaultConsulSecret resources")
+	}
+	removeFinalizers(ctx, c, log, vcsList)
+
+	vnsList := &secretsv1beta1.VaultNomadSecretList{}
+	err = c.List(ctx, vnsList, opts...)
+	if err != nil {
+		log.Error(err, "Unable to list VaultNomadSecret resources")
+	}
+	removeFinalizers(ctx, c, log, vnsList)
+
+	vldapList := &secretsv1beta1.VaultLDAPSecretList{}
+	err = c.List(ctx, vldapList, opts...)
+	if err != nil {
+		log.Error(err, "Unable to list VaultLDAPSecret resources")
+	}
+	removeFinalizers(ctx, c, log, vldapList)
+
+	vrmqList := &secretsv1beta1.VaultRabbitMQSecretList{}
+	err = c.List(ctx, vrmqList, opts...)
+	if err != nil {
+		log.Error(err, "Unable to list VaultRabbitMQSecret resources")
+	}
+	removeFinalizers(ctx, c, log, vrmqList)
+
+	vtsList := &secretsv1beta1.VaultTransitSecretList{}
+	err = c.List(ctx, vtsList, opts...)
+	if err != nil {
+		log.Error(err, "Unable to list VaultTransitSecret resources")
+	}
+	removeFinalizers(ctx, c, log, vtsList)
+
+	vksList := &secretsv1beta1.VaultKubernetesSecretList{}
+	err = c.List(ctx, vksList, opts...)
+	if err != nil {
+		log.Error(err, "Unable to list VaultKubernetesSecret resources")
+	}
+	removeFinalizers(ctx, c, log, vksList)
+
+	vtcsList := &secretsv1beta1.VaultTerraformCloudSecretList{}
+	err = c.List(ctx, vtcsList, opts...)
+	if err != nil {
+		log.Error(err, "Unable to list VaultTerraformCloudSecret resources")
+	}
+	removeFinalizers(ctx, c, log, vtcsList)
+
+	vgsList := &secretsv1beta1.VaultGenericSecretList{}
+	err = c.List(ctx, vgsList, opts...)
+	if err != nil {
+		log.Error(err, "Unable to list VaultGenericSecret resources")
+	}
+	removeFinalizers(ctx, c, log, vgsList)
+
+	vwsList := &secretsv1beta1.VaultWrappedSecretList{}
+	err = c.List(ctx, vwsList, opts...)
+	if err != nil {
+		log.Error(err, "Unable to list VaultWrappedSecret resources")
+	}
+	removeFinalizers(ctx, c, log, vwsList)
+
+	vitList := &secretsv1beta1.VaultIdentityTokenList{}
+	err = c.List(ctx, vitList, opts...)
+	if err != nil {
+		log.Error(err, "Unable to list VaultIdentityToken resources")
+	}
+	removeFinalizers(ctx, c, log, vitList)
+
+	vmdbasList := &secretsv1beta1.VaultMongoDBAtlasSecretList{}
+	err = c.List(ctx, vmdbasList, opts...)
+	if err != nil {
+		log.Error(err, "Unable to list VaultMongoDBAtlasSecret resources")
+	}
+	removeFinalizers(ctx, c, log, vmdbasList)
+
+	vsgList := &secretsv1beta1.VaultSecretGroupList{}
+	err = c.List(ctx, vsgList, opts...)
+	if err != nil {
+		log.Error(err, "Unable to list VaultSecretGroup resources")
+	}
+	removeFinalizers(ctx, c, log, vsgList)
 	return nil
 }
 
@@ -212,6 +336,126 @@ func removeFinalizers(ctx context.Context, c client.Client, log logr.Logger, obj
 				}
 			}
 		}
+	case *secretsv1beta1.VaultConsulSecretList:
+		for _, x := range t.Items {
+			cnt++
+			if controllerutil.RemoveFinalizer(&x, vaultConsulSecretFinalizer) {
+				log.Info(fmt.Sprintf("Updating finalizer for ConsulSecret %s", x.Name))
+				if err := c.Update(ctx, &x, &client.UpdateOptions{}); err != nil {
+					log.Error(err, fmt.Sprintf("Unable to update finalizer for %s: %s", vaultConsulSecretFinalizer, x.Name))
+				}
+			}
+		}
+	case *secretsv1beta1.VaultNomadSecretList:
+		for _, x := range t.Items {
+			cnt++
+			if controllerutil.RemoveFinalizer(&x, vaultNomadSecretFinalizer) {
+				log.Info(fmt.Sprintf("Updating finalizer for NomadSecret %s", x.Name))
+				if err := c.Update(ctx, &x, &client.UpdateOptions{}); err != nil {
+					log.Error(err, fmt.Sprintf("Unable to update finalizer for %s: %s", vaultNomadSecretFinalizer, x.Name))
+				}
+			}
+		}
+	case *secretsv1beta1.VaultLDAPSecretList:
+		for _, x := range t.Items {
+			cnt++
+			if controllerutil.RemoveFinalizer(&x, vaultLDAPSecretFinalizer) {
+				log.Info(fmt.Sprintf("Updating finalizer for LDAPSecret %s", x.Name))
+				if err := c.Update(ctx, &x, &client.UpdateOptions{}); err != nil {
+					log.Error(err, fmt.Sprintf("Unable to update finalizer for %s: %s", vaultLDAPSecretFinalizer, x.Name))
+				}
+			}
+		}
+	case *secretsv1beta1.VaultRabbitMQSecretList:
+		for _, x := range t.Items {
+			cnt++
+			if controllerutil.RemoveFinalizer(&x, vaultRabbitMQSecretFinalizer) {
+				log.Info(fmt.Sprintf("Updating finalizer for RabbitMQSecret %s", x.Name))
+				if err := c.Update(ctx, &x, &client.UpdateOptions{}); err != nil {
+					log.Error(err, fmt.Sprintf("Unable to update finalizer for %s: %s", vaultRabbitMQSecretFinalizer, x.Name))
+				}
+			}
+		}
+	case *secretsv1beta1.VaultTransitSecretList:
+		for _, x := range t.Items {
+			cnt++
+			if controllerutil.RemoveFinalizer(&x, vaultTransitSecretFinalizer) {
+				log.Info(fmt.Sprintf("Updating finalizer for VTS %s", x.Name))
+				if err := c.Update(ctx, &x, &client.UpdateOptions{}); err != nil {
+					log.Error(err, fmt.Sprintf("Unable to update finalizer for %s: %s", vaultTransitSecretFinalizer, x.Name))
+				}
+			}
+		}
+	case *secretsv1beta1.VaultKubernetesSecretList:
+		for _, x := range t.Items {
+			cnt++
+			if controllerutil.RemoveFinalizer(&x, vaultKubernetesSecretFinalizer) {
+				log.Info(fmt.Sprintf("Updating finalizer for kubernetes %s", x.Name))
+				if err := c.Update(ctx, &x, &client.UpdateOptions{}); err != nil {
+					log.Error(err, fmt.Sprintf("Unable to update finalizer for %s: %s", vaultKubernetesSecretFinalizer, x.Name))
+				}
+			}
+		}
+	case *secretsv1beta1.VaultTerraformCloudSecretList:
+		for _, x := range t.Items {
+			cnt++
+			if controllerutil.RemoveFinalizer(&x, vaultTerraformCloudSecretFinalizer) {
+				log.Info(fmt.Sprintf("Updating finalizer for terraformcloud %s", x.Name))
+				if err := c.Update(ctx, &x, &client.UpdateOptions{}); err != nil {
+					log.Error(err, fmt.Sprintf("Unable to update finalizer for %s: %s", vaultTerraformCloudSecretFinalizer, x.Name))
+				}
+			}
+		}
+	case *secretsv1beta1.VaultGenericSecretList:
+		for _, x := range t.Items {
+			cnt++
+			if controllerutil.RemoveFinalizer(&x, vaultGenericSecretFinalizer) {
+				log.Info(fmt.Sprintf("Updating finalizer for generic %s", x.Name))
+				if err := c.Update(ctx, &x, &client.UpdateOptions{}); err != nil {
+					log.Error(err, fmt.Sprintf("Unable to update finalizer for %s: %s", vaultGenericSecretFinalizer, x.Name))
+				}
+			}
+		}
+	case *secretsv1beta1.VaultWrappedSecretList:
+		for _, x := range t.Items {
+			cnt++
+			if controllerutil.RemoveFinalizer(&x, vaultWrappedSecretFinalizer) {
+				log.Info(fmt.Sprintf("Updating finalizer for wrapped %s", x.Name))
+				if err := c.Update(ctx, &x, &client.UpdateOptions{}); err != nil {
+					log.Error(err, fmt.Sprintf("Unable to update finalizer for %s: %s", vaultWrappedSecretFinalizer, x.Name))
+				}
+			}
+		}
+	case *secretsv1beta1.VaultIdentityTokenList:
+		for _, x := range t.Items {
+			cnt++
+			if controllerutil.RemoveFinalizer(&x, vaultIdentityTokenFinalizer) {
+				log.Info(fmt.Sprintf("Updating finalizer for identity %s", x.Name))
+				if err := c.Update(ctx, &x, &client.UpdateOptions{}); err != nil {
+					log.Error(err, fmt.Sprintf("Unable to update finalizer for %s: %s", vaultIdentityTokenFinalizer, x.Name))
+				}
+			}
+		}
+	case *secretsv1beta1.VaultMongoDBAtlasSecretList:
+		for _, x := range t.Items {
+			cnt++
+			if controllerutil.RemoveFinalizer(&x, vaultMongoDBAtlasSecretFinalizer) {
+				log.Info(fmt.Sprintf("Updating finalizer for mongodbatlas %s", x.Name))
+				if err := c.Update(ctx, &x, &client.UpdateOptions{}); err != nil {
+					log.Error(err, fmt.Sprintf("Unable to update finalizer for %s: %s", vaultMongoDBAtlasSecretFinalizer, x.Name))
+				}
+			}
+		}
+	case *secretsv1beta1.VaultSecretGroupList:
+		for _, x := range t.Items {
+			cnt++
+			if controllerutil.RemoveFinalizer(&x, vaultSecretGroupFinalizer) {
+				log.Info(fmt.Sprintf("Updating finalizer for VSG %s", x.Name))
+				if err := c.Update(ctx, &x, &client.UpdateOptions{}); err != nil {
+					log.Error(err, fmt.Sprintf("Unable to update finalizer for %s: %s", vaultSecretGroupFinalizer, x.Name))
+				}
+			}
+		}
 	}
 	log.Info(fmt.Sprintf("Removed %d finalizers", cnt))
 }
@@ -316,3 +560,89 @@ func updateConditions(current []metav1.Condition, updates ...metav1.Condition) [
 	}
 	return ret
 }
+
+// appendSyncMessage appends a new secretsv1beta1.SyncMessage to current,
+// returning a new slice that contains at most syncMessagesMaxEntries. The oldest
+// entries are dropped first.
+func appendSyncMessage(current []secretsv1beta1.SyncMessage, result secretsv1beta1.SyncResult, reason, msg string, a ...any) []secretsv1beta1.SyncMessage {
+	if len(a) > 0 {
+		msg = fmt.Sprintf(msg, a...)
+	}
+
+	ret := append(current, secretsv1beta1.SyncMessage{
+		Time:    metav1.NewTime(nowFunc()),
+		Result:  result,
+		Reason:  reason,
+		Message: msg,
+	})
+	if l := len(ret); l > syncMessagesMaxEntries {
+		ret = ret[l-syncMessagesMaxEntries:]
+	}
+
+	return append([]secretsv1beta1.SyncMessage(nil), ret...)
+}
+
+// patchSyncMessages patches the Status.LastSyncMessages of o with messages.
+// Status.Conditions is also patched, if conditions is not empty.
+func patchSyncMessages(ctx context.Context, c client.Client, o client.Object,
+	messages []secretsv1beta1.SyncMessage, conditions []metav1.Condition,
+) error {
+	status := map[string]any{
+		"lastSyncMessages": messages,
+	}
+	if len(conditions) > 0 {
+		status["conditions"] = conditions
+	}
+	b, err := json.Marshal(map[string]any{
+		"status": status,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.Status().Patch(ctx, o, client.RawPatch(types.MergePatchType, b))
+}
+
+// setIssuingCondition sets the Issuing condition to True if issueErr is
+// issuance.ErrIssuing, otherwise the condition is set to False, if it was
+// previously set.
+func setIssuingCondition(conditions *[]metav1.Condition, generation int64, issueErr error) {
+	if errors.Is(issueErr, issuance.ErrIssuing) {
+		meta.SetStatusCondition(conditions, metav1.Condition{
+			Type:               secretsv1beta1.ConditionTypeIssuing,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: generation,
+			Reason:             consts.ReasonIssuanceInProgress,
+			Message:            "Secret is being issued",
+		})
+		return
+	}
+
+	if meta.FindStatusCondition(*conditions, secretsv1beta1.ConditionTypeIssuing) == nil {
+		return
+	}
+
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               secretsv1beta1.ConditionTypeIssuing,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: generation,
+		Reason:             consts.ReasonIssuanceComplete,
+		Message:            "Secret issuance is complete",
+	})
+}
+
+// syncSecretErrorReason returns the event reason for an error returned by
+// helpers.SyncSecret.
+func syncSecretErrorReason(err error) string {
+	if helpers.IsDestinationConflict(err) {
+		return consts.ReasonDestinationConflict
+	}
+	if helpers.IsFanOutDenied(err) {
+		return consts.ReasonFanOutDenied
+	}
+	var leaseErr *LeaseTooShortError
+	if errors.As(err, &leaseErr) {
+		return consts.ReasonLeaseTooShort
+	}
+	return consts.ReasonSecretSyncError
+}
@@ -547,3 +547,86 @@ func TestVaultAuthReconciler_updateConditions(t *testing.T) {
 		})
 	}
 }
+
+func Test_appendSyncMessage(t *testing.T) {
+	t0 := time.Unix(1700000000, 0)
+	origNowFunc := nowFunc
+	t.Cleanup(func() {
+		nowFunc = origNowFunc
+	})
+	nowFunc = func() time.Time {
+		return t0
+	}
+
+	newMessages := func(n int) []secretsv1beta1.SyncMessage {
+		var ret []secretsv1beta1.SyncMessage
+		for i := 0; i < n; i++ {
+			ret = append(ret, secretsv1beta1.SyncMessage{
+				Time:    metav1.NewTime(t0.Add(-time.Duration(n-i) * time.Second)),
+				Result:  secretsv1beta1.SyncResultSuccess,
+				Reason:  fmt.Sprintf("reason-%d", i),
+				Message: fmt.Sprintf("message-%d", i),
+			})
+		}
+		return ret
+	}
+
+	tests := []struct {
+		name    string
+		current []secretsv1beta1.SyncMessage
+		result  secretsv1beta1.SyncResult
+		reason  string
+		msg     string
+		args    []any
+		want    []secretsv1beta1.SyncMessage
+	}{
+		{
+			name:   "empty",
+			result: secretsv1beta1.SyncResultSuccess,
+			reason: "SecretSynced",
+			msg:    "Secret synced",
+			want: []secretsv1beta1.SyncMessage{
+				{
+					Time:    metav1.NewTime(t0),
+					Result:  secretsv1beta1.SyncResultSuccess,
+					Reason:  "SecretSynced",
+					Message: "Secret synced",
+				},
+			},
+		},
+		{
+			name:    "with-format-args",
+			current: newMessages(1),
+			result:  secretsv1beta1.SyncResultFailure,
+			reason:  "VaultClientError",
+			msg:     "Failed to read Vault secret: %s",
+			args:    []any{"permission denied"},
+			want: append(newMessages(1), secretsv1beta1.SyncMessage{
+				Time:    metav1.NewTime(t0),
+				Result:  secretsv1beta1.SyncResultFailure,
+				Reason:  "VaultClientError",
+				Message: "Failed to read Vault secret: permission denied",
+			}),
+		},
+		{
+			name:    "drops-oldest",
+			current: newMessages(syncMessagesMaxEntries),
+			result:  secretsv1beta1.SyncResultSuccess,
+			reason:  "SecretRotated",
+			msg:     "Secret synced",
+			want: append(newMessages(syncMessagesMaxEntries)[1:], secretsv1beta1.SyncMessage{
+				Time:    metav1.NewTime(t0),
+				Result:  secretsv1beta1.SyncResultSuccess,
+				Reason:  "SecretRotated",
+				Message: "Secret synced",
+			}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := appendSyncMessage(tt.current, tt.result, tt.reason, tt.msg, tt.args...)
+			assert.Equal(t, tt.want, got)
+			assert.LessOrEqual(t, len(got), syncMessagesMaxEntries)
+		})
+	}
+}
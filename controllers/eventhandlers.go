@@ -18,6 +18,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/hashicorp/vault-secrets-operator/consts"
+	"github.com/hashicorp/vault-secrets-operator/helpers"
 )
 
 var maxRequeueAfter = time.Second * 1
@@ -176,6 +177,24 @@ func (e *enqueueOnDeletionRequestHandler) Delete(ctx context.Context,
 			logger.V(consts.LogLevelTrace).Info("No match", "ref", ref)
 		}
 	}
+
+	// Secrets outside their owner's namespace cannot have an OwnerReference, so
+	// the owner is found from the Secret's cross-namespace reference metadata.
+	if ref, ns, ok := helpers.CrossNamespaceOwner(evt.Object); ok &&
+		ref.APIVersion == e.gvk.GroupVersion().String() && ref.Kind == e.gvk.Kind {
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: ns,
+				Name:      ref.Name,
+			},
+		}
+		if _, ok := reqs[req]; !ok {
+			_, horizon := computeMaxJitterDuration(d)
+			logger.V(consts.LogLevelTrace).Info(
+				"Enqueuing cross-namespace owner", "obj", ref, "refKind", ref.Kind, "horizon", horizon)
+			q.AddAfter(req, horizon)
+		}
+	}
 }
 
 func (e *enqueueOnDeletionRequestHandler) Generic(ctx context.Context,
@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/vault/api"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/util/workqueue"
@@ -21,6 +22,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/helpers"
+	"github.com/hashicorp/vault-secrets-operator/internal/testutils"
 )
 
 type testCaseEnqueueRefRequestHandler struct {
@@ -544,8 +547,42 @@ func Test_enqueueOnDeletionRequestHandler_Delete(t *testing.T) {
 		},
 	}
 
+	owner := &secretsv1beta1.VaultStaticSecret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "baz",
+			UID:       "b1e6a8f2-c2ad-4c0b-9f0a-31d2e5d4c5b1",
+		},
+	}
+	crossNSLabels, crossNSAnnotations, err := helpers.CrossNamespaceOwnerMetadataForObj(
+		owner, testutils.NewFakeClientBuilder().Build().Scheme())
+	require.NoError(t, err)
+	deleteEventCrossNamespace := event.DeleteEvent{
+		Object: &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "other",
+				Name:        "vso-secret",
+				Labels:      crossNSLabels,
+				Annotations: crossNSAnnotations,
+			},
+		},
+	}
+
 	gvk := secretsv1beta1.GroupVersion.WithKind(kind.String())
 	tests := []testCaseEnqueueOnDeletionRequestHandler{
+		{
+			name: "enqueued-cross-namespace",
+			kind: kind,
+			deleteEvents: []event.DeleteEvent{
+				deleteEventCrossNamespace,
+			},
+			q: &DelegatingQueue{
+				TypedRateLimitingInterface: workqueue.NewTypedRateLimitingQueue[reconcile.Request](nil),
+			},
+			gvk:             gvk,
+			wantAddedAfter:  wantAddedAfterValid,
+			maxRequeueAfter: time.Second * 10,
+		},
 		{
 			name: "enqueued",
 			kind: kind,
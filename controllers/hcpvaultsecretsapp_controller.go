@@ -40,6 +40,8 @@ import (
 	"github.com/hashicorp/vault-secrets-operator/common"
 	"github.com/hashicorp/vault-secrets-operator/consts"
 	"github.com/hashicorp/vault-secrets-operator/helpers"
+	"github.com/hashicorp/vault-secrets-operator/internal/cloudevents"
+	"github.com/hashicorp/vault-secrets-operator/internal/diagnostics"
 	"github.com/hashicorp/vault-secrets-operator/internal/version"
 )
 
@@ -129,7 +131,7 @@ func (r *HCPVaultSecretsAppReconciler) Reconcile(ctx context.Context, req ctrl.R
 		d, err := parseDurationString(o.Spec.RefreshAfter, ".spec.refreshAfter", r.MinRefreshAfter)
 		if err != nil {
 			logger.Error(err, "Field validation failed")
-			r.Recorder.Eventf(o, corev1.EventTypeWarning, consts.ReasonHVSSecret,
+			r.recordSyncError(ctx, o, consts.ReasonHVSSecret,
 				"Field validation failed, err=%s", err)
 			return ctrl.Result{}, err
 		}
@@ -140,7 +142,7 @@ func (r *HCPVaultSecretsAppReconciler) Reconcile(ctx context.Context, req ctrl.R
 
 	transOption, err := helpers.NewSecretTransformationOption(ctx, r.Client, o, r.GlobalTransformationOptions)
 	if err != nil {
-		r.Recorder.Eventf(o, corev1.EventTypeWarning, consts.ReasonTransformationError,
+		r.recordSyncError(ctx, o, consts.ReasonTransformationError,
 			"Failed setting up SecretTransformationOption: %s", err)
 		return ctrl.Result{RequeueAfter: computeHorizonWithJitter(requeueDurationOnError)}, nil
 	}
@@ -148,7 +150,7 @@ func (r *HCPVaultSecretsAppReconciler) Reconcile(ctx context.Context, req ctrl.R
 	c, err := r.hvsClient(ctx, o)
 	if err != nil {
 		logger.Error(err, "Get HCP Vault Secrets Client")
-		r.Recorder.Eventf(o, corev1.EventTypeWarning, consts.ReasonHVSClientConfigError,
+		r.recordSyncError(ctx, o, consts.ReasonHVSClientConfigError,
 			"Failed to instantiate HVS client: %s", err)
 		return ctrl.Result{
 			RequeueAfter: computeHorizonWithJitter(requeueDurationOnError),
@@ -167,7 +169,7 @@ func (r *HCPVaultSecretsAppReconciler) Reconcile(ctx context.Context, req ctrl.R
 	resp, err := fetchOpenSecretsPaginated(ctx, c, params, nil)
 	if err != nil {
 		logger.Error(err, "Get App Secrets", "appName", o.Spec.AppName)
-		r.Recorder.Eventf(o, corev1.EventTypeWarning, consts.ReasonHVSSecret,
+		r.recordSyncError(ctx, o, consts.ReasonHVSSecret,
 			"Failed to get HVS App secrets: %s", err)
 		entry, _ := r.BackOffRegistry.Get(req.NamespacedName)
 		return ctrl.Result{
@@ -190,7 +192,7 @@ func (r *HCPVaultSecretsAppReconciler) Reconcile(ctx context.Context, req ctrl.R
 	dynamicSecrets, err := getHVSDynamicSecrets(ctx, c, o.Spec.AppName, renewPercent, shadowSecrets)
 	if err != nil {
 		logger.Error(err, "Get Dynamic Secrets", "appName", o.Spec.AppName)
-		r.Recorder.Eventf(o, corev1.EventTypeWarning, consts.ReasonHVSSecret,
+		r.recordSyncError(ctx, o, consts.ReasonHVSSecret,
 			"Failed to get HVS dynamic secrets: %s", err)
 		entry, _ := r.BackOffRegistry.Get(req.NamespacedName)
 		return ctrl.Result{
@@ -229,7 +231,7 @@ func (r *HCPVaultSecretsAppReconciler) Reconcile(ctx context.Context, req ctrl.R
 
 	data, err := r.SecretDataBuilder.WithHVSAppSecrets(resp, transOption)
 	if err != nil {
-		r.Recorder.Eventf(o, corev1.EventTypeWarning, consts.ReasonSecretDataBuilderError,
+		r.recordSyncError(ctx, o, consts.ReasonSecretDataBuilderError,
 			"Failed to build K8s secret data: %s", err)
 		logger.Error(err, "Failed to build K8s Secret data", "appName", o.Spec.AppName)
 		return ctrl.Result{
@@ -257,8 +259,11 @@ func (r *HCPVaultSecretsAppReconciler) Reconcile(ctx context.Context, req ctrl.R
 
 	o.Status.SecretMAC = base64.StdEncoding.EncodeToString(messageMAC)
 	if doSync {
-		if err := helpers.SyncSecret(ctx, r.Client, o, data); err != nil {
-			r.Recorder.Eventf(o, corev1.EventTypeWarning, consts.ReasonSecretSyncError,
+		err := helpers.SyncSecret(ctx, r.Client, o, data)
+		helpers.SetDestinationConflictCondition(&o.Status.Conditions, o.GetGeneration(), err)
+		helpers.SetFanOutDeniedCondition(&o.Status.Conditions, o.GetGeneration(), err)
+		if err != nil {
+			r.recordSyncError(ctx, o, syncSecretErrorReason(err),
 				"Failed to update k8s secret: %s", err)
 			return ctrl.Result{}, err
 		}
@@ -270,12 +275,17 @@ func (r *HCPVaultSecretsAppReconciler) Reconcile(ctx context.Context, req ctrl.R
 			_ = helpers.HandleRolloutRestarts(ctx, r.Client, o, r.Recorder)
 		}
 		if err := r.storeShadowSecretData(ctx, o, dynamicSecrets.secrets); err != nil {
-			r.Recorder.Eventf(o, corev1.EventTypeWarning, consts.ReasonSecretSyncError,
+			r.recordSyncError(ctx, o, consts.ReasonSecretSyncError,
 				"Failed to store shadow secret data for appName %s: %s",
 				o.Spec.AppName, err)
 			return ctrl.Result{}, nil
 		}
 		r.Recorder.Event(o, corev1.EventTypeNormal, reason, "Secret synced")
+		if reason == consts.ReasonSecretRotated {
+			cloudevents.Emit(ctx, cloudevents.TypeSecretRotated, o, cloudevents.Data{})
+		}
+		o.Status.LastSyncMessages = appendSyncMessage(o.Status.LastSyncMessages,
+			secretsv1beta1.SyncResultSuccess, reason, "Secret synced")
 	} else {
 		r.Recorder.Event(o, corev1.EventTypeNormal, consts.ReasonSecretSync, "Secret sync not required")
 	}
@@ -289,6 +299,18 @@ func (r *HCPVaultSecretsAppReconciler) Reconcile(ctx context.Context, req ctrl.R
 	}, nil
 }
 
+// recordSyncError emits a warning event for the failed sync attempt and records
+// it in the resource's Status.LastSyncMessages.
+func (r *HCPVaultSecretsAppReconciler) recordSyncError(ctx context.Context, o *secretsv1beta1.HCPVaultSecretsApp, reason, msg string, a ...any) {
+	r.Recorder.Eventf(o, corev1.EventTypeWarning, reason, msg, a...)
+	messages := appendSyncMessage(o.Status.LastSyncMessages,
+		secretsv1beta1.SyncResultFailure, reason, msg, a...)
+	if err := patchSyncMessages(ctx, r.Client, o, messages, o.Status.Conditions); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to record the sync error in the resource's status")
+	}
+	diagnostics.RecordFailure(ctx, r.Client, o, messages, o.Status.Conditions, reason, a...)
+}
+
 func (r *HCPVaultSecretsAppReconciler) updateStatus(ctx context.Context, o *secretsv1beta1.HCPVaultSecretsApp) error {
 	o.Status.LastGeneration = o.GetGeneration()
 	if err := r.Status().Update(ctx, o); err != nil {
@@ -381,6 +403,11 @@ func (r *HCPVaultSecretsAppReconciler) handleDeletion(ctx context.Context, o cli
 	if err := helpers.DeleteSecret(ctx, r.Client, shadowObjKey); err != nil {
 		logger.Error(err, "Failed to delete shadow secret", "shadow secret", shadowObjKey)
 	}
+	helpers.DeleteSecretlessData(o)
+	if err := helpers.HandleDestinationDeletion(ctx, r.Client, o); err != nil {
+		logger.Error(err, "Failed to apply the deletion policy of the destination Secrets")
+		return err
+	}
 	if controllerutil.ContainsFinalizer(o, hcpVaultSecretsAppFinalizer) {
 		logger.Info("Removing finalizer")
 		if controllerutil.RemoveFinalizer(o, hcpVaultSecretsAppFinalizer) {
@@ -0,0 +1,604 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/vault/api"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/consts"
+	"github.com/hashicorp/vault-secrets-operator/helpers"
+	"github.com/hashicorp/vault-secrets-operator/internal/cloudevents"
+	"github.com/hashicorp/vault-secrets-operator/internal/diagnostics"
+	"github.com/hashicorp/vault-secrets-operator/internal/issuance"
+	"github.com/hashicorp/vault-secrets-operator/vault"
+)
+
+// leasedSecret provides access to the configuration and status of a resource
+// that syncs leased credentials from a Vault secrets engine. All the fields are
+// references into the underlying object, so any updates to the status are
+// reflected in obj.
+type leasedSecret struct {
+	// obj is the underlying custom resource, e.g. VaultConsulSecret.
+	obj client.Object
+	// path in Vault to request the credentials from, including the mount.
+	path string
+	// method is the HTTP method used when requesting the credentials, defaults
+	// to GET.
+	method string
+	// params are sent along with the credentials request, only used with the
+	// PUT/POST methods.
+	params map[string]any
+	// renewalPercent of the lease duration when the lease is renewed.
+	renewalPercent int
+	// revoke the lease on resource deletion.
+	revoke      bool
+	destination *secretsv1beta1.Destination
+	status      *secretsv1beta1.VaultLeasedSecretStatus
+	// staticCreds should be set when syncing the credentials of a static role.
+	// Static credentials are not leased, they are rotated by Vault periodically.
+	// The credentials are synced again right after each rotation. It is a
+	// reference into the object's status.
+	staticCreds *secretsv1beta1.VaultStaticCredsMetaData
+	// addData is an optional hook that can add extra data derived from the Vault
+	// response to the Secret's data. It is called after the transformations have
+	// been applied.
+	addData func(resp vault.Response, data map[string][]byte) error
+	// selectData is an optional hook that replaces the Vault response that the
+	// Secret's data is built from. It is called before the transformations are
+	// applied.
+	selectData func(resp vault.Response) (vault.Response, error)
+	// refreshAfter is the period after which the secret is synced again, when
+	// the Vault response is not leased.
+	refreshAfter time.Duration
+	// ttl is an optional hook that returns the time-to-live, in seconds, of
+	// credentials that are not leased, but expire nonetheless, e.g. Vault
+	// identity tokens. It is recorded as the lease duration, so that new
+	// credentials are requested once the renewal window is reached.
+	ttl func(resp vault.Response) (int, error)
+	// propagationDelay is the period that newly issued credentials take to
+	// become usable, e.g. while the IP access list of a MongoDB Atlas
+	// programmatic API key propagates. The CredentialsReady condition is false
+	// until it has elapsed, and any rollout-restart is deferred until then.
+	propagationDelay time.Duration
+}
+
+// leasedSecretSyncer implements the lease lifecycle shared by all resources
+// that sync leased credentials from a Vault secrets engine. New credentials are
+// requested on the initial sync, or whenever the resource is updated. The lease
+// is renewed once it has reached its renewal window, and the credentials are
+// rotated if the lease can no longer be renewed. The lease may be revoked upon
+// resource deletion.
+type leasedSecretSyncer struct {
+	client                      client.Client
+	recorder                    record.EventRecorder
+	clientFactory               vault.ClientFactory
+	syncRegistry                *SyncRegistry
+	backOffRegistry             *BackOffRegistry
+	referenceCache              ResourceReferenceCache
+	globalTransformationOptions *helpers.GlobalTransformationOptions
+	finalizer                   string
+}
+
+func (s *leasedSecretSyncer) reconcile(ctx context.Context, req ctrl.Request, ls *leasedSecret) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	o := ls.obj
+
+	if o.GetDeletionTimestamp() != nil {
+		logger.Info("Got deletion timestamp", "obj", o)
+		return ctrl.Result{}, s.handleDeletion(ctx, ls)
+	}
+
+	// the status is the only part of o that is modified below.
+	orig := o.DeepCopyObject()
+
+	s.referenceCache.Set(SecretTransformation, req.NamespacedName,
+		helpers.GetTransformationRefObjKeys(
+			ls.destination.Transformation, o.GetNamespace())...)
+
+	destExists, _ := helpers.CheckSecretExists(ctx, s.client, o)
+	if !ls.destination.Create && !destExists {
+		logger.Info("Destination secret does not exist, either create it or "+
+			"set .spec.destination.create=true", "destination", ls.destination)
+		return ctrl.Result{RequeueAfter: requeueDurationOnError}, nil
+	}
+
+	c, err := s.clientFactory.Get(ctx, s.client, o)
+	if err != nil {
+		s.recordSyncError(ctx, ls, consts.ReasonVaultClientConfigError,
+			"Failed to get Vault client: %s", err)
+		return ctrl.Result{RequeueAfter: computeHorizonWithJitter(requeueDurationOnError)}, nil
+	}
+
+	// we can ignore the error here, since it was handled above in the Get() call.
+	clientCacheKey, _ := c.GetCacheKey()
+	lastClientCacheKey := ls.status.VaultClientMeta.CacheKey
+	ls.status.VaultClientMeta.CacheKey = clientCacheKey.String()
+	ls.status.VaultClientMeta.ID = c.ID()
+
+	var syncReason string
+	switch {
+	case ls.status.LastGeneration == 0:
+		syncReason = consts.ReasonInitialSync
+	case s.syncRegistry.Has(req.NamespacedName):
+		syncReason = consts.ReasonForceSync
+	case o.GetGeneration() != ls.status.LastGeneration:
+		syncReason = consts.ReasonResourceUpdated
+	case ls.destination.Create && !destExists:
+		syncReason = consts.ReasonInexistentDestination
+	case lastClientCacheKey != "" && lastClientCacheKey != ls.status.VaultClientMeta.CacheKey:
+		syncReason = consts.ReasonVaultClientConfigChanged
+	}
+
+	if syncReason == "" {
+		if remaining := s.completePropagation(ctx, ls); remaining > 0 {
+			logger.V(consts.LogLevelDebug).Info("Credentials are propagating", "horizon", remaining)
+			if err := s.updateStatus(ctx, ls, orig); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{RequeueAfter: remaining}, nil
+		}
+	}
+
+	secretLease := ls.status.SecretLease
+	if syncReason == "" {
+		horizon, inWindow := computeLeasedSecretRenewalWindow(ls)
+		if !inWindow {
+			logger.V(consts.LogLevelDebug).Info("Not in renewal window", "horizon", horizon)
+			if err := s.updateStatus(ctx, ls, orig); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{RequeueAfter: horizon}, nil
+		}
+
+		if secretLease.ID == "" || !secretLease.Renewable {
+			syncReason = consts.ReasonInRenewalWindow
+		} else if newLease, err := s.renewLease(ctx, c, ls); err == nil {
+			ls.status.SecretLease = *newLease
+			ls.status.LastRenewalTime = nowFunc().Unix()
+			horizon := computeLeasedSecretHorizon(ls)
+			ls.status.LastSyncMessages = appendSyncMessage(ls.status.LastSyncMessages,
+				secretsv1beta1.SyncResultSuccess, consts.ReasonSecretLeaseRenewal,
+				"Renewed lease, lease_id=%s, horizon=%s", secretLease.ID, horizon)
+			if err := s.updateStatus(ctx, ls, orig); err != nil {
+				return ctrl.Result{}, err
+			}
+			s.recorder.Eventf(o, corev1.EventTypeNormal, consts.ReasonSecretLeaseRenewal,
+				"Renewed lease, lease_id=%s, horizon=%s", secretLease.ID, horizon)
+			cloudevents.Emit(ctx, cloudevents.TypeLeaseRenewed, o, cloudevents.LeaseData(*newLease))
+			return ctrl.Result{RequeueAfter: horizon}, nil
+		} else {
+			var e *LeaseTruncatedError
+			if errors.As(err, &e) {
+				s.recorder.Eventf(o, corev1.EventTypeNormal, consts.ReasonSecretLeaseRenewal,
+					"Lease renewal duration was truncated from %ds to %ds, "+
+						"requesting new credentials", e.Expected, e.Actual)
+			} else {
+				if vault.IsForbiddenError(err) {
+					c.Taint()
+				}
+				s.recorder.Eventf(o, corev1.EventTypeWarning, consts.ReasonSecretLeaseRenewalError,
+					"Could not renew lease, lease_id=%s, err=%s", secretLease.ID, err)
+				if vault.IsLeaseNotFoundError(err) {
+					cloudevents.Emit(ctx, cloudevents.TypeLeaseExpired, o, cloudevents.LeaseData(secretLease))
+				}
+			}
+			syncReason = consts.ReasonSecretLeaseRenewalError
+		}
+	}
+
+	transOption, err := helpers.NewSecretTransformationOption(ctx, s.client, o, s.globalTransformationOptions)
+	if err != nil {
+		s.recordSyncError(ctx, ls, consts.ReasonTransformationError,
+			"Failed setting up SecretTransformationOption: %s", err)
+		return ctrl.Result{RequeueAfter: computeHorizonWithJitter(requeueDurationOnError)}, nil
+	}
+
+	// the issuance may outlive this reconciliation, so it must not share ls.
+	issued := *ls
+	resp, err := issuance.Do(ctx, req.String(), o.GetGeneration(),
+		func(ctx context.Context) (vault.Response, error) {
+			return s.doVault(ctx, c, &issued)
+		})
+	setIssuingCondition(&ls.status.Conditions, o.GetGeneration(), err)
+	if errors.Is(err, issuance.ErrIssuing) {
+		logger.V(consts.LogLevelDebug).Info("Credentials are being issued")
+		if err := patchSyncMessages(ctx, s.client, o, ls.status.LastSyncMessages, ls.status.Conditions); err != nil {
+			return ctrl.Result{}, err
+		}
+		s.syncRegistry.Add(req.NamespacedName)
+		return ctrl.Result{RequeueAfter: issuance.PollInterval}, nil
+	} else if err != nil {
+		s.syncRegistry.Add(req.NamespacedName)
+		if vault.IsForbiddenError(err) {
+			c.Taint()
+		}
+		entry, _ := s.backOffRegistry.Get(req.NamespacedName)
+		horizon := entry.NextBackOff()
+		s.recordSyncError(ctx, ls, consts.ReasonVaultClientError,
+			"Failed to request credentials from Vault, horizon=%s, err=%s", horizon, err)
+		return ctrl.Result{RequeueAfter: horizon}, nil
+	}
+
+	var staticCredsMeta *secretsv1beta1.VaultStaticCredsMetaData
+	if ls.staticCreds != nil {
+		staticCredsMeta, err = vaultStaticCredsMetaDataFromData(resp.Data())
+		if err != nil {
+			s.recordSyncError(ctx, ls, consts.ReasonVaultClientError,
+				"Invalid static-creds response from Vault: %s", err)
+			return ctrl.Result{RequeueAfter: computeHorizonWithJitter(requeueDurationOnError)}, nil
+		}
+	}
+
+	var ttl int
+	if ls.ttl != nil {
+		ttl, err = ls.ttl(resp)
+		if err != nil {
+			s.recordSyncError(ctx, ls, consts.ReasonVaultClientError,
+				"Invalid response from Vault: %s", err)
+			return ctrl.Result{RequeueAfter: computeHorizonWithJitter(requeueDurationOnError)}, nil
+		}
+	}
+
+	dataResp := resp
+	if ls.selectData != nil {
+		dataResp, err = ls.selectData(resp)
+	}
+	var data map[string][]byte
+	if err == nil {
+		data, err = dataResp.SecretK8sData(transOption)
+	}
+	if err == nil && ls.addData != nil {
+		err = ls.addData(resp, data)
+	}
+	if err != nil {
+		s.recordSyncError(ctx, ls, consts.ReasonSecretDataBuilderError,
+			"Failed to build K8s secret data: %s", err)
+		return ctrl.Result{RequeueAfter: computeHorizonWithJitter(requeueDurationOnError)}, nil
+	}
+
+	err = helpers.SyncSecret(ctx, s.client, o, data)
+	helpers.SetDestinationConflictCondition(&ls.status.Conditions, o.GetGeneration(), err)
+	helpers.SetFanOutDeniedCondition(&ls.status.Conditions, o.GetGeneration(), err)
+	if err != nil {
+		s.syncRegistry.Add(req.NamespacedName)
+		entry, _ := s.backOffRegistry.Get(req.NamespacedName)
+		horizon := entry.NextBackOff()
+		s.recordSyncError(ctx, ls, syncSecretErrorReason(err),
+			"Failed to sync the secret, horizon=%s, err=%s", horizon, err)
+		return ctrl.Result{RequeueAfter: horizon}, nil
+	}
+	s.backOffRegistry.Delete(req.NamespacedName)
+
+	reason := consts.ReasonSecretSynced
+	doRolloutRestart := ls.status.LastGeneration > 0
+	if staticCredsMeta != nil {
+		if syncReason == consts.ReasonInRenewalWindow {
+			// only consider the secret rotated once Vault has rotated the credentials.
+			doRolloutRestart = staticCredsMeta.LastVaultRotation != ls.staticCreds.LastVaultRotation
+		}
+		*ls.staticCreds = *staticCredsMeta
+	}
+	if doRolloutRestart {
+		reason = consts.ReasonSecretRotated
+	}
+
+	if ls.propagationDelay > 0 {
+		condReason := consts.ReasonCredentialsPropagating
+		if doRolloutRestart {
+			condReason = consts.ReasonRolloutRestartDeferred
+			doRolloutRestart = false
+		}
+		meta.SetStatusCondition(&ls.status.Conditions, metav1.Condition{
+			Type:               secretsv1beta1.ConditionTypeCredentialsReady,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: o.GetGeneration(),
+			Reason:             condReason,
+			Message:            fmt.Sprintf("Waiting %s for the credentials to propagate", ls.propagationDelay),
+		})
+	}
+
+	if doRolloutRestart {
+		// rollout-restart errors are not retryable
+		// all error reporting is handled by helpers.HandleRolloutRestarts
+		// it is called prior to updating the status, since it may set a condition.
+		_ = helpers.HandleRolloutRestarts(ctx, s.client, o, s.recorder)
+	}
+
+	ls.status.SecretLease = *leaseFromVaultSecret(resp.Secret())
+	if ls.ttl != nil {
+		ls.status.SecretLease.LeaseDuration = ttl
+	}
+	ls.status.LastRenewalTime = nowFunc().Unix()
+	horizon := computeLeasedSecretHorizon(ls)
+	ls.status.LastSyncMessages = appendSyncMessage(ls.status.LastSyncMessages,
+		secretsv1beta1.SyncResultSuccess, reason,
+		"Secret synced, lease_id=%q, horizon=%s, sync_reason=%q",
+		ls.status.SecretLease.ID, horizon, syncReason)
+	if err := s.updateStatus(ctx, ls, orig); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	s.recorder.Eventf(o, corev1.EventTypeNormal, reason,
+		"Secret synced, lease_id=%q, horizon=%s, sync_reason=%q",
+		ls.status.SecretLease.ID, horizon, syncReason)
+	if ls.status.SecretLease.ID != "" {
+		cloudevents.Emit(ctx, cloudevents.TypeLeaseIssued, o, cloudevents.LeaseData(ls.status.SecretLease))
+	}
+	if reason == consts.ReasonSecretRotated {
+		cloudevents.Emit(ctx, cloudevents.TypeSecretRotated, o, cloudevents.Data{})
+	}
+
+	s.syncRegistry.Delete(req.NamespacedName)
+
+	if ls.propagationDelay > 0 && (horizon == 0 || ls.propagationDelay < horizon) {
+		// check back once the credentials have propagated.
+		return ctrl.Result{RequeueAfter: ls.propagationDelay}, nil
+	}
+
+	if horizon == 0 {
+		logger.Info("Vault secret does not support periodic renewal/refresh via reconciliation",
+			"requeue", false, "horizon", horizon)
+		return ctrl.Result{}, nil
+	}
+
+	return ctrl.Result{RequeueAfter: horizon}, nil
+}
+
+// completePropagation marks the credentials as ready once their propagation
+// delay has elapsed, and triggers the rollout-restart that was deferred until
+// then. It returns the time remaining until the credentials are ready, zero if
+// they already are.
+func (s *leasedSecretSyncer) completePropagation(ctx context.Context, ls *leasedSecret) time.Duration {
+	cond := meta.FindStatusCondition(ls.status.Conditions, secretsv1beta1.ConditionTypeCredentialsReady)
+	if cond == nil || cond.Status == metav1.ConditionTrue {
+		return 0
+	}
+
+	readyAt := time.Unix(ls.status.LastRenewalTime, 0).Add(ls.propagationDelay)
+	if remaining := readyAt.Sub(nowFunc()); remaining > 0 {
+		return remaining
+	}
+
+	if cond.Reason == consts.ReasonRolloutRestartDeferred {
+		// rollout-restart errors are not retryable
+		// all error reporting is handled by helpers.HandleRolloutRestarts
+		_ = helpers.HandleRolloutRestarts(ctx, s.client, ls.obj, s.recorder)
+	}
+
+	meta.SetStatusCondition(&ls.status.Conditions, metav1.Condition{
+		Type:               secretsv1beta1.ConditionTypeCredentialsReady,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: ls.obj.GetGeneration(),
+		Reason:             consts.ReasonCredentialsPropagated,
+		Message:            "The credentials have propagated",
+	})
+	s.recorder.Eventf(ls.obj, corev1.EventTypeNormal, consts.ReasonCredentialsPropagated,
+		"Credentials are ready after a propagation delay of %s", ls.propagationDelay)
+
+	return 0
+}
+
+// doVault requests new credentials from Vault.
+func (s *leasedSecretSyncer) doVault(ctx context.Context, c vault.ClientBase, ls *leasedSecret) (vault.Response, error) {
+	method := ls.method
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	var resp vault.Response
+	var err error
+	switch method {
+	case http.MethodPut, http.MethodPost:
+		resp, err = c.Write(ctx, vault.NewWriteRequest(ls.path, ls.params))
+	case http.MethodGet:
+		resp, err = c.Read(ctx, vault.NewReadRequest(ls.path, nil))
+	default:
+		return nil, fmt.Errorf("unsupported HTTP method %q for sync", method)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if resp == nil || resp.Secret() == nil {
+		return nil, errors.New("nil response")
+	}
+
+	return resp, nil
+}
+
+func (s *leasedSecretSyncer) renewLease(ctx context.Context, c vault.ClientBase, ls *leasedSecret) (*secretsv1beta1.VaultSecretLease, error) {
+	resp, err := c.Write(ctx, vault.NewWriteRequest("/sys/leases/renew", map[string]any{
+		"lease_id":  ls.status.SecretLease.ID,
+		"increment": ls.status.SecretLease.LeaseDuration,
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	// The renewal duration can come back as less than the requested increment
+	// if the time remaining on max_ttl is less than the increment. In this case
+	// return an error so new credentials are acquired.
+	lease := leaseFromVaultSecret(resp.Secret())
+	if lease.LeaseDuration < ls.status.SecretLease.LeaseDuration {
+		return lease, &LeaseTruncatedError{
+			Expected: ls.status.SecretLease.LeaseDuration,
+			Actual:   lease.LeaseDuration,
+		}
+	}
+
+	return lease, nil
+}
+
+// revokeLease revokes the resource's current lease.
+// NOTE: Enabling revocation requires the VaultAuth referenced by the resource
+// to have a policy that includes `path "sys/leases/revoke" { capabilities = ["update"] }`,
+// otherwise this will fail with permission errors.
+func (s *leasedSecretSyncer) revokeLease(ctx context.Context, ls *leasedSecret) {
+	logger := log.FromContext(ctx)
+	leaseID := ls.status.SecretLease.ID
+	logger.Info("Revoking lease for credential", "id", leaseID)
+	c, err := s.clientFactory.Get(ctx, s.client, ls.obj)
+	if err != nil {
+		logger.Error(err, "Failed to get client when revoking lease", "id", leaseID)
+		return
+	}
+
+	if _, err = c.Write(ctx, vault.NewWriteRequest("/sys/leases/revoke", map[string]any{
+		"lease_id": leaseID,
+	})); err != nil {
+		s.recorder.Eventf(ls.obj, corev1.EventTypeWarning, consts.ReasonSecretLeaseRevoke,
+			"Failed to revoke lease: %s", err)
+		logger.Error(err, "Failed to revoke lease", "id", leaseID)
+	} else {
+		s.recorder.Eventf(ls.obj, corev1.EventTypeNormal, consts.ReasonSecretLeaseRevoke,
+			"Lease revoked: %s", leaseID)
+		cloudevents.Emit(ctx, cloudevents.TypeLeaseRevoked, ls.obj, cloudevents.Data{LeaseID: leaseID})
+		logger.Info("Lease revoked", "id", leaseID)
+	}
+}
+
+func (s *leasedSecretSyncer) handleDeletion(ctx context.Context, ls *leasedSecret) error {
+	logger := log.FromContext(ctx)
+	objKey := client.ObjectKeyFromObject(ls.obj)
+	s.syncRegistry.Delete(objKey)
+	s.backOffRegistry.Delete(objKey)
+	issuance.Forget(objKey.String())
+	s.referenceCache.Remove(SecretTransformation, objKey)
+	if ls.revoke && ls.status.SecretLease.ID != "" {
+		s.revokeLease(ctx, ls)
+	}
+
+	helpers.DeleteSecretlessData(ls.obj)
+	if err := helpers.HandleDestinationDeletion(ctx, s.client, ls.obj); err != nil {
+		logger.Error(err, "Failed to apply the deletion policy of the destination Secrets")
+		return err
+	}
+
+	if controllerutil.ContainsFinalizer(ls.obj, s.finalizer) {
+		logger.Info("Removing finalizer")
+		if controllerutil.RemoveFinalizer(ls.obj, s.finalizer) {
+			if err := s.client.Update(ctx, ls.obj); err != nil {
+				logger.Error(err, "Failed to remove the finalizer")
+				return err
+			}
+			logger.Info("Successfully removed the finalizer")
+		}
+	}
+	return nil
+}
+
+// updateStatus updates the status of ls.obj, unless ls.obj is equal to orig.
+func (s *leasedSecretSyncer) updateStatus(ctx context.Context, ls *leasedSecret, orig runtime.Object) error {
+	logger := log.FromContext(ctx)
+	ls.status.LastGeneration = ls.obj.GetGeneration()
+	if equality.Semantic.DeepEqual(orig, ls.obj) {
+		logger.V(consts.LogLevelDebug).Info("Status unchanged, skipping update")
+	} else if err := s.client.Status().Update(ctx, ls.obj); err != nil {
+		s.recorder.Eventf(ls.obj, corev1.EventTypeWarning, consts.ReasonStatusUpdateError,
+			"Failed to update the resource's status, err=%s", err)
+	}
+
+	_, err := maybeAddFinalizer(ctx, s.client, ls.obj, s.finalizer)
+	return err
+}
+
+// recordSyncError emits a warning event for the failed sync attempt and records
+// it in the resource's status. Only the sync messages are patched, the remaining
+// status fields are left as they were prior to the failed sync.
+func (s *leasedSecretSyncer) recordSyncError(ctx context.Context, ls *leasedSecret, reason, msg string, a ...any) {
+	s.recorder.Eventf(ls.obj, corev1.EventTypeWarning, reason, msg, a...)
+	messages := appendSyncMessage(ls.status.LastSyncMessages,
+		secretsv1beta1.SyncResultFailure, reason, msg, a...)
+	if err := patchSyncMessages(ctx, s.client, ls.obj, messages, ls.status.Conditions); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to record the sync error in the resource's status")
+	}
+	diagnostics.RecordFailure(ctx, s.client, ls.obj, messages, ls.status.Conditions, reason, a...)
+}
+
+// computeLeasedSecretHorizon returns the duration after which the lease should
+// be renewed. A zero duration is returned if the lease has no duration. For
+// static credentials, the horizon is computed to be just after the next
+// rotation.
+func computeLeasedSecretHorizon(ls *leasedSecret) time.Duration {
+	if ls.staticCreds != nil {
+		if !isStaticCreds(ls.staticCreds) {
+			return 0
+		}
+
+		horizon := time.Second * 1
+		if d := time.Duration(ls.staticCreds.TTL) * time.Second; d > 0 {
+			// give Vault an extra .5 seconds to perform the rotation.
+			horizon = d + 500*time.Millisecond
+		}
+		_, jitter := computeMaxJitterWithPercent(staticCredsJitterHorizon, vdsJitterFactor)
+		return horizon + time.Duration(jitter)
+	}
+
+	d := time.Duration(ls.status.SecretLease.LeaseDuration) * time.Second
+	if d <= 0 {
+		if ls.refreshAfter > 0 {
+			return computeHorizonWithJitter(ls.refreshAfter)
+		}
+		return 0
+	}
+	return computeDynamicHorizonWithJitter(d, ls.renewalPercent)
+}
+
+// computeLeasedSecretRenewalWindow returns whether the lease has entered its
+// renewal window. If it has not, the returned duration is the time remaining
+// until the start of the window.
+func computeLeasedSecretRenewalWindow(ls *leasedSecret) (time.Duration, bool) {
+	if ls.status.LastRenewalTime == 0 {
+		return 0, true
+	}
+
+	var renewAfter time.Duration
+	d := time.Duration(ls.status.SecretLease.LeaseDuration) * time.Second
+	switch {
+	case ls.staticCreds != nil:
+		// static credentials can only be synced after Vault has rotated them.
+		renewAfter = time.Duration(ls.staticCreds.TTL) * time.Second
+	case d > 0:
+		renewAfter = computeStartRenewingAt(d, ls.renewalPercent)
+	default:
+		renewAfter = ls.refreshAfter
+	}
+	if renewAfter <= 0 {
+		return 0, true
+	}
+
+	startRenewingAt := time.Unix(ls.status.LastRenewalTime, 0).Add(renewAfter)
+	horizon := startRenewingAt.Sub(nowFunc())
+	if horizon <= 0 {
+		return 0, true
+	}
+
+	return horizon, false
+}
+
+func leaseFromVaultSecret(s *api.Secret) *secretsv1beta1.VaultSecretLease {
+	return &secretsv1beta1.VaultSecretLease{
+		ID:            s.LeaseID,
+		LeaseDuration: s.LeaseDuration,
+		Renewable:     s.Renewable,
+		RequestID:     s.RequestID,
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package controllers
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/consts"
+	"github.com/hashicorp/vault-secrets-operator/helpers"
+	"github.com/hashicorp/vault-secrets-operator/vault"
+)
+
+// leasedSecretKind describes a kind of custom resource that syncs leased
+// credentials from a Vault secrets engine, e.g. VaultConsulSecret. Its
+// implementations are empty structs, only used as the type parameter of a
+// LeasedSecretReconciler.
+type leasedSecretKind[O client.Object] interface {
+	// resourceKind returns the kind of the custom resource.
+	resourceKind() ResourceKind
+	// finalizer returns the finalizer that is added to the custom resources.
+	finalizer() string
+	// newObject returns an empty custom resource.
+	newObject() O
+	// newLeasedSecret returns the leasedSecret of o. An error is returned if o's
+	// configuration is invalid.
+	newLeasedSecret(o O) (*leasedSecret, error)
+}
+
+// LeasedSecretReconciler reconciles the custom resources of the kind K, whose
+// leased credentials are synced by a leasedSecretSyncer.
+type LeasedSecretReconciler[O client.Object, K leasedSecretKind[O]] struct {
+	client.Client
+	Scheme                      *runtime.Scheme
+	Recorder                    record.EventRecorder
+	ClientFactory               vault.ClientFactory
+	SyncRegistry                *SyncRegistry
+	BackOffRegistry             *BackOffRegistry
+	GlobalTransformationOptions *helpers.GlobalTransformationOptions
+	referenceCache              ResourceReferenceCache
+	syncer                      *leasedSecretSyncer
+}
+
+// Reconcile ensures that the custom resource is synced from Vault to its
+// configured Kubernetes secret. See the leasedSecretKind implementations for the
+// details of each kind.
+func (r *LeasedSecretReconciler[O, K]) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var kind K
+	logger := log.FromContext(ctx)
+	o := kind.newObject()
+	if err := r.Client.Get(ctx, req.NamespacedName, o); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "error getting resource from k8s", "obj", o)
+		return ctrl.Result{}, err
+	}
+
+	ls, err := kind.newLeasedSecret(o)
+	if err != nil {
+		// the resource has to be updated in order to fix its configuration, so
+		// there is no point in requeuing it.
+		r.Recorder.Eventf(o, corev1.EventTypeWarning, consts.ReasonInvalidConfiguration,
+			"Invalid configuration: %s", err)
+		return ctrl.Result{}, nil
+	}
+
+	return r.syncer.reconcile(ctx, req, ls)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *LeasedSecretReconciler[O, K]) SetupWithManager(mgr ctrl.Manager, opts controller.Options) error {
+	var kind K
+	r.referenceCache = newResourceReferenceCache()
+	if r.BackOffRegistry == nil {
+		r.BackOffRegistry = NewBackOffRegistry()
+	}
+	if r.SyncRegistry == nil {
+		r.SyncRegistry = NewSyncRegistry()
+	}
+	r.syncer = &leasedSecretSyncer{
+		client:                      r.Client,
+		recorder:                    r.Recorder,
+		clientFactory:               r.ClientFactory,
+		syncRegistry:                r.SyncRegistry,
+		backOffRegistry:             r.BackOffRegistry,
+		referenceCache:              r.referenceCache,
+		globalTransformationOptions: r.GlobalTransformationOptions,
+		finalizer:                   kind.finalizer(),
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(kind.newObject()).
+		WithOptions(opts).
+		WithEventFilter(syncableSecretPredicate(r.SyncRegistry)).
+		Watches(
+			&secretsv1beta1.SecretTransformation{},
+			NewEnqueueRefRequestsHandlerST(r.referenceCache, r.SyncRegistry),
+		).
+		WatchesMetadata(
+			&corev1.Secret{},
+			&enqueueOnDeletionRequestHandler{
+				gvk: secretsv1beta1.GroupVersion.WithKind(kind.resourceKind().String()),
+			},
+			builder.WithPredicates(&secretsPredicate{}),
+		).
+		Complete(r)
+}
@@ -0,0 +1,192 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/consts"
+	vaultcredsconsts "github.com/hashicorp/vault-secrets-operator/credentials/vault/consts"
+	"github.com/hashicorp/vault-secrets-operator/vault"
+)
+
+// defaultPolicyDriftCheckInterval is used when the VaultAuth's
+// PolicyDriftCheck.Interval is not set.
+const defaultPolicyDriftCheckInterval = "5m"
+
+// checkPolicyDrift compares the policies of the cached Vault tokens that were
+// issued for the VaultAuth against the expected policies, and returns the
+// resulting PolicyDrift condition.
+func (r *VaultAuthReconciler) checkPolicyDrift(ctx context.Context, o *secretsv1beta1.VaultAuth) metav1.Condition {
+	cond := metav1.Condition{
+		Type:               secretsv1beta1.ConditionTypePolicyDrift,
+		Status:             metav1.ConditionUnknown,
+		ObservedGeneration: o.Generation,
+	}
+
+	clients := r.ClientFactory.Clients(o)
+	if len(clients) == 0 {
+		cond.Reason = consts.ReasonPolicyDriftUnknown
+		cond.Message = "No Vault token has been issued for this VaultAuth yet"
+		return cond
+	}
+
+	expected := o.Spec.PolicyDriftCheck.ExpectedPolicies
+	if len(expected) == 0 {
+		var err error
+		expected, err = readRolePolicies(ctx, clients[0], o)
+		if err != nil {
+			cond.Reason = consts.ReasonPolicyDriftUnknown
+			cond.Message = fmt.Sprintf("Failed to get the expected policies: %s", err)
+			return cond
+		}
+	}
+
+	var drifts []string
+	for _, c := range clients {
+		secret := c.GetTokenSecret()
+		if secret == nil || secret.Auth == nil {
+			continue
+		}
+
+		missing, unexpected := diffPolicies(expected, secret.Auth.Policies)
+		if len(missing) > 0 || len(unexpected) > 0 {
+			drifts = append(drifts, fmt.Sprintf("client=%s, missing=%v, unexpected=%v",
+				c.ID(), missing, unexpected))
+		}
+	}
+
+	if len(drifts) > 0 {
+		cond.Status = metav1.ConditionTrue
+		cond.Reason = consts.ReasonPolicyDriftDetected
+		cond.Message = strings.Join(drifts, "; ")
+		r.Recorder.Eventf(o, corev1.EventTypeWarning, consts.ReasonPolicyDriftDetected,
+			"Vault token policies have drifted from the expected policies: %s", cond.Message)
+	} else {
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = consts.ReasonNoPolicyDrift
+		cond.Message = fmt.Sprintf("The policies of %d Vault token(s) match the expected policies",
+			len(clients))
+	}
+
+	return cond
+}
+
+// readRolePolicies reads the token policies from the role of the VaultAuth's
+// auth method.
+func readRolePolicies(ctx context.Context, c vault.ClientBase, o *secretsv1beta1.VaultAuth) ([]string, error) {
+	var role string
+	// the roles of the cert auth method are named certs, the username and
+	// password methods have users instead.
+	roles := "role"
+	switch o.Spec.Method {
+	case vaultcredsconsts.ProviderMethodKubernetes:
+		if o.Spec.Kubernetes != nil {
+			role = o.Spec.Kubernetes.Role
+		}
+	case vaultcredsconsts.ProviderMethodJWT:
+		if o.Spec.JWT != nil {
+			role = o.Spec.JWT.Role
+		}
+	case vaultcredsconsts.ProviderMethodAWS:
+		if o.Spec.AWS != nil {
+			role = o.Spec.AWS.Role
+		}
+	case vaultcredsconsts.ProviderMethodGCP:
+		if o.Spec.GCP != nil {
+			role = o.Spec.GCP.Role
+		}
+	case vaultcredsconsts.ProviderMethodAzure:
+		if o.Spec.Azure != nil {
+			role = o.Spec.Azure.Role
+		}
+	case vaultcredsconsts.ProviderMethodCert:
+		if o.Spec.Cert != nil {
+			role = o.Spec.Cert.Name
+		}
+		roles = "certs"
+	case vaultcredsconsts.ProviderMethodLDAP:
+		if o.Spec.LDAP != nil {
+			role = o.Spec.LDAP.Username
+		}
+		roles = "users"
+	case vaultcredsconsts.ProviderMethodUserPass:
+		if o.Spec.UserPass != nil {
+			role = o.Spec.UserPass.Username
+		}
+		roles = "users"
+	case vaultcredsconsts.ProviderMethodOkta:
+		if o.Spec.Okta != nil {
+			role = o.Spec.Okta.Username
+		}
+		roles = "users"
+	}
+	if role == "" {
+		return nil, fmt.Errorf("the role of auth method %q is unknown, "+
+			"spec.policyDriftCheck.expectedPolicies must be set", o.Spec.Method)
+	}
+
+	path := fmt.Sprintf("auth/%s/%s/%s", strings.Trim(o.Spec.Mount, "/"), roles, role)
+	resp, err := c.Read(ctx, vault.NewReadRequest(path, nil))
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil || resp.Data() == nil {
+		return nil, fmt.Errorf("role %q not found", path)
+	}
+
+	var ret []string
+	// policies is the legacy name of token_policies.
+	for _, k := range []string{"token_policies", "policies"} {
+		v, ok := resp.Data()[k].([]any)
+		if !ok {
+			continue
+		}
+		for _, p := range v {
+			if s, ok := p.(string); ok {
+				ret = append(ret, s)
+			}
+		}
+	}
+
+	return ret, nil
+}
+
+// diffPolicies returns the expected policies that are missing from actual, and
+// the policies in actual that are not expected, both sorted. The default
+// policy is ignored.
+func diffPolicies(expected, actual []string) ([]string, []string) {
+	toSet := func(policies []string) map[string]bool {
+		ret := make(map[string]bool, len(policies))
+		for _, p := range policies {
+			if p != "default" {
+				ret[p] = true
+			}
+		}
+		return ret
+	}
+
+	e, a := toSet(expected), toSet(actual)
+	var missing, unexpected []string
+	for _, p := range slices.Sorted(maps.Keys(e)) {
+		if !a[p] {
+			missing = append(missing, p)
+		}
+	}
+	for _, p := range slices.Sorted(maps.Keys(a)) {
+		if !e[p] {
+			unexpected = append(unexpected, p)
+		}
+	}
+
+	return missing, unexpected
+}
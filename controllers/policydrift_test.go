@@ -0,0 +1,193 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/consts"
+	"github.com/hashicorp/vault-secrets-operator/vault"
+)
+
+type policyDriftClientFactory struct {
+	vault.CachingClientFactory
+	clients []vault.Client
+}
+
+func (f *policyDriftClientFactory) Clients(*secretsv1beta1.VaultAuth) []vault.Client {
+	return f.clients
+}
+
+type policyDriftClient struct {
+	vault.Client
+	mock     *vault.MockRecordingVaultClient
+	id       string
+	policies []string
+}
+
+func (c *policyDriftClient) ID() string {
+	return c.id
+}
+
+func (c *policyDriftClient) GetTokenSecret() *api.Secret {
+	return &api.Secret{
+		Auth: &api.SecretAuth{
+			Policies: c.policies,
+		},
+	}
+}
+
+func (c *policyDriftClient) Read(ctx context.Context, req vault.ReadRequest) (vault.Response, error) {
+	return c.mock.Read(ctx, req)
+}
+
+func Test_diffPolicies(t *testing.T) {
+	tests := []struct {
+		name           string
+		expected       []string
+		actual         []string
+		wantMissing    []string
+		wantUnexpected []string
+	}{
+		{
+			name:     "equal",
+			expected: []string{"b", "a"},
+			actual:   []string{"default", "a", "b"},
+		},
+		{
+			name:           "renamed",
+			expected:       []string{"app-v2", "db"},
+			actual:         []string{"default", "db", "app"},
+			wantMissing:    []string{"app-v2"},
+			wantUnexpected: []string{"app"},
+		},
+		{
+			name:        "removed-from-token",
+			expected:    []string{"app", "default"},
+			actual:      []string{"default"},
+			wantMissing: []string{"app"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			missing, unexpected := diffPolicies(tt.expected, tt.actual)
+			assert.Equal(t, tt.wantMissing, missing)
+			assert.Equal(t, tt.wantUnexpected, unexpected)
+		})
+	}
+}
+
+func TestVaultAuthReconciler_checkPolicyDrift(t *testing.T) {
+	ctx := context.Background()
+	roleResp := vault.NewDefaultResponse(&api.Secret{
+		Data: map[string]any{
+			"token_policies": []any{"app-v2"},
+		},
+	})
+
+	tests := []struct {
+		name          string
+		expected      []string
+		method        string
+		clients       []vault.Client
+		wantStatus    metav1.ConditionStatus
+		wantReason    string
+		wantMessage   string
+		wantRolePaths []string
+	}{
+		{
+			name:        "no-clients",
+			expected:    []string{"app"},
+			wantStatus:  metav1.ConditionUnknown,
+			wantReason:  consts.ReasonPolicyDriftUnknown,
+			wantMessage: "No Vault token has been issued for this VaultAuth yet",
+		},
+		{
+			name:     "expected-policies",
+			expected: []string{"app"},
+			clients: []vault.Client{
+				&policyDriftClient{id: "c1", policies: []string{"default", "app"}},
+			},
+			wantStatus:  metav1.ConditionFalse,
+			wantReason:  consts.ReasonNoPolicyDrift,
+			wantMessage: "The policies of 1 Vault token(s) match the expected policies",
+		},
+		{
+			name:   "role-policies-drift",
+			method: "kubernetes",
+			clients: []vault.Client{
+				&policyDriftClient{id: "c1", policies: []string{"default", "app-v2"}},
+				&policyDriftClient{id: "c2", policies: []string{"default", "app"}},
+			},
+			wantStatus:    metav1.ConditionTrue,
+			wantReason:    consts.ReasonPolicyDriftDetected,
+			wantMessage:   "client=c2, missing=[app-v2], unexpected=[app]",
+			wantRolePaths: []string{"auth/kubernetes/role/app"},
+		},
+		{
+			name:   "unknown-role",
+			method: "appRole",
+			clients: []vault.Client{
+				&policyDriftClient{id: "c1", policies: []string{"default", "app"}},
+			},
+			wantStatus: metav1.ConditionUnknown,
+			wantReason: consts.ReasonPolicyDriftUnknown,
+			wantMessage: `Failed to get the expected policies: the role of auth method "appRole" is unknown, ` +
+				"spec.policyDriftCheck.expectedPolicies must be set",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &vault.MockRecordingVaultClient{
+				ReadResponses: map[string][]vault.Response{
+					"auth/kubernetes/role/app": {roleResp},
+				},
+			}
+			for _, c := range tt.clients {
+				c.(*policyDriftClient).mock = mock
+			}
+			o := &secretsv1beta1.VaultAuth{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "foo",
+					Namespace:  "baz",
+					Generation: 2,
+				},
+				Spec: secretsv1beta1.VaultAuthSpec{
+					Method: tt.method,
+					Mount:  "kubernetes",
+					Kubernetes: &secretsv1beta1.VaultAuthConfigKubernetes{
+						Role: "app",
+					},
+					PolicyDriftCheck: &secretsv1beta1.VaultAuthPolicyDriftCheck{
+						ExpectedPolicies: tt.expected,
+					},
+				},
+			}
+			r := &VaultAuthReconciler{
+				Recorder:      record.NewFakeRecorder(10),
+				ClientFactory: &policyDriftClientFactory{clients: tt.clients},
+			}
+
+			got := r.checkPolicyDrift(ctx, o)
+			assert.Equal(t, secretsv1beta1.ConditionTypePolicyDrift, got.Type)
+			assert.Equal(t, int64(2), got.ObservedGeneration)
+			assert.Equal(t, tt.wantStatus, got.Status)
+			assert.Equal(t, tt.wantReason, got.Reason)
+			assert.Equal(t, tt.wantMessage, got.Message)
+
+			var paths []string
+			for _, req := range mock.Requests {
+				paths = append(paths, req.Path)
+			}
+			assert.Equal(t, tt.wantRolePaths, paths)
+		})
+	}
+}
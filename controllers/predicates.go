@@ -4,6 +4,7 @@
 package controllers
 
 import (
+	"maps"
 	"reflect"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -11,6 +12,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	"github.com/hashicorp/vault-secrets-operator/helpers"
+	"github.com/hashicorp/vault-secrets-operator/internal/diagnostics"
 )
 
 func syncableSecretPredicate(syncReg *SyncRegistry) predicate.Predicate {
@@ -29,7 +31,8 @@ type annotationChangedPredicate struct {
 }
 
 // Update implements default UpdateEvent filter for validating annotation change. On
-// change update the SyncRegistry if set.
+// change update the SyncRegistry if set. The diagnostics annotation is ignored,
+// since it is set by the Operator itself.
 func (p *annotationChangedPredicate) Update(e event.UpdateEvent) bool {
 	if e.ObjectOld == nil {
 		return false
@@ -38,7 +41,7 @@ func (p *annotationChangedPredicate) Update(e event.UpdateEvent) bool {
 		return false
 	}
 
-	if !reflect.DeepEqual(e.ObjectNew.GetAnnotations(), e.ObjectOld.GetAnnotations()) {
+	if !reflect.DeepEqual(syncAnnotations(e.ObjectNew), syncAnnotations(e.ObjectOld)) {
 		if p.syncReg != nil {
 			p.syncReg.Add(client.ObjectKeyFromObject(e.ObjectNew))
 		}
@@ -48,6 +51,21 @@ func (p *annotationChangedPredicate) Update(e event.UpdateEvent) bool {
 	return false
 }
 
+// syncAnnotations returns the annotations of obj that may affect its sync.
+func syncAnnotations(obj client.Object) map[string]string {
+	annotations := obj.GetAnnotations()
+	if _, ok := annotations[diagnostics.AnnotationDiagnostics]; !ok {
+		return annotations
+	}
+
+	annotations = maps.Clone(annotations)
+	delete(annotations, diagnostics.AnnotationDiagnostics)
+	if len(annotations) == 0 {
+		return nil
+	}
+	return annotations
+}
+
 type labelChangedPredicate struct {
 	syncReg *SyncRegistry
 	predicate.LabelChangedPredicate
@@ -17,6 +17,7 @@ import (
 
 	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
 	"github.com/hashicorp/vault-secrets-operator/helpers"
+	"github.com/hashicorp/vault-secrets-operator/internal/diagnostics"
 )
 
 type testCaseAnnoLabelChanged struct {
@@ -96,6 +97,25 @@ func Test_annotationChangedPredicate_Update(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name:    "no-update-diagnostics-with-sync-registry",
+			syncReg: NewSyncRegistry(),
+			evt: event.UpdateEvent{
+				ObjectOld: objectOldDefault,
+				ObjectNew: &secretsv1beta1.VaultDynamicSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "foo",
+						Annotations: map[string]string{
+							"foo":                             "baz",
+							diagnostics.AnnotationDiagnostics: "{}",
+						},
+					},
+				},
+			},
+			want:                   false,
+			wantRegistryObjectKeys: nil,
+		},
 		{
 			name:    "no-update-nil-old-object-with-sync-registry",
 			syncReg: NewSyncRegistry(),
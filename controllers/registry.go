@@ -21,6 +21,19 @@ const (
 	HCPVaultSecretsApp
 	VaultAuth
 	VaultAuthGlobal
+	VaultConsulSecret
+	VaultNomadSecret
+	VaultLDAPSecret
+	VaultRabbitMQSecret
+	VaultTransitSecret
+	VaultKubernetesSecret
+	VaultTerraformCloudSecret
+	VaultGenericSecret
+	VaultWrappedSecret
+	VaultIdentityToken
+	VaultMongoDBAtlasSecret
+	VaultSecretGroup
+	VaultConnection
 )
 
 func (k ResourceKind) String() string {
@@ -39,6 +52,32 @@ func (k ResourceKind) String() string {
 		return "VaultAuth"
 	case VaultAuthGlobal:
 		return "VaultAuthGlobal"
+	case VaultConsulSecret:
+		return "VaultConsulSecret"
+	case VaultNomadSecret:
+		return "VaultNomadSecret"
+	case VaultLDAPSecret:
+		return "VaultLDAPSecret"
+	case VaultRabbitMQSecret:
+		return "VaultRabbitMQSecret"
+	case VaultTransitSecret:
+		return "VaultTransitSecret"
+	case VaultKubernetesSecret:
+		return "VaultKubernetesSecret"
+	case VaultTerraformCloudSecret:
+		return "VaultTerraformCloudSecret"
+	case VaultGenericSecret:
+		return "VaultGenericSecret"
+	case VaultWrappedSecret:
+		return "VaultWrappedSecret"
+	case VaultIdentityToken:
+		return "VaultIdentityToken"
+	case VaultMongoDBAtlasSecret:
+		return "VaultMongoDBAtlasSecret"
+	case VaultSecretGroup:
+		return "VaultSecretGroup"
+	case VaultConnection:
+		return "VaultConnection"
 	default:
 		return "unknown"
 	}
@@ -0,0 +1,133 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/vault/api"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/consts"
+	vaultcredsconsts "github.com/hashicorp/vault-secrets-operator/credentials/vault/consts"
+	"github.com/hashicorp/vault-secrets-operator/vault"
+)
+
+// staticTokenCheckInterval is the maximum interval between two checks of the
+// static token of a VaultAuth.
+const staticTokenCheckInterval = 5 * time.Minute
+
+// checkStaticToken looks up the static token of the VaultAuth, from the secret
+// in the VaultAuth's namespace, and returns the resulting Degraded condition,
+// along with the duration after which the token should be checked again. The
+// token is checked again once it has expired, so that its expiry is reported
+// as soon as possible.
+func (r *VaultAuthReconciler) checkStaticToken(ctx context.Context, o *secretsv1beta1.VaultAuth, connObj *secretsv1beta1.VaultConnection) (metav1.Condition, time.Duration) {
+	cond := metav1.Condition{
+		Type:               secretsv1beta1.ConditionTypeDegraded,
+		Status:             metav1.ConditionUnknown,
+		ObservedGeneration: o.Generation,
+		Reason:             consts.ReasonStaticTokenUnknown,
+	}
+
+	key := client.ObjectKey{
+		Namespace: o.Namespace,
+		Name:      o.Spec.Token.SecretRef,
+	}
+	s := &corev1.Secret{}
+	if err := r.Client.Get(ctx, key, s); err != nil {
+		if apierrors.IsNotFound(err) {
+			// the secret may only exist in the namespaces of the consumers.
+			cond.Message = fmt.Sprintf("The static token's secret %s does not exist", key)
+		} else {
+			cond.Message = fmt.Sprintf("Failed to get the static token's secret %s: %s", key, err)
+		}
+		return cond, staticTokenCheckInterval
+	}
+
+	token := strings.TrimSpace(string(s.Data[vaultcredsconsts.ProviderSecretKeyToken]))
+	if token == "" && len(s.Data[vaultcredsconsts.ProviderSecretKeyTokenWrapped]) > 0 {
+		// the token is unwrapped on the first login.
+		cond.Message = fmt.Sprintf("The static token's secret %s holds a response-wrapping token, "+
+			"that has not been unwrapped yet", key)
+		return cond, staticTokenCheckInterval
+	}
+	if token == "" {
+		cond.Status = metav1.ConditionTrue
+		cond.Reason = consts.ReasonStaticTokenInvalid
+		cond.Message = fmt.Sprintf("No key %q found in the static token's secret %s",
+			vaultcredsconsts.ProviderSecretKeyToken, key)
+		return cond, staticTokenCheckInterval
+	}
+
+	secret, err := lookupStaticToken(ctx, r.Client, o, connObj, token)
+	if err != nil {
+		cond.Status = metav1.ConditionTrue
+		cond.Reason = consts.ReasonStaticTokenInvalid
+		cond.Message = fmt.Sprintf("The static token has expired, or is otherwise invalid: %s", err)
+		r.Recorder.Eventf(o, corev1.EventTypeWarning, consts.ReasonStaticTokenInvalid,
+			"The static token is invalid: %s", err)
+		return cond, staticTokenCheckInterval
+	}
+
+	ttl, err := secret.TokenTTL()
+	if err != nil {
+		cond.Message = fmt.Sprintf("Failed to get the static token's TTL: %s", err)
+		return cond, staticTokenCheckInterval
+	}
+	renewable, _ := secret.TokenIsRenewable()
+
+	cond.Status = metav1.ConditionFalse
+	cond.Reason = consts.ReasonStaticTokenValid
+	if ttl == 0 {
+		cond.Message = "The static token does not expire"
+		return cond, staticTokenCheckInterval
+	}
+
+	cond.Message = fmt.Sprintf("The static token is valid, renewable=%t", renewable)
+	if expireTime, ok := secret.Data["expire_time"].(string); ok && !renewable {
+		cond.Message = fmt.Sprintf("The static token is valid until %s, it is not renewable", expireTime)
+	}
+
+	return cond, min(ttl+time.Second, staticTokenCheckInterval)
+}
+
+// lookupStaticToken looks up token in Vault, the lookup fails if the token has
+// expired, or has been revoked.
+func lookupStaticToken(ctx context.Context, c client.Client, o *secretsv1beta1.VaultAuth, connObj *secretsv1beta1.VaultConnection, token string) (*api.Secret, error) {
+	cfg, err := vault.NewClientConfigFromConnObj(connObj, o.Spec.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	vc, err := vault.MakeVaultClient(ctx, cfg, c)
+	if err != nil {
+		return nil, err
+	}
+	vc.SetToken(token)
+	if len(o.Spec.Headers) > 0 {
+		headers := vc.Headers()
+		for k, v := range o.Spec.Headers {
+			headers[k] = []string{v}
+		}
+		vc.SetHeaders(headers)
+	}
+
+	secret, err := vc.Auth().Token().LookupSelfWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if secret == nil || secret.Data == nil {
+		return nil, fmt.Errorf("empty response from Vault, path=%q", "auth/token/lookup-self")
+	}
+
+	return secret, nil
+}
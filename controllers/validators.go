@@ -11,6 +11,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/helpers"
 	"github.com/hashicorp/vault-secrets-operator/template"
 )
 
@@ -44,6 +45,11 @@ func ValidateSecretTransformation(_ context.Context, o client.Object) error {
 				errs = errors.Join(errs, err)
 			}
 		}
+
+		// the tests can only pass once all the templates are valid.
+		if errs == nil {
+			errs = helpers.RunSecretTransformationTests(t)
+		}
 	default:
 		errs = errors.Join(errs, fmt.Errorf(
 			"unsupported type %T", t))
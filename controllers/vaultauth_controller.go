@@ -26,6 +26,7 @@ import (
 	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
 	"github.com/hashicorp/vault-secrets-operator/common"
 	"github.com/hashicorp/vault-secrets-operator/consts"
+	vaultcredsconsts "github.com/hashicorp/vault-secrets-operator/credentials/vault/consts"
 	"github.com/hashicorp/vault-secrets-operator/internal/metrics"
 	"github.com/hashicorp/vault-secrets-operator/vault"
 )
@@ -123,9 +124,31 @@ func (r *VaultAuthReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		errs = errors.Join(errs, err)
 	}
 
-	if _, err = common.GetVaultConnectionWithRetry(ctx, r.Client, connName, time.Millisecond*500, 60); err != nil {
+	connObj, err := common.GetVaultConnectionWithRetry(ctx, r.Client, connName, time.Millisecond*500, 60)
+	if err != nil {
 		errs = errors.Join(errs, err)
 		logger.Error(err, "Failed to find VaultConnectionRef")
+	} else if err := common.ValidateVaultConnectionNamespace(ctx, r.Client, connObj, o.Namespace); err != nil {
+		msg := "Invalid VaultConnectionRef"
+		logger.Error(err, msg)
+		r.recordEvent(o, consts.ReasonInvalidResourceRef, msg+": %s", err)
+		errs = errors.Join(errs, err)
+	}
+
+	if err := vault.ValidateLoginParams(o); err != nil {
+		errs = errors.Join(errs, err)
+	}
+
+	var driftCheckInterval time.Duration
+	if o.Spec.PolicyDriftCheck != nil {
+		interval := o.Spec.PolicyDriftCheck.Interval
+		if interval == "" {
+			interval = defaultPolicyDriftCheckInterval
+		}
+		driftCheckInterval, err = parseDurationString(interval, ".spec.policyDriftCheck.interval", time.Second)
+		if err != nil {
+			errs = errors.Join(errs, err)
+		}
 	}
 
 	// hash the VaultAuth.Spec so it can be used to determine if the VaultAuth
@@ -178,6 +201,17 @@ func (r *VaultAuthReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	} else {
 		o.Status.Valid = ptr.To(true)
 		o.Status.Error = ""
+		if o.Spec.PolicyDriftCheck != nil {
+			conditions = append(conditions, r.checkPolicyDrift(ctx, o))
+			horizon = computeHorizonWithJitter(driftCheckInterval)
+		}
+		if o.Spec.Method == vaultcredsconsts.ProviderMethodToken && o.Spec.Token != nil {
+			cond, d := r.checkStaticToken(ctx, o, connObj)
+			conditions = append(conditions, cond)
+			if horizon == 0 || d < horizon {
+				horizon = d
+			}
+		}
 	}
 
 	if err := r.updateStatus(ctx, o, conditions...); err != nil {
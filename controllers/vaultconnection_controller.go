@@ -6,17 +6,22 @@ package controllers
 import (
 	"context"
 	"errors"
+	"fmt"
 
+	"golang.org/x/crypto/blake2b"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
 	"github.com/hashicorp/vault-secrets-operator/consts"
@@ -32,6 +37,9 @@ type VaultConnectionReconciler struct {
 	Scheme        *runtime.Scheme
 	Recorder      record.EventRecorder
 	ClientFactory vault.CachingClientFactory
+	// referenceCache holds the CA certificate Secrets and ConfigMaps that are
+	// referenced by each VaultConnection.
+	referenceCache ResourceReferenceCache
 }
 
 // +kubebuilder:rbac:groups=secrets.hashicorp.com,resources=vaultconnections,verbs=get;list;watch;create;update;patch;delete
@@ -39,11 +47,14 @@ type VaultConnectionReconciler struct {
 // +kubebuilder:rbac:groups=secrets.hashicorp.com,resources=vaultconnections/finalizers,verbs=update
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
 // needed for managing cached Clients, duplicated in vaultauth_controller.go
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;delete;update;patch;deletecollection
 
 // Reconcile reconciles the secretsv1beta1.VaultConnection resource.
 // Upon a reconciliation it will verify that the configured Vault connection is valid.
+// The referent Vault Client(s) are pruned when the trusted CA certificates
+// change, so that they are recreated with the new certificates.
 //
 // Upon deletion of the resource, it will prune all referent Vault Client(s).
 func (r *VaultConnectionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
@@ -62,6 +73,7 @@ func (r *VaultConnectionReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	if o.GetDeletionTimestamp() != nil {
 		logger.Info("Got deletion timestamp", "obj", o)
 		metrics.DeleteResourceStatus("vaultconnection", o)
+		r.referenceCache.Remove(VaultConnection, req.NamespacedName)
 		return r.handleFinalizer(ctx, o)
 	}
 
@@ -75,6 +87,20 @@ func (r *VaultConnectionReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		}, err
 	}
 
+	var caRefs []client.ObjectKey
+	for _, name := range []string{o.Spec.CACertSecretRef, o.Spec.CACertConfigMapRef} {
+		if name != "" {
+			caRefs = append(caRefs, client.ObjectKey{Namespace: o.Namespace, Name: name})
+		}
+	}
+	r.referenceCache.Set(VaultConnection, req.NamespacedName, caRefs...)
+
+	// any error loading the CA certificates is reported by MakeVaultClient().
+	var caCertHash string
+	if b, err := vault.LoadCACertBundle(ctx, r.Client, vaultConfig); err == nil && len(b) > 0 {
+		caCertHash = fmt.Sprintf("%x", blake2b.Sum256(b))
+	}
+
 	var errs error
 	vaultClient, err := vault.MakeVaultClient(ctx, vaultConfig, r.Client)
 	if err != nil {
@@ -100,13 +126,25 @@ func (r *VaultConnectionReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	//
 	// Note: this is also done in controllers.VaultAuthReconciler
 	// TODO: consider adding a Predicate to the EventFilter, to filter events that do not result in a change to the Spec.
+	//
+	// All referent Clients are pruned when the CA certificates have changed,
+	// since they were set up with the previous certificates.
+	pruneAll := o.Status.CACertHash != "" && caCertHash != o.Status.CACertHash
 	if _, err := r.ClientFactory.Prune(ctx, r.Client, o, vault.CachingClientFactoryPruneRequest{
-		FilterFunc:   filterOldCacheRefs,
+		FilterFunc: func(cur, other client.Object) bool {
+			if pruneAll {
+				return filterAllCacheRefs(cur, other)
+			}
+			return filterOldCacheRefs(cur, other)
+		},
 		PruneStorage: true,
 	}); err != nil {
 		logger.Error(err, "Failed prune Client cache of older generations")
 		errs = errors.Join(errs, err)
+	} else if pruneAll {
+		logger.Info("CA certificates changed, pruned the referent Clients")
 	}
+	o.Status.CACertHash = caCertHash
 
 	if err := r.updateStatus(ctx, o); err != nil {
 		errs = errors.Join(errs, err)
@@ -151,10 +189,32 @@ func (r *VaultConnectionReconciler) handleFinalizer(ctx context.Context, o *secr
 	return ctrl.Result{}, nil
 }
 
+// connectionsForCACert maps a Secret or a ConfigMap to the VaultConnections
+// that reference it for their CA certificates.
+func (r *VaultConnectionReconciler) connectionsForCACert(_ context.Context, obj client.Object) []reconcile.Request {
+	var reqs []reconcile.Request
+	for _, objKey := range r.referenceCache.Get(VaultConnection, client.ObjectKeyFromObject(obj)) {
+		reqs = append(reqs, reconcile.Request{NamespacedName: objKey})
+	}
+
+	return reqs
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *VaultConnectionReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.referenceCache = newResourceReferenceCache()
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&secretsv1beta1.VaultConnection{}).
-		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		For(&secretsv1beta1.VaultConnection{},
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// the predicates are set per watch, since the CA certificates' data
+		// updates must not be filtered out.
+		WatchesMetadata(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.connectionsForCACert),
+		).
+		WatchesMetadata(
+			&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.connectionsForCACert),
+		).
 		Complete(r)
 }
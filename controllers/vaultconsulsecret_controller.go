@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+)
+
+const vaultConsulSecretFinalizer = "vaultconsulsecret.secrets.hashicorp.com/finalizer"
+
+var _ reconcile.Reconciler = &VaultConsulSecretReconciler{}
+
+// VaultConsulSecretReconciler reconciles a VaultConsulSecret object
+type VaultConsulSecretReconciler = LeasedSecretReconciler[*secretsv1beta1.VaultConsulSecret, vaultConsulSecretKind]
+
+// +kubebuilder:rbac:groups=secrets.hashicorp.com,resources=vaultconsulsecrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=secrets.hashicorp.com,resources=vaultconsulsecrets/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=secrets.hashicorp.com,resources=vaultconsulsecrets/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+
+// vaultConsulSecretKind ensures that the VaultConsulSecret Custom Resource is
+// synced from Vault's Consul secrets engine to its configured Kubernetes
+// secret. The Consul ACL token's lease is renewed periodically, if the renewal
+// fails or the lease is not renewable, a new ACL token is issued.
+type vaultConsulSecretKind struct{}
+
+func (vaultConsulSecretKind) resourceKind() ResourceKind {
+	return VaultConsulSecret
+}
+
+func (vaultConsulSecretKind) finalizer() string {
+	return vaultConsulSecretFinalizer
+}
+
+func (vaultConsulSecretKind) newObject() *secretsv1beta1.VaultConsulSecret {
+	return &secretsv1beta1.VaultConsulSecret{}
+}
+
+func (vaultConsulSecretKind) newLeasedSecret(o *secretsv1beta1.VaultConsulSecret) (*leasedSecret, error) {
+	return newVaultConsulLeasedSecret(o), nil
+}
+
+func newVaultConsulLeasedSecret(o *secretsv1beta1.VaultConsulSecret) *leasedSecret {
+	mount := strings.Trim(o.Spec.Mount, "/")
+	if mount == "" {
+		mount = "consul"
+	}
+
+	return &leasedSecret{
+		obj:            o,
+		path:           fmt.Sprintf("%s/creds/%s", mount, o.Spec.Role),
+		method:         http.MethodGet,
+		renewalPercent: o.Spec.RenewalPercent,
+		revoke:         o.Spec.Revoke,
+		destination:    &o.Spec.Destination,
+		status:         &o.Status.VaultLeasedSecretStatus,
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/hashicorp/vault/api"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -36,6 +37,11 @@ import (
 	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
 	"github.com/hashicorp/vault-secrets-operator/consts"
 	"github.com/hashicorp/vault-secrets-operator/helpers"
+	"github.com/hashicorp/vault-secrets-operator/internal/cloudevents"
+	"github.com/hashicorp/vault-secrets-operator/internal/diagnostics"
+	"github.com/hashicorp/vault-secrets-operator/internal/issuance"
+	"github.com/hashicorp/vault-secrets-operator/internal/standbyrenewal"
+	"github.com/hashicorp/vault-secrets-operator/template"
 
 	"github.com/hashicorp/vault-secrets-operator/vault"
 )
@@ -73,6 +79,14 @@ type VaultDynamicSecretReconciler struct {
 	// OPERATOR_POD_UID environment variable, or the /var/run/podinfo/uid file; in that order.
 	runtimePodUID types.UID
 	SecretsClient client.Client
+	// MinLeaseDuration is the minimum lease duration of the synced secrets.
+	// Secrets with a shorter lease are revoked and never synced, since they
+	// would expire before they could be renewed. Disabled when zero.
+	MinLeaseDuration time.Duration
+	// StandbyRenewals assigns the renewal of the renewable leases to the
+	// standby replicas in a VaultLeaseAssignment, should the leader fail to
+	// renew them in time.
+	StandbyRenewals bool
 }
 
 // +kubebuilder:rbac:groups=secrets.hashicorp.com,resources=vaultdynamicsecrets,verbs=get;list;watch;create;update;patch;delete
@@ -87,6 +101,10 @@ type VaultDynamicSecretReconciler struct {
 // +kubebuilder:rbac:groups=apps,resources=daemonsets,verbs=get;list;watch;patch
 // +kubebuilder:rbac:groups=argoproj.io,resources=rollouts,verbs=get;list;watch;patch
 //
+// required for standby renewals
+// +kubebuilder:rbac:groups=secrets.hashicorp.com,resources=vaultleaseassignments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=secrets.hashicorp.com,resources=vaultleaseassignments/status,verbs=get;update;patch
+//
 // needed for managing cached Clients, duplicated in vaultconnection_controller.go
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;delete;update;patch
 
@@ -124,8 +142,9 @@ func (r *VaultDynamicSecretReconciler) Reconcile(ctx context.Context, req ctrl.R
 	}
 
 	r.referenceCache.Set(SecretTransformation, req.NamespacedName,
-		helpers.GetTransformationRefObjKeys(
-			o.Spec.Destination.Transformation, o.Namespace)...)
+		helpers.GetDestinationsTransformationRefObjKeys(
+			append([]secretsv1beta1.Destination{o.Spec.Destination}, o.Spec.AdditionalDestinations...),
+			o.Namespace)...)
 
 	destExists, _ := helpers.CheckSecretExists(ctx, r.Client, o)
 	if !o.Spec.Destination.Create && !destExists {
@@ -136,7 +155,7 @@ func (r *VaultDynamicSecretReconciler) Reconcile(ctx context.Context, req ctrl.R
 
 	vClient, err := r.ClientFactory.Get(ctx, r.Client, o)
 	if err != nil {
-		r.Recorder.Eventf(o, corev1.EventTypeWarning, consts.ReasonVaultClientConfigError,
+		r.recordSyncError(ctx, o, consts.ReasonVaultClientConfigError,
 			"Failed to get Vault client: %s", err)
 		return ctrl.Result{RequeueAfter: computeHorizonWithJitter(requeueDurationOnError)}, nil
 	}
@@ -180,6 +199,16 @@ func (r *VaultDynamicSecretReconciler) Reconcile(ctx context.Context, req ctrl.R
 
 	doSync := syncReason != ""
 	leaseID := o.Status.SecretLease.ID
+	if r.StandbyRenewals && !doSync && leaseID != "" && !o.Spec.AllowStaticCreds {
+		if horizon, ok := r.adoptStandbyRenewal(ctx, o); ok {
+			if err := r.updateStatus(ctx, o); err != nil {
+				return ctrl.Result{}, err
+			}
+			r.assignStandbyRenewal(ctx, o, horizon)
+			return ctrl.Result{RequeueAfter: horizon}, nil
+		}
+	}
+
 	if !doSync && r.runtimePodUID != "" && r.runtimePodUID != o.Status.LastRuntimePodUID {
 		// don't take part in the thundering herd on start up,
 		// and the lease is still within the renewal window.
@@ -229,9 +258,6 @@ func (r *VaultDynamicSecretReconciler) Reconcile(ctx context.Context, req ctrl.R
 			o.Status.StaticCredsMetaData = secretsv1beta1.VaultStaticCredsMetaData{}
 			o.Status.SecretLease = *secretLease
 			o.Status.LastRenewalTime = nowFunc().Unix()
-			if err := r.updateStatus(ctx, o); err != nil {
-				return ctrl.Result{}, err
-			}
 
 			leaseDuration := time.Duration(secretLease.LeaseDuration) * time.Second
 			if leaseDuration < 1 {
@@ -240,8 +266,17 @@ func (r *VaultDynamicSecretReconciler) Reconcile(ctx context.Context, req ctrl.R
 				leaseDuration = time.Second * 5
 			}
 			horizon := computeDynamicHorizonWithJitter(leaseDuration, o.Spec.RenewalPercent)
+			o.Status.LastSyncMessages = appendSyncMessage(o.Status.LastSyncMessages,
+				secretsv1beta1.SyncResultSuccess, consts.ReasonSecretLeaseRenewal,
+				"Renewed lease, lease_id=%s, horizon=%s", leaseID, horizon)
+			if err := r.updateStatus(ctx, o); err != nil {
+				return ctrl.Result{}, err
+			}
+			r.assignStandbyRenewal(ctx, o, horizon)
+
 			r.Recorder.Eventf(o, corev1.EventTypeNormal, consts.ReasonSecretLeaseRenewal,
 				"Renewed lease, lease_id=%s, horizon=%s", leaseID, horizon)
+			cloudevents.Emit(ctx, cloudevents.TypeLeaseRenewed, o, cloudevents.LeaseData(*secretLease))
 			return ctrl.Result{RequeueAfter: horizon}, nil
 		} else {
 			var e *LeaseTruncatedError
@@ -256,6 +291,9 @@ func (r *VaultDynamicSecretReconciler) Reconcile(ctx context.Context, req ctrl.R
 				logger.V(consts.LogLevelWarning).Info("Tainting client", "err", err)
 				vClient.Taint()
 			}
+			if vault.IsLeaseNotFoundError(err) {
+				cloudevents.Emit(ctx, cloudevents.TypeLeaseExpired, o, cloudevents.LeaseData(o.Status.SecretLease))
+			}
 			syncReason = consts.ReasonSecretLeaseRenewalError
 		}
 	}
@@ -267,14 +305,26 @@ func (r *VaultDynamicSecretReconciler) Reconcile(ctx context.Context, req ctrl.R
 
 	transOption, err := helpers.NewSecretTransformationOption(ctx, r.Client, o, r.GlobalTransformationOptions)
 	if err != nil {
-		r.Recorder.Eventf(o, corev1.EventTypeWarning, consts.ReasonTransformationError,
+		r.recordSyncError(ctx, o, consts.ReasonTransformationError,
 			"Failed setting up SecretTransformationOption: %s", err)
 		return ctrl.Result{RequeueAfter: computeHorizonWithJitter(requeueDurationOnError)}, nil
 	}
 
 	// sync the secret
 	secretLease, staticCredsUpdated, err := r.syncSecret(ctx, vClient, o, transOption)
-	if err != nil {
+	setIssuingCondition(&o.Status.Conditions, o.GetGeneration(), err)
+	if errors.Is(err, issuance.ErrIssuing) {
+		// the status is patched, rather than updated, since the secret has not
+		// been synced for the resource's generation yet.
+		logger.V(consts.LogLevelDebug).Info("Secret is being issued")
+		if err := patchSyncMessages(ctx, r.Client, o, o.Status.LastSyncMessages, o.Status.Conditions); err != nil {
+			return ctrl.Result{}, err
+		}
+		r.SyncRegistry.Add(req.NamespacedName)
+		return ctrl.Result{
+			RequeueAfter: issuance.PollInterval,
+		}, nil
+	} else if err != nil {
 		r.SyncRegistry.Add(req.NamespacedName)
 		if vault.IsForbiddenError(err) {
 			logger.V(consts.LogLevelWarning).Info("Tainting client", "err", err)
@@ -282,7 +332,7 @@ func (r *VaultDynamicSecretReconciler) Reconcile(ctx context.Context, req ctrl.R
 		}
 		entry, _ := r.BackOffRegistry.Get(req.NamespacedName)
 		horizon := entry.NextBackOff()
-		r.Recorder.Eventf(o, corev1.EventTypeWarning, consts.ReasonSecretSyncError,
+		r.recordSyncError(ctx, o, syncSecretErrorReason(err),
 			"Failed to sync the secret, horizon=%s, err=%s", horizon, err)
 		return ctrl.Result{
 			RequeueAfter: horizon,
@@ -292,21 +342,32 @@ func (r *VaultDynamicSecretReconciler) Reconcile(ctx context.Context, req ctrl.R
 	}
 
 	doRolloutRestart := (doSync && o.Status.LastGeneration > 1) || staticCredsUpdated
+	if doRolloutRestart {
+		// rollout-restart errors are not retryable
+		// all error reporting is handled by helpers.HandleRolloutRestarts
+		// it is called prior to updating the status, since it may set a condition.
+		_ = helpers.HandleRolloutRestarts(ctx, r.Client, o, r.Recorder)
+	}
 	o.Status.SecretLease = *secretLease
 	o.Status.LastRenewalTime = nowFunc().Unix()
+	horizon := r.computePostSyncHorizon(ctx, o)
+	o.Status.LastSyncMessages = appendSyncMessage(o.Status.LastSyncMessages,
+		secretsv1beta1.SyncResultSuccess, reason,
+		"Secret synced, lease_id=%q, horizon=%s, sync_reason=%q",
+		secretLease.ID, horizon, syncReason)
 	if err := r.updateStatus(ctx, o); err != nil {
 		return ctrl.Result{}, err
 	}
+	r.assignStandbyRenewal(ctx, o, horizon)
 
-	horizon := r.computePostSyncHorizon(ctx, o)
 	r.Recorder.Eventf(o, corev1.EventTypeNormal, reason,
 		"Secret synced, lease_id=%q, horizon=%s, sync_reason=%q",
 		secretLease.ID, horizon, syncReason)
-
-	if doRolloutRestart {
-		// rollout-restart errors are not retryable
-		// all error reporting is handled by helpers.HandleRolloutRestarts
-		_ = helpers.HandleRolloutRestarts(ctx, r.Client, o, r.Recorder)
+	if secretLease.ID != "" {
+		cloudevents.Emit(ctx, cloudevents.TypeLeaseIssued, o, cloudevents.LeaseData(*secretLease))
+	}
+	if reason == consts.ReasonSecretRotated {
+		cloudevents.Emit(ctx, cloudevents.TypeSecretRotated, o, cloudevents.Data{})
 	}
 
 	if ok := r.SyncRegistry.Delete(req.NamespacedName); ok {
@@ -336,6 +397,12 @@ func (r *VaultDynamicSecretReconciler) isRenewableLease(secretLease *secretsv1be
 }
 
 func (r *VaultDynamicSecretReconciler) isStaticCreds(meta *secretsv1beta1.VaultStaticCredsMetaData) bool {
+	return isStaticCreds(meta)
+}
+
+// isStaticCreds returns true if meta was derived from a response that supports
+// static-creds semantics.
+func isStaticCreds(meta *secretsv1beta1.VaultStaticCredsMetaData) bool {
 	// the ldap and database engines have minimum rotation period of 5s, requiring a
 	// minimum of 1s should be okay here.
 	return meta.LastVaultRotation > 0 && (meta.RotationPeriod >= 1 || meta.RotationSchedule != "")
@@ -347,12 +414,23 @@ func (r *VaultDynamicSecretReconciler) doVault(ctx context.Context, c vault.Clie
 	var err error
 	var resp vault.Response
 	var params map[string]any
-	paramsLen := len(o.Spec.Params)
+	paramsLen := len(o.Spec.Params) + len(o.Spec.RequestData)
 	if paramsLen > 0 {
 		params = make(map[string]any, paramsLen)
 		for k, v := range o.Spec.Params {
 			params[k] = v
 		}
+
+		data, err := renderRequestData(o)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range data {
+			if _, ok := params[k]; ok {
+				return nil, fmt.Errorf("requestData key %q is also set in params", k)
+			}
+			params[k] = v
+		}
 	}
 
 	method := o.Spec.RequestHTTPMethod
@@ -360,7 +438,7 @@ func (r *VaultDynamicSecretReconciler) doVault(ctx context.Context, c vault.Clie
 	if params != nil {
 		if !(method == http.MethodPost || method == http.MethodPut) {
 			logger.V(consts.LogLevelWarning).Info(
-				"Params provided, ignoring specified method",
+				"Params or RequestData provided, ignoring specified method",
 				"requestHTTPMethod", o.Spec.RequestHTTPMethod)
 		}
 		method = http.MethodPut
@@ -391,12 +469,56 @@ func (r *VaultDynamicSecretReconciler) doVault(ctx context.Context, c vault.Clie
 	return resp, nil
 }
 
+// requestDataInput is the input of the VaultDynamicSecret's RequestData
+// templates.
+type requestDataInput struct {
+	Name        string
+	Namespace   string
+	Annotations map[string]string
+	Labels      map[string]string
+}
+
+// renderRequestData renders the VaultDynamicSecret's RequestData templates.
+func renderRequestData(o *secretsv1beta1.VaultDynamicSecret) (map[string]string, error) {
+	if len(o.Spec.RequestData) == 0 {
+		return nil, nil
+	}
+
+	input := &requestDataInput{
+		Name:        o.Name,
+		Namespace:   o.Namespace,
+		Annotations: o.GetAnnotations(),
+		Labels:      o.GetLabels(),
+	}
+
+	ret := make(map[string]string, len(o.Spec.RequestData))
+	for k, text := range o.Spec.RequestData {
+		t := template.NewSecretTemplate(k)
+		if err := t.Parse(k, text); err != nil {
+			return nil, fmt.Errorf("invalid requestData template %q: %w", k, err)
+		}
+
+		b, err := t.ExecuteTemplate(k, input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render requestData template %q: %w", k, err)
+		}
+		ret[k] = string(b)
+	}
+
+	return ret, nil
+}
+
 func (r *VaultDynamicSecretReconciler) syncSecret(ctx context.Context, c vault.ClientBase,
 	o *secretsv1beta1.VaultDynamicSecret, opt *helpers.SecretTransformationOption,
 ) (*secretsv1beta1.VaultSecretLease, bool, error) {
 	logger := log.FromContext(ctx).WithName("syncSecret")
 
-	resp, err := r.doVault(ctx, c, o)
+	// the issuance may outlive this reconciliation, so it must not share o.
+	issued := o.DeepCopy()
+	resp, err := issuance.Do(ctx, client.ObjectKeyFromObject(o).String(), o.GetGeneration(),
+		func(ctx context.Context) (vault.Response, error) {
+			return r.doVault(ctx, c, issued)
+		})
 	if err != nil {
 		return nil, false, err
 	}
@@ -439,13 +561,32 @@ func (r *VaultDynamicSecretReconciler) syncSecret(ctx context.Context, c vault.C
 		o.Status.StaticCredsMetaData = *staticCredsMeta
 		logger.V(consts.LogLevelDebug).Info("Static creds", "status", o.Status)
 	} else {
+		err = checkLeaseDuration(secretLease, r.MinLeaseDuration)
+		setLeaseTooShortCondition(&o.Status.Conditions, o.GetGeneration(), err)
+		if err != nil {
+			// the secret is never synced, so its lease is of no use.
+			if secretLease.ID != "" {
+				r.revokeLeaseWithClient(ctx, c, o, secretLease.ID)
+			}
+			return nil, false, err
+		}
+
 		data, err = resp.SecretK8sData(opt)
 		if err != nil {
 			return nil, false, err
 		}
 	}
 
-	if err := helpers.SyncSecret(ctx, r.Client, o, data); err != nil {
+	err = helpers.SyncSecret(ctx, r.Client, o, data)
+	if err == nil && len(o.Spec.AdditionalDestinations) > 0 {
+		err = helpers.SyncAdditionalDestinations(ctx, r.Client, o, r.GlobalTransformationOptions,
+			func(opt *helpers.SecretTransformationOption) (map[string][]byte, error) {
+				return resp.SecretK8sData(opt)
+			})
+	}
+	helpers.SetDestinationConflictCondition(&o.Status.Conditions, o.GetGeneration(), err)
+	helpers.SetFanOutDeniedCondition(&o.Status.Conditions, o.GetGeneration(), err)
+	if err != nil {
 		logger.Error(err, "Destination sync failed")
 		return nil, false, err
 	}
@@ -453,6 +594,53 @@ func (r *VaultDynamicSecretReconciler) syncSecret(ctx context.Context, c vault.C
 	return secretLease, true, nil
 }
 
+// checkLeaseDuration returns a LeaseTooShortError if the duration of lease is
+// less than minimum. Leases without a duration are not checked, and neither
+// are any leases when minimum is zero.
+func checkLeaseDuration(lease *secretsv1beta1.VaultSecretLease, minimum time.Duration) error {
+	if minimum <= 0 || lease.LeaseDuration <= 0 {
+		return nil
+	}
+
+	d := time.Duration(lease.LeaseDuration) * time.Second
+	if d < minimum {
+		return &LeaseTooShortError{
+			Duration: d,
+			Minimum:  minimum,
+		}
+	}
+	return nil
+}
+
+// setLeaseTooShortCondition sets the LeaseTooShort condition to True if
+// checkErr is a LeaseTooShortError, otherwise the condition is set to False,
+// if it was previously set.
+func setLeaseTooShortCondition(conditions *[]metav1.Condition, generation int64, checkErr error) {
+	var leaseErr *LeaseTooShortError
+	if errors.As(checkErr, &leaseErr) {
+		meta.SetStatusCondition(conditions, metav1.Condition{
+			Type:               secretsv1beta1.ConditionTypeLeaseTooShort,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: generation,
+			Reason:             consts.ReasonLeaseTooShort,
+			Message:            leaseErr.Error(),
+		})
+		return
+	}
+
+	if meta.FindStatusCondition(*conditions, secretsv1beta1.ConditionTypeLeaseTooShort) == nil {
+		return
+	}
+
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               secretsv1beta1.ConditionTypeLeaseTooShort,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: generation,
+		Reason:             consts.ReasonSecretSynced,
+		Message:            "Lease duration can be honored",
+	})
+}
+
 // awaitVaultSecretRotation waits for the Vault secret to be rotated. This is
 // necessary for the case where the Vault secret is a static-creds secret and includes
 // a rotation schedule.
@@ -547,6 +735,20 @@ func (r *VaultDynamicSecretReconciler) awaitVaultSecretRotation(ctx context.Cont
 	return staticCredsMeta, resp, nil
 }
 
+// recordSyncError emits a warning event for the failed sync attempt and records
+// it in the resource's Status.LastSyncMessages. Only the sync messages are
+// patched, the remaining status fields are left as they were prior to the
+// failed sync.
+func (r *VaultDynamicSecretReconciler) recordSyncError(ctx context.Context, o *secretsv1beta1.VaultDynamicSecret, reason, msg string, a ...any) {
+	r.Recorder.Eventf(o, corev1.EventTypeWarning, reason, msg, a...)
+	messages := appendSyncMessage(o.Status.LastSyncMessages,
+		secretsv1beta1.SyncResultFailure, reason, msg, a...)
+	if err := patchSyncMessages(ctx, r.Client, o, messages, o.Status.Conditions); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to record the sync error in the resource's status")
+	}
+	diagnostics.RecordFailure(ctx, r.Client, o, messages, o.Status.Conditions, reason, a...)
+}
+
 func (r *VaultDynamicSecretReconciler) updateStatus(ctx context.Context, o *secretsv1beta1.VaultDynamicSecret) error {
 	if r.runtimePodUID != "" {
 		o.Status.LastRuntimePodUID = r.runtimePodUID
@@ -594,6 +796,68 @@ func (r *VaultDynamicSecretReconciler) renewLease(
 	return r.getVaultSecretLease(resp.Secret()), nil
 }
 
+// adoptStandbyRenewal records the renewal of the current lease of o by a
+// standby replica, if it is more recent than the last renewal. It returns the
+// horizon of the next renewal, and false if the lease is due for renewal
+// regardless. Truncated standby renewals are never adopted, the leader renews
+// the lease again to detect the truncation.
+func (r *VaultDynamicSecretReconciler) adoptStandbyRenewal(ctx context.Context, o *secretsv1beta1.VaultDynamicSecret) (time.Duration, bool) {
+	logger := log.FromContext(ctx)
+	renewal, err := standbyrenewal.Adopt(ctx, r.Client, o)
+	if err != nil {
+		logger.Error(err, "Failed to get the standby renewal of the lease")
+		return 0, false
+	}
+	if renewal == nil || renewal.LeaseDuration < o.Status.SecretLease.LeaseDuration {
+		return 0, false
+	}
+
+	leaseDuration := time.Duration(renewal.LeaseDuration) * time.Second
+	if leaseDuration < 1 {
+		leaseDuration = time.Second * 5
+	}
+	horizon := computeDynamicHorizonWithJitter(leaseDuration, o.Spec.RenewalPercent) - nowFunc().Sub(renewal.RenewedAt)
+	if horizon <= 0 {
+		return 0, false
+	}
+
+	o.Status.SecretLease.LeaseDuration = renewal.LeaseDuration
+	o.Status.LastRenewalTime = renewal.RenewedAt.Unix()
+	o.Status.LastSyncMessages = appendSyncMessage(o.Status.LastSyncMessages,
+		secretsv1beta1.SyncResultSuccess, consts.ReasonSecretLeaseRenewal,
+		"Adopted lease renewal, lease_id=%s, holder=%s, horizon=%s",
+		o.Status.SecretLease.ID, renewal.Holder, horizon)
+	r.Recorder.Eventf(o, corev1.EventTypeNormal, consts.ReasonSecretLeaseRenewal,
+		"Adopted lease renewal, lease_id=%s, holder=%s, horizon=%s",
+		o.Status.SecretLease.ID, renewal.Holder, horizon)
+	cloudevents.Emit(ctx, cloudevents.TypeLeaseRenewed, o, cloudevents.LeaseData(o.Status.SecretLease))
+
+	return horizon, true
+}
+
+// assignStandbyRenewal assigns the renewal of the current lease of o to the
+// standby replicas, once past the horizon of the leader's own renewal. Leases
+// that are not renewable are unassigned. Errors are only logged, the leader
+// remains responsible for the renewal.
+func (r *VaultDynamicSecretReconciler) assignStandbyRenewal(ctx context.Context, o *secretsv1beta1.VaultDynamicSecret, horizon time.Duration) {
+	if !r.StandbyRenewals {
+		return
+	}
+
+	var err error
+	lease := o.Status.SecretLease
+	if lease.ID != "" && lease.Renewable && !o.Spec.AllowStaticCreds && horizon > 0 {
+		renewAfter := standbyrenewal.RenewAfter(nowFunc(), horizon,
+			time.Duration(lease.LeaseDuration)*time.Second)
+		err = standbyrenewal.Assign(ctx, r.Client, o, renewAfter)
+	} else {
+		err = standbyrenewal.Unassign(ctx, r.Client, o)
+	}
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to update the standby renewal of the lease")
+	}
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *VaultDynamicSecretReconciler) SetupWithManager(mgr ctrl.Manager, opts controller.Options) error {
 	r.referenceCache = newResourceReferenceCache()
@@ -657,7 +921,13 @@ func (r *VaultDynamicSecretReconciler) handleDeletion(ctx context.Context, o *se
 	objKey := client.ObjectKeyFromObject(o)
 	r.SyncRegistry.Delete(objKey)
 	r.BackOffRegistry.Delete(objKey)
+	issuance.Forget(objKey.String())
 	r.referenceCache.Remove(SecretTransformation, objKey)
+	helpers.DeleteSecretlessData(o)
+	if err := helpers.HandleDestinationDeletion(ctx, r.Client, o); err != nil {
+		logger.Error(err, "Failed to apply the deletion policy of the destination Secrets")
+		return err
+	}
 	if controllerutil.ContainsFinalizer(o, vaultDynamicSecretFinalizer) {
 		logger.Info("Removing finalizer")
 		if controllerutil.RemoveFinalizer(o, vaultDynamicSecretFinalizer) {
@@ -682,13 +952,21 @@ func (r *VaultDynamicSecretReconciler) revokeLease(ctx context.Context, o *secre
 	if leaseID == "" {
 		leaseID = o.Status.SecretLease.ID
 	}
-	logger.Info("Revoking lease for credential ", "id", leaseID)
 	c, err := r.ClientFactory.Get(ctx, r.Client, o)
 	if err != nil {
 		logger.Error(err, "Failed to get client when revoking lease for ", "id", leaseID)
 		return
 	}
-	if _, err = c.Write(ctx, vault.NewWriteRequest("/sys/leases/revoke", map[string]any{
+	r.revokeLeaseWithClient(ctx, c, o, leaseID)
+}
+
+// revokeLeaseWithClient revokes the lease leaseID with the Vault client c.
+func (r *VaultDynamicSecretReconciler) revokeLeaseWithClient(ctx context.Context, c vault.ClientBase,
+	o *secretsv1beta1.VaultDynamicSecret, leaseID string,
+) {
+	logger := log.FromContext(ctx)
+	logger.Info("Revoking lease for credential ", "id", leaseID)
+	if _, err := c.Write(ctx, vault.NewWriteRequest("/sys/leases/revoke", map[string]any{
 		"lease_id": leaseID,
 	})); err != nil {
 		msg := "Failed to revoke lease"
@@ -697,6 +975,7 @@ func (r *VaultDynamicSecretReconciler) revokeLease(ctx context.Context, o *secre
 	} else {
 		msg := "Lease revoked"
 		r.Recorder.Eventf(o, corev1.EventTypeNormal, consts.ReasonSecretLeaseRevoke, msg+": %s", leaseID)
+		cloudevents.Emit(ctx, cloudevents.TypeLeaseRevoked, o, cloudevents.Data{LeaseID: leaseID})
 		logger.Info("Lease revoked ", "id", leaseID)
 	}
 }
@@ -14,8 +14,10 @@ import (
 	"github.com/hashicorp/vault/api"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -552,6 +554,95 @@ func TestVaultDynamicSecretReconciler_syncSecret(t *testing.T) {
 					"unsupported HTTP method %q for sync", http.MethodOptions), i...)
 			},
 		},
+		{
+			name: "with-request-data",
+			fields: fields{
+				Client:        fake.NewClientBuilder().Build(),
+				runtimePodUID: "",
+			},
+			args: args{
+				ctx:     nil,
+				vClient: &vault.MockRecordingVaultClient{},
+				o: &secretsv1beta1.VaultDynamicSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "baz",
+						Namespace: "default",
+						Labels: map[string]string{
+							"app": "web",
+						},
+					},
+					Spec: secretsv1beta1.VaultDynamicSecretSpec{
+						Mount:             "transit",
+						Path:              "sign/app",
+						RequestHTTPMethod: http.MethodGet,
+						Params: map[string]string{
+							"hash_algorithm": "sha2-256",
+						},
+						RequestData: map[string]string{
+							"input": `{{ printf "%s/%s" .Namespace .Labels.app | b64enc }}`,
+						},
+						Destination: secretsv1beta1.Destination{
+							Name:   "baz",
+							Create: true,
+						},
+					},
+					Status: secretsv1beta1.VaultDynamicSecretStatus{},
+				},
+			},
+			want: &secretsv1beta1.VaultSecretLease{
+				LeaseDuration: 0,
+				Renewable:     false,
+			},
+			expectRequests: []*vault.MockRequest{
+				{
+					Method: http.MethodPut,
+					Path:   "transit/sign/app",
+					Params: map[string]any{
+						"hash_algorithm": "sha2-256",
+						"input":          "ZGVmYXVsdC93ZWI=",
+					},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "with-request-data-in-params",
+			fields: fields{
+				Client:        fake.NewClientBuilder().Build(),
+				runtimePodUID: "",
+			},
+			args: args{
+				ctx:     nil,
+				vClient: &vault.MockRecordingVaultClient{},
+				o: &secretsv1beta1.VaultDynamicSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "baz",
+						Namespace: "default",
+					},
+					Spec: secretsv1beta1.VaultDynamicSecretSpec{
+						Mount: "baz",
+						Path:  "foo",
+						Params: map[string]string{
+							"qux": "bar",
+						},
+						RequestData: map[string]string{
+							"qux": "{{ .Name }}",
+						},
+						Destination: secretsv1beta1.Destination{
+							Name:   "baz",
+							Create: true,
+						},
+					},
+					Status: secretsv1beta1.VaultDynamicSecretStatus{},
+				},
+			},
+			want:           nil,
+			expectRequests: nil,
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.EqualError(t, err,
+					`requestData key "qux" is also set in params`, i...)
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -568,6 +659,132 @@ func TestVaultDynamicSecretReconciler_syncSecret(t *testing.T) {
 	}
 }
 
+func TestVaultDynamicSecretReconciler_syncSecret_minLeaseDuration(t *testing.T) {
+	ctx := context.Background()
+	newResponse := func(leaseDuration int) vault.Response {
+		return vault.NewDefaultResponse(&api.Secret{
+			LeaseID:       "baz/foo/lease",
+			LeaseDuration: leaseDuration,
+			Renewable:     true,
+			Data: map[string]any{
+				"password": "s3cr3t",
+			},
+		})
+	}
+	o := &secretsv1beta1.VaultDynamicSecret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "baz",
+			Namespace:  "default",
+			Generation: 1,
+		},
+		Spec: secretsv1beta1.VaultDynamicSecretSpec{
+			Mount: "baz",
+			Path:  "foo",
+			Destination: secretsv1beta1.Destination{
+				Name:   "baz",
+				Create: true,
+			},
+		},
+	}
+	r := &VaultDynamicSecretReconciler{
+		Client:           testutils.NewFakeClientBuilder().Build(),
+		Recorder:         record.NewFakeRecorder(10),
+		MinLeaseDuration: time.Second * 10,
+	}
+
+	vClient := &vault.MockRecordingVaultClient{
+		ReadResponses: map[string][]vault.Response{
+			"baz/foo": {newResponse(5)},
+		},
+	}
+	_, _, err := r.syncSecret(ctx, vClient, o, nil)
+	var leaseErr *LeaseTooShortError
+	if assert.ErrorAs(t, err, &leaseErr) {
+		assert.Equal(t, &LeaseTooShortError{
+			Duration: time.Second * 5,
+			Minimum:  time.Second * 10,
+		}, leaseErr)
+	}
+	assert.Equal(t, []*vault.MockRequest{
+		{
+			Method: http.MethodGet,
+			Path:   "baz/foo",
+		},
+		{
+			Method: http.MethodPut,
+			Path:   "/sys/leases/revoke",
+			Params: map[string]any{
+				"lease_id": "baz/foo/lease",
+			},
+		},
+	}, vClient.Requests)
+	cond := meta.FindStatusCondition(o.Status.Conditions, secretsv1beta1.ConditionTypeLeaseTooShort)
+	if assert.NotNil(t, cond) {
+		assert.Equal(t, metav1.ConditionTrue, cond.Status)
+		assert.Equal(t, "LeaseTooShort", cond.Reason)
+	}
+
+	vClient = &vault.MockRecordingVaultClient{
+		ReadResponses: map[string][]vault.Response{
+			"baz/foo": {newResponse(30)},
+		},
+	}
+	got, _, err := r.syncSecret(ctx, vClient, o, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 30, got.LeaseDuration)
+	cond = meta.FindStatusCondition(o.Status.Conditions, secretsv1beta1.ConditionTypeLeaseTooShort)
+	if assert.NotNil(t, cond) {
+		assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	}
+}
+
+func Test_checkLeaseDuration(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		leaseDuration int
+		minimum       time.Duration
+		wantErr       assert.ErrorAssertionFunc
+	}{
+		{
+			name:          "disabled",
+			leaseDuration: 5,
+			wantErr:       assert.NoError,
+		},
+		{
+			name:    "no-lease-duration",
+			minimum: time.Second * 10,
+			wantErr: assert.NoError,
+		},
+		{
+			name:          "equal",
+			leaseDuration: 10,
+			minimum:       time.Second * 10,
+			wantErr:       assert.NoError,
+		},
+		{
+			name:          "too-short",
+			leaseDuration: 5,
+			minimum:       time.Second * 10,
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.EqualError(t, err,
+					"lease duration 5s is less than the minimum of 10s, "+
+						"the secret would expire before it could be renewed", i...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.wantErr(t, checkLeaseDuration(&secretsv1beta1.VaultSecretLease{
+				LeaseDuration: tt.leaseDuration,
+			}, tt.minimum))
+		})
+	}
+}
+
 // TestVaultDynamicSecretReconciler_isStaticCreds tests that we can appropriately
 // identify if a vault credential is "static" by checking the LastVaultRotation,
 // RotationPeriod, and RotationSchedule fields
@@ -1476,3 +1693,67 @@ func TestVaultDynamicSecretReconciler_awaitRotation(t *testing.T) {
 		})
 	}
 }
+
+func TestVaultDynamicSecretReconciler_standbyRenewal(t *testing.T) {
+	ctx := context.Background()
+	o := &secretsv1beta1.VaultDynamicSecret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "baz",
+			Namespace: "default",
+			UID:       "uid",
+		},
+		Spec: secretsv1beta1.VaultDynamicSecretSpec{
+			RenewalPercent: 67,
+		},
+		Status: secretsv1beta1.VaultDynamicSecretStatus{
+			SecretLease: secretsv1beta1.VaultSecretLease{
+				ID:            "baz/foo/lease",
+				LeaseDuration: 600,
+				Renewable:     true,
+			},
+			LastRenewalTime: nowFunc().Unix() - 500,
+		},
+	}
+	c := testutils.NewFakeClientBuilder().WithObjects(o).Build()
+	r := &VaultDynamicSecretReconciler{
+		Client:          c,
+		Recorder:        record.NewFakeRecorder(10),
+		StandbyRenewals: true,
+	}
+
+	// nothing to adopt before the lease is assigned.
+	_, ok := r.adoptStandbyRenewal(ctx, o)
+	assert.False(t, ok)
+
+	r.assignStandbyRenewal(ctx, o, time.Second*400)
+	var a secretsv1beta1.VaultLeaseAssignment
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(o), &a))
+	assert.Equal(t, "baz/foo/lease", a.Spec.LeaseID)
+	assert.Equal(t, 600, a.Spec.Increment)
+	assert.WithinDuration(t, nowFunc().Add(time.Second*500), a.Spec.RenewAfter.Time, time.Second)
+
+	// the standby replica renewed the lease.
+	renewedAt := metav1.NewTime(nowFunc().Add(-time.Second * 10))
+	a.Status = secretsv1beta1.VaultLeaseAssignmentStatus{
+		Holder:        "vso-1",
+		LeaseID:       "baz/foo/lease",
+		LeaseDuration: 600,
+		RenewedAt:     &renewedAt,
+	}
+	require.NoError(t, c.Update(ctx, &a))
+
+	horizon, ok := r.adoptStandbyRenewal(ctx, o)
+	require.True(t, ok)
+	assert.Greater(t, horizon, time.Duration(0))
+	assert.Less(t, horizon, time.Second*600)
+	assert.Equal(t, renewedAt.Unix(), o.Status.LastRenewalTime)
+
+	// already adopted.
+	_, ok = r.adoptStandbyRenewal(ctx, o)
+	assert.False(t, ok)
+
+	// leases that are not renewable are unassigned.
+	o.Status.SecretLease.Renewable = false
+	r.assignStandbyRenewal(ctx, o, time.Second*400)
+	assert.Error(t, c.Get(ctx, client.ObjectKeyFromObject(o), &a))
+}
@@ -0,0 +1,170 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"k8s.io/client-go/util/jsonpath"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/vault"
+)
+
+const vaultGenericSecretFinalizer = "vaultgenericsecret.secrets.hashicorp.com/finalizer"
+
+var _ reconcile.Reconciler = &VaultGenericSecretReconciler{}
+
+// VaultGenericSecretReconciler reconciles a VaultGenericSecret object
+type VaultGenericSecretReconciler = LeasedSecretReconciler[*secretsv1beta1.VaultGenericSecret, vaultGenericSecretKind]
+
+// +kubebuilder:rbac:groups=secrets.hashicorp.com,resources=vaultgenericsecrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=secrets.hashicorp.com,resources=vaultgenericsecrets/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=secrets.hashicorp.com,resources=vaultgenericsecrets/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+
+// vaultGenericSecretKind ensures that the VaultGenericSecret Custom Resource is
+// synced from an arbitrary Vault API path to its configured Kubernetes secret.
+// Leased responses are handled like any other leased secret, the lease is
+// renewed periodically, and the secret is requested again once the lease can no
+// longer be renewed. Responses without a lease are synced again after
+// RefreshAfter.
+type vaultGenericSecretKind struct{}
+
+func (vaultGenericSecretKind) resourceKind() ResourceKind {
+	return VaultGenericSecret
+}
+
+func (vaultGenericSecretKind) finalizer() string {
+	return vaultGenericSecretFinalizer
+}
+
+func (vaultGenericSecretKind) newObject() *secretsv1beta1.VaultGenericSecret {
+	return &secretsv1beta1.VaultGenericSecret{}
+}
+
+func (vaultGenericSecretKind) newLeasedSecret(o *secretsv1beta1.VaultGenericSecret) (*leasedSecret, error) {
+	return newVaultGenericLeasedSecret(o)
+}
+
+func newVaultGenericLeasedSecret(o *secretsv1beta1.VaultGenericSecret) (*leasedSecret, error) {
+	method := o.Spec.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	var params map[string]any
+	if method != http.MethodGet && len(o.Spec.Params) > 0 {
+		params = make(map[string]any, len(o.Spec.Params))
+		for k, v := range o.Spec.Params {
+			var val any
+			if err := json.Unmarshal(v.Raw, &val); err != nil {
+				return nil, fmt.Errorf("invalid param %q: %w", k, err)
+			}
+			params[k] = val
+		}
+	}
+
+	var refreshAfter time.Duration
+	if o.Spec.RefreshAfter != "" {
+		var err error
+		refreshAfter, err = parseDurationString(o.Spec.RefreshAfter, ".spec.refreshAfter", 0)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ls := &leasedSecret{
+		obj:            o,
+		path:           strings.Trim(o.Spec.Path, "/"),
+		method:         method,
+		params:         params,
+		renewalPercent: o.Spec.RenewalPercent,
+		revoke:         o.Spec.Revoke,
+		destination:    &o.Spec.Destination,
+		status:         &o.Status.VaultLeasedSecretStatus,
+		refreshAfter:   refreshAfter,
+	}
+	if len(o.Spec.Fields) > 0 {
+		ls.selectData = func(resp vault.Response) (vault.Response, error) {
+			return selectGenericSecretFields(o.Spec.Fields, resp)
+		}
+	}
+
+	return ls, nil
+}
+
+// selectGenericSecretFields returns a copy of resp, whose data only contains
+// the values selected by fields.
+func selectGenericSecretFields(fields []secretsv1beta1.GenericSecretField, resp vault.Response) (vault.Response, error) {
+	data := make(map[string]any, len(fields))
+	var errs error
+	for _, f := range fields {
+		v, err := evalJSONPath(f.Name, f.JSONPath, resp.Data())
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("field %q: %w", f.Name, err))
+			continue
+		}
+		data[f.Name] = v
+	}
+	if errs != nil {
+		return nil, errs
+	}
+
+	secret := *resp.Secret()
+	secret.Data = data
+	return vault.NewDefaultResponse(&secret), nil
+}
+
+// evalJSONPath evaluates the JSONPath expression against data. String values
+// are returned as is, all other values are encoded as JSON. Multiple results
+// are returned as a JSON array.
+func evalJSONPath(name, expr string, data map[string]any) (string, error) {
+	if !strings.HasPrefix(expr, "{") {
+		expr = "{" + expr + "}"
+	}
+
+	j := jsonpath.New(name)
+	if err := j.Parse(expr); err != nil {
+		return "", fmt.Errorf("invalid jsonPath: %w", err)
+	}
+
+	results, err := j.FindResults(data)
+	if err != nil {
+		return "", err
+	}
+
+	var values []any
+	for _, r := range results {
+		for _, v := range r {
+			values = append(values, v.Interface())
+		}
+	}
+
+	var v any
+	switch len(values) {
+	case 0:
+		return "", errors.New("no value found")
+	case 1:
+		v = values[0]
+		if s, ok := v.(string); ok {
+			return s, nil
+		}
+	default:
+		v = values
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
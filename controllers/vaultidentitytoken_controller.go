@@ -0,0 +1,93 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/vault"
+)
+
+const vaultIdentityTokenFinalizer = "vaultidentitytoken.secrets.hashicorp.com/finalizer"
+
+var _ reconcile.Reconciler = &VaultIdentityTokenReconciler{}
+
+// VaultIdentityTokenReconciler reconciles a VaultIdentityToken object
+type VaultIdentityTokenReconciler = LeasedSecretReconciler[*secretsv1beta1.VaultIdentityToken, vaultIdentityTokenKind]
+
+// +kubebuilder:rbac:groups=secrets.hashicorp.com,resources=vaultidentitytokens,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=secrets.hashicorp.com,resources=vaultidentitytokens/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=secrets.hashicorp.com,resources=vaultidentitytokens/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+
+// vaultIdentityTokenKind ensures that the VaultIdentityToken Custom Resource is
+// synced from Vault's identity token endpoint to its configured Kubernetes
+// secret. Identity tokens are not leased, a new token is generated once the
+// current token has reached its renewal window, so that the synced token is
+// never expired.
+type vaultIdentityTokenKind struct{}
+
+func (vaultIdentityTokenKind) resourceKind() ResourceKind {
+	return VaultIdentityToken
+}
+
+func (vaultIdentityTokenKind) finalizer() string {
+	return vaultIdentityTokenFinalizer
+}
+
+func (vaultIdentityTokenKind) newObject() *secretsv1beta1.VaultIdentityToken {
+	return &secretsv1beta1.VaultIdentityToken{}
+}
+
+func (vaultIdentityTokenKind) newLeasedSecret(o *secretsv1beta1.VaultIdentityToken) (*leasedSecret, error) {
+	return newVaultIdentityTokenLeasedSecret(o), nil
+}
+
+func newVaultIdentityTokenLeasedSecret(o *secretsv1beta1.VaultIdentityToken) *leasedSecret {
+	return &leasedSecret{
+		obj:            o,
+		path:           fmt.Sprintf("identity/oidc/token/%s", o.Spec.Role),
+		method:         http.MethodGet,
+		renewalPercent: o.Spec.RenewalPercent,
+		destination:    &o.Spec.Destination,
+		status:         &o.Status.VaultLeasedSecretStatus,
+		ttl:            identityTokenTTL,
+	}
+}
+
+// identityTokenTTL returns the ttl of the identity token in resp.
+func identityTokenTTL(resp vault.Response) (int, error) {
+	v, ok := resp.Data()["ttl"]
+	if !ok || v == nil {
+		return 0, errors.New("identity token response has no ttl")
+	}
+
+	var ttl int
+	switch t := v.(type) {
+	case json.Number:
+		i, err := t.Int64()
+		if err != nil {
+			return 0, fmt.Errorf("invalid identity token ttl: %w", err)
+		}
+		ttl = int(i)
+	case int:
+		ttl = t
+	case float64:
+		ttl = int(t)
+	default:
+		return 0, fmt.Errorf("invalid identity token ttl type %T", v)
+	}
+	if ttl <= 0 {
+		return 0, fmt.Errorf("invalid identity token ttl %d", ttl)
+	}
+
+	return ttl, nil
+}
@@ -0,0 +1,80 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+)
+
+const vaultKubernetesSecretFinalizer = "vaultkubernetessecret.secrets.hashicorp.com/finalizer"
+
+var _ reconcile.Reconciler = &VaultKubernetesSecretReconciler{}
+
+// VaultKubernetesSecretReconciler reconciles a VaultKubernetesSecret object
+type VaultKubernetesSecretReconciler = LeasedSecretReconciler[*secretsv1beta1.VaultKubernetesSecret, vaultKubernetesSecretKind]
+
+// +kubebuilder:rbac:groups=secrets.hashicorp.com,resources=vaultkubernetessecrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=secrets.hashicorp.com,resources=vaultkubernetessecrets/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=secrets.hashicorp.com,resources=vaultkubernetessecrets/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+
+// vaultKubernetesSecretKind ensures that the VaultKubernetesSecret Custom
+// Resource is synced from Vault's Kubernetes secrets engine to its configured
+// Kubernetes secret. The service account tokens are not renewable, so a new
+// token is requested once the current one has reached its renewal window.
+type vaultKubernetesSecretKind struct{}
+
+func (vaultKubernetesSecretKind) resourceKind() ResourceKind {
+	return VaultKubernetesSecret
+}
+
+func (vaultKubernetesSecretKind) finalizer() string {
+	return vaultKubernetesSecretFinalizer
+}
+
+func (vaultKubernetesSecretKind) newObject() *secretsv1beta1.VaultKubernetesSecret {
+	return &secretsv1beta1.VaultKubernetesSecret{}
+}
+
+func (vaultKubernetesSecretKind) newLeasedSecret(o *secretsv1beta1.VaultKubernetesSecret) (*leasedSecret, error) {
+	return newVaultKubernetesLeasedSecret(o), nil
+}
+
+func newVaultKubernetesLeasedSecret(o *secretsv1beta1.VaultKubernetesSecret) *leasedSecret {
+	mount := strings.Trim(o.Spec.Mount, "/")
+	if mount == "" {
+		mount = "kubernetes"
+	}
+
+	params := map[string]any{
+		"kubernetes_namespace": o.Spec.KubernetesNamespace,
+	}
+	if o.Spec.ClusterRoleBinding {
+		params["cluster_role_binding"] = true
+	}
+	if o.Spec.TTL != "" {
+		params["ttl"] = o.Spec.TTL
+	}
+	if len(o.Spec.Audiences) > 0 {
+		params["audiences"] = o.Spec.Audiences
+	}
+
+	return &leasedSecret{
+		obj:            o,
+		path:           fmt.Sprintf("%s/creds/%s", mount, o.Spec.Role),
+		method:         http.MethodPut,
+		params:         params,
+		renewalPercent: o.Spec.RenewalPercent,
+		revoke:         o.Spec.Revoke,
+		destination:    &o.Spec.Destination,
+		status:         &o.Status.VaultLeasedSecretStatus,
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+)
+
+const vaultLDAPSecretFinalizer = "vaultldapsecret.secrets.hashicorp.com/finalizer"
+
+var _ reconcile.Reconciler = &VaultLDAPSecretReconciler{}
+
+// VaultLDAPSecretReconciler reconciles a VaultLDAPSecret object
+type VaultLDAPSecretReconciler = LeasedSecretReconciler[*secretsv1beta1.VaultLDAPSecret, vaultLDAPSecretKind]
+
+// +kubebuilder:rbac:groups=secrets.hashicorp.com,resources=vaultldapsecrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=secrets.hashicorp.com,resources=vaultldapsecrets/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=secrets.hashicorp.com,resources=vaultldapsecrets/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+
+// vaultLDAPSecretKind ensures that the VaultLDAPSecret Custom Resource is
+// synced from Vault's LDAP secrets engine to its configured Kubernetes secret.
+// For dynamic roles, the lease is renewed periodically, if the renewal fails or
+// the lease is not renewable, new credentials are generated. For static roles,
+// the credentials are synced again after Vault has rotated the password.
+type vaultLDAPSecretKind struct{}
+
+func (vaultLDAPSecretKind) resourceKind() ResourceKind {
+	return VaultLDAPSecret
+}
+
+func (vaultLDAPSecretKind) finalizer() string {
+	return vaultLDAPSecretFinalizer
+}
+
+func (vaultLDAPSecretKind) newObject() *secretsv1beta1.VaultLDAPSecret {
+	return &secretsv1beta1.VaultLDAPSecret{}
+}
+
+func (vaultLDAPSecretKind) newLeasedSecret(o *secretsv1beta1.VaultLDAPSecret) (*leasedSecret, error) {
+	return newVaultLDAPLeasedSecret(o), nil
+}
+
+func newVaultLDAPLeasedSecret(o *secretsv1beta1.VaultLDAPSecret) *leasedSecret {
+	mount := strings.Trim(o.Spec.Mount, "/")
+	if mount == "" {
+		mount = "ldap"
+	}
+
+	ls := &leasedSecret{
+		obj:            o,
+		path:           fmt.Sprintf("%s/creds/%s", mount, o.Spec.Role),
+		method:         http.MethodGet,
+		renewalPercent: o.Spec.RenewalPercent,
+		revoke:         o.Spec.Revoke,
+		destination:    &o.Spec.Destination,
+		status:         &o.Status.VaultLeasedSecretStatus,
+	}
+	if o.Spec.StaticCreds {
+		ls.path = fmt.Sprintf("%s/static-cred/%s", mount, o.Spec.Role)
+		ls.revoke = false
+		ls.staticCreds = &o.Status.StaticCredsMetaData
+	}
+
+	return ls
+}
@@ -0,0 +1,80 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+)
+
+const vaultMongoDBAtlasSecretFinalizer = "vaultmongodbatlassecret.secrets.hashicorp.com/finalizer"
+
+var _ reconcile.Reconciler = &VaultMongoDBAtlasSecretReconciler{}
+
+// VaultMongoDBAtlasSecretReconciler reconciles a VaultMongoDBAtlasSecret object
+type VaultMongoDBAtlasSecretReconciler = LeasedSecretReconciler[*secretsv1beta1.VaultMongoDBAtlasSecret, vaultMongoDBAtlasSecretKind]
+
+// +kubebuilder:rbac:groups=secrets.hashicorp.com,resources=vaultmongodbatlassecrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=secrets.hashicorp.com,resources=vaultmongodbatlassecrets/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=secrets.hashicorp.com,resources=vaultmongodbatlassecrets/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+
+// vaultMongoDBAtlasSecretKind ensures that the VaultMongoDBAtlasSecret Custom
+// Resource is synced from Vault's MongoDB Atlas secrets engine to its
+// configured Kubernetes secret. The programmatic API key's lease is renewed
+// periodically, if the renewal fails or the lease is not renewable, a new key
+// is generated. A new key is only reported as ready once its IP access list has
+// had time to propagate.
+type vaultMongoDBAtlasSecretKind struct{}
+
+func (vaultMongoDBAtlasSecretKind) resourceKind() ResourceKind {
+	return VaultMongoDBAtlasSecret
+}
+
+func (vaultMongoDBAtlasSecretKind) finalizer() string {
+	return vaultMongoDBAtlasSecretFinalizer
+}
+
+func (vaultMongoDBAtlasSecretKind) newObject() *secretsv1beta1.VaultMongoDBAtlasSecret {
+	return &secretsv1beta1.VaultMongoDBAtlasSecret{}
+}
+
+func (vaultMongoDBAtlasSecretKind) newLeasedSecret(o *secretsv1beta1.VaultMongoDBAtlasSecret) (*leasedSecret, error) {
+	return newVaultMongoDBAtlasLeasedSecret(o)
+}
+
+func newVaultMongoDBAtlasLeasedSecret(o *secretsv1beta1.VaultMongoDBAtlasSecret) (*leasedSecret, error) {
+	mount := strings.Trim(o.Spec.Mount, "/")
+	if mount == "" {
+		mount = "mongodbatlas"
+	}
+
+	var propagationDelay time.Duration
+	if o.Spec.AccessListPropagationDelay != "" {
+		var err error
+		propagationDelay, err = parseDurationString(o.Spec.AccessListPropagationDelay,
+			".spec.accessListPropagationDelay", 0)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &leasedSecret{
+		obj:              o,
+		path:             fmt.Sprintf("%s/creds/%s", mount, o.Spec.Role),
+		method:           http.MethodGet,
+		renewalPercent:   o.Spec.RenewalPercent,
+		revoke:           o.Spec.Revoke,
+		destination:      &o.Spec.Destination,
+		status:           &o.Status.VaultLeasedSecretStatus,
+		propagationDelay: propagationDelay,
+	}, nil
+}
@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+)
+
+const vaultNomadSecretFinalizer = "vaultnomadsecret.secrets.hashicorp.com/finalizer"
+
+var _ reconcile.Reconciler = &VaultNomadSecretReconciler{}
+
+// VaultNomadSecretReconciler reconciles a VaultNomadSecret object
+type VaultNomadSecretReconciler = LeasedSecretReconciler[*secretsv1beta1.VaultNomadSecret, vaultNomadSecretKind]
+
+// +kubebuilder:rbac:groups=secrets.hashicorp.com,resources=vaultnomadsecrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=secrets.hashicorp.com,resources=vaultnomadsecrets/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=secrets.hashicorp.com,resources=vaultnomadsecrets/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+
+// vaultNomadSecretKind ensures that the VaultNomadSecret Custom Resource is
+// synced from Vault's Nomad secrets engine to its configured Kubernetes secret.
+// The Nomad ACL token's lease is renewed periodically, if the renewal fails or
+// the lease is not renewable, a new ACL token is issued.
+type vaultNomadSecretKind struct{}
+
+func (vaultNomadSecretKind) resourceKind() ResourceKind {
+	return VaultNomadSecret
+}
+
+func (vaultNomadSecretKind) finalizer() string {
+	return vaultNomadSecretFinalizer
+}
+
+func (vaultNomadSecretKind) newObject() *secretsv1beta1.VaultNomadSecret {
+	return &secretsv1beta1.VaultNomadSecret{}
+}
+
+func (vaultNomadSecretKind) newLeasedSecret(o *secretsv1beta1.VaultNomadSecret) (*leasedSecret, error) {
+	return newVaultNomadLeasedSecret(o), nil
+}
+
+func newVaultNomadLeasedSecret(o *secretsv1beta1.VaultNomadSecret) *leasedSecret {
+	mount := strings.Trim(o.Spec.Mount, "/")
+	if mount == "" {
+		mount = "nomad"
+	}
+
+	return &leasedSecret{
+		obj:            o,
+		path:           fmt.Sprintf("%s/creds/%s", mount, o.Spec.Role),
+		method:         http.MethodGet,
+		renewalPercent: o.Spec.RenewalPercent,
+		revoke:         o.Spec.Revoke,
+		destination:    &o.Spec.Destination,
+		status:         &o.Status.VaultLeasedSecretStatus,
+	}
+}
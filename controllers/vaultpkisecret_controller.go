@@ -8,6 +8,7 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"maps"
 	"strings"
@@ -29,6 +30,8 @@ import (
 	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
 	"github.com/hashicorp/vault-secrets-operator/consts"
 	"github.com/hashicorp/vault-secrets-operator/helpers"
+	"github.com/hashicorp/vault-secrets-operator/internal/cloudevents"
+	"github.com/hashicorp/vault-secrets-operator/internal/issuance"
 	"github.com/hashicorp/vault-secrets-operator/internal/metrics"
 
 	"github.com/hashicorp/vault-secrets-operator/vault"
@@ -99,7 +102,7 @@ func (r *VaultPKISecretReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 			o.Spec.Destination.Name, horizon)
 		logger.Info(msg)
 		o.Status.Error = consts.ReasonK8sClientError
-		r.recordEvent(o, o.Status.Error, msg)
+		r.recordSyncError(o, msg)
 		if err := r.updateStatus(ctx, o); err != nil {
 			return ctrl.Result{}, err
 		}
@@ -180,15 +183,30 @@ func (r *VaultPKISecretReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		}, nil
 	}
 
-	resp, err := c.Write(ctx, vault.NewWriteRequest(path, o.GetIssuerAPIData()))
-	if err != nil {
+	issuerData := o.GetIssuerAPIData()
+	resp, err := issuance.Do(ctx, req.String(), o.GetGeneration(),
+		func(ctx context.Context) (vault.Response, error) {
+			return c.Write(ctx, vault.NewWriteRequest(path, issuerData))
+		})
+	setIssuingCondition(&o.Status.Conditions, o.GetGeneration(), err)
+	if errors.Is(err, issuance.ErrIssuing) {
+		logger.V(consts.LogLevelDebug).Info("Certificate is being issued")
+		if err := r.updateStatus(ctx, o); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		r.SyncRegistry.Add(req.NamespacedName)
+		return ctrl.Result{
+			RequeueAfter: issuance.PollInterval,
+		}, nil
+	} else if err != nil {
 		if vault.IsForbiddenError(err) {
 			c.Taint()
 		}
 		o.Status.Error = consts.ReasonK8sClientError
 		msg := "Failed to issue certificate from Vault"
 		logger.Error(err, msg)
-		r.recordEvent(o, o.Status.Error, msg+": %s", err)
+		r.recordSyncError(o, msg+": %s", err)
 		if err := r.updateStatus(ctx, o); err != nil {
 			return ctrl.Result{}, err
 		}
@@ -207,7 +225,7 @@ func (r *VaultPKISecretReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		o.Status.Error = consts.ReasonK8sClientError
 		msg := "Failed to unmarshal PKI response"
 		logger.Error(err, msg)
-		r.recordEvent(o, o.Status.Error, msg+": %s", err)
+		r.recordSyncError(o, msg+": %s", err)
 		if err := r.updateStatus(ctx, o); err != nil {
 			return ctrl.Result{}, err
 		}
@@ -220,7 +238,7 @@ func (r *VaultPKISecretReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		o.Status.Error = consts.ReasonK8sClientError
 		msg := "Invalid Vault secret data, serial_number cannot be empty"
 		logger.Error(nil, msg)
-		r.recordEvent(o, o.Status.Error, msg)
+		r.recordSyncError(o, msg)
 		if err := r.updateStatus(ctx, o); err != nil {
 			return ctrl.Result{}, err
 		}
@@ -234,7 +252,7 @@ func (r *VaultPKISecretReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		o.Status.Error = consts.ReasonK8sClientError
 		msg := "Failed to marshal Vault secret data"
 		logger.Error(err, msg)
-		r.recordEvent(o, o.Status.Error, msg+": %s", err)
+		r.recordSyncError(o, msg+": %s", err)
 		if err := r.updateStatus(ctx, o); err != nil {
 			return ctrl.Result{}, err
 		}
@@ -252,11 +270,58 @@ func (r *VaultPKISecretReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		data = convertToK8sTLSSecretData(data)
 	}
 
+	if o.Spec.PKCS12 != nil {
+		if err := addPKCS12(ctx, c, o, certResp, data); err != nil {
+			o.Status.Error = consts.ReasonK8sClientError
+			msg := "Failed to build the PKCS#12 keystore"
+			logger.Error(err, msg)
+			r.recordSyncError(o, msg+": %s", err)
+			if err := r.updateStatus(ctx, o); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{
+				RequeueAfter: computeHorizonWithJitter(requeueDurationOnError),
+			}, nil
+		}
+	}
+
+	if o.Spec.JKS != nil {
+		if err := addJKS(ctx, c, o, certResp, data); err != nil {
+			o.Status.Error = consts.ReasonK8sClientError
+			msg := "Failed to build the JKS keystore"
+			logger.Error(err, msg)
+			r.recordSyncError(o, msg+": %s", err)
+			if err := r.updateStatus(ctx, o); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{
+				RequeueAfter: computeHorizonWithJitter(requeueDurationOnError),
+			}, nil
+		}
+	}
+
+	if len(o.Spec.Encodings) > 0 {
+		if err := addEncodings(o, certResp, data); err != nil {
+			o.Status.Error = consts.ReasonK8sClientError
+			msg := "Failed to encode the certificate"
+			logger.Error(err, msg)
+			r.recordSyncError(o, msg+": %s", err)
+			if err := r.updateStatus(ctx, o); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{
+				RequeueAfter: computeHorizonWithJitter(requeueDurationOnError),
+			}, nil
+		}
+	}
+
 	if b, err := json.Marshal(data); err == nil {
 		newMAC, err := r.HMACValidator.HMAC(ctx, r.SecretsClient, b)
 		if err != nil {
 			logger.Error(err, "HMAC data")
 			o.Status.Error = consts.ReasonHMACDataError
+			o.Status.LastSyncMessages = appendSyncMessage(o.Status.LastSyncMessages,
+				secretsv1beta1.SyncResultFailure, o.Status.Error, "Failed to HMAC data: %s", err)
 			if err := r.updateStatus(ctx, o); err != nil {
 				return ctrl.Result{}, err
 			}
@@ -267,9 +332,14 @@ func (r *VaultPKISecretReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		o.Status.SecretMAC = base64.StdEncoding.EncodeToString(newMAC)
 	}
 
-	if err := helpers.SyncSecret(ctx, r.Client, o, data); err != nil {
+	err = helpers.SyncSecret(ctx, r.Client, o, data)
+	helpers.SetDestinationConflictCondition(&o.Status.Conditions, o.GetGeneration(), err)
+	helpers.SetFanOutDeniedCondition(&o.Status.Conditions, o.GetGeneration(), err)
+	if err != nil {
 		logger.Error(err, "Sync secret")
-		o.Status.Error = consts.ReasonSecretSyncError
+		o.Status.Error = syncSecretErrorReason(err)
+		o.Status.LastSyncMessages = appendSyncMessage(o.Status.LastSyncMessages,
+			secretsv1beta1.SyncResultFailure, o.Status.Error, "Failed to sync secret: %s", err)
 		if err := r.updateStatus(ctx, o); err != nil {
 			return ctrl.Result{}, err
 		}
@@ -302,14 +372,24 @@ func (r *VaultPKISecretReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	o.Status.SerialNumber = certResp.SerialNumber
 	o.Status.Expiration = certResp.Expiration
 	o.Status.LastRotation = time.Now().Unix()
+	horizon, _ := computePKIRenewalWindow(ctx, o, .05)
+	o.Status.LastSyncMessages = appendSyncMessage(o.Status.LastSyncMessages,
+		secretsv1beta1.SyncResultSuccess, reason, "Secret synced, horizon=%s", horizon)
 	if err := r.updateStatus(ctx, o); err != nil {
 		logger.Error(err, "Failed to update the status")
 		return ctrl.Result{}, err
 	}
+	if reason == consts.ReasonSecretRotated {
+		cloudevents.Emit(ctx, cloudevents.TypeSecretRotated, o, cloudevents.Data{})
+	}
 
 	r.SyncRegistry.Delete(req.NamespacedName)
 
-	horizon, _ := computePKIRenewalWindow(ctx, o, .05)
+	if skews := clockSkewTracker.Skews(); len(skews) > 0 {
+		r.Recorder.Eventf(o, corev1.EventTypeWarning, consts.ReasonClockSkewDetected,
+			"Clock skew detected %v, the certificate will be renewed early by %s",
+			skews, clockSkewTracker.Margin())
+	}
 	r.recordEvent(o, reason, fmt.Sprintf("Secret synced, horizon=%s", horizon))
 	logger.Info("Successfully updated the secret", "horizon", horizon)
 	return ctrl.Result{
@@ -321,12 +401,18 @@ func (r *VaultPKISecretReconciler) handleDeletion(ctx context.Context, o *secret
 	objKey := client.ObjectKeyFromObject(o)
 	r.SyncRegistry.Delete(objKey)
 	r.BackOffRegistry.Delete(objKey)
+	issuance.Forget(objKey.String())
 
 	r.referenceCache.Remove(SecretTransformation, objKey)
 	finalizerSet := controllerutil.ContainsFinalizer(o, vaultPKIFinalizer)
 	logger := log.FromContext(ctx).WithName("handleDeletion").WithValues(
 		"finalizer", vaultPKIFinalizer, "isSet", finalizerSet)
 	logger.V(consts.LogLevelTrace).Info("In deletion")
+	helpers.DeleteSecretlessData(o)
+	if err := helpers.HandleDestinationDeletion(ctx, r.Client, o); err != nil {
+		logger.Error(err, "Failed to apply the deletion policy of the destination Secrets")
+		return err
+	}
 	if finalizerSet {
 		logger.V(consts.LogLevelDebug).Info("Delete finalizer")
 		if controllerutil.RemoveFinalizer(o, vaultPKIFinalizer) {
@@ -382,7 +468,8 @@ func (r *VaultPKISecretReconciler) finalizePKI(ctx context.Context, l logr.Logge
 		}
 	}
 
-	if !s.Spec.Destination.Create && s.Spec.Clear {
+	// in secretless mode there is no Secret to clear.
+	if !s.Spec.Destination.Create && s.Spec.Clear && s.Spec.Destination.Secretless == nil {
 		if err := r.clearSecretData(ctx, l, s); err != nil {
 			return err
 		}
@@ -433,6 +520,15 @@ func (r *VaultPKISecretReconciler) recordEvent(o *secretsv1beta1.VaultPKISecret,
 	r.Recorder.Eventf(o, eventType, reason, msg, i...)
 }
 
+// recordSyncError records the failed sync attempt in the resource's
+// Status.LastSyncMessages, and emits the corresponding event. The caller is
+// responsible for setting o.Status.Error prior to calling this method.
+func (r *VaultPKISecretReconciler) recordSyncError(o *secretsv1beta1.VaultPKISecret, msg string, i ...interface{}) {
+	o.Status.LastSyncMessages = appendSyncMessage(o.Status.LastSyncMessages,
+		secretsv1beta1.SyncResultFailure, o.Status.Error, msg, i...)
+	r.recordEvent(o, o.Status.Error, msg, i...)
+}
+
 func (r *VaultPKISecretReconciler) updateStatus(ctx context.Context, o *secretsv1beta1.VaultPKISecret) error {
 	logger := log.FromContext(ctx)
 	logger.V(consts.LogLevelTrace).Info("Update status called")
@@ -472,6 +568,13 @@ func computePKIRenewalWindow(ctx context.Context, o *secretsv1beta1.VaultPKISecr
 
 	now := nowFunc()
 	rotationTime := computeExpirationTimePKI(o, int64(offset.Seconds()))
+	// the expiration is computed from Vault's clock, renew early by the amount of
+	// any significant clock skew, so that the certificate never expires before
+	// it is renewed.
+	if margin := clockSkewTracker.Margin(); margin > 0 {
+		logger = logger.WithValues("clockSkewMargin", margin)
+		rotationTime = rotationTime.Add(-margin)
+	}
 	horizon := rotationTime.Sub(now)
 	var inWindow bool
 	if isInWindow(now, rotationTime) || horizon < minHorizon {
@@ -506,12 +609,17 @@ func convertToK8sTLSSecretData(data map[string][]byte) map[string][]byte {
 		ret[corev1.TLSPrivateKeyKey] = v
 	}
 
+	// the issuing ca is always provided as ca.crt, such that the Secret can be
+	// consumed directly by ingress controllers and webhooks.
+	if v, ok := data["issuing_ca"]; ok && len(v) > 0 {
+		ret[corev1.ServiceAccountRootCAKey] = v
+	}
+
 	// the ca_chain includes the issuing ca
 	var caData []byte
 	if v, ok := data["ca_chain"]; ok && len(v) > 0 {
 		caData = v
 	} else if v, ok := data["issuing_ca"]; ok && len(v) > 0 {
-		ret[corev1.ServiceAccountRootCAKey] = v
 		caData = v
 	}
 
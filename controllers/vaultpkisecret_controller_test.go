@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/internal/clockskew"
 )
 
 func Test_computePKIRenewalWindow(t *testing.T) {
@@ -57,6 +58,7 @@ func Test_computePKIRenewalWindow(t *testing.T) {
 		wantInWindow    bool
 		assertFunc      assertFunc
 		minHorizon      time.Duration
+		clockSkew       time.Duration
 	}{
 		{
 			name: "in-window-with-offset-overlap",
@@ -141,6 +143,30 @@ func Test_computePKIRenewalWindow(t *testing.T) {
 			assertFunc:      newNotInWindowAssertFunc(time.Second*60, time.Second*57, false),
 			wantInWindow:    false,
 		},
+		{
+			name: "not-in-window-with-clock-skew",
+			o: &secretsv1beta1.VaultPKISecret{
+				Spec:   secretsv1beta1.VaultPKISecretSpec{},
+				Status: secretsv1beta1.VaultPKISecretStatus{},
+			},
+			expirationDelta: 60,
+			jitterPercent:   0.05,
+			clockSkew:       time.Second * 20,
+			assertFunc:      newNotInWindowAssertFunc(time.Second*40, time.Second*38, false),
+			wantInWindow:    false,
+		},
+		{
+			name: "in-window-with-clock-skew",
+			o: &secretsv1beta1.VaultPKISecret{
+				Spec:   secretsv1beta1.VaultPKISecretSpec{},
+				Status: secretsv1beta1.VaultPKISecretStatus{},
+			},
+			expirationDelta: 20,
+			jitterPercent:   0.05,
+			clockSkew:       -time.Second * 30,
+			assertFunc:      newInWindowAssertFunc(time.Second*1, time.Duration(1.05*float64(time.Second))),
+			wantInWindow:    true,
+		},
 		{
 			name: "not-in-window-with-invalid-offset",
 			o: &secretsv1beta1.VaultPKISecret{
@@ -160,16 +186,22 @@ func Test_computePKIRenewalWindow(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			nowFuncOrig := nowFunc
 			minHorizonOrig := minHorizon
+			clockSkewTrackerOrig := clockSkewTracker
 			if tt.minHorizon > 0 {
 				minHorizon = tt.minHorizon
 			}
 			t.Cleanup(func() {
 				nowFunc = nowFuncOrig
 				minHorizon = minHorizonOrig
+				clockSkewTracker = clockSkewTrackerOrig
 			})
 
 			nowFunc = defaultNowFunc
 			now := nowFunc()
+			clockSkewTracker = clockskew.NewTracker(clockskew.DefaultThreshold)
+			if tt.clockSkew != 0 {
+				clockSkewTracker.Observe(clockskew.SourceVaultPrefix+"test", now, now, now.Add(tt.clockSkew))
+			}
 			tt.o.Status.Expiration = now.Unix() + tt.expirationDelta
 			gotHorizon, gotInWindow := computePKIRenewalWindow(ctx, tt.o, tt.jitterPercent)
 			tt.assertFunc(t, gotHorizon, "computePKIRenewalWindow(%v, %v, %v)", ctx, tt.o, tt.jitterPercent)
@@ -234,6 +266,24 @@ func Test_convertToK8sTLSSecretData(t *testing.T) {
 				"ca.crt":      []byte("v_issuing_ca"),
 			},
 		},
+		{
+			name: "with-ca-chain-and-issuing-ca",
+			data: map[string][]byte{
+				"private_key": []byte("v_private_key"),
+				"certificate": []byte("v_certificate"),
+				"ca_chain":    []byte("v_ca_chain"),
+				"issuing_ca":  []byte("v_issuing_ca"),
+			},
+			want: map[string][]byte{
+				"private_key": []byte("v_private_key"),
+				"certificate": []byte("v_certificate"),
+				"ca_chain":    []byte("v_ca_chain"),
+				"issuing_ca":  []byte("v_issuing_ca"),
+				"tls.key":     []byte("v_private_key"),
+				"tls.crt":     []byte("v_certificate\nv_ca_chain"),
+				"ca.crt":      []byte("v_issuing_ca"),
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,115 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package controllers
+
+import (
+	"crypto/x509"
+	"fmt"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/internal/keystore"
+	"github.com/hashicorp/vault-secrets-operator/vault"
+)
+
+const (
+	encodingSourceCertificate = "certificate"
+	encodingSourcePrivateKey  = "private_key"
+	encodingSourceIssuingCA   = "issuing_ca"
+	encodingSourceCAChain     = "ca_chain"
+
+	encodingFormatDER   = "der"
+	encodingFormatPKCS7 = "pkcs7"
+)
+
+// addEncodings adds the binary encodings of the fields of the certificate in
+// certResp to data, as configured by o.Spec.Encodings.
+func addEncodings(o *secretsv1beta1.VaultPKISecret, certResp *vault.PKICertResponse, data map[string][]byte) error {
+	for _, e := range o.Spec.Encodings {
+		b, err := encodePKIField(certResp, e.Source, e.Format)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s as %s for key %q: %w", e.Source, e.Format, e.Key, err)
+		}
+		data[e.Key] = b
+	}
+
+	return nil
+}
+
+func encodePKIField(certResp *vault.PKICertResponse, source, format string) ([]byte, error) {
+	if source == encodingSourcePrivateKey {
+		if format != encodingFormatDER {
+			return nil, fmt.Errorf("unsupported format %q", format)
+		}
+		key, err := keystore.ParsePrivateKey(certResp.PrivateKey)
+		if err != nil {
+			return nil, err
+		}
+		return x509.MarshalPKCS8PrivateKey(key)
+	}
+
+	certs, err := pkiFieldCertificates(certResp, source)
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case encodingFormatDER:
+		// the leaf, without its CA chain.
+		if source == encodingSourceCertificate {
+			return certs[0].Raw, nil
+		}
+		if len(certs) != 1 {
+			return nil, fmt.Errorf("the der format encodes a single certificate, found %d", len(certs))
+		}
+		return certs[0].Raw, nil
+	case encodingFormatPKCS7:
+		return keystore.EncodePKCS7(certs)
+	default:
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+}
+
+// pkiFieldCertificates returns the certificates of source in certResp, without
+// duplicates. The leaf certificate is followed by its CA chain.
+func pkiFieldCertificates(certResp *vault.PKICertResponse, source string) ([]*x509.Certificate, error) {
+	caChain := certResp.CAChain
+	if len(caChain) == 0 && certResp.IssuingCa != "" {
+		caChain = []string{certResp.IssuingCa}
+	}
+
+	var fields []string
+	switch source {
+	case encodingSourceCertificate:
+		fields = append([]string{certResp.Certificate}, caChain...)
+	case encodingSourceIssuingCA:
+		fields = []string{certResp.IssuingCa}
+	case encodingSourceCAChain:
+		fields = caChain
+	default:
+		return nil, fmt.Errorf("unsupported source %q", source)
+	}
+
+	var ret []*x509.Certificate
+	seen := make(map[string]bool)
+	for _, s := range fields {
+		if s == "" {
+			continue
+		}
+		certs, err := keystore.ParseCertificates(s)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range certs {
+			if !seen[string(c.Raw)] {
+				seen[string(c.Raw)] = true
+				ret = append(ret, c)
+			}
+		}
+	}
+	if len(ret) == 0 {
+		return nil, fmt.Errorf("no %s returned by Vault", source)
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,172 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package controllers
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/internal/keystore"
+	"github.com/hashicorp/vault-secrets-operator/vault"
+)
+
+func Test_addEncodings(t *testing.T) {
+	t.Parallel()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTmpl, caTmpl, caKey.Public(), caKey)
+	require.NoError(t, err)
+	ca, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca, key.Public(), caKey)
+	require.NoError(t, err)
+	leaf, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	pkcs8Key, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	caPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}))
+	certResp := &vault.PKICertResponse{
+		Certificate: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		PrivateKey:  string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})),
+		IssuingCa:   caPEM,
+		CAChain:     []string{caPEM},
+	}
+
+	pkcs7 := func(certs ...*x509.Certificate) []byte {
+		b, err := keystore.EncodePKCS7(certs)
+		require.NoError(t, err)
+		return b
+	}
+
+	tests := []struct {
+		name      string
+		encodings []secretsv1beta1.VaultPKISecretEncoding
+		certResp  *vault.PKICertResponse
+		want      map[string][]byte
+		wantErr   string
+	}{
+		{
+			name: "der",
+			encodings: []secretsv1beta1.VaultPKISecretEncoding{
+				{Key: "tls.der", Source: "certificate", Format: "der"},
+				{Key: "key.der", Source: "private_key", Format: "der"},
+				{Key: "ca.der", Source: "issuing_ca", Format: "der"},
+				{Key: "chain.der", Source: "ca_chain", Format: "der"},
+			},
+			certResp: certResp,
+			want: map[string][]byte{
+				"tls.der":   der,
+				"key.der":   pkcs8Key,
+				"ca.der":    caDER,
+				"chain.der": caDER,
+			},
+		},
+		{
+			name: "pkcs7",
+			encodings: []secretsv1beta1.VaultPKISecretEncoding{
+				{Key: "tls.p7b", Source: "certificate", Format: "pkcs7"},
+				{Key: "ca.p7b", Source: "ca_chain", Format: "pkcs7"},
+			},
+			certResp: certResp,
+			want: map[string][]byte{
+				"tls.p7b": pkcs7(leaf, ca),
+				"ca.p7b":  pkcs7(ca),
+			},
+		},
+		{
+			name: "der-format-response",
+			encodings: []secretsv1beta1.VaultPKISecretEncoding{
+				{Key: "tls.p7b", Source: "certificate", Format: "pkcs7"},
+			},
+			certResp: &vault.PKICertResponse{
+				Certificate: base64.StdEncoding.EncodeToString(der),
+				IssuingCa:   base64.StdEncoding.EncodeToString(caDER),
+			},
+			want: map[string][]byte{
+				"tls.p7b": pkcs7(leaf, ca),
+			},
+		},
+		{
+			name: "der-multiple-certificates",
+			encodings: []secretsv1beta1.VaultPKISecretEncoding{
+				{Key: "chain.der", Source: "ca_chain", Format: "der"},
+			},
+			certResp: &vault.PKICertResponse{
+				CAChain: []string{certResp.Certificate, caPEM},
+			},
+			wantErr: `failed to encode ca_chain as der for key "chain.der": ` +
+				`the der format encodes a single certificate, found 2`,
+		},
+		{
+			name: "pkcs7-private-key",
+			encodings: []secretsv1beta1.VaultPKISecretEncoding{
+				{Key: "key.p7b", Source: "private_key", Format: "pkcs7"},
+			},
+			certResp: certResp,
+			wantErr:  `failed to encode private_key as pkcs7 for key "key.p7b": unsupported format "pkcs7"`,
+		},
+		{
+			name: "no-ca-chain",
+			encodings: []secretsv1beta1.VaultPKISecretEncoding{
+				{Key: "ca.p7b", Source: "ca_chain", Format: "pkcs7"},
+			},
+			certResp: &vault.PKICertResponse{
+				Certificate: certResp.Certificate,
+			},
+			wantErr: `failed to encode ca_chain as pkcs7 for key "ca.p7b": no ca_chain returned by Vault`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			o := &secretsv1beta1.VaultPKISecret{
+				Spec: secretsv1beta1.VaultPKISecretSpec{
+					Encodings: tt.encodings,
+				},
+			}
+			data := map[string][]byte{}
+			err := addEncodings(o, tt.certResp, data)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, data)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package controllers
+
+import (
+	"context"
+	"crypto/rand"
+	"time"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/internal/keystore"
+	"github.com/hashicorp/vault-secrets-operator/vault"
+)
+
+const (
+	defaultJKSKey         = "keystore.jks"
+	defaultJKSPasswordKey = "jks.password"
+)
+
+// addJKS adds the JKS keystore of the certificate in certResp to data, along
+// with its passphrase, and the JKS truststore of its CA chain when configured.
+func addJKS(ctx context.Context, c vault.ClientBase, o *secretsv1beta1.VaultPKISecret,
+	certResp *vault.PKICertResponse, data map[string][]byte,
+) error {
+	j := o.Spec.JKS
+	entry, err := newKeystoreEntry(o, j.Alias, certResp)
+	if err != nil {
+		return err
+	}
+
+	password, err := pkcs12Password(ctx, c, j.PasswordSource)
+	if err != nil {
+		return err
+	}
+
+	created := time.Now()
+	b, err := keystore.EncodeJKS(rand.Reader, entry, password, created)
+	if err != nil {
+		return err
+	}
+
+	key := j.Key
+	if key == "" {
+		key = defaultJKSKey
+	}
+	passwordKey := j.PasswordKey
+	if passwordKey == "" {
+		passwordKey = defaultJKSPasswordKey
+	}
+
+	if j.TruststoreKey != "" {
+		ts, err := keystore.EncodeJKSTruststore(entry.CACertificates, password, created)
+		if err != nil {
+			return err
+		}
+		data[j.TruststoreKey] = ts
+	}
+
+	data[key] = b
+	data[passwordKey] = []byte(password)
+
+	return nil
+}
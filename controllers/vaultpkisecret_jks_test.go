@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package controllers
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/vault"
+)
+
+func Test_addJKS(t *testing.T) {
+	t.Parallel()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	cert := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	certResp := &vault.PKICertResponse{
+		Certificate: cert,
+		PrivateKey:  string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})),
+		IssuingCa:   cert,
+	}
+	o := &secretsv1beta1.VaultPKISecret{
+		Spec: secretsv1beta1.VaultPKISecretSpec{
+			CommonName: "example.com",
+			JKS:        &secretsv1beta1.VaultPKISecretJKS{},
+		},
+	}
+
+	data := map[string][]byte{
+		"certificate": []byte(cert),
+	}
+	require.NoError(t, addJKS(context.Background(), nil, o, certResp, data))
+	assert.NotEmpty(t, data[defaultJKSKey])
+	assert.NotEmpty(t, data[defaultJKSPasswordKey])
+	assert.Equal(t, []byte(cert), data["certificate"])
+	assert.Len(t, data, 3)
+
+	o.Spec.JKS.TruststoreKey = "truststore.jks"
+	require.NoError(t, addJKS(context.Background(), nil, o, certResp, data))
+	assert.NotEmpty(t, data["truststore.jks"])
+
+	certResp.PrivateKey = "invalid"
+	assert.ErrorContains(t, addJKS(context.Background(), nil, o, certResp, data), "invalid private key")
+}
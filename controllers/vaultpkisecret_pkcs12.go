@@ -0,0 +1,113 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package controllers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/consts"
+	"github.com/hashicorp/vault-secrets-operator/internal/keystore"
+	"github.com/hashicorp/vault-secrets-operator/vault"
+)
+
+const (
+	defaultPKCS12Key           = "keystore.p12"
+	defaultPKCS12PasswordKey   = "keystore.password"
+	defaultPKCS12PasswordField = "password"
+)
+
+// addPKCS12 adds the PKCS#12 keystore of the certificate in certResp to data,
+// along with its passphrase.
+func addPKCS12(ctx context.Context, c vault.ClientBase, o *secretsv1beta1.VaultPKISecret,
+	certResp *vault.PKICertResponse, data map[string][]byte,
+) error {
+	p := o.Spec.PKCS12
+	entry, err := newKeystoreEntry(o, p.Alias, certResp)
+	if err != nil {
+		return err
+	}
+
+	password, err := pkcs12Password(ctx, c, p.PasswordSource)
+	if err != nil {
+		return err
+	}
+
+	b, err := keystore.EncodePKCS12(rand.Reader, entry, password)
+	if err != nil {
+		return err
+	}
+
+	key := p.Key
+	if key == "" {
+		key = defaultPKCS12Key
+	}
+	passwordKey := p.PasswordKey
+	if passwordKey == "" {
+		passwordKey = defaultPKCS12PasswordKey
+	}
+
+	data[key] = b
+	data[passwordKey] = []byte(password)
+
+	return nil
+}
+
+// newKeystoreEntry returns the keystore.Entry of the certificate in certResp,
+// whose alias defaults to the certificate's CommonName. The CA chain falls back
+// to the issuing CA, when Vault did not return any.
+func newKeystoreEntry(o *secretsv1beta1.VaultPKISecret, alias string, certResp *vault.PKICertResponse) (*keystore.Entry, error) {
+	if alias == "" {
+		alias = o.Spec.CommonName
+	}
+
+	caChain := certResp.CAChain
+	if len(caChain) == 0 && certResp.IssuingCa != "" {
+		caChain = []string{certResp.IssuingCa}
+	}
+
+	return keystore.NewEntry(alias, certResp.Certificate, certResp.PrivateKey, caChain)
+}
+
+// pkcs12Password returns the keystore's passphrase that is read from the Vault
+// KV secret s, or a random one if s is nil.
+func pkcs12Password(ctx context.Context, c vault.ClientBase, s *secretsv1beta1.VaultPKISecretPKCS12PasswordSource) (string, error) {
+	if s == nil {
+		b := make([]byte, 24)
+		if _, err := rand.Read(b); err != nil {
+			return "", err
+		}
+		return base64.RawURLEncoding.EncodeToString(b), nil
+	}
+
+	var req vault.ReadRequest
+	switch s.Type {
+	case consts.KVSecretTypeV1:
+		req = vault.NewKVReadRequestV1(s.Mount, s.Path)
+	case consts.KVSecretTypeV2, "":
+		req = vault.NewKVReadRequestV2(s.Mount, s.Path, 0)
+	default:
+		return "", fmt.Errorf("unsupported secret type %q", s.Type)
+	}
+
+	resp, err := c.Read(ctx, req)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the keystore passphrase: %w", err)
+	}
+
+	field := s.Field
+	if field == "" {
+		field = defaultPKCS12PasswordField
+	}
+
+	password, ok := resp.Data()[field].(string)
+	if !ok || password == "" {
+		return "", fmt.Errorf("keystore passphrase field %q not found in the secret at %q", field, req.Path())
+	}
+
+	return password, nil
+}
@@ -0,0 +1,156 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package controllers
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/consts"
+	"github.com/hashicorp/vault-secrets-operator/vault"
+)
+
+func Test_pkcs12Password(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		source    *secretsv1beta1.VaultPKISecretPKCS12PasswordSource
+		responses map[string][]vault.Response
+		want      string
+		wantErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name: "kv-v2",
+			source: &secretsv1beta1.VaultPKISecretPKCS12PasswordSource{
+				Mount: "kv",
+				Path:  "app/keystore",
+			},
+			responses: map[string][]vault.Response{
+				"kv/data/app/keystore": {
+					vault.NewKVV2Response(&api.Secret{
+						Data: map[string]any{
+							"data": map[string]any{
+								"password": "s3cr3t",
+							},
+						},
+					}),
+				},
+			},
+			want:    "s3cr3t",
+			wantErr: assert.NoError,
+		},
+		{
+			name: "kv-v1-field",
+			source: &secretsv1beta1.VaultPKISecretPKCS12PasswordSource{
+				Mount: "kv",
+				Path:  "app/keystore",
+				Type:  consts.KVSecretTypeV1,
+				Field: "passphrase",
+			},
+			responses: map[string][]vault.Response{
+				"kv/app/keystore": {
+					vault.NewKVV1Response(&api.Secret{
+						Data: map[string]any{
+							"passphrase": "s3cr3t",
+						},
+					}),
+				},
+			},
+			want:    "s3cr3t",
+			wantErr: assert.NoError,
+		},
+		{
+			name: "missing-field",
+			source: &secretsv1beta1.VaultPKISecretPKCS12PasswordSource{
+				Mount: "kv",
+				Path:  "app/keystore",
+				Type:  consts.KVSecretTypeV1,
+			},
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.EqualError(t, err,
+					`keystore passphrase field "password" not found in the secret at "kv/app/keystore"`, i...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := &vault.MockRecordingVaultClient{
+				ReadResponses: tt.responses,
+			}
+			got, err := pkcs12Password(context.Background(), c, tt.source)
+			if !tt.wantErr(t, err) {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("generated", func(t *testing.T) {
+		t.Parallel()
+
+		a, err := pkcs12Password(context.Background(), nil, nil)
+		require.NoError(t, err)
+		b, err := pkcs12Password(context.Background(), nil, nil)
+		require.NoError(t, err)
+		assert.Len(t, a, 32)
+		assert.NotEqual(t, a, b)
+	})
+}
+
+func Test_addPKCS12(t *testing.T) {
+	t.Parallel()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certResp := &vault.PKICertResponse{
+		Certificate: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		PrivateKey:  string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})),
+	}
+	o := &secretsv1beta1.VaultPKISecret{
+		Spec: secretsv1beta1.VaultPKISecretSpec{
+			CommonName: "example.com",
+			PKCS12: &secretsv1beta1.VaultPKISecretPKCS12{
+				Key: "app.p12",
+			},
+		},
+	}
+
+	data := map[string][]byte{
+		"certificate": []byte(certResp.Certificate),
+	}
+	require.NoError(t, addPKCS12(context.Background(), nil, o, certResp, data))
+	assert.NotEmpty(t, data["app.p12"])
+	assert.NotEmpty(t, data[defaultPKCS12PasswordKey])
+	assert.Equal(t, []byte(certResp.Certificate), data["certificate"])
+
+	certResp.PrivateKey = "invalid"
+	assert.ErrorContains(t, addPKCS12(context.Background(), nil, o, certResp, data), "invalid private key")
+}
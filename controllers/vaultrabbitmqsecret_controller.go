@@ -0,0 +1,118 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/vault"
+)
+
+const vaultRabbitMQSecretFinalizer = "vaultrabbitmqsecret.secrets.hashicorp.com/finalizer"
+
+var _ reconcile.Reconciler = &VaultRabbitMQSecretReconciler{}
+
+// VaultRabbitMQSecretReconciler reconciles a VaultRabbitMQSecret object
+type VaultRabbitMQSecretReconciler = LeasedSecretReconciler[*secretsv1beta1.VaultRabbitMQSecret, vaultRabbitMQSecretKind]
+
+// +kubebuilder:rbac:groups=secrets.hashicorp.com,resources=vaultrabbitmqsecrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=secrets.hashicorp.com,resources=vaultrabbitmqsecrets/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=secrets.hashicorp.com,resources=vaultrabbitmqsecrets/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+
+// vaultRabbitMQSecretKind ensures that the VaultRabbitMQSecret Custom Resource
+// is synced from Vault's RabbitMQ secrets engine to its configured Kubernetes
+// secret. The credentials' lease is renewed periodically, if the renewal fails
+// or the lease is not renewable, new credentials are generated. The credentials
+// can also be rendered as an AMQP connection URI.
+type vaultRabbitMQSecretKind struct{}
+
+func (vaultRabbitMQSecretKind) resourceKind() ResourceKind {
+	return VaultRabbitMQSecret
+}
+
+func (vaultRabbitMQSecretKind) finalizer() string {
+	return vaultRabbitMQSecretFinalizer
+}
+
+func (vaultRabbitMQSecretKind) newObject() *secretsv1beta1.VaultRabbitMQSecret {
+	return &secretsv1beta1.VaultRabbitMQSecret{}
+}
+
+func (vaultRabbitMQSecretKind) newLeasedSecret(o *secretsv1beta1.VaultRabbitMQSecret) (*leasedSecret, error) {
+	return newVaultRabbitMQLeasedSecret(o), nil
+}
+
+func newVaultRabbitMQLeasedSecret(o *secretsv1beta1.VaultRabbitMQSecret) *leasedSecret {
+	mount := strings.Trim(o.Spec.Mount, "/")
+	if mount == "" {
+		mount = "rabbitmq"
+	}
+
+	ls := &leasedSecret{
+		obj:            o,
+		path:           fmt.Sprintf("%s/creds/%s", mount, o.Spec.Role),
+		method:         http.MethodGet,
+		renewalPercent: o.Spec.RenewalPercent,
+		revoke:         o.Spec.Revoke,
+		destination:    &o.Spec.Destination,
+		status:         &o.Status.VaultLeasedSecretStatus,
+	}
+	if o.Spec.ConnectionURI != nil {
+		ls.addData = func(resp vault.Response, data map[string][]byte) error {
+			return addRabbitMQConnectionURI(o.Spec.ConnectionURI, resp.Data(), data)
+		}
+	}
+
+	return ls
+}
+
+// addRabbitMQConnectionURI renders the AMQP connection URI from the credentials
+// in respData, and adds it to data.
+func addRabbitMQConnectionURI(cfg *secretsv1beta1.RabbitMQConnectionURI, respData map[string]any, data map[string][]byte) error {
+	username, _ := respData["username"].(string)
+	password, _ := respData["password"].(string)
+	if username == "" || password == "" {
+		return errors.New("response does not contain the username and password")
+	}
+
+	key := cfg.Key
+	if key == "" {
+		key = "uri"
+	}
+
+	host := cfg.Host
+	if cfg.Port > 0 {
+		host = net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port))
+	}
+
+	u := &url.URL{
+		Scheme: "amqp",
+		User:   url.UserPassword(username, password),
+		Host:   host,
+	}
+	if cfg.TLS {
+		u.Scheme = "amqps"
+	}
+	if cfg.VHost != "" {
+		// the vhost is a single path segment, any slashes it contains must be
+		// escaped, e.g. the vhost "/" is rendered as "%2F".
+		u.Path = "/" + cfg.VHost
+		u.RawPath = "/" + url.PathEscape(cfg.VHost)
+	}
+
+	data[key] = []byte(u.String())
+
+	return nil
+}
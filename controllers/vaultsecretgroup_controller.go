@@ -0,0 +1,357 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package controllers
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"maps"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/common"
+	"github.com/hashicorp/vault-secrets-operator/consts"
+	"github.com/hashicorp/vault-secrets-operator/helpers"
+	"github.com/hashicorp/vault-secrets-operator/internal/cloudevents"
+	"github.com/hashicorp/vault-secrets-operator/internal/diagnostics"
+)
+
+const vaultSecretGroupFinalizer = "vaultsecretgroup.secrets.hashicorp.com/finalizer"
+
+// VaultSecretGroupReconciler reconciles a VaultSecretGroup object
+type VaultSecretGroupReconciler struct {
+	client.Client
+	Scheme                      *runtime.Scheme
+	Recorder                    record.EventRecorder
+	SecretDataBuilder           *helpers.SecretDataBuilder
+	SecretsClient               client.Client
+	HMACValidator               helpers.HMACValidator
+	GlobalTransformationOptions *helpers.GlobalTransformationOptions
+	referenceCache              ResourceReferenceCache
+}
+
+// +kubebuilder:rbac:groups=secrets.hashicorp.com,resources=vaultsecretgroups,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=secrets.hashicorp.com,resources=vaultsecretgroups/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=secrets.hashicorp.com,resources=vaultsecretgroups/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+//
+// required for rollout-restart
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=apps,resources=daemonsets,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=argoproj.io,resources=rollouts,verbs=get;list;watch;patch
+//
+
+// Reconcile ensures that the data of all the VaultSecretGroup Custom
+// Resource's sources is merged into its configured Kubernetes secret. The
+// sources are other syncable secret resources, the group reads their
+// destination Secrets, and is reconciled whenever any of them changes.
+func (r *VaultSecretGroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	o := &secretsv1beta1.VaultSecretGroup{}
+	if err := r.Client.Get(ctx, req.NamespacedName, o); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+
+		logger.Error(err, "error getting resource from k8s", "secret", o)
+		return ctrl.Result{}, err
+	}
+
+	if o.GetDeletionTimestamp() != nil {
+		logger.Info("Got deletion timestamp", "obj", o)
+		return ctrl.Result{}, r.handleDeletion(ctx, o)
+	}
+
+	// the status is only updated if it changes, since it is unchanged for most
+	// reconciliations, when the secret data is already in sync.
+	origStatus := o.Status.DeepCopy()
+
+	r.referenceCache.Set(SecretTransformation, req.NamespacedName,
+		helpers.GetTransformationRefObjKeys(
+			o.Spec.Destination.Transformation, o.Namespace)...)
+
+	transOption, err := helpers.NewSecretTransformationOption(ctx, r.Client, o, r.GlobalTransformationOptions)
+	if err != nil {
+		r.recordSyncError(ctx, o, consts.ReasonTransformationError,
+			"Failed setting up SecretTransformationOption: %s", err)
+		return ctrl.Result{RequeueAfter: computeHorizonWithJitter(requeueDurationOnError)}, nil
+	}
+
+	sources, err := r.getSourceData(ctx, o)
+	// the source Secrets are cached even on error, so that the group is synced
+	// as soon as the missing Secrets are created.
+	if err != nil {
+		r.recordSyncError(ctx, o, consts.ReasonVaultSecretGroup,
+			"Failed to get the sources' data: %s", err)
+		return ctrl.Result{RequeueAfter: computeHorizonWithJitter(requeueDurationOnError)}, nil
+	}
+
+	merged, conflicts, err := mergeSecretGroupData(o.Spec.ConflictPolicy, sources)
+	if err != nil {
+		r.recordSyncError(ctx, o, consts.ReasonSecretGroupConflict,
+			"Failed to merge the sources' data: %s", err)
+		return ctrl.Result{RequeueAfter: computeHorizonWithJitter(requeueDurationOnError)}, nil
+	}
+	if len(conflicts) > 0 {
+		r.Recorder.Eventf(o, corev1.EventTypeWarning, consts.ReasonSecretGroupConflict,
+			"Keys provided by more than one source, conflictPolicy=%s, keys=%v",
+			o.Spec.ConflictPolicy, conflicts)
+	}
+
+	data, err := r.SecretDataBuilder.WithVaultData(merged, merged, transOption)
+	if err != nil {
+		r.recordSyncError(ctx, o, consts.ReasonSecretDataBuilderError,
+			"Failed to build K8s secret data: %s", err)
+		return ctrl.Result{RequeueAfter: computeHorizonWithJitter(requeueDurationOnError)}, nil
+	}
+
+	// doRolloutRestart only if this is not the first time this secret has been synced
+	doRolloutRestart := o.Status.SecretMAC != ""
+	macsEqual, messageMAC, err := helpers.HandleSecretHMAC(ctx, r.SecretsClient, r.HMACValidator, o, data)
+	if err != nil {
+		r.recordSyncError(ctx, o, consts.ReasonHMACDataError,
+			"Failed to HMAC the secret data: %s", err)
+		return ctrl.Result{RequeueAfter: computeHorizonWithJitter(requeueDurationOnError)}, nil
+	}
+
+	doSync := true
+	if o.Status.LastGeneration == o.GetGeneration() {
+		doSync = !macsEqual
+	}
+
+	if doSync {
+		err := helpers.SyncSecret(ctx, r.Client, o, data)
+		helpers.SetDestinationConflictCondition(&o.Status.Conditions, o.GetGeneration(), err)
+		helpers.SetFanOutDeniedCondition(&o.Status.Conditions, o.GetGeneration(), err)
+		if err != nil {
+			r.recordSyncError(ctx, o, syncSecretErrorReason(err),
+				"Failed to update k8s secret: %s", err)
+			return ctrl.Result{RequeueAfter: computeHorizonWithJitter(requeueDurationOnError)}, nil
+		}
+		reason := consts.ReasonSecretSynced
+		if doRolloutRestart {
+			reason = consts.ReasonSecretRotated
+			// rollout-restart errors are not retryable
+			// all error reporting is handled by helpers.HandleRolloutRestarts
+			_ = helpers.HandleRolloutRestarts(ctx, r.Client, o, r.Recorder)
+		}
+		r.Recorder.Event(o, corev1.EventTypeNormal, reason, "Secret synced")
+		if reason == consts.ReasonSecretRotated {
+			cloudevents.Emit(ctx, cloudevents.TypeSecretRotated, o, cloudevents.Data{})
+		}
+		o.Status.LastSyncMessages = appendSyncMessage(o.Status.LastSyncMessages,
+			secretsv1beta1.SyncResultSuccess, reason, "Secret synced")
+	} else {
+		logger.V(consts.LogLevelDebug).Info("Secret sync not required")
+	}
+
+	o.Status.SecretMAC = base64.StdEncoding.EncodeToString(messageMAC)
+	if err := r.updateStatus(ctx, o, origStatus); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// secretGroupSourceData holds the data of a single VaultSecretGroup source.
+type secretGroupSourceData struct {
+	source secretsv1beta1.SecretGroupSource
+	data   map[string][]byte
+}
+
+// getSourceData returns the data of the destination Secret of all of o's
+// sources, in order. The destination Secrets are always cached for o, even if
+// they do not exist yet.
+func (r *VaultSecretGroupReconciler) getSourceData(ctx context.Context, o *secretsv1beta1.VaultSecretGroup) ([]*secretGroupSourceData, error) {
+	var refs []client.ObjectKey
+	defer func() {
+		r.referenceCache.Set(VaultSecretGroup, client.ObjectKeyFromObject(o), refs...)
+	}()
+
+	var ret []*secretGroupSourceData
+	for _, src := range o.Spec.Sources {
+		ro, err := r.Scheme.New(secretsv1beta1.GroupVersion.WithKind(src.Kind))
+		if err != nil {
+			return nil, fmt.Errorf("unsupported source kind %q: %w", src.Kind, err)
+		}
+
+		obj, ok := ro.(client.Object)
+		if !ok {
+			return nil, fmt.Errorf("unsupported source kind %q", src.Kind)
+		}
+
+		if err := r.Client.Get(ctx, client.ObjectKey{Namespace: o.Namespace, Name: src.Name}, obj); err != nil {
+			return nil, fmt.Errorf("failed to get source %s %s: %w", src.Kind, src.Name, err)
+		}
+
+		meta, err := common.NewSyncableSecretMetaData(obj)
+		if err != nil {
+			return nil, err
+		}
+
+		objKey := client.ObjectKey{Namespace: o.Namespace, Name: meta.Destination.Name}
+		refs = append(refs, objKey)
+		s, err := helpers.GetSecret(ctx, r.Client, objKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the destination Secret of source %s %s: %w",
+				src.Kind, src.Name, err)
+		}
+
+		ret = append(ret, &secretGroupSourceData{
+			source: src,
+			data:   s.Data,
+		})
+	}
+
+	return ret, nil
+}
+
+// mergeSecretGroupData merges the data of all sources, in order, per the
+// conflict policy. The sources' _raw data is always dropped. It returns the
+// merged data along with the conflicting keys, which is an error for the Fail
+// policy.
+func mergeSecretGroupData(policy string, sources []*secretGroupSourceData) (map[string]any, []string, error) {
+	ret := make(map[string]any)
+	var conflicts []string
+	for _, src := range sources {
+		for _, k := range slices.Sorted(maps.Keys(src.data)) {
+			if k == helpers.SecretDataKeyRaw {
+				continue
+			}
+
+			key := src.source.Prefix + k
+			if _, ok := ret[key]; ok {
+				conflicts = append(conflicts, key)
+				switch policy {
+				case secretsv1beta1.SecretGroupConflictPolicyLastWins:
+				case secretsv1beta1.SecretGroupConflictPolicyFirstWins:
+					continue
+				default:
+					return nil, nil, fmt.Errorf("key %q from source %s %s is provided by another source",
+						key, src.source.Kind, src.source.Name)
+				}
+			}
+			ret[key] = string(src.data[k])
+		}
+	}
+
+	return ret, conflicts, nil
+}
+
+// updateStatus updates o's status, unless it is equal to origStatus. A nil
+// origStatus forces the update.
+func (r *VaultSecretGroupReconciler) updateStatus(ctx context.Context, o *secretsv1beta1.VaultSecretGroup,
+	origStatus *secretsv1beta1.VaultSecretGroupStatus,
+) error {
+	logger := log.FromContext(ctx)
+	o.Status.LastGeneration = o.GetGeneration()
+	if origStatus != nil && equality.Semantic.DeepEqual(origStatus, &o.Status) {
+		logger.V(consts.LogLevelDebug).Info("Status unchanged, skipping update")
+	} else {
+		logger.V(consts.LogLevelDebug).Info("Updating status")
+		if err := r.Status().Update(ctx, o); err != nil {
+			r.Recorder.Eventf(o, corev1.EventTypeWarning, consts.ReasonStatusUpdateError,
+				"Failed to update the resource's status, err=%s", err)
+		}
+	}
+
+	_, err := maybeAddFinalizer(ctx, r.Client, o, vaultSecretGroupFinalizer)
+	return err
+}
+
+// recordSyncError emits a warning event for the failed sync attempt and records
+// it in the resource's Status.LastSyncMessages.
+func (r *VaultSecretGroupReconciler) recordSyncError(ctx context.Context, o *secretsv1beta1.VaultSecretGroup, reason, msg string, a ...any) {
+	r.Recorder.Eventf(o, corev1.EventTypeWarning, reason, msg, a...)
+	messages := appendSyncMessage(o.Status.LastSyncMessages,
+		secretsv1beta1.SyncResultFailure, reason, msg, a...)
+	if err := patchSyncMessages(ctx, r.Client, o, messages, o.Status.Conditions); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to record the sync error in the resource's status")
+	}
+	diagnostics.RecordFailure(ctx, r.Client, o, messages, o.Status.Conditions, reason, a...)
+}
+
+func (r *VaultSecretGroupReconciler) handleDeletion(ctx context.Context, o *secretsv1beta1.VaultSecretGroup) error {
+	logger := log.FromContext(ctx)
+	objKey := client.ObjectKeyFromObject(o)
+	r.referenceCache.Remove(SecretTransformation, objKey)
+	r.referenceCache.Remove(VaultSecretGroup, objKey)
+	helpers.DeleteSecretlessData(o)
+	if err := helpers.HandleDestinationDeletion(ctx, r.Client, o); err != nil {
+		logger.Error(err, "Failed to apply the deletion policy of the destination Secrets")
+		return err
+	}
+	if controllerutil.ContainsFinalizer(o, vaultSecretGroupFinalizer) {
+		logger.Info("Removing finalizer")
+		if controllerutil.RemoveFinalizer(o, vaultSecretGroupFinalizer) {
+			if err := r.Update(ctx, o); err != nil {
+				logger.Error(err, "Failed to remove the finalizer")
+				return err
+			}
+			logger.Info("Successfully removed the finalizer")
+		}
+	}
+	return nil
+}
+
+// groupsForSecret maps a Secret to the VaultSecretGroups that either use it as
+// a source, or own it as their destination.
+func (r *VaultSecretGroupReconciler) groupsForSecret(_ context.Context, obj client.Object) []reconcile.Request {
+	var reqs []reconcile.Request
+	for _, objKey := range r.referenceCache.Get(VaultSecretGroup, client.ObjectKeyFromObject(obj)) {
+		reqs = append(reqs, reconcile.Request{NamespacedName: objKey})
+	}
+
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.APIVersion == secretsv1beta1.GroupVersion.String() && ref.Kind == VaultSecretGroup.String() {
+			reqs = append(reqs, reconcile.Request{
+				NamespacedName: client.ObjectKey{
+					Namespace: obj.GetNamespace(),
+					Name:      ref.Name,
+				},
+			})
+		}
+	}
+
+	return reqs
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *VaultSecretGroupReconciler) SetupWithManager(mgr ctrl.Manager, opts controller.Options) error {
+	r.referenceCache = newResourceReferenceCache()
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&secretsv1beta1.VaultSecretGroup{},
+			builder.WithPredicates(syncableSecretPredicate(nil))).
+		WithOptions(opts).
+		Watches(
+			&secretsv1beta1.SecretTransformation{},
+			NewEnqueueRefRequestsHandlerST(r.referenceCache, nil),
+		).
+		// the predicates are set per watch, since the Secrets' data updates
+		// must not be filtered out.
+		WatchesMetadata(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.groupsForSecret),
+		).
+		Complete(r)
+}
@@ -0,0 +1,196 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/helpers"
+	"github.com/hashicorp/vault-secrets-operator/internal/testutils"
+)
+
+func Test_mergeSecretGroupData(t *testing.T) {
+	t.Parallel()
+
+	sources := []*secretGroupSourceData{
+		{
+			source: secretsv1beta1.SecretGroupSource{
+				Kind:   "VaultDynamicSecret",
+				Name:   "db",
+				Prefix: "db_",
+			},
+			data: map[string][]byte{
+				"username":               []byte("dbuser"),
+				"password":               []byte("dbpass"),
+				helpers.SecretDataKeyRaw: []byte(`{}`),
+			},
+		},
+		{
+			source: secretsv1beta1.SecretGroupSource{
+				Kind: "VaultStaticSecret",
+				Name: "api",
+			},
+			data: map[string][]byte{
+				"api_key":     []byte("key"),
+				"db_password": []byte("other"),
+			},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		policy        string
+		want          map[string]any
+		wantConflicts []string
+		wantErr       assert.ErrorAssertionFunc
+	}{
+		{
+			name:   "fail",
+			policy: secretsv1beta1.SecretGroupConflictPolicyFail,
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.EqualError(t, err,
+					`key "db_password" from source VaultStaticSecret api is provided by another source`, i...)
+			},
+		},
+		{
+			name:   "first-wins",
+			policy: secretsv1beta1.SecretGroupConflictPolicyFirstWins,
+			want: map[string]any{
+				"db_username": "dbuser",
+				"db_password": "dbpass",
+				"api_key":     "key",
+			},
+			wantConflicts: []string{"db_password"},
+			wantErr:       assert.NoError,
+		},
+		{
+			name:   "last-wins",
+			policy: secretsv1beta1.SecretGroupConflictPolicyLastWins,
+			want: map[string]any{
+				"db_username": "dbuser",
+				"db_password": "other",
+				"api_key":     "key",
+			},
+			wantConflicts: []string{"db_password"},
+			wantErr:       assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, conflicts, err := mergeSecretGroupData(tt.policy, sources)
+			if !tt.wantErr(t, err) {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantConflicts, conflicts)
+		})
+	}
+}
+
+func TestVaultSecretGroupReconciler_getSourceData(t *testing.T) {
+	ctx := context.Background()
+	o := &secretsv1beta1.VaultSecretGroup{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: secretsv1beta1.GroupVersion.String(),
+			Kind:       "VaultSecretGroup",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "group",
+			Namespace: "baz",
+		},
+		Spec: secretsv1beta1.VaultSecretGroupSpec{
+			Sources: []secretsv1beta1.SecretGroupSource{
+				{
+					Kind: "VaultStaticSecret",
+					Name: "api",
+				},
+			},
+			Destination: secretsv1beta1.Destination{
+				Name: "app",
+			},
+		},
+	}
+
+	k8sClient := testutils.NewFakeClientBuilder().Build()
+	r := &VaultSecretGroupReconciler{
+		Client:         k8sClient,
+		Scheme:         k8sClient.Scheme(),
+		referenceCache: newResourceReferenceCache(),
+	}
+
+	_, err := r.getSourceData(ctx, o)
+	assert.ErrorContains(t, err, "failed to get source VaultStaticSecret api")
+
+	require.NoError(t, k8sClient.Create(ctx, &secretsv1beta1.VaultStaticSecret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "api",
+			Namespace: "baz",
+		},
+		Spec: secretsv1beta1.VaultStaticSecretSpec{
+			Destination: secretsv1beta1.Destination{
+				Name: "api-secret",
+			},
+		},
+	}))
+	_, err = r.getSourceData(ctx, o)
+	assert.ErrorContains(t, err, "failed to get the destination Secret of source VaultStaticSecret api")
+
+	// the missing destination Secret is cached, so its creation triggers a sync.
+	secretKey := client.ObjectKey{Namespace: "baz", Name: "api-secret"}
+	want := []reconcile.Request{
+		{NamespacedName: client.ObjectKeyFromObject(o)},
+	}
+	assert.Equal(t, want, r.groupsForSecret(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretKey.Name,
+			Namespace: secretKey.Namespace,
+		},
+	}))
+
+	require.NoError(t, k8sClient.Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretKey.Name,
+			Namespace: secretKey.Namespace,
+		},
+		Data: map[string][]byte{
+			"api_key": []byte("key"),
+		},
+	}))
+	got, err := r.getSourceData(ctx, o)
+	require.NoError(t, err)
+	assert.Equal(t, []*secretGroupSourceData{
+		{
+			source: o.Spec.Sources[0],
+			data: map[string][]byte{
+				"api_key": []byte("key"),
+			},
+		},
+	}, got)
+
+	// the group's own destination Secret.
+	assert.Equal(t, want, r.groupsForSecret(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "app",
+			Namespace: "baz",
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: secretsv1beta1.GroupVersion.String(),
+					Kind:       "VaultSecretGroup",
+					Name:       "group",
+				},
+			},
+		},
+	}))
+}
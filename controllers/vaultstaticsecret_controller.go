@@ -7,14 +7,19 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"nhooyr.io/websocket"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -32,7 +37,10 @@ import (
 	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
 	"github.com/hashicorp/vault-secrets-operator/consts"
 	"github.com/hashicorp/vault-secrets-operator/helpers"
-
+	"github.com/hashicorp/vault-secrets-operator/internal/cloudevents"
+	"github.com/hashicorp/vault-secrets-operator/internal/diagnostics"
+	"github.com/hashicorp/vault-secrets-operator/internal/featuregates"
+	"github.com/hashicorp/vault-secrets-operator/internal/metrics"
 	"github.com/hashicorp/vault-secrets-operator/vault"
 )
 
@@ -44,12 +52,15 @@ const (
 // VaultStaticSecretReconciler reconciles a VaultStaticSecret object
 type VaultStaticSecretReconciler struct {
 	client.Client
-	Scheme                      *runtime.Scheme
-	Recorder                    record.EventRecorder
-	ClientFactory               vault.ClientFactory
-	SecretDataBuilder           *helpers.SecretDataBuilder
-	SecretsClient               client.Client
-	HMACValidator               helpers.HMACValidator
+	Scheme            *runtime.Scheme
+	Recorder          record.EventRecorder
+	ClientFactory     vault.ClientFactory
+	SecretDataBuilder *helpers.SecretDataBuilder
+	SecretsClient     client.Client
+	HMACValidator     helpers.HMACValidator
+	// MACStore holds the MACs of the synced data. If nil, they are kept in
+	// each resource's Status.SecretMAC.
+	MACStore                    *helpers.SecretMACStore
 	referenceCache              ResourceReferenceCache
 	GlobalTransformationOptions *helpers.GlobalTransformationOptions
 	BackOffRegistry             *BackOffRegistry
@@ -65,6 +76,7 @@ type VaultStaticSecretReconciler struct {
 // +kubebuilder:rbac:groups=secrets.hashicorp.com,resources=vaultstaticsecrets/finalizers,verbs=update
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch
 //
 // required for rollout-restart
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;patch
@@ -72,6 +84,9 @@ type VaultStaticSecretReconciler struct {
 // +kubebuilder:rbac:groups=apps,resources=daemonsets,verbs=get;list;watch;patch
 // +kubebuilder:rbac:groups=argoproj.io,resources=rollouts,verbs=get;list;watch;patch
 //
+// required for the destination's namespaceSelector
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+//
 
 func (r *VaultStaticSecretReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
@@ -91,9 +106,14 @@ func (r *VaultStaticSecretReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		return ctrl.Result{}, r.handleDeletion(ctx, o)
 	}
 
+	// the status is only updated if it changes, since it is unchanged for most
+	// reconciliations, when the secret data is already in sync.
+	origStatus := o.Status.DeepCopy()
+
 	c, err := r.ClientFactory.Get(ctx, r.Client, o)
 	if err != nil {
-		r.Recorder.Eventf(o, corev1.EventTypeWarning, consts.ReasonVaultClientConfigError,
+		r.markServingStaleData(ctx, o, err)
+		r.recordSyncError(ctx, o, consts.ReasonVaultClientConfigError,
 			"Failed to get Vault auth login: %s", err)
 		return ctrl.Result{RequeueAfter: computeHorizonWithJitter(requeueDurationOnError)}, nil
 	}
@@ -103,7 +123,7 @@ func (r *VaultStaticSecretReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		d, err := parseDurationString(o.Spec.RefreshAfter, ".spec.refreshAfter", 0)
 		if err != nil {
 			logger.Error(err, "Field validation failed")
-			r.Recorder.Eventf(o, corev1.EventTypeWarning, consts.ReasonVaultStaticSecret,
+			r.recordSyncError(ctx, o, consts.ReasonVaultStaticSecret,
 				"Field validation failed, err=%s", err)
 			return ctrl.Result{RequeueAfter: computeHorizonWithJitter(requeueDurationOnError)}, nil
 		}
@@ -111,39 +131,79 @@ func (r *VaultStaticSecretReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	}
 
 	r.referenceCache.Set(SecretTransformation, req.NamespacedName,
-		helpers.GetTransformationRefObjKeys(
-			o.Spec.Destination.Transformation, o.Namespace)...)
+		helpers.GetDestinationsTransformationRefObjKeys(
+			append([]secretsv1beta1.Destination{o.Spec.Destination}, o.Spec.AdditionalDestinations...),
+			o.Namespace)...)
 
 	transOption, err := helpers.NewSecretTransformationOption(ctx, r.Client, o, r.GlobalTransformationOptions)
 	if err != nil {
-		r.Recorder.Eventf(o, corev1.EventTypeWarning, consts.ReasonTransformationError,
+		r.recordSyncError(ctx, o, consts.ReasonTransformationError,
 			"Failed setting up SecretTransformationOption: %s", err)
 		return ctrl.Result{RequeueAfter: computeHorizonWithJitter(requeueDurationOnError)}, nil
 	}
 
 	kvReq, err := newKVRequest(o.Spec)
 	if err != nil {
-		r.Recorder.Event(o, corev1.EventTypeWarning, consts.ReasonVaultStaticSecret, err.Error())
+		r.recordSyncError(ctx, o, consts.ReasonVaultStaticSecret, "%s", err)
 		return ctrl.Result{RequeueAfter: computeHorizonWithJitter(requeueDurationOnError)}, nil
 	}
 
-	resp, err := c.Read(ctx, kvReq)
+	if needsSeed(o) {
+		seeded, err := seedKV(ctx, c, r.Client, o)
+		if errors.Is(err, errSeedConflict) {
+			// the concurrent write wins, it is synced like any other secret.
+			r.Recorder.Eventf(o, corev1.EventTypeWarning, consts.ReasonSeedConflict,
+				"Skipped seeding the Vault secret: %s", err)
+		} else if err != nil {
+			r.recordSyncError(ctx, o, consts.ReasonSeedError,
+				"Failed to seed the Vault secret: %s", err)
+			return ctrl.Result{RequeueAfter: computeHorizonWithJitter(requeueDurationOnError)}, nil
+		}
+		if seeded {
+			r.Recorder.Eventf(o, corev1.EventTypeNormal, consts.ReasonSecretSeeded,
+				"Seeded the Vault secret from the Kubernetes Secret")
+		}
+		o.Status.Seeded = true
+	}
+
+	var resp vault.Response
+	var prefixResps map[string]vault.Response
+	var pathsResps []vault.Response
+	switch {
+	case len(o.Spec.Paths) > 0:
+		pathsResps, err = readKVPaths(ctx, c, o.Spec)
+	case o.Spec.Prefix != nil:
+		prefixResps, err = readKVPrefix(ctx, c, o.Spec)
+	default:
+		resp, err = c.Read(ctx, kvReq)
+	}
 	if err != nil {
 		if vault.IsForbiddenError(err) {
 			c.Taint()
 		}
 
 		entry, _ := r.BackOffRegistry.Get(req.NamespacedName)
-		r.Recorder.Eventf(o, corev1.EventTypeWarning, consts.ReasonVaultClientError,
+		r.markServingStaleData(ctx, o, err)
+		r.recordSyncError(ctx, o, consts.ReasonVaultClientError,
 			"Failed to read Vault secret: %s", err)
 		return ctrl.Result{RequeueAfter: entry.NextBackOff()}, nil
 	} else {
 		r.BackOffRegistry.Delete(req.NamespacedName)
+		r.clearServingStaleData(o)
 	}
 
-	data, err := r.SecretDataBuilder.WithVaultData(resp.Data(), resp.Secret().Data, transOption)
+	var data map[string][]byte
+	var pathData map[string]map[string][]byte
+	switch {
+	case len(o.Spec.Paths) > 0:
+		data, err = buildPathsData(r.SecretDataBuilder, o.Spec, pathsResps, transOption)
+	case o.Spec.Prefix != nil:
+		data, pathData, err = buildPrefixData(r.SecretDataBuilder, o, prefixResps, transOption)
+	default:
+		data, err = r.SecretDataBuilder.WithVaultData(resp.Data(), resp.Secret().Data, transOption)
+	}
 	if err != nil {
-		r.Recorder.Eventf(o, corev1.EventTypeWarning, consts.ReasonSecretDataBuilderError,
+		r.recordSyncError(ctx, o, consts.ReasonSecretDataBuilderError,
 			"Failed to build K8s secret data: %s", err)
 		return ctrl.Result{RequeueAfter: computeHorizonWithJitter(requeueDurationOnError)}, nil
 	}
@@ -158,46 +218,119 @@ func (r *VaultStaticSecretReconciler) Reconcile(ctx context.Context, req ctrl.Re
 			requeueAfter = computeHorizonWithJitter(time.Second * 60)
 		}
 
+		// the MAC is held in o.Status.SecretMAC for the rest of the
+		// reconciliation, it is moved to the MACStore before the status is
+		// updated.
+		if err := r.loadSecretMAC(ctx, o); err != nil {
+			r.recordSyncError(ctx, o, consts.ReasonSecretSyncError,
+				"Failed to load the secret MAC: %s", err)
+			return ctrl.Result{RequeueAfter: computeHorizonWithJitter(requeueDurationOnError)}, nil
+		}
+
 		// doRolloutRestart only if this is not the first time this secret has been synced
 		doRolloutRestart = o.Status.SecretMAC != ""
 
-		macsEqual, messageMAC, err := helpers.HandleSecretHMAC(ctx, r.SecretsClient, r.HMACValidator, o, data)
+		var macsEqual bool
+		var messageMAC []byte
+		if isPerPathPrefix(o) {
+			macsEqual, messageMAC, err = hmacPrefixData(ctx, r.SecretsClient, r.HMACValidator, o, data)
+		} else {
+			macsEqual, messageMAC, err = helpers.HandleSecretHMAC(ctx, r.SecretsClient, r.HMACValidator, o, data)
+		}
 		if err != nil {
 			return ctrl.Result{RequeueAfter: computeHorizonWithJitter(requeueDurationOnError)}, nil
 		}
 
-		// skip the next sync if the data has not changed since the last sync, and the
-		// resource has not been updated.
-		if o.Status.LastGeneration == o.GetGeneration() {
-			doSync = !macsEqual
+		if isPerPathPrefix(o) {
+			// the per-path Secrets are always synced, the MAC only tells whether
+			// the Vault secret data has changed.
+			doRolloutRestart = doRolloutRestart && !macsEqual
+		} else if o.Status.LastGeneration == o.GetGeneration() {
+			// skip the next sync if the data has not changed since the last sync, and the
+			// resource has not been updated.
+			doSync = !macsEqual || (o.Spec.SyncConfig != nil && o.Spec.SyncConfig.ForceSyncAlways)
+			// a forced sync of unchanged data is not a rotation.
+			doRolloutRestart = doRolloutRestart && !macsEqual
 		}
 
 		o.Status.SecretMAC = base64.StdEncoding.EncodeToString(messageMAC)
-	} else if len(o.Spec.RolloutRestartTargets) > 0 {
-		logger.V(consts.LogLevelWarning).Info("Ignoring RolloutRestartTargets",
-			"hmacSecretData", o.Spec.HMACSecretData,
-			"targets", o.Spec.RolloutRestartTargets)
+	} else {
+		// the sync progress is keyed by the secret's MAC, so it cannot be tracked.
+		o.Status.SyncProgress = nil
+		if len(o.Spec.RolloutRestartTargets) > 0 {
+			logger.V(consts.LogLevelWarning).Info("Ignoring RolloutRestartTargets",
+				"hmacSecretData", o.Spec.HMACSecretData,
+				"targets", o.Spec.RolloutRestartTargets)
+		}
 	}
 
 	if doSync {
-		if err := helpers.SyncSecret(ctx, r.Client, o, data); err != nil {
-			r.Recorder.Eventf(o, corev1.EventTypeWarning, consts.ReasonSecretSyncError,
+		var err error
+		if isPerPathPrefix(o) {
+			err = syncPerPathSecrets(ctx, r.Client, o, pathData)
+		} else {
+			opts := helpers.DefaultSyncOptions()
+			opts.SourceVersion = kvSecretVersion(resp)
+			err = helpers.SyncSecret(ctx, r.Client, o, data, opts)
+		}
+		if err == nil && len(o.Spec.AdditionalDestinations) > 0 {
+			err = helpers.SyncAdditionalDestinations(ctx, r.Client, o, r.GlobalTransformationOptions,
+				func(opt *helpers.SecretTransformationOption) (map[string][]byte, error) {
+					if len(o.Spec.Paths) > 0 {
+						return buildPathsData(r.SecretDataBuilder, o.Spec, pathsResps, opt)
+					}
+					if o.Spec.Prefix != nil {
+						d, _, err := buildPrefixData(r.SecretDataBuilder, o, prefixResps, opt)
+						return d, err
+					}
+					return r.SecretDataBuilder.WithVaultData(resp.Data(), resp.Secret().Data, opt)
+				})
+		}
+		helpers.SetDestinationConflictCondition(&o.Status.Conditions, o.GetGeneration(), err)
+		helpers.SetFanOutDeniedCondition(&o.Status.Conditions, o.GetGeneration(), err)
+		if err != nil {
+			r.recordSyncError(ctx, o, syncSecretErrorReason(err),
 				"Failed to update k8s secret: %s", err)
 			return ctrl.Result{RequeueAfter: computeHorizonWithJitter(requeueDurationOnError)}, nil
 		}
 		reason := consts.ReasonSecretSynced
 		if doRolloutRestart {
 			reason = consts.ReasonSecretRotated
-			// rollout-restart errors are not retryable
-			// all error reporting is handled by helpers.HandleRolloutRestarts
-			_ = helpers.HandleRolloutRestarts(ctx, r.Client, o, r.Recorder)
+			o.Status.SyncProgress = helpers.NewSyncProgress(o.Status.SecretMAC, o.Spec.RolloutRestartTargets)
 		}
 		r.Recorder.Event(o, corev1.EventTypeNormal, reason, "Secret synced")
+		if reason == consts.ReasonSecretRotated {
+			cloudevents.Emit(ctx, cloudevents.TypeSecretRotated, o, cloudevents.Data{})
+		}
+		o.Status.LastSyncMessages = appendSyncMessage(o.Status.LastSyncMessages,
+			secretsv1beta1.SyncResultSuccess, reason, "Secret synced")
 	} else {
 		logger.V(consts.LogLevelDebug).Info("Secret sync not required")
 	}
 
-	if o.Spec.SyncConfig != nil && o.Spec.SyncConfig.InstantUpdates {
+	if p := o.Status.SyncProgress; p != nil {
+		if p.SecretMAC != o.Status.SecretMAC {
+			// the data has changed since the sync was partially applied, the
+			// pending steps are superseded by those of the next sync.
+			o.Status.SyncProgress = nil
+		} else {
+			// resume from the failed steps of a partially applied sync, the
+			// targets that were already restarted are not restarted again.
+			if err := helpers.RolloutRestartPending(ctx, r.Client, o, r.Recorder,
+				o.Spec.RolloutRestartTargets, &o.Status.Conditions, p); err != nil {
+				o.Status.LastSyncMessages = appendSyncMessage(o.Status.LastSyncMessages,
+					secretsv1beta1.SyncResultFailure, consts.ReasonRolloutRestartFailed,
+					"Rollout restart failed, pending steps: %s", strings.Join(p.PendingSteps, ", "))
+				requeueAfter = computeHorizonWithJitter(requeueDurationOnError)
+			}
+			if len(p.PendingSteps) == 0 {
+				o.Status.SyncProgress = nil
+			}
+		}
+	}
+
+	if o.Spec.SyncConfig != nil && o.Spec.SyncConfig.InstantUpdates &&
+		featuregates.Enabled(featuregates.EventDrivenSync) {
 		logger.V(consts.LogLevelDebug).Info("Event watcher enabled")
 		// ensure event watcher is running
 		if err := r.ensureEventWatcher(ctx, o, c); err != nil {
@@ -208,7 +341,8 @@ func (r *VaultStaticSecretReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		r.unWatchEvents(o)
 	}
 
-	if err := r.updateStatus(ctx, o); err != nil {
+	r.storeSecretMAC(ctx, o)
+	if err := r.updateStatus(ctx, o, origStatus); err != nil {
 		return ctrl.Result{}, err
 	}
 
@@ -217,25 +351,155 @@ func (r *VaultStaticSecretReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	}, nil
 }
 
-func (r *VaultStaticSecretReconciler) updateStatus(ctx context.Context, o *secretsv1beta1.VaultStaticSecret) error {
+// loadSecretMAC sets o.Status.SecretMAC to the MAC held in the MACStore. The
+// MAC that is still in the status of the resources that were last synced
+// without a MACStore is used until the next sync, and is moved to the
+// MACStore by storeSecretMAC.
+func (r *VaultStaticSecretReconciler) loadSecretMAC(ctx context.Context, o *secretsv1beta1.VaultStaticSecret) error {
+	if r.MACStore == nil {
+		return nil
+	}
+
+	mac, err := r.MACStore.Get(ctx, o)
+	if err != nil {
+		return err
+	}
+	if mac != "" {
+		o.Status.SecretMAC = mac
+	}
+	return nil
+}
+
+// storeSecretMAC moves o.Status.SecretMAC to the MACStore, so that the
+// status is left unchanged when the secret data is. The MAC is kept in the
+// status if it cannot be stored, and the move is retried on the next
+// reconciliation.
+func (r *VaultStaticSecretReconciler) storeSecretMAC(ctx context.Context, o *secretsv1beta1.VaultStaticSecret) {
+	if r.MACStore == nil || o.Status.SecretMAC == "" {
+		return
+	}
+
+	if err := r.MACStore.Set(ctx, o, o.Status.SecretMAC); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to store the secret MAC, keeping it in the status")
+		return
+	}
+	o.Status.SecretMAC = ""
+}
+
+// updateStatus updates o's status, unless it is equal to origStatus. A nil
+// origStatus forces the update.
+func (r *VaultStaticSecretReconciler) updateStatus(ctx context.Context, o *secretsv1beta1.VaultStaticSecret,
+	origStatus *secretsv1beta1.VaultStaticSecretStatus,
+) error {
 	logger := log.FromContext(ctx)
-	logger.V(consts.LogLevelDebug).Info("Updating status")
 	o.Status.LastGeneration = o.GetGeneration()
-	if err := r.Status().Update(ctx, o); err != nil {
-		r.Recorder.Eventf(o, corev1.EventTypeWarning, consts.ReasonStatusUpdateError,
-			"Failed to update the resource's status, err=%s", err)
+	if origStatus != nil && equality.Semantic.DeepEqual(origStatus, &o.Status) {
+		logger.V(consts.LogLevelDebug).Info("Status unchanged, skipping update")
+	} else {
+		logger.V(consts.LogLevelDebug).Info("Updating status")
+		if err := r.Status().Update(ctx, o); err != nil {
+			r.Recorder.Eventf(o, corev1.EventTypeWarning, consts.ReasonStatusUpdateError,
+				"Failed to update the resource's status, err=%s", err)
+		}
 	}
 
 	_, err := maybeAddFinalizer(ctx, r.Client, o, vaultStaticSecretFinalizer)
 	return err
 }
 
+// recordSyncError emits a warning event for the failed sync attempt and records
+// it in the resource's Status.LastSyncMessages. The status update is best effort,
+// any errors are only logged, since the caller will requeue the resource anyway.
+func (r *VaultStaticSecretReconciler) recordSyncError(ctx context.Context, o *secretsv1beta1.VaultStaticSecret, reason, msg string, a ...any) {
+	r.Recorder.Eventf(o, corev1.EventTypeWarning, reason, msg, a...)
+	messages := appendSyncMessage(o.Status.LastSyncMessages,
+		secretsv1beta1.SyncResultFailure, reason, msg, a...)
+	if err := patchSyncMessages(ctx, r.Client, o, messages, o.Status.Conditions); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to record the sync error in the resource's status")
+	}
+	diagnostics.RecordFailure(ctx, r.Client, o, messages, o.Status.Conditions, reason, a...)
+}
+
+// markServingStaleData marks o as serving its last synced data, if it is
+// configured to do so, and err denotes that Vault is unavailable. Nothing is
+// marked if o was never synced.
+func (r *VaultStaticSecretReconciler) markServingStaleData(ctx context.Context, o *secretsv1beta1.VaultStaticSecret, err error) {
+	if o.Spec.SyncConfig == nil || !o.Spec.SyncConfig.ServeStaleData || !vault.IsUnavailableError(err) {
+		return
+	}
+
+	if o.Status.StaleSince == nil {
+		if o.Status.LastGeneration == 0 {
+			return
+		}
+
+		now := metav1.Now()
+		o.Status.StaleSince = &now
+		// the rest of the status is patched by recordSyncError.
+		b, err := json.Marshal(map[string]any{
+			"status": map[string]any{
+				"staleSince": o.Status.StaleSince,
+			},
+		})
+		if err == nil {
+			err = r.Status().Patch(ctx, o, client.RawPatch(types.MergePatchType, b))
+		}
+		if err != nil {
+			log.FromContext(ctx).Error(err, "Failed to record the staleness in the resource's status")
+		}
+	}
+
+	staleness := time.Since(o.Status.StaleSince.Time).Truncate(time.Second)
+	meta.SetStatusCondition(&o.Status.Conditions, metav1.Condition{
+		Type:               secretsv1beta1.ConditionTypeServingStaleData,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: o.GetGeneration(),
+		Reason:             consts.ReasonServingStaleData,
+		Message: fmt.Sprintf("Vault is unavailable, serving the last synced data, "+
+			"which may have been stale for %s", staleness),
+	})
+	metrics.StaleDataSeconds.WithLabelValues(VaultStaticSecret.String(), o.Name, o.Namespace).Set(staleness.Seconds())
+}
+
+// clearServingStaleData clears the staleness of o, once Vault is available
+// again.
+func (r *VaultStaticSecretReconciler) clearServingStaleData(o *secretsv1beta1.VaultStaticSecret) {
+	if o.Status.StaleSince == nil {
+		return
+	}
+
+	r.Recorder.Eventf(o, corev1.EventTypeNormal, consts.ReasonVaultAvailable,
+		"Vault is available again, after serving stale data for %s",
+		time.Since(o.Status.StaleSince.Time).Truncate(time.Second))
+	o.Status.StaleSince = nil
+	meta.SetStatusCondition(&o.Status.Conditions, metav1.Condition{
+		Type:               secretsv1beta1.ConditionTypeServingStaleData,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: o.GetGeneration(),
+		Reason:             consts.ReasonVaultAvailable,
+		Message:            "Vault is available",
+	})
+	metrics.StaleDataSeconds.DeleteLabelValues(VaultStaticSecret.String(), o.Name, o.Namespace)
+}
+
 func (r *VaultStaticSecretReconciler) handleDeletion(ctx context.Context, o client.Object) error {
 	logger := log.FromContext(ctx)
 	objKey := client.ObjectKeyFromObject(o)
 	r.referenceCache.Remove(SecretTransformation, objKey)
 	r.BackOffRegistry.Delete(objKey)
+	metrics.StaleDataSeconds.DeleteLabelValues(VaultStaticSecret.String(), o.GetName(), o.GetNamespace())
 	r.unWatchEvents(o.(*secretsv1beta1.VaultStaticSecret))
+	helpers.DeleteSecretlessData(o)
+	if r.MACStore != nil {
+		if err := r.MACStore.Delete(ctx, o); err != nil {
+			logger.Error(err, "Failed to delete the secret MAC")
+			return err
+		}
+	}
+	if err := helpers.HandleDestinationDeletion(ctx, r.Client, o); err != nil {
+		logger.Error(err, "Failed to apply the deletion policy of the destination Secrets")
+		return err
+	}
 	if controllerutil.ContainsFinalizer(o, vaultStaticSecretFinalizer) {
 		logger.Info("Removing finalizer")
 		if controllerutil.RemoveFinalizer(o, vaultStaticSecretFinalizer) {
@@ -468,15 +732,19 @@ func (r *VaultStaticSecretReconciler) streamStaticSecretEvents(ctx context.Conte
 			if modified {
 				namespace := strings.Trim(messageMap.Data.Namespace, "/")
 				path := messageMap.Data.Event.Metadata.Path
-				specPath := strings.Join([]string{o.Spec.Mount, o.Spec.Path}, "/")
-
-				if o.Spec.Type == consts.KVSecretTypeV2 {
-					specPath = strings.Join([]string{o.Spec.Mount, "data", o.Spec.Path}, "/")
-				}
+				specPath := kvSecretEventPath(o.Spec, o.Spec.Path)
 				logger.V(consts.LogLevelTrace).Info("modified Event received from Vault",
 					"namespace", namespace, "path", path, "spec.namespace", o.Spec.Namespace,
 					"spec path", specPath)
-				if namespace == o.Spec.Namespace && path == specPath {
+				matches := path == specPath
+				for _, p := range o.Spec.Paths {
+					matches = matches || path == kvSecretEventPath(o.Spec, p)
+				}
+				if o.Spec.Prefix != nil {
+					// any secret under the prefix may have been modified.
+					matches = strings.HasPrefix(path, strings.TrimSuffix(specPath, "/")+"/")
+				}
+				if namespace == o.Spec.Namespace && matches {
 					logger.V(consts.LogLevelDebug).Info("Event matches, sending requeue",
 						"namespace", namespace, "path", path)
 					r.SourceCh <- event.GenericEvent{
@@ -537,6 +805,14 @@ func (r *VaultStaticSecretReconciler) SetupWithManager(mgr ctrl.Manager, opts co
 		Complete(r)
 }
 
+// kvSecretEventPath returns the path of the Vault events of the secret at p.
+func kvSecretEventPath(s secretsv1beta1.VaultStaticSecretSpec, p string) string {
+	if s.Type == consts.KVSecretTypeV2 {
+		return strings.Join([]string{s.Mount, "data", p}, "/")
+	}
+	return strings.Join([]string{s.Mount, p}, "/")
+}
+
 func newKVRequest(s secretsv1beta1.VaultStaticSecretSpec) (vault.ReadRequest, error) {
 	var kvReq vault.ReadRequest
 	switch s.Type {
@@ -549,3 +825,25 @@ func newKVRequest(s secretsv1beta1.VaultStaticSecretSpec) (vault.ReadRequest, er
 	}
 	return kvReq, nil
 }
+
+// kvSecretVersion returns the version of the kv-v2 secret of resp, or an empty
+// string if it is not versioned.
+func kvSecretVersion(resp vault.Response) string {
+	if resp == nil || resp.Secret() == nil {
+		return ""
+	}
+
+	metadata, ok := resp.Secret().Data["metadata"].(map[string]any)
+	if !ok {
+		return ""
+	}
+
+	switch v := metadata["version"].(type) {
+	case json.Number:
+		return v.String()
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return ""
+	}
+}
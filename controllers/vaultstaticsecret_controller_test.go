@@ -0,0 +1,206 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/consts"
+	"github.com/hashicorp/vault-secrets-operator/helpers"
+	"github.com/hashicorp/vault-secrets-operator/internal/testutils"
+)
+
+func TestVaultStaticSecretReconciler_updateStatus(t *testing.T) {
+	ctx := context.Background()
+	o := &secretsv1beta1.VaultStaticSecret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: secretsv1beta1.GroupVersion.String(),
+			Kind:       "VaultStaticSecret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "foo",
+			Namespace:  "baz",
+			Generation: 1,
+		},
+	}
+
+	var updates int
+	k8sClient := testutils.NewFakeClientBuilder().
+		WithObjects(o).
+		WithStatusSubresource(o).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string,
+				obj client.Object, opts ...client.SubResourceUpdateOption,
+			) error {
+				updates++
+				return c.SubResource(subResourceName).Update(ctx, obj, opts...)
+			},
+		}).
+		Build()
+	r := &VaultStaticSecretReconciler{
+		Client:   k8sClient,
+		Recorder: record.NewFakeRecorder(10),
+	}
+
+	get := func() *secretsv1beta1.VaultStaticSecret {
+		t.Helper()
+		var got secretsv1beta1.VaultStaticSecret
+		require.NoError(t, k8sClient.Get(ctx, client.ObjectKeyFromObject(o), &got))
+		return &got
+	}
+
+	// the first sync always updates the status.
+	got := get()
+	origStatus := got.Status.DeepCopy()
+	got.Status.SecretMAC = "mac"
+	require.NoError(t, r.updateStatus(ctx, got, origStatus))
+	assert.Equal(t, 1, updates)
+	got = get()
+	assert.Equal(t, "mac", got.Status.SecretMAC)
+	assert.Equal(t, int64(1), got.Status.LastGeneration)
+	assert.Contains(t, got.GetFinalizers(), vaultStaticSecretFinalizer)
+
+	// nothing changed, the status update is skipped.
+	origStatus = got.Status.DeepCopy()
+	require.NoError(t, r.updateStatus(ctx, got, origStatus))
+	assert.Equal(t, 1, updates)
+
+	// a new generation must be recorded.
+	got.Generation = 2
+	require.NoError(t, r.updateStatus(ctx, got, origStatus))
+	assert.Equal(t, 2, updates)
+	assert.Equal(t, int64(2), get().Status.LastGeneration)
+
+	// a nil origStatus forces the update.
+	got = get()
+	require.NoError(t, r.updateStatus(ctx, got, nil))
+	assert.Equal(t, 3, updates)
+}
+
+func TestVaultStaticSecretReconciler_secretMAC(t *testing.T) {
+	ctx := context.Background()
+	o := &secretsv1beta1.VaultStaticSecret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "baz",
+			UID:       "a1b2c3d4-0000-0000-0000-000000000001",
+		},
+		Status: secretsv1beta1.VaultStaticSecretStatus{
+			SecretMAC: "c3RhdHVz",
+		},
+	}
+
+	r := &VaultStaticSecretReconciler{
+		MACStore: helpers.NewSecretMACStore(testutils.NewFakeClientBuilder().Build()),
+	}
+
+	// the MAC of a resource synced without a MACStore is kept from its status.
+	require.NoError(t, r.loadSecretMAC(ctx, o))
+	assert.Equal(t, "c3RhdHVz", o.Status.SecretMAC)
+
+	// and moved to the MACStore on the next sync.
+	o.Status.SecretMAC = "c3RvcmU="
+	r.storeSecretMAC(ctx, o)
+	assert.Empty(t, o.Status.SecretMAC)
+
+	require.NoError(t, r.loadSecretMAC(ctx, o))
+	assert.Equal(t, "c3RvcmU=", o.Status.SecretMAC)
+
+	require.NoError(t, r.MACStore.Delete(ctx, o))
+	o.Status.SecretMAC = ""
+	require.NoError(t, r.loadSecretMAC(ctx, o))
+	assert.Empty(t, o.Status.SecretMAC)
+}
+
+func TestVaultStaticSecretReconciler_servingStaleData(t *testing.T) {
+	ctx := context.Background()
+	o := &secretsv1beta1.VaultStaticSecret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: secretsv1beta1.GroupVersion.String(),
+			Kind:       "VaultStaticSecret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "foo",
+			Namespace:  "baz",
+			Generation: 1,
+		},
+		Spec: secretsv1beta1.VaultStaticSecretSpec{
+			SyncConfig: &secretsv1beta1.SyncConfig{
+				ServeStaleData: true,
+			},
+		},
+		Status: secretsv1beta1.VaultStaticSecretStatus{
+			LastGeneration: 1,
+		},
+	}
+
+	k8sClient := testutils.NewFakeClientBuilder().
+		WithObjects(o).
+		WithStatusSubresource(o).
+		Build()
+	recorder := record.NewFakeRecorder(10)
+	r := &VaultStaticSecretReconciler{
+		Client:   k8sClient,
+		Recorder: recorder,
+	}
+
+	unavailableErr := &api.ResponseError{StatusCode: http.StatusServiceUnavailable}
+	forbiddenErr := &api.ResponseError{StatusCode: http.StatusForbidden}
+
+	// only unavailability errors are considered.
+	r.markServingStaleData(ctx, o, forbiddenErr)
+	assert.Nil(t, o.Status.StaleSince)
+	assert.Empty(t, o.Status.Conditions)
+
+	r.markServingStaleData(ctx, o, unavailableErr)
+	require.NotNil(t, o.Status.StaleSince)
+	staleSince := o.Status.StaleSince
+	cond := meta.FindStatusCondition(o.Status.Conditions, secretsv1beta1.ConditionTypeServingStaleData)
+	if assert.NotNil(t, cond) {
+		assert.Equal(t, metav1.ConditionTrue, cond.Status)
+		assert.Equal(t, consts.ReasonServingStaleData, cond.Reason)
+	}
+
+	var got secretsv1beta1.VaultStaticSecret
+	require.NoError(t, k8sClient.Get(ctx, client.ObjectKeyFromObject(o), &got))
+	if assert.NotNil(t, got.Status.StaleSince) {
+		assert.Equal(t, staleSince.Unix(), got.Status.StaleSince.Unix())
+	}
+
+	// the staleness is counted from the first failure.
+	r.markServingStaleData(ctx, o, unavailableErr)
+	assert.Equal(t, staleSince, o.Status.StaleSince)
+
+	r.clearServingStaleData(o)
+	assert.Nil(t, o.Status.StaleSince)
+	cond = meta.FindStatusCondition(o.Status.Conditions, secretsv1beta1.ConditionTypeServingStaleData)
+	if assert.NotNil(t, cond) {
+		assert.Equal(t, metav1.ConditionFalse, cond.Status)
+		assert.Equal(t, consts.ReasonVaultAvailable, cond.Reason)
+	}
+	assert.Len(t, recorder.Events, 1)
+
+	// not configured to serve stale data.
+	o.Spec.SyncConfig = nil
+	r.markServingStaleData(ctx, o, unavailableErr)
+	assert.Nil(t, o.Status.StaleSince)
+
+	// never synced.
+	o.Spec.SyncConfig = &secretsv1beta1.SyncConfig{ServeStaleData: true}
+	o.Status.LastGeneration = 0
+	r.markServingStaleData(ctx, o, unavailableErr)
+	assert.Nil(t, o.Status.StaleSince)
+}
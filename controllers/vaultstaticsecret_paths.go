@@ -0,0 +1,84 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package controllers
+
+import (
+	"context"
+	"errors"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/helpers"
+	"github.com/hashicorp/vault-secrets-operator/vault"
+)
+
+// kvPaths returns the paths of all the secrets whose data is merged, in
+// increasing order of precedence.
+func kvPaths(s secretsv1beta1.VaultStaticSecretSpec) []string {
+	return append([]string{s.Path}, s.Paths...)
+}
+
+// readKVPaths reads the secrets at s.Path and s.Paths, the responses are in
+// the order of kvPaths.
+func readKVPaths(ctx context.Context, c vault.ClientBase, s secretsv1beta1.VaultStaticSecretSpec) ([]vault.Response, error) {
+	if s.Prefix != nil {
+		return nil, errors.New("paths cannot be combined with prefix")
+	}
+
+	var resps []vault.Response
+	for i, p := range kvPaths(s) {
+		spec := s
+		spec.Path = p
+		if i > 0 {
+			spec.Version = 0
+		}
+		req, err := newKVRequest(spec)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := c.Read(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		resps = append(resps, resp)
+	}
+
+	return resps, nil
+}
+
+// buildPathsData returns the K8s Secret data of the merged data of resps, see
+// mergeKVData. The raw data of each secret is keyed by its path.
+func buildPathsData(b *helpers.SecretDataBuilder, s secretsv1beta1.VaultStaticSecretSpec,
+	resps []vault.Response, opt *helpers.SecretTransformationOption,
+) (map[string][]byte, error) {
+	paths := kvPaths(s)
+	d := make(map[string]any)
+	raw := make(map[string]any, len(resps))
+	for i, resp := range resps {
+		mergeKVData(d, resp.Data())
+		raw[paths[i]] = resp.Secret().Data
+	}
+
+	return b.WithVaultData(d, raw, opt)
+}
+
+// mergeKVData merges src into dst, the values of src take precedence. The
+// objects are merged recursively into new objects, so that src is never
+// modified by a later merge.
+func mergeKVData(dst, src map[string]any) {
+	for k, v := range src {
+		srcObj, ok := v.(map[string]any)
+		if !ok {
+			dst[k] = v
+			continue
+		}
+
+		dstObj, ok := dst[k].(map[string]any)
+		if !ok {
+			dstObj = make(map[string]any, len(srcObj))
+		}
+		mergeKVData(dstObj, srcObj)
+		dst[k] = dstObj
+	}
+}
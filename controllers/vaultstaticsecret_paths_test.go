@@ -0,0 +1,109 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/consts"
+	"github.com/hashicorp/vault-secrets-operator/helpers"
+	"github.com/hashicorp/vault-secrets-operator/vault"
+)
+
+func Test_readKVPaths(t *testing.T) {
+	t.Parallel()
+
+	spec := secretsv1beta1.VaultStaticSecretSpec{
+		Mount:   "kv",
+		Path:    "common",
+		Paths:   []string{"app"},
+		Type:    consts.KVSecretTypeV2,
+		Version: 2,
+	}
+	commonResp := vault.NewKVV2Response(&api.Secret{
+		Data: map[string]any{
+			"data": map[string]any{
+				"host": "db",
+			},
+		},
+	})
+	appResp := vault.NewKVV2Response(&api.Secret{
+		Data: map[string]any{
+			"data": map[string]any{
+				"password": "s3cr3t",
+			},
+		},
+	})
+	c := &vault.MockRecordingVaultClient{
+		ReadResponses: map[string][]vault.Response{
+			"kv/data/common": {commonResp},
+			"kv/data/app":    {appResp},
+		},
+	}
+
+	got, err := readKVPaths(context.Background(), c, spec)
+	require.NoError(t, err)
+	assert.Equal(t, []vault.Response{commonResp, appResp}, got)
+	require.Len(t, c.Requests, 2)
+	assert.Equal(t, "kv/data/common", c.Requests[0].Path)
+	assert.Equal(t, "kv/data/app", c.Requests[1].Path)
+
+	spec.Prefix = &secretsv1beta1.VaultStaticSecretPrefix{}
+	_, err = readKVPaths(context.Background(), c, spec)
+	assert.EqualError(t, err, "paths cannot be combined with prefix")
+}
+
+func Test_buildPathsData(t *testing.T) {
+	t.Parallel()
+
+	spec := secretsv1beta1.VaultStaticSecretSpec{
+		Path:  "common",
+		Paths: []string{"app", "app/prod"},
+	}
+	common := map[string]any{
+		"host": "db",
+		"port": "5432",
+		"pool": map[string]any{
+			"min": 1,
+			"max": 10,
+		},
+	}
+	resps := []vault.Response{
+		vault.NewKVV1Response(&api.Secret{
+			Data: common,
+		}),
+		vault.NewKVV1Response(&api.Secret{
+			Data: map[string]any{
+				"password": "s3cr3t",
+				"pool": map[string]any{
+					"max": 20,
+				},
+			},
+		}),
+		vault.NewKVV1Response(&api.Secret{
+			Data: map[string]any{
+				"host": "db.prod",
+			},
+		}),
+	}
+
+	got, err := buildPathsData(helpers.NewSecretsDataBuilder(), spec, resps, nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"host":     []byte("db.prod"),
+		"port":     []byte("5432"),
+		"password": []byte("s3cr3t"),
+		"pool":     []byte(`{"max":20,"min":1}`),
+		helpers.SecretDataKeyRaw: []byte(`{"app":{"password":"s3cr3t","pool":{"max":20}},` +
+			`"app/prod":{"host":"db.prod"},"common":{"host":"db","pool":{"max":10,"min":1},"port":"5432"}}`),
+	}, got)
+	// the secret data is never modified.
+	assert.Equal(t, map[string]any{"min": 1, "max": 10}, common["pool"])
+}
@@ -0,0 +1,262 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package controllers
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/consts"
+	"github.com/hashicorp/vault-secrets-operator/helpers"
+	"github.com/hashicorp/vault-secrets-operator/vault"
+)
+
+// isPerPathPrefix returns true if each secret found under the prefix of o is
+// synced into its own Secret.
+func isPerPathPrefix(o *secretsv1beta1.VaultStaticSecret) bool {
+	return o.Spec.Prefix != nil && o.Spec.Prefix.Mode == secretsv1beta1.VaultStaticSecretPrefixModePerPath
+}
+
+func newKVListRequest(s secretsv1beta1.VaultStaticSecretSpec, p string) (vault.ReadRequest, error) {
+	switch s.Type {
+	case consts.KVSecretTypeV1:
+		return vault.NewKVListRequestV1(s.Mount, p), nil
+	case consts.KVSecretTypeV2:
+		return vault.NewKVListRequestV2(s.Mount, p), nil
+	default:
+		return nil, fmt.Errorf("unsupported secret type %q", s.Type)
+	}
+}
+
+// listKVPrefix returns the paths, relative to s.Path, of all the secrets found
+// under it, sorted.
+func listKVPrefix(ctx context.Context, c vault.ClientBase, s secretsv1beta1.VaultStaticSecretSpec) ([]string, error) {
+	var paths []string
+	prefix := strings.TrimSuffix(s.Path, "/")
+	folders := []string{""}
+	for len(folders) > 0 {
+		folder := folders[0]
+		folders = folders[1:]
+
+		req, err := newKVListRequest(s, strings.TrimSuffix(vault.JoinPath(prefix, folder), "/"))
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := c.Read(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		keys, _ := resp.Data()["keys"].([]any)
+		for _, k := range keys {
+			key, ok := k.(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid key %v listed under %q", k, req.Path())
+			}
+
+			rel := folder + key
+			if strings.HasSuffix(key, "/") {
+				if s.Prefix.Recursive {
+					folders = append(folders, rel)
+				}
+				continue
+			}
+
+			if s.Prefix.Glob != "" {
+				match, err := path.Match(s.Prefix.Glob, rel)
+				if err != nil {
+					return nil, fmt.Errorf("invalid glob %q: %w", s.Prefix.Glob, err)
+				}
+				if !match {
+					continue
+				}
+			}
+			paths = append(paths, rel)
+		}
+	}
+
+	slices.Sort(paths)
+	return paths, nil
+}
+
+// readKVPrefix reads all the secrets found under s.Path, the responses are
+// keyed by the secret's path relative to s.Path.
+func readKVPrefix(ctx context.Context, c vault.ClientBase, s secretsv1beta1.VaultStaticSecretSpec) (map[string]vault.Response, error) {
+	paths, err := listKVPrefix(ctx, c, s)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no secrets found under %q", s.Path)
+	}
+
+	resps := make(map[string]vault.Response, len(paths))
+	for _, p := range paths {
+		spec := s
+		spec.Path = vault.JoinPath(strings.TrimSuffix(s.Path, "/"), p)
+		spec.Version = 0
+		req, err := newKVRequest(spec)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := c.Read(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		resps[p] = resp
+	}
+
+	return resps, nil
+}
+
+// prefixKeyPrefix returns the prefix of the keys of the secret at the
+// relative path p, when merged with the other secrets found under the prefix.
+func prefixKeyPrefix(p string) string {
+	return strings.ReplaceAll(p, "/", "_") + "_"
+}
+
+// prefixDestinationName returns the name of the Secret that the secret at the
+// relative path p is synced to in PerPath mode.
+func prefixDestinationName(name, p string) string {
+	return name + "-" + strings.NewReplacer("/", "-", "_", "-").Replace(strings.ToLower(p))
+}
+
+// buildPrefixData returns the K8s Secret data of the secrets found under the
+// prefix of o. The merged data is always returned, since it is also used to
+// compute the secret's HMAC. In PerPath mode, the data of each secret is also
+// returned, keyed by its relative path.
+func buildPrefixData(b *helpers.SecretDataBuilder, o *secretsv1beta1.VaultStaticSecret,
+	resps map[string]vault.Response, opt *helpers.SecretTransformationOption,
+) (map[string][]byte, map[string]map[string][]byte, error) {
+	if isPerPathPrefix(o) {
+		data := make(map[string][]byte)
+		pathData := make(map[string]map[string][]byte, len(resps))
+		for p, resp := range resps {
+			d, err := b.WithVaultData(resp.Data(), resp.Secret().Data, opt)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to build the data of the secret at %q: %w", p, err)
+			}
+			pathData[p] = d
+			for k, v := range d {
+				data[prefixKeyPrefix(p)+k] = v
+			}
+		}
+		return data, pathData, nil
+	}
+
+	d := make(map[string]any)
+	raw := make(map[string]any, len(resps))
+	for _, p := range slices.Sorted(maps.Keys(resps)) {
+		resp := resps[p]
+		for k, v := range resp.Data() {
+			key := prefixKeyPrefix(p) + k
+			if _, ok := d[key]; ok {
+				return nil, nil, fmt.Errorf("key %q of the secret at %q is provided by another secret", key, p)
+			}
+			d[key] = v
+		}
+		raw[p] = resp.Secret().Data
+	}
+
+	data, err := b.WithVaultData(d, raw, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, nil, nil
+}
+
+// hmacPrefixData computes the HMAC of data, returning true if it is equal to
+// the one stored in o.Status.SecretMAC. Unlike helpers.HandleSecretHMAC, no
+// drift detection is done, since there is no single destination Secret in
+// PerPath mode.
+func hmacPrefixData(ctx context.Context, client ctrlclient.Client, validator helpers.HMACValidator,
+	o *secretsv1beta1.VaultStaticSecret, data map[string][]byte,
+) (bool, []byte, error) {
+	if o.Spec.SyncConfig != nil {
+		var err error
+		data, err = helpers.MACData(data, o.Spec.SyncConfig.IgnoreFields)
+		if err != nil {
+			return false, nil, err
+		}
+	}
+
+	message, err := json.Marshal(data)
+	if err != nil {
+		return false, nil, err
+	}
+
+	newMAC, err := validator.HMAC(ctx, client, message)
+	if err != nil {
+		return false, nil, err
+	}
+
+	if o.Status.SecretMAC == "" {
+		return false, newMAC, nil
+	}
+
+	lastMAC, err := base64.StdEncoding.DecodeString(o.Status.SecretMAC)
+	if err != nil {
+		return false, nil, err
+	}
+
+	return helpers.EqualMACS(lastMAC, newMAC), newMAC, nil
+}
+
+// syncPerPathSecrets syncs the data of each secret found under the prefix of o
+// into its own Secret, then deletes the Secrets owned by o that were not
+// synced, e.g. those of the secrets that were deleted from Vault.
+func syncPerPathSecrets(ctx context.Context, client ctrlclient.Client, o *secretsv1beta1.VaultStaticSecret,
+	pathData map[string]map[string][]byte,
+) error {
+	if !o.Spec.Destination.Create {
+		return errors.New("destination.create must be set in PerPath mode")
+	}
+	if len(o.Spec.AdditionalDestinations) > 0 {
+		return errors.New("additionalDestinations are not supported in PerPath mode")
+	}
+	if o.Spec.Destination.Immutable {
+		return errors.New("destination.immutable is not supported in PerPath mode")
+	}
+
+	names := make(map[string]bool, len(pathData))
+	for _, p := range slices.Sorted(maps.Keys(pathData)) {
+		obj := o.DeepCopy()
+		obj.Spec.Destination.Name = prefixDestinationName(o.Spec.Destination.Name, p)
+		// orphans are pruned below, once all the Secrets are synced.
+		if err := helpers.SyncSecret(ctx, client, obj, pathData[p], helpers.SyncOptions{}); err != nil {
+			return fmt.Errorf("failed to sync the secret at %q: %w", p, err)
+		}
+		names[obj.Spec.Destination.Name] = true
+	}
+
+	owned, err := helpers.FindSecretsOwnedByObj(ctx, client, o)
+	if err != nil {
+		return err
+	}
+
+	var errs error
+	for _, s := range owned {
+		if names[s.Name] {
+			continue
+		}
+		if err := client.Delete(ctx, &s); ctrlclient.IgnoreNotFound(err) != nil {
+			errs = errors.Join(errs, err)
+		}
+	}
+
+	return errs
+}
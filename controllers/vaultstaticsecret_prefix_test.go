@@ -0,0 +1,337 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/consts"
+	"github.com/hashicorp/vault-secrets-operator/helpers"
+	"github.com/hashicorp/vault-secrets-operator/internal/testutils"
+	"github.com/hashicorp/vault-secrets-operator/vault"
+)
+
+func newKVListResponse(keys ...any) []vault.Response {
+	return []vault.Response{
+		vault.NewDefaultResponse(&api.Secret{
+			Data: map[string]any{
+				"keys": keys,
+			},
+		}),
+	}
+}
+
+func Test_listKVPrefix(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		spec      secretsv1beta1.VaultStaticSecretSpec
+		responses map[string][]vault.Response
+		want      []string
+		wantErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name: "kv-v2",
+			spec: secretsv1beta1.VaultStaticSecretSpec{
+				Mount:  "kv",
+				Path:   "app",
+				Type:   consts.KVSecretTypeV2,
+				Prefix: &secretsv1beta1.VaultStaticSecretPrefix{},
+			},
+			responses: map[string][]vault.Response{
+				"kv/metadata/app": newKVListResponse("db", "api", "sub/"),
+			},
+			want:    []string{"api", "db"},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "kv-v1-recursive",
+			spec: secretsv1beta1.VaultStaticSecretSpec{
+				Mount: "kv",
+				Path:  "app/",
+				Type:  consts.KVSecretTypeV1,
+				Prefix: &secretsv1beta1.VaultStaticSecretPrefix{
+					Recursive: true,
+				},
+			},
+			responses: map[string][]vault.Response{
+				"kv/app":     newKVListResponse("db", "sub/"),
+				"kv/app/sub": newKVListResponse("api"),
+			},
+			want:    []string{"db", "sub/api"},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "glob",
+			spec: secretsv1beta1.VaultStaticSecretSpec{
+				Mount: "kv",
+				Path:  "app",
+				Type:  consts.KVSecretTypeV2,
+				Prefix: &secretsv1beta1.VaultStaticSecretPrefix{
+					Recursive: true,
+					Glob:      "*/db-*",
+				},
+			},
+			responses: map[string][]vault.Response{
+				"kv/metadata/app":     newKVListResponse("db-main", "sub/"),
+				"kv/metadata/app/sub": newKVListResponse("db-main", "api"),
+			},
+			want:    []string{"sub/db-main"},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "invalid-glob",
+			spec: secretsv1beta1.VaultStaticSecretSpec{
+				Mount: "kv",
+				Path:  "app",
+				Type:  consts.KVSecretTypeV2,
+				Prefix: &secretsv1beta1.VaultStaticSecretPrefix{
+					Glob: "[",
+				},
+			},
+			responses: map[string][]vault.Response{
+				"kv/metadata/app": newKVListResponse("db"),
+			},
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.ErrorContains(t, err, `invalid glob "["`, i...)
+			},
+		},
+		{
+			name: "invalid-type",
+			spec: secretsv1beta1.VaultStaticSecretSpec{
+				Mount:  "kv",
+				Path:   "app",
+				Type:   "kv-v3",
+				Prefix: &secretsv1beta1.VaultStaticSecretPrefix{},
+			},
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.EqualError(t, err, `unsupported secret type "kv-v3"`, i...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := &vault.MockRecordingVaultClient{
+				ReadResponses: tt.responses,
+			}
+			got, err := listKVPrefix(context.Background(), c, tt.spec)
+			if !tt.wantErr(t, err) {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_readKVPrefix(t *testing.T) {
+	t.Parallel()
+
+	spec := secretsv1beta1.VaultStaticSecretSpec{
+		Mount:   "kv",
+		Path:    "app",
+		Type:    consts.KVSecretTypeV2,
+		Version: 2,
+		Prefix:  &secretsv1beta1.VaultStaticSecretPrefix{},
+	}
+	dbResp := vault.NewKVV2Response(&api.Secret{
+		Data: map[string]any{
+			"data": map[string]any{
+				"password": "s3cr3t",
+			},
+		},
+	})
+	c := &vault.MockRecordingVaultClient{
+		ReadResponses: map[string][]vault.Response{
+			"kv/metadata/app": newKVListResponse("db"),
+			"kv/data/app/db":  {dbResp},
+		},
+	}
+
+	got, err := readKVPrefix(context.Background(), c, spec)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]vault.Response{"db": dbResp}, got)
+
+	c = &vault.MockRecordingVaultClient{
+		ReadResponses: map[string][]vault.Response{
+			"kv/metadata/app": newKVListResponse("sub/"),
+		},
+	}
+	_, err = readKVPrefix(context.Background(), c, spec)
+	assert.EqualError(t, err, `no secrets found under "app"`)
+}
+
+func Test_buildPrefixData(t *testing.T) {
+	t.Parallel()
+
+	resps := map[string]vault.Response{
+		"db": vault.NewKVV1Response(&api.Secret{
+			Data: map[string]any{
+				"password": "s3cr3t",
+			},
+		}),
+		"sub/api": vault.NewKVV1Response(&api.Secret{
+			Data: map[string]any{
+				"key": "abc",
+			},
+		}),
+	}
+
+	tests := []struct {
+		name         string
+		mode         string
+		resps        map[string]vault.Response
+		want         map[string][]byte
+		wantPathData map[string]map[string][]byte
+		wantErr      assert.ErrorAssertionFunc
+	}{
+		{
+			name:  "merge",
+			mode:  secretsv1beta1.VaultStaticSecretPrefixModeMerge,
+			resps: resps,
+			want: map[string][]byte{
+				"db_password":            []byte("s3cr3t"),
+				"sub_api_key":            []byte("abc"),
+				helpers.SecretDataKeyRaw: []byte(`{"db":{"password":"s3cr3t"},"sub/api":{"key":"abc"}}`),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "merge-conflict",
+			mode: secretsv1beta1.VaultStaticSecretPrefixModeMerge,
+			resps: map[string]vault.Response{
+				"a": vault.NewKVV1Response(&api.Secret{
+					Data: map[string]any{
+						"b_c": "1",
+					},
+				}),
+				"a_b": vault.NewKVV1Response(&api.Secret{
+					Data: map[string]any{
+						"c": "2",
+					},
+				}),
+			},
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.EqualError(t, err,
+					`key "a_b_c" of the secret at "a_b" is provided by another secret`, i...)
+			},
+		},
+		{
+			name:  "per-path",
+			mode:  secretsv1beta1.VaultStaticSecretPrefixModePerPath,
+			resps: resps,
+			want: map[string][]byte{
+				"db_password":  []byte("s3cr3t"),
+				"db__raw":      []byte(`{"password":"s3cr3t"}`),
+				"sub_api_key":  []byte("abc"),
+				"sub_api__raw": []byte(`{"key":"abc"}`),
+			},
+			wantPathData: map[string]map[string][]byte{
+				"db": {
+					"password":               []byte("s3cr3t"),
+					helpers.SecretDataKeyRaw: []byte(`{"password":"s3cr3t"}`),
+				},
+				"sub/api": {
+					"key":                    []byte("abc"),
+					helpers.SecretDataKeyRaw: []byte(`{"key":"abc"}`),
+				},
+			},
+			wantErr: assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			o := &secretsv1beta1.VaultStaticSecret{
+				Spec: secretsv1beta1.VaultStaticSecretSpec{
+					Prefix: &secretsv1beta1.VaultStaticSecretPrefix{
+						Mode: tt.mode,
+					},
+				},
+			}
+			got, gotPathData, err := buildPrefixData(helpers.NewSecretsDataBuilder(), o, tt.resps, nil)
+			if !tt.wantErr(t, err) {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantPathData, gotPathData)
+		})
+	}
+}
+
+func Test_prefixDestinationName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "dest-app-db-main", prefixDestinationName("dest", "App/db_main"))
+}
+
+func Test_syncPerPathSecrets(t *testing.T) {
+	ctx := context.Background()
+	o := &secretsv1beta1.VaultStaticSecret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: secretsv1beta1.GroupVersion.String(),
+			Kind:       "VaultStaticSecret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "baz",
+			UID:       types.UID("uid"),
+		},
+		Spec: secretsv1beta1.VaultStaticSecretSpec{
+			Prefix: &secretsv1beta1.VaultStaticSecretPrefix{
+				Mode: secretsv1beta1.VaultStaticSecretPrefixModePerPath,
+			},
+			Destination: secretsv1beta1.Destination{
+				Name: "dest",
+			},
+		},
+	}
+	k8sClient := testutils.NewFakeClientBuilder().Build()
+
+	pathData := map[string]map[string][]byte{
+		"db": {
+			"password": []byte("s3cr3t"),
+		},
+		"api": {
+			"key": []byte("abc"),
+		},
+	}
+	assert.EqualError(t, syncPerPathSecrets(ctx, k8sClient, o, pathData),
+		"destination.create must be set in PerPath mode")
+
+	o.Spec.Destination.Create = true
+	require.NoError(t, syncPerPathSecrets(ctx, k8sClient, o, pathData))
+
+	getData := func(name string) map[string][]byte {
+		t.Helper()
+		var s corev1.Secret
+		require.NoError(t, k8sClient.Get(ctx, client.ObjectKey{Namespace: "baz", Name: name}, &s))
+		return s.Data
+	}
+	assert.Equal(t, pathData["db"], getData("dest-db"))
+	assert.Equal(t, pathData["api"], getData("dest-api"))
+
+	// the Secrets of the paths that are no longer found are deleted.
+	delete(pathData, "api")
+	require.NoError(t, syncPerPathSecrets(ctx, k8sClient, o, pathData))
+	assert.Equal(t, pathData["db"], getData("dest-db"))
+	owned, err := helpers.FindSecretsOwnedByObj(ctx, k8sClient, o)
+	require.NoError(t, err)
+	if assert.Len(t, owned, 1) {
+		assert.Equal(t, "dest-db", owned[0].Name)
+	}
+}
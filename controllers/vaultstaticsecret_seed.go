@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/consts"
+	"github.com/hashicorp/vault-secrets-operator/helpers"
+	"github.com/hashicorp/vault-secrets-operator/vault"
+)
+
+// LabelSeed must be set to "true" on the Kubernetes Secrets that can be written
+// to Vault by a VaultStaticSecret's seeding.
+const LabelSeed = "vso.secrets.hashicorp.com/seed"
+
+// errSeedConflict is returned by seedKV when the secret was written to Vault
+// concurrently, after it was found to be missing.
+var errSeedConflict = errors.New("the Vault secret was written concurrently, it was not overwritten by the seed")
+
+// needsSeed returns true if o is configured to be seeded, and it has not been
+// seeded yet.
+func needsSeed(o *secretsv1beta1.VaultStaticSecret) bool {
+	return o.Spec.Seed != nil && !o.Status.Seeded
+}
+
+// seedKV writes the data of o's seed Secret to the secret at o's Path, unless
+// it already exists in Vault. It returns true if the secret was written. KV
+// version 2 secrets are written with check-and-set, errSeedConflict is returned
+// if the secret was created since it was read. KV version 1 has no
+// check-and-set, so a secret that is created concurrently may be overwritten.
+func seedKV(ctx context.Context, c vault.ClientBase, k8sClient client.Client, o *secretsv1beta1.VaultStaticSecret) (bool, error) {
+	s := o.Spec
+	if len(s.Paths) > 0 || s.Prefix != nil {
+		return false, errors.New("seed cannot be combined with paths or prefix")
+	}
+
+	kvReq, err := newKVRequest(s)
+	if err != nil {
+		return false, err
+	}
+	if _, err := c.Read(ctx, kvReq); err == nil {
+		// never overwrite an existing secret.
+		return false, nil
+	} else if !vault.IsSecretNotFoundError(err) {
+		return false, err
+	}
+
+	name := s.Seed.SecretName
+	if name == "" {
+		name = s.Destination.Name
+	}
+	secret, err := helpers.GetSecret(ctx, k8sClient, client.ObjectKey{Namespace: o.Namespace, Name: name})
+	if err != nil {
+		return false, err
+	}
+	if secret.Labels[LabelSeed] != "true" {
+		return false, fmt.Errorf("the Secret %s is not labeled with %s=true", name, LabelSeed)
+	}
+
+	data := seedData(secret)
+	if len(data) == 0 {
+		return false, fmt.Errorf("the Secret %s has no data", name)
+	}
+
+	var req vault.WriteRequest
+	switch s.Type {
+	case consts.KVSecretTypeV1:
+		req = vault.NewKVWriteRequestV1(s.Mount, s.Path, data)
+	case consts.KVSecretTypeV2:
+		// check-and-set 0 only writes the secret if it does not exist, in case it
+		// was created since it was read.
+		req = vault.NewKVWriteRequestV2(s.Mount, s.Path, data, 0)
+	default:
+		return false, fmt.Errorf("unsupported secret type %q", s.Type)
+	}
+
+	if _, err := c.Write(ctx, req); err != nil {
+		if vault.IsCheckAndSetError(err) {
+			return false, errSeedConflict
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// seedData returns the data of secret to write to Vault. The raw data that
+// is synced by the Operator is omitted.
+func seedData(secret *corev1.Secret) map[string]any {
+	data := make(map[string]any, len(secret.Data))
+	for k, v := range secret.Data {
+		if k == helpers.SecretDataKeyRaw {
+			continue
+		}
+		data[k] = string(v)
+	}
+	return data
+}
@@ -0,0 +1,218 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/consts"
+	"github.com/hashicorp/vault-secrets-operator/helpers"
+	"github.com/hashicorp/vault-secrets-operator/vault"
+)
+
+// notFoundVaultClient has no secret at the paths without a read response. All
+// the writes fail with writeErr, if it is set.
+type notFoundVaultClient struct {
+	*vault.MockRecordingVaultClient
+	writeErr error
+}
+
+func (c *notFoundVaultClient) Read(ctx context.Context, req vault.ReadRequest) (vault.Response, error) {
+	if _, ok := c.ReadResponses[req.Path()]; !ok {
+		return nil, &vault.SecretNotFoundError{Path: req.Path()}
+	}
+	return c.MockRecordingVaultClient.Read(ctx, req)
+}
+
+func (c *notFoundVaultClient) Write(ctx context.Context, req vault.WriteRequest) (vault.Response, error) {
+	resp, err := c.MockRecordingVaultClient.Write(ctx, req)
+	if c.writeErr != nil {
+		return nil, c.writeErr
+	}
+	return resp, err
+}
+
+func Test_seedKV(t *testing.T) {
+	t.Parallel()
+
+	newSecret := func(labels map[string]string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "app",
+				Namespace: "foo",
+				Labels:    labels,
+			},
+			Data: map[string][]byte{
+				"password":               []byte("s3cr3t"),
+				helpers.SecretDataKeyRaw: []byte(`{"password":"s3cr3t"}`),
+			},
+		}
+	}
+	seedLabels := map[string]string{
+		LabelSeed: "true",
+	}
+
+	tests := []struct {
+		name          string
+		secretType    string
+		secret        *corev1.Secret
+		readResponses map[string][]vault.Response
+		seed          *secretsv1beta1.VaultStaticSecretSeed
+		prefix        *secretsv1beta1.VaultStaticSecretPrefix
+		writeErr      error
+		wantSeeded    bool
+		wantWrite     *vault.MockRequest
+		wantErr       string
+	}{
+		{
+			name:       "kv-v2",
+			secretType: consts.KVSecretTypeV2,
+			secret:     newSecret(seedLabels),
+			seed:       &secretsv1beta1.VaultStaticSecretSeed{},
+			wantSeeded: true,
+			wantWrite: &vault.MockRequest{
+				Method: "PUT",
+				Path:   "kv/data/app",
+				Params: map[string]any{
+					"options": map[string]any{
+						"cas": 0,
+					},
+					"data": map[string]any{
+						"password": "s3cr3t",
+					},
+				},
+			},
+		},
+		{
+			name:       "kv-v2-concurrent-write",
+			secretType: consts.KVSecretTypeV2,
+			secret:     newSecret(seedLabels),
+			seed:       &secretsv1beta1.VaultStaticSecretSeed{},
+			writeErr: &api.ResponseError{
+				StatusCode: http.StatusBadRequest,
+				Errors:     []string{"check-and-set parameter did not match the current version"},
+			},
+			wantWrite: &vault.MockRequest{
+				Method: "PUT",
+				Path:   "kv/data/app",
+				Params: map[string]any{
+					"options": map[string]any{
+						"cas": 0,
+					},
+					"data": map[string]any{
+						"password": "s3cr3t",
+					},
+				},
+			},
+			wantErr: errSeedConflict.Error(),
+		},
+		{
+			name:       "kv-v1-secret-name",
+			secretType: consts.KVSecretTypeV1,
+			secret:     newSecret(seedLabels),
+			seed: &secretsv1beta1.VaultStaticSecretSeed{
+				SecretName: "app",
+			},
+			wantSeeded: true,
+			wantWrite: &vault.MockRequest{
+				Method: "PUT",
+				Path:   "kv/app",
+				Params: map[string]any{
+					"password": "s3cr3t",
+				},
+			},
+		},
+		{
+			name:       "exists-in-vault",
+			secretType: consts.KVSecretTypeV2,
+			secret:     newSecret(seedLabels),
+			seed:       &secretsv1beta1.VaultStaticSecretSeed{},
+			readResponses: map[string][]vault.Response{
+				"kv/data/app": {
+					vault.NewKVV2Response(&api.Secret{
+						Data: map[string]any{
+							"data": map[string]any{
+								"password": "other",
+							},
+						},
+					}),
+				},
+			},
+		},
+		{
+			name:       "not-labeled",
+			secretType: consts.KVSecretTypeV2,
+			secret:     newSecret(nil),
+			seed:       &secretsv1beta1.VaultStaticSecretSeed{},
+			wantErr:    "the Secret app is not labeled with vso.secrets.hashicorp.com/seed=true",
+		},
+		{
+			name:       "with-prefix",
+			secretType: consts.KVSecretTypeV2,
+			secret:     newSecret(seedLabels),
+			seed:       &secretsv1beta1.VaultStaticSecretSeed{},
+			prefix:     &secretsv1beta1.VaultStaticSecretPrefix{},
+			wantErr:    "seed cannot be combined with paths or prefix",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &secretsv1beta1.VaultStaticSecret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "vss",
+					Namespace: "foo",
+				},
+				Spec: secretsv1beta1.VaultStaticSecretSpec{
+					Mount:  "kv",
+					Path:   "app",
+					Type:   tt.secretType,
+					Prefix: tt.prefix,
+					Seed:   tt.seed,
+					Destination: secretsv1beta1.Destination{
+						Name: "app",
+					},
+				},
+			}
+			require.True(t, needsSeed(o))
+
+			c := &notFoundVaultClient{
+				MockRecordingVaultClient: &vault.MockRecordingVaultClient{
+					ReadResponses: tt.readResponses,
+				},
+				writeErr: tt.writeErr,
+			}
+			k8sClient := fake.NewClientBuilder().WithObjects(tt.secret).Build()
+
+			seeded, err := seedKV(context.Background(), c, k8sClient, o)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantSeeded, seeded)
+
+			var writes []*vault.MockRequest
+			for _, req := range c.Requests {
+				if req.Method == "PUT" {
+					writes = append(writes, req)
+				}
+			}
+			if tt.wantWrite != nil {
+				assert.Equal(t, []*vault.MockRequest{tt.wantWrite}, writes)
+			} else {
+				assert.Empty(t, writes)
+			}
+		})
+	}
+}
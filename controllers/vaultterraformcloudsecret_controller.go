@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+)
+
+const vaultTerraformCloudSecretFinalizer = "vaultterraformcloudsecret.secrets.hashicorp.com/finalizer"
+
+var _ reconcile.Reconciler = &VaultTerraformCloudSecretReconciler{}
+
+// VaultTerraformCloudSecretReconciler reconciles a VaultTerraformCloudSecret object
+type VaultTerraformCloudSecretReconciler = LeasedSecretReconciler[*secretsv1beta1.VaultTerraformCloudSecret, vaultTerraformCloudSecretKind]
+
+// +kubebuilder:rbac:groups=secrets.hashicorp.com,resources=vaultterraformcloudsecrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=secrets.hashicorp.com,resources=vaultterraformcloudsecrets/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=secrets.hashicorp.com,resources=vaultterraformcloudsecrets/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+
+// vaultTerraformCloudSecretKind ensures that the VaultTerraformCloudSecret
+// Custom Resource is synced from Vault's Terraform Cloud secrets engine to its
+// configured Kubernetes secret. A user API token is leased, its lease is
+// renewed periodically, and a new token is issued once the lease can no longer
+// be renewed. Organization and team API tokens are not leased, they are synced
+// again on each reconciliation.
+type vaultTerraformCloudSecretKind struct{}
+
+func (vaultTerraformCloudSecretKind) resourceKind() ResourceKind {
+	return VaultTerraformCloudSecret
+}
+
+func (vaultTerraformCloudSecretKind) finalizer() string {
+	return vaultTerraformCloudSecretFinalizer
+}
+
+func (vaultTerraformCloudSecretKind) newObject() *secretsv1beta1.VaultTerraformCloudSecret {
+	return &secretsv1beta1.VaultTerraformCloudSecret{}
+}
+
+func (vaultTerraformCloudSecretKind) newLeasedSecret(o *secretsv1beta1.VaultTerraformCloudSecret) (*leasedSecret, error) {
+	return newVaultTerraformCloudLeasedSecret(o), nil
+}
+
+func newVaultTerraformCloudLeasedSecret(o *secretsv1beta1.VaultTerraformCloudSecret) *leasedSecret {
+	mount := strings.Trim(o.Spec.Mount, "/")
+	if mount == "" {
+		mount = "terraform"
+	}
+
+	return &leasedSecret{
+		obj:            o,
+		path:           fmt.Sprintf("%s/creds/%s", mount, o.Spec.Role),
+		method:         http.MethodGet,
+		renewalPercent: o.Spec.RenewalPercent,
+		revoke:         o.Spec.Revoke,
+		destination:    &o.Spec.Destination,
+		status:         &o.Status.VaultLeasedSecretStatus,
+	}
+}
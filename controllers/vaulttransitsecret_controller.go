@@ -0,0 +1,600 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package controllers
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/consts"
+	"github.com/hashicorp/vault-secrets-operator/helpers"
+	"github.com/hashicorp/vault-secrets-operator/internal/cloudevents"
+	"github.com/hashicorp/vault-secrets-operator/internal/diagnostics"
+	"github.com/hashicorp/vault-secrets-operator/vault"
+)
+
+const vaultTransitSecretFinalizer = "vaulttransitsecret.secrets.hashicorp.com/finalizer"
+
+// transitCiphertextVersionRe matches the version prefix of a transit
+// ciphertext, e.g. vault:v3:...
+var transitCiphertextVersionRe = regexp.MustCompile(`^vault:v(\d+):`)
+
+// VaultTransitSecretReconciler reconciles a VaultTransitSecret object
+type VaultTransitSecretReconciler struct {
+	client.Client
+	Scheme                      *runtime.Scheme
+	Recorder                    record.EventRecorder
+	ClientFactory               vault.ClientFactory
+	SecretDataBuilder           *helpers.SecretDataBuilder
+	SecretsClient               client.Client
+	HMACValidator               helpers.HMACValidator
+	BackOffRegistry             *BackOffRegistry
+	GlobalTransformationOptions *helpers.GlobalTransformationOptions
+	referenceCache              ResourceReferenceCache
+}
+
+// +kubebuilder:rbac:groups=secrets.hashicorp.com,resources=vaulttransitsecrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=secrets.hashicorp.com,resources=vaulttransitsecrets/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=secrets.hashicorp.com,resources=vaulttransitsecrets/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;update
+//
+// required for rollout-restart
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=apps,resources=daemonsets,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=argoproj.io,resources=rollouts,verbs=get;list;watch;patch
+//
+
+// Reconcile ensures that the VaultTransitSecret Custom Resource's payloads are
+// decrypted with Vault's transit secrets engine, and that the plaintext is
+// synced to its configured Kubernetes secret. The transit key's version is
+// polled every RefreshAfter period, when the key is rotated the ciphertext of
+// payloads sourced from a Secret or ConfigMap can be rewrapped in place.
+func (r *VaultTransitSecretReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	o := &secretsv1beta1.VaultTransitSecret{}
+	if err := r.Client.Get(ctx, req.NamespacedName, o); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+
+		logger.Error(err, "error getting resource from k8s", "secret", o)
+		return ctrl.Result{}, err
+	}
+
+	if o.GetDeletionTimestamp() != nil {
+		logger.Info("Got deletion timestamp", "obj", o)
+		return ctrl.Result{}, r.handleDeletion(ctx, o)
+	}
+
+	requeueAfter := computeHorizonWithJitter(time.Second * 60)
+	if o.Spec.RefreshAfter != "" {
+		d, err := parseDurationString(o.Spec.RefreshAfter, ".spec.refreshAfter", 0)
+		if err != nil {
+			r.recordSyncError(ctx, o, consts.ReasonVaultTransitSecret,
+				"Field validation failed, err=%s", err)
+			return ctrl.Result{RequeueAfter: computeHorizonWithJitter(requeueDurationOnError)}, nil
+		}
+		requeueAfter = computeHorizonWithJitter(d)
+	}
+
+	if err := validateTransitPayloads(o.Spec.Payloads); err != nil {
+		r.recordSyncError(ctx, o, consts.ReasonVaultTransitSecret,
+			"Field validation failed, err=%s", err)
+		return ctrl.Result{RequeueAfter: computeHorizonWithJitter(requeueDurationOnError)}, nil
+	}
+
+	c, err := r.ClientFactory.Get(ctx, r.Client, o)
+	if err != nil {
+		r.recordSyncError(ctx, o, consts.ReasonVaultClientConfigError,
+			"Failed to get Vault auth login: %s", err)
+		return ctrl.Result{RequeueAfter: computeHorizonWithJitter(requeueDurationOnError)}, nil
+	}
+
+	r.referenceCache.Set(SecretTransformation, req.NamespacedName,
+		helpers.GetTransformationRefObjKeys(
+			o.Spec.Destination.Transformation, o.Namespace)...)
+
+	transOption, err := helpers.NewSecretTransformationOption(ctx, r.Client, o, r.GlobalTransformationOptions)
+	if err != nil {
+		r.recordSyncError(ctx, o, consts.ReasonTransformationError,
+			"Failed setting up SecretTransformationOption: %s", err)
+		return ctrl.Result{RequeueAfter: computeHorizonWithJitter(requeueDurationOnError)}, nil
+	}
+
+	ciphertexts, err := r.loadCiphertexts(ctx, o)
+	if err != nil {
+		r.recordSyncError(ctx, o, consts.ReasonVaultTransitSecret,
+			"Failed to load the ciphertext: %s", err)
+		return ctrl.Result{RequeueAfter: computeHorizonWithJitter(requeueDurationOnError)}, nil
+	}
+
+	mount := strings.Trim(o.Spec.Mount, "/")
+	if mount == "" {
+		mount = "transit"
+	}
+
+	keyVersion, plaintexts, err := r.doVault(ctx, c, o, mount, ciphertexts)
+	if err != nil {
+		if vault.IsForbiddenError(err) {
+			c.Taint()
+		}
+
+		entry, _ := r.BackOffRegistry.Get(req.NamespacedName)
+		r.recordSyncError(ctx, o, consts.ReasonVaultClientError,
+			"Failed to decrypt with Vault transit: %s", err)
+		return ctrl.Result{RequeueAfter: entry.NextBackOff()}, nil
+	} else {
+		r.BackOffRegistry.Delete(req.NamespacedName)
+	}
+
+	d := make(map[string]any, len(plaintexts))
+	for i, p := range o.Spec.Payloads {
+		d[p.Name] = string(plaintexts[i])
+	}
+	data, err := r.SecretDataBuilder.WithVaultData(d, d, transOption)
+	if err != nil {
+		r.recordSyncError(ctx, o, consts.ReasonSecretDataBuilderError,
+			"Failed to build K8s secret data: %s", err)
+		return ctrl.Result{RequeueAfter: computeHorizonWithJitter(requeueDurationOnError)}, nil
+	}
+
+	// doRolloutRestart only if this is not the first time this secret has been synced
+	doRolloutRestart := o.Status.SecretMAC != ""
+	macsEqual, messageMAC, err := helpers.HandleSecretHMAC(ctx, r.SecretsClient, r.HMACValidator, o, data)
+	if err != nil {
+		r.recordSyncError(ctx, o, consts.ReasonHMACDataError,
+			"Failed to HMAC the secret data: %s", err)
+		return ctrl.Result{RequeueAfter: computeHorizonWithJitter(requeueDurationOnError)}, nil
+	}
+
+	// skip the sync if the data has not changed since the last sync, and the
+	// resource has not been updated.
+	doSync := true
+	if o.Status.LastGeneration == o.GetGeneration() {
+		doSync = !macsEqual
+	}
+
+	if doSync {
+		err := helpers.SyncSecret(ctx, r.Client, o, data)
+		helpers.SetDestinationConflictCondition(&o.Status.Conditions, o.GetGeneration(), err)
+		helpers.SetFanOutDeniedCondition(&o.Status.Conditions, o.GetGeneration(), err)
+		if err != nil {
+			r.recordSyncError(ctx, o, syncSecretErrorReason(err),
+				"Failed to update k8s secret: %s", err)
+			return ctrl.Result{RequeueAfter: computeHorizonWithJitter(requeueDurationOnError)}, nil
+		}
+		reason := consts.ReasonSecretSynced
+		if doRolloutRestart {
+			reason = consts.ReasonSecretRotated
+			// rollout-restart errors are not retryable
+			// all error reporting is handled by helpers.HandleRolloutRestarts
+			_ = helpers.HandleRolloutRestarts(ctx, r.Client, o, r.Recorder)
+		}
+		r.Recorder.Event(o, corev1.EventTypeNormal, reason, "Secret synced")
+		if reason == consts.ReasonSecretRotated {
+			cloudevents.Emit(ctx, cloudevents.TypeSecretRotated, o, cloudevents.Data{})
+		}
+		o.Status.LastSyncMessages = appendSyncMessage(o.Status.LastSyncMessages,
+			secretsv1beta1.SyncResultSuccess, reason, "Secret synced")
+	} else {
+		logger.V(consts.LogLevelDebug).Info("Secret sync not required")
+	}
+
+	o.Status.SecretMAC = base64.StdEncoding.EncodeToString(messageMAC)
+	o.Status.KeyVersion = keyVersion
+	if err := r.updateStatus(ctx, o); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{
+		RequeueAfter: requeueAfter,
+	}, nil
+}
+
+// doVault reads the transit key's latest version, and rewraps the sourced
+// ciphertext that was encrypted with an older version of the key, if
+// configured to do so. The rewrapped ciphertext is written back to its source.
+// Returns the key's latest version along with the decrypted ciphertexts.
+func (r *VaultTransitSecretReconciler) doVault(ctx context.Context, c vault.ClientBase,
+	o *secretsv1beta1.VaultTransitSecret, mount string, ciphertexts []string,
+) (int, [][]byte, error) {
+	keyVersion, err := r.rewrap(ctx, c, o, mount, ciphertexts)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	plaintexts, err := transitDecrypt(ctx, c, mount, o.Spec.Key, o.Spec.Payloads, ciphertexts)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return keyVersion, plaintexts, nil
+}
+
+// rewrap returns the transit key's latest version. If the resource is
+// configured for rewrapping, the sourced ciphertext that was encrypted with an
+// older version of the key is rewrapped, and updated in both its source and
+// ciphertexts.
+func (r *VaultTransitSecretReconciler) rewrap(ctx context.Context, c vault.ClientBase,
+	o *secretsv1beta1.VaultTransitSecret, mount string, ciphertexts []string,
+) (int, error) {
+	logger := log.FromContext(ctx)
+	path := fmt.Sprintf("%s/keys/%s", mount, o.Spec.Key)
+	resp, err := c.Read(ctx, vault.NewReadRequest(path, nil))
+	if err != nil {
+		return 0, err
+	}
+	if resp == nil {
+		return 0, fmt.Errorf("nil response from Vault, path=%s", path)
+	}
+
+	keyVersion, err := transitKeyLatestVersion(resp.Data())
+	if err != nil {
+		return 0, err
+	}
+
+	if o.Status.KeyVersion != 0 && keyVersion != o.Status.KeyVersion {
+		logger.Info("Transit key rotated", "from", o.Status.KeyVersion, "to", keyVersion)
+	}
+
+	if !o.Spec.Rewrap {
+		return keyVersion, nil
+	}
+
+	var idx []int
+	for i, p := range o.Spec.Payloads {
+		if p.CiphertextFrom == nil {
+			continue
+		}
+		v, err := transitCiphertextVersion(ciphertexts[i])
+		if err != nil {
+			return 0, err
+		}
+		if v < keyVersion {
+			idx = append(idx, i)
+		}
+	}
+
+	if len(idx) == 0 {
+		return keyVersion, nil
+	}
+
+	var input []map[string]any
+	for _, i := range idx {
+		input = append(input, transitBatchItem("ciphertext", ciphertexts[i], o.Spec.Payloads[i].Context))
+	}
+
+	results, err := transitBatchWrite(ctx, c, fmt.Sprintf("%s/rewrap/%s", mount, o.Spec.Key), input)
+	if err != nil {
+		return 0, err
+	}
+
+	for n, i := range idx {
+		ct, _ := results[n]["ciphertext"].(string)
+		if ct == "" {
+			return 0, fmt.Errorf("rewrap response for payload %q contains no ciphertext",
+				o.Spec.Payloads[i].Name)
+		}
+		if err := r.storeCiphertext(ctx, o, o.Spec.Payloads[i].CiphertextFrom, ct); err != nil {
+			return 0, err
+		}
+		ciphertexts[i] = ct
+		r.Recorder.Eventf(o, corev1.EventTypeNormal, consts.ReasonCiphertextRewrapped,
+			"Rewrapped the ciphertext of payload %q to key version %d",
+			o.Spec.Payloads[i].Name, keyVersion)
+	}
+
+	return keyVersion, nil
+}
+
+// loadCiphertexts returns the ciphertext of each payload, in order.
+func (r *VaultTransitSecretReconciler) loadCiphertexts(ctx context.Context, o *secretsv1beta1.VaultTransitSecret) ([]string, error) {
+	result := make([]string, len(o.Spec.Payloads))
+	for i, p := range o.Spec.Payloads {
+		if p.CiphertextFrom == nil {
+			result[i] = p.Ciphertext
+			continue
+		}
+
+		src := p.CiphertextFrom
+		key := client.ObjectKey{Namespace: o.Namespace, Name: src.Name}
+		var ct string
+		switch src.Kind {
+		case "Secret":
+			s, err := helpers.GetSecret(ctx, r.Client, key)
+			if err != nil {
+				return nil, err
+			}
+			ct = string(s.Data[src.Key])
+		case "ConfigMap":
+			cm, err := helpers.GetConfigMap(ctx, r.Client, key)
+			if err != nil {
+				return nil, err
+			}
+			ct = cm.Data[src.Key]
+			if ct == "" {
+				ct = string(cm.BinaryData[src.Key])
+			}
+		default:
+			return nil, fmt.Errorf("unsupported ciphertext source kind %q", src.Kind)
+		}
+
+		ct = strings.TrimSpace(ct)
+		if ct == "" {
+			return nil, fmt.Errorf("%s %s has no ciphertext in key %q", src.Kind, key, src.Key)
+		}
+		result[i] = ct
+	}
+
+	return result, nil
+}
+
+// storeCiphertext writes the rewrapped ciphertext back to its source.
+func (r *VaultTransitSecretReconciler) storeCiphertext(ctx context.Context, o *secretsv1beta1.VaultTransitSecret,
+	src *secretsv1beta1.TransitCiphertextSource, ciphertext string,
+) error {
+	key := client.ObjectKey{Namespace: o.Namespace, Name: src.Name}
+	switch src.Kind {
+	case "Secret":
+		s, err := helpers.GetSecret(ctx, r.Client, key)
+		if err != nil {
+			return err
+		}
+		if s.Data == nil {
+			s.Data = make(map[string][]byte)
+		}
+		s.Data[src.Key] = []byte(ciphertext)
+		return r.Client.Update(ctx, s)
+	case "ConfigMap":
+		cm, err := helpers.GetConfigMap(ctx, r.Client, key)
+		if err != nil {
+			return err
+		}
+		if _, ok := cm.BinaryData[src.Key]; ok {
+			cm.BinaryData[src.Key] = []byte(ciphertext)
+		} else {
+			if cm.Data == nil {
+				cm.Data = make(map[string]string)
+			}
+			cm.Data[src.Key] = ciphertext
+		}
+		return r.Client.Update(ctx, cm)
+	default:
+		return fmt.Errorf("unsupported ciphertext source kind %q", src.Kind)
+	}
+}
+
+func (r *VaultTransitSecretReconciler) updateStatus(ctx context.Context, o *secretsv1beta1.VaultTransitSecret) error {
+	logger := log.FromContext(ctx)
+	logger.V(consts.LogLevelDebug).Info("Updating status")
+	o.Status.LastGeneration = o.GetGeneration()
+	if err := r.Status().Update(ctx, o); err != nil {
+		r.Recorder.Eventf(o, corev1.EventTypeWarning, consts.ReasonStatusUpdateError,
+			"Failed to update the resource's status, err=%s", err)
+	}
+
+	_, err := maybeAddFinalizer(ctx, r.Client, o, vaultTransitSecretFinalizer)
+	return err
+}
+
+// recordSyncError emits a warning event for the failed sync attempt and records
+// it in the resource's Status.LastSyncMessages. The status update is best effort,
+// any errors are only logged, since the caller will requeue the resource anyway.
+func (r *VaultTransitSecretReconciler) recordSyncError(ctx context.Context, o *secretsv1beta1.VaultTransitSecret, reason, msg string, a ...any) {
+	r.Recorder.Eventf(o, corev1.EventTypeWarning, reason, msg, a...)
+	messages := appendSyncMessage(o.Status.LastSyncMessages,
+		secretsv1beta1.SyncResultFailure, reason, msg, a...)
+	if err := patchSyncMessages(ctx, r.Client, o, messages, o.Status.Conditions); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to record the sync error in the resource's status")
+	}
+	diagnostics.RecordFailure(ctx, r.Client, o, messages, o.Status.Conditions, reason, a...)
+}
+
+func (r *VaultTransitSecretReconciler) handleDeletion(ctx context.Context, o *secretsv1beta1.VaultTransitSecret) error {
+	logger := log.FromContext(ctx)
+	objKey := client.ObjectKeyFromObject(o)
+	r.referenceCache.Remove(SecretTransformation, objKey)
+	r.BackOffRegistry.Delete(objKey)
+	helpers.DeleteSecretlessData(o)
+	if err := helpers.HandleDestinationDeletion(ctx, r.Client, o); err != nil {
+		logger.Error(err, "Failed to apply the deletion policy of the destination Secrets")
+		return err
+	}
+	if controllerutil.ContainsFinalizer(o, vaultTransitSecretFinalizer) {
+		logger.Info("Removing finalizer")
+		if controllerutil.RemoveFinalizer(o, vaultTransitSecretFinalizer) {
+			if err := r.Update(ctx, o); err != nil {
+				logger.Error(err, "Failed to remove the finalizer")
+				return err
+			}
+			logger.Info("Successfully removed the finalizer")
+		}
+	}
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *VaultTransitSecretReconciler) SetupWithManager(mgr ctrl.Manager, opts controller.Options) error {
+	r.referenceCache = newResourceReferenceCache()
+	if r.BackOffRegistry == nil {
+		r.BackOffRegistry = NewBackOffRegistry()
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&secretsv1beta1.VaultTransitSecret{}).
+		WithEventFilter(syncableSecretPredicate(nil)).
+		WithOptions(opts).
+		Watches(
+			&secretsv1beta1.SecretTransformation{},
+			NewEnqueueRefRequestsHandlerST(r.referenceCache, nil),
+		).
+		WatchesMetadata(
+			&corev1.Secret{},
+			&enqueueOnDeletionRequestHandler{
+				gvk: secretsv1beta1.GroupVersion.WithKind(VaultTransitSecret.String()),
+			},
+			builder.WithPredicates(&secretsPredicate{}),
+		).
+		Complete(r)
+}
+
+// validateTransitPayloads ensures that each payload has exactly one ciphertext
+// source, and that the payload names are unique.
+func validateTransitPayloads(payloads []secretsv1beta1.TransitPayload) error {
+	if len(payloads) == 0 {
+		return errors.New("at least one payload is required")
+	}
+
+	var errs error
+	seen := make(map[string]bool, len(payloads))
+	for i, p := range payloads {
+		if p.Name == "" {
+			errs = errors.Join(errs, fmt.Errorf("payloads[%d]: name is required", i))
+		} else if seen[p.Name] {
+			errs = errors.Join(errs, fmt.Errorf("payloads[%d]: duplicate name %q", i, p.Name))
+		}
+		seen[p.Name] = true
+
+		if (p.Ciphertext == "") == (p.CiphertextFrom == nil) {
+			errs = errors.Join(errs, fmt.Errorf(
+				"payloads[%d]: exactly one of ciphertext or ciphertextFrom must be set", i))
+		}
+	}
+
+	return errs
+}
+
+// transitDecrypt decrypts all ciphertexts in a single batch request. The
+// plaintexts are returned in the same order as ciphertexts.
+func transitDecrypt(ctx context.Context, c vault.ClientBase, mount, key string,
+	payloads []secretsv1beta1.TransitPayload, ciphertexts []string,
+) ([][]byte, error) {
+	input := make([]map[string]any, len(ciphertexts))
+	for i, ct := range ciphertexts {
+		input[i] = transitBatchItem("ciphertext", ct, payloads[i].Context)
+	}
+
+	results, err := transitBatchWrite(ctx, c, fmt.Sprintf("%s/decrypt/%s", mount, key), input)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintexts := make([][]byte, len(results))
+	for i, res := range results {
+		p, _ := res["plaintext"].(string)
+		b, err := base64.StdEncoding.DecodeString(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid plaintext for payload %q: %w", payloads[i].Name, err)
+		}
+		plaintexts[i] = b
+	}
+
+	return plaintexts, nil
+}
+
+func transitBatchItem(field, value, context string) map[string]any {
+	item := map[string]any{
+		field: value,
+	}
+	if context != "" {
+		item["context"] = context
+	}
+	return item
+}
+
+// transitBatchWrite sends input as the batch_input of a transit request to path,
+// returning its batch_results. Any per-item error fails the whole request.
+func transitBatchWrite(ctx context.Context, c vault.ClientBase, path string, input []map[string]any) ([]map[string]any, error) {
+	resp, err := c.Write(ctx, vault.NewWriteRequest(path, map[string]any{
+		"batch_input": input,
+	}))
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, fmt.Errorf("nil response from Vault, path=%s", path)
+	}
+
+	b, err := json.Marshal(resp.Data()["batch_results"])
+	if err != nil {
+		return nil, err
+	}
+
+	var results []map[string]any
+	if err := json.Unmarshal(b, &results); err != nil {
+		return nil, err
+	}
+
+	if len(results) != len(input) {
+		return nil, fmt.Errorf("expected %d batch results, got %d, path=%s",
+			len(input), len(results), path)
+	}
+
+	var errs error
+	for i, res := range results {
+		if e, _ := res["error"].(string); e != "" {
+			errs = errors.Join(errs, fmt.Errorf("batch item %d: %s", i, e))
+		}
+	}
+
+	return results, errs
+}
+
+// transitKeyLatestVersion returns the latest_version from a transit key read
+// response.
+func transitKeyLatestVersion(data map[string]any) (int, error) {
+	var v int
+	var err error
+	switch t := data["latest_version"].(type) {
+	case json.Number:
+		var i int64
+		i, err = t.Int64()
+		v = int(i)
+	case float64:
+		v = int(t)
+	case int:
+		v = t
+	default:
+		err = fmt.Errorf("unexpected type %T", t)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("invalid latest_version in transit key response: %w", err)
+	}
+
+	return v, nil
+}
+
+// transitCiphertextVersion returns the key version that ciphertext was
+// encrypted with.
+func transitCiphertextVersion(ciphertext string) (int, error) {
+	m := transitCiphertextVersionRe.FindStringSubmatch(ciphertext)
+	if m == nil {
+		return 0, errors.New("invalid transit ciphertext, expected the vault:v<version>: prefix")
+	}
+
+	return strconv.Atoi(m[1])
+}
@@ -0,0 +1,341 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package controllers
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/internal/testutils"
+	"github.com/hashicorp/vault-secrets-operator/vault"
+)
+
+func Test_validateTransitPayloads(t *testing.T) {
+	tests := []struct {
+		name     string
+		payloads []secretsv1beta1.TransitPayload
+		wantErr  assert.ErrorAssertionFunc
+	}{
+		{
+			name: "valid",
+			payloads: []secretsv1beta1.TransitPayload{
+				{
+					Name:       "foo",
+					Ciphertext: "vault:v1:Zm9v",
+				},
+				{
+					Name: "bar",
+					CiphertextFrom: &secretsv1beta1.TransitCiphertextSource{
+						Kind: "Secret",
+						Name: "baz",
+						Key:  "bar",
+					},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "empty",
+			wantErr: assert.Error,
+		},
+		{
+			name: "invalid",
+			payloads: []secretsv1beta1.TransitPayload{
+				{
+					Name: "foo",
+				},
+				{
+					Name:       "foo",
+					Ciphertext: "vault:v1:Zm9v",
+					CiphertextFrom: &secretsv1beta1.TransitCiphertextSource{
+						Kind: "Secret",
+						Name: "baz",
+						Key:  "bar",
+					},
+				},
+			},
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.EqualError(t, err,
+					"payloads[0]: exactly one of ciphertext or ciphertextFrom must be set\n"+
+						"payloads[1]: duplicate name \"foo\"\n"+
+						"payloads[1]: exactly one of ciphertext or ciphertextFrom must be set", i...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.wantErr(t, validateTransitPayloads(tt.payloads))
+		})
+	}
+}
+
+func Test_transitCiphertextVersion(t *testing.T) {
+	tests := []struct {
+		ciphertext string
+		want       int
+		wantErr    bool
+	}{
+		{
+			ciphertext: "vault:v1:Zm9v",
+			want:       1,
+		},
+		{
+			ciphertext: "vault:v12:Zm9v",
+			want:       12,
+		},
+		{
+			ciphertext: "Zm9v",
+			wantErr:    true,
+		},
+		{
+			ciphertext: "vault:vX:Zm9v",
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ciphertext, func(t *testing.T) {
+			got, err := transitCiphertextVersion(tt.ciphertext)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_transitKeyLatestVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    map[string]any
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "json-number",
+			data: map[string]any{"latest_version": json.Number("3")},
+			want: 3,
+		},
+		{
+			name: "float",
+			data: map[string]any{"latest_version": float64(2)},
+			want: 2,
+		},
+		{
+			name:    "missing",
+			data:    map[string]any{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := transitKeyLatestVersion(tt.data)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestVaultTransitSecretReconciler_doVault(t *testing.T) {
+	ctx := context.Background()
+	b64 := func(s string) string {
+		return base64.StdEncoding.EncodeToString([]byte(s))
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "ciphertexts",
+			Namespace: "baz",
+		},
+		Data: map[string]string{
+			"bar": "vault:v1:YmFy",
+		},
+	}
+
+	newObj := func(rewrap bool) *secretsv1beta1.VaultTransitSecret {
+		return &secretsv1beta1.VaultTransitSecret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo",
+				Namespace: "baz",
+			},
+			Spec: secretsv1beta1.VaultTransitSecretSpec{
+				Key:    "app",
+				Rewrap: rewrap,
+				Payloads: []secretsv1beta1.TransitPayload{
+					{
+						Name:       "foo",
+						Ciphertext: "vault:v1:Zm9v",
+						Context:    b64("ctx"),
+					},
+					{
+						Name: "bar",
+						CiphertextFrom: &secretsv1beta1.TransitCiphertextSource{
+							Kind: "ConfigMap",
+							Name: "ciphertexts",
+							Key:  "bar",
+						},
+					},
+				},
+			},
+		}
+	}
+
+	newVaultClient := func() *vault.MockRecordingVaultClient {
+		return &vault.MockRecordingVaultClient{
+			ReadResponses: map[string][]vault.Response{
+				"transit/keys/app": {
+					vault.NewDefaultResponse(&api.Secret{
+						Data: map[string]any{
+							"latest_version": json.Number("2"),
+						},
+					}),
+				},
+			},
+			WriteResponses: map[string][]vault.Response{
+				"transit/rewrap/app": {
+					vault.NewDefaultResponse(&api.Secret{
+						Data: map[string]any{
+							"batch_results": []any{
+								map[string]any{
+									"ciphertext":  "vault:v2:YmFy",
+									"key_version": json.Number("2"),
+								},
+							},
+						},
+					}),
+				},
+				"transit/decrypt/app": {
+					vault.NewDefaultResponse(&api.Secret{
+						Data: map[string]any{
+							"batch_results": []any{
+								map[string]any{"plaintext": b64("foo")},
+								map[string]any{"plaintext": b64("bar")},
+							},
+						},
+					}),
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		rewrap         bool
+		wantRequests   []*vault.MockRequest
+		wantCiphertext string
+	}{
+		{
+			name:   "no-rewrap",
+			rewrap: false,
+			wantRequests: []*vault.MockRequest{
+				{
+					Method: http.MethodGet,
+					Path:   "transit/keys/app",
+				},
+				{
+					Method: http.MethodPut,
+					Path:   "transit/decrypt/app",
+					Params: map[string]any{
+						"batch_input": []map[string]any{
+							{"ciphertext": "vault:v1:Zm9v", "context": b64("ctx")},
+							{"ciphertext": "vault:v1:YmFy"},
+						},
+					},
+				},
+			},
+			wantCiphertext: "vault:v1:YmFy",
+		},
+		{
+			name:   "rewrap",
+			rewrap: true,
+			wantRequests: []*vault.MockRequest{
+				{
+					Method: http.MethodGet,
+					Path:   "transit/keys/app",
+				},
+				{
+					Method: http.MethodPut,
+					Path:   "transit/rewrap/app",
+					Params: map[string]any{
+						"batch_input": []map[string]any{
+							{"ciphertext": "vault:v1:YmFy"},
+						},
+					},
+				},
+				{
+					Method: http.MethodPut,
+					Path:   "transit/decrypt/app",
+					Params: map[string]any{
+						"batch_input": []map[string]any{
+							{"ciphertext": "vault:v1:Zm9v", "context": b64("ctx")},
+							{"ciphertext": "vault:v2:YmFy"},
+						},
+					},
+				},
+			},
+			wantCiphertext: "vault:v2:YmFy",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sClient := testutils.NewFakeClientBuilder().WithObjects(cm.DeepCopy()).Build()
+			r := &VaultTransitSecretReconciler{
+				Client:   k8sClient,
+				Recorder: record.NewFakeRecorder(10),
+			}
+
+			o := newObj(tt.rewrap)
+			ciphertexts, err := r.loadCiphertexts(ctx, o)
+			require.NoError(t, err)
+
+			c := newVaultClient()
+			keyVersion, plaintexts, err := r.doVault(ctx, c, o, "transit", ciphertexts)
+			require.NoError(t, err)
+			assert.Equal(t, 2, keyVersion)
+			assert.Equal(t, [][]byte{[]byte("foo"), []byte("bar")}, plaintexts)
+			assert.Equal(t, tt.wantRequests, c.Requests)
+
+			var got corev1.ConfigMap
+			require.NoError(t, k8sClient.Get(ctx, client.ObjectKeyFromObject(cm), &got))
+			assert.Equal(t, tt.wantCiphertext, got.Data["bar"])
+		})
+	}
+}
+
+func Test_transitBatchWrite_itemError(t *testing.T) {
+	c := &vault.MockRecordingVaultClient{
+		WriteResponses: map[string][]vault.Response{
+			"transit/decrypt/app": {
+				vault.NewDefaultResponse(&api.Secret{
+					Data: map[string]any{
+						"batch_results": []any{
+							map[string]any{"error": "cipher: message authentication failed"},
+						},
+					},
+				}),
+			},
+		},
+	}
+
+	_, err := transitBatchWrite(context.Background(), c, "transit/decrypt/app",
+		[]map[string]any{{"ciphertext": "vault:v1:Zm9v"}})
+	assert.EqualError(t, err, "batch item 0: cipher: message authentication failed")
+}
@@ -0,0 +1,494 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/consts"
+	"github.com/hashicorp/vault-secrets-operator/helpers"
+	"github.com/hashicorp/vault-secrets-operator/internal/cloudevents"
+	"github.com/hashicorp/vault-secrets-operator/internal/diagnostics"
+	"github.com/hashicorp/vault-secrets-operator/vault"
+)
+
+const (
+	vaultWrappedSecretFinalizer = "vaultwrappedsecret.secrets.hashicorp.com/finalizer"
+	// wrappedPayloadKey is the key of the payload Secret's data that holds the
+	// JSON encoded payload.
+	wrappedPayloadKey = "payload"
+	// wrappedTokenMACKey is the key of the payload Secret's data that holds the
+	// MAC of the wrapping token that the payload was unwrapped from.
+	wrappedTokenMACKey = "tokenMAC"
+)
+
+// VaultWrappedSecretReconciler reconciles a VaultWrappedSecret object
+type VaultWrappedSecretReconciler struct {
+	client.Client
+	Scheme                      *runtime.Scheme
+	Recorder                    record.EventRecorder
+	ClientFactory               vault.ClientFactory
+	SecretDataBuilder           *helpers.SecretDataBuilder
+	SecretsClient               client.Client
+	HMACValidator               helpers.HMACValidator
+	BackOffRegistry             *BackOffRegistry
+	GlobalTransformationOptions *helpers.GlobalTransformationOptions
+	referenceCache              ResourceReferenceCache
+}
+
+// +kubebuilder:rbac:groups=secrets.hashicorp.com,resources=vaultwrappedsecrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=secrets.hashicorp.com,resources=vaultwrappedsecrets/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=secrets.hashicorp.com,resources=vaultwrappedsecrets/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+//
+// required for rollout-restart
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=apps,resources=daemonsets,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=argoproj.io,resources=rollouts,verbs=get;list;watch;patch
+//
+
+// Reconcile ensures that the payload of the VaultWrappedSecret Custom
+// Resource's response-wrapping token is synced to its configured Kubernetes
+// secret. Every wrapping token is unwrapped exactly once, the payload is then
+// stored in a Secret that is owned by the resource, and the destination is
+// always rendered from that stored payload. The token's Secret is polled every
+// RefreshAfter period for a new wrapping token.
+func (r *VaultWrappedSecretReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	o := &secretsv1beta1.VaultWrappedSecret{}
+	if err := r.Client.Get(ctx, req.NamespacedName, o); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+
+		logger.Error(err, "error getting resource from k8s", "secret", o)
+		return ctrl.Result{}, err
+	}
+
+	if o.GetDeletionTimestamp() != nil {
+		logger.Info("Got deletion timestamp", "obj", o)
+		return ctrl.Result{}, r.handleDeletion(ctx, o)
+	}
+
+	requeueAfter := computeHorizonWithJitter(time.Second * 60)
+	if o.Spec.RefreshAfter != "" {
+		d, err := parseDurationString(o.Spec.RefreshAfter, ".spec.refreshAfter", 0)
+		if err != nil {
+			r.recordSyncError(ctx, o, consts.ReasonVaultWrappedSecret,
+				"Field validation failed, err=%s", err)
+			return ctrl.Result{RequeueAfter: computeHorizonWithJitter(requeueDurationOnError)}, nil
+		}
+		requeueAfter = computeHorizonWithJitter(d)
+	}
+
+	r.referenceCache.Set(SecretTransformation, req.NamespacedName,
+		helpers.GetTransformationRefObjKeys(
+			o.Spec.Destination.Transformation, o.Namespace)...)
+
+	transOption, err := helpers.NewSecretTransformationOption(ctx, r.Client, o, r.GlobalTransformationOptions)
+	if err != nil {
+		r.recordSyncError(ctx, o, consts.ReasonTransformationError,
+			"Failed setting up SecretTransformationOption: %s", err)
+		return ctrl.Result{RequeueAfter: computeHorizonWithJitter(requeueDurationOnError)}, nil
+	}
+
+	payload, payloadTokenMAC, err := r.loadPayload(ctx, o)
+	if err != nil {
+		r.recordSyncError(ctx, o, consts.ReasonVaultWrappedSecret,
+			"Failed to load the stored payload: %s", err)
+		return ctrl.Result{RequeueAfter: computeHorizonWithJitter(requeueDurationOnError)}, nil
+	}
+
+	token, err := r.getWrappingToken(ctx, o)
+	if err != nil {
+		r.recordSyncError(ctx, o, consts.ReasonVaultWrappedSecret,
+			"Failed to get the wrapping token: %s", err)
+		return ctrl.Result{RequeueAfter: computeHorizonWithJitter(requeueDurationOnError)}, nil
+	}
+
+	if token != "" {
+		mac, err := r.HMACValidator.HMAC(ctx, r.SecretsClient, []byte(token))
+		if err != nil {
+			r.recordSyncError(ctx, o, consts.ReasonHMACDataError,
+				"Failed to HMAC the wrapping token: %s", err)
+			return ctrl.Result{RequeueAfter: computeHorizonWithJitter(requeueDurationOnError)}, nil
+		}
+
+		// the token is consumed once its payload has been stored, or once it has
+		// been rejected by Vault.
+		tokenMAC := base64.StdEncoding.EncodeToString(mac)
+		if tokenMAC != payloadTokenMAC && tokenMAC != o.Status.WrappingTokenMAC {
+			p, result := r.unwrap(ctx, o, token, tokenMAC)
+			if result != nil {
+				return *result, nil
+			}
+			if p != nil {
+				payload = p
+			}
+		}
+	}
+
+	if payload == nil {
+		r.recordSyncError(ctx, o, consts.ReasonVaultWrappedSecret,
+			"No payload available, waiting for a wrapping token in Secret %s",
+			o.Spec.WrappingTokenRef.SecretName)
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+
+	data, err := r.SecretDataBuilder.WithVaultData(payload, payload, transOption)
+	if err != nil {
+		r.recordSyncError(ctx, o, consts.ReasonSecretDataBuilderError,
+			"Failed to build K8s secret data: %s", err)
+		return ctrl.Result{RequeueAfter: computeHorizonWithJitter(requeueDurationOnError)}, nil
+	}
+
+	// doRolloutRestart only if this is not the first time this secret has been synced
+	doRolloutRestart := o.Status.SecretMAC != ""
+	macsEqual, messageMAC, err := helpers.HandleSecretHMAC(ctx, r.SecretsClient, r.HMACValidator, o, data)
+	if err != nil {
+		r.recordSyncError(ctx, o, consts.ReasonHMACDataError,
+			"Failed to HMAC the secret data: %s", err)
+		return ctrl.Result{RequeueAfter: computeHorizonWithJitter(requeueDurationOnError)}, nil
+	}
+
+	doSync := true
+	if o.Status.LastGeneration == o.GetGeneration() {
+		doSync = !macsEqual
+	}
+
+	if doSync {
+		err := helpers.SyncSecret(ctx, r.Client, o, data)
+		helpers.SetDestinationConflictCondition(&o.Status.Conditions, o.GetGeneration(), err)
+		helpers.SetFanOutDeniedCondition(&o.Status.Conditions, o.GetGeneration(), err)
+		if err != nil {
+			r.recordSyncError(ctx, o, syncSecretErrorReason(err),
+				"Failed to update k8s secret: %s", err)
+			return ctrl.Result{RequeueAfter: computeHorizonWithJitter(requeueDurationOnError)}, nil
+		}
+		reason := consts.ReasonSecretSynced
+		if doRolloutRestart {
+			reason = consts.ReasonSecretRotated
+			// rollout-restart errors are not retryable
+			// all error reporting is handled by helpers.HandleRolloutRestarts
+			_ = helpers.HandleRolloutRestarts(ctx, r.Client, o, r.Recorder)
+		}
+		r.Recorder.Event(o, corev1.EventTypeNormal, reason, "Secret synced")
+		if reason == consts.ReasonSecretRotated {
+			cloudevents.Emit(ctx, cloudevents.TypeSecretRotated, o, cloudevents.Data{})
+		}
+		o.Status.LastSyncMessages = appendSyncMessage(o.Status.LastSyncMessages,
+			secretsv1beta1.SyncResultSuccess, reason, "Secret synced")
+	} else {
+		logger.V(consts.LogLevelDebug).Info("Secret sync not required")
+	}
+
+	o.Status.SecretMAC = base64.StdEncoding.EncodeToString(messageMAC)
+	if err := r.updateStatus(ctx, o); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{
+		RequeueAfter: requeueAfter,
+	}, nil
+}
+
+// unwrap the wrapping token, and store its payload along with the token's MAC,
+// which marks the token as consumed. A nil payload is returned when the token
+// was rejected by Vault, or when its payload could not be stored, in both cases
+// a new wrapping token is required. A non-nil result is returned when the
+// unwrapping should be retried.
+func (r *VaultWrappedSecretReconciler) unwrap(ctx context.Context, o *secretsv1beta1.VaultWrappedSecret,
+	token, tokenMAC string,
+) (map[string]any, *ctrl.Result) {
+	logger := log.FromContext(ctx)
+	req := client.ObjectKeyFromObject(o)
+
+	c, err := r.ClientFactory.Get(ctx, r.Client, o)
+	if err != nil {
+		r.recordSyncError(ctx, o, consts.ReasonVaultClientConfigError,
+			"Failed to get Vault auth login: %s", err)
+		return nil, &ctrl.Result{RequeueAfter: computeHorizonWithJitter(requeueDurationOnError)}
+	}
+
+	payload, err := unwrapToken(ctx, c, token, o.Spec.CreationPath)
+	if err != nil {
+		if vault.IsInvalidWrappingTokenError(err) {
+			// the token has either expired, or it has already been unwrapped by
+			// someone else. Either way it will never be valid again.
+			o.Status.WrappingTokenMAC = tokenMAC
+			r.Recorder.Eventf(o, corev1.EventTypeWarning, consts.ReasonWrappingTokenInvalid,
+				"The wrapping token is expired or has already been unwrapped, "+
+					"it may have been intercepted: %s", err)
+			o.Status.LastSyncMessages = appendSyncMessage(o.Status.LastSyncMessages,
+				secretsv1beta1.SyncResultFailure, consts.ReasonWrappingTokenInvalid,
+				"The wrapping token is expired or has already been unwrapped")
+			if err := r.updateStatus(ctx, o); err != nil {
+				return nil, &ctrl.Result{RequeueAfter: computeHorizonWithJitter(requeueDurationOnError)}
+			}
+			return nil, nil
+		}
+
+		if vault.IsForbiddenError(err) {
+			c.Taint()
+		}
+
+		entry, _ := r.BackOffRegistry.Get(req)
+		r.recordSyncError(ctx, o, consts.ReasonVaultClientError,
+			"Failed to unwrap the wrapping token: %s", err)
+		return nil, &ctrl.Result{RequeueAfter: entry.NextBackOff()}
+	}
+	r.BackOffRegistry.Delete(req)
+
+	o.Status.WrappingTokenMAC = tokenMAC
+	o.Status.UnwrapTime = nowFunc().Unix()
+	if err := r.storePayload(ctx, o, payload, tokenMAC); err != nil {
+		// the payload can only be recovered from Vault by writing a new
+		// wrapping token, so there is no point in retrying.
+		logger.Error(err, "Failed to store the unwrapped payload")
+		r.Recorder.Eventf(o, corev1.EventTypeWarning, consts.ReasonVaultWrappedSecret,
+			"Failed to store the unwrapped payload, a new wrapping token is required: %s", err)
+		if err := r.updateStatus(ctx, o); err != nil {
+			return nil, &ctrl.Result{RequeueAfter: computeHorizonWithJitter(requeueDurationOnError)}
+		}
+		return nil, nil
+	}
+
+	r.Recorder.Event(o, corev1.EventTypeNormal, consts.ReasonWrappingTokenUnwrapped,
+		"Wrapping token unwrapped")
+
+	return payload, nil
+}
+
+// unwrapToken unwraps token with Vault. If creationPath is set, the token is
+// looked up first, and rejected if it was created for a different path.
+func unwrapToken(ctx context.Context, c vault.ClientBase, token, creationPath string) (map[string]any, error) {
+	params := map[string]any{
+		"token": token,
+	}
+	if creationPath != "" {
+		resp, err := c.Write(ctx, vault.NewWriteRequest("sys/wrapping/lookup", params))
+		if err != nil {
+			return nil, err
+		}
+		if resp == nil {
+			return nil, errors.New("nil response from Vault, path=sys/wrapping/lookup")
+		}
+		if got, _ := resp.Data()["creation_path"].(string); got != creationPath {
+			return nil, fmt.Errorf("wrapping token was created for path %q, expected %q",
+				got, creationPath)
+		}
+	}
+
+	resp, err := c.Write(ctx, vault.NewWriteRequest("sys/wrapping/unwrap", params))
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil || resp.Secret() == nil {
+		return nil, errors.New("nil response from Vault, path=sys/wrapping/unwrap")
+	}
+
+	payload := resp.Data()
+	// wrapped auth responses, e.g. tokens, do not carry any data.
+	if len(payload) == 0 && resp.Secret().Auth != nil {
+		payload = map[string]any{
+			"token":    resp.Secret().Auth.ClientToken,
+			"accessor": resp.Secret().Auth.Accessor,
+		}
+	}
+	if len(payload) == 0 {
+		return nil, errors.New("wrapped response is empty")
+	}
+
+	return payload, nil
+}
+
+// getWrappingToken returns the wrapping token from the WrappingTokenRef Secret.
+// An empty token is returned if the Secret or the key do not exist, since the
+// token may only be written later on.
+func (r *VaultWrappedSecretReconciler) getWrappingToken(ctx context.Context, o *secretsv1beta1.VaultWrappedSecret) (string, error) {
+	ref := o.Spec.WrappingTokenRef
+	key := ref.Key
+	if key == "" {
+		key = "token"
+	}
+
+	s, err := helpers.GetSecret(ctx, r.Client, client.ObjectKey{
+		Namespace: o.Namespace,
+		Name:      ref.SecretName,
+	})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return string(bytes.TrimSpace(s.Data[key])), nil
+}
+
+// payloadSecretName returns the name of the Secret that holds the payload
+// unwrapped for o.
+func payloadSecretName(o *secretsv1beta1.VaultWrappedSecret) string {
+	return fmt.Sprintf("vso-wrapped-%s", o.GetUID())
+}
+
+// loadPayload returns the payload that was previously unwrapped for o, along
+// with the MAC of the wrapping token that it was unwrapped from. A nil payload
+// is returned if there is none.
+func (r *VaultWrappedSecretReconciler) loadPayload(ctx context.Context, o *secretsv1beta1.VaultWrappedSecret) (map[string]any, string, error) {
+	s, err := helpers.GetSecret(ctx, r.Client, client.ObjectKey{
+		Namespace: o.Namespace,
+		Name:      payloadSecretName(o),
+	})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, "", nil
+		}
+		return nil, "", err
+	}
+
+	if !metav1.IsControlledBy(s, o) {
+		return nil, "", fmt.Errorf("secret %s is not owned by the resource", s.Name)
+	}
+
+	var payload map[string]any
+	dec := json.NewDecoder(bytes.NewReader(s.Data[wrappedPayloadKey]))
+	dec.UseNumber()
+	if err := dec.Decode(&payload); err != nil {
+		return nil, "", fmt.Errorf("invalid payload in Secret %s: %w", s.Name, err)
+	}
+
+	return payload, string(s.Data[wrappedTokenMACKey]), nil
+}
+
+// storePayload writes payload and the MAC of its wrapping token to the payload
+// Secret, which is controlled by o. It is deliberately not labeled like the
+// destination Secrets, so that it is never considered for orphan pruning.
+func (r *VaultWrappedSecretReconciler) storePayload(ctx context.Context, o *secretsv1beta1.VaultWrappedSecret,
+	payload map[string]any, tokenMAC string,
+) error {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	s := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      payloadSecretName(o),
+			Namespace: o.Namespace,
+		},
+	}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, s, func() error {
+		s.Labels = map[string]string{
+			"app.kubernetes.io/component":  "wrapped-payload",
+			"app.kubernetes.io/managed-by": "hashicorp-vso",
+		}
+		s.Type = corev1.SecretTypeOpaque
+		s.Data = map[string][]byte{
+			wrappedPayloadKey:  b,
+			wrappedTokenMACKey: []byte(tokenMAC),
+		}
+		return controllerutil.SetControllerReference(o, s, r.Scheme)
+	}); err != nil {
+		return err
+	}
+
+	o.Status.PayloadSecretName = s.Name
+	return nil
+}
+
+func (r *VaultWrappedSecretReconciler) updateStatus(ctx context.Context, o *secretsv1beta1.VaultWrappedSecret) error {
+	logger := log.FromContext(ctx)
+	logger.V(consts.LogLevelDebug).Info("Updating status")
+	o.Status.LastGeneration = o.GetGeneration()
+	if err := r.Status().Update(ctx, o); err != nil {
+		r.Recorder.Eventf(o, corev1.EventTypeWarning, consts.ReasonStatusUpdateError,
+			"Failed to update the resource's status, err=%s", err)
+	}
+
+	_, err := maybeAddFinalizer(ctx, r.Client, o, vaultWrappedSecretFinalizer)
+	return err
+}
+
+// recordSyncError emits a warning event for the failed sync attempt and records
+// it in the resource's Status.LastSyncMessages.
+func (r *VaultWrappedSecretReconciler) recordSyncError(ctx context.Context, o *secretsv1beta1.VaultWrappedSecret, reason, msg string, a ...any) {
+	r.Recorder.Eventf(o, corev1.EventTypeWarning, reason, msg, a...)
+	messages := appendSyncMessage(o.Status.LastSyncMessages,
+		secretsv1beta1.SyncResultFailure, reason, msg, a...)
+	if err := patchSyncMessages(ctx, r.Client, o, messages, o.Status.Conditions); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to record the sync error in the resource's status")
+	}
+	diagnostics.RecordFailure(ctx, r.Client, o, messages, o.Status.Conditions, reason, a...)
+}
+
+func (r *VaultWrappedSecretReconciler) handleDeletion(ctx context.Context, o *secretsv1beta1.VaultWrappedSecret) error {
+	logger := log.FromContext(ctx)
+	objKey := client.ObjectKeyFromObject(o)
+	r.referenceCache.Remove(SecretTransformation, objKey)
+	r.BackOffRegistry.Delete(objKey)
+	helpers.DeleteSecretlessData(o)
+	if err := helpers.HandleDestinationDeletion(ctx, r.Client, o); err != nil {
+		logger.Error(err, "Failed to apply the deletion policy of the destination Secrets")
+		return err
+	}
+	if controllerutil.ContainsFinalizer(o, vaultWrappedSecretFinalizer) {
+		logger.Info("Removing finalizer")
+		if controllerutil.RemoveFinalizer(o, vaultWrappedSecretFinalizer) {
+			if err := r.Update(ctx, o); err != nil {
+				logger.Error(err, "Failed to remove the finalizer")
+				return err
+			}
+			logger.Info("Successfully removed the finalizer")
+		}
+	}
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *VaultWrappedSecretReconciler) SetupWithManager(mgr ctrl.Manager, opts controller.Options) error {
+	r.referenceCache = newResourceReferenceCache()
+	if r.BackOffRegistry == nil {
+		r.BackOffRegistry = NewBackOffRegistry()
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&secretsv1beta1.VaultWrappedSecret{}).
+		WithEventFilter(syncableSecretPredicate(nil)).
+		WithOptions(opts).
+		Watches(
+			&secretsv1beta1.SecretTransformation{},
+			NewEnqueueRefRequestsHandlerST(r.referenceCache, nil),
+		).
+		WatchesMetadata(
+			&corev1.Secret{},
+			&enqueueOnDeletionRequestHandler{
+				gvk: secretsv1beta1.GroupVersion.WithKind(VaultWrappedSecret.String()),
+			},
+			builder.WithPredicates(&secretsPredicate{}),
+		).
+		Complete(r)
+}
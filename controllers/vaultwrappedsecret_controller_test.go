@@ -0,0 +1,214 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/helpers"
+	"github.com/hashicorp/vault-secrets-operator/internal/testutils"
+	"github.com/hashicorp/vault-secrets-operator/vault"
+)
+
+func Test_unwrapToken(t *testing.T) {
+	ctx := context.Background()
+	lookupResp := vault.NewDefaultResponse(&api.Secret{
+		Data: map[string]any{
+			"creation_path": "secret/data/app",
+		},
+	})
+	dataResp := vault.NewDefaultResponse(&api.Secret{
+		Data: map[string]any{
+			"password": "s3cr3t",
+		},
+	})
+
+	tests := []struct {
+		name           string
+		creationPath   string
+		writeResponses map[string][]vault.Response
+		want           map[string]any
+		wantRequests   []string
+		wantErr        assert.ErrorAssertionFunc
+	}{
+		{
+			name: "unwrap",
+			writeResponses: map[string][]vault.Response{
+				"sys/wrapping/unwrap": {dataResp},
+			},
+			want: map[string]any{
+				"password": "s3cr3t",
+			},
+			wantRequests: []string{"sys/wrapping/unwrap"},
+			wantErr:      assert.NoError,
+		},
+		{
+			name:         "creation-path",
+			creationPath: "secret/data/app",
+			writeResponses: map[string][]vault.Response{
+				"sys/wrapping/lookup": {lookupResp},
+				"sys/wrapping/unwrap": {dataResp},
+			},
+			want: map[string]any{
+				"password": "s3cr3t",
+			},
+			wantRequests: []string{"sys/wrapping/lookup", "sys/wrapping/unwrap"},
+			wantErr:      assert.NoError,
+		},
+		{
+			name:         "creation-path-mismatch",
+			creationPath: "secret/data/other",
+			writeResponses: map[string][]vault.Response{
+				"sys/wrapping/lookup": {lookupResp},
+			},
+			wantRequests: []string{"sys/wrapping/lookup"},
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.EqualError(t, err,
+					`wrapping token was created for path "secret/data/app", expected "secret/data/other"`, i...)
+			},
+		},
+		{
+			name: "auth-response",
+			writeResponses: map[string][]vault.Response{
+				"sys/wrapping/unwrap": {
+					vault.NewDefaultResponse(&api.Secret{
+						Auth: &api.SecretAuth{
+							ClientToken: "hvs.token",
+							Accessor:    "accessor",
+						},
+					}),
+				},
+			},
+			want: map[string]any{
+				"token":    "hvs.token",
+				"accessor": "accessor",
+			},
+			wantRequests: []string{"sys/wrapping/unwrap"},
+			wantErr:      assert.NoError,
+		},
+		{
+			name: "empty-response",
+			writeResponses: map[string][]vault.Response{
+				"sys/wrapping/unwrap": {
+					vault.NewDefaultResponse(&api.Secret{}),
+				},
+			},
+			wantRequests: []string{"sys/wrapping/unwrap"},
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.EqualError(t, err, "wrapped response is empty", i...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &vault.MockRecordingVaultClient{
+				WriteResponses: tt.writeResponses,
+			}
+			got, err := unwrapToken(ctx, c, "hvs.wrapping", tt.creationPath)
+			if !tt.wantErr(t, err) {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+
+			var paths []string
+			for _, req := range c.Requests {
+				assert.Equal(t, http.MethodPut, req.Method)
+				assert.Equal(t, map[string]any{"token": "hvs.wrapping"}, req.Params)
+				paths = append(paths, req.Path)
+			}
+			assert.Equal(t, tt.wantRequests, paths)
+		})
+	}
+}
+
+func TestVaultWrappedSecretReconciler_payload(t *testing.T) {
+	ctx := context.Background()
+	o := &secretsv1beta1.VaultWrappedSecret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: secretsv1beta1.GroupVersion.String(),
+			Kind:       "VaultWrappedSecret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "baz",
+			UID:       "uid-foo",
+		},
+		Spec: secretsv1beta1.VaultWrappedSecretSpec{
+			WrappingTokenRef: secretsv1beta1.WrappingTokenSource{
+				SecretName: "ci-token",
+			},
+		},
+	}
+
+	k8sClient := testutils.NewFakeClientBuilder().Build()
+	r := &VaultWrappedSecretReconciler{
+		Client: k8sClient,
+		Scheme: k8sClient.Scheme(),
+	}
+
+	// nothing has been stored, nor written by CI.
+	payload, tokenMAC, err := r.loadPayload(ctx, o)
+	require.NoError(t, err)
+	assert.Nil(t, payload)
+	assert.Empty(t, tokenMAC)
+	token, err := r.getWrappingToken(ctx, o)
+	require.NoError(t, err)
+	assert.Empty(t, token)
+
+	require.NoError(t, k8sClient.Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "ci-token",
+			Namespace: "baz",
+		},
+		Data: map[string][]byte{
+			"token": []byte("hvs.wrapping\n"),
+		},
+	}))
+	token, err = r.getWrappingToken(ctx, o)
+	require.NoError(t, err)
+	assert.Equal(t, "hvs.wrapping", token)
+
+	require.NoError(t, r.storePayload(ctx, o, map[string]any{
+		"password": "s3cr3t",
+		"port":     json.Number("5432"),
+	}, "mac"))
+	assert.Equal(t, "vso-wrapped-uid-foo", o.Status.PayloadSecretName)
+
+	var s corev1.Secret
+	require.NoError(t, k8sClient.Get(ctx, client.ObjectKey{Namespace: "baz", Name: "vso-wrapped-uid-foo"}, &s))
+	assert.True(t, metav1.IsControlledBy(&s, o))
+	// the payload Secret must never be pruned as an orphaned destination.
+	assert.False(t, helpers.HasOwnerLabels(&s))
+
+	payload, tokenMAC, err = r.loadPayload(ctx, o)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"password": "s3cr3t",
+		"port":     json.Number("5432"),
+	}, payload)
+	assert.Equal(t, "mac", tokenMAC)
+
+	// a payload Secret that is not controlled by the resource is never used.
+	other := o.DeepCopy()
+	other.UID = "uid-other"
+	require.NoError(t, k8sClient.Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      payloadSecretName(other),
+			Namespace: "baz",
+		},
+	}))
+	_, _, err = r.loadPayload(ctx, other)
+	assert.ErrorContains(t, err, "is not owned by the resource")
+}
@@ -24,6 +24,12 @@ var ProviderMethodsSupported = []string{
 	consts.ProviderMethodAppRole,
 	consts.ProviderMethodAWS,
 	consts.ProviderMethodGCP,
+	consts.ProviderMethodAzure,
+	consts.ProviderMethodCert,
+	consts.ProviderMethodToken,
+	consts.ProviderMethodLDAP,
+	consts.ProviderMethodUserPass,
+	consts.ProviderMethodOkta,
 	hcp.ProviderMethodServicePrincipal,
 }
 
@@ -45,6 +51,14 @@ func NewCredentialProvider(ctx context.Context, client client.Client, obj client
 			prov = &vault.AWSCredentialProvider{}
 		case consts.ProviderMethodGCP:
 			prov = &vault.GCPCredentialProvider{}
+		case consts.ProviderMethodAzure:
+			prov = &vault.AzureCredentialProvider{}
+		case consts.ProviderMethodCert:
+			prov = &vault.CertCredentialProvider{}
+		case consts.ProviderMethodToken:
+			prov = &vault.TokenCredentialProvider{}
+		case consts.ProviderMethodLDAP, consts.ProviderMethodUserPass, consts.ProviderMethodOkta:
+			prov = &vault.UserPassCredentialProvider{}
 		default:
 			return nil, fmt.Errorf("unsupported authentication method %s", authObj.Spec.Method)
 		}
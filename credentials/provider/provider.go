@@ -5,6 +5,7 @@ package provider
 
 import (
 	"context"
+	"crypto/tls"
 
 	"k8s.io/apimachinery/pkg/types"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -15,3 +16,49 @@ type CredentialProviderBase interface {
 	GetNamespace() string
 	GetCreds(context.Context, ctrlclient.Client) (map[string]interface{}, error)
 }
+
+// UnwrapFunc unwraps the response-wrapping token, returning the data that it
+// wraps. The data of a wrapped auth response only holds its client_token.
+type UnwrapFunc func(ctx context.Context, token string) (map[string]interface{}, error)
+
+// UnwrappingCredentialProvider is implemented by the credential providers whose
+// credentials can be provided as a response-wrapping token. Unwrap is called
+// before GetCreds, so that the wrapped credentials can be unwrapped and
+// persisted first.
+type UnwrappingCredentialProvider interface {
+	Unwrap(context.Context, ctrlclient.Client, UnwrapFunc) error
+}
+
+// ClientCertificateProvider is implemented by the credential providers whose
+// login requires a TLS client certificate. The certificate is loaded by
+// GetCreds.
+type ClientCertificateProvider interface {
+	// ClientCertificate returns the certificate that was loaded by the last call
+	// to GetCreds.
+	ClientCertificate() *tls.Certificate
+	// ClientCertificateRotated returns true if the certificate has changed since
+	// it was loaded.
+	ClientCertificateRotated(context.Context, ctrlclient.Client) (bool, error)
+}
+
+// LoginPathProvider is implemented by the credential providers whose login
+// path depends on their credentials, e.g. the username and password methods,
+// which log in at auth/<mount>/login/<username>. The path is known once
+// GetCreds has been called.
+type LoginPathProvider interface {
+	// LoginPath returns the path to log in at, relative to the auth method's
+	// mount.
+	LoginPath() string
+}
+
+// StaticTokenProvider is implemented by the credential providers whose
+// credentials are a Vault token, which is used as is, instead of logging in.
+// The token is loaded by GetCreds.
+type StaticTokenProvider interface {
+	// StaticToken returns the token that was loaded by the last call to
+	// GetCreds.
+	StaticToken() string
+	// StaticTokenRotated returns true if the token has changed since it was
+	// loaded.
+	StaticTokenRotated(context.Context, ctrlclient.Client) (bool, error)
+}
@@ -12,12 +12,16 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/credentials/provider"
 	"github.com/hashicorp/vault-secrets-operator/helpers"
 
 	"github.com/hashicorp/vault-secrets-operator/credentials/vault/consts"
 )
 
-var _ CredentialProvider = (*AppRoleCredentialProvider)(nil)
+var (
+	_ CredentialProvider                    = (*AppRoleCredentialProvider)(nil)
+	_ provider.UnwrappingCredentialProvider = (*AppRoleCredentialProvider)(nil)
+)
 
 type AppRoleCredentialProvider struct {
 	authObj           *secretsv1beta1.VaultAuth
@@ -59,6 +63,35 @@ func (l *AppRoleCredentialProvider) Init(ctx context.Context, client ctrlclient.
 	return nil
 }
 
+// Unwrap the AppRole Role's secretID, if the secret holds a response-wrapping
+// token of it. The secretID is written back to the secret, and the token is
+// removed, since it can only be unwrapped once.
+func (l *AppRoleCredentialProvider) Unwrap(ctx context.Context, client ctrlclient.Client, unwrap provider.UnwrapFunc) error {
+	logger := log.FromContext(ctx)
+	key := ctrlclient.ObjectKey{
+		Namespace: l.providerNamespace,
+		Name:      l.authObj.Spec.AppRole.SecretRef,
+	}
+	unwrapped, err := unwrapSecretKey(ctx, client, key, consts.ProviderSecretKeyAppRoleWrapped,
+		consts.ProviderSecretKeyAppRole, unwrap, func(data map[string]interface{}) (string, error) {
+			secretID, ok := data["secret_id"].(string)
+			if !ok || secretID == "" {
+				return "", fmt.Errorf("no secret_id found in the unwrapped data")
+			}
+			return secretID, nil
+		})
+	if err != nil {
+		logger.Error(err, "Failed to unwrap the secretID", "secret_name",
+			l.authObj.Spec.AppRole.SecretRef)
+		return err
+	}
+
+	if unwrapped {
+		logger.Info("Unwrapped the AppRole secretID", "secret_name", l.authObj.Spec.AppRole.SecretRef)
+	}
+	return nil
+}
+
 func (l *AppRoleCredentialProvider) GetCreds(ctx context.Context, client ctrlclient.Client) (map[string]interface{}, error) {
 	logger := log.FromContext(ctx)
 	// Fetch the AppRole Role's SecretID from the Kubernetes Secret each time there is a call to
@@ -0,0 +1,142 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package vault
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+)
+
+func TestAppRoleCredentialProvider_Unwrap(t *testing.T) {
+	tests := map[string]struct {
+		data         map[string][]byte
+		unwrapped    map[string]interface{}
+		unwrapErr    error
+		wantData     map[string][]byte
+		wantCalls    int
+		wantErr      string
+		wantSecretID string
+	}{
+		"wrapped": {
+			data: map[string][]byte{
+				"wrapped_id": []byte("hvs.wrapping"),
+			},
+			unwrapped: map[string]interface{}{
+				"secret_id":          "secret-id",
+				"secret_id_accessor": "accessor",
+			},
+			wantData: map[string][]byte{
+				"id": []byte("secret-id"),
+			},
+			wantCalls:    1,
+			wantSecretID: "secret-id",
+		},
+		"wrapped-replaces-id": {
+			data: map[string][]byte{
+				"id":         []byte("old-secret-id"),
+				"wrapped_id": []byte("hvs.wrapping"),
+			},
+			unwrapped: map[string]interface{}{
+				"secret_id": "secret-id",
+			},
+			wantData: map[string][]byte{
+				"id": []byte("secret-id"),
+			},
+			wantCalls:    1,
+			wantSecretID: "secret-id",
+		},
+		"not-wrapped": {
+			data: map[string][]byte{
+				"id": []byte("secret-id"),
+			},
+			wantData: map[string][]byte{
+				"id": []byte("secret-id"),
+			},
+			wantSecretID: "secret-id",
+		},
+		"unwrap-error": {
+			data: map[string][]byte{
+				"wrapped_id": []byte("hvs.wrapping"),
+			},
+			unwrapErr: errors.New("wrapping token is not valid or does not exist"),
+			wantData: map[string][]byte{
+				"wrapped_id": []byte("hvs.wrapping"),
+			},
+			wantCalls: 1,
+			wantErr:   "wrapping token is not valid or does not exist",
+		},
+		"no-secret-id": {
+			data: map[string][]byte{
+				"wrapped_id": []byte("hvs.wrapping"),
+			},
+			unwrapped: map[string]interface{}{
+				"foo": "bar",
+			},
+			wantData: map[string][]byte{
+				"wrapped_id": []byte("hvs.wrapping"),
+			},
+			wantCalls: 1,
+			wantErr:   "no secret_id found in the unwrapped data",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "approle",
+					Namespace: "foo",
+				},
+				Data: tt.data,
+			}
+			client := fake.NewClientBuilder().WithObjects(secret).Build()
+
+			p := &AppRoleCredentialProvider{}
+			require.NoError(t, p.Init(ctx, client, &secretsv1beta1.VaultAuth{
+				Spec: secretsv1beta1.VaultAuthSpec{
+					AppRole: &secretsv1beta1.VaultAuthConfigAppRole{
+						RoleID:    "role-id",
+						SecretRef: "approle",
+					},
+				},
+			}, "foo"))
+
+			var calls int
+			err := p.Unwrap(ctx, client, func(_ context.Context, token string) (map[string]interface{}, error) {
+				calls++
+				assert.Equal(t, "hvs.wrapping", token)
+				return tt.unwrapped, tt.unwrapErr
+			})
+			assert.Equal(t, tt.wantCalls, calls)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			var got corev1.Secret
+			require.NoError(t, client.Get(ctx, ctrlclient.ObjectKeyFromObject(secret), &got))
+			assert.Equal(t, tt.wantData, got.Data)
+
+			if tt.wantSecretID != "" {
+				creds, err := p.GetCreds(ctx, client)
+				require.NoError(t, err)
+				assert.Equal(t, map[string]interface{}{
+					"role_id":   "role-id",
+					"secret_id": tt.wantSecretID,
+				}, creds)
+			}
+		})
+	}
+}
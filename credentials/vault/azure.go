@@ -0,0 +1,310 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/common"
+	"github.com/hashicorp/vault-secrets-operator/helpers"
+)
+
+var _ CredentialProvider = (*AzureCredentialProvider)(nil)
+
+const (
+	AzureAnnotationClientID = "azure.workload.identity/client-id"
+	AzureAnnotationTenantID = "azure.workload.identity/tenant-id"
+	// AzureDefaultResource is the default resource of the Azure AD access
+	// token, it is the default of the Vault auth method as well.
+	AzureDefaultResource = "https://management.azure.com/"
+	// azureTokenExchangeAudience is the audience of the ServiceAccount token
+	// that is exchanged for an Azure AD access token with workload identity.
+	azureTokenExchangeAudience = "api://AzureADTokenExchange"
+)
+
+var (
+	// azureIMDSEndpoint and azureAuthorityHost can be replaced in tests.
+	azureIMDSEndpoint  = "http://169.254.169.254"
+	azureAuthorityHost = "https://login.microsoftonline.com"
+	azureHTTPClient    = &http.Client{Timeout: 30 * time.Second}
+)
+
+type AzureCredentialProvider struct {
+	authObj           *secretsv1beta1.VaultAuth
+	providerNamespace string
+	uid               types.UID
+}
+
+func (l *AzureCredentialProvider) GetNamespace() string {
+	return l.providerNamespace
+}
+
+func (l *AzureCredentialProvider) GetUID() types.UID {
+	return l.uid
+}
+
+func (l *AzureCredentialProvider) Init(ctx context.Context, client ctrlclient.Client, authObj *secretsv1beta1.VaultAuth, providerNamespace string) error {
+	if authObj.Spec.Azure == nil {
+		return fmt.Errorf("Azure auth method not configured")
+	}
+	if err := authObj.Spec.Azure.Validate(); err != nil {
+		return fmt.Errorf("invalid Azure auth configuration: %w", err)
+	}
+
+	l.authObj = authObj
+	l.providerNamespace = providerNamespace
+
+	if l.authObj.Spec.Azure.WorkloadIdentityServiceAccount == "" {
+		// the managed identity of the Operator's node will be used for
+		// credentials, and since it is a cluster-wide entity, just use the root
+		// CA UID
+		key := ctrlclient.ObjectKey{
+			Namespace: common.OperatorNamespace,
+			Name:      K8sRootCA,
+		}
+		kubeRootCA, err := helpers.GetConfigMap(ctx, client, key)
+		if err != nil {
+			return err
+		}
+		l.uid = kubeRootCA.UID
+		return nil
+	}
+
+	key := ctrlclient.ObjectKey{
+		Namespace: l.providerNamespace,
+		Name:      l.authObj.Spec.Azure.WorkloadIdentityServiceAccount,
+	}
+	workloadIdentitySA, err := helpers.GetServiceAccount(ctx, client, key)
+	if err != nil {
+		return err
+	}
+	l.uid = workloadIdentitySA.UID
+
+	return nil
+}
+
+func (l *AzureCredentialProvider) GetCreds(ctx context.Context, client ctrlclient.Client) (map[string]interface{}, error) {
+	cfg := l.authObj.Spec.Azure
+	resource := cfg.Resource
+	if resource == "" {
+		resource = AzureDefaultResource
+	}
+
+	var token string
+	var err error
+	if cfg.WorkloadIdentityServiceAccount == "" {
+		token, err = AzureMSIToken(ctx, resource, cfg.ClientID)
+	} else {
+		token, err = l.workloadIdentityToken(ctx, client, resource)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	loginData := map[string]any{
+		"role": cfg.Role,
+		"jwt":  token,
+	}
+
+	subscriptionID := cfg.SubscriptionID
+	resourceGroupName := cfg.ResourceGroupName
+	vmName := cfg.VMName
+	vmssName := cfg.VMSSName
+	// the node's details are only relevant when its managed identity is used,
+	// or when they are needed to fill in the required login fields.
+	if subscriptionID == "" || resourceGroupName == "" ||
+		(cfg.WorkloadIdentityServiceAccount == "" && vmName == "" && vmssName == "") {
+		compute, err := azureInstanceCompute(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if subscriptionID == "" {
+			subscriptionID = compute.SubscriptionID
+		}
+		if resourceGroupName == "" {
+			resourceGroupName = compute.ResourceGroupName
+		}
+		if cfg.WorkloadIdentityServiceAccount == "" && vmName == "" && vmssName == "" {
+			if compute.VMScaleSetName != "" {
+				vmssName = compute.VMScaleSetName
+			} else {
+				vmName = compute.Name
+			}
+		}
+	}
+
+	loginData["subscription_id"] = subscriptionID
+	loginData["resource_group_name"] = resourceGroupName
+	if vmName != "" {
+		loginData["vm_name"] = vmName
+	}
+	if vmssName != "" {
+		loginData["vmss_name"] = vmssName
+	}
+
+	return loginData, nil
+}
+
+// workloadIdentityToken exchanges a token of the workload identity
+// ServiceAccount for an Azure AD access token of resource.
+func (l *AzureCredentialProvider) workloadIdentityToken(ctx context.Context, client ctrlclient.Client, resource string) (string, error) {
+	cfg := l.authObj.Spec.Azure
+	key := ctrlclient.ObjectKey{
+		Namespace: l.providerNamespace,
+		Name:      cfg.WorkloadIdentityServiceAccount,
+	}
+	sa, err := helpers.GetServiceAccount(ctx, client, key)
+	if err != nil {
+		return "", err
+	}
+
+	clientID := cfg.ClientID
+	if clientID == "" {
+		clientID = sa.Annotations[AzureAnnotationClientID]
+	}
+	if clientID == "" {
+		return "", fmt.Errorf("workload identity service account %q is missing annotation %q",
+			sa.Name, AzureAnnotationClientID)
+	}
+	tenantID := cfg.TenantID
+	if tenantID == "" {
+		tenantID = sa.Annotations[AzureAnnotationTenantID]
+	}
+	if tenantID == "" {
+		return "", fmt.Errorf("the tenantID is not set, and workload identity service account %q "+
+			"is missing annotation %q", sa.Name, AzureAnnotationTenantID)
+	}
+
+	tokenReq, err := helpers.RequestSAToken(ctx, client, sa, 600, []string{azureTokenExchangeAudience})
+	if err != nil {
+		return "", fmt.Errorf("failed to get service account token: %w", err)
+	}
+
+	return AzureWorkloadIdentityToken(ctx, tenantID, clientID, resource, tokenReq.Status.Token)
+}
+
+// AzureWorkloadIdentityToken exchanges the federated assertion, a Kubernetes
+// ServiceAccount token, for an Azure AD access token of resource, with the
+// OAuth 2.0 client credentials flow of the Microsoft identity platform.
+func AzureWorkloadIdentityToken(ctx context.Context, tenantID, clientID, resource, assertion string) (string, error) {
+	form := url.Values{
+		"grant_type":            {"client_credentials"},
+		"client_id":             {clientID},
+		"client_assertion_type": {"urn:ietf:params:oauth:client-assertion-type:jwt-bearer"},
+		"client_assertion":      {assertion},
+		"scope":                 {strings.TrimSuffix(resource, "/") + "/.default"},
+	}
+	endpoint := fmt.Sprintf("%s/%s/oauth2/v2.0/token", azureAuthorityHost, url.PathEscape(tenantID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	token, err := azureAccessToken(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to exchange the service account token for an Azure AD token: %w", err)
+	}
+	return token, nil
+}
+
+// AzureMSIToken returns an Azure AD access token of resource for the managed
+// identity of the node, from the Azure Instance Metadata Service. The
+// system-assigned identity is used, unless clientID of a user-assigned one is
+// set.
+func AzureMSIToken(ctx context.Context, resource, clientID string) (string, error) {
+	values := url.Values{
+		"api-version": {"2018-02-01"},
+		"resource":    {resource},
+	}
+	if clientID != "" {
+		values.Set("client_id", clientID)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		azureIMDSEndpoint+"/metadata/identity/oauth2/token?"+values.Encode(), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Metadata", "true")
+
+	token, err := azureAccessToken(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch the managed identity token from the metadata service: %w", err)
+	}
+	return token, nil
+}
+
+// azureAccessToken sends req, and returns the access token of its response.
+func azureAccessToken(req *http.Request) (string, error) {
+	resp, err := azureHTTPClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", err
+	}
+	var body struct {
+		AccessToken      string `json:"access_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.Unmarshal(b, &body); err != nil {
+		return "", fmt.Errorf("invalid response, status=%d: %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("status=%d, error=%q, description=%q",
+			resp.StatusCode, body.Error, body.ErrorDescription)
+	}
+	if body.AccessToken == "" {
+		return "", fmt.Errorf("empty access token")
+	}
+	return body.AccessToken, nil
+}
+
+type azureCompute struct {
+	Name              string `json:"name"`
+	SubscriptionID    string `json:"subscriptionId"`
+	ResourceGroupName string `json:"resourceGroupName"`
+	VMScaleSetName    string `json:"vmScaleSetName"`
+}
+
+// azureInstanceCompute returns the compute details of the node from the Azure
+// Instance Metadata Service.
+func azureInstanceCompute(ctx context.Context) (*azureCompute, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		azureIMDSEndpoint+"/metadata/instance/compute?api-version=2021-02-01&format=json", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Metadata", "true")
+
+	resp, err := azureHTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the instance metadata: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch the instance metadata, status=%d", resp.StatusCode)
+	}
+
+	var compute azureCompute
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&compute); err != nil {
+		return nil, fmt.Errorf("failed to decode the instance metadata: %w", err)
+	}
+	return &compute, nil
+}
@@ -0,0 +1,127 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package vault
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/common"
+)
+
+// newAzureServer serves the Azure Instance Metadata Service and the token
+// endpoint of the Microsoft identity platform, for the duration of the test.
+func newAzureServer(t *testing.T) *[]*http.Request {
+	t.Helper()
+
+	var reqs []*http.Request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		require.NoError(t, req.ParseForm())
+		reqs = append(reqs, req)
+		switch req.URL.Path {
+		case "/metadata/identity/oauth2/token":
+			if req.Header.Get("Metadata") != "true" {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"error":"invalid_request","error_description":"Required metadata header not specified"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"access_token":"msi-token"}`))
+		case "/metadata/instance/compute":
+			_, _ = w.Write([]byte(`{"name":"aks-nodepool1-0","subscriptionId":"sub","resourceGroupName":"rg","vmScaleSetName":"aks-nodepool1"}`))
+		case "/tenant/oauth2/v2.0/token":
+			if req.PostForm.Get("client_assertion") != "sa-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"error":"invalid_client","error_description":"AADSTS700024"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"access_token":"wi-token"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	origIMDS, origAuthority := azureIMDSEndpoint, azureAuthorityHost
+	azureIMDSEndpoint, azureAuthorityHost = srv.URL, srv.URL
+	t.Cleanup(func() {
+		azureIMDSEndpoint, azureAuthorityHost = origIMDS, origAuthority
+	})
+	return &reqs
+}
+
+func TestAzureCredentialProvider_msi(t *testing.T) {
+	reqs := newAzureServer(t)
+
+	ctx := context.Background()
+	client := fake.NewClientBuilder().WithObjects(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      K8sRootCA,
+			Namespace: common.OperatorNamespace,
+			UID:       "root-ca-uid",
+		},
+	}).Build()
+
+	p := &AzureCredentialProvider{}
+	require.NoError(t, p.Init(ctx, client, &secretsv1beta1.VaultAuth{
+		Spec: secretsv1beta1.VaultAuthSpec{
+			Azure: &secretsv1beta1.VaultAuthConfigAzure{
+				Role:     "my-role",
+				ClientID: "user-assigned",
+			},
+		},
+	}, "foo"))
+	assert.Equal(t, types.UID("root-ca-uid"), p.GetUID())
+
+	creds, err := p.GetCreds(ctx, client)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"role":                "my-role",
+		"jwt":                 "msi-token",
+		"subscription_id":     "sub",
+		"resource_group_name": "rg",
+		"vmss_name":           "aks-nodepool1",
+	}, creds)
+	require.NotEmpty(t, *reqs)
+	assert.Equal(t, AzureDefaultResource, (*reqs)[0].Form.Get("resource"))
+	assert.Equal(t, "user-assigned", (*reqs)[0].Form.Get("client_id"))
+}
+
+func TestAzureWorkloadIdentityToken(t *testing.T) {
+	reqs := newAzureServer(t)
+
+	ctx := context.Background()
+	token, err := AzureWorkloadIdentityToken(ctx, "tenant", "client", "https://vault.example.com/", "sa-token")
+	require.NoError(t, err)
+	assert.Equal(t, "wi-token", token)
+	require.Len(t, *reqs, 1)
+	assert.Equal(t, "client", (*reqs)[0].PostForm.Get("client_id"))
+	assert.Equal(t, "https://vault.example.com/.default", (*reqs)[0].PostForm.Get("scope"))
+	assert.Equal(t, "client_credentials", (*reqs)[0].PostForm.Get("grant_type"))
+
+	_, err = AzureWorkloadIdentityToken(ctx, "tenant", "client", AzureDefaultResource, "other")
+	assert.EqualError(t, err, `failed to exchange the service account token for an Azure AD token: `+
+		`status=401, error="invalid_client", description="AADSTS700024"`)
+}
+
+func TestVaultAuthConfigAzure_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, (&secretsv1beta1.VaultAuthConfigAzure{Role: "role"}).Validate())
+	assert.EqualError(t, (&secretsv1beta1.VaultAuthConfigAzure{}).Validate(), "empty role")
+	assert.EqualError(t, (&secretsv1beta1.VaultAuthConfigAzure{
+		Role:     "role",
+		VMName:   "vm",
+		VMSSName: "vmss",
+	}).Validate(), "vmName and vmssName are mutually exclusive")
+}
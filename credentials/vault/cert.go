@@ -0,0 +1,181 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package vault
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/credentials/provider"
+	"github.com/hashicorp/vault-secrets-operator/helpers"
+)
+
+var (
+	_ CredentialProvider                 = (*CertCredentialProvider)(nil)
+	_ provider.ClientCertificateProvider = (*CertCredentialProvider)(nil)
+)
+
+const (
+	// CertPKISecretKeyCertificate and CertPKISecretKeyPrivateKey are the keys
+	// of the destination secret of a VaultPKISecret.
+	CertPKISecretKeyCertificate = "certificate"
+	CertPKISecretKeyPrivateKey  = "private_key"
+)
+
+type CertCredentialProvider struct {
+	authObj           *secretsv1beta1.VaultAuth
+	providerNamespace string
+	uid               types.UID
+
+	mu          sync.RWMutex
+	certificate *tls.Certificate
+	// checksum of the PEM data of the loaded certificate.
+	checksum []byte
+}
+
+func (l *CertCredentialProvider) GetNamespace() string {
+	return l.providerNamespace
+}
+
+func (l *CertCredentialProvider) GetUID() types.UID {
+	return l.uid
+}
+
+func (l *CertCredentialProvider) Init(ctx context.Context, client ctrlclient.Client, authObj *secretsv1beta1.VaultAuth, providerNamespace string) error {
+	if authObj.Spec.Cert == nil {
+		return fmt.Errorf("cert auth method not configured")
+	}
+	if err := authObj.Spec.Cert.Validate(); err != nil {
+		return fmt.Errorf("invalid cert auth configuration: %w", err)
+	}
+
+	l.authObj = authObj
+	l.providerNamespace = providerNamespace
+
+	// We use the UID of the secret which holds the client certificate for the
+	// provider UID, it is kept when the certificate is rotated.
+	secret, err := l.getSecret(ctx, client)
+	if err != nil {
+		return err
+	}
+	l.uid = secret.UID
+	return nil
+}
+
+// GetCreds loads the client certificate from the secret each time, so that the
+// login always uses its latest rotation. The certificate itself is presented
+// on the TLS connection of the login request, by the Vault client.
+func (l *CertCredentialProvider) GetCreds(ctx context.Context, client ctrlclient.Client) (map[string]interface{}, error) {
+	logger := log.FromContext(ctx)
+	secret, err := l.getSecret(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	certPEM, keyPEM, err := certificateData(secret)
+	if err != nil {
+		logger.Error(err, "Failed to get the client certificate from secret",
+			"secret_name", l.authObj.Spec.Cert.SecretRef)
+		return nil, err
+	}
+	certificate, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		err = fmt.Errorf("invalid client certificate in secret %q: %w", secret.Name, err)
+		logger.Error(err, "Failed to parse the client certificate", "secret_name",
+			l.authObj.Spec.Cert.SecretRef)
+		return nil, err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.certificate = &certificate
+	l.checksum = certificateChecksum(certPEM, keyPEM)
+
+	creds := map[string]interface{}{}
+	if l.authObj.Spec.Cert.Name != "" {
+		creds["name"] = l.authObj.Spec.Cert.Name
+	}
+	return creds, nil
+}
+
+func (l *CertCredentialProvider) ClientCertificate() *tls.Certificate {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.certificate
+}
+
+// ClientCertificateRotated returns true if the certificate in the secret
+// differs from the one that was loaded by the last call to GetCreds. It always
+// returns false if no certificate was loaded yet, e.g. when the Vault client
+// was restored from the client cache storage.
+func (l *CertCredentialProvider) ClientCertificateRotated(ctx context.Context, client ctrlclient.Client) (bool, error) {
+	l.mu.RLock()
+	checksum := l.checksum
+	l.mu.RUnlock()
+	if checksum == nil {
+		return false, nil
+	}
+
+	secret, err := l.getSecret(ctx, client)
+	if err != nil {
+		return false, err
+	}
+	certPEM, keyPEM, err := certificateData(secret)
+	if err != nil {
+		return false, err
+	}
+
+	return !bytes.Equal(checksum, certificateChecksum(certPEM, keyPEM)), nil
+}
+
+func (l *CertCredentialProvider) getSecret(ctx context.Context, client ctrlclient.Client) (*corev1.Secret, error) {
+	key := ctrlclient.ObjectKey{
+		Namespace: l.providerNamespace,
+		Name:      l.authObj.Spec.Cert.SecretRef,
+	}
+	secret, err := helpers.GetSecret(ctx, client, key)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to get secret", "secret_name",
+			l.authObj.Spec.Cert.SecretRef)
+		return nil, err
+	}
+	return secret, nil
+}
+
+// certificateData returns the PEM encoded certificate and private key from
+// secret, it supports both the kubernetes.io/tls keys, and those of a
+// VaultPKISecret's destination.
+func certificateData(secret *corev1.Secret) ([]byte, []byte, error) {
+	for _, keys := range [][2]string{
+		{corev1.TLSCertKey, corev1.TLSPrivateKeyKey},
+		{CertPKISecretKeyCertificate, CertPKISecretKeyPrivateKey},
+	} {
+		certPEM, keyPEM := secret.Data[keys[0]], secret.Data[keys[1]]
+		if len(certPEM) > 0 && len(keyPEM) > 0 {
+			return certPEM, keyPEM, nil
+		}
+	}
+
+	return nil, nil, fmt.Errorf("no client certificate found in secret %q, "+
+		"the keys %q and %q, or %q and %q are required", secret.Name,
+		corev1.TLSCertKey, corev1.TLSPrivateKeyKey,
+		CertPKISecretKeyCertificate, CertPKISecretKeyPrivateKey)
+}
+
+func certificateChecksum(certPEM, keyPEM []byte) []byte {
+	h := sha256.New()
+	h.Write(certPEM)
+	h.Write(keyPEM)
+	return h.Sum(nil)
+}
@@ -0,0 +1,138 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package vault
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+)
+
+// newTestCertificate returns a PEM encoded self-signed certificate, and its
+// private key.
+func newTestCertificate(t *testing.T, cn string) ([]byte, []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func TestCertCredentialProvider(t *testing.T) {
+	ctx := context.Background()
+	certPEM, keyPEM := newTestCertificate(t, "first")
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "client-cert",
+			Namespace: "foo",
+			UID:       "secret-uid",
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       certPEM,
+			corev1.TLSPrivateKeyKey: keyPEM,
+		},
+	}
+	client := fake.NewClientBuilder().WithObjects(secret).Build()
+
+	p := &CertCredentialProvider{}
+	require.NoError(t, p.Init(ctx, client, &secretsv1beta1.VaultAuth{
+		Spec: secretsv1beta1.VaultAuthSpec{
+			Cert: &secretsv1beta1.VaultAuthConfigCert{
+				Name:      "web",
+				SecretRef: "client-cert",
+			},
+		},
+	}, "foo"))
+	assert.Equal(t, types.UID("secret-uid"), p.GetUID())
+
+	// no certificate was loaded yet.
+	assert.Nil(t, p.ClientCertificate())
+	rotated, err := p.ClientCertificateRotated(ctx, client)
+	require.NoError(t, err)
+	assert.False(t, rotated)
+
+	creds, err := p.GetCreds(ctx, client)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"name": "web"}, creds)
+	require.NotNil(t, p.ClientCertificate())
+	leaf, err := x509.ParseCertificate(p.ClientCertificate().Certificate[0])
+	require.NoError(t, err)
+	assert.Equal(t, "first", leaf.Subject.CommonName)
+	rotated, err = p.ClientCertificateRotated(ctx, client)
+	require.NoError(t, err)
+	assert.False(t, rotated)
+
+	// the certificate is rotated by a VaultPKISecret.
+	certPEM, keyPEM = newTestCertificate(t, "second")
+	secret.Type = corev1.SecretTypeOpaque
+	secret.Data = map[string][]byte{
+		CertPKISecretKeyCertificate: certPEM,
+		CertPKISecretKeyPrivateKey:  keyPEM,
+	}
+	require.NoError(t, client.Update(ctx, secret))
+	rotated, err = p.ClientCertificateRotated(ctx, client)
+	require.NoError(t, err)
+	assert.True(t, rotated)
+
+	_, err = p.GetCreds(ctx, client)
+	require.NoError(t, err)
+	leaf, err = x509.ParseCertificate(p.ClientCertificate().Certificate[0])
+	require.NoError(t, err)
+	assert.Equal(t, "second", leaf.Subject.CommonName)
+	rotated, err = p.ClientCertificateRotated(ctx, client)
+	require.NoError(t, err)
+	assert.False(t, rotated)
+
+	// the private key does not match the certificate.
+	otherCertPEM, _ := newTestCertificate(t, "other")
+	secret.Data[CertPKISecretKeyCertificate] = otherCertPEM
+	require.NoError(t, client.Update(ctx, secret))
+	_, err = p.GetCreds(ctx, client)
+	assert.ErrorContains(t, err, `invalid client certificate in secret "client-cert"`)
+
+	secret.Data = map[string][]byte{
+		corev1.TLSCertKey: certPEM,
+	}
+	require.NoError(t, client.Update(ctx, secret))
+	_, err = p.GetCreds(ctx, client)
+	assert.EqualError(t, err, `no client certificate found in secret "client-cert", `+
+		`the keys "tls.crt" and "tls.key", or "certificate" and "private_key" are required`)
+}
+
+func TestVaultAuthConfigCert_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, (&secretsv1beta1.VaultAuthConfigCert{SecretRef: "client-cert"}).Validate())
+	assert.EqualError(t, (&secretsv1beta1.VaultAuthConfigCert{Name: "web"}).Validate(), "empty secretRef")
+}
@@ -11,4 +11,28 @@ const (
 	ProviderMethodAppRole    = "appRole"
 	ProviderMethodAWS        = "aws"
 	ProviderMethodGCP        = "gcp"
+	ProviderMethodAzure      = "azure"
+	ProviderMethodCert       = "cert"
+	ProviderMethodToken      = "token"
+	ProviderMethodLDAP       = "ldap"
+	ProviderMethodUserPass   = "userpass"
+	ProviderMethodOkta       = "okta"
 )
+
+// ProviderSecretKeyToken holds the Vault token of the token method.
+const ProviderSecretKeyToken = "token"
+
+// ProviderSecretKeyUsername and ProviderSecretKeyPassword hold the credentials
+// of the username and password methods: ldap, userpass, and okta.
+const (
+	ProviderSecretKeyUsername = "username"
+	ProviderSecretKeyPassword = "password"
+)
+
+// ProviderSecretKeyAppRoleWrapped holds a response-wrapping token of the
+// AppRole Role's secretID.
+const ProviderSecretKeyAppRoleWrapped = "wrapped_id"
+
+// ProviderSecretKeyTokenWrapped holds a response-wrapping token of the Vault
+// token of the token method.
+const ProviderSecretKeyTokenWrapped = "wrapped_token"
@@ -6,6 +6,7 @@ package vault
 import (
 	"context"
 	"fmt"
+	"net/url"
 
 	"cloud.google.com/go/compute/metadata"
 	"google.golang.org/api/iamcredentials/v1"
@@ -17,6 +18,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/common"
 	"github.com/hashicorp/vault-secrets-operator/helpers"
 )
 
@@ -24,6 +26,9 @@ var _ CredentialProvider = (*GCPCredentialProvider)(nil)
 
 const GCPAnnotationServiceAccount = "iam.gke.io/gcp-service-account"
 
+// gcpMetadataGet is the metadata server client, it can be replaced in tests.
+var gcpMetadataGet = metadata.GetWithContext
+
 type GCPCredentialProvider struct {
 	authObj           *secretsv1beta1.VaultAuth
 	providerNamespace string
@@ -49,6 +54,21 @@ func (l *GCPCredentialProvider) Init(ctx context.Context, client ctrlclient.Clie
 	l.authObj = authObj
 	l.providerNamespace = providerNamespace
 
+	if l.authObj.Spec.GCP.WorkloadIdentityServiceAccount == "" {
+		// the Operator's GCP service account will be used for credentials, and
+		// since it is a cluster-wide entity, just use the root CA UID
+		key := ctrlclient.ObjectKey{
+			Namespace: common.OperatorNamespace,
+			Name:      K8sRootCA,
+		}
+		kubeRootCA, err := helpers.GetConfigMap(ctx, client, key)
+		if err != nil {
+			return err
+		}
+		l.uid = kubeRootCA.UID
+		return nil
+	}
+
 	key := ctrlclient.ObjectKey{
 		Namespace: l.providerNamespace,
 		Name:      l.authObj.Spec.GCP.WorkloadIdentityServiceAccount,
@@ -65,6 +85,17 @@ func (l *GCPCredentialProvider) Init(ctx context.Context, client ctrlclient.Clie
 func (l *GCPCredentialProvider) GetCreds(ctx context.Context, client ctrlclient.Client) (map[string]interface{}, error) {
 	logger := log.FromContext(ctx)
 
+	if l.authObj.Spec.GCP.WorkloadIdentityServiceAccount == "" {
+		signedJwt, err := GCPMetadataIdentityToken(ctx, l.authObj.Spec.GCP.Role)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{
+			"role": l.authObj.Spec.GCP.Role,
+			"jwt":  signedJwt,
+		}, nil
+	}
+
 	var err error
 	gcpProject := l.authObj.Spec.GCP.ProjectID
 	if gcpProject == "" {
@@ -120,6 +151,25 @@ func (l *GCPCredentialProvider) GetCreds(ctx context.Context, client ctrlclient.
 	return loginData, nil
 }
 
+// GCPMetadataIdentityToken returns an identity token (signed jwt) of the
+// default service account of the GCE instance, or of the workload identity on
+// GKE, from the local metadata server. The full format includes the instance's
+// details, which are required by the Vault roles of type gce.
+func GCPMetadataIdentityToken(ctx context.Context, vaultRole string) (string, error) {
+	values := url.Values{
+		"audience": {fmt.Sprintf("http://vault/%s", vaultRole)},
+		"format":   {"full"},
+	}
+	token, err := gcpMetadataGet(ctx, "instance/service-accounts/default/identity?"+values.Encode())
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch the identity token from the metadata server: %w", err)
+	}
+	if token == "" {
+		return "", fmt.Errorf("empty identity token from the metadata server")
+	}
+	return token, nil
+}
+
 type GCPTokenExchangeConfig struct {
 	KSA            *corev1.ServiceAccount
 	GKEClusterName string
@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package vault
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/common"
+)
+
+func TestGCPCredentialProvider_metadata(t *testing.T) {
+	origGet := gcpMetadataGet
+	t.Cleanup(func() {
+		gcpMetadataGet = origGet
+	})
+
+	ctx := context.Background()
+	client := fake.NewClientBuilder().WithObjects(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      K8sRootCA,
+			Namespace: common.OperatorNamespace,
+			UID:       "root-ca-uid",
+		},
+	}).Build()
+
+	p := &GCPCredentialProvider{}
+	require.NoError(t, p.Init(ctx, client, &secretsv1beta1.VaultAuth{
+		Spec: secretsv1beta1.VaultAuthSpec{
+			GCP: &secretsv1beta1.VaultAuthConfigGCP{
+				Role: "my-role",
+			},
+		},
+	}, "foo"))
+	assert.Equal(t, types.UID("root-ca-uid"), p.GetUID())
+
+	var gotSuffix string
+	gcpMetadataGet = func(_ context.Context, suffix string) (string, error) {
+		gotSuffix = suffix
+		return "signed-jwt", nil
+	}
+	creds, err := p.GetCreds(ctx, client)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"role": "my-role",
+		"jwt":  "signed-jwt",
+	}, creds)
+	assert.Equal(t, "instance/service-accounts/default/identity?"+
+		"audience=http%3A%2F%2Fvault%2Fmy-role&format=full", gotSuffix)
+
+	gcpMetadataGet = func(_ context.Context, _ string) (string, error) {
+		return "", errors.New("not on GCE")
+	}
+	_, err = p.GetCreds(ctx, client)
+	assert.EqualError(t, err, "failed to fetch the identity token from the metadata server: not on GCE")
+}
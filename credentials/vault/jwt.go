@@ -25,6 +25,7 @@ type JWTCredentialProvider struct {
 	providerNamespace string
 	tokenSecret       *corev1.Secret
 	uid               types.UID
+	tokenSource       saTokenSource
 }
 
 func (l *JWTCredentialProvider) GetNamespace() string {
@@ -94,7 +95,7 @@ func (l *JWTCredentialProvider) GetCreds(ctx context.Context, client ctrlclient.
 			return nil, err
 		}
 
-		tr, err := helpers.RequestSAToken(ctx, client, sa, l.authObj.Spec.JWT.TokenExpirationSeconds, l.authObj.Spec.JWT.TokenAudiences)
+		token, err := l.tokenSource.Token(ctx, client, sa, l.authObj.Spec.JWT.TokenExpirationSeconds, l.authObj.Spec.JWT.TokenAudiences)
 		if err != nil {
 			logger.Error(err, "Failed to get service account token")
 			return nil, err
@@ -103,7 +104,7 @@ func (l *JWTCredentialProvider) GetCreds(ctx context.Context, client ctrlclient.
 		// credentials needed for JWT auth
 		return map[string]interface{}{
 			"role": l.authObj.Spec.JWT.Role,
-			"jwt":  tr.Status.Token,
+			"jwt":  token,
 		}, nil
 	}
 
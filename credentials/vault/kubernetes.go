@@ -13,7 +13,6 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
-	"github.com/hashicorp/vault-secrets-operator/helpers"
 )
 
 var _ CredentialProvider = (*KubernetesCredentialProvider)(nil)
@@ -22,15 +21,16 @@ type KubernetesCredentialProvider struct {
 	authObj           *secretsv1beta1.VaultAuth
 	providerNamespace string
 	uid               types.UID
+	tokenSource       saTokenSource
 }
 
 func NewKubernetesCredentialProvider(authObj *secretsv1beta1.VaultAuth, providerNamespace string,
 	uid types.UID,
 ) *KubernetesCredentialProvider {
 	return &KubernetesCredentialProvider{
-		authObj,
-		providerNamespace,
-		uid,
+		authObj:           authObj,
+		providerNamespace: providerNamespace,
+		uid:               uid,
 	}
 }
 
@@ -84,7 +84,7 @@ func (l *KubernetesCredentialProvider) GetCreds(ctx context.Context, client ctrl
 		return nil, err
 	}
 
-	tr, err := helpers.RequestSAToken(ctx, client, sa, l.authObj.Spec.Kubernetes.TokenExpirationSeconds, l.authObj.Spec.Kubernetes.TokenAudiences)
+	token, err := l.tokenSource.Token(ctx, client, sa, l.authObj.Spec.Kubernetes.TokenExpirationSeconds, l.authObj.Spec.Kubernetes.TokenAudiences)
 	if err != nil {
 		logger.Error(err, "Failed to get service account token")
 		return nil, err
@@ -93,6 +93,6 @@ func (l *KubernetesCredentialProvider) GetCreds(ctx context.Context, client ctrl
 	// credentials needed for Kubernetes auth
 	return map[string]interface{}{
 		"role": l.authObj.Spec.Kubernetes.Role,
-		"jwt":  tr.Status.Token,
+		"jwt":  token,
 	}, nil
 }
@@ -0,0 +1,76 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package vault
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/hashicorp/vault-secrets-operator/helpers"
+)
+
+// saTokenRefreshRatio is the ratio of the lifetime of a ServiceAccount token
+// after which a new one is minted, the same ratio is used by the kubelet for
+// projected ServiceAccount tokens.
+const saTokenRefreshRatio = 0.8
+
+// saTokenSource mints ServiceAccount tokens with the TokenRequest API, rather
+// than relying on long-lived ServiceAccount token Secrets. A minted token is
+// reused for the following logins, until most of its lifetime has elapsed,
+// after which a new one is minted.
+type saTokenSource struct {
+	mu                sync.Mutex
+	token             string
+	uid               types.UID
+	expirationSeconds int64
+	audiences         []string
+	refreshAt         time.Time
+	// now is only set in tests.
+	now func() time.Time
+}
+
+// Token returns a token for sa, with the given expirationSeconds and
+// audiences. A new token is minted if none was minted yet for the same
+// parameters, or if the last one is about to expire.
+func (s *saTokenSource) Token(ctx context.Context, client ctrlclient.Client, sa *corev1.ServiceAccount, expirationSeconds int64, audiences []string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now
+	if s.now != nil {
+		now = s.now
+	}
+
+	if s.token != "" && s.uid == sa.UID && s.expirationSeconds == expirationSeconds &&
+		slices.Equal(s.audiences, audiences) && now().Before(s.refreshAt) {
+		return s.token, nil
+	}
+
+	issued := now()
+	tr, err := helpers.RequestSAToken(ctx, client, sa, expirationSeconds, audiences)
+	if err != nil {
+		return "", err
+	}
+
+	lifetime := tr.Status.ExpirationTimestamp.Sub(issued)
+	if lifetime <= 0 {
+		// never reuse a token that has no, or an invalid expiration.
+		s.token = ""
+		return tr.Status.Token, nil
+	}
+
+	s.token = tr.Status.Token
+	s.uid = sa.UID
+	s.expirationSeconds = expirationSeconds
+	s.audiences = slices.Clone(audiences)
+	s.refreshAt = issued.Add(time.Duration(float64(lifetime) * saTokenRefreshRatio))
+
+	return s.token, nil
+}
@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package vault
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/hashicorp/vault-secrets-operator/internal/testutils"
+)
+
+func Test_saTokenSource_Token(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	var minted int
+	client := testutils.NewFakeClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+		SubResourceCreate: func(ctx context.Context, client ctrlclient.Client, subResourceName string, obj ctrlclient.Object, subResource ctrlclient.Object, opts ...ctrlclient.SubResourceCreateOption) error {
+			minted++
+			tr := subResource.(*authv1.TokenRequest)
+			tr.Status.Token = fmt.Sprintf("%s-%d", tr.Spec.Audiences[0], minted)
+			tr.Status.ExpirationTimestamp = metav1.NewTime(now.Add(
+				time.Duration(*tr.Spec.ExpirationSeconds) * time.Second))
+			return nil
+		},
+	}).Build()
+
+	sa := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "tenant",
+			Name:      "app",
+			UID:       "sa-uid",
+		},
+	}
+
+	s := &saTokenSource{
+		now: func() time.Time {
+			return now
+		},
+	}
+	token, err := s.Token(ctx, client, sa, 600, []string{"vault"})
+	require.NoError(t, err)
+	assert.Equal(t, "vault-1", token)
+
+	// reused until 80% of its lifetime has elapsed.
+	now = now.Add(479 * time.Second)
+	token, err = s.Token(ctx, client, sa, 600, []string{"vault"})
+	require.NoError(t, err)
+	assert.Equal(t, "vault-1", token)
+
+	now = now.Add(time.Second)
+	token, err = s.Token(ctx, client, sa, 600, []string{"vault"})
+	require.NoError(t, err)
+	assert.Equal(t, "vault-2", token)
+
+	// minted again when the parameters change.
+	token, err = s.Token(ctx, client, sa, 600, []string{"other"})
+	require.NoError(t, err)
+	assert.Equal(t, "other-3", token)
+
+	sa.UID = "new-sa-uid"
+	token, err = s.Token(ctx, client, sa, 600, []string{"other"})
+	require.NoError(t, err)
+	assert.Equal(t, "other-4", token)
+	assert.Equal(t, 4, minted)
+}
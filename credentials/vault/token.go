@@ -0,0 +1,173 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package vault
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/credentials/provider"
+	"github.com/hashicorp/vault-secrets-operator/credentials/vault/consts"
+	"github.com/hashicorp/vault-secrets-operator/helpers"
+)
+
+var (
+	_ CredentialProvider                    = (*TokenCredentialProvider)(nil)
+	_ provider.StaticTokenProvider          = (*TokenCredentialProvider)(nil)
+	_ provider.UnwrappingCredentialProvider = (*TokenCredentialProvider)(nil)
+)
+
+type TokenCredentialProvider struct {
+	authObj           *secretsv1beta1.VaultAuth
+	providerNamespace string
+	uid               types.UID
+
+	mu    sync.RWMutex
+	token string
+}
+
+func (l *TokenCredentialProvider) GetNamespace() string {
+	return l.providerNamespace
+}
+
+func (l *TokenCredentialProvider) GetUID() types.UID {
+	return l.uid
+}
+
+func (l *TokenCredentialProvider) Init(ctx context.Context, client ctrlclient.Client, authObj *secretsv1beta1.VaultAuth, providerNamespace string) error {
+	if authObj.Spec.Token == nil {
+		return fmt.Errorf("token auth method not configured")
+	}
+	if err := authObj.Spec.Token.Validate(); err != nil {
+		return fmt.Errorf("invalid token auth configuration: %w", err)
+	}
+
+	l.authObj = authObj
+	l.providerNamespace = providerNamespace
+
+	// We use the UID of the secret which holds the token for the provider UID,
+	// it is kept when the token is replaced.
+	secret, err := l.getSecret(ctx, client)
+	if err != nil {
+		return err
+	}
+	l.uid = secret.UID
+	return nil
+}
+
+// Unwrap the Vault token, if the secret holds a response-wrapping token of it.
+// The token is written back to the secret, and the response-wrapping token is
+// removed, since it can only be unwrapped once. Both wrapped tokens, e.g. from
+// `vault token create -wrap-ttl=1h`, and wrapped data holding a token, e.g.
+// from `vault write sys/wrapping/wrap token=...`, are supported.
+func (l *TokenCredentialProvider) Unwrap(ctx context.Context, client ctrlclient.Client, unwrap provider.UnwrapFunc) error {
+	logger := log.FromContext(ctx)
+	key := ctrlclient.ObjectKey{
+		Namespace: l.providerNamespace,
+		Name:      l.authObj.Spec.Token.SecretRef,
+	}
+	unwrapped, err := unwrapSecretKey(ctx, client, key, consts.ProviderSecretKeyTokenWrapped,
+		consts.ProviderSecretKeyToken, unwrap, func(data map[string]interface{}) (string, error) {
+			for _, k := range []string{"client_token", "token"} {
+				if token, ok := data[k].(string); ok && token != "" {
+					return token, nil
+				}
+			}
+			return "", fmt.Errorf("no token found in the unwrapped data")
+		})
+	if err != nil {
+		logger.Error(err, "Failed to unwrap the token", "secret_name",
+			l.authObj.Spec.Token.SecretRef)
+		return err
+	}
+
+	if unwrapped {
+		logger.Info("Unwrapped the Vault token", "secret_name", l.authObj.Spec.Token.SecretRef)
+	}
+	return nil
+}
+
+// GetCreds loads the token from the secret each time, so that the latest one
+// is always used.
+func (l *TokenCredentialProvider) GetCreds(ctx context.Context, client ctrlclient.Client) (map[string]interface{}, error) {
+	secret, err := l.getSecret(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := tokenData(secret)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to get the token from secret",
+			"secret_name", l.authObj.Spec.Token.SecretRef)
+		return nil, err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.token = token
+
+	return map[string]interface{}{
+		consts.ProviderSecretKeyToken: token,
+	}, nil
+}
+
+func (l *TokenCredentialProvider) StaticToken() string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.token
+}
+
+// StaticTokenRotated returns true if the token in the secret differs from the
+// one that was loaded by the last call to GetCreds. It always returns false if
+// no token was loaded yet, e.g. when the Vault client was restored from the
+// client cache storage.
+func (l *TokenCredentialProvider) StaticTokenRotated(ctx context.Context, client ctrlclient.Client) (bool, error) {
+	l.mu.RLock()
+	cur := l.token
+	l.mu.RUnlock()
+	if cur == "" {
+		return false, nil
+	}
+
+	secret, err := l.getSecret(ctx, client)
+	if err != nil {
+		return false, err
+	}
+	token, err := tokenData(secret)
+	if err != nil {
+		return false, err
+	}
+
+	return token != cur, nil
+}
+
+func (l *TokenCredentialProvider) getSecret(ctx context.Context, client ctrlclient.Client) (*corev1.Secret, error) {
+	key := ctrlclient.ObjectKey{
+		Namespace: l.providerNamespace,
+		Name:      l.authObj.Spec.Token.SecretRef,
+	}
+	secret, err := helpers.GetSecret(ctx, client, key)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to get secret", "secret_name",
+			l.authObj.Spec.Token.SecretRef)
+		return nil, err
+	}
+	return secret, nil
+}
+
+func tokenData(secret *corev1.Secret) (string, error) {
+	token := strings.TrimSpace(string(secret.Data[consts.ProviderSecretKeyToken]))
+	if token == "" {
+		return "", fmt.Errorf("no key %q found in secret %q", consts.ProviderSecretKeyToken, secret.Name)
+	}
+	return token, nil
+}
@@ -0,0 +1,176 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package vault
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+)
+
+func TestTokenCredentialProvider(t *testing.T) {
+	ctx := context.Background()
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "vault-token",
+			Namespace: "foo",
+			UID:       "secret-uid",
+		},
+		Data: map[string][]byte{
+			"token": []byte("hvs.first\n"),
+		},
+	}
+	client := fake.NewClientBuilder().WithObjects(secret).Build()
+
+	p := &TokenCredentialProvider{}
+	require.NoError(t, p.Init(ctx, client, &secretsv1beta1.VaultAuth{
+		Spec: secretsv1beta1.VaultAuthSpec{
+			Token: &secretsv1beta1.VaultAuthConfigToken{
+				SecretRef: "vault-token",
+			},
+		},
+	}, "foo"))
+	assert.Equal(t, types.UID("secret-uid"), p.GetUID())
+
+	// nothing was loaded yet.
+	rotated, err := p.StaticTokenRotated(ctx, client)
+	require.NoError(t, err)
+	assert.False(t, rotated)
+
+	creds, err := p.GetCreds(ctx, client)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"token": "hvs.first"}, creds)
+	assert.Equal(t, "hvs.first", p.StaticToken())
+
+	rotated, err = p.StaticTokenRotated(ctx, client)
+	require.NoError(t, err)
+	assert.False(t, rotated)
+
+	secret.Data["token"] = []byte("hvs.second")
+	require.NoError(t, client.Update(ctx, secret))
+	rotated, err = p.StaticTokenRotated(ctx, client)
+	require.NoError(t, err)
+	assert.True(t, rotated)
+
+	secret.Data = map[string][]byte{"other": []byte("hvs.third")}
+	require.NoError(t, client.Update(ctx, secret))
+	_, err = p.GetCreds(ctx, client)
+	assert.EqualError(t, err, `no key "token" found in secret "vault-token"`)
+}
+
+func TestTokenCredentialProvider_Unwrap(t *testing.T) {
+	tests := map[string]struct {
+		unwrapped map[string]interface{}
+		wantData  map[string][]byte
+		wantErr   string
+	}{
+		"wrapped-auth": {
+			unwrapped: map[string]interface{}{
+				"client_token": "hvs.token",
+			},
+			wantData: map[string][]byte{
+				"token": []byte("hvs.token"),
+			},
+		},
+		"wrapped-data": {
+			unwrapped: map[string]interface{}{
+				"token": "hvs.token",
+			},
+			wantData: map[string][]byte{
+				"token": []byte("hvs.token"),
+			},
+		},
+		"no-token": {
+			unwrapped: map[string]interface{}{
+				"foo": "bar",
+			},
+			wantData: map[string][]byte{
+				"wrapped_token": []byte("hvs.wrapping"),
+			},
+			wantErr: "no token found in the unwrapped data",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "vault-token",
+					Namespace: "foo",
+				},
+				Data: map[string][]byte{
+					"wrapped_token": []byte("hvs.wrapping"),
+				},
+			}
+			client := fake.NewClientBuilder().WithObjects(secret).Build()
+
+			p := &TokenCredentialProvider{}
+			require.NoError(t, p.Init(ctx, client, &secretsv1beta1.VaultAuth{
+				Spec: secretsv1beta1.VaultAuthSpec{
+					Token: &secretsv1beta1.VaultAuthConfigToken{
+						SecretRef: "vault-token",
+					},
+				},
+			}, "foo"))
+
+			err := p.Unwrap(ctx, client, func(_ context.Context, token string) (map[string]interface{}, error) {
+				assert.Equal(t, "hvs.wrapping", token)
+				return tt.unwrapped, nil
+			})
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			var got corev1.Secret
+			require.NoError(t, client.Get(ctx, ctrlclient.ObjectKeyFromObject(secret), &got))
+			assert.Equal(t, tt.wantData, got.Data)
+		})
+	}
+}
+
+func Test_unwrapSecretKey_concurrent(t *testing.T) {
+	ctx := context.Background()
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "vault-token",
+			Namespace: "foo",
+		},
+		Data: map[string][]byte{
+			"wrapped_token": []byte("hvs.wrapping"),
+		},
+	}
+	client := fake.NewClientBuilder().WithObjects(secret).Build()
+	key := ctrlclient.ObjectKeyFromObject(secret)
+
+	// the token is unwrapped by another client while this one tries to.
+	unwrapped, err := unwrapSecretKey(ctx, client, key, "wrapped_token", "token",
+		func(ctx context.Context, _ string) (map[string]interface{}, error) {
+			secret.Data = map[string][]byte{"token": []byte("hvs.token")}
+			require.NoError(t, client.Update(ctx, secret))
+			return nil, errors.New("wrapping token is not valid or does not exist")
+		}, func(map[string]interface{}) (string, error) {
+			return "", errors.New("unexpected")
+		})
+	require.NoError(t, err)
+	assert.False(t, unwrapped)
+}
+
+func TestVaultAuthConfigToken_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, (&secretsv1beta1.VaultAuthConfigToken{SecretRef: "foo"}).Validate())
+	assert.EqualError(t, (&secretsv1beta1.VaultAuthConfigToken{}).Validate(), "empty secretRef")
+}
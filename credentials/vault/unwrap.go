@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package vault
+
+import (
+	"context"
+
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/hashicorp/vault-secrets-operator/credentials/provider"
+	"github.com/hashicorp/vault-secrets-operator/helpers"
+)
+
+// unwrapSecretKey unwraps the response-wrapping token in the wrappedKey of the
+// secret, if any. The value that is returned by extract from the unwrapped data
+// is written to the targetKey of the secret, and the wrappedKey is removed,
+// since the token can only be unwrapped once. It returns true if the secret was
+// updated.
+//
+// When the unwrapping fails because the token was concurrently unwrapped, e.g.
+// by the Vault client of another consumer of the secret, the secret is
+// reloaded, and no error is returned if the wrappedKey has been removed since.
+func unwrapSecretKey(ctx context.Context, client ctrlclient.Client, key ctrlclient.ObjectKey,
+	wrappedKey, targetKey string, unwrap provider.UnwrapFunc, extract func(map[string]interface{}) (string, error),
+) (bool, error) {
+	logger := log.FromContext(ctx).WithValues("secret_name", key.Name)
+	secret, err := helpers.GetSecret(ctx, client, key)
+	if err != nil {
+		return false, err
+	}
+
+	token := secret.Data[wrappedKey]
+	if len(token) == 0 {
+		return false, nil
+	}
+
+	data, err := unwrap(ctx, string(token))
+	if err != nil {
+		if cur, getErr := helpers.GetSecret(ctx, client, key); getErr == nil &&
+			len(cur.Data[wrappedKey]) == 0 && len(cur.Data[targetKey]) > 0 {
+			logger.V(1).Info("The response-wrapping token was unwrapped concurrently")
+			return false, nil
+		}
+		return false, err
+	}
+
+	value, err := extract(data)
+	if err != nil {
+		return false, err
+	}
+
+	patch := ctrlclient.MergeFrom(secret.DeepCopy())
+	secret.Data[targetKey] = []byte(value)
+	delete(secret.Data, wrappedKey)
+	if err := client.Patch(ctx, secret, patch); err != nil {
+		logger.Error(err, "Failed to write the unwrapped value", "key", targetKey)
+		return false, err
+	}
+
+	return true, nil
+}
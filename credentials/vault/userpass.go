@@ -0,0 +1,143 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package vault
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/credentials/provider"
+	"github.com/hashicorp/vault-secrets-operator/credentials/vault/consts"
+	"github.com/hashicorp/vault-secrets-operator/helpers"
+)
+
+var (
+	_ CredentialProvider         = (*UserPassCredentialProvider)(nil)
+	_ provider.LoginPathProvider = (*UserPassCredentialProvider)(nil)
+)
+
+// UserPassCredentialProvider provides the credentials of the username and
+// password methods: ldap, userpass, and okta. They all log in at
+// auth/<mount>/login/<username>, with the password.
+type UserPassCredentialProvider struct {
+	authObj           *secretsv1beta1.VaultAuth
+	providerNamespace string
+	uid               types.UID
+	config            *secretsv1beta1.VaultAuthConfigUserPass
+
+	mu       sync.RWMutex
+	username string
+}
+
+func (l *UserPassCredentialProvider) GetNamespace() string {
+	return l.providerNamespace
+}
+
+func (l *UserPassCredentialProvider) GetUID() types.UID {
+	return l.uid
+}
+
+func (l *UserPassCredentialProvider) Init(ctx context.Context, client ctrlclient.Client, authObj *secretsv1beta1.VaultAuth, providerNamespace string) error {
+	switch authObj.Spec.Method {
+	case consts.ProviderMethodLDAP:
+		l.config = authObj.Spec.LDAP
+	case consts.ProviderMethodUserPass:
+		l.config = authObj.Spec.UserPass
+	case consts.ProviderMethodOkta:
+		l.config = authObj.Spec.Okta
+	default:
+		return fmt.Errorf("unsupported username and password auth method %q", authObj.Spec.Method)
+	}
+	if l.config == nil {
+		return fmt.Errorf("%s auth method not configured", authObj.Spec.Method)
+	}
+	if err := l.config.Validate(); err != nil {
+		return fmt.Errorf("invalid %s auth configuration: %w", authObj.Spec.Method, err)
+	}
+
+	l.authObj = authObj
+	l.providerNamespace = providerNamespace
+
+	// We use the UID of the secret which holds the password for the provider
+	// UID.
+	secret, err := l.getSecret(ctx, client)
+	if err != nil {
+		return err
+	}
+	l.uid = secret.UID
+	return nil
+}
+
+func (l *UserPassCredentialProvider) GetCreds(ctx context.Context, client ctrlclient.Client) (map[string]interface{}, error) {
+	logger := log.FromContext(ctx)
+	secret, err := l.getSecret(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	username, password, err := l.credentials(secret)
+	if err != nil {
+		logger.Error(err, "Failed to get the credentials from secret",
+			"secret_name", l.config.SecretRef)
+		return nil, err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.username = username
+
+	return map[string]interface{}{
+		consts.ProviderSecretKeyPassword: password,
+	}, nil
+}
+
+// LoginPath returns the login path of the username that was loaded by the
+// last call to GetCreds.
+func (l *UserPassCredentialProvider) LoginPath() string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return "login/" + url.PathEscape(l.username)
+}
+
+func (l *UserPassCredentialProvider) credentials(secret *corev1.Secret) (string, string, error) {
+	username := l.config.Username
+	if username == "" {
+		username = strings.TrimSpace(string(secret.Data[consts.ProviderSecretKeyUsername]))
+	}
+	if username == "" {
+		return "", "", fmt.Errorf("no username set, and no key %q found in secret %q",
+			consts.ProviderSecretKeyUsername, secret.Name)
+	}
+
+	password := string(secret.Data[consts.ProviderSecretKeyPassword])
+	if password == "" {
+		return "", "", fmt.Errorf("no key %q found in secret %q",
+			consts.ProviderSecretKeyPassword, secret.Name)
+	}
+
+	return username, password, nil
+}
+
+func (l *UserPassCredentialProvider) getSecret(ctx context.Context, client ctrlclient.Client) (*corev1.Secret, error) {
+	key := ctrlclient.ObjectKey{
+		Namespace: l.providerNamespace,
+		Name:      l.config.SecretRef,
+	}
+	secret, err := helpers.GetSecret(ctx, client, key)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to get secret", "secret_name",
+			l.config.SecretRef)
+		return nil, err
+	}
+	return secret, nil
+}
@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package vault
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+)
+
+func TestUserPassCredentialProvider(t *testing.T) {
+	ctx := context.Background()
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "creds",
+			Namespace: "foo",
+			UID:       "secret-uid",
+		},
+		Data: map[string][]byte{
+			"username": []byte("alice"),
+			"password": []byte("s3cr3t"),
+		},
+	}
+	client := fake.NewClientBuilder().WithObjects(secret).Build()
+
+	tests := []struct {
+		name          string
+		spec          secretsv1beta1.VaultAuthSpec
+		wantLoginPath string
+		wantErr       string
+	}{
+		{
+			name: "ldap-username-from-secret",
+			spec: secretsv1beta1.VaultAuthSpec{
+				Method: "ldap",
+				LDAP:   &secretsv1beta1.VaultAuthConfigUserPass{SecretRef: "creds"},
+			},
+			wantLoginPath: "login/alice",
+		},
+		{
+			name: "userpass-username-from-spec",
+			spec: secretsv1beta1.VaultAuthSpec{
+				Method: "userpass",
+				UserPass: &secretsv1beta1.VaultAuthConfigUserPass{
+					SecretRef: "creds",
+					Username:  "bob@example.com",
+				},
+			},
+			wantLoginPath: "login/bob@example.com",
+		},
+		{
+			name: "okta-not-configured",
+			spec: secretsv1beta1.VaultAuthSpec{
+				Method: "okta",
+				LDAP:   &secretsv1beta1.VaultAuthConfigUserPass{SecretRef: "creds"},
+			},
+			wantErr: "okta auth method not configured",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &UserPassCredentialProvider{}
+			err := p.Init(ctx, client, &secretsv1beta1.VaultAuth{Spec: tt.spec}, "foo")
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, types.UID("secret-uid"), p.GetUID())
+
+			creds, err := p.GetCreds(ctx, client)
+			require.NoError(t, err)
+			assert.Equal(t, map[string]any{"password": "s3cr3t"}, creds)
+			assert.Equal(t, tt.wantLoginPath, p.LoginPath())
+		})
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package helpers
+
+import (
+	"encoding/base64"
+	"fmt"
+	"maps"
+)
+
+// decodeBase64Fields returns data with the values of the
+// SecretTransformationOption's Base64Decode fields decoded. The fields that are
+// not in data are ignored, an error is returned if any value is not a valid
+// base64 encoded string.
+func decodeBase64Fields[V any](opt *SecretTransformationOption, data map[string]V) (map[string]V, error) {
+	if opt == nil || len(opt.Base64Decode) == 0 {
+		return data, nil
+	}
+
+	m := maps.Clone(data)
+	for _, k := range opt.Base64Decode {
+		v, ok := data[k]
+		if !ok {
+			continue
+		}
+
+		s, ok := any(v).(string)
+		if !ok {
+			return nil, fmt.Errorf("base64Decode field %q is not a string", k)
+		}
+		b, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return nil, fmt.Errorf("base64Decode field %q is not valid base64: %w", k, err)
+		}
+
+		value, ok := any(b).(V)
+		if !ok {
+			return nil, fmt.Errorf("unsupported value type %T of %q", v, k)
+		}
+		m[k] = value
+	}
+
+	return m, nil
+}
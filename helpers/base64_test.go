@@ -0,0 +1,91 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package helpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_decodeBase64Fields(t *testing.T) {
+	t.Parallel()
+
+	data := map[string]any{
+		"keystore": "AAEC/w==",
+		"password": "s3cr3t",
+		"port":     float64(5432),
+	}
+	tests := []struct {
+		name         string
+		base64Decode []string
+		want         map[string]any
+		wantErr      assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "no-fields",
+			want:    data,
+			wantErr: assert.NoError,
+		},
+		{
+			name:         "decoded",
+			base64Decode: []string{"keystore", "unknown"},
+			want: map[string]any{
+				"keystore": []byte{0x00, 0x01, 0x02, 0xff},
+				"password": "s3cr3t",
+				"port":     float64(5432),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:         "invalid-base64",
+			base64Decode: []string{"password"},
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.ErrorContains(t, err, `base64Decode field "password" is not valid base64`, i...)
+			},
+		},
+		{
+			name:         "not-a-string",
+			base64Decode: []string{"port"},
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.EqualError(t, err, `base64Decode field "port" is not a string`, i...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := decodeBase64Fields(&SecretTransformationOption{
+				Base64Decode: tt.base64Decode,
+			}, data)
+			if !tt.wantErr(t, err) {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSecretDataBuilder_WithVaultData_base64Decode(t *testing.T) {
+	t.Parallel()
+
+	d := map[string]any{
+		"keystore": "AAEC/w==",
+		"password": "s3cr3t",
+	}
+	got, err := NewSecretsDataBuilder().WithVaultData(d, d, &SecretTransformationOption{
+		ExcludeRaw:   true,
+		Base64Decode: []string{"keystore"},
+		KeyMap: map[string]string{
+			"keystore": "keystore.p12",
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"keystore.p12": {0x00, 0x01, 0x02, 0xff},
+		"password":     []byte("s3cr3t"),
+	}, got)
+}
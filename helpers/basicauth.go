@@ -0,0 +1,47 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package helpers
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// addBasicAuth adds the "username" and "password" keys that are mapped by the
+// SecretTransformationOption's BasicAuth to data, which typically holds the
+// rendered templates.
+func addBasicAuth(opt *SecretTransformationOption, d map[string]any, data map[string][]byte) error {
+	if opt.BasicAuth == nil {
+		return nil
+	}
+
+	usernameKey := opt.BasicAuth.UsernameKey
+	if usernameKey == "" {
+		usernameKey = defaultUsernameKey
+	}
+	username, err := sourceField(d, "basicAuth", usernameKey, true)
+	if err != nil {
+		return err
+	}
+
+	passwordKey := opt.BasicAuth.PasswordKey
+	if passwordKey == "" {
+		passwordKey = defaultPasswordKey
+	}
+	password, err := sourceField(d, "basicAuth", passwordKey, true)
+	if err != nil {
+		return err
+	}
+
+	for _, k := range []string{corev1.BasicAuthUsernameKey, corev1.BasicAuthPasswordKey} {
+		if _, ok := data[k]; ok {
+			return fmt.Errorf("key %q from basicAuth conflicts with a template", k)
+		}
+	}
+	data[corev1.BasicAuthUsernameKey] = []byte(username)
+	data[corev1.BasicAuthPasswordKey] = []byte(password)
+
+	return nil
+}
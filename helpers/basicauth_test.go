@@ -0,0 +1,116 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package helpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+)
+
+func Test_addBasicAuth(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		basicAuth *secretsv1beta1.BasicAuth
+		d         map[string]any
+		data      map[string][]byte
+		want      map[string][]byte
+		wantErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name: "not-configured",
+			d: map[string]any{
+				"user": "foo",
+			},
+			data:    map[string][]byte{},
+			want:    map[string][]byte{},
+			wantErr: assert.NoError,
+		},
+		{
+			name:      "default-keys",
+			basicAuth: &secretsv1beta1.BasicAuth{},
+			d: map[string]any{
+				"username": "foo",
+				"password": "bar",
+			},
+			data: map[string][]byte{},
+			want: map[string][]byte{
+				"username": []byte("foo"),
+				"password": []byte("bar"),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "mapped-keys",
+			basicAuth: &secretsv1beta1.BasicAuth{
+				UsernameKey: "user",
+				PasswordKey: "token",
+			},
+			d: map[string]any{
+				"user":  "foo",
+				"token": "bar",
+			},
+			data: map[string][]byte{
+				"other": []byte("baz"),
+			},
+			want: map[string][]byte{
+				"other":    []byte("baz"),
+				"username": []byte("foo"),
+				"password": []byte("bar"),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "missing-password",
+			basicAuth: &secretsv1beta1.BasicAuth{
+				PasswordKey: "token",
+			},
+			d: map[string]any{
+				"username": "foo",
+			},
+			data: map[string][]byte{},
+			want: map[string][]byte{},
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.EqualError(t, err,
+					`basicAuth field "token" not found in secret data`, i...)
+			},
+		},
+		{
+			name:      "template-conflict",
+			basicAuth: &secretsv1beta1.BasicAuth{},
+			d: map[string]any{
+				"username": "foo",
+				"password": "bar",
+			},
+			data: map[string][]byte{
+				"password": []byte("baz"),
+			},
+			want: map[string][]byte{
+				"password": []byte("baz"),
+			},
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.EqualError(t, err,
+					`key "password" from basicAuth conflicts with a template`, i...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			opt := &SecretTransformationOption{
+				BasicAuth: tt.basicAuth,
+			}
+			err := addBasicAuth(opt, tt.d, tt.data)
+			if !tt.wantErr(t, err) {
+				return
+			}
+			assert.Equal(t, tt.want, tt.data)
+		})
+	}
+}
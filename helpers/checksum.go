@@ -0,0 +1,61 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package helpers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	// ChecksumAlgorithmSHA256 is the default checksum algorithm.
+	ChecksumAlgorithmSHA256 = "sha256"
+	// ChecksumAlgorithmBLAKE2b is the 256-bit BLAKE2b checksum algorithm.
+	ChecksumAlgorithmBLAKE2b = "blake2b"
+	// annotationChecksumPrefix prefixes the checksum annotation, it is
+	// followed by the checksum algorithm.
+	annotationChecksumPrefix = "vso.secrets.hashicorp.com/checksum-"
+)
+
+// ChecksumAlgorithms are all the supported checksum algorithms.
+var ChecksumAlgorithms = []string{
+	ChecksumAlgorithmSHA256,
+	ChecksumAlgorithmBLAKE2b,
+}
+
+// DefaultChecksumAlgorithm is the checksum algorithm of the checksum
+// annotation of the destination Secrets, it is set from the
+// --checksum-algorithm flag.
+var DefaultChecksumAlgorithm = ChecksumAlgorithmSHA256
+
+// ChecksumAnnotation returns the name of the checksum annotation for algorithm,
+// e.g. vso.secrets.hashicorp.com/checksum-sha256. The algorithm is part of the
+// name, such that policy engines can tell which algorithm a workload relies on.
+func ChecksumAnnotation(algorithm string) string {
+	return annotationChecksumPrefix + algorithm
+}
+
+// Checksum returns the hex encoded checksum of data with algorithm.
+func Checksum(algorithm string, data map[string][]byte) (string, error) {
+	// the keys of the JSON encoding are sorted, so it is stable.
+	b, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+
+	switch algorithm {
+	case ChecksumAlgorithmSHA256:
+		sum := sha256.Sum256(b)
+		return hex.EncodeToString(sum[:]), nil
+	case ChecksumAlgorithmBLAKE2b:
+		sum := blake2b.Sum256(b)
+		return hex.EncodeToString(sum[:]), nil
+	default:
+		return "", fmt.Errorf("unsupported checksum algorithm %q", algorithm)
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package helpers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/internal/testutils"
+)
+
+func TestChecksum(t *testing.T) {
+	t.Parallel()
+
+	data := map[string][]byte{
+		"a": []byte("b"),
+	}
+	tests := []struct {
+		algorithm string
+		want      string
+		wantErr   assert.ErrorAssertionFunc
+	}{
+		{
+			algorithm: ChecksumAlgorithmSHA256,
+			want:      "3d791b164a808638da9a8df03924be2a41e34cd664e42231c00fe369e3588272",
+			wantErr:   assert.NoError,
+		},
+		{
+			algorithm: ChecksumAlgorithmBLAKE2b,
+			want:      "c07c288f9bb845de22731212da7d6a5a1e5536ad2f2a69c79c64be505ac04ded",
+			wantErr:   assert.NoError,
+		},
+		{
+			algorithm: "md5",
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.EqualError(t, err, `unsupported checksum algorithm "md5"`, i...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.algorithm, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Checksum(tt.algorithm, data)
+			if !tt.wantErr(t, err) {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSyncSecret_checksumAnnotation(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	c := testutils.NewFakeClientBuilder().Build()
+	obj := &secretsv1beta1.VaultStaticSecret{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "VaultStaticSecret",
+			APIVersion: "secrets.hashicorp.com/v1beta1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "baz",
+			Namespace: "foo",
+			UID:       types.UID("buzz"),
+		},
+		Spec: secretsv1beta1.VaultStaticSecretSpec{
+			Destination: secretsv1beta1.Destination{
+				Name:               "baz",
+				Create:             true,
+				Annotations:        map[string]string{"team": "a"},
+				ChecksumAnnotation: true,
+			},
+		},
+	}
+
+	data := map[string][]byte{
+		"a": []byte("b"),
+	}
+	require.NoError(t, SyncSecret(ctx, c, obj, data))
+
+	var s corev1.Secret
+	require.NoError(t, c.Get(ctx, ctrlclient.ObjectKey{Namespace: "foo", Name: "baz"}, &s))
+	assert.Equal(t, map[string]string{
+		"team": "a",
+		"vso.secrets.hashicorp.com/checksum-sha256": "3d791b164a808638da9a8df03924be2a41e34cd664e42231c00fe369e3588272",
+	}, s.Annotations)
+	// the configured annotations are left untouched.
+	assert.Equal(t, map[string]string{"team": "a"}, obj.Spec.Destination.Annotations)
+
+	obj.Spec.Destination.ChecksumAnnotation = false
+	require.NoError(t, SyncSecret(ctx, c, obj, data))
+	require.NoError(t, c.Get(ctx, ctrlclient.ObjectKey{Namespace: "foo", Name: "baz"}, &s))
+	assert.Equal(t, map[string]string{"team": "a"}, s.Annotations)
+}
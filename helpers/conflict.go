@@ -0,0 +1,229 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package helpers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/consts"
+	"github.com/hashicorp/vault-secrets-operator/internal/metrics"
+)
+
+// OwnershipRepairRecorder records an event on the syncable secret resource
+// whenever the stale ownership of its destination Secret is repaired. It is set
+// by the Operator on startup, no event is recorded if it is nil.
+var OwnershipRepairRecorder record.EventRecorder
+
+// DestinationConflictError is returned by SyncSecret when the destination
+// Secret exists, but it was not created by the syncable secret resource. It
+// carries the details needed to identify the Secret's actual owner.
+type DestinationConflictError struct {
+	// Key of the conflicting Secret.
+	Key ctrlclient.ObjectKey
+	// OwnerReferences of the conflicting Secret.
+	OwnerReferences []metav1.OwnerReference
+	// FieldManagers that have managed fields in the conflicting Secret.
+	FieldManagers []string
+	// Err holds the reasons for the ownership check failure.
+	Err error
+}
+
+func (e *DestinationConflictError) Error() string {
+	var owners []string
+	for _, ref := range e.OwnerReferences {
+		owners = append(owners, fmt.Sprintf("%s/%s (uid=%s)", ref.Kind, ref.Name, ref.UID))
+	}
+	if len(owners) == 0 {
+		owners = []string{"<none>"}
+	}
+	managers := e.FieldManagers
+	if len(managers) == 0 {
+		managers = []string{"<none>"}
+	}
+
+	return fmt.Sprintf("destination Secret %s exists, but is not owned by this resource, "+
+		"owners=[%s], fieldManagers=[%s]: %s",
+		e.Key, strings.Join(owners, ", "), strings.Join(managers, ", "), e.Err)
+}
+
+func (e *DestinationConflictError) Unwrap() error {
+	return e.Err
+}
+
+// IsDestinationConflict returns true if err is, or wraps, a
+// DestinationConflictError.
+func IsDestinationConflict(err error) bool {
+	var conflictErr *DestinationConflictError
+	return errors.As(err, &conflictErr)
+}
+
+func newDestinationConflictError(dest *corev1.Secret, err error) *DestinationConflictError {
+	var managers []string
+	for _, f := range dest.GetManagedFields() {
+		if f.Manager != "" && !slices.Contains(managers, f.Manager) {
+			managers = append(managers, f.Manager)
+		}
+	}
+
+	return &DestinationConflictError{
+		Key:             ctrlclient.ObjectKeyFromObject(dest),
+		OwnerReferences: dest.GetOwnerReferences(),
+		FieldManagers:   managers,
+		Err:             err,
+	}
+}
+
+// isOrphanedSecret returns true if dest was created by the Operator, and none
+// of its owners exist anymore. An owner is considered gone if it cannot be
+// found, or if it was recreated with a different UID. Any error other than not
+// found is returned, since the owner's existence cannot be determined. A
+// Secret that was retained upon its owner's deletion is always orphaned.
+func isOrphanedSecret(ctx context.Context, client ctrlclient.Client, dest *corev1.Secret) (bool, error) {
+	if isRetainedSecret(dest) {
+		return true, nil
+	}
+	if !HasOwnerLabels(dest) || len(dest.GetOwnerReferences()) == 0 {
+		return false, nil
+	}
+
+	for _, ref := range dest.GetOwnerReferences() {
+		owner := &unstructured.Unstructured{}
+		owner.SetAPIVersion(ref.APIVersion)
+		owner.SetKind(ref.Kind)
+		err := client.Get(ctx, ctrlclient.ObjectKey{
+			Namespace: dest.GetNamespace(),
+			Name:      ref.Name,
+		}, owner)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return false, err
+		}
+		if owner.GetUID() == ref.UID {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// hasStaleOwnership returns true if dest was created by the Operator for a
+// resource of the same kind and name as references' owner, but with another
+// UID. This is the case after the resource has been restored from a backup,
+// e.g. by Velero, since the restored resource is assigned a new UID. The
+// previous owner is necessarily gone, since its name is now taken by the
+// restored resource.
+func hasStaleOwnership(dest *corev1.Secret, references []metav1.OwnerReference) bool {
+	if !HasOwnerLabels(dest) || len(dest.OwnerReferences) != 1 || len(references) != 1 {
+		return false
+	}
+
+	ref, want := dest.OwnerReferences[0], references[0]
+	refGV, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil {
+		return false
+	}
+	wantGV, err := schema.ParseGroupVersion(want.APIVersion)
+	if err != nil {
+		return false
+	}
+
+	return refGV.Group == wantGV.Group &&
+		ref.Kind == want.Kind &&
+		ref.Name == want.Name &&
+		ref.UID != want.UID &&
+		dest.Labels[labelOwnerRefUID] == string(ref.UID)
+}
+
+// checkDestinationOwnership verifies that the pre-existing destination Secret
+// is owned by obj. If it is not, then the Secret's stale ownership is repaired
+// if it was owned by obj before it was restored from a backup, or the Secret
+// is adopted if Destination.AdoptIfOwnerGone is set and the Secret's previous
+// owner no longer exists, otherwise a DestinationConflictError is returned.
+func checkDestinationOwnership(ctx context.Context, client ctrlclient.Client, obj ctrlclient.Object,
+	dest *corev1.Secret, destination *secretsv1beta1.Destination, references []metav1.OwnerReference,
+) error {
+	err := checkSecretIsOwnedByObj(dest, references)
+	if err == nil {
+		return nil
+	}
+
+	kind := references[0].Kind
+	if hasStaleOwnership(dest, references) {
+		staleUID := dest.OwnerReferences[0].UID
+		log.FromContext(ctx).Info("Repairing the stale ownership of the destination Secret",
+			"secret", ctrlclient.ObjectKeyFromObject(dest), "staleUID", staleUID)
+		if OwnershipRepairRecorder != nil {
+			OwnershipRepairRecorder.Eventf(obj, corev1.EventTypeNormal, consts.ReasonOwnershipRepaired,
+				"Repaired the stale ownership of the destination Secret %s, previous owner uid=%s",
+				dest.Name, staleUID)
+		}
+		metrics.DestinationConflicts.WithLabelValues(
+			kind, obj.GetNamespace(), metrics.ResolutionRepaired).Inc()
+		return nil
+	}
+
+	if destination.AdoptIfOwnerGone {
+		orphaned, orphanErr := isOrphanedSecret(ctx, client, dest)
+		if orphanErr != nil {
+			return errors.Join(newDestinationConflictError(dest, err),
+				fmt.Errorf("failed to check for the previous owner: %w", orphanErr))
+		}
+		if orphaned {
+			metrics.DestinationConflicts.WithLabelValues(
+				kind, obj.GetNamespace(), metrics.ResolutionAdopted).Inc()
+			return nil
+		}
+	}
+
+	metrics.DestinationConflicts.WithLabelValues(
+		kind, obj.GetNamespace(), metrics.ResolutionConflict).Inc()
+	return newDestinationConflictError(dest, err)
+}
+
+// SetDestinationConflictCondition updates the DestinationConflict condition in
+// conditions from the result of a SyncSecret call. The condition is only added
+// when a conflict is encountered, afterward it is kept up-to-date.
+func SetDestinationConflictCondition(conditions *[]metav1.Condition, generation int64, syncErr error) {
+	var conflictErr *DestinationConflictError
+	if errors.As(syncErr, &conflictErr) {
+		meta.SetStatusCondition(conditions, metav1.Condition{
+			Type:               secretsv1beta1.ConditionTypeDestinationConflict,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: generation,
+			Reason:             consts.ReasonDestinationConflict,
+			Message:            conflictErr.Error(),
+		})
+		return
+	}
+
+	if syncErr != nil || meta.FindStatusCondition(*conditions,
+		secretsv1beta1.ConditionTypeDestinationConflict) == nil {
+		return
+	}
+
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               secretsv1beta1.ConditionTypeDestinationConflict,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: generation,
+		Reason:             consts.ReasonSecretSynced,
+		Message:            "Destination Secret is owned by this resource",
+	})
+}
@@ -0,0 +1,296 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package helpers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/internal/testutils"
+)
+
+func TestSyncSecret_destinationConflict(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	newObj := func(name string, uid types.UID, adopt bool) *secretsv1beta1.VaultStaticSecret {
+		return &secretsv1beta1.VaultStaticSecret{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: secretsv1beta1.GroupVersion.String(),
+				Kind:       "VaultStaticSecret",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "baz",
+				UID:       uid,
+			},
+			Spec: secretsv1beta1.VaultStaticSecretSpec{
+				Destination: secretsv1beta1.Destination{
+					Name:             "creds",
+					Create:           true,
+					AdoptIfOwnerGone: adopt,
+				},
+			},
+		}
+	}
+
+	prevOwner := newObj("prev", "uid-prev", false)
+	newSecret := func() *corev1.Secret {
+		labels, err := OwnerLabelsForObj(prevOwner)
+		require.NoError(t, err)
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "creds",
+				Namespace: "baz",
+				Labels:    labels,
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						APIVersion: secretsv1beta1.GroupVersion.String(),
+						Kind:       "VaultStaticSecret",
+						Name:       prevOwner.Name,
+						UID:        prevOwner.UID,
+					},
+				},
+				ManagedFields: []metav1.ManagedFieldsEntry{
+					{Manager: "vault-secrets-operator"},
+					{Manager: "kubectl"},
+					{Manager: "vault-secrets-operator"},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		adopt       bool
+		objs        []ctrlclient.Object
+		wantAdopted bool
+	}{
+		{
+			name:  "conflict",
+			adopt: false,
+		},
+		{
+			name:        "adopt-owner-gone",
+			adopt:       true,
+			wantAdopted: true,
+		},
+		{
+			name:        "adopt-owner-recreated",
+			adopt:       true,
+			objs:        []ctrlclient.Object{newObj("prev", "uid-other", false)},
+			wantAdopted: true,
+		},
+		{
+			name:  "adopt-owner-exists",
+			adopt: true,
+			objs:  []ctrlclient.Object{prevOwner.DeepCopy()},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := testutils.NewFakeClientBuilder().
+				WithObjects(append(tt.objs, newSecret())...).Build()
+			obj := newObj("foo", "uid-foo", tt.adopt)
+			data := map[string][]byte{"password": []byte("secret")}
+
+			err := SyncSecret(ctx, client, obj, data)
+			SetDestinationConflictCondition(&obj.Status.Conditions, obj.GetGeneration(), err)
+			s, getErr := GetSecret(ctx, client, ctrlclient.ObjectKey{Namespace: "baz", Name: "creds"})
+			require.NoError(t, getErr)
+			cond := meta.FindStatusCondition(obj.Status.Conditions,
+				secretsv1beta1.ConditionTypeDestinationConflict)
+			if tt.wantAdopted {
+				require.NoError(t, err)
+				assert.Nil(t, cond)
+				assert.Equal(t, data, s.Data)
+				assert.Equal(t, types.UID("uid-foo"), s.OwnerReferences[0].UID)
+				return
+			}
+
+			var conflictErr *DestinationConflictError
+			require.True(t, errors.As(err, &conflictErr))
+			assert.True(t, IsDestinationConflict(err))
+			assert.Equal(t, ctrlclient.ObjectKey{Namespace: "baz", Name: "creds"}, conflictErr.Key)
+			assert.Equal(t, []string{"vault-secrets-operator", "kubectl"}, conflictErr.FieldManagers)
+			assert.Equal(t, newSecret().OwnerReferences, conflictErr.OwnerReferences)
+			assert.ErrorContains(t, err, "VaultStaticSecret/prev (uid=uid-prev)")
+			assert.Nil(t, s.Data)
+
+			require.NotNil(t, cond)
+			assert.Equal(t, metav1.ConditionTrue, cond.Status)
+
+			// the condition is cleared once the conflict is resolved.
+			require.NoError(t, client.Delete(ctx, s))
+			err = SyncSecret(ctx, client, obj, data)
+			require.NoError(t, err)
+			SetDestinationConflictCondition(&obj.Status.Conditions, obj.GetGeneration(), err)
+			cond = meta.FindStatusCondition(obj.Status.Conditions,
+				secretsv1beta1.ConditionTypeDestinationConflict)
+			require.NotNil(t, cond)
+			assert.Equal(t, metav1.ConditionFalse, cond.Status)
+		})
+	}
+}
+
+func TestSyncSecret_staleOwnership(t *testing.T) {
+	recorder := record.NewFakeRecorder(1)
+	OwnershipRepairRecorder = recorder
+	t.Cleanup(func() {
+		OwnershipRepairRecorder = nil
+	})
+
+	ctx := context.Background()
+	newObj := func(uid types.UID) *secretsv1beta1.VaultStaticSecret {
+		return &secretsv1beta1.VaultStaticSecret{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: secretsv1beta1.GroupVersion.String(),
+				Kind:       "VaultStaticSecret",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo",
+				Namespace: "baz",
+				UID:       uid,
+			},
+			Spec: secretsv1beta1.VaultStaticSecretSpec{
+				Destination: secretsv1beta1.Destination{
+					Name:   "creds",
+					Create: true,
+				},
+			},
+		}
+	}
+
+	// the Secret was created for the resource before it was restored with a new
+	// UID.
+	backedUp := newObj("uid-backed-up")
+	labels, err := OwnerLabelsForObj(backedUp)
+	require.NoError(t, err)
+	client := testutils.NewFakeClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "creds",
+			Namespace: "baz",
+			Labels:    labels,
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: secretsv1beta1.GroupVersion.String(),
+					Kind:       "VaultStaticSecret",
+					Name:       backedUp.Name,
+					UID:        backedUp.UID,
+				},
+			},
+		},
+	}).Build()
+
+	restored := newObj("uid-restored")
+	data := map[string][]byte{"password": []byte("secret")}
+	require.NoError(t, SyncSecret(ctx, client, restored, data))
+
+	s, err := GetSecret(ctx, client, ctrlclient.ObjectKey{Namespace: "baz", Name: "creds"})
+	require.NoError(t, err)
+	assert.Equal(t, data, s.Data)
+	require.Len(t, s.OwnerReferences, 1)
+	assert.Equal(t, types.UID("uid-restored"), s.OwnerReferences[0].UID)
+	assert.Equal(t, "uid-restored", s.Labels[labelOwnerRefUID])
+	require.Len(t, recorder.Events, 1)
+	assert.Equal(t, "Normal OwnershipRepaired Repaired the stale ownership of the destination "+
+		"Secret creds, previous owner uid=uid-backed-up", <-recorder.Events)
+}
+
+func Test_hasStaleOwnership(t *testing.T) {
+	t.Parallel()
+
+	references := []metav1.OwnerReference{
+		{
+			APIVersion: secretsv1beta1.GroupVersion.String(),
+			Kind:       "VaultStaticSecret",
+			Name:       "foo",
+			UID:        "uid-new",
+		},
+	}
+	newSecret := func(ref metav1.OwnerReference, uidLabel string) *corev1.Secret {
+		labels := map[string]string{
+			labelOwnerRefUID: uidLabel,
+		}
+		for k, v := range OwnerLabels {
+			labels[k] = v
+		}
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels:          labels,
+				OwnerReferences: []metav1.OwnerReference{ref},
+			},
+		}
+	}
+	staleRef := metav1.OwnerReference{
+		APIVersion: "secrets.hashicorp.com/v1alpha1",
+		Kind:       "VaultStaticSecret",
+		Name:       "foo",
+		UID:        "uid-old",
+	}
+
+	tests := []struct {
+		name   string
+		secret *corev1.Secret
+		want   bool
+	}{
+		{
+			name:   "stale",
+			secret: newSecret(staleRef, "uid-old"),
+			want:   true,
+		},
+		{
+			name:   "owned",
+			secret: newSecret(references[0], "uid-new"),
+		},
+		{
+			name: "other-name",
+			secret: newSecret(metav1.OwnerReference{
+				APIVersion: staleRef.APIVersion,
+				Kind:       staleRef.Kind,
+				Name:       "bar",
+				UID:        staleRef.UID,
+			}, "uid-old"),
+		},
+		{
+			name: "other-kind",
+			secret: newSecret(metav1.OwnerReference{
+				APIVersion: staleRef.APIVersion,
+				Kind:       "VaultDynamicSecret",
+				Name:       staleRef.Name,
+				UID:        staleRef.UID,
+			}, "uid-old"),
+		},
+		{
+			name:   "label-mismatch",
+			secret: newSecret(staleRef, "uid-other"),
+		},
+		{
+			name: "no-owner-labels",
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					OwnerReferences: []metav1.OwnerReference{staleRef},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, hasStaleOwnership(tt.secret, references))
+		})
+	}
+}
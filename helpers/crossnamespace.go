@@ -0,0 +1,431 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package helpers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/common"
+	"github.com/hashicorp/vault-secrets-operator/consts"
+	"github.com/hashicorp/vault-secrets-operator/utils"
+)
+
+// Since a metav1.OwnerReference cannot refer to an object in another namespace,
+// the Secrets synced outside of their owner's namespace are indexed by the
+// following label and annotation instead. Together with labelOwnerRefUID, they
+// provide a durable reference index that survives Operator restarts, and that can
+// be queried with a label selector.
+var (
+	// labelOwnerNamespace holds the namespace of the owning object.
+	labelOwnerNamespace = fmt.Sprintf("%s/vso-ownerNamespace", secretsv1beta1.GroupVersion.Group)
+	// annotationOwnerRef holds the JSON encoded metav1.OwnerReference of the
+	// owning object.
+	annotationOwnerRef = fmt.Sprintf("%s/vso-ownerRef", secretsv1beta1.GroupVersion.Group)
+	// annotationFanOutNamespaces holds the comma separated namespaces that a
+	// Secret was copied to, it is set on the Secret in the owning object's
+	// namespace. It tracks the copies that must be deleted once their
+	// namespace is no longer selected.
+	annotationFanOutNamespaces = fmt.Sprintf("%s/vso-fanOutNamespaces", secretsv1beta1.GroupVersion.Group)
+)
+
+// AnnotationAllowedSourceNamespaces must be set on a Namespace for it to receive
+// the Secrets that are synced from other namespaces, with
+// Destination.Namespaces or Destination.NamespaceSelector. It holds the comma
+// separated namespaces that are allowed to sync Secrets into it, "*" allows all
+// of them.
+const AnnotationAllowedSourceNamespaces = "vso.secrets.hashicorp.com/allowedSourceNamespaces"
+
+// FanOutDeniedError is returned by SyncSecret when some of the namespaces that
+// the destination Secret should be synced to have not opted in to receive
+// Secrets from the syncable secret resource's namespace, see
+// AnnotationAllowedSourceNamespaces. Nothing is synced in that case.
+type FanOutDeniedError struct {
+	// SourceNamespace is the namespace of the syncable secret resource.
+	SourceNamespace string
+	// Namespaces that denied the fan-out.
+	Namespaces []string
+}
+
+func (e *FanOutDeniedError) Error() string {
+	return fmt.Sprintf("the namespaces [%s] do not allow Secrets to be synced from namespace %q, "+
+		"their %s annotation must include it",
+		strings.Join(e.Namespaces, ", "), e.SourceNamespace, AnnotationAllowedSourceNamespaces)
+}
+
+// IsFanOutDenied returns true if err is, or wraps, a FanOutDeniedError.
+func IsFanOutDenied(err error) bool {
+	var deniedErr *FanOutDeniedError
+	return errors.As(err, &deniedErr)
+}
+
+// SetFanOutDeniedCondition updates the FanOutDenied condition in conditions
+// from the error returned by SyncSecret. The condition is only set to false
+// once the sync succeeds, if it was previously set.
+func SetFanOutDeniedCondition(conditions *[]metav1.Condition, generation int64, syncErr error) {
+	var deniedErr *FanOutDeniedError
+	if errors.As(syncErr, &deniedErr) {
+		meta.SetStatusCondition(conditions, metav1.Condition{
+			Type:               secretsv1beta1.ConditionTypeFanOutDenied,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: generation,
+			Reason:             consts.ReasonFanOutDenied,
+			Message:            deniedErr.Error(),
+		})
+		return
+	}
+
+	if syncErr != nil || meta.FindStatusCondition(*conditions,
+		secretsv1beta1.ConditionTypeFanOutDenied) == nil {
+		return
+	}
+
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               secretsv1beta1.ConditionTypeFanOutDenied,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: generation,
+		Reason:             consts.ReasonSecretSynced,
+		Message:            "All the destination namespaces allow the fan-out",
+	})
+}
+
+// fanOutAllowed returns true if the Namespace ns allows Secrets to be synced
+// from the namespace source.
+func fanOutAllowed(ns *corev1.Namespace, source string) bool {
+	for _, v := range strings.Split(ns.GetAnnotations()[AnnotationAllowedSourceNamespaces], ",") {
+		if v = strings.TrimSpace(v); v == "*" || v == source {
+			return true
+		}
+	}
+	return false
+}
+
+// CrossNamespaceOwnerMetadataForObj returns the labels and annotations that must
+// be set on a Secret that is owned by obj, and that is in a namespace other than
+// obj's. The labels include the canonical set from OwnerLabelsForObj.
+func CrossNamespaceOwnerMetadataForObj(obj ctrlclient.Object, scheme *runtime.Scheme) (map[string]string, map[string]string, error) {
+	labels, err := OwnerLabelsForObj(obj)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ref, err := utils.GetOwnerRefFromObj(obj, scheme)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	b, err := json.Marshal(ref)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	labels[labelOwnerNamespace] = obj.GetNamespace()
+	return labels, map[string]string{
+		annotationOwnerRef: string(b),
+	}, nil
+}
+
+// CrossNamespaceOwner returns the metav1.OwnerReference and the namespace of the
+// object that owns o from another namespace. It returns false if o is not owned
+// across namespaces, or if its reference metadata is invalid.
+func CrossNamespaceOwner(o metav1.Object) (*metav1.OwnerReference, string, bool) {
+	ns, ok := o.GetLabels()[labelOwnerNamespace]
+	if !ok || ns == "" || ns == o.GetNamespace() {
+		return nil, "", false
+	}
+
+	v, ok := o.GetAnnotations()[annotationOwnerRef]
+	if !ok {
+		return nil, "", false
+	}
+
+	var ref metav1.OwnerReference
+	if err := json.Unmarshal([]byte(v), &ref); err != nil {
+		return nil, "", false
+	}
+
+	if ref.Name == "" || string(ref.UID) != o.GetLabels()[labelOwnerRefUID] {
+		return nil, "", false
+	}
+
+	return &ref, ns, true
+}
+
+// FindCrossNamespaceSecretsOwnedByObj returns all the corev1.Secrets that are
+// owned by obj, and that are outside obj's namespace.
+func FindCrossNamespaceSecretsOwnedByObj(ctx context.Context, client ctrlclient.Client, obj ctrlclient.Object) ([]corev1.Secret, error) {
+	matchingLabels, err := matchingLabelsForObj(obj)
+	if err != nil {
+		return nil, err
+	}
+	matchingLabels[labelOwnerNamespace] = obj.GetNamespace()
+
+	secrets := &corev1.SecretList{}
+	if err := client.List(ctx, secrets, matchingLabels); err != nil {
+		return nil, err
+	}
+
+	var result []corev1.Secret
+	for _, s := range secrets.Items {
+		ref, _, ok := CrossNamespaceOwner(&s)
+		if ok && ref.UID == obj.GetUID() {
+			result = append(result, s)
+		}
+	}
+
+	return result, nil
+}
+
+// DeleteCrossNamespaceSecrets deletes all Secrets that are owned by obj outside of
+// its namespace. It should be called when obj is being deleted, prior to
+// removing its finalizer. Nothing is deleted when the obj's
+// Destination.CascadeDelete is false.
+//
+// See NewSyncableSecretMetaData for the supported types for obj.
+func DeleteCrossNamespaceSecrets(ctx context.Context, client ctrlclient.Client, obj ctrlclient.Object) error {
+	meta, err := common.NewSyncableSecretMetaData(obj)
+	if err != nil {
+		return err
+	}
+
+	// an object without a UID cannot own any Secrets.
+	if !meta.Destination.CascadeDelete || obj.GetUID() == "" {
+		return nil
+	}
+
+	owned, err := FindCrossNamespaceSecretsOwnedByObj(ctx, client, obj)
+	if err != nil {
+		return err
+	}
+
+	logger := log.FromContext(ctx).WithName("DeleteCrossNamespaceSecrets")
+	var errs error
+	for _, s := range owned {
+		if err := client.Delete(ctx, &s); err != nil && !apierrors.IsNotFound(err) {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		logger.V(consts.LogLevelDebug).Info("Deleted cross-namespace Secret",
+			"secret", ctrlclient.ObjectKeyFromObject(&s))
+	}
+
+	return errs
+}
+
+// hasFanOut returns true if the Secret of the Destination d is synced to other
+// namespaces.
+func hasFanOut(d *secretsv1beta1.Destination) bool {
+	return len(d.Namespaces) > 0 || d.NamespaceSelector != nil
+}
+
+// fanOutNamespaces returns the sorted namespaces, other than obj's, that the
+// Secret of the Destination d is synced to. A FanOutDeniedError is returned if
+// any of them has not opted in to receive Secrets from obj's namespace, or if
+// it does not exist.
+func fanOutNamespaces(ctx context.Context, client ctrlclient.Client, obj ctrlclient.Object, d *secretsv1beta1.Destination) ([]string, error) {
+	if !hasFanOut(d) {
+		return nil, nil
+	}
+
+	source := obj.GetNamespace()
+	set := make(map[string]bool)
+	var denied []string
+	for _, name := range d.Namespaces {
+		if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid Destination namespace %q: %s", name, strings.Join(errs, ", "))
+		}
+		if name == source {
+			continue
+		}
+
+		var ns corev1.Namespace
+		if err := client.Get(ctx, ctrlclient.ObjectKey{Name: name}, &ns); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, err
+			}
+			denied = append(denied, name)
+			continue
+		}
+		if !fanOutAllowed(&ns, source) {
+			denied = append(denied, name)
+			continue
+		}
+		set[name] = true
+	}
+
+	if d.NamespaceSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(d.NamespaceSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Destination namespaceSelector: %w", err)
+		}
+
+		var namespaces corev1.NamespaceList
+		if err := client.List(ctx, &namespaces, ctrlclient.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return nil, err
+		}
+		for _, ns := range namespaces.Items {
+			if ns.DeletionTimestamp != nil || ns.Name == source {
+				continue
+			}
+			if !fanOutAllowed(&ns, source) {
+				denied = append(denied, ns.Name)
+				continue
+			}
+			set[ns.Name] = true
+		}
+	}
+
+	if len(denied) > 0 {
+		slices.Sort(denied)
+		return nil, &FanOutDeniedError{
+			SourceNamespace: source,
+			Namespaces:      slices.Compact(denied),
+		}
+	}
+
+	return slices.Sorted(maps.Keys(set)), nil
+}
+
+// fanOutNamespacesOf returns the namespaces that the Secret s was copied to.
+func fanOutNamespacesOf(s *corev1.Secret) []string {
+	v := s.GetAnnotations()[annotationFanOutNamespaces]
+	if v == "" {
+		return nil
+	}
+	return strings.Split(v, ",")
+}
+
+// syncCrossNamespaceSecrets copies the Secret src, synced in obj's namespace,
+// to each of the namespaces. The copies are owned by obj across namespaces.
+// The copies in the previous namespaces that are no longer selected are
+// deleted.
+func syncCrossNamespaceSecrets(ctx context.Context, client ctrlclient.Client, obj ctrlclient.Object,
+	src *corev1.Secret, namespaces, previous []string,
+) error {
+	var errs error
+	if len(namespaces) > 0 {
+		ownerLabels, ownerAnnotations, err := CrossNamespaceOwnerMetadataForObj(obj, client.Scheme())
+		if err != nil {
+			return err
+		}
+
+		labels := maps.Clone(src.GetLabels())
+		maps.Copy(labels, ownerLabels)
+		annotations := maps.Clone(src.GetAnnotations())
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		delete(annotations, annotationFanOutNamespaces)
+		maps.Copy(annotations, ownerAnnotations)
+
+		for _, ns := range namespaces {
+			if err := syncCrossNamespaceSecret(ctx, client, obj, src, ns, labels, annotations); err != nil {
+				errs = errors.Join(errs, err)
+			}
+		}
+	}
+
+	var stale []string
+	for _, ns := range previous {
+		if !slices.Contains(namespaces, ns) {
+			stale = append(stale, ns)
+		}
+	}
+
+	return errors.Join(errs, deleteCrossNamespaceSecrets(ctx, client, obj, src.Name, stale))
+}
+
+func syncCrossNamespaceSecret(ctx context.Context, client ctrlclient.Client, obj ctrlclient.Object,
+	src *corev1.Secret, namespace string, labels, annotations map[string]string,
+) error {
+	key := ctrlclient.ObjectKey{
+		Namespace: namespace,
+		Name:      src.Name,
+	}
+	dest, exists, err := getSecretExists(ctx, client, key)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		dest = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        key.Name,
+				Namespace:   key.Namespace,
+				Labels:      labels,
+				Annotations: annotations,
+			},
+			Type: src.Type,
+			Data: src.Data,
+		}
+		return createSecret(ctx, client, dest)
+	}
+
+	if ref, _, ok := CrossNamespaceOwner(dest); !ok || ref.UID != obj.GetUID() {
+		return newDestinationConflictError(dest,
+			fmt.Errorf("the Secret is not owned by %s", ctrlclient.ObjectKeyFromObject(obj)))
+	}
+
+	if dest.Type != src.Type {
+		// the Secret type is immutable.
+		if err := client.Delete(ctx, dest); err != nil {
+			return err
+		}
+		dest.ResourceVersion = ""
+		dest.Type = src.Type
+		dest.Data = src.Data
+		dest.SetLabels(labels)
+		dest.SetAnnotations(annotations)
+		return createSecret(ctx, client, dest)
+	}
+
+	orig := dest.DeepCopy()
+	dest.Data = src.Data
+	dest.SetLabels(labels)
+	dest.SetAnnotations(annotations)
+	return patchSecret(ctx, client, orig, dest)
+}
+
+// deleteCrossNamespaceSecrets deletes the copies of the Secret name in the
+// namespaces, provided that they are owned by obj.
+func deleteCrossNamespaceSecrets(ctx context.Context, client ctrlclient.Client, obj ctrlclient.Object,
+	name string, namespaces []string,
+) error {
+	var errs error
+	for _, ns := range namespaces {
+		s, exists, err := getSecretExists(ctx, client, ctrlclient.ObjectKey{
+			Namespace: ns,
+			Name:      name,
+		})
+		if err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		if !exists {
+			continue
+		}
+		if ref, _, ok := CrossNamespaceOwner(s); !ok || ref.UID != obj.GetUID() {
+			continue
+		}
+		if err := client.Delete(ctx, s); err != nil && !apierrors.IsNotFound(err) {
+			errs = errors.Join(errs, err)
+		}
+	}
+	return errs
+}
@@ -0,0 +1,418 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package helpers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/consts"
+	"github.com/hashicorp/vault-secrets-operator/internal/testutils"
+)
+
+func TestCrossNamespaceOwner(t *testing.T) {
+	t.Parallel()
+
+	client := testutils.NewFakeClientBuilder().Build()
+	owner := &secretsv1beta1.VaultDynamicSecret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "baz",
+			UID:       "a1b2c3d4-0000-0000-0000-000000000001",
+		},
+	}
+
+	labels, annotations, err := CrossNamespaceOwnerMetadataForObj(owner, client.Scheme())
+	require.NoError(t, err)
+	for k, v := range OwnerLabels {
+		assert.Equal(t, v, labels[k])
+	}
+	assert.Equal(t, "baz", labels[labelOwnerNamespace])
+	assert.Equal(t, string(owner.UID), labels[labelOwnerRefUID])
+
+	tests := []struct {
+		name        string
+		namespace   string
+		labels      map[string]string
+		annotations map[string]string
+		wantOK      bool
+	}{
+		{
+			name:        "valid",
+			namespace:   "other",
+			labels:      labels,
+			annotations: annotations,
+			wantOK:      true,
+		},
+		{
+			name:        "same-namespace",
+			namespace:   "baz",
+			labels:      labels,
+			annotations: annotations,
+		},
+		{
+			name:      "no-annotation",
+			namespace: "other",
+			labels:    labels,
+		},
+		{
+			name:      "invalid-annotation",
+			namespace: "other",
+			labels:    labels,
+			annotations: map[string]string{
+				annotationOwnerRef: "{",
+			},
+		},
+		{
+			name:      "uid-mismatch",
+			namespace: "other",
+			labels: map[string]string{
+				labelOwnerNamespace: "baz",
+				labelOwnerRefUID:    "other-uid",
+			},
+			annotations: annotations,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "dest",
+					Namespace:   tt.namespace,
+					Labels:      tt.labels,
+					Annotations: tt.annotations,
+				},
+			}
+			ref, ns, ok := CrossNamespaceOwner(s)
+			require.Equal(t, tt.wantOK, ok)
+			if !tt.wantOK {
+				return
+			}
+
+			assert.Equal(t, "baz", ns)
+			assert.Equal(t, "foo", ref.Name)
+			assert.Equal(t, "VaultDynamicSecret", ref.Kind)
+			assert.Equal(t, secretsv1beta1.GroupVersion.String(), ref.APIVersion)
+			assert.Equal(t, owner.UID, ref.UID)
+		})
+	}
+}
+
+func TestDeleteCrossNamespaceSecrets(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	newOwner := func(cascadeDelete bool) *secretsv1beta1.VaultStaticSecret {
+		return &secretsv1beta1.VaultStaticSecret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo",
+				Namespace: "baz",
+				UID:       "a1b2c3d4-0000-0000-0000-000000000002",
+			},
+			Spec: secretsv1beta1.VaultStaticSecretSpec{
+				Destination: secretsv1beta1.Destination{
+					Name:          "dest",
+					CascadeDelete: cascadeDelete,
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		cascadeDelete bool
+		wantDeleted   bool
+	}{
+		{
+			name:          "cascade",
+			cascadeDelete: true,
+			wantDeleted:   true,
+		},
+		{
+			name:          "no-cascade",
+			cascadeDelete: false,
+			wantDeleted:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			owner := newOwner(tt.cascadeDelete)
+			client := testutils.NewFakeClientBuilder().Build()
+			labels, annotations, err := CrossNamespaceOwnerMetadataForObj(owner, client.Scheme())
+			require.NoError(t, err)
+
+			ownerLabels, err := OwnerLabelsForObj(owner)
+			require.NoError(t, err)
+			sameNamespace := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "dest",
+					Namespace: "baz",
+					Labels:    ownerLabels,
+				},
+			}
+			crossNamespace := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "dest",
+					Namespace:   "other",
+					Labels:      labels,
+					Annotations: annotations,
+				},
+			}
+			unrelated := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "unrelated",
+					Namespace: "other",
+				},
+			}
+			for _, o := range []ctrlclient.Object{sameNamespace, crossNamespace, unrelated} {
+				require.NoError(t, client.Create(ctx, o))
+			}
+
+			require.NoError(t, DeleteCrossNamespaceSecrets(ctx, client, owner))
+
+			_, exists, err := getSecretExists(ctx, client, ctrlclient.ObjectKeyFromObject(crossNamespace))
+			require.NoError(t, err)
+			assert.Equal(t, !tt.wantDeleted, exists)
+
+			for _, o := range []ctrlclient.Object{sameNamespace, unrelated} {
+				_, exists, err := getSecretExists(ctx, client, ctrlclient.ObjectKeyFromObject(o))
+				require.NoError(t, err)
+				assert.True(t, exists)
+			}
+		})
+	}
+}
+
+func TestSyncSecret_fanOut(t *testing.T) {
+	ctx := context.Background()
+	namespace := func(name string, labels map[string]string) *corev1.Namespace {
+		return &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: labels,
+				Annotations: map[string]string{
+					AnnotationAllowedSourceNamespaces: "other, app",
+				},
+			},
+		}
+	}
+	shared := map[string]string{"registry": "shared"}
+	unowned := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "creds",
+			Namespace: "taken",
+		},
+	}
+	client := testutils.NewFakeClientBuilder().WithObjects(
+		namespace("app", nil),
+		namespace("team-a", shared),
+		namespace("team-b", shared),
+		namespace("team-c", nil),
+		namespace("taken", nil),
+		unowned,
+	).Build()
+
+	obj := &secretsv1beta1.VaultStaticSecret{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "VaultStaticSecret",
+			APIVersion: secretsv1beta1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "creds",
+			Namespace: "app",
+			UID:       "a1b2c3d4-0000-0000-0000-000000000002",
+		},
+		Spec: secretsv1beta1.VaultStaticSecretSpec{
+			Destination: secretsv1beta1.Destination{
+				Name:   "creds",
+				Create: true,
+				Labels: map[string]string{
+					"team": "platform",
+				},
+				Type:       corev1.SecretTypeDockerConfigJson,
+				Namespaces: []string{"team-c", "app"},
+				NamespaceSelector: &metav1.LabelSelector{
+					MatchLabels: shared,
+				},
+			},
+		},
+	}
+	data := map[string][]byte{
+		corev1.DockerConfigJsonKey: []byte(`{"auths":{}}`),
+	}
+
+	getSecret := func(ns string) (*corev1.Secret, bool) {
+		t.Helper()
+		s, exists, err := getSecretExists(ctx, client, ctrlclient.ObjectKey{Namespace: ns, Name: "creds"})
+		require.NoError(t, err)
+		return s, exists
+	}
+
+	require.NoError(t, SyncSecret(ctx, client, obj, data))
+	primary, exists := getSecret("app")
+	require.True(t, exists)
+	assert.Equal(t, "team-a,team-b,team-c", primary.Annotations[annotationFanOutNamespaces])
+	for _, ns := range []string{"team-a", "team-b", "team-c"} {
+		s, exists := getSecret(ns)
+		require.True(t, exists, ns)
+		assert.Equal(t, data, s.Data)
+		assert.Equal(t, corev1.SecretTypeDockerConfigJson, s.Type)
+		assert.Equal(t, "platform", s.Labels["team"])
+		assert.NotContains(t, s.Annotations, annotationFanOutNamespaces)
+		ref, ownerNS, ok := CrossNamespaceOwner(s)
+		require.True(t, ok, ns)
+		assert.Equal(t, "app", ownerNS)
+		assert.Equal(t, obj.UID, ref.UID)
+		assert.Empty(t, s.OwnerReferences)
+	}
+
+	// the namespaces that are no longer selected are cleaned up.
+	obj.Spec.Destination.Namespaces = nil
+	data[corev1.DockerConfigJsonKey] = []byte(`{"auths":{"r":{}}}`)
+	require.NoError(t, SyncSecret(ctx, client, obj, data))
+	_, exists = getSecret("team-c")
+	assert.False(t, exists)
+	s, exists := getSecret("team-a")
+	require.True(t, exists)
+	assert.Equal(t, data, s.Data)
+
+	// Secrets that are not owned by the object are never overwritten.
+	obj.Spec.Destination.Namespaces = []string{"taken"}
+	err := SyncSecret(ctx, client, obj, data)
+	assert.True(t, IsDestinationConflict(err), err)
+	s, exists = getSecret("taken")
+	require.True(t, exists)
+	assert.Empty(t, s.Data)
+
+	// renaming the destination deletes the previous copies.
+	obj.Spec.Destination.Namespaces = nil
+	obj.Spec.Destination.Name = "renamed"
+	require.NoError(t, SyncSecret(ctx, client, obj, data))
+	for _, ns := range []string{"app", "team-a", "team-b"} {
+		_, exists := getSecret(ns)
+		assert.False(t, exists, ns)
+	}
+
+	obj.Spec.Destination.Create = false
+	assert.EqualError(t, SyncSecret(ctx, client, obj, data),
+		"invalid Destination, namespaces and namespaceSelector require create=true, "+
+			"and cannot be combined with secretless")
+}
+
+func TestSyncSecret_fanOutDenied(t *testing.T) {
+	ctx := context.Background()
+	namespace := func(name, allowed string) *corev1.Namespace {
+		ns := &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				Labels: map[string]string{
+					"registry": "shared",
+				},
+			},
+		}
+		if allowed != "" {
+			ns.Annotations = map[string]string{
+				AnnotationAllowedSourceNamespaces: allowed,
+			}
+		}
+		return ns
+	}
+
+	tests := []struct {
+		name              string
+		namespaces        []string
+		namespaceSelector *metav1.LabelSelector
+		wantDenied        []string
+	}{
+		{
+			name:       "not-annotated",
+			namespaces: []string{"allowed", "kube-system"},
+			wantDenied: []string{"kube-system"},
+		},
+		{
+			name:       "other-source",
+			namespaces: []string{"other-source"},
+			wantDenied: []string{"other-source"},
+		},
+		{
+			name:       "not-found",
+			namespaces: []string{"missing"},
+			wantDenied: []string{"missing"},
+		},
+		{
+			name:              "selector",
+			namespaces:        []string{"kube-system"},
+			namespaceSelector: &metav1.LabelSelector{},
+			wantDenied:        []string{"kube-system", "other-source"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := testutils.NewFakeClientBuilder().WithObjects(
+				namespace("app", ""),
+				namespace("allowed", "*"),
+				namespace("kube-system", ""),
+				namespace("other-source", "team-a,team-b"),
+			).Build()
+
+			obj := &secretsv1beta1.VaultStaticSecret{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "VaultStaticSecret",
+					APIVersion: secretsv1beta1.GroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "creds",
+					Namespace:  "app",
+					UID:        "a1b2c3d4-0000-0000-0000-000000000003",
+					Generation: 1,
+				},
+				Spec: secretsv1beta1.VaultStaticSecretSpec{
+					Destination: secretsv1beta1.Destination{
+						Name:              "creds",
+						Create:            true,
+						Namespaces:        tt.namespaces,
+						NamespaceSelector: tt.namespaceSelector,
+					},
+				},
+			}
+
+			err := SyncSecret(ctx, client, obj, map[string][]byte{"foo": []byte("bar")})
+			var deniedErr *FanOutDeniedError
+			require.ErrorAs(t, err, &deniedErr)
+			assert.Equal(t, "app", deniedErr.SourceNamespace)
+			assert.Equal(t, tt.wantDenied, deniedErr.Namespaces)
+			assert.True(t, IsFanOutDenied(err))
+
+			// nothing is synced, not even to the allowed namespaces.
+			var secrets corev1.SecretList
+			require.NoError(t, client.List(ctx, &secrets))
+			assert.Empty(t, secrets.Items)
+
+			SetFanOutDeniedCondition(&obj.Status.Conditions, obj.Generation, err)
+			cond := meta.FindStatusCondition(obj.Status.Conditions, secretsv1beta1.ConditionTypeFanOutDenied)
+			require.NotNil(t, cond)
+			assert.Equal(t, metav1.ConditionTrue, cond.Status)
+			assert.Equal(t, consts.ReasonFanOutDenied, cond.Reason)
+			assert.Equal(t, err.Error(), cond.Message)
+
+			// the condition is cleared once the namespaces are fixed.
+			SetFanOutDeniedCondition(&obj.Status.Conditions, obj.Generation, nil)
+			cond = meta.FindStatusCondition(obj.Status.Conditions, secretsv1beta1.ConditionTypeFanOutDenied)
+			require.NotNil(t, cond)
+			assert.Equal(t, metav1.ConditionFalse, cond.Status)
+		})
+	}
+}
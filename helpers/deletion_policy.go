@@ -0,0 +1,120 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package helpers
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/hashicorp/vault-secrets-operator/common"
+	"github.com/hashicorp/vault-secrets-operator/consts"
+)
+
+const (
+	DeletionPolicyDelete = "Delete"
+	DeletionPolicyRetain = "Retain"
+	DeletionPolicyOrphan = "Orphan"
+)
+
+// HandleDestinationDeletion applies the Destination.DeletionPolicy of obj to
+// the Secrets that it owns. It should be called when obj is being deleted,
+// prior to removing its finalizer. Retain only applies to the Secrets in obj's
+// namespace, the Secrets outside of it are deleted as per CascadeDelete, like
+// with Delete.
+//
+// See NewSyncableSecretMetaData for the supported types for obj.
+func HandleDestinationDeletion(ctx context.Context, client ctrlclient.Client, obj ctrlclient.Object) error {
+	meta, err := common.NewSyncableSecretMetaData(obj)
+	if err != nil {
+		return err
+	}
+
+	switch meta.Destination.DeletionPolicy {
+	case DeletionPolicyOrphan:
+		return releaseOwnedSecrets(ctx, client, obj, true)
+	case DeletionPolicyRetain:
+		if err := releaseOwnedSecrets(ctx, client, obj, false); err != nil {
+			return err
+		}
+		return DeleteCrossNamespaceSecrets(ctx, client, obj)
+	default:
+		return DeleteCrossNamespaceSecrets(ctx, client, obj)
+	}
+}
+
+// releaseOwnedSecrets removes the owner references to obj from the Secrets in
+// its namespace, so that they are not garbage collected with it. If orphan is
+// true, all the Operator's ownership metadata is removed from them, and from
+// the Secrets that obj owns in the other namespaces.
+func releaseOwnedSecrets(ctx context.Context, client ctrlclient.Client, obj ctrlclient.Object, orphan bool) error {
+	// an object without a UID cannot own any Secrets.
+	if obj.GetUID() == "" {
+		return nil
+	}
+
+	owned, err := FindSecretsOwnedByObj(ctx, client, obj)
+	if err != nil {
+		return err
+	}
+	if orphan {
+		crossNamespace, err := FindCrossNamespaceSecretsOwnedByObj(ctx, client, obj)
+		if err != nil {
+			return err
+		}
+		owned = append(owned, crossNamespace...)
+	}
+
+	logger := log.FromContext(ctx).WithName("releaseOwnedSecrets").WithValues("orphan", orphan)
+	var errs error
+	for _, s := range owned {
+		patch := ctrlclient.MergeFrom(s.DeepCopy())
+		s.SetOwnerReferences(slices.DeleteFunc(s.GetOwnerReferences(), func(ref metav1.OwnerReference) bool {
+			return ref.UID == obj.GetUID()
+		}))
+		if orphan {
+			removeOwnerMetadata(&s)
+		}
+		if err := client.Patch(ctx, &s, patch, ctrlclient.FieldOwner(FieldManager)); err != nil && !apierrors.IsNotFound(err) {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		logger.V(consts.LogLevelDebug).Info("Released Secret",
+			"secret", ctrlclient.ObjectKeyFromObject(&s))
+	}
+
+	return errs
+}
+
+// removeOwnerMetadata removes the labels and annotations that associate the
+// Secret s with its owner.
+func removeOwnerMetadata(s *corev1.Secret) {
+	labels := s.GetLabels()
+	for k := range OwnerLabels {
+		delete(labels, k)
+	}
+	delete(labels, labelOwnerRefUID)
+	delete(labels, labelOwnerNamespace)
+	s.SetLabels(labels)
+
+	annotations := s.GetAnnotations()
+	delete(annotations, annotationOwnerRef)
+	delete(annotations, annotationFanOutNamespaces)
+	s.SetAnnotations(annotations)
+}
+
+// isRetainedSecret returns true if the Secret s was created by the Operator, and
+// then retained upon the deletion of its owner. See DeletionPolicyRetain.
+func isRetainedSecret(s *corev1.Secret) bool {
+	if _, _, ok := CrossNamespaceOwner(s); ok {
+		return false
+	}
+	return HasOwnerLabels(s) && len(s.GetOwnerReferences()) == 0
+}
@@ -0,0 +1,142 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package helpers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/internal/testutils"
+)
+
+func TestHandleDestinationDeletion(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	tests := []struct {
+		name                string
+		policy              string
+		noCascadeDelete     bool
+		wantCrossNamespace  bool
+		wantOwnerReferences bool
+		wantOwnerLabels     bool
+	}{
+		{
+			name:                "delete",
+			policy:              DeletionPolicyDelete,
+			wantOwnerReferences: true,
+			wantOwnerLabels:     true,
+		},
+		{
+			name:                "default",
+			wantOwnerReferences: true,
+			wantOwnerLabels:     true,
+		},
+		{
+			name:                "delete-no-cascade",
+			policy:              DeletionPolicyDelete,
+			noCascadeDelete:     true,
+			wantCrossNamespace:  true,
+			wantOwnerReferences: true,
+			wantOwnerLabels:     true,
+		},
+		{
+			// the cross-namespace Secrets are deleted as per CascadeDelete.
+			name:            "retain",
+			policy:          DeletionPolicyRetain,
+			wantOwnerLabels: true,
+		},
+		{
+			name:               "retain-no-cascade",
+			policy:             DeletionPolicyRetain,
+			noCascadeDelete:    true,
+			wantCrossNamespace: true,
+			wantOwnerLabels:    true,
+		},
+		{
+			name:               "orphan",
+			policy:             DeletionPolicyOrphan,
+			wantCrossNamespace: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			owner := &secretsv1beta1.VaultStaticSecret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo",
+					Namespace: "baz",
+					UID:       "a1b2c3d4-0000-0000-0000-000000000003",
+				},
+				Spec: secretsv1beta1.VaultStaticSecretSpec{
+					Destination: secretsv1beta1.Destination{
+						Name:           "dest",
+						CascadeDelete:  !tt.noCascadeDelete,
+						DeletionPolicy: tt.policy,
+					},
+				},
+			}
+			client := testutils.NewFakeClientBuilder().Build()
+			labels, annotations, err := CrossNamespaceOwnerMetadataForObj(owner, client.Scheme())
+			require.NoError(t, err)
+
+			ownerLabels, err := OwnerLabelsForObj(owner)
+			require.NoError(t, err)
+			ownerLabels["app"] = "foo"
+			sameNamespace := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "dest",
+					Namespace: "baz",
+					Labels:    ownerLabels,
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							APIVersion: "secrets.hashicorp.com/v1beta1",
+							Kind:       "VaultStaticSecret",
+							Name:       owner.Name,
+							UID:        owner.UID,
+						},
+					},
+				},
+			}
+			crossNamespace := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "dest",
+					Namespace:   "other",
+					Labels:      labels,
+					Annotations: annotations,
+				},
+			}
+			for _, o := range []ctrlclient.Object{sameNamespace, crossNamespace} {
+				require.NoError(t, client.Create(ctx, o))
+			}
+
+			require.NoError(t, HandleDestinationDeletion(ctx, client, owner))
+
+			got, exists, err := getSecretExists(ctx, client, ctrlclient.ObjectKeyFromObject(sameNamespace))
+			require.NoError(t, err)
+			require.True(t, exists)
+			assert.Equal(t, tt.wantOwnerReferences, len(got.OwnerReferences) > 0)
+			assert.Equal(t, tt.wantOwnerLabels, HasOwnerLabels(got))
+			assert.Equal(t, "foo", got.Labels["app"])
+			assert.Equal(t, tt.policy == DeletionPolicyRetain, isRetainedSecret(got))
+
+			got, exists, err = getSecretExists(ctx, client, ctrlclient.ObjectKeyFromObject(crossNamespace))
+			require.NoError(t, err)
+			require.Equal(t, tt.wantCrossNamespace, exists)
+			if exists {
+				_, _, owned := CrossNamespaceOwner(got)
+				// only Orphan releases the cross-namespace Secrets.
+				assert.Equal(t, tt.policy != DeletionPolicyOrphan, owned)
+			}
+		})
+	}
+}
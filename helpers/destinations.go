@@ -0,0 +1,120 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package helpers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/common"
+)
+
+// SecretDataFunc builds the K8s Secret data of a destination from the secret
+// data that was read from Vault, given the destination's transformation.
+type SecretDataFunc func(opt *SecretTransformationOption) (map[string][]byte, error)
+
+// SyncAdditionalDestinations syncs the data built by dataFunc to each of the
+// additional destinations of obj. Each destination is synced as if it was the
+// Destination of obj, so that its own transformation applies. The Secrets of
+// the destinations that were removed are pruned when the Destination of obj
+// is synced.
+//
+// See common.NewSyncableSecretMetaData for the types of obj that support
+// additional destinations.
+func SyncAdditionalDestinations(ctx context.Context, client ctrlclient.Client, obj ctrlclient.Object,
+	globalOpt *GlobalTransformationOptions, dataFunc SecretDataFunc,
+) error {
+	meta, err := common.NewSyncableSecretMetaData(obj)
+	if err != nil {
+		return err
+	}
+
+	if err := validateAdditionalDestinations(meta); err != nil {
+		return err
+	}
+
+	var errs error
+	for _, d := range meta.AdditionalDestinations {
+		if err := syncAdditionalDestination(ctx, client, obj, d, globalOpt, dataFunc); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to sync the additional destination %q: %w", d.Name, err))
+		}
+	}
+	return errs
+}
+
+func syncAdditionalDestination(ctx context.Context, client ctrlclient.Client, obj ctrlclient.Object,
+	d secretsv1beta1.Destination, globalOpt *GlobalTransformationOptions, dataFunc SecretDataFunc,
+) error {
+	destObj, err := withDestination(obj, d)
+	if err != nil {
+		return err
+	}
+
+	opt, err := NewSecretTransformationOption(ctx, client, destObj, globalOpt)
+	if err != nil {
+		return err
+	}
+
+	data, err := dataFunc(opt)
+	if err != nil {
+		return err
+	}
+
+	// orphans are pruned when the Destination of obj is synced.
+	return SyncSecret(ctx, client, destObj, data, SyncOptions{})
+}
+
+// validateAdditionalDestinations returns an error if the name of any of the
+// additional destinations is empty, or is not unique.
+func validateAdditionalDestinations(meta *common.SyncableSecretMetaData) error {
+	names := map[string]bool{
+		meta.Destination.Name: true,
+	}
+	for _, d := range meta.AdditionalDestinations {
+		if d.Name == "" {
+			return errors.New("invalid additional destination, name is required")
+		}
+		if d.Immutable {
+			return fmt.Errorf("invalid additional destination %q, immutable is not supported", d.Name)
+		}
+		if names[d.Name] {
+			return fmt.Errorf("invalid additional destination, name %q is not unique", d.Name)
+		}
+		names[d.Name] = true
+	}
+	return nil
+}
+
+// additionalDestinationNames returns the set of the names of the additional
+// destinations of meta.
+func additionalDestinationNames(meta *common.SyncableSecretMetaData) map[string]bool {
+	ret := make(map[string]bool, len(meta.AdditionalDestinations))
+	for _, d := range meta.AdditionalDestinations {
+		ret[d.Name] = true
+	}
+	return ret
+}
+
+// withDestination returns a copy of obj whose Destination is d, and which has
+// no additional destinations.
+func withDestination(obj ctrlclient.Object, d secretsv1beta1.Destination) (ctrlclient.Object, error) {
+	switch t := obj.(type) {
+	case *secretsv1beta1.VaultStaticSecret:
+		o := t.DeepCopy()
+		o.Spec.Destination = d
+		o.Spec.AdditionalDestinations = nil
+		return o, nil
+	case *secretsv1beta1.VaultDynamicSecret:
+		o := t.DeepCopy()
+		o.Spec.Destination = d
+		o.Spec.AdditionalDestinations = nil
+		return o, nil
+	default:
+		return nil, fmt.Errorf("additional destinations are not supported for %T", obj)
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package helpers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/internal/testutils"
+)
+
+func TestSyncAdditionalDestinations(t *testing.T) {
+	ctx := context.Background()
+	client := testutils.NewFakeClientBuilder().Build()
+
+	obj := &secretsv1beta1.VaultStaticSecret{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "VaultStaticSecret",
+			APIVersion: secretsv1beta1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "app",
+			Namespace: "tenant",
+			UID:       "a1b2c3d4-0000-0000-0000-000000000003",
+		},
+		Spec: secretsv1beta1.VaultStaticSecretSpec{
+			Destination: secretsv1beta1.Destination{
+				Name:   "app",
+				Create: true,
+			},
+			AdditionalDestinations: []secretsv1beta1.Destination{
+				{
+					Name:   "app-env",
+					Create: true,
+					KeyMap: map[string]string{
+						"username": "DB_USER",
+						"password": "DB_PASSWORD",
+					},
+					Transformation: secretsv1beta1.Transformation{
+						ExcludeRaw: true,
+					},
+				},
+				{
+					Name:   "app-basic-auth",
+					Create: true,
+					Type:   corev1.SecretTypeBasicAuth,
+					Transformation: secretsv1beta1.Transformation{
+						ExcludeRaw: true,
+					},
+				},
+			},
+		},
+	}
+	vaultData := map[string]any{
+		"username": "alice",
+		"password": "s3cr3t",
+	}
+	var calls int
+	dataFunc := func(opt *SecretTransformationOption) (map[string][]byte, error) {
+		calls++
+		return NewSecretsDataBuilder().WithVaultData(vaultData, vaultData, opt)
+	}
+
+	getSecret := func(name string) (*corev1.Secret, bool) {
+		t.Helper()
+		s, exists, err := getSecretExists(ctx, client, ctrlclient.ObjectKey{Namespace: "tenant", Name: name})
+		require.NoError(t, err)
+		return s, exists
+	}
+
+	require.NoError(t, SyncSecret(ctx, client, obj, map[string][]byte{"username": []byte("alice")}))
+	require.NoError(t, SyncAdditionalDestinations(ctx, client, obj, nil, dataFunc))
+	assert.Equal(t, 2, calls)
+
+	s, ok := getSecret("app-env")
+	require.True(t, ok)
+	assert.Equal(t, map[string][]byte{
+		"DB_USER":     []byte("alice"),
+		"DB_PASSWORD": []byte("s3cr3t"),
+	}, s.Data)
+	assert.Equal(t, corev1.SecretTypeOpaque, s.Type)
+	assert.NoError(t, checkSecretIsOwnedByObj(s, []metav1.OwnerReference{
+		{
+			APIVersion: obj.APIVersion,
+			Kind:       obj.Kind,
+			Name:       obj.Name,
+			UID:        obj.UID,
+		},
+	}))
+
+	s, ok = getSecret("app-basic-auth")
+	require.True(t, ok)
+	assert.Equal(t, corev1.SecretTypeBasicAuth, s.Type)
+	assert.Equal(t, []byte("s3cr3t"), s.Data["password"])
+
+	// syncing the Destination does not prune the additional destinations.
+	require.NoError(t, SyncSecret(ctx, client, obj, map[string][]byte{"username": []byte("alice")}))
+	_, ok = getSecret("app-env")
+	assert.True(t, ok)
+
+	// removed additional destinations are pruned.
+	obj.Spec.AdditionalDestinations = obj.Spec.AdditionalDestinations[1:]
+	require.NoError(t, SyncSecret(ctx, client, obj, map[string][]byte{"username": []byte("alice")}))
+	_, ok = getSecret("app-env")
+	assert.False(t, ok)
+	_, ok = getSecret("app-basic-auth")
+	assert.True(t, ok)
+
+	obj.Spec.AdditionalDestinations = append(obj.Spec.AdditionalDestinations, secretsv1beta1.Destination{
+		Name:   "app",
+		Create: true,
+	})
+	assert.EqualError(t, SyncAdditionalDestinations(ctx, client, obj, nil, dataFunc),
+		`invalid additional destination, name "app" is not unique`)
+
+	_, err := withDestination(&secretsv1beta1.VaultPKISecret{}, secretsv1beta1.Destination{})
+	assert.EqualError(t, err, "additional destinations are not supported for *v1beta1.VaultPKISecret")
+}
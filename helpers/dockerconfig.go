@@ -0,0 +1,132 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package helpers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+)
+
+const (
+	// defaultUsernameKey and defaultPasswordKey are the source secret data
+	// fields that hold the credentials, unless configured otherwise.
+	defaultUsernameKey = "username"
+	defaultPasswordKey = "password"
+)
+
+// dockerConfigEntry is the registry credentials of a .dockerconfigjson
+// payload, see https://kubernetes.io/docs/concepts/configuration/secret/#docker-config-secrets
+type dockerConfigEntry struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Email    string `json:"email,omitempty"`
+	Auth     string `json:"auth"`
+}
+
+type dockerConfigJSON struct {
+	Auths map[string]dockerConfigEntry `json:"auths"`
+}
+
+// addDockerConfigJSON adds the .dockerconfigjson payload that is rendered from
+// the SecretTransformationOption's DockerConfigJSON to data, which typically
+// holds the rendered templates.
+func addDockerConfigJSON(opt *SecretTransformationOption, d map[string]any, data map[string][]byte) error {
+	if opt.DockerConfigJSON == nil {
+		return nil
+	}
+
+	b, err := renderDockerConfigJSON(opt.DockerConfigJSON, d)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := data[corev1.DockerConfigJsonKey]; ok {
+		return fmt.Errorf("key %q from dockerConfigJSON conflicts with a template", corev1.DockerConfigJsonKey)
+	}
+	data[corev1.DockerConfigJsonKey] = b
+
+	return nil
+}
+
+// renderDockerConfigJSON renders the registry credentials found in d into a
+// .dockerconfigjson payload.
+func renderDockerConfigJSON(c *secretsv1beta1.DockerConfigJSON, d map[string]any) ([]byte, error) {
+	if (c.Server == "") == (c.ServerKey == "") {
+		return nil, errors.New("dockerConfigJSON requires exactly one of server or serverKey")
+	}
+
+	server := c.Server
+	if c.ServerKey != "" {
+		var err error
+		if server, err = sourceField(d, "dockerConfigJSON", c.ServerKey, true); err != nil {
+			return nil, err
+		}
+	}
+
+	usernameKey := c.UsernameKey
+	if usernameKey == "" {
+		usernameKey = defaultUsernameKey
+	}
+	username, err := sourceField(d, "dockerConfigJSON", usernameKey, true)
+	if err != nil {
+		return nil, err
+	}
+
+	passwordKey := c.PasswordKey
+	if passwordKey == "" {
+		passwordKey = defaultPasswordKey
+	}
+	password, err := sourceField(d, "dockerConfigJSON", passwordKey, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var email string
+	if c.EmailKey != "" {
+		if email, err = sourceField(d, "dockerConfigJSON", c.EmailKey, false); err != nil {
+			return nil, err
+		}
+	}
+
+	return json.Marshal(dockerConfigJSON{
+		Auths: map[string]dockerConfigEntry{
+			server: {
+				Username: username,
+				Password: password,
+				Email:    email,
+				Auth:     base64.StdEncoding.EncodeToString([]byte(username + ":" + password)),
+			},
+		},
+	})
+}
+
+// sourceField returns the string value of the field in d, that is mapped by
+// the named transformation option. An error is returned if the field is not a
+// string, or if it is required and empty.
+func sourceField(d map[string]any, option, field string, required bool) (string, error) {
+	v, ok := d[field]
+	if !ok {
+		if required {
+			return "", fmt.Errorf("%s field %q not found in secret data", option, field)
+		}
+		return "", nil
+	}
+
+	s, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("%s field %q is not a string", option, field)
+	}
+
+	if required && s == "" {
+		return "", fmt.Errorf("%s field %q is empty", option, field)
+	}
+
+	return s, nil
+}
@@ -0,0 +1,154 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package helpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+)
+
+func Test_addDockerConfigJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		config  *secretsv1beta1.DockerConfigJSON
+		d       map[string]any
+		data    map[string][]byte
+		want    map[string][]byte
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "not-configured",
+			d: map[string]any{
+				"username": "foo",
+			},
+			data:    map[string][]byte{},
+			want:    map[string][]byte{},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "server-default-keys",
+			config: &secretsv1beta1.DockerConfigJSON{
+				Server: "ghcr.io",
+			},
+			d: map[string]any{
+				"username": "foo",
+				"password": "bar",
+			},
+			data: map[string][]byte{},
+			want: map[string][]byte{
+				corev1.DockerConfigJsonKey: []byte(
+					`{"auths":{"ghcr.io":{"username":"foo","password":"bar","auth":"Zm9vOmJhcg=="}}}`),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "server-key-with-email",
+			config: &secretsv1beta1.DockerConfigJSON{
+				ServerKey:   "registry",
+				UsernameKey: "user",
+				PasswordKey: "token",
+				EmailKey:    "email",
+			},
+			d: map[string]any{
+				"registry": "registry.example.com",
+				"user":     "foo",
+				"token":    "bar",
+				"email":    "foo@example.com",
+			},
+			data: map[string][]byte{
+				"other": []byte("baz"),
+			},
+			want: map[string][]byte{
+				"other": []byte("baz"),
+				corev1.DockerConfigJsonKey: []byte(
+					`{"auths":{"registry.example.com":{"username":"foo","password":"bar","email":"foo@example.com","auth":"Zm9vOmJhcg=="}}}`),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "server-and-server-key",
+			config: &secretsv1beta1.DockerConfigJSON{
+				Server:    "ghcr.io",
+				ServerKey: "registry",
+			},
+			data: map[string][]byte{},
+			want: map[string][]byte{},
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.EqualError(t, err,
+					"dockerConfigJSON requires exactly one of server or serverKey", i...)
+			},
+		},
+		{
+			name: "missing-password",
+			config: &secretsv1beta1.DockerConfigJSON{
+				Server: "ghcr.io",
+			},
+			d: map[string]any{
+				"username": "foo",
+			},
+			data: map[string][]byte{},
+			want: map[string][]byte{},
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.EqualError(t, err,
+					`dockerConfigJSON field "password" not found in secret data`, i...)
+			},
+		},
+		{
+			name: "invalid-username",
+			config: &secretsv1beta1.DockerConfigJSON{
+				Server: "ghcr.io",
+			},
+			d: map[string]any{
+				"username": 1,
+				"password": "bar",
+			},
+			data: map[string][]byte{},
+			want: map[string][]byte{},
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.EqualError(t, err,
+					`dockerConfigJSON field "username" is not a string`, i...)
+			},
+		},
+		{
+			name: "template-conflict",
+			config: &secretsv1beta1.DockerConfigJSON{
+				Server: "ghcr.io",
+			},
+			d: map[string]any{
+				"username": "foo",
+				"password": "bar",
+			},
+			data: map[string][]byte{
+				corev1.DockerConfigJsonKey: []byte("{}"),
+			},
+			want: map[string][]byte{
+				corev1.DockerConfigJsonKey: []byte("{}"),
+			},
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.EqualError(t, err,
+					`key ".dockerconfigjson" from dockerConfigJSON conflicts with a template`, i...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			opt := &SecretTransformationOption{
+				DockerConfigJSON: tt.config,
+			}
+			err := addDockerConfigJSON(opt, tt.d, tt.data)
+			if !tt.wantErr(t, err) {
+				return
+			}
+			assert.Equal(t, tt.want, tt.data)
+		})
+	}
+}
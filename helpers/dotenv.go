@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package helpers
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+const defaultDotEnvKey = ".env"
+
+// dotEnvNameRegex matches the keys that are valid environment variable names.
+var dotEnvNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// dotEnvEscaper escapes the values, which are always double-quoted, so that
+// they are never interpolated by the dotenv loaders.
+var dotEnvEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`"`, `\"`,
+	`$`, `\$`,
+	"\n", `\n`,
+	"\r", `\r`,
+)
+
+// addDotEnv adds the dotenv file that is configured by the
+// SecretTransformationOption's DotEnv to data. The file contains every key of
+// data, other than _raw, sorted. An error is returned if any of the keys is not
+// a valid environment variable name.
+func addDotEnv(opt *SecretTransformationOption, data map[string][]byte) (map[string][]byte, error) {
+	if opt == nil || opt.DotEnv == nil {
+		return data, nil
+	}
+
+	key := opt.DotEnv.Key
+	if key == "" {
+		key = defaultDotEnvKey
+	}
+	if _, ok := data[key]; ok {
+		return nil, fmt.Errorf("key %q from dotEnv conflicts with the secret data", key)
+	}
+
+	var b strings.Builder
+	for _, k := range slices.Sorted(maps.Keys(data)) {
+		if k == SecretDataKeyRaw {
+			continue
+		}
+		if !dotEnvNameRegex.MatchString(k) {
+			return nil, fmt.Errorf(
+				"key %q is not a valid environment variable name for dotEnv, "+
+					"it can be renamed with the keyMap, or omitted with the excludes", k)
+		}
+		fmt.Fprintf(&b, "%s=\"%s\"\n", k, dotEnvEscaper.Replace(string(data[k])))
+	}
+
+	if opt.DotEnv.Only {
+		for k := range data {
+			if k != SecretDataKeyRaw {
+				delete(data, k)
+			}
+		}
+	}
+	data[key] = []byte(b.String())
+
+	return data, nil
+}
@@ -0,0 +1,123 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package helpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+)
+
+func Test_addDotEnv(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		data    map[string][]byte
+		dotEnv  *secretsv1beta1.DotEnv
+		want    map[string][]byte
+		wantErr string
+	}{
+		{
+			name: "no-dotenv",
+			data: map[string][]byte{
+				"foo": []byte("bar"),
+			},
+			want: map[string][]byte{
+				"foo": []byte("bar"),
+			},
+		},
+		{
+			name: "default-key",
+			data: map[string][]byte{
+				"USER":           []byte("alice"),
+				"PASSWORD":       []byte(`p"a$s\s`),
+				"CERT":           []byte("line1\nline2"),
+				SecretDataKeyRaw: []byte(`{}`),
+			},
+			dotEnv: &secretsv1beta1.DotEnv{},
+			want: map[string][]byte{
+				"USER":           []byte("alice"),
+				"PASSWORD":       []byte(`p"a$s\s`),
+				"CERT":           []byte("line1\nline2"),
+				SecretDataKeyRaw: []byte(`{}`),
+				".env": []byte(`CERT="line1\nline2"` + "\n" +
+					`PASSWORD="p\"a\$s\\s"` + "\n" +
+					`USER="alice"` + "\n"),
+			},
+		},
+		{
+			name: "only",
+			data: map[string][]byte{
+				"user":           []byte("alice"),
+				SecretDataKeyRaw: []byte(`{}`),
+			},
+			dotEnv: &secretsv1beta1.DotEnv{
+				Key:  "app.env",
+				Only: true,
+			},
+			want: map[string][]byte{
+				SecretDataKeyRaw: []byte(`{}`),
+				"app.env":        []byte(`user="alice"` + "\n"),
+			},
+		},
+		{
+			name: "invalid-name",
+			data: map[string][]byte{
+				"db.host": []byte("db"),
+			},
+			dotEnv:  &secretsv1beta1.DotEnv{},
+			wantErr: `key "db.host" is not a valid environment variable name for dotEnv`,
+		},
+		{
+			name: "conflict",
+			data: map[string][]byte{
+				".env": []byte("FOO=bar"),
+			},
+			dotEnv:  &secretsv1beta1.DotEnv{},
+			wantErr: `key ".env" from dotEnv conflicts with the secret data`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := addDotEnv(&SecretTransformationOption{DotEnv: tt.dotEnv}, tt.data)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSecretDataBuilder_WithVaultData_dotEnv(t *testing.T) {
+	t.Parallel()
+
+	opt := &SecretTransformationOption{
+		ExcludeRaw: true,
+		Flatten: &secretsv1beta1.Flatten{
+			UpperCase: true,
+		},
+		DotEnv: &secretsv1beta1.DotEnv{
+			Only: true,
+		},
+	}
+	d := map[string]any{
+		"db": map[string]any{
+			"host": "db.example.com",
+			"port": 5432,
+		},
+	}
+	got, err := NewSecretsDataBuilder().WithVaultData(d, d, opt)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		".env": []byte("DB_HOST=\"db.example.com\"\nDB_PORT=\"5432\"\n"),
+	}, got)
+}
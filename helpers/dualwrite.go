@@ -0,0 +1,92 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package helpers
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/hashicorp/vault-secrets-operator/common"
+	"github.com/hashicorp/vault-secrets-operator/internal/dualwrite"
+)
+
+// dualWriteSecret writes the destination Secret of obj, as it was synced with
+// data, into the migration's target cluster, if dual-write is enabled. The
+// Secret is written without its owner references, so that it is treated as a
+// retained Secret there, that can be adopted with
+// Destination.AdoptIfOwnerGone. An error is returned if the Secret could not
+// be written, so that the sync is retried, since the Secrets of both clusters
+// would otherwise differ.
+func dualWriteSecret(ctx context.Context, client ctrlclient.Client, obj ctrlclient.Object, data map[string][]byte) error {
+	if dualwrite.DefaultWriter == nil {
+		return nil
+	}
+
+	meta, err := common.NewSyncableSecretMetaData(obj)
+	if err != nil {
+		return err
+	}
+	// the data of secretless destinations is not stored in a Secret.
+	if meta.Destination.Secretless != nil {
+		return nil
+	}
+
+	name := meta.Destination.Name
+	if meta.Destination.Immutable {
+		name, err = immutableSecretName(name, destinationSecretType(meta.Destination), data)
+		if err != nil {
+			return err
+		}
+	}
+
+	s, err := GetSecret(ctx, client, ctrlclient.ObjectKey{
+		Namespace: obj.GetNamespace(),
+		Name:      name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get the destination secret for dual-write: %w", err)
+	}
+
+	if err := dualwrite.DefaultWriter.Write(ctx, dualWriteCopy(s)); err != nil {
+		return fmt.Errorf("failed to dual-write the destination secret: %w", err)
+	}
+
+	return nil
+}
+
+// DualWriteSecrets returns all the destination Secrets, as they are written
+// into the migration's target cluster. They are used to backfill the target
+// cluster, see dualwrite.Backfiller. The copies in the fan-out namespaces are
+// not included, since they are not written to the target cluster.
+func DualWriteSecrets(ctx context.Context, client ctrlclient.Client) ([]*corev1.Secret, error) {
+	var list corev1.SecretList
+	if err := client.List(ctx, &list, ctrlclient.MatchingLabels(OwnerLabels)); err != nil {
+		return nil, err
+	}
+
+	var result []*corev1.Secret
+	for i := range list.Items {
+		s := &list.Items[i]
+		if _, ok := s.Labels[labelOwnerNamespace]; ok || len(s.OwnerReferences) == 0 {
+			continue
+		}
+		result = append(result, dualWriteCopy(s))
+	}
+
+	return result, nil
+}
+
+// dualWriteCopy returns s without its owner references, or the namespaces of its
+// fan-out copies.
+func dualWriteCopy(s *corev1.Secret) *corev1.Secret {
+	s = s.DeepCopy()
+	s.SetOwnerReferences(nil)
+	annotations := s.GetAnnotations()
+	delete(annotations, annotationFanOutNamespaces)
+	s.SetAnnotations(annotations)
+	return s
+}
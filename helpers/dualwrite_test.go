@@ -0,0 +1,88 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package helpers
+
+import (
+	"context"
+	"maps"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/hashicorp/vault-secrets-operator/internal/dualwrite"
+	"github.com/hashicorp/vault-secrets-operator/internal/testutils"
+)
+
+func TestDualWriteSecrets_backfill(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ownerRefs := []metav1.OwnerReference{
+		{
+			APIVersion: "secrets.hashicorp.com/v1beta1",
+			Kind:       "VaultStaticSecret",
+			Name:       "foo",
+			UID:        "uid-foo",
+		},
+	}
+	// synced before the migration window started, and never rotated since.
+	synced := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "creds",
+			Namespace:       "baz",
+			Labels:          maps.Clone(OwnerLabels),
+			Annotations:     map[string]string{annotationFanOutNamespaces: "other"},
+			OwnerReferences: ownerRefs,
+		},
+		Data: map[string][]byte{"password": []byte("secret")},
+	}
+	copyLabels := maps.Clone(OwnerLabels)
+	copyLabels[labelOwnerNamespace] = "baz"
+	fanOutCopy := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "creds",
+			Namespace: "other",
+			Labels:    copyLabels,
+		},
+		Data: map[string][]byte{"password": []byte("secret")},
+	}
+	unmanaged := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "unmanaged",
+			Namespace:       "baz",
+			OwnerReferences: ownerRefs,
+		},
+	}
+	client := testutils.NewFakeClientBuilder().WithObjects(synced, fanOutCopy, unmanaged).Build()
+	target := testutils.NewFakeClientBuilder().Build()
+
+	b := &dualwrite.Backfiller{
+		Writer: &dualwrite.Writer{
+			Target: target,
+			Until:  time.Now().Add(time.Hour),
+		},
+		List: func(ctx context.Context) ([]*corev1.Secret, error) {
+			return DualWriteSecrets(ctx, client)
+		},
+	}
+	require.True(t, b.Backfill(ctx))
+
+	var got corev1.Secret
+	require.NoError(t, target.Get(ctx, ctrlclient.ObjectKeyFromObject(synced), &got))
+	assert.Equal(t, synced.Data, got.Data)
+	assert.Equal(t, synced.Labels, got.Labels)
+	assert.Empty(t, got.Annotations)
+	assert.Empty(t, got.OwnerReferences)
+
+	for _, s := range []*corev1.Secret{fanOutCopy, unmanaged} {
+		err := target.Get(ctx, ctrlclient.ObjectKeyFromObject(s), &corev1.Secret{})
+		assert.True(t, apierrors.IsNotFound(err), "%s/%s", s.Namespace, s.Name)
+	}
+}
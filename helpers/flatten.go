@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package helpers
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// flattenData returns data with its nested objects expanded into top-level
+// keys, according to the SecretTransformationOption's Flatten. The keys of the
+// nested objects are joined with the separator, and all the keys are converted
+// to upper case if requested. Empty objects, and arrays, are kept as is. An
+// error is returned if two fields are flattened to the same key.
+func flattenData[V any](opt *SecretTransformationOption, data map[string]V) (map[string]V, error) {
+	if opt == nil || opt.Flatten == nil {
+		return data, nil
+	}
+
+	sep := opt.Flatten.Separator
+	if sep == "" {
+		sep = "_"
+	}
+
+	m := make(map[string]V, len(data))
+	sources := make(map[string]string, len(data))
+	// path is the dotted path of the field in the secret data, used in the
+	// error messages.
+	var add func(key, path string, v any) error
+	add = func(key, path string, v any) error {
+		if obj, ok := v.(map[string]any); ok && len(obj) > 0 {
+			// sorted for stable error messages.
+			for _, k := range slices.Sorted(maps.Keys(obj)) {
+				if err := add(key+sep+k, path+"."+k, obj[k]); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		if opt.Flatten.UpperCase {
+			key = strings.ToUpper(key)
+		}
+		if source, ok := sources[key]; ok {
+			return fmt.Errorf("flattened key %q of %q conflicts with %q", key, path, source)
+		}
+
+		value, ok := v.(V)
+		if !ok {
+			return fmt.Errorf("unsupported value type %T of %q", v, path)
+		}
+		sources[key] = path
+		m[key] = value
+		return nil
+	}
+
+	for _, k := range slices.Sorted(maps.Keys(data)) {
+		if err := add(k, k, data[k]); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
@@ -0,0 +1,139 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package helpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+)
+
+func Test_flattenData(t *testing.T) {
+	t.Parallel()
+
+	data := map[string]any{
+		"user": "alice",
+		"db": map[string]any{
+			"host": "db.example.com",
+			"port": float64(5432),
+			"tls": map[string]any{
+				"enabled": true,
+			},
+		},
+		"hosts": []any{"a", "b"},
+		"empty": map[string]any{},
+	}
+	tests := []struct {
+		name    string
+		data    map[string]any
+		flatten *secretsv1beta1.Flatten
+		want    map[string]any
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "no-flatten",
+			data:    data,
+			want:    data,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "default-separator",
+			data:    data,
+			flatten: &secretsv1beta1.Flatten{},
+			want: map[string]any{
+				"user":           "alice",
+				"db_host":        "db.example.com",
+				"db_port":        float64(5432),
+				"db_tls_enabled": true,
+				"hosts":          []any{"a", "b"},
+				"empty":          map[string]any{},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "dot-upper-case",
+			data: data,
+			flatten: &secretsv1beta1.Flatten{
+				Separator: ".",
+				UpperCase: true,
+			},
+			want: map[string]any{
+				"USER":           "alice",
+				"DB.HOST":        "db.example.com",
+				"DB.PORT":        float64(5432),
+				"DB.TLS.ENABLED": true,
+				"HOSTS":          []any{"a", "b"},
+				"EMPTY":          map[string]any{},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "conflict",
+			data: map[string]any{
+				"db_host": "a",
+				"db": map[string]any{
+					"host": "b",
+				},
+			},
+			flatten: &secretsv1beta1.Flatten{},
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.EqualError(t, err,
+					`flattened key "db_host" of "db_host" conflicts with "db.host"`, i...)
+			},
+		},
+		{
+			name: "upper-case-conflict",
+			data: map[string]any{
+				"Host": "a",
+				"host": "b",
+			},
+			flatten: &secretsv1beta1.Flatten{
+				UpperCase: true,
+			},
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.EqualError(t, err,
+					`flattened key "HOST" of "host" conflicts with "Host"`, i...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := flattenData(&SecretTransformationOption{Flatten: tt.flatten}, tt.data)
+			if !tt.wantErr(t, err) {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSecretDataBuilder_WithVaultData_flatten(t *testing.T) {
+	t.Parallel()
+
+	d := map[string]any{
+		"db": map[string]any{
+			"host":     "db.example.com",
+			"password": "s3cr3t",
+		},
+	}
+	got, err := NewSecretsDataBuilder().WithVaultData(d, d, &SecretTransformationOption{
+		Excludes:   []string{"PASSWORD"},
+		ExcludeRaw: true,
+		Flatten: &secretsv1beta1.Flatten{
+			UpperCase: true,
+		},
+		KeyMap: map[string]string{
+			"DB_HOST": "PGHOST",
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"PGHOST": []byte("db.example.com"),
+	}, got)
+}
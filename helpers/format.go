@@ -0,0 +1,141 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package helpers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	FormatTypeYAML       = "yaml"
+	FormatTypeJSON       = "json"
+	FormatTypeProperties = "properties"
+)
+
+// addFormattedFile adds the file that is configured by the
+// SecretTransformationOption's Format to data, which typically holds the
+// rendered templates. The file contains all the fields of secretData.
+func addFormattedFile[V any](opt *SecretTransformationOption, secretData map[string]V, data map[string][]byte) error {
+	if opt.Format == nil {
+		return nil
+	}
+
+	key := opt.Format.Key
+	if key == "" {
+		key = "config." + opt.Format.Type
+	}
+	if _, ok := data[key]; ok {
+		return fmt.Errorf("key %q from format conflicts with a template", key)
+	}
+
+	b, err := json.Marshal(secretData)
+	if err != nil {
+		return err
+	}
+
+	switch opt.Format.Type {
+	case FormatTypeJSON:
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, b, "", "  "); err != nil {
+			return err
+		}
+		buf.WriteString("\n")
+		b = buf.Bytes()
+	case FormatTypeYAML:
+		b, err = yaml.JSONToYAML(b)
+		if err != nil {
+			return err
+		}
+	case FormatTypeProperties:
+		b, err = marshalProperties(b)
+		if err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported format type %q", opt.Format.Type)
+	}
+
+	data[key] = b
+	return nil
+}
+
+// marshalProperties returns the Java properties file of the JSON object b. The
+// keys of the nested objects are joined with ".", and the array elements are
+// suffixed with their index, as is customary for Spring Boot.
+func marshalProperties(b []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var obj map[string]any
+	if err := dec.Decode(&obj); err != nil {
+		return nil, err
+	}
+
+	var buf strings.Builder
+	var add func(key string, v any)
+	add = func(key string, v any) {
+		switch t := v.(type) {
+		case map[string]any:
+			for _, k := range slices.Sorted(maps.Keys(t)) {
+				add(key+"."+k, t[k])
+			}
+		case []any:
+			for i, e := range t {
+				add(fmt.Sprintf("%s[%d]", key, i), e)
+			}
+		case nil:
+			fmt.Fprintf(&buf, "%s=\n", escapeProperty(key, true))
+		default:
+			fmt.Fprintf(&buf, "%s=%s\n", escapeProperty(key, true), escapeProperty(fmt.Sprint(t), false))
+		}
+	}
+	for _, k := range slices.Sorted(maps.Keys(obj)) {
+		add(k, obj[k])
+	}
+
+	return []byte(buf.String()), nil
+}
+
+// escapeProperty escapes s for a Java properties file, the same way as
+// java.util.Properties.store. The files are read as ISO 8859-1, so all the
+// other characters are escaped as Unicode escapes.
+func escapeProperty(s string, isKey bool) string {
+	var b strings.Builder
+	for i, r := range s {
+		switch {
+		case r == '\\':
+			b.WriteString(`\\`)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r == '\f':
+			b.WriteString(`\f`)
+		case r == ' ' && (isKey || i == 0):
+			b.WriteString(`\ `)
+		case r == '=' || r == ':' || r == '#' || r == '!':
+			b.WriteRune('\\')
+			b.WriteRune(r)
+		case r < 0x20 || r > 0x7e:
+			if r > 0xffff {
+				// encoded as a UTF-16 surrogate pair.
+				r -= 0x10000
+				fmt.Fprintf(&b, `\u%04x\u%04x`, 0xd800+(r>>10), 0xdc00+(r&0x3ff))
+			} else {
+				fmt.Fprintf(&b, `\u%04x`, r)
+			}
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,138 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package helpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+)
+
+func Test_addFormattedFile(t *testing.T) {
+	t.Parallel()
+
+	secretData := map[string]any{
+		"user": "alice",
+		"db": map[string]any{
+			"host": "db.example.com",
+			"port": 5432,
+			"tls":  true,
+		},
+		"hosts": []any{"a", "b"},
+	}
+	tests := []struct {
+		name    string
+		format  *secretsv1beta1.DestinationFormat
+		data    map[string][]byte
+		want    map[string][]byte
+		wantErr string
+	}{
+		{
+			name:   "yaml",
+			format: &secretsv1beta1.DestinationFormat{Type: FormatTypeYAML},
+			data:   map[string][]byte{},
+			want: map[string][]byte{
+				"config.yaml": []byte(`db:
+  host: db.example.com
+  port: 5432
+  tls: true
+hosts:
+- a
+- b
+user: alice
+`),
+			},
+		},
+		{
+			name: "json",
+			format: &secretsv1beta1.DestinationFormat{
+				Type: FormatTypeJSON,
+				Key:  "app.json",
+			},
+			data: map[string][]byte{
+				"tmpl": []byte("rendered"),
+			},
+			want: map[string][]byte{
+				"tmpl": []byte("rendered"),
+				"app.json": []byte(`{
+  "db": {
+    "host": "db.example.com",
+    "port": 5432,
+    "tls": true
+  },
+  "hosts": [
+    "a",
+    "b"
+  ],
+  "user": "alice"
+}
+`),
+			},
+		},
+		{
+			name:   "properties",
+			format: &secretsv1beta1.DestinationFormat{Type: FormatTypeProperties},
+			data:   map[string][]byte{},
+			want: map[string][]byte{
+				"config.properties": []byte(`db.host=db.example.com
+db.port=5432
+db.tls=true
+hosts[0]=a
+hosts[1]=b
+user=alice
+`),
+			},
+		},
+		{
+			name:    "conflict",
+			format:  &secretsv1beta1.DestinationFormat{Type: FormatTypeYAML},
+			data:    map[string][]byte{"config.yaml": nil},
+			wantErr: `key "config.yaml" from format conflicts with a template`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := addFormattedFile(&SecretTransformationOption{Format: tt.format}, secretData, tt.data)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, tt.data)
+		})
+	}
+}
+
+func Test_escapeProperty(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, `a\ b\=c\:d`, escapeProperty("a b=c:d", true))
+	assert.Equal(t, `\ a b\#\!\\`, escapeProperty(" a b#!\\", false))
+	assert.Equal(t, `line1\nline2\tcaf\u00e9 \ud83d\udd11`, escapeProperty("line1\nline2\tcafé 🔑", false))
+}
+
+func TestSecretDataBuilder_WithVaultData_format(t *testing.T) {
+	t.Parallel()
+
+	opt := &SecretTransformationOption{
+		ExcludeRaw: true,
+		Excludes:   []string{"^internal$"},
+		KeyMap:     map[string]string{"password": "db_password"},
+		Format:     &secretsv1beta1.DestinationFormat{Type: FormatTypeProperties},
+	}
+	d := map[string]any{
+		"password": "s3cr3t",
+		"internal": "x",
+	}
+	got, err := NewSecretsDataBuilder().WithVaultData(d, d, opt)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"config.properties": []byte("db_password=s3cr3t\n"),
+	}, got)
+}
@@ -12,6 +12,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -35,7 +36,7 @@ var hmacSecretLabels = map[string]string{
 // new-MAC will be returned so that o.Status.SecretHMAC can be updated.
 //
 // Supported types for obj are: VaultDynamicSecret, VaultStaticSecret,
-// VaultPKISecret, HCPVaultSecretsApp
+// VaultPKISecret, HCPVaultSecretsApp, VaultTransitSecret, VaultWrappedSecret
 func HandleSecretHMAC(ctx context.Context, client ctrlclient.Client,
 	validator HMACValidator, obj ctrlclient.Object, data map[string][]byte,
 ) (bool, []byte, error) {
@@ -44,6 +45,11 @@ func HandleSecretHMAC(ctx context.Context, client ctrlclient.Client,
 		return false, nil, err
 	}
 
+	data, err = MACData(data, macIgnoreFields(obj))
+	if err != nil {
+		return false, nil, err
+	}
+
 	// HMAC the Vault secret data so that it can be compared to the what's in the
 	// destination Secret.
 	message, err := json.Marshal(data)
@@ -81,7 +87,8 @@ func HandleSecretHMAC(ctx context.Context, client ctrlclient.Client,
 // HMACDestinationSecret compares the HMAC value stored in o.Status.SecretHMAC to
 // the HMAC of the destination K8s Secret data.
 // Supported types for obj are:
-// VaultDynamicSecret, VaultStaticSecret, VaultPKISecret, HCPVaultSecretsApp
+// VaultDynamicSecret, VaultStaticSecret, VaultPKISecret, HCPVaultSecretsApp,
+// VaultTransitSecret, VaultWrappedSecret
 func HMACDestinationSecret(ctx context.Context, client ctrlclient.Client,
 	validator HMACValidator, obj ctrlclient.Object,
 ) (bool, error) {
@@ -109,7 +116,16 @@ func HMACDestinationSecret(ctx context.Context, client ctrlclient.Client,
 	// out-of-band change made to the Secret's data in this case the controller
 	// should do the sync.
 	if cur, ok, err := GetSyncableSecret(ctx, client, obj); ok {
-		curMessage, err := json.Marshal(cur.Data)
+		curData := cur.Data
+		if meta, err := common.NewSyncableSecretMetaData(obj); err == nil && isMergeMode(meta.Destination) {
+			// the other keys are not managed by the Operator.
+			curData = managedSecretData(cur)
+		}
+		curData, err = MACData(curData, macIgnoreFields(obj))
+		if err != nil {
+			return false, err
+		}
+		curMessage, err := json.Marshal(curData)
 		if err != nil {
 			return false, err
 		}
@@ -125,6 +141,17 @@ func HMACDestinationSecret(ctx context.Context, client ctrlclient.Client,
 		} else {
 			macsEqual = true
 		}
+
+		if macsEqual {
+			drifted, err := destinationMetadataDrifted(obj, cur)
+			if err != nil {
+				return false, err
+			}
+			if drifted {
+				logger.V(consts.LogLevelDebug).Info("Secret metadata drift detected")
+				macsEqual = false
+			}
+		}
 	} else if err != nil {
 		return false, err
 	}
@@ -132,6 +159,75 @@ func HMACDestinationSecret(ctx context.Context, client ctrlclient.Client,
 	return macsEqual, nil
 }
 
+// destinationMetadataDrifted returns true if any of the labels or annotations
+// configured on the Destination of obj is missing from the Secret s, or has a
+// different value. They are only enforced when the Secret is created by the
+// Operator, the owner labels always take precedence over the configured ones.
+func destinationMetadataDrifted(obj ctrlclient.Object, s *corev1.Secret) (bool, error) {
+	meta, err := common.NewSyncableSecretMetaData(obj)
+	if err != nil {
+		return false, err
+	}
+	if !meta.Destination.Create || meta.Destination.Secretless != nil {
+		return false, nil
+	}
+
+	for k, v := range meta.Destination.Labels {
+		if _, ok := OwnerLabels[k]; ok || k == labelOwnerRefUID {
+			continue
+		}
+		if cur, ok := s.Labels[k]; !ok || cur != v {
+			return true, nil
+		}
+	}
+	for k, v := range meta.Destination.Annotations {
+		if cur, ok := s.Annotations[k]; !ok || cur != v {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// MACData returns data without the ignored fields, that are excluded from the
+// HMAC-based change detection. The fields are also removed from the raw secret
+// data stored under SecretDataKeyRaw.
+func MACData(data map[string][]byte, ignored []string) (map[string][]byte, error) {
+	if len(ignored) == 0 {
+		return data, nil
+	}
+
+	result := maps.Clone(data)
+	for _, f := range ignored {
+		delete(result, f)
+	}
+
+	if b, ok := result[SecretDataKeyRaw]; ok {
+		var raw map[string]any
+		if err := json.Unmarshal(b, &raw); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal the raw secret data: %w", err)
+		}
+		for _, f := range ignored {
+			delete(raw, f)
+		}
+		b, err := json.Marshal(raw)
+		if err != nil {
+			return nil, err
+		}
+		result[SecretDataKeyRaw] = b
+	}
+
+	return result, nil
+}
+
+// macIgnoreFields returns the fields of obj's data that are excluded from the
+// HMAC-based change detection.
+func macIgnoreFields(obj ctrlclient.Object) []string {
+	if t, ok := obj.(*v1beta1.VaultStaticSecret); ok && t.Spec.SyncConfig != nil {
+		return t.Spec.SyncConfig.IgnoreFields
+	}
+	return nil
+}
+
 func getSecretMac(obj ctrlclient.Object) (string, error) {
 	var cur string
 	switch t := obj.(type) {
@@ -143,6 +239,10 @@ func getSecretMac(obj ctrlclient.Object) (string, error) {
 		cur = t.Status.SecretMAC
 	case *v1beta1.HCPVaultSecretsApp:
 		cur = t.Status.SecretMAC
+	case *v1beta1.VaultTransitSecret:
+		cur = t.Status.SecretMAC
+	case *v1beta1.VaultWrappedSecret:
+		cur = t.Status.SecretMAC
 	default:
 		return "", fmt.Errorf("unsupported object type %T", t)
 	}
@@ -286,6 +286,63 @@ func TestHMACDestinationSecret(t *testing.T) {
 			want:      false,
 			wantErr:   assert.NoError,
 		},
+		{
+			name:      "matched-create",
+			secretMAC: defaultSecretMAC,
+			objMeta:   objMeta,
+			destination: secretsv1beta1.Destination{
+				Name:   "baz",
+				Create: true,
+			},
+			data:    defaultData,
+			wantErr: assert.NoError,
+			want:    true,
+		},
+		{
+			name:      "mismatch-labels-drift",
+			secretMAC: defaultSecretMAC,
+			objMeta:   objMeta,
+			destination: secretsv1beta1.Destination{
+				Name:   "baz",
+				Create: true,
+				Labels: map[string]string{
+					"reloader.stakater.com/match": "true",
+				},
+			},
+			data:    defaultData,
+			wantErr: assert.NoError,
+			want:    false,
+		},
+		{
+			name:      "mismatch-annotations-drift",
+			secretMAC: defaultSecretMAC,
+			objMeta:   objMeta,
+			destination: secretsv1beta1.Destination{
+				Name:   "baz",
+				Create: true,
+				Annotations: map[string]string{
+					"velero.io/exclude-from-backup": "true",
+				},
+			},
+			data:    defaultData,
+			wantErr: assert.NoError,
+			want:    false,
+		},
+		{
+			name:      "matched-labels-not-enforced",
+			secretMAC: defaultSecretMAC,
+			objMeta:   objMeta,
+			destination: secretsv1beta1.Destination{
+				Name:   "baz",
+				Create: false,
+				Labels: map[string]string{
+					"reloader.stakater.com/match": "true",
+				},
+			},
+			data:    defaultData,
+			wantErr: assert.NoError,
+			want:    true,
+		},
 		{
 			name:    "mismatch-destination-inexistent",
 			objMeta: objMeta,
@@ -586,3 +643,58 @@ func assertSecretHMAC(t *testing.T, tt hmacSecretTestCase, c client.Client) {
 		})
 	}
 }
+
+func TestMACData(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    map[string][]byte
+		ignored []string
+		want    map[string][]byte
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "no-ignored-fields",
+			data: map[string][]byte{
+				"foo": []byte(`bar`),
+			},
+			want: map[string][]byte{
+				"foo": []byte(`bar`),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "ignored-fields",
+			data: map[string][]byte{
+				"foo":            []byte(`bar`),
+				"timestamp":      []byte(`2024-01-02T03:04:05Z`),
+				SecretDataKeyRaw: []byte(`{"foo":"bar","timestamp":"2024-01-02T03:04:05Z"}`),
+			},
+			ignored: []string{"timestamp", "other"},
+			want: map[string][]byte{
+				"foo":            []byte(`bar`),
+				SecretDataKeyRaw: []byte(`{"foo":"bar"}`),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "invalid-raw",
+			data: map[string][]byte{
+				SecretDataKeyRaw: []byte(`{`),
+			},
+			ignored: []string{"timestamp"},
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.ErrorContains(t, err, "failed to unmarshal the raw secret data", i...)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MACData(tt.data, tt.ignored)
+			if !tt.wantErr(t, err) {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
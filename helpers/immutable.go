@@ -0,0 +1,176 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/hashicorp/vault-secrets-operator/common"
+	"github.com/hashicorp/vault-secrets-operator/consts"
+)
+
+// AnnotationImmutableSecretName is set on the resources whose Destination is
+// immutable, it holds the name of their current destination Secret.
+const AnnotationImmutableSecretName = "vso.secrets.hashicorp.com/immutableSecretName"
+
+// immutableSecretName returns the name of the immutable destination Secret that
+// holds data. The name only changes along with the Secret's type or data.
+func immutableSecretName(name string, secretType corev1.SecretType, data map[string][]byte) (string, error) {
+	checksum, err := Checksum(ChecksumAlgorithmSHA256, data)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s-%s", name, HashString(string(secretType) + checksum)[:10]), nil
+}
+
+// syncImmutableSecret creates the immutable destination Secret of data, unless
+// it already exists, and points the AnnotationImmutableSecretName of obj to
+// it. The Secrets owned by obj are pruned, other than the current Secret, the
+// previous one, and those of the additional destinations.
+func syncImmutableSecret(ctx context.Context, client ctrlclient.Client, obj ctrlclient.Object,
+	meta *common.SyncableSecretMetaData, data map[string][]byte, options SyncOptions,
+) error {
+	d := meta.Destination
+	if !d.Create || hasFanOut(d) {
+		return fmt.Errorf("invalid Destination, immutable requires create=true, " +
+			"and cannot be combined with namespaces and namespaceSelector")
+	}
+
+	secretType := destinationSecretType(d)
+	if err := validateSecretType(secretType, data); err != nil {
+		return fmt.Errorf("invalid Destination, err=%w", err)
+	}
+	name, err := immutableSecretName(d.Name, secretType, data)
+	if err != nil {
+		return err
+	}
+
+	key := ctrlclient.ObjectKey{
+		Namespace: obj.GetNamespace(),
+		Name:      name,
+	}
+	if err := common.ValidateObjectKey(key); err != nil {
+		return fmt.Errorf("invalid Destination, err=%w", err)
+	}
+
+	logger := log.FromContext(ctx).WithName("syncImmutableSecret").WithValues("secret", key)
+	labels := maps.Clone(d.Labels)
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	ownerLabels, err := OwnerLabelsForObj(obj)
+	if err != nil {
+		return err
+	}
+	// always add the "owner" labels last to guard against intersections with d.Labels
+	maps.Copy(labels, ownerLabels)
+
+	annotations := maps.Clone(d.Annotations)
+	if d.ChecksumAnnotation {
+		checksum, err := Checksum(DefaultChecksumAlgorithm, data)
+		if err != nil {
+			return err
+		}
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[ChecksumAnnotation(DefaultChecksumAlgorithm)] = checksum
+	}
+
+	references := []metav1.OwnerReference{
+		{
+			APIVersion: meta.APIVersion,
+			Kind:       meta.Kind,
+			Name:       obj.GetName(),
+			UID:        obj.GetUID(),
+		},
+	}
+
+	dest, exists, err := getSecretExists(ctx, client, key)
+	if err != nil {
+		return err
+	}
+	if exists {
+		if err := checkDestinationOwnership(ctx, client, obj, dest, d, references); err != nil {
+			return err
+		}
+		// only the metadata of an immutable Secret can be updated.
+		orig := dest.DeepCopy()
+		dest.SetLabels(labels)
+		dest.SetAnnotations(annotations)
+		dest.SetOwnerReferences(references)
+		logger.V(consts.LogLevelDebug).Info("Updating secret metadata")
+		if err := patchSecret(ctx, client, orig, dest); err != nil {
+			return err
+		}
+	} else {
+		dest = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            key.Name,
+				Namespace:       key.Namespace,
+				Labels:          labels,
+				Annotations:     annotations,
+				OwnerReferences: references,
+			},
+			Immutable: ptr.To(true),
+			Type:      secretType,
+			Data:      data,
+		}
+		logger.V(consts.LogLevelDebug).Info("Creating secret")
+		if err := createSecret(ctx, client, dest); err != nil {
+			return err
+		}
+	}
+
+	previous := obj.GetAnnotations()[AnnotationImmutableSecretName]
+	if previous != name {
+		if err := setImmutableSecretName(ctx, client, obj, name); err != nil {
+			return err
+		}
+	}
+
+	if options.PruneOrphans {
+		// for now we treat orphan pruning errors as being non-fatal.
+		if err := pruneOrphanSecrets(ctx, client, obj, meta, name, previous); err != nil {
+			logger.V(consts.LogLevelWarning).Error(err, "Failed to prune orphan secrets",
+				"owner", ctrlclient.ObjectKeyFromObject(obj).String())
+		}
+	}
+
+	return nil
+}
+
+// setImmutableSecretName sets the AnnotationImmutableSecretName of obj to name.
+// Only the annotations and resource version of obj are updated from the
+// patched object, so that any pending change to its status is preserved.
+func setImmutableSecretName(ctx context.Context, client ctrlclient.Client, obj ctrlclient.Object, name string) error {
+	o, ok := obj.DeepCopyObject().(ctrlclient.Object)
+	if !ok {
+		return fmt.Errorf("unsupported object type %T", obj)
+	}
+
+	patch := ctrlclient.MergeFrom(o.DeepCopyObject().(ctrlclient.Object))
+	annotations := maps.Clone(o.GetAnnotations())
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[AnnotationImmutableSecretName] = name
+	o.SetAnnotations(annotations)
+	if err := client.Patch(ctx, o, patch); err != nil {
+		return fmt.Errorf("failed to set the %s annotation: %w", AnnotationImmutableSecretName, err)
+	}
+
+	obj.SetAnnotations(o.GetAnnotations())
+	obj.SetResourceVersion(o.GetResourceVersion())
+	return nil
+}
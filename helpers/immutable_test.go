@@ -0,0 +1,101 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package helpers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/internal/testutils"
+)
+
+func TestSyncSecret_immutable(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	obj := &secretsv1beta1.VaultStaticSecret{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "VaultStaticSecret",
+			APIVersion: "secrets.hashicorp.com/v1beta1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "baz",
+			Namespace: "foo",
+			UID:       types.UID("buzz"),
+		},
+		Spec: secretsv1beta1.VaultStaticSecretSpec{
+			Destination: secretsv1beta1.Destination{
+				Name:      "baz",
+				Create:    true,
+				Immutable: true,
+				Labels: map[string]string{
+					"app": "baz",
+				},
+			},
+		},
+	}
+	c := testutils.NewFakeClientBuilder().WithObjects(obj).Build()
+
+	sync := func(data map[string][]byte) string {
+		t.Helper()
+		require.NoError(t, SyncSecret(ctx, c, obj, data))
+
+		name := obj.GetAnnotations()[AnnotationImmutableSecretName]
+		want, err := immutableSecretName("baz", corev1.SecretTypeOpaque, data)
+		require.NoError(t, err)
+		require.Equal(t, want, name)
+
+		var got secretsv1beta1.VaultStaticSecret
+		require.NoError(t, c.Get(ctx, ctrlclient.ObjectKeyFromObject(obj), &got))
+		assert.Equal(t, name, got.Annotations[AnnotationImmutableSecretName])
+
+		s, ok, err := GetSyncableSecret(ctx, c, obj)
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, name, s.Name)
+		assert.Equal(t, data, s.Data)
+		assert.Equal(t, "baz", s.Labels["app"])
+		if assert.NotNil(t, s.Immutable) {
+			assert.True(t, *s.Immutable)
+		}
+		return name
+	}
+	exists := func(name string) bool {
+		t.Helper()
+		_, ok, err := getSecretExists(ctx, c, ctrlclient.ObjectKey{Namespace: "foo", Name: name})
+		require.NoError(t, err)
+		return ok
+	}
+
+	_, ok, err := GetSyncableSecret(ctx, c, obj)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	first := sync(map[string][]byte{"password": []byte("v1")})
+	assert.Regexp(t, `^baz-[0-9a-f]{10}$`, first)
+	assert.Equal(t, first, sync(map[string][]byte{"password": []byte("v1")}))
+
+	// rotation creates a new Secret, and retains the previous one.
+	second := sync(map[string][]byte{"password": []byte("v2")})
+	assert.NotEqual(t, first, second)
+	assert.True(t, exists(first))
+
+	third := sync(map[string][]byte{"password": []byte("v3")})
+	assert.False(t, exists(first))
+	assert.True(t, exists(second))
+	assert.True(t, exists(third))
+
+	obj.Spec.Destination.Create = false
+	assert.EqualError(t, SyncSecret(ctx, c, obj, map[string][]byte{"password": []byte("v4")}),
+		"invalid Destination, immutable requires create=true, "+
+			"and cannot be combined with namespaces and namespaceSelector")
+}
@@ -0,0 +1,47 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package helpers
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// mapKeys returns data with its keys renamed according to the
+// SecretTransformationOption's KeyMap, the keys that are not mapped are kept
+// as is. An error is returned if a key is mapped to an invalid K8s Secret data
+// key, or to a key that is already provided by another field.
+func mapKeys[V any](opt *SecretTransformationOption, data map[string]V) (map[string]V, error) {
+	if opt == nil || len(opt.KeyMap) == 0 {
+		return data, nil
+	}
+
+	m := make(map[string]V, len(data))
+	sources := make(map[string]string, len(data))
+	// sorted for stable error messages.
+	for _, k := range slices.Sorted(maps.Keys(data)) {
+		key := k
+		if mapped, ok := opt.KeyMap[k]; ok {
+			if errs := validation.IsConfigMapKey(mapped); len(errs) > 0 {
+				return nil, fmt.Errorf("invalid keyMap key %q for %q: %s", mapped, k, strings.Join(errs, ", "))
+			}
+			if mapped == SecretDataKeyRaw {
+				return nil, fmt.Errorf("keyMap key %q for %q is reserved", mapped, k)
+			}
+			key = mapped
+		}
+
+		if source, ok := sources[key]; ok {
+			return nil, fmt.Errorf("keyMap key %q of %q conflicts with %q", key, k, source)
+		}
+		sources[key] = k
+		m[key] = data[k]
+	}
+
+	return m, nil
+}
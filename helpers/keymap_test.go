@@ -0,0 +1,116 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package helpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_mapKeys(t *testing.T) {
+	t.Parallel()
+
+	data := map[string]any{
+		"username": "alice",
+		"password": "s3cr3t",
+	}
+	tests := []struct {
+		name    string
+		keyMap  map[string]string
+		want    map[string]any
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "no-key-map",
+			want:    data,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "mapped",
+			keyMap: map[string]string{
+				"password": "POSTGRES_PASSWORD",
+				"unknown":  "UNKNOWN",
+			},
+			want: map[string]any{
+				"username":          "alice",
+				"POSTGRES_PASSWORD": "s3cr3t",
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "swapped",
+			keyMap: map[string]string{
+				"password": "username",
+				"username": "password",
+			},
+			want: map[string]any{
+				"username": "s3cr3t",
+				"password": "alice",
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "conflict",
+			keyMap: map[string]string{
+				"password": "username",
+			},
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.EqualError(t, err,
+					`keyMap key "username" of "username" conflicts with "password"`, i...)
+			},
+		},
+		{
+			name: "invalid-key",
+			keyMap: map[string]string{
+				"password": "pass word",
+			},
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.ErrorContains(t, err, `invalid keyMap key "pass word" for "password"`, i...)
+			},
+		},
+		{
+			name: "reserved-key",
+			keyMap: map[string]string{
+				"password": SecretDataKeyRaw,
+			},
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.EqualError(t, err, `keyMap key "_raw" for "password" is reserved`, i...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := mapKeys(&SecretTransformationOption{KeyMap: tt.keyMap}, data)
+			if !tt.wantErr(t, err) {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSecretDataBuilder_WithVaultData_keyMap(t *testing.T) {
+	t.Parallel()
+
+	d := map[string]any{
+		"username": "alice",
+		"password": "s3cr3t",
+	}
+	got, err := NewSecretsDataBuilder().WithVaultData(d, d, &SecretTransformationOption{
+		Excludes:   []string{"username"},
+		ExcludeRaw: true,
+		KeyMap: map[string]string{
+			"password": "POSTGRES_PASSWORD",
+			"username": "POSTGRES_USER",
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"POSTGRES_PASSWORD": []byte("s3cr3t"),
+	}, got)
+}
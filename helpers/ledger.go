@@ -0,0 +1,43 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package helpers
+
+import (
+	"context"
+	"fmt"
+
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/common"
+	"github.com/hashicorp/vault-secrets-operator/ledger"
+)
+
+// recordSyncLedger appends the successful sync of data for obj to the sync
+// ledger, if it is enabled. An error is returned if the sync could not be
+// recorded, so that the sync is retried, since a sync that is missing from the
+// ledger would defeat its purpose.
+func recordSyncLedger(ctx context.Context, obj ctrlclient.Object, data map[string][]byte) error {
+	if ledger.DefaultRecorder == nil {
+		return nil
+	}
+
+	meta, err := common.NewSyncableSecretMetaData(obj)
+	if err != nil {
+		return err
+	}
+
+	owner := secretsv1beta1.SyncLedgerOwner{
+		APIVersion: meta.APIVersion,
+		Kind:       meta.Kind,
+		Name:       obj.GetName(),
+		UID:        obj.GetUID(),
+	}
+	if err := ledger.DefaultRecorder.Record(ctx, obj.GetNamespace(), owner,
+		obj.GetGeneration(), meta.Destination.Name, data); err != nil {
+		return fmt.Errorf("failed to record the sync in the ledger: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package helpers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/internal/testutils"
+	"github.com/hashicorp/vault-secrets-operator/ledger"
+)
+
+func TestSyncSecret_ledger(t *testing.T) {
+	ctx := context.Background()
+	client := testutils.NewFakeClientBuilder().Build()
+	var macErr error
+	t.Cleanup(func() {
+		ledger.DefaultRecorder = nil
+	})
+	mac := func(_ context.Context, message []byte) ([]byte, error) {
+		return []byte("mac"), macErr
+	}
+	ledger.DefaultRecorder = &ledger.Recorder{
+		Sink: &ledger.CustomResourceSink{Client: client, MAC: mac},
+		MAC:  mac,
+	}
+
+	obj := &secretsv1beta1.VaultStaticSecret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: secretsv1beta1.GroupVersion.String(),
+			Kind:       "VaultStaticSecret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "foo",
+			Namespace:  "baz",
+			UID:        "uid-foo",
+			Generation: 2,
+		},
+		Spec: secretsv1beta1.VaultStaticSecretSpec{
+			Destination: secretsv1beta1.Destination{
+				Name:   "creds",
+				Create: true,
+			},
+		},
+	}
+
+	require.NoError(t, SyncSecret(ctx, client, obj, map[string][]byte{"password": []byte("secret")}))
+	var o secretsv1beta1.SecretSyncLedger
+	require.NoError(t, client.Get(ctx, ctrlclient.ObjectKey{Namespace: "baz", Name: "uid-foo"}, &o))
+	assert.Equal(t, secretsv1beta1.SyncLedgerOwner{
+		APIVersion: secretsv1beta1.GroupVersion.String(),
+		Kind:       "VaultStaticSecret",
+		Name:       "foo",
+		UID:        "uid-foo",
+	}, o.Spec.Owner)
+	require.Len(t, o.Spec.Entries, 1)
+	assert.Equal(t, int64(2), o.Spec.Entries[0].Generation)
+	assert.Equal(t, "creds", o.Spec.Entries[0].Destination)
+
+	macErr = errors.New("mac failed")
+	err := SyncSecret(ctx, client, obj, map[string][]byte{"password": []byte("rotated")})
+	assert.ErrorContains(t, err, "failed to record the sync in the ledger")
+}
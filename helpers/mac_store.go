@@ -0,0 +1,238 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package helpers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// SecretMACStoreName is the name of the Secret that persists the MACs of the
+// syncable secret resources of a namespace.
+const SecretMACStoreName = "vso-secret-macs"
+
+var secretMACStoreLabels = map[string]string{
+	ManagedByLabel: OwnerLabels[ManagedByLabel],
+	AppNameLabel:   OwnerLabels[AppNameLabel],
+	ComponentLabel: "secret-mac-store",
+}
+
+// secretMACEntry is the persisted MAC of a single resource. The UID guards
+// against a resource that was recreated with the same name, before its entry
+// could be deleted.
+type secretMACEntry struct {
+	UID types.UID `json:"uid"`
+	MAC string    `json:"mac"`
+}
+
+type namespaceMACs struct {
+	loaded  bool
+	entries map[string]secretMACEntry
+}
+
+// SecretMACStore holds the MACs of the data synced by the syncable secret
+// resources, used for change detection. The MACs are indexed in memory by
+// namespace, so that they are read without any API round-trip, and they are
+// persisted in a single SecretMACStoreName Secret per namespace. The Secret of
+// a namespace is loaded upon the first access to any of its MACs, e.g. after an
+// Operator restart or a leader election. Only writes that change a MAC go to
+// the API server.
+type SecretMACStore struct {
+	client     ctrlclient.Client
+	mu         sync.Mutex
+	namespaces map[string]*namespaceMACs
+}
+
+// NewSecretMACStore returns a SecretMACStore that persists the MACs with
+// client. The client should be the label-selected secrets client, see
+// NewSecretsClientForManager.
+func NewSecretMACStore(client ctrlclient.Client) *SecretMACStore {
+	return &SecretMACStore{
+		client:     client,
+		namespaces: make(map[string]*namespaceMACs),
+	}
+}
+
+// Get returns the base64 encoded MAC stored for obj, it is empty if none is
+// stored.
+func (s *SecretMACStore) Get(ctx context.Context, obj ctrlclient.Object) (string, error) {
+	key, err := s.keyFor(obj)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.load(ctx, obj.GetNamespace()); err != nil {
+		return "", err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.namespaces[obj.GetNamespace()].entries[key]
+	if !ok || entry.UID != obj.GetUID() {
+		return "", nil
+	}
+
+	return entry.MAC, nil
+}
+
+// Set stores the base64 encoded MAC for obj. Nothing is persisted if the MAC
+// is unchanged.
+func (s *SecretMACStore) Set(ctx context.Context, obj ctrlclient.Object, mac string) error {
+	key, err := s.keyFor(obj)
+	if err != nil {
+		return err
+	}
+
+	if err := s.load(ctx, obj.GetNamespace()); err != nil {
+		return err
+	}
+
+	entry := secretMACEntry{
+		UID: obj.GetUID(),
+		MAC: mac,
+	}
+	s.mu.Lock()
+	cur, ok := s.namespaces[obj.GetNamespace()].entries[key]
+	s.mu.Unlock()
+	if ok && cur == entry {
+		return nil
+	}
+
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if err := s.persist(ctx, obj.GetNamespace(), map[string]any{key: b}); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.namespaces[obj.GetNamespace()].entries[key] = entry
+	return nil
+}
+
+// Delete the MAC stored for obj, it should be called when obj is deleted.
+func (s *SecretMACStore) Delete(ctx context.Context, obj ctrlclient.Object) error {
+	key, err := s.keyFor(obj)
+	if err != nil {
+		return err
+	}
+
+	if err := s.load(ctx, obj.GetNamespace()); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	_, ok := s.namespaces[obj.GetNamespace()].entries[key]
+	s.mu.Unlock()
+	if !ok {
+		return nil
+	}
+
+	if err := s.persist(ctx, obj.GetNamespace(), map[string]any{key: nil}); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.namespaces[obj.GetNamespace()].entries, key)
+	return nil
+}
+
+// keyFor returns the key of obj's entry in its namespace's Secret.
+func (s *SecretMACStore) keyFor(obj ctrlclient.Object) (string, error) {
+	gvk, err := apiutil.GVKForObject(obj, s.client.Scheme())
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s.%s", gvk.Kind, obj.GetName()), nil
+}
+
+// load the persisted MACs of the namespace into memory, unless they already
+// are.
+func (s *SecretMACStore) load(ctx context.Context, namespace string) error {
+	s.mu.Lock()
+	ns, ok := s.namespaces[namespace]
+	s.mu.Unlock()
+	if ok && ns.loaded {
+		return nil
+	}
+
+	entries := make(map[string]secretMACEntry)
+	sec, exists, err := getSecretExists(ctx, s.client, ctrlclient.ObjectKey{
+		Namespace: namespace,
+		Name:      SecretMACStoreName,
+	})
+	if err != nil {
+		return err
+	}
+	if exists {
+		for k, v := range sec.Data {
+			var entry secretMACEntry
+			if err := json.Unmarshal(v, &entry); err != nil {
+				return fmt.Errorf("invalid entry %q in %s/%s: %w", k, namespace, SecretMACStoreName, err)
+			}
+			entries[k] = entry
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ns, ok := s.namespaces[namespace]; ok && ns.loaded {
+		// loaded concurrently.
+		return nil
+	}
+	s.namespaces[namespace] = &namespaceMACs{
+		loaded:  true,
+		entries: entries,
+	}
+	return nil
+}
+
+// persist merges data into the namespace's Secret, creating it if needed. A
+// nil value deletes its key. Since each key belongs to a single resource, the
+// merge patch never conflicts with the concurrent updates of the other keys.
+func (s *SecretMACStore) persist(ctx context.Context, namespace string, data map[string]any) error {
+	b, err := json.Marshal(map[string]any{
+		"data": data,
+	})
+	if err != nil {
+		return err
+	}
+
+	sec := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      SecretMACStoreName,
+			Namespace: namespace,
+		},
+	}
+	err = s.client.Patch(ctx, sec, ctrlclient.RawPatch(types.MergePatchType, b))
+	if !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	sec.Labels = secretMACStoreLabels
+	sec.Data = make(map[string][]byte)
+	for k, v := range data {
+		if v, ok := v.([]byte); ok {
+			sec.Data[k] = v
+		}
+	}
+	err = s.client.Create(ctx, sec)
+	if apierrors.IsAlreadyExists(err) {
+		// created concurrently.
+		return s.client.Patch(ctx, sec, ctrlclient.RawPatch(types.MergePatchType, b))
+	}
+	return err
+}
@@ -0,0 +1,98 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package helpers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/internal/testutils"
+)
+
+func TestSecretMACStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	var patches int
+	client := testutils.NewFakeClientBuilder().
+		WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(ctx context.Context, client ctrlclient.WithWatch, obj ctrlclient.Object, patch ctrlclient.Patch, opts ...ctrlclient.PatchOption) error {
+				patches++
+				return client.Patch(ctx, obj, patch, opts...)
+			},
+		}).Build()
+
+	foo := &secretsv1beta1.VaultStaticSecret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "baz",
+			UID:       "a1b2c3d4-0000-0000-0000-000000000001",
+		},
+	}
+	bar := &secretsv1beta1.VaultStaticSecret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "bar",
+			Namespace: "baz",
+			UID:       "a1b2c3d4-0000-0000-0000-000000000002",
+		},
+	}
+
+	s := NewSecretMACStore(client)
+	mac, err := s.Get(ctx, foo)
+	require.NoError(t, err)
+	assert.Empty(t, mac)
+
+	// the Secret is created on the first Set.
+	require.NoError(t, s.Set(ctx, foo, "Zm9v"))
+	require.NoError(t, s.Set(ctx, bar, "YmFy"))
+	sec := &corev1.Secret{}
+	require.NoError(t, client.Get(ctx, ctrlclient.ObjectKey{Namespace: "baz", Name: SecretMACStoreName}, sec))
+	assert.Equal(t, secretMACStoreLabels, sec.Labels)
+	assert.Len(t, sec.Data, 2)
+	assert.Contains(t, sec.Data, "VaultStaticSecret.foo")
+	assert.Contains(t, sec.Data, "VaultStaticSecret.bar")
+
+	// an unchanged MAC is not persisted.
+	patches = 0
+	require.NoError(t, s.Set(ctx, foo, "Zm9v"))
+	assert.Equal(t, 0, patches)
+	require.NoError(t, s.Set(ctx, foo, "Zm9vMg=="))
+	assert.Equal(t, 1, patches)
+
+	mac, err = s.Get(ctx, foo)
+	require.NoError(t, err)
+	assert.Equal(t, "Zm9vMg==", mac)
+
+	// a new store loads the MACs from the Secret.
+	s = NewSecretMACStore(client)
+	mac, err = s.Get(ctx, foo)
+	require.NoError(t, err)
+	assert.Equal(t, "Zm9vMg==", mac)
+	mac, err = s.Get(ctx, bar)
+	require.NoError(t, err)
+	assert.Equal(t, "YmFy", mac)
+
+	// a recreated resource does not get the MAC of its predecessor.
+	recreated := foo.DeepCopy()
+	recreated.UID = "a1b2c3d4-0000-0000-0000-000000000003"
+	mac, err = s.Get(ctx, recreated)
+	require.NoError(t, err)
+	assert.Empty(t, mac)
+
+	require.NoError(t, s.Delete(ctx, foo))
+	mac, err = s.Get(ctx, foo)
+	require.NoError(t, err)
+	assert.Empty(t, mac)
+	require.NoError(t, client.Get(ctx, ctrlclient.ObjectKey{Namespace: "baz", Name: SecretMACStoreName}, sec))
+	assert.NotContains(t, sec.Data, "VaultStaticSecret.foo")
+	assert.Contains(t, sec.Data, "VaultStaticSecret.bar")
+}
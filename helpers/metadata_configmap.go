@@ -0,0 +1,158 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package helpers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	"github.com/hashicorp/vault-secrets-operator/common"
+)
+
+const (
+	MetadataKeyOwner        = "owner"
+	MetadataKeySource       = "source"
+	MetadataKeyVersion      = "version"
+	MetadataKeyRotatedAt    = "rotatedAt"
+	MetadataKeyTemplateHash = "templateHash"
+)
+
+// metadataNow returns the time of the rotations. Overridden in tests.
+var metadataNow = time.Now
+
+// metadataConfigMapName returns the name of the companion ConfigMap of the
+// syncable secret's Destination.
+func metadataConfigMapName(meta *common.SyncableSecretMetaData) string {
+	if meta.Destination.MetadataConfigMap.Name != "" {
+		return meta.Destination.MetadataConfigMap.Name
+	}
+	return meta.Destination.Name + "-metadata"
+}
+
+// syncMetadataConfigMap writes the rendering metadata of the Secret synced for
+// obj to its companion ConfigMap, if one is configured. The rotatedAt time is
+// only updated if rotated is true, or if the ConfigMap does not exist yet.
+func syncMetadataConfigMap(ctx context.Context, client ctrlclient.Client, obj ctrlclient.Object,
+	meta *common.SyncableSecretMetaData, rotated bool, version string,
+) error {
+	if meta.Destination.MetadataConfigMap == nil {
+		return nil
+	}
+
+	templateHash, err := json.Marshal(meta.Destination.Transformation)
+	if err != nil {
+		return err
+	}
+
+	kind := meta.Kind
+	if gvk, err := apiutil.GVKForObject(obj, client.Scheme()); err == nil {
+		kind = gvk.Kind
+	}
+	data := map[string]string{
+		MetadataKeyOwner:        fmt.Sprintf("%s/%s", kind, obj.GetName()),
+		MetadataKeyTemplateHash: HashString(string(templateHash)),
+	}
+	if source := sourceForObj(obj); source != "" {
+		data[MetadataKeySource] = source
+	}
+	if version != "" {
+		data[MetadataKeyVersion] = version
+	}
+
+	ownerLabels, err := OwnerLabelsForObj(obj)
+	if err != nil {
+		return err
+	}
+	references := []metav1.OwnerReference{
+		{
+			APIVersion: meta.APIVersion,
+			Kind:       meta.Kind,
+			Name:       obj.GetName(),
+			UID:        obj.GetUID(),
+		},
+	}
+
+	key := ctrlclient.ObjectKey{
+		Namespace: obj.GetNamespace(),
+		Name:      metadataConfigMapName(meta),
+	}
+	var cm corev1.ConfigMap
+	if err := client.Get(ctx, key, &cm); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+
+		data[MetadataKeyRotatedAt] = metadataNow().UTC().Format(time.RFC3339)
+		cm = corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:       key.Namespace,
+				Name:            key.Name,
+				Labels:          ownerLabels,
+				OwnerReferences: references,
+			},
+			Data: data,
+		}
+		return client.Create(ctx, &cm, ctrlclient.FieldOwner(FieldManager))
+	}
+
+	if !slices.ContainsFunc(cm.OwnerReferences, func(ref metav1.OwnerReference) bool {
+		return ref.UID == obj.GetUID()
+	}) {
+		return fmt.Errorf("metadata ConfigMap %s exists, but is not owned by this resource", key)
+	}
+
+	if rotated || cm.Data[MetadataKeyRotatedAt] == "" {
+		data[MetadataKeyRotatedAt] = metadataNow().UTC().Format(time.RFC3339)
+	} else {
+		data[MetadataKeyRotatedAt] = cm.Data[MetadataKeyRotatedAt]
+	}
+	if maps.Equal(cm.Data, data) {
+		return nil
+	}
+
+	patch := ctrlclient.MergeFrom(cm.DeepCopy())
+	cm.Data = data
+	return client.Patch(ctx, &cm, patch, ctrlclient.FieldOwner(FieldManager))
+}
+
+// sourceForObj returns the Vault path that the syncable secret obj reads its
+// data from, made of its spec's mount, and path or role.
+func sourceForObj(obj ctrlclient.Object) string {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return ""
+	}
+
+	spec, _ := u["spec"].(map[string]any)
+	var parts []string
+	if v, ok := spec["mount"].(string); ok && v != "" {
+		parts = append(parts, strings.Trim(v, "/"))
+	}
+	for _, f := range []string{"path", "role", "appName"} {
+		if v, ok := spec[f].(string); ok && v != "" {
+			parts = append(parts, strings.Trim(v, "/"))
+			break
+		}
+	}
+
+	return strings.Join(parts, "/")
+}
+
+// secretDataChanged returns true if the data of dest differs from orig's.
+func secretDataChanged(orig, dest *corev1.Secret) bool {
+	return !maps.EqualFunc(orig.Data, dest.Data, bytes.Equal)
+}
@@ -0,0 +1,135 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package helpers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/internal/testutils"
+)
+
+func TestSyncSecret_metadataConfigMap(t *testing.T) {
+	// metadataNow is global, so this test must not run in parallel.
+	t.Cleanup(func() {
+		metadataNow = time.Now
+	})
+
+	ctx := context.Background()
+	c := testutils.NewFakeClientBuilder().Build()
+	obj := &secretsv1beta1.VaultStaticSecret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "foo",
+			UID:       "a1b2c3d4-0000-0000-0000-000000000004",
+		},
+		Spec: secretsv1beta1.VaultStaticSecretSpec{
+			Mount: "kvv2/",
+			Path:  "app/config",
+			Destination: secretsv1beta1.Destination{
+				Name:              "app",
+				Create:            true,
+				MetadataConfigMap: &secretsv1beta1.MetadataConfigMap{},
+			},
+		},
+	}
+
+	sync := func(now time.Time, data map[string][]byte) map[string]string {
+		t.Helper()
+
+		metadataNow = func() time.Time { return now }
+		opts := DefaultSyncOptions()
+		opts.SourceVersion = "3"
+		require.NoError(t, SyncSecret(ctx, c, obj, data, opts))
+
+		var cm corev1.ConfigMap
+		require.NoError(t, c.Get(ctx, ctrlclient.ObjectKey{Namespace: "default", Name: "app-metadata"}, &cm))
+		require.Len(t, cm.OwnerReferences, 1)
+		assert.Equal(t, obj.UID, cm.OwnerReferences[0].UID)
+		return cm.Data
+	}
+
+	t0 := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	got := sync(t0, map[string][]byte{"password": []byte("foo")})
+	assert.NotEmpty(t, got[MetadataKeyTemplateHash])
+	delete(got, MetadataKeyTemplateHash)
+	assert.Equal(t, map[string]string{
+		MetadataKeyOwner:     "VaultStaticSecret/foo",
+		MetadataKeySource:    "kvv2/app/config",
+		MetadataKeyVersion:   "3",
+		MetadataKeyRotatedAt: "2024-01-02T03:04:05Z",
+	}, got)
+
+	// the data has not changed.
+	got = sync(t0.Add(time.Hour), map[string][]byte{"password": []byte("foo")})
+	assert.Equal(t, "2024-01-02T03:04:05Z", got[MetadataKeyRotatedAt])
+
+	got = sync(t0.Add(2*time.Hour), map[string][]byte{"password": []byte("bar")})
+	assert.Equal(t, "2024-01-02T05:04:05Z", got[MetadataKeyRotatedAt])
+
+	// not owned by the resource.
+	obj.Spec.Destination.MetadataConfigMap.Name = "other"
+	require.NoError(t, c.Create(ctx, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "other",
+		},
+	}))
+	assert.EqualError(t, SyncSecret(ctx, c, obj, map[string][]byte{"password": []byte("bar")}),
+		"metadata ConfigMap default/other exists, but is not owned by this resource")
+}
+
+func Test_sourceForObj(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		obj  ctrlclient.Object
+		want string
+	}{
+		{
+			name: "vds",
+			obj: &secretsv1beta1.VaultDynamicSecret{
+				Spec: secretsv1beta1.VaultDynamicSecretSpec{
+					Mount: "database",
+					Path:  "creds/app",
+				},
+			},
+			want: "database/creds/app",
+		},
+		{
+			name: "pki",
+			obj: &secretsv1beta1.VaultPKISecret{
+				Spec: secretsv1beta1.VaultPKISecretSpec{
+					Mount: "pki",
+					Role:  "web",
+				},
+			},
+			want: "pki/web",
+		},
+		{
+			name: "hvs",
+			obj: &secretsv1beta1.HCPVaultSecretsApp{
+				Spec: secretsv1beta1.HCPVaultSecretsAppSpec{
+					AppName: "app",
+				},
+			},
+			want: "app",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, sourceForObj(tt.obj))
+		})
+	}
+}
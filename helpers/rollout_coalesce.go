@@ -0,0 +1,381 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package helpers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	argorolloutsv1alpha1 "github.com/argoproj/argo-rollouts/pkg/apis/rollouts/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+)
+
+// AnnotationRolloutRestartDeferredUntil is set on a target whose restart was
+// deferred to the end of its coalescing window, it holds the time of the
+// deferred restart. It is removed once the target has been restarted.
+const AnnotationRolloutRestartDeferredUntil = "vso.secrets.hashicorp.com/rolloutRestartDeferredUntil"
+
+const (
+	// coalescedRestartTimeout bounds a restart that was deferred to the end of
+	// its coalescing window.
+	coalescedRestartTimeout = 30 * time.Second
+	// coalescedRestartRetryInterval is the delay before a failed deferred
+	// restart is retried.
+	coalescedRestartRetryInterval = 30 * time.Second
+)
+
+// DefaultRolloutRestartCoalescer coalesces the rollout-restarts of all the
+// syncable secrets. It is nil unless a coalescing window has been configured on
+// the Operator.
+var DefaultRolloutRestartCoalescer *RolloutRestartCoalescer
+
+// dedupeRolloutRestartTargets returns targets without the duplicated Kind/Name
+// pairs, in their configured order. A duplicated target is restarted in the
+// lowest of its waves, with the longest of its timeouts.
+func dedupeRolloutRestartTargets(targets []v1beta1.RolloutRestartTarget) []v1beta1.RolloutRestartTarget {
+	var result []v1beta1.RolloutRestartTarget
+	for _, target := range targets {
+		idx := slices.IndexFunc(result, func(t v1beta1.RolloutRestartTarget) bool {
+			return t.Kind == target.Kind && t.Name == target.Name
+		})
+		if idx < 0 {
+			result = append(result, target)
+			continue
+		}
+
+		t := &result[idx]
+		if target.Wave < t.Wave {
+			t.Wave = target.Wave
+		}
+		if target.Timeout != "" {
+			if t.Timeout == "" {
+				t.Timeout = target.Timeout
+			} else if d, err := time.ParseDuration(target.Timeout); err == nil {
+				if cur, err := time.ParseDuration(t.Timeout); err == nil && d > cur {
+					t.Timeout = target.Timeout
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+var _ manager.Runnable = (*RolloutRestartCoalescer)(nil)
+
+// RolloutRestartCoalescer ensures that a target is restarted at most once per
+// Window, no matter how many syncable secrets reference it. A restart that is
+// requested within the Window of the target's previous restart is deferred to
+// the end of that Window, any further request is coalesced into the deferred
+// restart. Since the deferred restart happens after all the coalesced
+// rotations, the restarted Pods always get the most recent secret data.
+//
+// A deferred restart is recorded on the target with the
+// AnnotationRolloutRestartDeferredUntil annotation, so that it survives an
+// Operator restart, or a leader election: the deferred restarts of all the
+// annotated targets are scheduled again when the RolloutRestartCoalescer is
+// started. A failed deferred restart is retried until it succeeds, or the
+// target is deleted.
+type RolloutRestartCoalescer struct {
+	Client ctrlclient.Client
+	Window time.Duration
+	mu     sync.Mutex
+	// last holds the time of each target's most recent restart, the entries
+	// are pruned once they are older than the Window.
+	last map[string]time.Time
+	// pending holds the targets with a deferred restart.
+	pending map[string]*time.Timer
+	// retryInterval is the delay before a failed deferred restart is retried.
+	retryInterval time.Duration
+	now           func() time.Time
+}
+
+// NewRolloutRestartCoalescer returns a RolloutRestartCoalescer that restarts
+// each target at most once per window.
+func NewRolloutRestartCoalescer(client ctrlclient.Client, window time.Duration) *RolloutRestartCoalescer {
+	return &RolloutRestartCoalescer{
+		Client:        client,
+		Window:        window,
+		last:          make(map[string]time.Time),
+		pending:       make(map[string]*time.Timer),
+		retryInterval: coalescedRestartRetryInterval,
+		now:           time.Now,
+	}
+}
+
+// Start schedules the deferred restarts that are recorded on the targets, then
+// blocks until ctx is done.
+func (c *RolloutRestartCoalescer) Start(ctx context.Context) error {
+	if err := c.resumeDeferred(ctx); err != nil {
+		return err
+	}
+
+	<-ctx.Done()
+	return nil
+}
+
+// NeedLeaderElection returns true, since the restarts are only requested by the
+// leader.
+func (c *RolloutRestartCoalescer) NeedLeaderElection() bool {
+	return true
+}
+
+// RolloutRestart restarts the target in namespace, unless it was already
+// restarted within the Window, in which case the restart is deferred. Returns
+// true if the restart was deferred, or coalesced into an already deferred one.
+func (c *RolloutRestartCoalescer) RolloutRestart(ctx context.Context, namespace string, target v1beta1.RolloutRestartTarget) (bool, error) {
+	key := rolloutRestartKey(namespace, target)
+
+	c.mu.Lock()
+	if _, ok := c.pending[key]; ok {
+		c.mu.Unlock()
+		return true, nil
+	}
+
+	now := c.now()
+	c.pruneLocked(now)
+	last, ok := c.last[key]
+	if ok {
+		// reserve the deferred restart, so that the concurrent requests are
+		// coalesced into it.
+		c.pending[key] = nil
+	} else {
+		// reserve the restart, so that the concurrent requests are deferred.
+		c.last[key] = now
+	}
+	c.mu.Unlock()
+
+	if ok {
+		until := last.Add(c.Window)
+		deferredUntil := until.UTC().Format(time.RFC3339Nano)
+		if err := c.setDeferredUntil(ctx, namespace, target, deferredUntil); err != nil {
+			c.mu.Lock()
+			delete(c.pending, key)
+			c.mu.Unlock()
+			return false, err
+		}
+		c.schedule(namespace, target, deferredUntil, until.Sub(now))
+		return true, nil
+	}
+
+	if err := RolloutRestart(ctx, namespace, target, c.Client); err != nil {
+		c.mu.Lock()
+		if c.last[key].Equal(now) {
+			delete(c.last, key)
+		}
+		c.mu.Unlock()
+		return false, err
+	}
+
+	return false, nil
+}
+
+// Restarted records a restart of the target in namespace that happened outside
+// the RolloutRestartCoalescer.
+func (c *RolloutRestartCoalescer) Restarted(namespace string, target v1beta1.RolloutRestartTarget) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	c.pruneLocked(now)
+	c.last[rolloutRestartKey(namespace, target)] = now
+}
+
+// pruneLocked removes the restarts that are older than the Window, they can no
+// longer cause a restart to be deferred. c.mu must be held.
+func (c *RolloutRestartCoalescer) pruneLocked(now time.Time) {
+	for key, last := range c.last {
+		if !now.Before(last.Add(c.Window)) {
+			delete(c.last, key)
+		}
+	}
+}
+
+// schedule the deferred restart of the target in namespace after d.
+// deferredUntil is the value of the target's
+// AnnotationRolloutRestartDeferredUntil annotation.
+func (c *RolloutRestartCoalescer) schedule(namespace string, target v1beta1.RolloutRestartTarget,
+	deferredUntil string, d time.Duration,
+) {
+	key := rolloutRestartKey(namespace, target)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if timer := c.pending[key]; timer != nil {
+		timer.Stop()
+	}
+	c.pending[key] = time.AfterFunc(d, func() {
+		c.restartDeferred(namespace, target, deferredUntil)
+	})
+}
+
+// restartDeferred restarts the target in namespace, then removes its
+// AnnotationRolloutRestartDeferredUntil annotation, unless it was set again by
+// a restart that has been deferred in the meantime. The restart is retried
+// after the retryInterval if it fails.
+func (c *RolloutRestartCoalescer) restartDeferred(namespace string, target v1beta1.RolloutRestartTarget, deferredUntil string) {
+	key := rolloutRestartKey(namespace, target)
+	logger := log.Log.WithName("rolloutRestartCoalescer").WithValues("target", key)
+
+	ctx, cancel := context.WithTimeout(context.Background(), coalescedRestartTimeout)
+	defer cancel()
+
+	// the restart is recorded before it happens, so that any request that
+	// follows is deferred to the next window, rather than coalesced into a
+	// restart that might not include its rotation.
+	c.mu.Lock()
+	delete(c.pending, key)
+	now := c.now()
+	c.pruneLocked(now)
+	c.last[key] = now
+	c.mu.Unlock()
+
+	if err := RolloutRestart(ctx, namespace, target, c.Client); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("Deferred rollout restart target no longer exists")
+			return
+		}
+
+		c.mu.Lock()
+		_, ok := c.pending[key]
+		if !ok {
+			c.pending[key] = nil
+		}
+		c.mu.Unlock()
+		if ok {
+			// a restart has been deferred in the meantime.
+			logger.Error(err, "Deferred rollout restart failed")
+			return
+		}
+
+		logger.Error(err, "Deferred rollout restart failed, retrying",
+			"retryAfter", c.retryInterval)
+		c.schedule(namespace, target, deferredUntil, c.retryInterval)
+		return
+	}
+
+	if err := c.removeDeferredUntil(ctx, namespace, target, deferredUntil); err != nil {
+		logger.Error(err, "Failed to remove the deferred rollout restart annotation")
+	}
+}
+
+// setDeferredUntil sets the target's AnnotationRolloutRestartDeferredUntil
+// annotation to deferredUntil.
+func (c *RolloutRestartCoalescer) setDeferredUntil(ctx context.Context, namespace string,
+	target v1beta1.RolloutRestartTarget, deferredUntil string,
+) error {
+	obj, err := rolloutRestartObject(namespace, target)
+	if err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]any{
+				AnnotationRolloutRestartDeferredUntil: deferredUntil,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.Client.Patch(ctx, obj, ctrlclient.RawPatch(types.MergePatchType, b))
+}
+
+// removeDeferredUntil removes the target's AnnotationRolloutRestartDeferredUntil
+// annotation, provided that it is still set to deferredUntil.
+func (c *RolloutRestartCoalescer) removeDeferredUntil(ctx context.Context, namespace string,
+	target v1beta1.RolloutRestartTarget, deferredUntil string,
+) error {
+	obj, err := rolloutRestartObject(namespace, target)
+	if err != nil {
+		return err
+	}
+
+	path := "/metadata/annotations/" + strings.ReplaceAll(AnnotationRolloutRestartDeferredUntil, "/", "~1")
+	b, err := json.Marshal([]map[string]any{
+		{"op": "test", "path": path, "value": deferredUntil},
+		{"op": "remove", "path": path},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = c.Client.Patch(ctx, obj, ctrlclient.RawPatch(types.JSONPatchType, b))
+	if apierrors.IsInvalid(err) {
+		// the annotation was set again, or removed.
+		return nil
+	}
+	return err
+}
+
+// resumeDeferred schedules the deferred restarts of all the targets that have
+// the AnnotationRolloutRestartDeferredUntil annotation.
+func (c *RolloutRestartCoalescer) resumeDeferred(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("rolloutRestartCoalescer")
+
+	lists := map[string]ctrlclient.ObjectList{
+		"Deployment":   &appsv1.DeploymentList{},
+		"StatefulSet":  &appsv1.StatefulSetList{},
+		"DaemonSet":    &appsv1.DaemonSetList{},
+		"argo.Rollout": &argorolloutsv1alpha1.RolloutList{},
+	}
+	for kind, list := range lists {
+		if err := c.Client.List(ctx, list); err != nil {
+			if meta.IsNoMatchError(err) {
+				// the Argo Rollouts CRD is not installed.
+				continue
+			}
+			return fmt.Errorf("failed to list the %s rollout restart targets: %w", kind, err)
+		}
+
+		if err := meta.EachListItem(list, func(o runtime.Object) error {
+			obj := o.(ctrlclient.Object)
+			v, ok := obj.GetAnnotations()[AnnotationRolloutRestartDeferredUntil]
+			if !ok {
+				return nil
+			}
+
+			target := v1beta1.RolloutRestartTarget{
+				Kind: kind,
+				Name: obj.GetName(),
+			}
+			var d time.Duration
+			if until, err := time.Parse(time.RFC3339Nano, v); err != nil {
+				logger.Error(err, "Invalid deferred rollout restart time, restarting now",
+					"target", rolloutRestartKey(obj.GetNamespace(), target))
+			} else {
+				d = until.Sub(c.now())
+			}
+
+			logger.Info("Resuming the deferred rollout restart",
+				"target", rolloutRestartKey(obj.GetNamespace(), target), "after", d)
+			c.schedule(obj.GetNamespace(), target, v, d)
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func rolloutRestartKey(namespace string, target v1beta1.RolloutRestartTarget) string {
+	return fmt.Sprintf("%s/%s/%s", namespace, target.Kind, target.Name)
+}
@@ -27,24 +27,71 @@ const AnnotationRestartedAt = "vso.secrets.hashicorp.com/restartedAt"
 // HandleRolloutRestarts for all v1beta1.RolloutRestartTarget(s) configured for obj.
 // Supported objs are: v1beta1.VaultDynamicSecret, v1beta1.VaultStaticSecret, v1beta1.VaultPKISecret
 // Please note the following:
-// - a rollout-restart will be triggered for each configured v1beta1.RolloutRestartTarget
+// - a rollout-restart will be triggered for each configured v1beta1.RolloutRestartTarget,
+// duplicated targets are only restarted once
+// - the restarts of a target are coalesced across all the syncable secrets that
+// reference it, when the DefaultRolloutRestartCoalescer is set. See
+// RolloutRestartCoalescer.
 // - the rollout-restart action has no support for roll-back
-// - does not wait for the action to complete
+// - does not wait for the action to complete, unless the targets are spread
+// across multiple waves, in which case each wave's rollouts must complete
+// before the next wave is restarted. See handleRolloutRestartWaves.
 //
 // Returns all errors encountered.
 func HandleRolloutRestarts(ctx context.Context, client ctrlclient.Client, obj ctrlclient.Object, recorder record.EventRecorder) error {
 	logger := log.FromContext(ctx)
 
 	var targets []v1beta1.RolloutRestartTarget
+	var conditions *[]metav1.Condition
 	switch t := obj.(type) {
 	case *v1beta1.VaultDynamicSecret:
 		targets = t.Spec.RolloutRestartTargets
+		conditions = &t.Status.Conditions
 	case *v1beta1.VaultStaticSecret:
 		targets = t.Spec.RolloutRestartTargets
+		conditions = &t.Status.Conditions
 	case *v1beta1.VaultPKISecret:
 		targets = t.Spec.RolloutRestartTargets
+		conditions = &t.Status.Conditions
 	case *v1beta1.HCPVaultSecretsApp:
 		targets = t.Spec.RolloutRestartTargets
+		conditions = &t.Status.Conditions
+	case *v1beta1.VaultConsulSecret:
+		targets = t.Spec.RolloutRestartTargets
+		conditions = &t.Status.Conditions
+	case *v1beta1.VaultNomadSecret:
+		targets = t.Spec.RolloutRestartTargets
+		conditions = &t.Status.Conditions
+	case *v1beta1.VaultLDAPSecret:
+		targets = t.Spec.RolloutRestartTargets
+		conditions = &t.Status.Conditions
+	case *v1beta1.VaultRabbitMQSecret:
+		targets = t.Spec.RolloutRestartTargets
+		conditions = &t.Status.Conditions
+	case *v1beta1.VaultTransitSecret:
+		targets = t.Spec.RolloutRestartTargets
+		conditions = &t.Status.Conditions
+	case *v1beta1.VaultKubernetesSecret:
+		targets = t.Spec.RolloutRestartTargets
+		conditions = &t.Status.Conditions
+	case *v1beta1.VaultTerraformCloudSecret:
+		targets = t.Spec.RolloutRestartTargets
+		conditions = &t.Status.Conditions
+	case *v1beta1.VaultGenericSecret:
+		targets = t.Spec.RolloutRestartTargets
+		conditions = &t.Status.Conditions
+	case *v1beta1.VaultWrappedSecret:
+		targets = t.Spec.RolloutRestartTargets
+		conditions = &t.Status.Conditions
+	case *v1beta1.VaultIdentityToken:
+		targets = t.Spec.RolloutRestartTargets
+		conditions = &t.Status.Conditions
+	case *v1beta1.VaultMongoDBAtlasSecret:
+		targets = t.Spec.RolloutRestartTargets
+		conditions = &t.Status.Conditions
+	case *v1beta1.VaultSecretGroup:
+		targets = t.Spec.RolloutRestartTargets
+		conditions = &t.Status.Conditions
 	default:
 		err := fmt.Errorf("unsupported Object type %T", t)
 		recorder.Eventf(obj, corev1.EventTypeWarning, consts.ReasonRolloutRestartUnsupported,
@@ -56,18 +103,13 @@ func HandleRolloutRestarts(ctx context.Context, client ctrlclient.Client, obj ct
 		return nil
 	}
 
-	var errs error
-	for _, target := range targets {
-		if err := RolloutRestart(ctx, obj.GetNamespace(), target, client); err != nil {
-			errs = errors.Join(err)
-			recorder.Eventf(obj, corev1.EventTypeWarning, consts.ReasonRolloutRestartFailed,
-				"Rollout restart failed for target %#v: err=%s", target, err)
-		} else {
-			recorder.Eventf(obj, corev1.EventTypeNormal, consts.ReasonRolloutRestartTriggered,
-				"Rollout restart triggered for %v", target)
-		}
+	targets = dedupeRolloutRestartTargets(targets)
+	waves := rolloutRestartWaves(targets)
+	if len(waves) > 1 {
+		return handleRolloutRestartWaves(ctx, client, obj, recorder, waves, conditions)
 	}
 
+	errs := rolloutRestartTargets(ctx, client, obj, recorder, targets, true)
 	if errs != nil {
 		logger.Error(errs, "Rollout restart failed", "targets", targets)
 	} else {
@@ -77,11 +119,66 @@ func HandleRolloutRestarts(ctx context.Context, client ctrlclient.Client, obj ct
 	return errs
 }
 
+// rolloutRestartTargets triggers a rollout-restart for each target, an event
+// is emitted for every target. The restarts may be coalesced, unless they must
+// happen right away.
+func rolloutRestartTargets(ctx context.Context, client ctrlclient.Client, obj ctrlclient.Object, recorder record.EventRecorder, targets []v1beta1.RolloutRestartTarget, coalesce bool) error {
+	var errs error
+	for _, target := range targets {
+		errs = errors.Join(errs, rolloutRestartTarget(ctx, client, obj, recorder, target, coalesce))
+	}
+
+	return errs
+}
+
+// rolloutRestartTarget triggers a rollout-restart for target, emitting an event
+// for the outcome. If coalesce is true, the restart goes through the
+// DefaultRolloutRestartCoalescer, when it is set.
+func rolloutRestartTarget(ctx context.Context, client ctrlclient.Client, obj ctrlclient.Object, recorder record.EventRecorder, target v1beta1.RolloutRestartTarget, coalesce bool) error {
+	coalescer := DefaultRolloutRestartCoalescer
+	var coalesced bool
+	var err error
+	if coalesce && coalescer != nil {
+		coalesced, err = coalescer.RolloutRestart(ctx, obj.GetNamespace(), target)
+	} else {
+		err = RolloutRestart(ctx, obj.GetNamespace(), target, client)
+		if err == nil && coalescer != nil {
+			coalescer.Restarted(obj.GetNamespace(), target)
+		}
+	}
+	if err != nil {
+		recorder.Eventf(obj, corev1.EventTypeWarning, consts.ReasonRolloutRestartFailed,
+			"Rollout restart failed for target %#v: err=%s", target, err)
+		return err
+	}
+
+	if coalesced {
+		recorder.Eventf(obj, corev1.EventTypeNormal, consts.ReasonRolloutRestartCoalesced,
+			"Rollout restart coalesced for %v, it was restarted less than %s ago", target, coalescer.Window)
+		return nil
+	}
+
+	recorder.Eventf(obj, corev1.EventTypeNormal, consts.ReasonRolloutRestartTriggered,
+		"Rollout restart triggered for %v", target)
+	return nil
+}
+
 // RolloutRestart patches the target in namespace for rollout-restart.
 // Supported target Kinds are: DaemonSet, Deployment, StatefulSet
 func RolloutRestart(ctx context.Context, namespace string, target v1beta1.RolloutRestartTarget, client ctrlclient.Client) error {
+	obj, err := rolloutRestartObject(namespace, target)
+	if err != nil {
+		return err
+	}
+
+	return patchForRolloutRestart(ctx, obj, client)
+}
+
+// rolloutRestartObject returns an empty object of the target's Kind, for the
+// target in namespace.
+func rolloutRestartObject(namespace string, target v1beta1.RolloutRestartTarget) (ctrlclient.Object, error) {
 	if namespace == "" {
-		return fmt.Errorf("namespace cannot be empty")
+		return nil, fmt.Errorf("namespace cannot be empty")
 	}
 
 	objectMeta := metav1.ObjectMeta{
@@ -108,10 +205,10 @@ func RolloutRestart(ctx context.Context, namespace string, target v1beta1.Rollou
 			ObjectMeta: objectMeta,
 		}
 	default:
-		return fmt.Errorf("unsupported Kind %q for %T", target.Kind, target)
+		return nil, fmt.Errorf("unsupported Kind %q for %T", target.Kind, target)
 	}
 
-	return patchForRolloutRestart(ctx, obj, client)
+	return obj, nil
 }
 
 func patchForRolloutRestart(ctx context.Context, obj ctrlclient.Object, client ctrlclient.Client) error {
@@ -5,7 +5,9 @@ package helpers
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -13,10 +15,15 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	"github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/consts"
 	"github.com/hashicorp/vault-secrets-operator/internal/testutils"
 )
 
@@ -185,3 +192,253 @@ func assertPatchedRolloutRestartObj(t *testing.T, ctx context.Context, obj ctrlc
 		"restartAt should be after beforeRolloutRestart",
 		attr, restartAtTime, "beforeRolloutRestart", beforeRolloutRestart)
 }
+
+func Test_rolloutRestartWaves(t *testing.T) {
+	t.Parallel()
+
+	waves := rolloutRestartWaves([]v1beta1.RolloutRestartTarget{
+		{Kind: "Deployment", Name: "app", Wave: 1},
+		{Kind: "StatefulSet", Name: "db", Timeout: "10m"},
+		{Kind: "Deployment", Name: "worker", Wave: 1, Timeout: "1m"},
+		{Kind: "DaemonSet", Name: "agent", Timeout: "30s"},
+	})
+	require.Len(t, waves, 2)
+	assert.Equal(t, int32(0), waves[0].number)
+	assert.Equal(t, []string{"db", "agent"}, []string{waves[0].targets[0].Name, waves[0].targets[1].Name})
+	assert.Equal(t, 10*time.Minute, waves[0].timeout)
+	assert.Equal(t, int32(1), waves[1].number)
+	assert.Equal(t, []string{"app", "worker"}, []string{waves[1].targets[0].Name, waves[1].targets[1].Name})
+	assert.Equal(t, time.Minute, waves[1].timeout)
+
+	waves = rolloutRestartWaves([]v1beta1.RolloutRestartTarget{
+		{Kind: "Deployment", Name: "app"},
+	})
+	require.Len(t, waves, 1)
+	assert.Equal(t, DefaultRolloutWaveTimeout, waves[0].timeout)
+}
+
+func TestHandleRolloutRestarts_waves(t *testing.T) {
+	// rolloutWaitInterval is global, so this test must not run in parallel.
+	interval := rolloutWaitInterval
+	rolloutWaitInterval = 10 * time.Millisecond
+	t.Cleanup(func() {
+		rolloutWaitInterval = interval
+	})
+
+	ctx := context.Background()
+	newStatefulSet := func(ready bool) *appsv1.StatefulSet {
+		o := &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "db",
+			},
+			Status: appsv1.StatefulSetStatus{
+				CurrentRevision: "db-1",
+				UpdateRevision:  "db-1",
+				UpdatedReplicas: 1,
+				ReadyReplicas:   1,
+			},
+		}
+		if !ready {
+			o.Status.UpdateRevision = "db-2"
+		}
+		return o
+	}
+
+	tests := []struct {
+		name        string
+		ready       bool
+		wantErr     assert.ErrorAssertionFunc
+		wantStatus  metav1.ConditionStatus
+		wantReason  string
+		wantRestart bool
+	}{
+		{
+			name:        "complete",
+			ready:       true,
+			wantErr:     assert.NoError,
+			wantStatus:  metav1.ConditionTrue,
+			wantReason:  consts.ReasonRolloutRestartTriggered,
+			wantRestart: true,
+		},
+		{
+			name:  "aborted",
+			ready: false,
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.EqualError(t, err,
+					"timed out after 100ms waiting for the rollout of StatefulSet/db", i...)
+			},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: consts.ReasonRolloutRestartAborted,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deployment := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "app",
+				},
+			}
+			c := testutils.NewFakeClientBuilder().
+				WithObjects(newStatefulSet(tt.ready), deployment).Build()
+
+			obj := &v1beta1.VaultStaticSecret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "foo",
+				},
+				Spec: v1beta1.VaultStaticSecretSpec{
+					RolloutRestartTargets: []v1beta1.RolloutRestartTarget{
+						{Kind: "Deployment", Name: "app", Wave: 1},
+						{Kind: "StatefulSet", Name: "db", Timeout: "100ms"},
+					},
+				},
+			}
+
+			recorder := record.NewFakeRecorder(10)
+			tt.wantErr(t, HandleRolloutRestarts(ctx, c, obj, recorder))
+
+			cond := meta.FindStatusCondition(obj.Status.Conditions,
+				v1beta1.ConditionTypeRolloutRestartComplete)
+			require.NotNil(t, cond)
+			assert.Equal(t, tt.wantStatus, cond.Status)
+			assert.Equal(t, tt.wantReason, cond.Reason)
+
+			require.NoError(t, c.Get(ctx, ctrlclient.ObjectKeyFromObject(deployment), deployment))
+			_, restarted := deployment.Spec.Template.Annotations[AnnotationRestartedAt]
+			assert.Equal(t, tt.wantRestart, restarted)
+		})
+	}
+}
+
+func Test_dedupeRolloutRestartTargets(t *testing.T) {
+	t.Parallel()
+
+	got := dedupeRolloutRestartTargets([]v1beta1.RolloutRestartTarget{
+		{Kind: "Deployment", Name: "app", Wave: 2, Timeout: "1m"},
+		{Kind: "StatefulSet", Name: "app"},
+		{Kind: "Deployment", Name: "worker"},
+		{Kind: "Deployment", Name: "app", Wave: 1, Timeout: "30s"},
+		{Kind: "Deployment", Name: "app", Wave: 3, Timeout: "5m"},
+		{Kind: "Deployment", Name: "worker", Timeout: "10s"},
+	})
+	assert.Equal(t, []v1beta1.RolloutRestartTarget{
+		{Kind: "Deployment", Name: "app", Wave: 1, Timeout: "5m"},
+		{Kind: "StatefulSet", Name: "app"},
+		{Kind: "Deployment", Name: "worker", Timeout: "10s"},
+	}, got)
+}
+
+func TestRolloutRestartCoalescer(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "app",
+		},
+	}
+	var patches atomic.Int32
+	c := testutils.NewFakeClientBuilder().
+		WithObjects(deployment).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(ctx context.Context, client ctrlclient.WithWatch, obj ctrlclient.Object, patch ctrlclient.Patch, opts ...ctrlclient.PatchOption) error {
+				// only count the restarts.
+				if patch.Type() == types.StrategicMergePatchType {
+					patches.Add(1)
+				}
+				return client.Patch(ctx, obj, patch, opts...)
+			},
+		}).Build()
+
+	coalescer := NewRolloutRestartCoalescer(c, 200*time.Millisecond)
+	deferredUntil := func() (string, bool) {
+		var o appsv1.Deployment
+		require.NoError(t, c.Get(ctx, ctrlclient.ObjectKeyFromObject(deployment), &o))
+		v, ok := o.Annotations[AnnotationRolloutRestartDeferredUntil]
+		return v, ok
+	}
+	target := v1beta1.RolloutRestartTarget{Kind: "Deployment", Name: "app"}
+
+	coalesced, err := coalescer.RolloutRestart(ctx, "default", target)
+	require.NoError(t, err)
+	assert.False(t, coalesced)
+	assert.Equal(t, int32(1), patches.Load())
+
+	// the next restarts are deferred to the end of the window, as a single one.
+	for i := 0; i < 5; i++ {
+		coalesced, err = coalescer.RolloutRestart(ctx, "default", target)
+		require.NoError(t, err)
+		assert.True(t, coalesced)
+	}
+	assert.Equal(t, int32(1), patches.Load())
+	// the deferred restart is recorded on the target.
+	_, ok := deferredUntil()
+	assert.True(t, ok)
+
+	assert.Eventually(t, func() bool {
+		return patches.Load() == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	time.Sleep(300 * time.Millisecond)
+	assert.Equal(t, int32(2), patches.Load())
+	_, ok = deferredUntil()
+	assert.False(t, ok)
+
+	// another target is never coalesced.
+	coalesced, err = coalescer.RolloutRestart(ctx, "other", target)
+	assert.False(t, coalesced)
+	assert.ErrorContains(t, err, "not found")
+}
+
+func TestRolloutRestartCoalescer_resume(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	// deferred by a previous Operator instance.
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "app",
+			Annotations: map[string]string{
+				AnnotationRolloutRestartDeferredUntil: time.Now().Add(-time.Second).UTC().Format(time.RFC3339Nano),
+			},
+		},
+	}
+	var patches atomic.Int32
+	c := testutils.NewFakeClientBuilder().
+		WithObjects(deployment).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(ctx context.Context, client ctrlclient.WithWatch, obj ctrlclient.Object, patch ctrlclient.Patch, opts ...ctrlclient.PatchOption) error {
+				if patch.Type() == types.StrategicMergePatchType {
+					// the first restart fails, it must be retried.
+					if patches.Add(1) == 1 {
+						return errors.New("restart failed")
+					}
+				}
+				return client.Patch(ctx, obj, patch, opts...)
+			},
+		}).Build()
+
+	coalescer := NewRolloutRestartCoalescer(c, time.Minute)
+	coalescer.retryInterval = 10 * time.Millisecond
+	go func() {
+		_ = coalescer.Start(ctx)
+	}()
+
+	assert.Eventually(t, func() bool {
+		var o appsv1.Deployment
+		require.NoError(t, c.Get(ctx, ctrlclient.ObjectKeyFromObject(deployment), &o))
+		_, deferred := o.Annotations[AnnotationRolloutRestartDeferredUntil]
+		return !deferred && o.Spec.Template.Annotations[AnnotationRestartedAt] != ""
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, int32(2), patches.Load())
+
+	// the restart is recorded, the next one is deferred.
+	coalesced, err := coalescer.RolloutRestart(ctx, "default",
+		v1beta1.RolloutRestartTarget{Kind: "Deployment", Name: "app"})
+	require.NoError(t, err)
+	assert.True(t, coalesced)
+}
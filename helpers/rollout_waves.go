@@ -0,0 +1,230 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	argorolloutsv1alpha1 "github.com/argoproj/argo-rollouts/pkg/apis/rollouts/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/consts"
+)
+
+// DefaultRolloutWaveTimeout is the time to wait for the targets of a wave to
+// complete their rollout, when none of them set a timeout.
+const DefaultRolloutWaveTimeout = 5 * time.Minute
+
+// rolloutWaitInterval is the interval at which the rollout status of a wave's
+// targets is polled. Overridden in tests.
+var rolloutWaitInterval = 2 * time.Second
+
+// rolloutRestartWave is a group of targets that are restarted together.
+type rolloutRestartWave struct {
+	number  int32
+	targets []v1beta1.RolloutRestartTarget
+	timeout time.Duration
+}
+
+// rolloutRestartWaves groups targets by their wave number, in ascending order.
+// The targets keep their configured order within a wave.
+func rolloutRestartWaves(targets []v1beta1.RolloutRestartTarget) []*rolloutRestartWave {
+	var waves []*rolloutRestartWave
+	for _, target := range targets {
+		idx := slices.IndexFunc(waves, func(w *rolloutRestartWave) bool {
+			return w.number == target.Wave
+		})
+		if idx < 0 {
+			waves = append(waves, &rolloutRestartWave{number: target.Wave})
+			idx = len(waves) - 1
+		}
+
+		w := waves[idx]
+		w.targets = append(w.targets, target)
+		if target.Timeout != "" {
+			// the pattern is validated by the CRD schema.
+			if d, err := time.ParseDuration(target.Timeout); err == nil && d > w.timeout {
+				w.timeout = d
+			}
+		}
+	}
+
+	for _, w := range waves {
+		if w.timeout == 0 {
+			w.timeout = DefaultRolloutWaveTimeout
+		}
+	}
+
+	slices.SortStableFunc(waves, func(a, b *rolloutRestartWave) int {
+		return int(a.number) - int(b.number)
+	})
+
+	return waves
+}
+
+// handleRolloutRestartWaves restarts the targets of each wave in order, waiting
+// for every wave's rollouts to complete before starting the next one. All
+// remaining waves are aborted on the first failed, or timed out, wave. The
+// outcome is recorded in the RolloutRestartComplete condition. The waves'
+// restarts are never coalesced, since they are awaited.
+func handleRolloutRestartWaves(ctx context.Context, client ctrlclient.Client, obj ctrlclient.Object,
+	recorder record.EventRecorder, waves []*rolloutRestartWave, conditions *[]metav1.Condition,
+) error {
+	logger := log.FromContext(ctx).WithValues("waves", len(waves))
+
+	var err error
+	var failedWave int32
+	var aborted []string
+	for i, w := range waves {
+		if err != nil {
+			for _, target := range w.targets {
+				aborted = append(aborted, fmt.Sprintf("%s/%s", target.Kind, target.Name))
+			}
+			continue
+		}
+
+		logger.V(consts.LogLevelDebug).Info("Rollout restart wave", "wave", w.number)
+		if err = rolloutRestartTargets(ctx, client, obj, recorder, w.targets, false); err != nil {
+			failedWave = w.number
+			continue
+		}
+
+		// nothing depends on the last wave, so there is no need to wait for it.
+		if i == len(waves)-1 {
+			break
+		}
+
+		if err = waitForRolloutWave(ctx, client, obj.GetNamespace(), w); err != nil {
+			failedWave = w.number
+			recorder.Eventf(obj, corev1.EventTypeWarning, consts.ReasonRolloutRestartFailed,
+				"Rollout restart wave %d did not complete: err=%s", w.number, err)
+		}
+	}
+
+	condition := metav1.Condition{
+		Type:               v1beta1.ConditionTypeRolloutRestartComplete,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: obj.GetGeneration(),
+		Reason:             consts.ReasonRolloutRestartTriggered,
+		Message:            fmt.Sprintf("Rollout restart completed for all %d waves", len(waves)),
+	}
+	if err != nil {
+		msg := fmt.Sprintf("Rollout restart failed in wave %d: %s", failedWave, err)
+		if len(aborted) > 0 {
+			msg += fmt.Sprintf(", aborted targets: %s", strings.Join(aborted, ", "))
+			recorder.Eventf(obj, corev1.EventTypeWarning, consts.ReasonRolloutRestartAborted,
+				"Rollout restart aborted after wave %d for targets: %s",
+				failedWave, strings.Join(aborted, ", "))
+		}
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = consts.ReasonRolloutRestartAborted
+		condition.Message = msg
+		logger.Error(err, "Rollout restart failed", "wave", failedWave, "aborted", aborted)
+	} else {
+		logger.V(consts.LogLevelDebug).Info("Rollout restart succeeded")
+	}
+
+	if conditions != nil {
+		meta.SetStatusCondition(conditions, condition)
+	}
+
+	return err
+}
+
+// waitForRolloutWave waits for the rollouts of all the wave's targets to
+// complete, or for the wave's timeout to expire.
+func waitForRolloutWave(ctx context.Context, client ctrlclient.Client, namespace string, w *rolloutRestartWave) error {
+	var pending []string
+	err := wait.PollUntilContextTimeout(ctx, rolloutWaitInterval, w.timeout, false,
+		func(ctx context.Context) (bool, error) {
+			pending = pending[:0]
+			for _, target := range w.targets {
+				done, err := isRolloutComplete(ctx, client, namespace, target)
+				if err != nil {
+					return false, err
+				}
+				if !done {
+					pending = append(pending, fmt.Sprintf("%s/%s", target.Kind, target.Name))
+				}
+			}
+			return len(pending) == 0, nil
+		},
+	)
+	if err != nil && wait.Interrupted(err) {
+		return fmt.Errorf("timed out after %s waiting for the rollout of %s",
+			w.timeout, strings.Join(pending, ", "))
+	}
+
+	return err
+}
+
+// isRolloutComplete returns true once the target's most recent rollout has
+// completed, and all of its replicas are available.
+func isRolloutComplete(ctx context.Context, client ctrlclient.Client, namespace string, target v1beta1.RolloutRestartTarget) (bool, error) {
+	key := ctrlclient.ObjectKey{
+		Namespace: namespace,
+		Name:      target.Name,
+	}
+
+	switch target.Kind {
+	case "Deployment":
+		var o appsv1.Deployment
+		if err := client.Get(ctx, key, &o); err != nil {
+			return false, err
+		}
+		replicas := int32(1)
+		if o.Spec.Replicas != nil {
+			replicas = *o.Spec.Replicas
+		}
+		return o.Status.ObservedGeneration >= o.Generation &&
+			o.Status.UpdatedReplicas == replicas &&
+			o.Status.Replicas == replicas &&
+			o.Status.AvailableReplicas == replicas, nil
+	case "StatefulSet":
+		var o appsv1.StatefulSet
+		if err := client.Get(ctx, key, &o); err != nil {
+			return false, err
+		}
+		replicas := int32(1)
+		if o.Spec.Replicas != nil {
+			replicas = *o.Spec.Replicas
+		}
+		return o.Status.ObservedGeneration >= o.Generation &&
+			o.Status.UpdateRevision == o.Status.CurrentRevision &&
+			o.Status.UpdatedReplicas == replicas &&
+			o.Status.ReadyReplicas == replicas, nil
+	case "DaemonSet":
+		var o appsv1.DaemonSet
+		if err := client.Get(ctx, key, &o); err != nil {
+			return false, err
+		}
+		return o.Status.ObservedGeneration >= o.Generation &&
+			o.Status.UpdatedNumberScheduled == o.Status.DesiredNumberScheduled &&
+			o.Status.NumberAvailable == o.Status.DesiredNumberScheduled, nil
+	case "argo.Rollout":
+		var o argorolloutsv1alpha1.Rollout
+		if err := client.Get(ctx, key, &o); err != nil {
+			return false, err
+		}
+		if o.Spec.RestartAt != nil && (o.Status.RestartedAt == nil ||
+			o.Status.RestartedAt.Before(o.Spec.RestartAt)) {
+			return false, nil
+		}
+		return o.Status.Phase == argorolloutsv1alpha1.RolloutPhaseHealthy, nil
+	default:
+		return false, fmt.Errorf("unsupported Kind %q", target.Kind)
+	}
+}
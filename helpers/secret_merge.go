@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package helpers
+
+import (
+	"encoding/json"
+	"maps"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+)
+
+const (
+	OverwriteModeReplace = "Replace"
+	OverwriteModeMerge   = "Merge"
+
+	// FieldManager is the field manager of all the Operator's writes to the
+	// destination Secrets.
+	FieldManager = "vault-secrets-operator"
+)
+
+// isMergeMode returns true if only the synced keys of the Destination d are
+// written to its Secret.
+func isMergeMode(d *secretsv1beta1.Destination) bool {
+	return d.OverwriteMode == OverwriteModeMerge
+}
+
+// managedDataKeys returns the data keys of the Secret s that are managed by the
+// FieldManager, according to its managedFields.
+func managedDataKeys(s *corev1.Secret) map[string]bool {
+	keys := make(map[string]bool)
+	for _, f := range s.GetManagedFields() {
+		if f.Manager != FieldManager || f.FieldsV1 == nil {
+			continue
+		}
+
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(f.FieldsV1.Raw, &fields); err != nil {
+			continue
+		}
+		var data map[string]json.RawMessage
+		if err := json.Unmarshal(fields["f:data"], &data); err != nil {
+			continue
+		}
+		for k := range data {
+			if key, ok := strings.CutPrefix(k, "f:"); ok {
+				keys[key] = true
+			}
+		}
+	}
+
+	return keys
+}
+
+// mergeSecretData returns the data of the Secret orig, merged with the synced
+// data. The keys of orig that are managed by the FieldManager, but are no
+// longer synced, are removed. All other keys of orig are retained.
+func mergeSecretData(orig *corev1.Secret, data map[string][]byte) map[string][]byte {
+	managed := managedDataKeys(orig)
+	result := make(map[string][]byte, len(orig.Data)+len(data))
+	for k, v := range orig.Data {
+		if _, ok := data[k]; !ok && managed[k] {
+			continue
+		}
+		result[k] = v
+	}
+	maps.Copy(result, data)
+
+	return result
+}
+
+// managedSecretData returns the data of the Secret s that is managed by the
+// FieldManager.
+func managedSecretData(s *corev1.Secret) map[string][]byte {
+	managed := managedDataKeys(s)
+	result := make(map[string][]byte, len(managed))
+	for k, v := range s.Data {
+		if managed[k] {
+			result[k] = v
+		}
+	}
+
+	return result
+}
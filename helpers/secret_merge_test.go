@@ -0,0 +1,134 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package helpers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/internal/testutils"
+)
+
+func newMergeTestSecret() *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "app",
+			ManagedFields: []metav1.ManagedFieldsEntry{
+				{
+					Manager:   FieldManager,
+					Operation: metav1.ManagedFieldsOperationUpdate,
+					FieldsV1: &metav1.FieldsV1{
+						Raw: []byte(`{"f:data":{".":{},"f:password":{},"f:stale":{}}}`),
+					},
+				},
+				{
+					Manager:   "cert-manager",
+					Operation: metav1.ManagedFieldsOperationApply,
+					FieldsV1: &metav1.FieldsV1{
+						Raw: []byte(`{"f:data":{"f:tls.crt":{}}}`),
+					},
+				},
+			},
+		},
+		Data: map[string][]byte{
+			"password": []byte("old"),
+			"stale":    []byte("stale"),
+			"tls.crt":  []byte("crt"),
+		},
+	}
+}
+
+func Test_managedDataKeys(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, map[string]bool{
+		"password": true,
+		"stale":    true,
+	}, managedDataKeys(newMergeTestSecret()))
+	assert.Empty(t, managedDataKeys(&corev1.Secret{}))
+}
+
+func Test_mergeSecretData(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, map[string][]byte{
+		"password": []byte("new"),
+		"username": []byte("app"),
+		"tls.crt":  []byte("crt"),
+	}, mergeSecretData(newMergeTestSecret(), map[string][]byte{
+		"password": []byte("new"),
+		"username": []byte("app"),
+	}))
+}
+
+func Test_managedSecretData(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, map[string][]byte{
+		"password": []byte("old"),
+		"stale":    []byte("stale"),
+	}, managedSecretData(newMergeTestSecret()))
+}
+
+func TestSyncSecret_overwriteMode(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	data := map[string][]byte{
+		"password": []byte("new"),
+	}
+
+	tests := []struct {
+		name string
+		mode string
+		want map[string][]byte
+	}{
+		{
+			name: "replace",
+			mode: OverwriteModeReplace,
+			want: data,
+		},
+		{
+			name: "merge",
+			mode: OverwriteModeMerge,
+			want: map[string][]byte{
+				"password": []byte("new"),
+				"tls.crt":  []byte("crt"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := newMergeTestSecret()
+			c := testutils.NewFakeClientBuilder().WithObjects(s).Build()
+			obj := &secretsv1beta1.VaultStaticSecret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "foo",
+				},
+				Spec: secretsv1beta1.VaultStaticSecretSpec{
+					Destination: secretsv1beta1.Destination{
+						Name:          "app",
+						OverwriteMode: tt.mode,
+					},
+				},
+			}
+			require.NoError(t, SyncSecret(ctx, c, obj, data))
+
+			var got corev1.Secret
+			require.NoError(t, c.Get(ctx, ctrlclient.ObjectKeyFromObject(s), &got))
+			assert.Equal(t, tt.want, got.Data)
+		})
+	}
+}
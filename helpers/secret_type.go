@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package helpers
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// secretTypeRequiredKeys are the data keys that Kubernetes requires for the
+// built-in Secret types. Any of a type's keys may be required, e.g. basic-auth
+// requires either a username or a password, see secretTypeAnyKey.
+var secretTypeRequiredKeys = map[corev1.SecretType][]string{
+	corev1.SecretTypeTLS:              {corev1.TLSCertKey, corev1.TLSPrivateKeyKey},
+	corev1.SecretTypeBasicAuth:        {corev1.BasicAuthUsernameKey, corev1.BasicAuthPasswordKey},
+	corev1.SecretTypeSSHAuth:          {corev1.SSHAuthPrivateKey},
+	corev1.SecretTypeDockercfg:        {corev1.DockerConfigKey},
+	corev1.SecretTypeDockerConfigJson: {corev1.DockerConfigJsonKey},
+}
+
+// secretTypeAnyKey holds the Secret types that only require any one of their
+// secretTypeRequiredKeys.
+var secretTypeAnyKey = map[corev1.SecretType]bool{
+	corev1.SecretTypeBasicAuth: true,
+}
+
+// validateSecretType returns an error if secretType is not a valid Secret type,
+// or if data does not hold the keys that are required for it. Any type is
+// supported, including the custom ones, e.g. argoproj.io/cluster, only the
+// built-in types have required keys. Empty data is never validated, since it
+// clears the Secret.
+func validateSecretType(secretType corev1.SecretType, data map[string][]byte) error {
+	if errs := validation.IsQualifiedName(string(secretType)); len(errs) > 0 {
+		return fmt.Errorf("invalid Secret type %q: %s", secretType, strings.Join(errs, ", "))
+	}
+
+	keys, ok := secretTypeRequiredKeys[secretType]
+	if !ok || len(data) == 0 {
+		return nil
+	}
+
+	var missing []string
+	for _, k := range keys {
+		if _, ok := data[k]; !ok {
+			missing = append(missing, k)
+		}
+	}
+	if len(missing) == 0 || (secretTypeAnyKey[secretType] && len(missing) < len(keys)) {
+		return nil
+	}
+
+	if secretTypeAnyKey[secretType] {
+		return fmt.Errorf("secret data of type %q requires any of the keys: %s",
+			secretType, strings.Join(keys, ", "))
+	}
+	return fmt.Errorf("secret data of type %q is missing the required keys: %s",
+		secretType, strings.Join(missing, ", "))
+}
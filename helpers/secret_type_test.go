@@ -0,0 +1,83 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package helpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func Test_validateSecretType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		secretType corev1.SecretType
+		data       map[string][]byte
+		wantErr    string
+	}{
+		{
+			name:       "opaque",
+			secretType: corev1.SecretTypeOpaque,
+			data:       map[string][]byte{"foo": nil},
+		},
+		{
+			name:       "custom",
+			secretType: "argoproj.io/cluster",
+			data:       map[string][]byte{"server": nil},
+		},
+		{
+			name:       "invalid-custom",
+			secretType: "argoproj.io/cluster/v1",
+			data:       map[string][]byte{"server": nil},
+			wantErr:    `invalid Secret type "argoproj.io/cluster/v1"`,
+		},
+		{
+			name:       "tls",
+			secretType: corev1.SecretTypeTLS,
+			data:       map[string][]byte{"tls.crt": nil, "tls.key": nil},
+		},
+		{
+			name:       "tls-missing-key",
+			secretType: corev1.SecretTypeTLS,
+			data:       map[string][]byte{"tls.crt": nil},
+			wantErr:    `secret data of type "kubernetes.io/tls" is missing the required keys: tls.key`,
+		},
+		{
+			name:       "tls-empty",
+			secretType: corev1.SecretTypeTLS,
+		},
+		{
+			name:       "basic-auth-username",
+			secretType: corev1.SecretTypeBasicAuth,
+			data:       map[string][]byte{"username": nil},
+		},
+		{
+			name:       "basic-auth-missing",
+			secretType: corev1.SecretTypeBasicAuth,
+			data:       map[string][]byte{"user": nil},
+			wantErr:    `secret data of type "kubernetes.io/basic-auth" requires any of the keys: username, password`,
+		},
+		{
+			name:       "ssh-auth-missing",
+			secretType: corev1.SecretTypeSSHAuth,
+			data:       map[string][]byte{"key": nil},
+			wantErr:    `secret data of type "kubernetes.io/ssh-auth" is missing the required keys: ssh-privatekey`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validateSecretType(tt.secretType, tt.data)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package helpers
+
+import (
+	"context"
+	"errors"
+	"maps"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/consts"
+	"github.com/hashicorp/vault-secrets-operator/secretless"
+)
+
+var errSecretlessDisabled = errors.New(
+	"destination requires secretless mode, which is not enabled on the operator")
+
+// syncSecretless stores data in the secretless.DefaultStore instead of a K8s
+// Secret. Since the data must never land in a Secret when secretless mode is
+// requested, any Secrets previously synced for obj are deleted.
+func syncSecretless(ctx context.Context, client ctrlclient.Client, obj ctrlclient.Object,
+	dest *secretsv1beta1.Destination, key ctrlclient.ObjectKey, data map[string][]byte,
+) error {
+	if secretless.DefaultStore == nil {
+		return errSecretlessDisabled
+	}
+
+	logger := log.FromContext(ctx).WithName("syncSecretless").WithValues("key", key)
+	if err := secretless.DefaultStore.Set(key, obj.GetUID(), dest.Secretless.ServiceAccounts,
+		dest.Secretless.OneShot, data); err != nil {
+		return err
+	}
+	logger.V(consts.LogLevelDebug).Info("Stored secretless data")
+
+	secrets, err := FindSecretsOwnedByObj(ctx, client, obj)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range secrets {
+		logger.V(consts.LogLevelDebug).Info("Deleting previously synced secret",
+			"secret", ctrlclient.ObjectKeyFromObject(&s))
+		if err := DeleteSecret(ctx, client, ctrlclient.ObjectKeyFromObject(&s)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getSecretlessSecret returns a Secret that holds the data stored for obj in
+// secretless.DefaultStore, it is never persisted. This allows for the existence
+// checks and drift detection to treat both delivery modes the same way.
+func getSecretlessSecret(obj ctrlclient.Object, key ctrlclient.ObjectKey) (*corev1.Secret, bool, error) {
+	if secretless.DefaultStore == nil {
+		return nil, false, errSecretlessDisabled
+	}
+
+	entry, ok := secretless.DefaultStore.Get(key)
+	if !ok || entry.OwnerUID != obj.GetUID() {
+		return nil, false, nil
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+		},
+		Data: maps.Clone(entry.Data),
+	}, true, nil
+}
+
+// DeleteSecretlessData removes any data stored for obj in secretless mode. It
+// should be called when obj is deleted.
+func DeleteSecretlessData(obj ctrlclient.Object) {
+	if secretless.DefaultStore != nil {
+		secretless.DefaultStore.DeleteOwnedBy(obj.GetUID())
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package helpers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/internal/testutils"
+	"github.com/hashicorp/vault-secrets-operator/secretless"
+)
+
+func TestSyncSecret_secretless(t *testing.T) {
+	// secretless.DefaultStore is global, so this test must not run in parallel.
+	t.Cleanup(func() {
+		secretless.DefaultStore = nil
+	})
+
+	ctx := context.Background()
+	obj := &secretsv1beta1.VaultStaticSecret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: secretsv1beta1.GroupVersion.String(),
+			Kind:       "VaultStaticSecret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "baz",
+			UID:       "a1b2c3d4-0000-0000-0000-000000000001",
+		},
+		Spec: secretsv1beta1.VaultStaticSecretSpec{
+			Destination: secretsv1beta1.Destination{
+				Name:   "creds",
+				Create: true,
+			},
+		},
+	}
+	data := map[string][]byte{"password": []byte("secret")}
+	key := ctrlclient.ObjectKey{Namespace: "baz", Name: "creds"}
+	client := testutils.NewFakeClientBuilder().Build()
+
+	// sync to a Secret first.
+	require.NoError(t, SyncSecret(ctx, client, obj, data))
+	_, err := GetSecret(ctx, client, key)
+	require.NoError(t, err)
+
+	obj.Spec.Destination.Secretless = &secretsv1beta1.SecretlessDelivery{
+		ServiceAccounts: []string{"app"},
+	}
+	assert.ErrorIs(t, SyncSecret(ctx, client, obj, data), errSecretlessDisabled)
+	_, _, err = GetSyncableSecret(ctx, client, obj)
+	assert.ErrorIs(t, err, errSecretlessDisabled)
+
+	secretless.DefaultStore = secretless.NewStore()
+	exists, err := CheckSecretExists(ctx, client, obj)
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	require.NoError(t, SyncSecret(ctx, client, obj, data))
+	entry, ok := secretless.DefaultStore.Get(types.NamespacedName(key))
+	require.True(t, ok)
+	assert.Equal(t, data, entry.Data)
+	assert.Equal(t, []string{"app"}, entry.ServiceAccounts)
+
+	// the previously synced Secret must be gone.
+	_, err = GetSecret(ctx, client, key)
+	assert.True(t, apierrors.IsNotFound(err))
+
+	got, exists, err := GetSyncableSecret(ctx, client, obj)
+	require.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, data, got.Data)
+
+	// switching back out of secretless mode drops the stored data.
+	obj.Spec.Destination.Secretless = nil
+	require.NoError(t, SyncSecret(ctx, client, obj, data))
+	assert.Equal(t, 0, secretless.DefaultStore.Len())
+	s, err := GetSecret(ctx, client, key)
+	require.NoError(t, err)
+	assert.Equal(t, data, s.Data)
+	assert.Equal(t, corev1.SecretTypeOpaque, s.Type)
+
+	obj.Spec.Destination.Secretless = &secretsv1beta1.SecretlessDelivery{
+		ServiceAccounts: []string{"app"},
+	}
+	require.NoError(t, SyncSecret(ctx, client, obj, data))
+	DeleteSecretlessData(obj)
+	assert.Equal(t, 0, secretless.DefaultStore.Len())
+}
@@ -4,11 +4,14 @@
 package helpers
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha256"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 	"time"
 
@@ -19,6 +22,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -26,6 +30,7 @@ import (
 	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
 	"github.com/hashicorp/vault-secrets-operator/common"
 	"github.com/hashicorp/vault-secrets-operator/consts"
+	"github.com/hashicorp/vault-secrets-operator/internal/metrics"
 	"github.com/hashicorp/vault-secrets-operator/utils"
 )
 
@@ -134,6 +139,9 @@ func DefaultSyncOptions() SyncOptions {
 type SyncOptions struct {
 	// PruneOrphans controls whether to delete any previously synced k8s Secrets.
 	PruneOrphans bool
+	// SourceVersion is the version of the Vault secret that data was read from,
+	// if it is known. It is recorded in the Destination's MetadataConfigMap.
+	SourceVersion string
 }
 
 // SyncSecret writes data to a Kubernetes Secret for obj. All configuring is
@@ -143,6 +151,18 @@ type SyncOptions struct {
 //
 // See NewSyncableSecretMetaData for the supported types for obj.
 func SyncSecret(ctx context.Context, client ctrlclient.Client, obj ctrlclient.Object, data map[string][]byte, opts ...SyncOptions) error {
+	if err := syncSecret(ctx, client, obj, data, opts...); err != nil {
+		return err
+	}
+
+	if err := dualWriteSecret(ctx, client, obj, data); err != nil {
+		return err
+	}
+
+	return recordSyncLedger(ctx, obj, data)
+}
+
+func syncSecret(ctx context.Context, client ctrlclient.Client, obj ctrlclient.Object, data map[string][]byte, opts ...SyncOptions) error {
 	var options SyncOptions
 	if len(opts) > 0 {
 		options = opts[0]
@@ -166,6 +186,25 @@ func SyncSecret(ctx context.Context, client ctrlclient.Client, obj ctrlclient.Ob
 		return fmt.Errorf("invalid Destination, err=%w", err)
 	}
 
+	if hasFanOut(meta.Destination) && (!meta.Destination.Create || meta.Destination.Secretless != nil) {
+		return fmt.Errorf("invalid Destination, namespaces and namespaceSelector require create=true, " +
+			"and cannot be combined with secretless")
+	}
+
+	if meta.Destination.Immutable && meta.Destination.Secretless != nil {
+		return fmt.Errorf("invalid Destination, immutable cannot be combined with secretless")
+	}
+
+	if meta.Destination.Secretless != nil {
+		return syncSecretless(ctx, client, obj, meta.Destination, key, data)
+	}
+	// the destination may have been switched out of secretless mode.
+	DeleteSecretlessData(obj)
+
+	if meta.Destination.Immutable {
+		return syncImmutableSecret(ctx, client, obj, meta, data, options)
+	}
+
 	dest, exists, err := getSecretExists(ctx, client, key)
 	if err != nil {
 		return err
@@ -174,7 +213,7 @@ func SyncSecret(ctx context.Context, client ctrlclient.Client, obj ctrlclient.Ob
 	pruneOrphans := func() {
 		if options.PruneOrphans {
 			// for now we treat orphan pruning errors as being non-fatal.
-			if err := pruneOrphanSecrets(ctx, client, obj, meta.Destination); err != nil {
+			if err := pruneOrphanSecrets(ctx, client, obj, meta); err != nil {
 				logger.V(consts.LogLevelWarning).Error(err, "Failed to prune orphan secrets",
 					"owner", ctrlclient.ObjectKeyFromObject(obj).String())
 			} else {
@@ -195,9 +234,19 @@ func SyncSecret(ctx context.Context, client ctrlclient.Client, obj ctrlclient.Ob
 		// It will make cleaning up previous labels/annotation additions difficult,  since we don't know
 		// what we set previously. It is possible to keep the previous labels/annotations in the
 		// syncable-secret's Status, but...
-		dest.Data = data
+		orig := dest.DeepCopy()
+		if isMergeMode(meta.Destination) {
+			dest.Data = mergeSecretData(orig, data)
+		} else {
+			dest.Data = data
+		}
 		logger.V(consts.LogLevelDebug).Info("Updating secret")
-		if err := client.Update(ctx, dest); err != nil {
+		if err := patchSecret(ctx, client, orig, dest); err != nil {
+			return err
+		}
+
+		if err := syncMetadataConfigMap(ctx, client, obj, meta,
+			secretDataChanged(orig, dest), options.SourceVersion); err != nil {
 			return err
 		}
 
@@ -207,9 +256,13 @@ func SyncSecret(ctx context.Context, client ctrlclient.Client, obj ctrlclient.Ob
 	}
 
 	// we are responsible for the Secret's complete lifecycle
-	secretType := corev1.SecretTypeOpaque
-	if meta.Destination.Type != "" {
-		secretType = meta.Destination.Type
+	secretType := destinationSecretType(meta.Destination)
+	if err := validateSecretType(secretType, data); err != nil {
+		return fmt.Errorf("invalid Destination, err=%w", err)
+	}
+	namespaces, err := fanOutNamespaces(ctx, client, obj, meta.Destination)
+	if err != nil {
+		return err
 	}
 
 	// these are the OwnerReferences that should be included in any Secret that is created/owned by
@@ -232,7 +285,7 @@ func SyncSecret(ctx context.Context, client ctrlclient.Client, obj ctrlclient.Ob
 		}
 
 		if checkOwnerShip {
-			if err := checkSecretIsOwnedByObj(dest, references); err != nil {
+			if err := checkDestinationOwnership(ctx, client, obj, dest, meta.Destination, references); err != nil {
 				return err
 			}
 		}
@@ -268,9 +321,33 @@ func SyncSecret(ctx context.Context, client ctrlclient.Client, obj ctrlclient.Ob
 	}
 
 	lastType := dest.Type
-	dest.Data = data
+	orig := dest.DeepCopy()
+	if exists && isMergeMode(meta.Destination) {
+		dest.Data = mergeSecretData(orig, data)
+	} else {
+		dest.Data = data
+	}
 	dest.Type = secretType
-	dest.SetAnnotations(meta.Destination.Annotations)
+	annotations := meta.Destination.Annotations
+	if meta.Destination.ChecksumAnnotation {
+		checksum, err := Checksum(DefaultChecksumAlgorithm, data)
+		if err != nil {
+			return err
+		}
+		annotations = maps.Clone(annotations)
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[ChecksumAnnotation(DefaultChecksumAlgorithm)] = checksum
+	}
+	if len(namespaces) > 0 {
+		annotations = maps.Clone(annotations)
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[annotationFanOutNamespaces] = strings.Join(namespaces, ",")
+	}
+	dest.SetAnnotations(annotations)
 	dest.SetLabels(labels)
 	dest.SetOwnerReferences(references)
 	logger.V(consts.LogLevelTrace).Info("ObjectMeta", "objectMeta", dest.ObjectMeta)
@@ -296,36 +373,171 @@ func SyncSecret(ctx context.Context, client ctrlclient.Client, obj ctrlclient.Ob
 			dest.ResourceVersion = ""
 			dest.Generation = 0
 			dest.SetLabels(labels)
-			if err := client.Create(ctx, dest); err != nil {
+			if err := createSecret(ctx, client, dest); err != nil {
 				return err
 			}
 		} else {
 			logger.V(consts.LogLevelDebug).Info("Updating secret")
-			if err := client.Update(ctx, dest); err != nil {
+			if err := patchSecret(ctx, client, orig, dest); err != nil {
 				return err
 			}
 		}
 	} else {
 		logger.V(consts.LogLevelDebug).Info("Creating secret")
-		if err := client.Create(ctx, dest); err != nil {
+		if err := createSecret(ctx, client, dest); err != nil {
 			return err
 		}
 	}
 
+	if err := syncCrossNamespaceSecrets(ctx, client, obj, dest, namespaces, fanOutNamespacesOf(orig)); err != nil {
+		return err
+	}
+
+	if err := syncMetadataConfigMap(ctx, client, obj, meta,
+		!exists || secretDataChanged(orig, dest), options.SourceVersion); err != nil {
+		return err
+	}
+
 	pruneOrphans()
 
 	return nil
 }
 
-func pruneOrphanSecrets(ctx context.Context, client ctrlclient.Client, obj ctrlclient.Object, dest *secretsv1beta1.Destination) error {
+// destinationSecretType returns the type of the Secrets that are created for
+// the Destination d.
+func destinationSecretType(d *secretsv1beta1.Destination) corev1.SecretType {
+	switch {
+	case d.Type != "":
+		return d.Type
+	case d.Transformation.DockerConfigJSON != nil:
+		return corev1.SecretTypeDockerConfigJson
+	case d.Transformation.BasicAuth != nil:
+		return corev1.SecretTypeBasicAuth
+	default:
+		return corev1.SecretTypeOpaque
+	}
+}
+
+// jsonPatchOperation is a single RFC 6902 JSON Patch operation.
+type jsonPatchOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	Value any    `json:"value,omitempty"`
+}
+
+// patchSecret updates the Secret in Kubernetes by sending a JSON Patch
+// containing only the differences between orig and dest. Only the data keys
+// that were added, changed, or removed are included in the patch, which keeps
+// the write size small when a few keys change in a large Secret. The patch is
+// guarded by a test on the orig's ResourceVersion, so it will fail with a
+// conflict if the Secret was modified concurrently. No request is made if the
+// Secret has not changed.
+func patchSecret(ctx context.Context, client ctrlclient.Client, orig, dest *corev1.Secret) error {
+	ops := secretJSONPatchOps(orig, dest)
+	if len(ops) == 0 {
+		log.FromContext(ctx).V(consts.LogLevelTrace).Info("Secret is unchanged, skipping update")
+		return nil
+	}
+
+	ops = append([]jsonPatchOperation{
+		{
+			Op:    "test",
+			Path:  "/metadata/resourceVersion",
+			Value: orig.ResourceVersion,
+		},
+	}, ops...)
+
+	b, err := json.Marshal(ops)
+	if err != nil {
+		return err
+	}
+
+	if err := client.Patch(ctx, dest, ctrlclient.RawPatch(types.JSONPatchType, b),
+		ctrlclient.FieldOwner(FieldManager)); err != nil {
+		return err
+	}
+
+	metrics.ObserveSecretDataWritten(dest.Namespace, dest.Data)
+	return nil
+}
+
+// createSecret creates the Secret dest in Kubernetes.
+func createSecret(ctx context.Context, client ctrlclient.Client, dest *corev1.Secret) error {
+	if err := client.Create(ctx, dest, ctrlclient.FieldOwner(FieldManager)); err != nil {
+		return err
+	}
+
+	metrics.ObserveSecretDataWritten(dest.Namespace, dest.Data)
+	return nil
+}
+
+// secretJSONPatchOps returns the JSON Patch operations needed to transform orig
+// into dest. Only the Secret's data, labels, annotations, and owner references
+// are considered.
+func secretJSONPatchOps(orig, dest *corev1.Secret) []jsonPatchOperation {
+	var ops []jsonPatchOperation
+	switch {
+	case len(orig.Data) == 0 && len(dest.Data) > 0:
+		ops = append(ops, jsonPatchOperation{Op: "add", Path: "/data", Value: dest.Data})
+	case len(orig.Data) > 0 && len(dest.Data) == 0:
+		ops = append(ops, jsonPatchOperation{Op: "remove", Path: "/data"})
+	default:
+		for _, k := range slices.Sorted(maps.Keys(orig.Data)) {
+			if _, ok := dest.Data[k]; !ok {
+				ops = append(ops, jsonPatchOperation{Op: "remove", Path: "/data/" + escapeJSONPointer(k)})
+			}
+		}
+		for _, k := range slices.Sorted(maps.Keys(dest.Data)) {
+			v := dest.Data[k]
+			if last, ok := orig.Data[k]; !ok {
+				ops = append(ops, jsonPatchOperation{Op: "add", Path: "/data/" + escapeJSONPointer(k), Value: v})
+			} else if !bytes.Equal(last, v) {
+				ops = append(ops, jsonPatchOperation{Op: "replace", Path: "/data/" + escapeJSONPointer(k), Value: v})
+			}
+		}
+	}
+
+	addMetaOp := func(path string, last, cur any, empty bool) {
+		if equality.Semantic.DeepEqual(last, cur) {
+			return
+		}
+		if empty {
+			ops = append(ops, jsonPatchOperation{Op: "remove", Path: path})
+		} else {
+			ops = append(ops, jsonPatchOperation{Op: "add", Path: path, Value: cur})
+		}
+	}
+	addMetaOp("/metadata/labels", orig.Labels, dest.Labels, len(dest.Labels) == 0)
+	addMetaOp("/metadata/annotations", orig.Annotations, dest.Annotations, len(dest.Annotations) == 0)
+	addMetaOp("/metadata/ownerReferences", orig.OwnerReferences, dest.OwnerReferences, len(dest.OwnerReferences) == 0)
+
+	return ops
+}
+
+// escapeJSONPointer escapes s for use as a reference token in a JSON Pointer,
+// as defined in RFC 6901.
+func escapeJSONPointer(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~", "~0"), "/", "~1")
+}
+
+// pruneOrphanSecrets deletes the Secrets owned by obj, other than those of its
+// Destination and additional destinations, and those named in keep.
+func pruneOrphanSecrets(ctx context.Context, client ctrlclient.Client, obj ctrlclient.Object,
+	meta *common.SyncableSecretMetaData, keep ...string,
+) error {
 	owned, err := FindSecretsOwnedByObj(ctx, client, obj)
 	if err != nil {
 		return err
 	}
 
+	additional := additionalDestinationNames(meta)
 	var errs error
 	for _, s := range owned {
-		if s.Name == dest.Name {
+		if s.Name == meta.Destination.Name || additional[s.Name] || slices.Contains(keep, s.Name) {
+			continue
+		}
+		if err := deleteCrossNamespaceSecrets(ctx, client, obj, s.Name, fanOutNamespacesOf(&s)); err != nil {
+			errs = errors.Join(errs, err)
 			continue
 		}
 		if err := client.Delete(ctx, &s); err != nil {
@@ -361,6 +573,17 @@ func getSecretExistsForObj(ctx context.Context, client ctrlclient.Client, obj ct
 	logger := log.FromContext(ctx).WithName("syncSecret").WithValues(
 		"secretName", meta.Destination.Name, "create", meta.Destination.Create)
 	objKey := ctrlclient.ObjectKey{Namespace: obj.GetNamespace(), Name: meta.Destination.Name}
+	if meta.Destination.Secretless != nil {
+		return getSecretlessSecret(obj, objKey)
+	}
+	if meta.Destination.Immutable {
+		// the current immutable Secret, if any was synced yet.
+		objKey.Name = obj.GetAnnotations()[AnnotationImmutableSecretName]
+		if objKey.Name == "" {
+			return nil, false, nil
+		}
+	}
+
 	s, exists, err := getSecretExists(ctx, client, objKey)
 	if err != nil {
 		// let the caller log the error
@@ -447,7 +670,7 @@ func checkSecretIsOwnedByObj(dest *corev1.Secret, references []metav1.OwnerRefer
 // then creates an existing secret.
 func StoreImmutableSecret(ctx context.Context, client ctrlclient.Client, dest *corev1.Secret) error {
 	objKey := ctrlclient.ObjectKeyFromObject(dest)
-	err := client.Create(ctx, dest)
+	err := createSecret(ctx, client, dest)
 	if apierrors.IsAlreadyExists(err) {
 		// since the Secret is immutable we need to always recreate it
 		err = DeleteSecret(ctx, client, objKey)
@@ -459,7 +682,7 @@ func StoreImmutableSecret(ctx context.Context, client ctrlclient.Client, dest *c
 		bo := backoff.NewExponentialBackOff()
 		bo.MaxInterval = 2 * time.Second
 		err = backoff.Retry(func() error {
-			return client.Create(ctx, dest)
+			return createSecret(ctx, client, dest)
 		}, backoff.WithMaxRetries(bo, 5))
 		if err != nil {
 			return err
@@ -507,13 +730,25 @@ func (s *SecretDataBuilder) WithVaultData(d, secretData map[string]any, opt *Sec
 			metadata = make(map[string]any)
 		}
 
-		input := NewSecretInput(d, metadata, opt.Annotations, opt.Labels)
+		input := newSecretInputForOption(d, metadata, opt)
 		data, err = renderTemplates(opt, input)
 		if err != nil {
 			return nil, err
 		}
 	}
 
+	if err := addYAMLSplits(opt, d, data); err != nil {
+		return nil, err
+	}
+
+	if err := addDockerConfigJSON(opt, d, data); err != nil {
+		return nil, err
+	}
+
+	if err := addBasicAuth(opt, d, data); err != nil {
+		return nil, err
+	}
+
 	return makeK8sData(d, data, raw, opt)
 }
 
@@ -523,6 +758,8 @@ func marshalJSON(value any) ([]byte, error) {
 	switch x := value.(type) {
 	case string:
 		b = []byte(x)
+	case []byte:
+		b = x
 	default:
 		b, err = json.Marshal(value)
 		if err != nil {
@@ -608,12 +845,24 @@ func (s *SecretDataBuilder) WithHVSAppSecrets(resp *hvsclient.OpenAppSecretsOK,
 	}
 
 	if hasTemplates {
-		data, err = renderTemplates(opt, NewSecretInput(secrets, metadata, opt.Annotations, opt.Labels))
+		data, err = renderTemplates(opt, newSecretInputForOption(secrets, metadata, opt))
 		if err != nil {
 			return nil, err
 		}
 	}
 
+	if err := addYAMLSplits(opt, secrets, data); err != nil {
+		return nil, err
+	}
+
+	if err := addDockerConfigJSON(opt, secrets, data); err != nil {
+		return nil, err
+	}
+
+	if err := addBasicAuth(opt, secrets, data); err != nil {
+		return nil, err
+	}
+
 	return makeK8sData(secrets, data, raw, opt)
 }
 
@@ -682,11 +931,33 @@ func makeK8sData[V any](secretData map[string]V, extraData map[string][]byte,
 		data[k] = v
 	}
 
-	filtered, err := filterData(opt, secretData)
+	flattened, err := flattenData(opt, secretData)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered, err := filterData(opt, flattened)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered, err = decodeBase64Fields(opt, filtered)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered, err = mapKeys(opt, filtered)
 	if err != nil {
 		return nil, err
 	}
 
+	if opt.Format != nil {
+		if err := addFormattedFile(opt, filtered, data); err != nil {
+			return nil, err
+		}
+		return addDotEnv(opt, data)
+	}
+
 	// include the filtered fields that are not already in data
 	for k, v := range filtered {
 		if _, ok := data[k]; !ok {
@@ -698,7 +969,7 @@ func makeK8sData[V any](secretData map[string]V, extraData map[string][]byte,
 		}
 	}
 
-	return data, nil
+	return addDotEnv(opt, data)
 }
 
 func NewSecretsDataBuilder() *SecretDataBuilder {
@@ -14,6 +14,7 @@ import (
 	"github.com/go-openapi/strfmt"
 	hvsclient "github.com/hashicorp/hcp-sdk-go/clients/cloud-vault-secrets/preview/2023-11-28/client/secret_service"
 	"github.com/hashicorp/hcp-sdk-go/clients/cloud-vault-secrets/preview/2023-11-28/models"
+	io_prometheus_client "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
@@ -25,6 +26,7 @@ import (
 
 	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
 	"github.com/hashicorp/vault-secrets-operator/common"
+	"github.com/hashicorp/vault-secrets-operator/internal/metrics"
 	"github.com/hashicorp/vault-secrets-operator/internal/testutils"
 )
 
@@ -1256,6 +1258,59 @@ META_QUX=biff
 			},
 			wantErr: assert.NoError,
 		},
+		{
+			name: "yaml-splits-excluded-source",
+			opt: &SecretTransformationOption{
+				ExcludeRaw: true,
+				Excludes:   []string{"^manifests$"},
+				YAMLSplits: []secretsv1beta1.YAMLSplit{
+					{
+						Field:     "manifests",
+						KeyPath:   "$.metadata.name",
+						KeySuffix: ".yaml",
+					},
+				},
+			},
+			data: map[string]interface{}{
+				"baz":       "qux",
+				"manifests": "metadata:\n  name: foo\n---\nmetadata:\n  name: bar\n",
+			},
+			want: map[string][]byte{
+				"baz":      []byte("qux"),
+				"foo.yaml": []byte("metadata:\n  name: foo\n"),
+				"bar.yaml": []byte("metadata:\n  name: bar\n"),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "yaml-splits-template-conflict",
+			opt: &SecretTransformationOption{
+				ExcludeRaw: true,
+				KeyedTemplates: []*KeyedTemplate{
+					{
+						Key: "foo",
+						Template: secretsv1beta1.Template{
+							Name: "tmpl",
+							Text: "{{ .Secrets.baz }}",
+						},
+					},
+				},
+				YAMLSplits: []secretsv1beta1.YAMLSplit{
+					{
+						Field:   "manifests",
+						KeyPath: ".metadata.name",
+					},
+				},
+			},
+			data: map[string]interface{}{
+				"baz":       "qux",
+				"manifests": "metadata:\n  name: foo\n",
+			},
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.EqualError(t, err,
+					`key "foo" from yaml split conflicts with a template`, i...)
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1786,3 +1841,171 @@ func checkDynamicOpenSecretEqual(t *testing.T, want, got *models.Secrets20231128
 	assert.Equal(t, want.DynamicInstance.TTL, got.DynamicInstance.TTL)
 	assert.Equal(t, want.DynamicInstance.Values, got.DynamicInstance.Values)
 }
+
+func Test_secretJSONPatchOps(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		orig *corev1.Secret
+		dest *corev1.Secret
+		want []jsonPatchOperation
+	}{
+		{
+			name: "unchanged",
+			orig: &corev1.Secret{
+				Data: map[string][]byte{"foo": []byte("bar")},
+			},
+			dest: &corev1.Secret{
+				Data: map[string][]byte{"foo": []byte("bar")},
+			},
+		},
+		{
+			name: "data-keys",
+			orig: &corev1.Secret{
+				Data: map[string][]byte{
+					"unchanged": []byte("qux"),
+					"changed":   []byte("foo"),
+					"removed":   []byte("baz"),
+				},
+			},
+			dest: &corev1.Secret{
+				Data: map[string][]byte{
+					"unchanged": []byte("qux"),
+					"changed":   []byte("bar"),
+					"a/b~c":     []byte("buz"),
+				},
+			},
+			want: []jsonPatchOperation{
+				{Op: "remove", Path: "/data/removed"},
+				{Op: "add", Path: "/data/a~1b~0c", Value: []byte("buz")},
+				{Op: "replace", Path: "/data/changed", Value: []byte("bar")},
+			},
+		},
+		{
+			name: "data-from-empty",
+			orig: &corev1.Secret{},
+			dest: &corev1.Secret{
+				Data: map[string][]byte{"foo": []byte("bar")},
+			},
+			want: []jsonPatchOperation{
+				{Op: "add", Path: "/data", Value: map[string][]byte{"foo": []byte("bar")}},
+			},
+		},
+		{
+			name: "data-to-empty",
+			orig: &corev1.Secret{
+				Data: map[string][]byte{"foo": []byte("bar")},
+			},
+			dest: &corev1.Secret{},
+			want: []jsonPatchOperation{
+				{Op: "remove", Path: "/data"},
+			},
+		},
+		{
+			name: "metadata",
+			orig: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      map[string]string{"foo": "bar"},
+					Annotations: map[string]string{"baz": "qux"},
+				},
+			},
+			dest: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"foo": "baz"},
+				},
+			},
+			want: []jsonPatchOperation{
+				{Op: "add", Path: "/metadata/labels", Value: map[string]string{"foo": "baz"}},
+				{Op: "remove", Path: "/metadata/annotations"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, secretJSONPatchOps(tt.orig, tt.dest))
+		})
+	}
+}
+
+func Test_patchSecret(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	key := ctrlclient.ObjectKey{Namespace: "default", Name: "baz"}
+	existing := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+		},
+		Data: map[string][]byte{
+			"unchanged": []byte("qux"),
+			"changed":   []byte("foo"),
+			"removed":   []byte("baz"),
+		},
+	}
+
+	client := testutils.NewFakeClientBuilder().WithObjects(existing).Build()
+	orig, err := GetSecret(ctx, client, key)
+	require.NoError(t, err)
+
+	dest := orig.DeepCopy()
+	dest.Data = map[string][]byte{
+		"unchanged": []byte("qux"),
+		"changed":   []byte("bar"),
+		"added":     []byte("buz"),
+	}
+	require.NoError(t, patchSecret(ctx, client, orig, dest))
+
+	got, err := GetSecret(ctx, client, key)
+	require.NoError(t, err)
+	assert.Equal(t, dest.Data, got.Data)
+
+	// the stale ResourceVersion should cause the patch to fail.
+	dest.Data = map[string][]byte{"foo": []byte("bar")}
+	assert.Error(t, patchSecret(ctx, client, orig, dest))
+
+	// an unchanged Secret should not result in an update.
+	rv := got.ResourceVersion
+	require.NoError(t, patchSecret(ctx, client, got, got.DeepCopy()))
+	got, err = GetSecret(ctx, client, key)
+	require.NoError(t, err)
+	assert.Equal(t, rv, got.ResourceVersion)
+}
+
+func TestSecretDataWrittenBytes(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	namespace := "written-bytes"
+	writtenBytes := func() float64 {
+		t.Helper()
+		var m io_prometheus_client.Metric
+		require.NoError(t, metrics.SecretDataWrittenBytes.WithLabelValues(namespace).Write(&m))
+		return m.GetCounter().GetValue()
+	}
+
+	client := testutils.NewFakeClientBuilder().Build()
+	s := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "baz",
+			Namespace: namespace,
+		},
+		Data: map[string][]byte{
+			"foo": []byte("bar"),
+		},
+	}
+	require.NoError(t, createSecret(ctx, client, s))
+	assert.Equal(t, float64(6), writtenBytes())
+
+	orig := s.DeepCopy()
+	s.Data["foo"] = []byte("barbaz")
+	require.NoError(t, patchSecret(ctx, client, orig, s))
+	assert.Equal(t, float64(15), writtenBytes())
+
+	// an unchanged Secret is not written.
+	require.NoError(t, patchSecret(ctx, client, s, s.DeepCopy()))
+	assert.Equal(t, float64(15), writtenBytes())
+}
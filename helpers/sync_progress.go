@@ -0,0 +1,117 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package helpers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/consts"
+)
+
+// RolloutRestartStep returns the v1beta1.SyncProgress step of the
+// rollout-restart of target.
+func RolloutRestartStep(target v1beta1.RolloutRestartTarget) string {
+	return fmt.Sprintf("RolloutRestart/%s/%s", target.Kind, target.Name)
+}
+
+// NewSyncProgress returns the v1beta1.SyncProgress of the sync of the data
+// whose MAC is secretMAC, with a pending step for the rollout-restart of each
+// target.
+func NewSyncProgress(secretMAC string, targets []v1beta1.RolloutRestartTarget) *v1beta1.SyncProgress {
+	p := &v1beta1.SyncProgress{
+		SecretMAC: secretMAC,
+	}
+	for _, target := range targets {
+		p.PendingSteps = append(p.PendingSteps, RolloutRestartStep(target))
+	}
+	return p
+}
+
+// IsStepPending returns true if step has not completed yet.
+func IsStepPending(p *v1beta1.SyncProgress, step string) bool {
+	return p != nil && slices.Contains(p.PendingSteps, step)
+}
+
+// CompleteStep removes step from the pending steps of p.
+func CompleteStep(p *v1beta1.SyncProgress, step string) {
+	if p == nil {
+		return
+	}
+	p.PendingSteps = slices.DeleteFunc(p.PendingSteps, func(s string) bool {
+		return s == step
+	})
+}
+
+// RolloutRestartPending triggers a rollout-restart for each of targets whose
+// step is still pending in p. The step of every target that was restarted is
+// completed, such that a failed sync is resumed without restarting those
+// targets again. When the pending targets are spread across multiple waves,
+// their steps are only completed once all the waves have completed, since the
+// waves are always restarted in order. See handleRolloutRestartWaves. The
+// steps of the targets that are no longer configured are discarded.
+//
+// Returns all errors encountered.
+func RolloutRestartPending(ctx context.Context, client ctrlclient.Client, obj ctrlclient.Object,
+	recorder record.EventRecorder, targets []v1beta1.RolloutRestartTarget,
+	conditions *[]metav1.Condition, p *v1beta1.SyncProgress,
+) error {
+	if p == nil {
+		return nil
+	}
+
+	targets = dedupeRolloutRestartTargets(targets)
+	steps := make([]string, 0, len(targets))
+	var pending []v1beta1.RolloutRestartTarget
+	for _, target := range targets {
+		step := RolloutRestartStep(target)
+		steps = append(steps, step)
+		if IsStepPending(p, step) {
+			pending = append(pending, target)
+		}
+	}
+	p.PendingSteps = slices.DeleteFunc(p.PendingSteps, func(s string) bool {
+		return !slices.Contains(steps, s)
+	})
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	logger := log.FromContext(ctx).WithValues("pending", len(pending), "total", len(targets))
+	if waves := rolloutRestartWaves(pending); len(waves) > 1 {
+		if err := handleRolloutRestartWaves(ctx, client, obj, recorder, waves, conditions); err != nil {
+			return err
+		}
+		for _, target := range pending {
+			CompleteStep(p, RolloutRestartStep(target))
+		}
+		return nil
+	}
+
+	var errs error
+	for _, target := range pending {
+		if err := rolloutRestartTarget(ctx, client, obj, recorder, target, true); err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		CompleteStep(p, RolloutRestartStep(target))
+	}
+
+	if errs != nil {
+		logger.Error(errs, "Rollout restart failed", "remaining", p.PendingSteps)
+	} else {
+		logger.V(consts.LogLevelDebug).Info("Rollout restart succeeded")
+	}
+
+	return errs
+}
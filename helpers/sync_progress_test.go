@@ -0,0 +1,101 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package helpers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/internal/testutils"
+)
+
+func TestNewSyncProgress(t *testing.T) {
+	t.Parallel()
+
+	p := NewSyncProgress("mac", []v1beta1.RolloutRestartTarget{
+		{Kind: "Deployment", Name: "app"},
+		{Kind: "StatefulSet", Name: "db"},
+	})
+	assert.Equal(t, &v1beta1.SyncProgress{
+		SecretMAC: "mac",
+		PendingSteps: []string{
+			"RolloutRestart/Deployment/app",
+			"RolloutRestart/StatefulSet/db",
+		},
+	}, p)
+	assert.True(t, IsStepPending(p, "RolloutRestart/Deployment/app"))
+
+	CompleteStep(p, "RolloutRestart/Deployment/app")
+	assert.False(t, IsStepPending(p, "RolloutRestart/Deployment/app"))
+	assert.Equal(t, []string{"RolloutRestart/StatefulSet/db"}, p.PendingSteps)
+	assert.False(t, IsStepPending(nil, "RolloutRestart/StatefulSet/db"))
+}
+
+func TestRolloutRestartPending(t *testing.T) {
+	ctx := context.Background()
+	app := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "app",
+		},
+	}
+	c := testutils.NewFakeClientBuilder().WithObjects(app).Build()
+	obj := &v1beta1.VaultStaticSecret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "foo",
+		},
+		Spec: v1beta1.VaultStaticSecretSpec{
+			RolloutRestartTargets: []v1beta1.RolloutRestartTarget{
+				{Kind: "Deployment", Name: "app"},
+				{Kind: "Deployment", Name: "web"},
+			},
+		},
+	}
+
+	restartedAt := func() string {
+		t.Helper()
+		var d appsv1.Deployment
+		require.NoError(t, c.Get(ctx, ctrlclient.ObjectKeyFromObject(app), &d))
+		return d.Spec.Template.Annotations[AnnotationRestartedAt]
+	}
+
+	// the Deployment web does not exist yet, so only app is restarted.
+	p := NewSyncProgress("mac", obj.Spec.RolloutRestartTargets)
+	// a step of a target that is no longer configured is discarded.
+	p.PendingSteps = append(p.PendingSteps, "RolloutRestart/Deployment/removed")
+	recorder := record.NewFakeRecorder(10)
+	assert.Error(t, RolloutRestartPending(ctx, c, obj, recorder,
+		obj.Spec.RolloutRestartTargets, &obj.Status.Conditions, p))
+	assert.Equal(t, []string{"RolloutRestart/Deployment/web"}, p.PendingSteps)
+	assert.Len(t, recorder.Events, 2)
+	appRestartedAt := restartedAt()
+	assert.NotEmpty(t, appRestartedAt)
+
+	// resuming only restarts the targets that are still pending.
+	require.NoError(t, c.Create(ctx, &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "web",
+		},
+	}))
+	recorder = record.NewFakeRecorder(10)
+	require.NoError(t, RolloutRestartPending(ctx, c, obj, recorder,
+		obj.Spec.RolloutRestartTargets, &obj.Status.Conditions, p))
+	assert.Empty(t, p.PendingSteps)
+	assert.Len(t, recorder.Events, 1)
+	assert.Equal(t, appRestartedAt, restartedAt())
+
+	// nothing is pending.
+	require.NoError(t, RolloutRestartPending(ctx, c, obj, recorder,
+		obj.Spec.RolloutRestartTargets, &obj.Status.Conditions, nil))
+}
@@ -13,6 +13,8 @@ import (
 	"slices"
 
 	lru "github.com/hashicorp/golang-lru/v2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/utils/ptr"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -84,11 +86,41 @@ type SecretTransformationOption struct {
 	Annotations map[string]string
 	// Labels to include in the SecretInput.
 	Labels map[string]string
+	// NamespaceLabels are the labels of the syncable secret's namespace, to
+	// include in the SecretInput.
+	NamespaceLabels map[string]string
+	// NamespaceAnnotations are the annotations of the syncable secret's
+	// namespace, to include in the SecretInput.
+	NamespaceAnnotations map[string]string
+	// OperatorMetadata to include in the SecretInput.
+	OperatorMetadata map[string]string
 	// KeyedTemplates contains the derived set of all templates that will be used
 	// during the secret data transformation.
 	KeyedTemplates []*KeyedTemplate
 	// ExcludeRaw data from the resulting K8s Secret data.
 	ExcludeRaw bool
+	// YAMLSplits contains the multi-document YAML fields that will be split into
+	// a K8s Secret data key per document.
+	YAMLSplits []secretsv1beta1.YAMLSplit
+	// DockerConfigJSON configures the rendering of registry credentials into the
+	// .dockerconfigjson K8s Secret data key.
+	DockerConfigJSON *secretsv1beta1.DockerConfigJSON
+	// BasicAuth configures the mapping of the credentials to the keys of a
+	// kubernetes.io/basic-auth K8s Secret.
+	BasicAuth *secretsv1beta1.BasicAuth
+	// KeyMap renames the secret data fields to the given K8s Secret data keys.
+	KeyMap map[string]string
+	// Flatten expands the nested objects of the secret data into top-level
+	// keys.
+	Flatten *secretsv1beta1.Flatten
+	// Base64Decode contains the base64 encoded secret data fields that will be
+	// decoded.
+	Base64Decode []string
+	// DotEnv configures the rendering of the K8s Secret data into a dotenv
+	// file.
+	DotEnv *secretsv1beta1.DotEnv
+	// Format configures the rendering of the secret data into a single file.
+	Format *secretsv1beta1.DestinationFormat
 }
 
 // KeyedTemplate maps a secret data key to its secretsv1beta1.Template
@@ -120,6 +152,10 @@ type GlobalTransformationOptions struct {
 	// of _raw from the destination secret.
 	// This is usually set from main via the command line arg --global-transformation-options
 	ExcludeRaw bool
+	// OperatorMetadata describes the Operator instance, e.g. its cluster name and
+	// region, it is available as .Operator in the templates.
+	// This is usually set from main via the command line arg --operator-metadata
+	OperatorMetadata map[string]string
 }
 
 func NewSecretTransformationOption(ctx context.Context, client ctrlclient.Client, obj ctrlclient.Object, globalOpt *GlobalTransformationOptions) (*SecretTransformationOption, error) {
@@ -134,15 +170,35 @@ func NewSecretTransformationOption(ctx context.Context, client ctrlclient.Client
 	}
 
 	opt := &SecretTransformationOption{
-		Excludes:       ff.excludes(),
-		Includes:       ff.includes(),
-		KeyedTemplates: keyedTemplates,
-		Annotations:    obj.GetAnnotations(),
-		Labels:         obj.GetLabels(),
+		Excludes:         ff.excludes(),
+		Includes:         ff.includes(),
+		KeyedTemplates:   keyedTemplates,
+		Annotations:      obj.GetAnnotations(),
+		Labels:           obj.GetLabels(),
+		YAMLSplits:       meta.Destination.Transformation.YAMLSplits,
+		DockerConfigJSON: meta.Destination.Transformation.DockerConfigJSON,
+		BasicAuth:        meta.Destination.Transformation.BasicAuth,
+		KeyMap:           meta.Destination.KeyMap,
+		Flatten:          meta.Destination.Transformation.Flatten,
+		Base64Decode:     meta.Destination.Transformation.Base64Decode,
+		DotEnv:           meta.Destination.Transformation.DotEnv,
+		Format:           meta.Destination.Format,
 	}
 
 	if globalOpt != nil {
 		opt.ExcludeRaw = globalOpt.ExcludeRaw
+		opt.OperatorMetadata = globalOpt.OperatorMetadata
+	}
+
+	if len(keyedTemplates) > 0 {
+		var ns corev1.Namespace
+		if err := client.Get(ctx, ctrlclient.ObjectKey{Name: obj.GetNamespace()}, &ns); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, fmt.Errorf("failed to get the namespace %q: %w", obj.GetNamespace(), err)
+			}
+		}
+		opt.NamespaceLabels = ns.GetLabels()
+		opt.NamespaceAnnotations = ns.GetAnnotations()
 	}
 
 	if meta.Destination.Transformation.ExcludeRaw {
@@ -441,6 +497,47 @@ type SecretInput struct {
 	Annotations map[string]any `json:"annotations"`
 	// Labels associated with syncable secret K8s resource
 	Labels map[string]any `json:"labels"`
+	// Namespace of the syncable secret K8s resource
+	Namespace NamespaceInput `json:"namespace"`
+	// Operator contains the metadata of the Operator instance, e.g. its cluster
+	// name and region.
+	Operator map[string]any `json:"operator"`
+}
+
+// NamespaceInput holds the labels and annotations of a namespace, for secret
+// template rendering. They allow a single template to render environment
+// specific values, e.g. {{ .Namespace.Labels.env }}.
+type NamespaceInput struct {
+	// Annotations associated with the namespace
+	Annotations map[string]any `json:"annotations"`
+	// Labels associated with the namespace
+	Labels map[string]any `json:"labels"`
+}
+
+// newSecretInputForOption returns the SecretInput of the secret data and
+// secret metadata, along with the metadata provided by opt.
+func newSecretInputForOption(secrets, metadata map[string]any, opt *SecretTransformationOption) *SecretInput {
+	input := NewSecretInput(secrets, metadata, opt.Annotations, opt.Labels)
+	input.Namespace = NamespaceInput{
+		Annotations: anyMap(opt.NamespaceAnnotations),
+		Labels:      anyMap(opt.NamespaceLabels),
+	}
+	input.Operator = anyMap(opt.OperatorMetadata)
+	return input
+}
+
+// anyMap returns a copy of m that is valid Go template input, or nil if m is
+// nil.
+func anyMap[V any](m map[string]V) map[string]any {
+	if m == nil {
+		return nil
+	}
+
+	ret := make(map[string]any, len(m))
+	for k, v := range m {
+		ret[k] = v
+	}
+	return ret
 }
 
 // NewSecretInput sets up a SecretInput instance from the provided secret data
@@ -449,29 +546,11 @@ type SecretInput struct {
 func NewSecretInput[A, L any](secrets, metadata map[string]any,
 	annotations map[string]A, labels map[string]L,
 ) *SecretInput {
-	var a map[string]any
-	if annotations != nil {
-		a = make(map[string]any)
-		// copy annotations to `a` to ensure it is valid Go template input.
-		for k, v := range annotations {
-			a[k] = v
-		}
-	}
-
-	var l map[string]any
-	if labels != nil {
-		l = make(map[string]any)
-		// copy annotations to `a` to ensure it is valid Go template input.
-		for k, v := range labels {
-			l[k] = v
-		}
-	}
-
 	return &SecretInput{
 		Secrets:     secrets,
 		Metadata:    metadata,
-		Annotations: a,
-		Labels:      l,
+		Annotations: anyMap(annotations),
+		Labels:      anyMap(labels),
 	}
 }
 
@@ -532,6 +611,17 @@ func newFieldFilters() *fieldFilters {
 	}
 }
 
+// GetDestinationsTransformationRefObjKeys returns the keys of the
+// SecretTransformations referenced by all of dests.
+func GetDestinationsTransformationRefObjKeys(dests []secretsv1beta1.Destination, defaultNS string) []ctrlclient.ObjectKey {
+	var result []ctrlclient.ObjectKey
+	for _, d := range dests {
+		result = append(result, GetTransformationRefObjKeys(d.Transformation, defaultNS)...)
+	}
+
+	return result
+}
+
 func GetTransformationRefObjKeys(t secretsv1beta1.Transformation, defaultNS string) []ctrlclient.ObjectKey {
 	var result []ctrlclient.ObjectKey
 	for _, ref := range t.TransformationRefs {
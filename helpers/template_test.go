@@ -1351,3 +1351,63 @@ func Test_validateTemplate(t *testing.T) {
 		})
 	}
 }
+
+func TestNewSecretTransformationOption_environment(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "tenant",
+			Labels: map[string]string{
+				"env": "prod",
+			},
+			Annotations: map[string]string{
+				"example.com/db-host": "db.prod.internal",
+			},
+		},
+	}
+	obj := &secretsv1beta1.VaultStaticSecret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "app",
+			Namespace: "tenant",
+		},
+		Spec: secretsv1beta1.VaultStaticSecretSpec{
+			Destination: secretsv1beta1.Destination{
+				Transformation: secretsv1beta1.Transformation{
+					Templates: map[string]secretsv1beta1.Template{
+						"url": {
+							Text: `{{ index .Namespace.Annotations "example.com/db-host" }}/{{ .Operator.region }}/{{ .Namespace.Labels.env }}`,
+						},
+					},
+					ExcludeRaw: true,
+				},
+			},
+		},
+	}
+	globalOpt := &GlobalTransformationOptions{
+		OperatorMetadata: map[string]string{
+			"region": "eu-west-1",
+		},
+	}
+
+	c := testutils.NewFakeClientBuilder().WithObjects(ns).Build()
+	opt, err := NewSecretTransformationOption(ctx, c, obj, globalOpt)
+	require.NoError(t, err)
+	assert.Equal(t, ns.Labels, opt.NamespaceLabels)
+	assert.Equal(t, ns.Annotations, opt.NamespaceAnnotations)
+	assert.Equal(t, globalOpt.OperatorMetadata, opt.OperatorMetadata)
+
+	data, err := NewSecretsDataBuilder().WithVaultData(map[string]any{}, map[string]any{}, opt)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"url": []byte("db.prod.internal/eu-west-1/prod"),
+	}, data)
+
+	// the namespace is only read when there are templates to render.
+	obj.Spec.Destination.Transformation.Templates = nil
+	opt, err = NewSecretTransformationOption(ctx, c, obj, globalOpt)
+	require.NoError(t, err)
+	assert.Nil(t, opt.NamespaceLabels)
+	assert.Nil(t, opt.NamespaceAnnotations)
+}
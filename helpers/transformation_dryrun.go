@@ -0,0 +1,117 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package helpers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+)
+
+// RunSecretTransformationTests renders the SecretTransformation o with the
+// input of each of its Tests, and compares the rendered K8s Secret data to the
+// test's expected data. The errors of all the failed tests are returned.
+func RunSecretTransformationTests(o *secretsv1beta1.SecretTransformation) error {
+	if len(o.Spec.Tests) == 0 {
+		return nil
+	}
+
+	objKey := ctrlclient.ObjectKeyFromObject(o)
+	var keyedTemplates []*KeyedTemplate
+	for key, tmpl := range o.Spec.Templates {
+		if tmpl.Name == "" {
+			tmpl.Name = fmt.Sprintf("%s/%s", objKey, key)
+		}
+		keyedTemplates = append(keyedTemplates, &KeyedTemplate{
+			Key:      key,
+			Template: tmpl,
+		})
+	}
+	for idx, tmpl := range o.Spec.SourceTemplates {
+		name := tmpl.Name
+		if name == "" {
+			name = fmt.Sprintf("%s/%d", objKey, idx)
+		}
+		keyedTemplates = append(keyedTemplates, &KeyedTemplate{
+			Template: secretsv1beta1.Template{
+				Name: name,
+				Text: tmpl.Text,
+			},
+		})
+	}
+	slices.SortFunc(keyedTemplates, func(a, b *KeyedTemplate) int {
+		return a.Cmp(b)
+	})
+
+	var errs error
+	for _, test := range o.Spec.Tests {
+		if err := runSecretTransformationTest(o, keyedTemplates, test); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("test %q failed: %w", test.Name, err))
+		}
+	}
+	return errs
+}
+
+func runSecretTransformationTest(o *secretsv1beta1.SecretTransformation,
+	keyedTemplates []*KeyedTemplate, test secretsv1beta1.SecretTransformationTest,
+) error {
+	secrets, err := decodeJSONMap(test.Secrets)
+	if err != nil {
+		return fmt.Errorf("invalid secrets: %w", err)
+	}
+	metadata, err := decodeJSONMap(test.Metadata)
+	if err != nil {
+		return fmt.Errorf("invalid metadata: %w", err)
+	}
+
+	opt := &SecretTransformationOption{
+		Excludes:       o.Spec.Excludes,
+		Includes:       o.Spec.Includes,
+		KeyedTemplates: keyedTemplates,
+		Annotations:          test.Annotations,
+		Labels:               test.Labels,
+		NamespaceLabels:      test.NamespaceLabels,
+		NamespaceAnnotations: test.NamespaceAnnotations,
+		OperatorMetadata:     test.Operator,
+		ExcludeRaw:           true,
+	}
+	data, err := NewSecretsDataBuilder().WithVaultData(secrets, map[string]any{
+		"metadata": metadata,
+	}, opt)
+	if err != nil {
+		return err
+	}
+
+	var errs error
+	for _, k := range slices.Sorted(maps.Keys(test.Expected)) {
+		v, ok := data[k]
+		if !ok {
+			errs = errors.Join(errs, fmt.Errorf("key %q was not rendered", k))
+			continue
+		}
+		if string(v) != test.Expected[k] {
+			errs = errors.Join(errs, fmt.Errorf("key %q: expected %q, got %q", k, test.Expected[k], v))
+		}
+	}
+	return errs
+}
+
+func decodeJSONMap(m map[string]apiextensionsv1.JSON) (map[string]any, error) {
+	ret := make(map[string]any, len(m))
+	for k, v := range m {
+		var value any
+		if err := json.Unmarshal(v.Raw, &value); err != nil {
+			return nil, fmt.Errorf("invalid value of %q: %w", k, err)
+		}
+		ret[k] = value
+	}
+	return ret, nil
+}
@@ -0,0 +1,132 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package helpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+)
+
+func TestRunSecretTransformationTests(t *testing.T) {
+	t.Parallel()
+
+	spec := secretsv1beta1.SecretTransformationSpec{
+		Templates: map[string]secretsv1beta1.Template{
+			"url": {
+				Text: `{{ template "creds" . }}@{{ get .Secrets "host" }}`,
+			},
+			"version": {
+				Text: `{{ get .Metadata "version" }}`,
+			},
+			"team": {
+				Text: `{{ index .Labels "team" }}`,
+			},
+			"region": {
+				Text: `{{ index .Namespace.Labels "env" }}-{{ index .Operator "region" }}`,
+			},
+		},
+		SourceTemplates: []secretsv1beta1.SourceTemplate{
+			{
+				Name: "creds",
+				Text: `{{- define "creds" }}{{ get .Secrets "user" }}:{{ get .Secrets "pass" }}{{ end -}}`,
+			},
+		},
+	}
+	secrets := map[string]apiextensionsv1.JSON{
+		"user": {Raw: []byte(`"alice"`)},
+		"pass": {Raw: []byte(`"s3cr3t"`)},
+		"host": {Raw: []byte(`"db"`)},
+	}
+
+	tests := []struct {
+		name    string
+		tests   []secretsv1beta1.SecretTransformationTest
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "no-tests",
+			wantErr: assert.NoError,
+		},
+		{
+			name: "pass",
+			tests: []secretsv1beta1.SecretTransformationTest{
+				{
+					Name:    "url",
+					Secrets: secrets,
+					Metadata: map[string]apiextensionsv1.JSON{
+						"version": {Raw: []byte(`2`)},
+					},
+					Labels: map[string]string{
+						"team": "payments",
+					},
+					NamespaceLabels: map[string]string{
+						"env": "prod",
+					},
+					Operator: map[string]string{
+						"region": "eu-west-1",
+					},
+					Expected: map[string]string{
+						"url":     "alice:s3cr3t@db",
+						"version": "2",
+						"team":    "payments",
+						"region":  "prod-eu-west-1",
+					},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "fail",
+			tests: []secretsv1beta1.SecretTransformationTest{
+				{
+					Name:    "mismatch",
+					Secrets: secrets,
+					Expected: map[string]string{
+						"url":     "bob:s3cr3t@db",
+						"missing": "alice",
+					},
+				},
+				{
+					Name:    "pass",
+					Secrets: secrets,
+					Expected: map[string]string{
+						"url": "alice:s3cr3t@db",
+					},
+				},
+				{
+					Name: "invalid-secrets",
+					Secrets: map[string]apiextensionsv1.JSON{
+						"user": {Raw: []byte(`{`)},
+					},
+				},
+			},
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.EqualError(t, err,
+					`test "mismatch" failed: key "missing" was not rendered`+"\n"+
+						`key "url": expected "bob:s3cr3t@db", got "alice:s3cr3t@db"`+"\n"+
+						`test "invalid-secrets" failed: invalid secrets: invalid value of "user": unexpected end of JSON input`, i...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			o := &secretsv1beta1.SecretTransformation{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "xform",
+					Namespace: "default",
+				},
+				Spec: spec,
+			}
+			o.Spec.Tests = tt.tests
+			tt.wantErr(t, RunSecretTransformationTests(o))
+		})
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package helpers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+)
+
+// addYAMLSplits adds the documents of all the SecretTransformationOption's
+// YAMLSplits to data, which typically holds the rendered templates.
+func addYAMLSplits(opt *SecretTransformationOption, d map[string]any, data map[string][]byte) error {
+	if len(opt.YAMLSplits) == 0 {
+		return nil
+	}
+
+	docs, err := splitYAMLFields(opt.YAMLSplits, d)
+	if err != nil {
+		return err
+	}
+
+	for k, v := range docs {
+		if _, ok := data[k]; ok {
+			return fmt.Errorf("key %q from yaml split conflicts with a template", k)
+		}
+		data[k] = v
+	}
+
+	return nil
+}
+
+// splitYAMLFields splits all the multi-document YAML fields in d, per
+// YAMLSplit, returning the resulting K8s Secret data.
+func splitYAMLFields(splits []secretsv1beta1.YAMLSplit, d map[string]any) (map[string][]byte, error) {
+	data := make(map[string][]byte)
+	for _, split := range splits {
+		v, ok := d[split.Field]
+		if !ok {
+			return nil, fmt.Errorf("yaml split field %q not found in secret data", split.Field)
+		}
+
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("yaml split field %q is not a string", split.Field)
+		}
+
+		docs, err := splitYAML(split, []byte(s))
+		if err != nil {
+			return nil, fmt.Errorf("failed to split yaml field %q: %w", split.Field, err)
+		}
+
+		for k, b := range docs {
+			if _, ok := data[k]; ok {
+				return nil, fmt.Errorf("duplicate key %q from yaml split field %q", k, split.Field)
+			}
+			data[k] = b
+		}
+	}
+
+	return data, nil
+}
+
+// splitYAML splits the multi-document YAML b into a K8s Secret data key per
+// document. The key is the scalar value that is selected by the YAMLSplit's
+// KeyPath.
+func splitYAML(split secretsv1beta1.YAMLSplit, b []byte) (map[string][]byte, error) {
+	path, err := parseYAMLPath(split.KeyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make(map[string][]byte)
+	dec := yaml.NewDecoder(bytes.NewReader(b))
+	for idx := 0; ; idx++ {
+		var doc yaml.Node
+		if err := dec.Decode(&doc); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("document %d: %w", idx, err)
+		}
+
+		if len(doc.Content) == 0 || doc.Content[0].Tag == "!!null" {
+			continue
+		}
+
+		node := lookupYAMLPath(doc.Content[0], path)
+		if node == nil || node.Kind != yaml.ScalarNode || node.Value == "" {
+			return nil, fmt.Errorf("document %d: %q does not select a scalar value", idx, split.KeyPath)
+		}
+
+		key := split.KeyPrefix + node.Value + split.KeySuffix
+		if _, ok := ret[key]; ok {
+			return nil, fmt.Errorf("document %d: duplicate key %q", idx, key)
+		}
+
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err := enc.Encode(&doc); err != nil {
+			return nil, fmt.Errorf("document %d: %w", idx, err)
+		}
+		if err := enc.Close(); err != nil {
+			return nil, fmt.Errorf("document %d: %w", idx, err)
+		}
+
+		ret[key] = buf.Bytes()
+	}
+
+	return ret, nil
+}
+
+// yamlPathElement is either a mapping key or a sequence index.
+type yamlPathElement struct {
+	key   string
+	index int
+}
+
+// parseYAMLPath parses the subset of YAMLPath that consists of child
+// (.name, ['name']) and index ([0]) selectors, with an optional leading root
+// selector ($).
+func parseYAMLPath(s string) ([]yamlPathElement, error) {
+	p := strings.TrimPrefix(s, "$")
+	var ret []yamlPathElement
+	for len(p) > 0 {
+		switch {
+		case p[0] == '.':
+			end := strings.IndexAny(p[1:], ".[")
+			if end < 0 {
+				end = len(p) - 1
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("invalid yaml path %q: empty key", s)
+			}
+			ret = append(ret, yamlPathElement{key: p[1 : end+1], index: -1})
+			p = p[end+1:]
+		case strings.HasPrefix(p, "['"):
+			end := strings.Index(p, "']")
+			if end < 0 {
+				return nil, fmt.Errorf("invalid yaml path %q: unterminated key", s)
+			}
+			ret = append(ret, yamlPathElement{key: p[2:end], index: -1})
+			p = p[end+2:]
+		case p[0] == '[':
+			end := strings.IndexByte(p, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid yaml path %q: unterminated index", s)
+			}
+			idx, err := strconv.Atoi(p[1:end])
+			if err != nil || idx < 0 {
+				return nil, fmt.Errorf("invalid yaml path %q: invalid index %q", s, p[1:end])
+			}
+			ret = append(ret, yamlPathElement{index: idx})
+			p = p[end+1:]
+		default:
+			return nil, fmt.Errorf("invalid yaml path %q", s)
+		}
+	}
+
+	if len(ret) == 0 {
+		return nil, fmt.Errorf("invalid yaml path %q: no selector", s)
+	}
+
+	return ret, nil
+}
+
+// lookupYAMLPath returns the node that is selected by path, or nil if there is
+// none.
+func lookupYAMLPath(node *yaml.Node, path []yamlPathElement) *yaml.Node {
+	for _, e := range path {
+		if node.Kind == yaml.AliasNode {
+			node = node.Alias
+		}
+
+		var next *yaml.Node
+		switch {
+		case e.index >= 0:
+			if node.Kind == yaml.SequenceNode && e.index < len(node.Content) {
+				next = node.Content[e.index]
+			}
+		case node.Kind == yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == e.key {
+					next = node.Content[i+1]
+					break
+				}
+			}
+		}
+		if next == nil {
+			return nil
+		}
+		node = next
+	}
+
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+
+	return node
+}
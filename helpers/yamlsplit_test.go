@@ -0,0 +1,162 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package helpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+)
+
+func Test_splitYAMLFields(t *testing.T) {
+	t.Parallel()
+
+	kubeconfigs := `# dev cluster
+apiVersion: v1
+kind: Config
+contexts:
+- name: dev
+  context:
+    cluster: dev
+---
+apiVersion: v1
+kind: Config
+contexts:
+- name: prod
+  context:
+    cluster: prod
+---
+`
+
+	tests := []struct {
+		name    string
+		splits  []secretsv1beta1.YAMLSplit
+		data    map[string]any
+		want    map[string][]byte
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "kubeconfigs",
+			splits: []secretsv1beta1.YAMLSplit{
+				{
+					Field:     "kubeconfigs",
+					KeyPath:   "$.contexts[0].name",
+					KeyPrefix: "kubeconfig-",
+				},
+			},
+			data: map[string]any{
+				"kubeconfigs": kubeconfigs,
+			},
+			want: map[string][]byte{
+				"kubeconfig-dev": []byte(`# dev cluster
+apiVersion: v1
+kind: Config
+contexts:
+  - name: dev
+    context:
+      cluster: dev
+`),
+				"kubeconfig-prod": []byte(`apiVersion: v1
+kind: Config
+contexts:
+  - name: prod
+    context:
+      cluster: prod
+`),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "quoted-key",
+			splits: []secretsv1beta1.YAMLSplit{
+				{
+					Field:   "manifests",
+					KeyPath: "$.metadata['app.kubernetes.io/name']",
+				},
+			},
+			data: map[string]any{
+				"manifests": "metadata:\n  app.kubernetes.io/name: foo\n",
+			},
+			want: map[string][]byte{
+				"foo": []byte("metadata:\n  app.kubernetes.io/name: foo\n"),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "missing-field",
+			splits: []secretsv1beta1.YAMLSplit{
+				{
+					Field:   "manifests",
+					KeyPath: ".metadata.name",
+				},
+			},
+			data: map[string]any{},
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.EqualError(t, err,
+					`yaml split field "manifests" not found in secret data`, i...)
+			},
+		},
+		{
+			name: "non-scalar-key",
+			splits: []secretsv1beta1.YAMLSplit{
+				{
+					Field:   "kubeconfigs",
+					KeyPath: ".contexts",
+				},
+			},
+			data: map[string]any{
+				"kubeconfigs": kubeconfigs,
+			},
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.EqualError(t, err,
+					`failed to split yaml field "kubeconfigs": document 0: ".contexts" does not select a scalar value`, i...)
+			},
+		},
+		{
+			name: "duplicate-key",
+			splits: []secretsv1beta1.YAMLSplit{
+				{
+					Field:   "kubeconfigs",
+					KeyPath: ".kind",
+				},
+			},
+			data: map[string]any{
+				"kubeconfigs": kubeconfigs,
+			},
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.EqualError(t, err,
+					`failed to split yaml field "kubeconfigs": document 1: duplicate key "Config"`, i...)
+			},
+		},
+		{
+			name: "invalid-yaml-path",
+			splits: []secretsv1beta1.YAMLSplit{
+				{
+					Field:   "kubeconfigs",
+					KeyPath: "$.contexts[first]",
+				},
+			},
+			data: map[string]any{
+				"kubeconfigs": kubeconfigs,
+			},
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.EqualError(t, err,
+					`failed to split yaml field "kubeconfigs": invalid yaml path "$.contexts[first]": invalid index "first"`, i...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := splitYAMLFields(tt.splits, tt.data)
+			if !tt.wantErr(t, err) {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
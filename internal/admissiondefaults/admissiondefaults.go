@@ -0,0 +1,156 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package admissiondefaults fills the platform-wide defaults of the syncable
+// secrets' specs upon admission, with a mutating webhook. The defaults are
+// declared in the Operator's configuration, so that they do not need to be
+// repeated in every manifest. Only the fields that are not set are defaulted.
+package admissiondefaults
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"sigs.k8s.io/yaml"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/consts"
+)
+
+const (
+	// EngineKVV1 is the engine type of the VaultStaticSecrets of type kv-v1.
+	EngineKVV1 = "kv-v1"
+	// EngineKVV2 is the engine type of the VaultStaticSecrets of type kv-v2.
+	EngineKVV2 = "kv-v2"
+	// EngineDynamic is the engine type of the VaultDynamicSecrets.
+	EngineDynamic = "dynamic"
+	// EngineHVS is the engine type of the HCPVaultSecretsApps.
+	EngineHVS = "hvs"
+)
+
+var _ admission.CustomDefaulter = (*Defaulter)(nil)
+
+// refreshAfterPattern is the validation pattern of the refreshAfter fields.
+var refreshAfterPattern = regexp.MustCompile(`^([0-9]+(\.[0-9]+)?(s|m|h))$`)
+
+// Config declares the defaults.
+type Config struct {
+	// RefreshAfter is the default refreshAfter of the syncable secrets, keyed
+	// by their engine type, e.g. kv-v2.
+	RefreshAfter map[string]string `json:"refreshAfter,omitempty"`
+	// DestinationTypes is the default type of the destination Secret, keyed by
+	// the kind of the syncable secret, e.g. VaultPKISecret.
+	DestinationTypes map[string]corev1.SecretType `json:"destinationTypes,omitempty"`
+	// ExcludeRaw sets excludeRaw in the transformation of every destination.
+	ExcludeRaw bool `json:"excludeRaw,omitempty"`
+}
+
+// Validate returns an error if any of the defaults is invalid.
+func (c *Config) Validate() error {
+	for engine, v := range c.RefreshAfter {
+		switch engine {
+		case EngineKVV1, EngineKVV2, EngineDynamic, EngineHVS:
+		default:
+			return fmt.Errorf("unsupported engine type %q", engine)
+		}
+		if !refreshAfterPattern.MatchString(v) {
+			return fmt.Errorf("invalid refreshAfter %q of engine type %q", v, engine)
+		}
+	}
+	for kind := range c.DestinationTypes {
+		if _, ok := kindObjects[kind]; !ok {
+			return fmt.Errorf("unsupported kind %q", kind)
+		}
+	}
+	return nil
+}
+
+// LoadConfig reads the Config from the YAML file at path.
+func LoadConfig(path string) (*Config, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var c Config
+	if err := yaml.UnmarshalStrict(b, &c); err != nil {
+		return nil, fmt.Errorf("invalid admission defaults %q: %w", path, err)
+	}
+	if err := c.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid admission defaults %q: %w", path, err)
+	}
+	return &c, nil
+}
+
+// kindObjects are the kinds that are defaulted.
+var kindObjects = map[string]client.Object{
+	"VaultStaticSecret":  &secretsv1beta1.VaultStaticSecret{},
+	"VaultDynamicSecret": &secretsv1beta1.VaultDynamicSecret{},
+	"VaultPKISecret":     &secretsv1beta1.VaultPKISecret{},
+	"HCPVaultSecretsApp": &secretsv1beta1.HCPVaultSecretsApp{},
+}
+
+// Defaulter defaults the syncable secrets from its Config.
+type Defaulter struct {
+	Config *Config
+}
+
+// SetupWebhookWithManager registers the mutating webhook of every defaulted
+// kind with mgr.
+func (d *Defaulter) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	for kind, obj := range kindObjects {
+		if err := ctrl.NewWebhookManagedBy(mgr).For(obj).WithDefaulter(d).Complete(); err != nil {
+			return fmt.Errorf("failed to register the %s webhook: %w", kind, err)
+		}
+	}
+	return nil
+}
+
+// Default implements admission.CustomDefaulter.
+func (d *Defaulter) Default(ctx context.Context, obj runtime.Object) error {
+	var kind, engine string
+	var refreshAfter *string
+	var dest *secretsv1beta1.Destination
+	switch o := obj.(type) {
+	case *secretsv1beta1.VaultStaticSecret:
+		kind, engine = "VaultStaticSecret", o.Spec.Type
+		refreshAfter, dest = &o.Spec.RefreshAfter, &o.Spec.Destination
+	case *secretsv1beta1.VaultDynamicSecret:
+		kind, engine = "VaultDynamicSecret", EngineDynamic
+		refreshAfter, dest = &o.Spec.RefreshAfter, &o.Spec.Destination
+	case *secretsv1beta1.VaultPKISecret:
+		kind, dest = "VaultPKISecret", &o.Spec.Destination
+	case *secretsv1beta1.HCPVaultSecretsApp:
+		kind, engine = "HCPVaultSecretsApp", EngineHVS
+		refreshAfter, dest = &o.Spec.RefreshAfter, &o.Spec.Destination
+	default:
+		return fmt.Errorf("unsupported type %T", obj)
+	}
+
+	var defaulted []string
+	if v, ok := d.Config.RefreshAfter[engine]; ok && refreshAfter != nil && *refreshAfter == "" {
+		*refreshAfter = v
+		defaulted = append(defaulted, "refreshAfter")
+	}
+	if v, ok := d.Config.DestinationTypes[kind]; ok && dest.Type == "" {
+		dest.Type = v
+		defaulted = append(defaulted, "destination.type")
+	}
+	if d.Config.ExcludeRaw && !dest.Transformation.ExcludeRaw {
+		dest.Transformation.ExcludeRaw = true
+		defaulted = append(defaulted, "destination.transformation.excludeRaw")
+	}
+
+	if len(defaulted) > 0 {
+		log.FromContext(ctx).V(consts.LogLevelDebug).Info("Defaulted the spec", "kind", kind, "fields", defaulted)
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package admissiondefaults
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+		want    *Config
+		wantErr string
+	}{
+		{
+			name: "valid",
+			content: `
+refreshAfter:
+  kv-v2: 1h
+  dynamic: 30m
+destinationTypes:
+  VaultPKISecret: kubernetes.io/tls
+excludeRaw: true
+`,
+			want: &Config{
+				RefreshAfter: map[string]string{
+					EngineKVV2:    "1h",
+					EngineDynamic: "30m",
+				},
+				DestinationTypes: map[string]corev1.SecretType{
+					"VaultPKISecret": corev1.SecretTypeTLS,
+				},
+				ExcludeRaw: true,
+			},
+		},
+		{
+			name:    "unknown-field",
+			content: `refresh: {}`,
+			wantErr: `unknown field "refresh"`,
+		},
+		{
+			name:    "unsupported-engine",
+			content: `refreshAfter: {pki: 1h}`,
+			wantErr: `unsupported engine type "pki"`,
+		},
+		{
+			name:    "invalid-refresh-after",
+			content: `refreshAfter: {kv-v1: 1h30m}`,
+			wantErr: `invalid refreshAfter "1h30m" of engine type "kv-v1"`,
+		},
+		{
+			name:    "unsupported-kind",
+			content: `destinationTypes: {VaultAuth: Opaque}`,
+			wantErr: `unsupported kind "VaultAuth"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "defaults.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+			got, err := LoadConfig(path)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDefaulter_Default(t *testing.T) {
+	t.Parallel()
+
+	d := &Defaulter{
+		Config: &Config{
+			RefreshAfter: map[string]string{
+				EngineKVV2:    "1h",
+				EngineDynamic: "30m",
+			},
+			DestinationTypes: map[string]corev1.SecretType{
+				"VaultPKISecret": corev1.SecretTypeTLS,
+			},
+			ExcludeRaw: true,
+		},
+	}
+
+	tests := []struct {
+		name string
+		obj  runtime.Object
+		want runtime.Object
+	}{
+		{
+			name: "vss",
+			obj: &secretsv1beta1.VaultStaticSecret{
+				Spec: secretsv1beta1.VaultStaticSecretSpec{
+					Type: EngineKVV2,
+				},
+			},
+			want: &secretsv1beta1.VaultStaticSecret{
+				Spec: secretsv1beta1.VaultStaticSecretSpec{
+					Type:         EngineKVV2,
+					RefreshAfter: "1h",
+					Destination: secretsv1beta1.Destination{
+						Transformation: secretsv1beta1.Transformation{
+							ExcludeRaw: true,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "vss-kv-v1-set",
+			obj: &secretsv1beta1.VaultStaticSecret{
+				Spec: secretsv1beta1.VaultStaticSecretSpec{
+					Type:         EngineKVV1,
+					RefreshAfter: "5m",
+				},
+			},
+			want: &secretsv1beta1.VaultStaticSecret{
+				Spec: secretsv1beta1.VaultStaticSecretSpec{
+					Type:         EngineKVV1,
+					RefreshAfter: "5m",
+					Destination: secretsv1beta1.Destination{
+						Transformation: secretsv1beta1.Transformation{
+							ExcludeRaw: true,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "vds",
+			obj:  &secretsv1beta1.VaultDynamicSecret{},
+			want: &secretsv1beta1.VaultDynamicSecret{
+				Spec: secretsv1beta1.VaultDynamicSecretSpec{
+					RefreshAfter: "30m",
+					Destination: secretsv1beta1.Destination{
+						Transformation: secretsv1beta1.Transformation{
+							ExcludeRaw: true,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "pki",
+			obj:  &secretsv1beta1.VaultPKISecret{},
+			want: &secretsv1beta1.VaultPKISecret{
+				Spec: secretsv1beta1.VaultPKISecretSpec{
+					Destination: secretsv1beta1.Destination{
+						Type: corev1.SecretTypeTLS,
+						Transformation: secretsv1beta1.Transformation{
+							ExcludeRaw: true,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "pki-type-set",
+			obj: &secretsv1beta1.VaultPKISecret{
+				Spec: secretsv1beta1.VaultPKISecretSpec{
+					Destination: secretsv1beta1.Destination{
+						Type: corev1.SecretTypeOpaque,
+					},
+				},
+			},
+			want: &secretsv1beta1.VaultPKISecret{
+				Spec: secretsv1beta1.VaultPKISecretSpec{
+					Destination: secretsv1beta1.Destination{
+						Type: corev1.SecretTypeOpaque,
+						Transformation: secretsv1beta1.Transformation{
+							ExcludeRaw: true,
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.NoError(t, d.Default(context.Background(), tt.obj))
+			assert.Equal(t, tt.want, tt.obj)
+		})
+	}
+
+	assert.EqualError(t, d.Default(context.Background(), &secretsv1beta1.VaultAuth{}),
+		"unsupported type *v1beta1.VaultAuth")
+}
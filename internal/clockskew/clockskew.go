@@ -0,0 +1,182 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package clockskew detects clock skew between the Operator and the servers
+// that it talks to, i.e. Vault and the Kubernetes API server. The skew is
+// estimated from the Date header of every HTTP response. Since the header only
+// has a resolution of one second, and since the request's round-trip time is
+// not always known, only a skew that exceeds the Tracker's threshold is
+// considered significant.
+package clockskew
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	vsometrics "github.com/hashicorp/vault-secrets-operator/internal/metrics"
+)
+
+const (
+	// DefaultThreshold is the skew above which the clocks are considered to be
+	// out of sync.
+	DefaultThreshold = 10 * time.Second
+	// SourceKubernetes is the source name of the Kubernetes API server.
+	SourceKubernetes = "kubernetes"
+	// SourceVaultPrefix prefixes the source name of a Vault server, it is
+	// followed by the server's address.
+	SourceVaultPrefix = "vault/"
+)
+
+// Skew is the latest estimated clock skew per source. A positive value means
+// that the source's clock is ahead of the Operator's.
+var Skew = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: vsometrics.Namespace,
+	Name:      "clock_skew_seconds",
+	Help:      "Estimated clock skew between the Operator and the source's clock",
+}, []string{
+	"source",
+})
+
+func init() {
+	metrics.Registry.MustRegister(Skew)
+}
+
+// DefaultTracker is the Tracker used by the Operator.
+var DefaultTracker = NewTracker(DefaultThreshold)
+
+// Tracker keeps the latest estimated clock skew of each source.
+type Tracker struct {
+	threshold time.Duration
+	skews     map[string]time.Duration
+	mu        sync.RWMutex
+	now       func() time.Time
+}
+
+// NewTracker returns a Tracker that considers any skew exceeding threshold to
+// be significant.
+func NewTracker(threshold time.Duration) *Tracker {
+	return &Tracker{
+		threshold: threshold,
+		skews:     make(map[string]time.Duration),
+		now:       time.Now,
+	}
+}
+
+// SetThreshold sets the skew above which the clocks are considered to be out
+// of sync.
+func (t *Tracker) SetThreshold(threshold time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.threshold = threshold
+}
+
+// Observe records the skew between the local clock and the source's clock as
+// reported by remote, for a request that was sent at start and whose response
+// was received at end.
+func (t *Tracker) Observe(source string, start, end, remote time.Time) {
+	// the server generated its response somewhere between start and end.
+	skew := remote.Sub(start.Add(end.Sub(start) / 2)).Truncate(time.Second)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	prev, ok := t.skews[source]
+	t.skews[source] = skew
+	Skew.WithLabelValues(source).Set(skew.Seconds())
+	if t.significant(skew) && (!ok || !t.significant(prev)) {
+		ctrl.Log.WithName("clockskew").Info("Warning: clock skew detected",
+			"source", source, "skew", skew, "threshold", t.threshold)
+	}
+}
+
+// ObserveResponse records the skew from the Date header of resp, for a request
+// that was sent at start. Responses without a valid Date header are ignored.
+func (t *Tracker) ObserveResponse(source string, start time.Time, resp *http.Response) {
+	if resp == nil {
+		return
+	}
+
+	v := resp.Header.Get("Date")
+	if v == "" {
+		return
+	}
+
+	remote, err := http.ParseTime(v)
+	if err != nil {
+		return
+	}
+
+	t.Observe(source, start, t.now(), remote)
+}
+
+// Skews returns all the significant skews by source.
+func (t *Tracker) Skews() map[string]time.Duration {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	ret := make(map[string]time.Duration)
+	for source, skew := range t.skews {
+		if t.significant(skew) {
+			ret[source] = skew
+		}
+	}
+	return ret
+}
+
+// Margin returns the largest significant skew, in absolute value, across all
+// sources. It is zero when all clocks are in sync. It should be subtracted
+// from any time that was computed from a remote clock, like a certificate's
+// expiration, before scheduling a renewal from it.
+func (t *Tracker) Margin() time.Duration {
+	var ret time.Duration
+	for _, skew := range t.Skews() {
+		if skew < 0 {
+			skew = -skew
+		}
+		ret = max(ret, skew)
+	}
+	return ret
+}
+
+func (t *Tracker) significant(skew time.Duration) bool {
+	return skew > t.threshold || skew < -t.threshold
+}
+
+// WrapTransport returns a function that wraps an http.RoundTripper, such that
+// all responses are observed for source. It is suitable for
+// rest.Config.WrapTransport.
+func (t *Tracker) WrapTransport(source string) func(http.RoundTripper) http.RoundTripper {
+	return func(rt http.RoundTripper) http.RoundTripper {
+		if rt == nil {
+			rt = http.DefaultTransport
+		}
+		return &transport{
+			source:  source,
+			tracker: t,
+			next:    rt,
+		}
+	}
+}
+
+var _ http.RoundTripper = (*transport)(nil)
+
+type transport struct {
+	source  string
+	tracker *Tracker
+	next    http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := t.tracker.now()
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	t.tracker.ObserveResponse(t.source, start, resp)
+	return resp, nil
+}
@@ -0,0 +1,103 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package clockskew
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracker_Observe(t *testing.T) {
+	t.Parallel()
+
+	start := time.Unix(1700000000, 0)
+	tests := []struct {
+		name       string
+		observe    map[string]time.Duration
+		wantSkews  map[string]time.Duration
+		wantMargin time.Duration
+	}{
+		{
+			name: "in-sync",
+			observe: map[string]time.Duration{
+				SourceKubernetes:         time.Second,
+				SourceVaultPrefix + "v1": -5 * time.Second,
+			},
+			wantSkews: map[string]time.Duration{},
+		},
+		{
+			name: "ahead",
+			observe: map[string]time.Duration{
+				SourceKubernetes:         time.Second,
+				SourceVaultPrefix + "v1": 30 * time.Second,
+			},
+			wantSkews: map[string]time.Duration{
+				SourceVaultPrefix + "v1": 30 * time.Second,
+			},
+			wantMargin: 30 * time.Second,
+		},
+		{
+			name: "behind",
+			observe: map[string]time.Duration{
+				SourceKubernetes:         -time.Minute,
+				SourceVaultPrefix + "v1": 30 * time.Second,
+			},
+			wantSkews: map[string]time.Duration{
+				SourceKubernetes:         -time.Minute,
+				SourceVaultPrefix + "v1": 30 * time.Second,
+			},
+			wantMargin: time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tr := NewTracker(DefaultThreshold)
+			for source, skew := range tt.observe {
+				// a two second round-trip.
+				tr.Observe(source, start, start.Add(2*time.Second), start.Add(time.Second+skew))
+			}
+			assert.Equal(t, tt.wantSkews, tr.Skews())
+			assert.Equal(t, tt.wantMargin, tr.Margin())
+		})
+	}
+}
+
+func TestTracker_WrapTransport(t *testing.T) {
+	t.Parallel()
+
+	remote := time.Unix(1700000060, 0)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", remote.UTC().Format(http.TimeFormat))
+	}))
+	t.Cleanup(srv.Close)
+
+	tr := NewTracker(DefaultThreshold)
+	tr.now = func() time.Time {
+		return time.Unix(1700000000, 0)
+	}
+	c := &http.Client{
+		Transport: tr.WrapTransport("test")(nil),
+	}
+	resp, err := c.Get(srv.URL)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, map[string]time.Duration{"test": time.Minute}, tr.Skews())
+
+	// the skew was corrected.
+	tr.now = func() time.Time {
+		return remote
+	}
+	resp, err = c.Get(srv.URL)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Empty(t, tr.Skews())
+	assert.Zero(t, tr.Margin())
+}
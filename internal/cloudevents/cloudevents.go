@@ -0,0 +1,193 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package cloudevents exports the lifecycle transitions of the Vault leases, and
+// the rotations of the synced secrets, as CloudEvents, so that event-driven
+// platforms can build workflows on top of the Operator's activity. The events
+// are encoded in the CloudEvents v1.0 structured JSON format, and delivered
+// asynchronously to a Sink.
+package cloudevents
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/common"
+)
+
+const (
+	// SpecVersion is the version of the CloudEvents specification.
+	SpecVersion = "1.0"
+	// DefaultSource is the source of the events, if none is configured.
+	DefaultSource = "vault-secrets-operator"
+	// DefaultQueueSize is the number of events that are buffered until they
+	// are delivered, if none is configured.
+	DefaultQueueSize = 1000
+
+	// TypeLeaseIssued is the type of the event emitted when a new lease is
+	// issued by Vault.
+	TypeLeaseIssued = "com.hashicorp.secrets.lease.issued"
+	// TypeLeaseRenewed is the type of the event emitted when a lease is renewed.
+	TypeLeaseRenewed = "com.hashicorp.secrets.lease.renewed"
+	// TypeLeaseExpired is the type of the event emitted when a lease could not
+	// be renewed since it has expired.
+	TypeLeaseExpired = "com.hashicorp.secrets.lease.expired"
+	// TypeLeaseRevoked is the type of the event emitted when a lease is revoked.
+	TypeLeaseRevoked = "com.hashicorp.secrets.lease.revoked"
+	// TypeSecretRotated is the type of the event emitted when the data of a
+	// destination Secret is rotated.
+	TypeSecretRotated = "com.hashicorp.secrets.secret.rotated"
+
+	// sendTimeout bounds the delivery of a single event.
+	sendTimeout = 10 * time.Second
+)
+
+// DefaultEmitter emits the events of all the controllers. It is nil unless a
+// sink has been configured on the Operator.
+var DefaultEmitter *Emitter
+
+// Event is a CloudEvent in the structured JSON format.
+type Event struct {
+	SpecVersion     string    `json:"specversion"`
+	ID              string    `json:"id"`
+	Source          string    `json:"source"`
+	Type            string    `json:"type"`
+	Subject         string    `json:"subject"`
+	Time            time.Time `json:"time"`
+	DataContentType string    `json:"datacontenttype"`
+	Data            Data      `json:"data"`
+}
+
+// Data is the data of an Event, it identifies the resource the event is about.
+type Data struct {
+	Kind        string `json:"kind"`
+	Namespace   string `json:"namespace"`
+	Name        string `json:"name"`
+	Destination string `json:"destination,omitempty"`
+	// The lease fields are only set on the lease events.
+	LeaseID       string `json:"leaseID,omitempty"`
+	LeaseDuration int    `json:"leaseDuration,omitempty"`
+	Renewable     bool   `json:"renewable,omitempty"`
+}
+
+// LeaseData returns the Data of the lease events of lease.
+func LeaseData(lease secretsv1beta1.VaultSecretLease) Data {
+	return Data{
+		LeaseID:       lease.ID,
+		LeaseDuration: lease.LeaseDuration,
+		Renewable:     lease.Renewable,
+	}
+}
+
+// Sink delivers the events.
+type Sink interface {
+	Send(ctx context.Context, event Event) error
+}
+
+var _ manager.Runnable = (*Emitter)(nil)
+
+// Emitter queues the events, and delivers them to its Sink in the background,
+// so that the reconciliations are never blocked by the Sink. The events are
+// dropped when the queue is full.
+type Emitter struct {
+	Sink Sink
+	// Source of the events, it defaults to DefaultSource.
+	Source string
+	// Scheme is used to get the kind of the resources.
+	Scheme *runtime.Scheme
+	queue  chan Event
+	now    func() time.Time
+}
+
+// NewEmitter returns an Emitter that buffers up to queueSize events.
+func NewEmitter(sink Sink, source string, scheme *runtime.Scheme, queueSize int) *Emitter {
+	if source == "" {
+		source = DefaultSource
+	}
+	if queueSize <= 0 {
+		queueSize = DefaultQueueSize
+	}
+	return &Emitter{
+		Sink:   sink,
+		Source: source,
+		Scheme: scheme,
+		queue:  make(chan Event, queueSize),
+		now:    time.Now,
+	}
+}
+
+// Emit queues an event of eventType about obj. The resource fields of data are
+// set from obj.
+func (e *Emitter) Emit(ctx context.Context, eventType string, obj ctrlclient.Object, data Data) {
+	logger := log.FromContext(ctx).WithName("cloudevents")
+
+	data.Namespace = obj.GetNamespace()
+	data.Name = obj.GetName()
+	data.Kind = obj.GetObjectKind().GroupVersionKind().Kind
+	if e.Scheme != nil {
+		if gvk, err := apiutil.GVKForObject(obj, e.Scheme); err == nil {
+			data.Kind = gvk.Kind
+		}
+	}
+	if data.Destination == "" {
+		if meta, err := common.NewSyncableSecretMetaData(obj); err == nil && meta.Destination != nil {
+			data.Destination = meta.Destination.Name
+		}
+	}
+
+	event := Event{
+		SpecVersion:     SpecVersion,
+		ID:              uuid.NewString(),
+		Source:          e.Source,
+		Type:            eventType,
+		Subject:         ctrlclient.ObjectKeyFromObject(obj).String(),
+		Time:            e.now().UTC(),
+		DataContentType: "application/json",
+		Data:            data,
+	}
+	select {
+	case e.queue <- event:
+	default:
+		logger.Error(nil, "Dropped the event, the queue is full", "type", eventType, "subject", event.Subject)
+	}
+}
+
+// Start delivers the queued events until ctx is done.
+func (e *Emitter) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("cloudevents")
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event := <-e.queue:
+			sendCtx, cancel := context.WithTimeout(ctx, sendTimeout)
+			if err := e.Sink.Send(sendCtx, event); err != nil {
+				logger.Error(err, "Failed to send the event", "type", event.Type, "subject", event.Subject)
+			}
+			cancel()
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. The events are
+// only emitted by the leader, the Emitter is started on every replica so that
+// none are queued up until the election.
+func (e *Emitter) NeedLeaderElection() bool {
+	return false
+}
+
+// Emit queues an event with the DefaultEmitter, if it is set.
+func Emit(ctx context.Context, eventType string, obj ctrlclient.Object, data Data) {
+	if DefaultEmitter == nil {
+		return
+	}
+	DefaultEmitter.Emit(ctx, eventType, obj, data)
+}
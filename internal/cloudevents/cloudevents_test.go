@@ -0,0 +1,175 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package cloudevents
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+)
+
+type request struct {
+	path        string
+	contentType string
+	body        []byte
+}
+
+func newTestServer(t *testing.T, status int) (*httptest.Server, chan request) {
+	t.Helper()
+
+	requests := make(chan request, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		requests <- request{
+			path:        r.URL.Path,
+			contentType: r.Header.Get("Content-Type"),
+			body:        b,
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, requests
+}
+
+func testScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, secretsv1beta1.AddToScheme(scheme))
+	return scheme
+}
+
+func TestEmitter(t *testing.T) {
+	t.Parallel()
+
+	srv, requests := newTestServer(t, http.StatusAccepted)
+	e := NewEmitter(&HTTPSink{URL: srv.URL}, "", testScheme(t), 0)
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	e.now = func() time.Time { return now }
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go func() {
+		_ = e.Start(ctx)
+	}()
+
+	obj := &secretsv1beta1.VaultDynamicSecret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "foo",
+			Name:      "bar",
+		},
+		Spec: secretsv1beta1.VaultDynamicSecretSpec{
+			Destination: secretsv1beta1.Destination{
+				Name: "baz",
+			},
+		},
+	}
+	e.Emit(ctx, TypeLeaseRenewed, obj, LeaseData(secretsv1beta1.VaultSecretLease{
+		ID:            "database/creds/app/1",
+		LeaseDuration: 300,
+		Renewable:     true,
+	}))
+
+	var req request
+	select {
+	case req = <-requests:
+	case <-time.After(10 * time.Second):
+		require.FailNow(t, "timed out waiting for the event")
+	}
+	assert.Equal(t, ContentTypeStructured, req.contentType)
+
+	var got Event
+	require.NoError(t, json.Unmarshal(req.body, &got))
+	assert.NotEmpty(t, got.ID)
+	got.ID = ""
+	assert.Equal(t, Event{
+		SpecVersion:     SpecVersion,
+		Source:          DefaultSource,
+		Type:            TypeLeaseRenewed,
+		Subject:         "foo/bar",
+		Time:            now,
+		DataContentType: "application/json",
+		Data: Data{
+			Kind:          "VaultDynamicSecret",
+			Namespace:     "foo",
+			Name:          "bar",
+			Destination:   "baz",
+			LeaseID:       "database/creds/app/1",
+			LeaseDuration: 300,
+			Renewable:     true,
+		},
+	}, got)
+}
+
+func TestEmitter_queueFull(t *testing.T) {
+	t.Parallel()
+
+	e := NewEmitter(&HTTPSink{}, "", nil, 1)
+	obj := &secretsv1beta1.VaultStaticSecret{}
+	e.Emit(context.Background(), TypeSecretRotated, obj, Data{})
+	e.Emit(context.Background(), TypeSecretRotated, obj, Data{})
+	assert.Len(t, e.queue, 1)
+}
+
+func TestEmit_disabled(t *testing.T) {
+	t.Parallel()
+
+	// never panics without a DefaultEmitter.
+	Emit(context.Background(), TypeSecretRotated, &secretsv1beta1.VaultStaticSecret{}, Data{})
+}
+
+func TestHTTPSink_Send(t *testing.T) {
+	t.Parallel()
+
+	srv, _ := newTestServer(t, http.StatusInternalServerError)
+	s := &HTTPSink{URL: srv.URL}
+	assert.ErrorContains(t, s.Send(context.Background(), Event{}),
+		`unexpected response status "500 Internal Server Error"`)
+}
+
+func TestKafkaRESTSink_Send(t *testing.T) {
+	t.Parallel()
+
+	srv, requests := newTestServer(t, http.StatusOK)
+	s := &KafkaRESTSink{
+		URL:   srv.URL + "/",
+		Topic: "vso-events",
+	}
+	event := Event{
+		SpecVersion: SpecVersion,
+		ID:          "1",
+		Type:        TypeLeaseRevoked,
+		Subject:     "foo/bar",
+	}
+	require.NoError(t, s.Send(context.Background(), event))
+
+	req := <-requests
+	assert.Equal(t, "/topics/vso-events", req.path)
+	assert.Equal(t, ContentTypeKafkaREST, req.contentType)
+
+	var got kafkaRecords
+	require.NoError(t, json.Unmarshal(req.body, &got))
+	assert.Equal(t, kafkaRecords{
+		Records: []kafkaRecord{
+			{
+				Key:   "foo/bar",
+				Value: event,
+			},
+		},
+	}, got)
+}
@@ -0,0 +1,108 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package cloudevents
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+const (
+	// ContentTypeStructured is the content type of the events sent by the
+	// HTTPSink, as per the CloudEvents HTTP protocol binding.
+	ContentTypeStructured = "application/cloudevents+json; charset=utf-8"
+	// ContentTypeKafkaREST is the content type of the records produced by the
+	// KafkaRESTSink.
+	ContentTypeKafkaREST = "application/vnd.kafka.json.v2+json"
+)
+
+var (
+	_ Sink = (*HTTPSink)(nil)
+	_ Sink = (*KafkaRESTSink)(nil)
+)
+
+// HTTPSink sends each event to URL, in the structured content mode of the
+// CloudEvents HTTP protocol binding, e.g. to a Knative broker.
+type HTTPSink struct {
+	URL string
+	// Client defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+func (s *HTTPSink) Send(ctx context.Context, event Event) error {
+	b, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return post(ctx, s.Client, s.URL, ContentTypeStructured, b)
+}
+
+// KafkaRESTSink produces each event to Topic, through the Kafka REST proxy at
+// URL, e.g. the Confluent REST Proxy or the Strimzi Kafka Bridge. The records
+// are keyed by the event's subject, so that all the events of a resource are
+// produced to the same partition, in order.
+type KafkaRESTSink struct {
+	URL   string
+	Topic string
+	// Client defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+type kafkaRecord struct {
+	Key   string `json:"key"`
+	Value Event  `json:"value"`
+}
+
+type kafkaRecords struct {
+	Records []kafkaRecord `json:"records"`
+}
+
+func (s *KafkaRESTSink) Send(ctx context.Context, event Event) error {
+	u, err := url.JoinPath(s.URL, "topics", s.Topic)
+	if err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(kafkaRecords{
+		Records: []kafkaRecord{
+			{
+				Key:   event.Subject,
+				Value: event,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return post(ctx, s.Client, u, ContentTypeKafkaREST, b)
+}
+
+func post(ctx context.Context, client *http.Client, u, contentType string, body []byte) error {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected response status %q from %s", resp.Status, u)
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package configdrift detects drift between the Operator's effective runtime
+// configuration, i.e. its command line flags and environment variables, and
+// the desired configuration declared in a ConfigMap, e.g. rendered from the
+// Helm chart's values. Drift typically comes from out-of-band edits of the
+// Operator's Deployment, e.g. with kubectl, that diverge from the GitOps
+// source.
+//
+// The ConfigMap's keys are either flag names, e.g. "client-cache-size", or
+// environment variable names prefixed with EnvKeyPrefix, e.g.
+// "env.VSO_CLIENT_CACHE_SIZE". Only the declared keys are checked.
+package configdrift
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/hashicorp/vault-secrets-operator/consts"
+	vsometrics "github.com/hashicorp/vault-secrets-operator/internal/metrics"
+)
+
+// EnvKeyPrefix prefixes the keys of the environment variables in the
+// configuration.
+const EnvKeyPrefix = "env."
+
+// Drifted is set to 1 for every key whose effective value differs from the
+// desired one.
+var Drifted = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: vsometrics.Namespace,
+	Name:      "config_drift",
+	Help:      "Set to 1 when the effective value of the configuration key differs from the desired one",
+}, []string{
+	"key",
+})
+
+func init() {
+	metrics.Registry.MustRegister(Drifted)
+}
+
+// Drift of a single configuration key.
+type Drift struct {
+	// Key of the configuration.
+	Key string
+	// Desired value of the key.
+	Desired string
+	// Actual value of the key, empty when it is not set.
+	Actual string
+}
+
+func (d Drift) String() string {
+	return fmt.Sprintf("%s: desired=%q, actual=%q", d.Key, d.Desired, d.Actual)
+}
+
+// Effective returns the effective configuration made of all the flags of fs,
+// and of the environment variables in environ, as returned by os.Environ.
+func Effective(fs *flag.FlagSet, environ []string) map[string]string {
+	ret := make(map[string]string)
+	fs.VisitAll(func(f *flag.Flag) {
+		ret[f.Name] = f.Value.String()
+	})
+	for _, kv := range environ {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			ret[EnvKeyPrefix+k] = v
+		}
+	}
+	return ret
+}
+
+// Compare returns the drift between the desired and the effective
+// configuration, sorted by key. Only the keys of desired are compared.
+func Compare(desired, effective map[string]string) []Drift {
+	var ret []Drift
+	for _, k := range slices.Sorted(maps.Keys(desired)) {
+		if actual := effective[k]; !equalValues(desired[k], actual) {
+			ret = append(ret, Drift{
+				Key:     k,
+				Desired: desired[k],
+				Actual:  actual,
+			})
+		}
+	}
+	return ret
+}
+
+// equalValues returns true if a and b are equal, or represent the same
+// duration, number, or boolean, e.g. "60s" and "1m0s", since the flags
+// format their values differently than they are usually declared.
+func equalValues(a, b string) bool {
+	if a == b {
+		return true
+	}
+	if x, err := time.ParseDuration(a); err == nil {
+		if y, err := time.ParseDuration(b); err == nil {
+			return x == y
+		}
+	}
+	if x, err := strconv.ParseFloat(a, 64); err == nil {
+		if y, err := strconv.ParseFloat(b, 64); err == nil {
+			return x == y
+		}
+	}
+	if x, err := strconv.ParseBool(a); err == nil {
+		if y, err := strconv.ParseBool(b); err == nil {
+			return x == y
+		}
+	}
+	return false
+}
+
+// Check compares the effective configuration to the desired one, declared in
+// the ConfigMap objKey. Every drift is reported in the Drifted metric, and in
+// a warning Event that is recorded on the ConfigMap.
+func Check(ctx context.Context, c client.Client, recorder record.EventRecorder,
+	objKey client.ObjectKey, effective map[string]string,
+) ([]Drift, error) {
+	cm := &corev1.ConfigMap{}
+	if err := c.Get(ctx, objKey, cm); err != nil {
+		return nil, fmt.Errorf("failed to get the desired configuration ConfigMap %s: %w", objKey, err)
+	}
+
+	drifts := Compare(cm.Data, effective)
+	Drifted.Reset()
+	for _, d := range drifts {
+		Drifted.WithLabelValues(d.Key).Set(1)
+	}
+
+	if len(drifts) > 0 {
+		msgs := make([]string, len(drifts))
+		for i, d := range drifts {
+			msgs[i] = d.String()
+		}
+		ctrl.Log.WithName("configdrift").Info("Warning: runtime configuration drift detected",
+			"configMap", objKey, "drifts", msgs)
+		recorder.Eventf(cm, corev1.EventTypeWarning, consts.ReasonConfigDriftDetected,
+			"Runtime configuration differs from the desired one: %s", strings.Join(msgs, ", "))
+	}
+
+	return drifts, nil
+}
@@ -0,0 +1,174 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package configdrift
+
+import (
+	"context"
+	"flag"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestEffective(t *testing.T) {
+	t.Parallel()
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Int("client-cache-size", 10000, "")
+	fs.Bool("leader-elect", false, "")
+	require.NoError(t, fs.Parse([]string{"--leader-elect"}))
+
+	assert.Equal(t, map[string]string{
+		"client-cache-size":     "10000",
+		"leader-elect":          "true",
+		"env.VSO_OUTPUT_FORMAT": "json",
+		"env.EMPTY":             "",
+		"env.WITH_EQUALS":       "a=b",
+	}, Effective(fs, []string{
+		"VSO_OUTPUT_FORMAT=json",
+		"EMPTY=",
+		"WITH_EQUALS=a=b",
+		"INVALID",
+	}))
+}
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+
+	effective := map[string]string{
+		"backoff-max-interval":  "1m0s",
+		"backoff-multiplier":    "1.5",
+		"client-cache-size":     "10000",
+		"leader-elect":          "true",
+		"env.VSO_OUTPUT_FORMAT": "json",
+	}
+	tests := []struct {
+		name    string
+		desired map[string]string
+		want    []Drift
+	}{
+		{
+			name: "in-sync",
+			desired: map[string]string{
+				"client-cache-size":     "10000",
+				"env.VSO_OUTPUT_FORMAT": "json",
+			},
+		},
+		{
+			name: "equivalent-values",
+			desired: map[string]string{
+				"backoff-max-interval": "60s",
+				"backoff-multiplier":   "1.50",
+				"leader-elect":         "1",
+			},
+		},
+		{
+			name: "drifted",
+			desired: map[string]string{
+				"leader-elect":          "false",
+				"client-cache-size":     "100",
+				"env.VSO_OUTPUT_FORMAT": "json",
+				"env.VSO_CLIENT_CACHE":  "direct-encrypted",
+			},
+			want: []Drift{
+				{
+					Key:     "client-cache-size",
+					Desired: "100",
+					Actual:  "10000",
+				},
+				{
+					Key:     "env.VSO_CLIENT_CACHE",
+					Desired: "direct-encrypted",
+				},
+				{
+					Key:     "leader-elect",
+					Desired: "false",
+					Actual:  "true",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, Compare(tt.desired, effective))
+		})
+	}
+}
+
+// gatherDrifted returns the value of the Drifted metric by key.
+func gatherDrifted(t *testing.T) map[string]float64 {
+	t.Helper()
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(Drifted)
+	mfs, err := reg.Gather()
+	require.NoError(t, err)
+
+	ret := make(map[string]float64)
+	for _, mf := range mfs {
+		for _, m := range mf.GetMetric() {
+			ret[m.GetLabel()[0].GetValue()] = m.GetGauge().GetValue()
+		}
+	}
+	return ret
+}
+
+func TestCheck(t *testing.T) {
+	ctx := context.Background()
+	objKey := client.ObjectKey{Namespace: "vso", Name: "desired-config"}
+	c := fake.NewClientBuilder().Build()
+	recorder := record.NewFakeRecorder(10)
+	effective := map[string]string{
+		"client-cache-size": "10000",
+		"leader-elect":      "true",
+	}
+
+	_, err := Check(ctx, c, recorder, objKey, effective)
+	assert.ErrorContains(t, err, "failed to get the desired configuration ConfigMap vso/desired-config")
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      objKey.Name,
+			Namespace: objKey.Namespace,
+		},
+		Data: map[string]string{
+			"client-cache-size": "100",
+			"leader-elect":      "true",
+		},
+	}
+	require.NoError(t, c.Create(ctx, cm))
+
+	drifts, err := Check(ctx, c, recorder, objKey, effective)
+	require.NoError(t, err)
+	assert.Equal(t, []Drift{
+		{
+			Key:     "client-cache-size",
+			Desired: "100",
+			Actual:  "10000",
+		},
+	}, drifts)
+	assert.Equal(t, map[string]float64{"client-cache-size": 1}, gatherDrifted(t))
+	if assert.Len(t, recorder.Events, 1) {
+		assert.Equal(t,
+			`Warning ConfigDriftDetected Runtime configuration differs from the desired one: `+
+				`client-cache-size: desired="100", actual="10000"`,
+			<-recorder.Events)
+	}
+
+	cm.Data["client-cache-size"] = "10000"
+	require.NoError(t, c.Update(ctx, cm))
+	drifts, err = Check(ctx, c, recorder, objKey, effective)
+	require.NoError(t, err)
+	assert.Empty(t, drifts)
+	assert.Empty(t, gatherDrifted(t))
+	assert.Empty(t, recorder.Events)
+}
@@ -0,0 +1,285 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package diagnostics captures a snapshot of a syncable secret resource that
+// keeps failing to sync, so that it can be attached to a bug report. The
+// snapshot is stored in the AnnotationDiagnostics annotation of the resource,
+// once the resource has failed to sync Threshold times in a row. It is
+// anonymized: it holds the paths and status codes of the failed requests, the
+// failure timings, the auth method, and the condition history, but never the
+// error messages, nor any secret data.
+package diagnostics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/vault/api"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/common"
+)
+
+const (
+	// AnnotationDiagnostics holds the JSON encoded Snapshot of the resource.
+	AnnotationDiagnostics = "vso.secrets.hashicorp.com/diagnostics"
+
+	// maxFailures is the number of failures kept in a Snapshot, the oldest
+	// ones are dropped first.
+	maxFailures = 10
+	// staleAfter is the duration after which the failure streak of a resource
+	// is forgotten, if it has not failed since, e.g. when it was deleted.
+	staleAfter = 24 * time.Hour
+)
+
+// DefaultCapturer captures the snapshots of all the controllers. It is nil
+// unless a failure threshold has been configured on the Operator.
+var DefaultCapturer *Capturer
+
+// Snapshot is the anonymized diagnostic data of a resource.
+type Snapshot struct {
+	CapturedAt          time.Time `json:"capturedAt"`
+	Kind                string    `json:"kind"`
+	ConsecutiveFailures int       `json:"consecutiveFailures"`
+	FirstFailureAt      time.Time `json:"firstFailureAt"`
+	// AuthMethod is the method of the VaultAuth, or the HCPAuth, of the
+	// resource, if it could be resolved.
+	AuthMethod string      `json:"authMethod,omitempty"`
+	Failures   []Failure   `json:"failures"`
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+// Failure is a single failed sync.
+type Failure struct {
+	Time   time.Time `json:"time"`
+	Reason string    `json:"reason"`
+	// Method, Path and StatusCode are those of the failed Vault request, if
+	// any. The query is never included in the Path.
+	Method     string `json:"method,omitempty"`
+	Path       string `json:"path,omitempty"`
+	StatusCode int    `json:"statusCode,omitempty"`
+}
+
+// Condition is a status condition of the resource, without its message.
+type Condition struct {
+	Type               string                 `json:"type"`
+	Status             metav1.ConditionStatus `json:"status"`
+	Reason             string                 `json:"reason"`
+	LastTransitionTime time.Time              `json:"lastTransitionTime"`
+}
+
+type streak struct {
+	failures []Failure
+	count    int
+	first    time.Time
+	captured bool
+}
+
+// Capturer counts the consecutive sync failures of every resource, and
+// captures its Snapshot once they reach the Threshold. A single Snapshot is
+// captured per failure streak.
+type Capturer struct {
+	// Threshold is the number of consecutive failures after which the
+	// Snapshot is captured.
+	Threshold int
+	// Scheme is used to get the kind of the resources.
+	Scheme  *runtime.Scheme
+	streaks map[types.UID]*streak
+	mu      sync.Mutex
+	now     func() time.Time
+}
+
+// NewCapturer returns a Capturer that captures a Snapshot after threshold
+// consecutive failures.
+func NewCapturer(threshold int, scheme *runtime.Scheme) *Capturer {
+	return &Capturer{
+		Threshold: threshold,
+		Scheme:    scheme,
+		streaks:   make(map[types.UID]*streak),
+		now:       time.Now,
+	}
+}
+
+// RecordFailure records a sync failure of obj, whose last sync messages,
+// ending with the failure, are messages. The args of the failure's message are
+// inspected for the failed Vault request. The failure streak is reset if the
+// last sync of obj succeeded. The Snapshot is patched into the annotations of
+// obj once the streak reaches the Threshold.
+func (c *Capturer) RecordFailure(ctx context.Context, client ctrlclient.Client, obj ctrlclient.Object,
+	messages []secretsv1beta1.SyncMessage, conditions []metav1.Condition, reason string, a ...any,
+) {
+	logger := log.FromContext(ctx).WithName("diagnostics")
+
+	snapshot, ok := c.recordFailure(obj.GetUID(), messages, conditions, reason, a...)
+	if !ok {
+		return
+	}
+
+	snapshot.Kind = obj.GetObjectKind().GroupVersionKind().Kind
+	if c.Scheme != nil {
+		if gvk, err := apiutil.GVKForObject(obj, c.Scheme); err == nil {
+			snapshot.Kind = gvk.Kind
+		}
+	}
+	snapshot.AuthMethod = authMethod(ctx, client, obj)
+
+	b, err := json.Marshal(snapshot)
+	if err != nil {
+		logger.Error(err, "Failed to marshal the diagnostic snapshot")
+		return
+	}
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]string{
+				AnnotationDiagnostics: string(b),
+			},
+		},
+	})
+	if err != nil {
+		logger.Error(err, "Failed to marshal the diagnostic snapshot patch")
+		return
+	}
+	if err := client.Patch(ctx, obj, ctrlclient.RawPatch(types.MergePatchType, patch)); err != nil {
+		logger.Error(err, "Failed to store the diagnostic snapshot")
+		return
+	}
+
+	logger.Info("Captured the diagnostic snapshot of the persistent sync failure",
+		"annotation", AnnotationDiagnostics, "failures", snapshot.ConsecutiveFailures)
+}
+
+func (c *Capturer) recordFailure(uid types.UID, messages []secretsv1beta1.SyncMessage,
+	conditions []metav1.Condition, reason string, a ...any,
+) (*Snapshot, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	for k, s := range c.streaks {
+		if now.Sub(s.failures[len(s.failures)-1].Time) > staleAfter {
+			delete(c.streaks, k)
+		}
+	}
+
+	s, ok := c.streaks[uid]
+	if !ok || lastSyncSucceeded(messages) {
+		s = &streak{
+			first: now,
+		}
+		c.streaks[uid] = s
+	}
+
+	failure := Failure{
+		Time:   now.UTC(),
+		Reason: reason,
+	}
+	setRequest(&failure, a...)
+	s.count++
+	s.failures = append(s.failures, failure)
+	if l := len(s.failures); l > maxFailures {
+		s.failures = s.failures[l-maxFailures:]
+	}
+
+	if s.captured || s.count < c.Threshold {
+		return nil, false
+	}
+	s.captured = true
+
+	snapshot := &Snapshot{
+		CapturedAt:          now.UTC(),
+		ConsecutiveFailures: s.count,
+		FirstFailureAt:      s.first.UTC(),
+		Failures:            append([]Failure(nil), s.failures...),
+	}
+	for _, cond := range conditions {
+		snapshot.Conditions = append(snapshot.Conditions, Condition{
+			Type:               cond.Type,
+			Status:             cond.Status,
+			Reason:             cond.Reason,
+			LastTransitionTime: cond.LastTransitionTime.UTC(),
+		})
+	}
+
+	return snapshot, true
+}
+
+// RecordFailure records a sync failure with the DefaultCapturer, if it is set.
+func RecordFailure(ctx context.Context, client ctrlclient.Client, obj ctrlclient.Object,
+	messages []secretsv1beta1.SyncMessage, conditions []metav1.Condition, reason string, a ...any,
+) {
+	if DefaultCapturer == nil {
+		return
+	}
+	DefaultCapturer.RecordFailure(ctx, client, obj, messages, conditions, reason, a...)
+}
+
+// lastSyncSucceeded returns true if the sync prior to the last one succeeded.
+func lastSyncSucceeded(messages []secretsv1beta1.SyncMessage) bool {
+	if len(messages) < 2 {
+		return false
+	}
+	return messages[len(messages)-2].Result == secretsv1beta1.SyncResultSuccess
+}
+
+// setRequest sets the request fields of failure from the first error in args
+// that wraps a failed Vault request.
+func setRequest(failure *Failure, args ...any) {
+	for _, arg := range args {
+		err, ok := arg.(error)
+		if !ok {
+			continue
+		}
+
+		var respErr *api.ResponseError
+		if errors.As(err, &respErr) {
+			failure.Method = respErr.HTTPMethod
+			failure.Path = stripQuery(respErr.URL)
+			failure.StatusCode = respErr.StatusCode
+			return
+		}
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			failure.Method = urlErr.Op
+			failure.Path = stripQuery(urlErr.URL)
+			return
+		}
+	}
+}
+
+// stripQuery returns the path of rawURL, since its query may hold
+// sensitive values.
+func stripQuery(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return u.Path
+}
+
+// authMethod returns the auth method of obj, or an empty string if it cannot
+// be resolved.
+func authMethod(ctx context.Context, client ctrlclient.Client, obj ctrlclient.Object) string {
+	if _, ok := obj.(*secretsv1beta1.HCPVaultSecretsApp); ok {
+		authObj, err := common.GetHCPAuthForObj(ctx, client, obj)
+		if err != nil {
+			return ""
+		}
+		return authObj.Spec.Method
+	}
+
+	authObj, err := common.GetVaultAuthNamespaced(ctx, client, obj, nil)
+	if err != nil {
+		return ""
+	}
+	return authObj.Spec.Method
+}
@@ -0,0 +1,153 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package diagnostics
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/internal/testutils"
+)
+
+func TestCapturer_RecordFailure(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	authObj := &secretsv1beta1.VaultAuth{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "baz",
+			Name:      "default",
+		},
+		Spec: secretsv1beta1.VaultAuthSpec{
+			Method: "kubernetes",
+		},
+	}
+	o := &secretsv1beta1.VaultStaticSecret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "baz",
+			Name:      "foo",
+			UID:       "uid",
+		},
+		Spec: secretsv1beta1.VaultStaticSecretSpec{
+			VaultAuthRef: "default",
+		},
+	}
+	client := testutils.NewFakeClientBuilder().WithObjects(authObj, o).Build()
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	c := NewCapturer(3, client.Scheme())
+	c.now = func() time.Time {
+		return now
+	}
+
+	success := secretsv1beta1.SyncMessage{Result: secretsv1beta1.SyncResultSuccess}
+	failure := secretsv1beta1.SyncMessage{Result: secretsv1beta1.SyncResultFailure}
+	conditions := []metav1.Condition{
+		{
+			Type:               "Healthy",
+			Status:             metav1.ConditionFalse,
+			Reason:             "VaultClientError",
+			Message:            "secret data",
+			LastTransitionTime: metav1.NewTime(now),
+		},
+	}
+	respErr := &api.ResponseError{
+		HTTPMethod: http.MethodGet,
+		URL:        "https://vault.example.com:8200/v1/kv/data/foo?version=1",
+		StatusCode: http.StatusForbidden,
+		Errors:     []string{"permission denied"},
+	}
+	urlErr := &url.Error{
+		Op:  http.MethodGet,
+		URL: "https://vault.example.com:8200/v1/kv/data/foo?version=1",
+		Err: fmt.Errorf("connection refused"),
+	}
+
+	annotation := func(t *testing.T) string {
+		t.Helper()
+		var got secretsv1beta1.VaultStaticSecret
+		require.NoError(t, client.Get(ctx, ctrlclient.ObjectKeyFromObject(o), &got))
+		return got.GetAnnotations()[AnnotationDiagnostics]
+	}
+
+	// the streak is reset by the successful sync.
+	c.RecordFailure(ctx, client, o, []secretsv1beta1.SyncMessage{failure}, conditions, "VaultClientError", respErr)
+	c.RecordFailure(ctx, client, o, []secretsv1beta1.SyncMessage{failure, failure}, conditions, "VaultClientError", respErr)
+	c.RecordFailure(ctx, client, o, []secretsv1beta1.SyncMessage{success, failure}, conditions, "VaultClientError", respErr)
+	c.RecordFailure(ctx, client, o, []secretsv1beta1.SyncMessage{failure, failure}, conditions, "VaultClientError",
+		fmt.Errorf("failed to read: %w", urlErr))
+	assert.Empty(t, annotation(t))
+
+	c.RecordFailure(ctx, client, o, []secretsv1beta1.SyncMessage{failure, failure}, conditions, "VaultClientError", respErr)
+	var snapshot Snapshot
+	require.NoError(t, json.Unmarshal([]byte(annotation(t)), &snapshot))
+	assert.Equal(t, Snapshot{
+		CapturedAt:          now,
+		Kind:                "VaultStaticSecret",
+		ConsecutiveFailures: 3,
+		FirstFailureAt:      now,
+		AuthMethod:          "kubernetes",
+		Failures: []Failure{
+			{
+				Time:       now,
+				Reason:     "VaultClientError",
+				Method:     http.MethodGet,
+				Path:       "/v1/kv/data/foo",
+				StatusCode: http.StatusForbidden,
+			},
+			{
+				Time:   now,
+				Reason: "VaultClientError",
+				Method: http.MethodGet,
+				Path:   "/v1/kv/data/foo",
+			},
+			{
+				Time:       now,
+				Reason:     "VaultClientError",
+				Method:     http.MethodGet,
+				Path:       "/v1/kv/data/foo",
+				StatusCode: http.StatusForbidden,
+			},
+		},
+		Conditions: []Condition{
+			{
+				Type:               "Healthy",
+				Status:             metav1.ConditionFalse,
+				Reason:             "VaultClientError",
+				LastTransitionTime: now,
+			},
+		},
+	}, snapshot)
+	assert.NotContains(t, annotation(t), "permission denied")
+	assert.NotContains(t, annotation(t), "secret data")
+
+	// a single snapshot is captured per streak.
+	require.NoError(t, client.Patch(ctx, o, ctrlclient.RawPatch(types.MergePatchType,
+		[]byte(`{"metadata":{"annotations":null}}`))))
+	c.RecordFailure(ctx, client, o, []secretsv1beta1.SyncMessage{failure, failure}, conditions, "VaultClientError", respErr)
+	assert.Empty(t, annotation(t))
+
+	// the streaks that have not failed for a while are forgotten.
+	now = now.Add(staleAfter + time.Second)
+	c.RecordFailure(ctx, client, &secretsv1beta1.VaultStaticSecret{
+		ObjectMeta: metav1.ObjectMeta{
+			UID: "other",
+		},
+	}, nil, nil, "VaultClientError")
+	assert.Len(t, c.streaks, 1)
+	assert.Contains(t, c.streaks, types.UID("other"))
+}
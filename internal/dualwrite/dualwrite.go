@@ -0,0 +1,221 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package dualwrite writes the destination Secrets into a target cluster, in
+// addition to the Operator's own cluster, during a cluster migration. The
+// Secrets are kept byte-identical in both clusters until the end of the
+// migration window, so that the workloads can be shifted to the target
+// cluster without freezing their secrets.
+package dualwrite
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/clientcmd"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// KubeconfigKey is the key of the kubeconfig in the target cluster's Secret.
+const KubeconfigKey = "kubeconfig"
+
+// DefaultWriter writes the destination Secrets of all the controllers into
+// the target cluster. It is nil unless a migration target has been configured
+// on the Operator.
+var DefaultWriter *Writer
+
+// Writer writes Secrets into the target cluster until the end of the
+// migration window.
+type Writer struct {
+	// Target is the client of the target cluster.
+	Target ctrlclient.Client
+	// Until is the end of the migration window, no Secret is written after it.
+	Until time.Time
+	now   func() time.Time
+	once  sync.Once
+}
+
+// NewWriter returns a Writer for the target cluster of the kubeconfig that is
+// stored in the Secret for key.
+func NewWriter(ctx context.Context, client ctrlclient.Reader, key ctrlclient.ObjectKey,
+	until time.Time, scheme *runtime.Scheme,
+) (*Writer, error) {
+	s := &corev1.Secret{}
+	if err := client.Get(ctx, key, s); err != nil {
+		return nil, fmt.Errorf("failed to get the target cluster's kubeconfig secret %s: %w", key, err)
+	}
+
+	b, ok := s.Data[KubeconfigKey]
+	if !ok || len(b) == 0 {
+		return nil, fmt.Errorf("%q not present in the target cluster's kubeconfig secret %s", KubeconfigKey, key)
+	}
+
+	config, err := clientcmd.RESTConfigFromKubeConfig(b)
+	if err != nil {
+		return nil, fmt.Errorf("invalid kubeconfig in secret %s: %w", key, err)
+	}
+
+	target, err := ctrlclient.New(config, ctrlclient.Options{
+		Scheme: scheme,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Writer{
+		Target: target,
+		Until:  until,
+	}, nil
+}
+
+// Active returns true if the migration window has not ended.
+func (w *Writer) Active() bool {
+	now := time.Now
+	if w.now != nil {
+		now = w.now
+	}
+	return now().Before(w.Until)
+}
+
+// Write s into the same namespace of the target cluster. The Secret's type,
+// data, labels, and annotations are written as is. Its owner references are
+// not, since the owners do not exist in the target cluster, where the Secret
+// would otherwise be garbage collected. The Secret is left as is when it is
+// already in sync. Nothing is written once the migration window has ended.
+func (w *Writer) Write(ctx context.Context, s *corev1.Secret) error {
+	if !w.Active() {
+		w.once.Do(func() {
+			log.FromContext(ctx).Info("The dual-write migration window has ended, "+
+				"the destination secrets are no longer written to the target cluster",
+				"until", w.Until)
+		})
+		return nil
+	}
+
+	dest := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        s.Name,
+			Namespace:   s.Namespace,
+			Labels:      maps.Clone(s.Labels),
+			Annotations: maps.Clone(s.Annotations),
+		},
+		Immutable: s.Immutable,
+		Type:      s.Type,
+		Data:      maps.Clone(s.Data),
+	}
+
+	key := ctrlclient.ObjectKeyFromObject(dest)
+	existing := &corev1.Secret{}
+	if err := w.Target.Get(ctx, key, existing); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get the secret %s in the target cluster: %w", key, err)
+		}
+		if err := w.Target.Create(ctx, dest); err != nil {
+			return fmt.Errorf("failed to create the secret %s in the target cluster: %w", key, err)
+		}
+		return nil
+	}
+
+	// the type of Secret, and the data of an immutable Secret, cannot be updated.
+	if existing.Type != dest.Type || (existing.Immutable != nil && *existing.Immutable) {
+		if err := w.Target.Delete(ctx, existing); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete the secret %s in the target cluster: %w", key, err)
+		}
+		if err := w.Target.Create(ctx, dest); err != nil {
+			return fmt.Errorf("failed to recreate the secret %s in the target cluster: %w", key, err)
+		}
+		return nil
+	}
+
+	if equality.Semantic.DeepEqual(existing.Labels, dest.Labels) &&
+		equality.Semantic.DeepEqual(existing.Annotations, dest.Annotations) &&
+		equality.Semantic.DeepEqual(existing.Immutable, dest.Immutable) &&
+		equality.Semantic.DeepEqual(existing.Data, dest.Data) {
+		return nil
+	}
+
+	existing.Labels = dest.Labels
+	existing.Annotations = dest.Annotations
+	existing.Immutable = dest.Immutable
+	existing.Data = dest.Data
+	if err := w.Target.Update(ctx, existing); err != nil {
+		return fmt.Errorf("failed to update the secret %s in the target cluster: %w", key, err)
+	}
+
+	return nil
+}
+
+var _ manager.Runnable = (*Backfiller)(nil)
+
+// Backfiller writes all the destination Secrets into the target cluster once,
+// upon startup. The Writer is otherwise only called when a Secret is synced,
+// so the Secrets that were synced before the migration window started, and
+// that are not rotated, would never reach the target cluster.
+type Backfiller struct {
+	Writer *Writer
+	// List returns the Secrets to write, see helpers.DualWriteSecrets.
+	List func(ctx context.Context) ([]*corev1.Secret, error)
+	// Interval between two attempts, when some of the Secrets could not be
+	// written.
+	Interval time.Duration
+}
+
+// Start the backfill, blocking until all the Secrets have been written, the
+// migration window has ended, or ctx is done.
+func (b *Backfiller) Start(ctx context.Context) error {
+	ticker := time.NewTicker(b.Interval)
+	defer ticker.Stop()
+
+	for {
+		if b.Backfill(ctx) || !b.Writer.Active() {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection returns true, so that the Secrets are only written by the
+// leader.
+func (b *Backfiller) NeedLeaderElection() bool {
+	return true
+}
+
+// Backfill writes every listed Secret once, it returns true if all of them were
+// written.
+func (b *Backfiller) Backfill(ctx context.Context) bool {
+	logger := log.FromContext(ctx).WithName("dualwrite")
+
+	secrets, err := b.List(ctx)
+	if err != nil {
+		logger.Error(err, "Failed to list the destination secrets to backfill")
+		return false
+	}
+
+	ok := true
+	for _, s := range secrets {
+		if err := b.Writer.Write(ctx, s); err != nil {
+			logger.Error(err, "Failed to backfill the destination secret",
+				"secret", ctrlclient.ObjectKeyFromObject(s))
+			ok = false
+		}
+	}
+
+	if ok {
+		logger.Info("Backfilled the destination secrets into the target cluster", "count", len(secrets))
+	}
+	return ok
+}
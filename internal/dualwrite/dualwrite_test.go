@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package dualwrite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/hashicorp/vault-secrets-operator/internal/testutils"
+)
+
+func TestWriter_Write(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	var updates int
+	target := testutils.NewFakeClientBuilder().
+		WithInterceptorFuncs(interceptor.Funcs{
+			Update: func(ctx context.Context, client ctrlclient.WithWatch, obj ctrlclient.Object, opts ...ctrlclient.UpdateOption) error {
+				updates++
+				return client.Update(ctx, obj, opts...)
+			},
+		}).Build()
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	w := &Writer{
+		Target: target,
+		Until:  now.Add(time.Hour),
+		now: func() time.Time {
+			return now
+		},
+	}
+
+	s := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "foo",
+			Namespace:   "baz",
+			Labels:      map[string]string{"app": "foo"},
+			Annotations: map[string]string{"a": "b"},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: "secrets.hashicorp.com/v1beta1",
+					Kind:       "VaultStaticSecret",
+					Name:       "foo",
+					UID:        "uid",
+				},
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{"password": []byte("one")},
+	}
+
+	got := func(t *testing.T) *corev1.Secret {
+		t.Helper()
+		var o corev1.Secret
+		require.NoError(t, target.Get(ctx, ctrlclient.ObjectKeyFromObject(s), &o))
+		return &o
+	}
+
+	// created
+	require.NoError(t, w.Write(ctx, s))
+	o := got(t)
+	assert.Equal(t, s.Data, o.Data)
+	assert.Equal(t, s.Labels, o.Labels)
+	assert.Equal(t, s.Annotations, o.Annotations)
+	assert.Empty(t, o.OwnerReferences)
+
+	// updated
+	s.Data = map[string][]byte{"password": []byte("two")}
+	require.NoError(t, w.Write(ctx, s))
+	assert.Equal(t, s.Data, got(t).Data)
+	assert.Equal(t, 1, updates)
+
+	// not updated when in sync
+	require.NoError(t, w.Write(ctx, s))
+	assert.Equal(t, 1, updates)
+
+	// recreated on type change
+	s.Type = corev1.SecretTypeBasicAuth
+	s.Data = map[string][]byte{
+		corev1.BasicAuthUsernameKey: []byte("user"),
+		corev1.BasicAuthPasswordKey: []byte("three"),
+	}
+	require.NoError(t, w.Write(ctx, s))
+	o = got(t)
+	assert.Equal(t, corev1.SecretTypeBasicAuth, o.Type)
+	assert.Equal(t, s.Data, o.Data)
+
+	// recreated when immutable
+	s.Immutable = ptr.To(true)
+	require.NoError(t, w.Write(ctx, s))
+	s.Data = map[string][]byte{
+		corev1.BasicAuthUsernameKey: []byte("user"),
+		corev1.BasicAuthPasswordKey: []byte("four"),
+	}
+	require.NoError(t, w.Write(ctx, s))
+	assert.Equal(t, s.Data, got(t).Data)
+
+	// not written after the migration window
+	now = w.Until
+	assert.False(t, w.Active())
+	s.Data = map[string][]byte{
+		corev1.BasicAuthUsernameKey: []byte("user"),
+		corev1.BasicAuthPasswordKey: []byte("five"),
+	}
+	require.NoError(t, w.Write(ctx, s))
+	assert.Equal(t, []byte("four"), got(t).Data[corev1.BasicAuthPasswordKey])
+}
+
+func TestNewWriter(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := testutils.NewFakeClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "empty",
+			Namespace: "vso",
+		},
+	}).Build()
+
+	_, err := NewWriter(ctx, client, ctrlclient.ObjectKey{Namespace: "vso", Name: "empty"},
+		time.Now(), client.Scheme())
+	assert.EqualError(t, err, `"kubeconfig" not present in the target cluster's kubeconfig secret vso/empty`)
+
+	_, err = NewWriter(ctx, client, ctrlclient.ObjectKey{Namespace: "vso", Name: "missing"},
+		time.Now(), client.Scheme())
+	assert.ErrorContains(t, err, "failed to get the target cluster's kubeconfig secret vso/missing")
+}
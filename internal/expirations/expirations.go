@@ -0,0 +1,208 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package expirations lists the expirations of all the certificates and leases
+// managed by the Operator, such that portals can render what is about to
+// expire without having to inspect every custom resource.
+package expirations
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+)
+
+const (
+	// TypeCertificate is the Type of the Items of PKI certificates.
+	TypeCertificate = "certificate"
+	// TypeLease is the Type of the Items of Vault secret leases.
+	TypeLease = "lease"
+)
+
+// Item is the expiration of a single certificate or lease.
+type Item struct {
+	// Kind of the custom resource.
+	Kind string `json:"kind"`
+	// Namespace of the custom resource.
+	Namespace string `json:"namespace"`
+	// Name of the custom resource.
+	Name string `json:"name"`
+	// Type of the expiring credential, one of TypeCertificate or TypeLease.
+	Type string `json:"type"`
+	// ID of the credential, the serial number of a certificate, or the ID of
+	// a lease.
+	ID string `json:"id,omitempty"`
+	// ExpiresAt is the time the credential expires.
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// Source of Items, one per custom resource Kind.
+type Source struct {
+	// Kind of the custom resource.
+	Kind string
+	// Resource is the plural name of the Kind, as used for authorization.
+	Resource string
+	// NewList returns a new empty list of the Kind.
+	NewList func() ctrlclient.ObjectList
+}
+
+// Sources of all the Kinds that hold expiring credentials.
+var Sources = []Source{
+	{
+		Kind:     "VaultPKISecret",
+		Resource: "vaultpkisecrets",
+		NewList:  func() ctrlclient.ObjectList { return &secretsv1beta1.VaultPKISecretList{} },
+	},
+	{
+		Kind:     "VaultDynamicSecret",
+		Resource: "vaultdynamicsecrets",
+		NewList:  func() ctrlclient.ObjectList { return &secretsv1beta1.VaultDynamicSecretList{} },
+	},
+	{
+		Kind:     "VaultConsulSecret",
+		Resource: "vaultconsulsecrets",
+		NewList:  func() ctrlclient.ObjectList { return &secretsv1beta1.VaultConsulSecretList{} },
+	},
+	{
+		Kind:     "VaultGenericSecret",
+		Resource: "vaultgenericsecrets",
+		NewList:  func() ctrlclient.ObjectList { return &secretsv1beta1.VaultGenericSecretList{} },
+	},
+	{
+		Kind:     "VaultIdentityToken",
+		Resource: "vaultidentitytokens",
+		NewList:  func() ctrlclient.ObjectList { return &secretsv1beta1.VaultIdentityTokenList{} },
+	},
+	{
+		Kind:     "VaultKubernetesSecret",
+		Resource: "vaultkubernetessecrets",
+		NewList:  func() ctrlclient.ObjectList { return &secretsv1beta1.VaultKubernetesSecretList{} },
+	},
+	{
+		Kind:     "VaultLDAPSecret",
+		Resource: "vaultldapsecrets",
+		NewList:  func() ctrlclient.ObjectList { return &secretsv1beta1.VaultLDAPSecretList{} },
+	},
+	{
+		Kind:     "VaultMongoDBAtlasSecret",
+		Resource: "vaultmongodbatlassecrets",
+		NewList:  func() ctrlclient.ObjectList { return &secretsv1beta1.VaultMongoDBAtlasSecretList{} },
+	},
+	{
+		Kind:     "VaultNomadSecret",
+		Resource: "vaultnomadsecrets",
+		NewList:  func() ctrlclient.ObjectList { return &secretsv1beta1.VaultNomadSecretList{} },
+	},
+	{
+		Kind:     "VaultRabbitMQSecret",
+		Resource: "vaultrabbitmqsecrets",
+		NewList:  func() ctrlclient.ObjectList { return &secretsv1beta1.VaultRabbitMQSecretList{} },
+	},
+	{
+		Kind:     "VaultTerraformCloudSecret",
+		Resource: "vaultterraformcloudsecrets",
+		NewList:  func() ctrlclient.ObjectList { return &secretsv1beta1.VaultTerraformCloudSecretList{} },
+	},
+}
+
+// List returns the Items of src in namespace, all namespaces if empty.
+func List(ctx context.Context, c ctrlclient.Reader, src Source, namespace string) ([]Item, error) {
+	list := src.NewList()
+	if err := c.List(ctx, list, ctrlclient.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", src.Kind, err)
+	}
+
+	objs, err := meta.ExtractList(list)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []Item
+	for _, obj := range objs {
+		if item, ok := itemOf(src.Kind, obj); ok {
+			ret = append(ret, item)
+		}
+	}
+	return ret, nil
+}
+
+// Sort items soonest expiration first.
+func Sort(items []Item) {
+	slices.SortStableFunc(items, func(a, b Item) int {
+		return cmp.Or(
+			a.ExpiresAt.Compare(b.ExpiresAt),
+			cmp.Compare(a.Namespace, b.Namespace),
+			cmp.Compare(a.Kind, b.Kind),
+			cmp.Compare(a.Name, b.Name),
+		)
+	})
+}
+
+// itemOf returns the Item of obj, false if it does not hold any expiring
+// credential, e.g. it has not been synced yet.
+func itemOf(kind string, obj runtime.Object) (Item, bool) {
+	var status *secretsv1beta1.VaultLeasedSecretStatus
+	switch t := obj.(type) {
+	case *secretsv1beta1.VaultPKISecret:
+		if t.Status.Expiration <= 0 {
+			return Item{}, false
+		}
+		return newItem(kind, t, TypeCertificate, t.Status.SerialNumber,
+			time.Unix(t.Status.Expiration, 0)), true
+	case *secretsv1beta1.VaultDynamicSecret:
+		return leaseItem(kind, t, t.Status.SecretLease, t.Status.LastRenewalTime)
+	case *secretsv1beta1.VaultConsulSecret:
+		status = &t.Status.VaultLeasedSecretStatus
+	case *secretsv1beta1.VaultGenericSecret:
+		status = &t.Status.VaultLeasedSecretStatus
+	case *secretsv1beta1.VaultIdentityToken:
+		status = &t.Status.VaultLeasedSecretStatus
+	case *secretsv1beta1.VaultKubernetesSecret:
+		status = &t.Status.VaultLeasedSecretStatus
+	case *secretsv1beta1.VaultLDAPSecret:
+		status = &t.Status.VaultLeasedSecretStatus
+	case *secretsv1beta1.VaultMongoDBAtlasSecret:
+		status = &t.Status.VaultLeasedSecretStatus
+	case *secretsv1beta1.VaultNomadSecret:
+		status = &t.Status.VaultLeasedSecretStatus
+	case *secretsv1beta1.VaultRabbitMQSecret:
+		status = &t.Status.VaultLeasedSecretStatus
+	case *secretsv1beta1.VaultTerraformCloudSecret:
+		status = &t.Status.VaultLeasedSecretStatus
+	default:
+		return Item{}, false
+	}
+
+	return leaseItem(kind, obj.(ctrlclient.Object), status.SecretLease, status.LastRenewalTime)
+}
+
+// leaseItem returns the Item of lease, which expires LeaseDuration seconds
+// after it was last renewed. Leases without a duration never expire, e.g.
+// those of static credentials.
+func leaseItem(kind string, obj ctrlclient.Object, lease secretsv1beta1.VaultSecretLease, lastRenewalTime int64) (Item, bool) {
+	if lease.LeaseDuration <= 0 || lastRenewalTime <= 0 {
+		return Item{}, false
+	}
+
+	return newItem(kind, obj, TypeLease, lease.ID,
+		time.Unix(lastRenewalTime, 0).Add(time.Duration(lease.LeaseDuration)*time.Second)), true
+}
+
+func newItem(kind string, obj ctrlclient.Object, typ, id string, expiresAt time.Time) Item {
+	return Item{
+		Kind:      kind,
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+		Type:      typ,
+		ID:        id,
+		ExpiresAt: expiresAt.UTC(),
+	}
+}
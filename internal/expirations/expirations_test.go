@@ -0,0 +1,105 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package expirations
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/internal/testutils"
+)
+
+func TestList(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	renewed := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := testutils.NewFakeClientBuilder().WithObjects(
+		&secretsv1beta1.VaultPKISecret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "baz", Name: "cert"},
+			Status: secretsv1beta1.VaultPKISecretStatus{
+				SerialNumber: "01:02",
+				Expiration:   renewed.Add(time.Hour).Unix(),
+			},
+		},
+		// not issued yet.
+		&secretsv1beta1.VaultPKISecret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "baz", Name: "pending"},
+		},
+		&secretsv1beta1.VaultDynamicSecret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "qux", Name: "db"},
+			Status: secretsv1beta1.VaultDynamicSecretStatus{
+				LastRenewalTime: renewed.Unix(),
+				SecretLease: secretsv1beta1.VaultSecretLease{
+					ID:            "database/creds/app/1",
+					LeaseDuration: 600,
+				},
+			},
+		},
+		// static credentials have no lease duration.
+		&secretsv1beta1.VaultDynamicSecret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "qux", Name: "static"},
+			Status: secretsv1beta1.VaultDynamicSecretStatus{
+				LastRenewalTime: renewed.Unix(),
+			},
+		},
+		&secretsv1beta1.VaultConsulSecret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "baz", Name: "consul"},
+			Status: secretsv1beta1.VaultConsulSecretStatus{
+				VaultLeasedSecretStatus: secretsv1beta1.VaultLeasedSecretStatus{
+					LastRenewalTime: renewed.Unix(),
+					SecretLease: secretsv1beta1.VaultSecretLease{
+						ID:            "consul/creds/app/1",
+						LeaseDuration: 60,
+					},
+				},
+			},
+		},
+	).Build()
+
+	var got []Item
+	for _, src := range Sources {
+		items, err := List(ctx, c, src, "")
+		require.NoError(t, err)
+		got = append(got, items...)
+	}
+	Sort(got)
+
+	assert.Equal(t, []Item{
+		{
+			Kind:      "VaultConsulSecret",
+			Namespace: "baz",
+			Name:      "consul",
+			Type:      TypeLease,
+			ID:        "consul/creds/app/1",
+			ExpiresAt: renewed.Add(time.Minute),
+		},
+		{
+			Kind:      "VaultDynamicSecret",
+			Namespace: "qux",
+			Name:      "db",
+			Type:      TypeLease,
+			ID:        "database/creds/app/1",
+			ExpiresAt: renewed.Add(10 * time.Minute),
+		},
+		{
+			Kind:      "VaultPKISecret",
+			Namespace: "baz",
+			Name:      "cert",
+			Type:      TypeCertificate,
+			ID:        "01:02",
+			ExpiresAt: renewed.Add(time.Hour),
+		},
+	}, got)
+
+	got, err := List(ctx, c, Sources[0], "qux")
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
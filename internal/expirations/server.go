@@ -0,0 +1,263 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package expirations
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	authnv1 "k8s.io/api/authentication/v1"
+	authzv1 "k8s.io/api/authorization/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/consts"
+)
+
+// Path of the expirations endpoint.
+const Path = "/v1/expirations"
+
+var (
+	_ manager.Runnable               = (*Server)(nil)
+	_ manager.LeaderElectionRunnable = (*Server)(nil)
+)
+
+// Response is the body returned by the expirations endpoint.
+type Response struct {
+	// Items sorted soonest expiration first.
+	Items []Item `json:"items"`
+}
+
+// Server serves the expirations of all the certificates and leases that the
+// requester is allowed to list. Requests are authenticated with a bearer token
+// by way of the Kubernetes TokenReview API, and the requester's access to
+// each Kind and namespace is checked with the SubjectAccessReview API.
+//
+// The endpoint supports the following query parameters:
+//   - namespace: only list the Items in the namespace.
+//   - within: only list the Items expiring within the duration, e.g. 168h.
+type Server struct {
+	// Addr is the TCP address to listen on.
+	Addr string
+	// Client used to list the custom resources, and to review the requester's
+	// token and access.
+	Client ctrlclient.Client
+	// Audiences that the requester's token must be issued for, any audience
+	// accepted by the Kubernetes API server when empty.
+	Audiences []string
+	// TLSConfig of the server, it is required.
+	TLSConfig *tls.Config
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. The data is
+// read from the custom resources, so every replica can serve it.
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// Start the server, blocking until ctx is done.
+func (s *Server) Start(ctx context.Context) error {
+	if s.TLSConfig == nil {
+		return errors.New("expirations server requires a TLS config")
+	}
+
+	logger := log.FromContext(ctx).WithName("expirations")
+	ln, err := tls.Listen("tcp", s.Addr, s.TLSConfig)
+	if err != nil {
+		return err
+	}
+
+	srv := &http.Server{
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext: func(net.Listener) context.Context {
+			return ctx
+		},
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			logger.Error(err, "Failed to shutdown the expirations server")
+		}
+	}()
+
+	logger.Info("Starting the expirations server", "addr", ln.Addr().String())
+	if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+// Handler returns the server's http.Handler.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+Path, s.handleList)
+	return mux
+}
+
+func (s *Server) handleList(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	logger := log.FromContext(ctx).WithName("expirations")
+
+	var within time.Duration
+	if v := req.URL.Query().Get("within"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			http.Error(w, fmt.Sprintf("invalid within %q", v), http.StatusBadRequest)
+			return
+		}
+		within = d
+	}
+	namespace := req.URL.Query().Get("namespace")
+
+	user, err := s.authenticate(ctx, req)
+	if err != nil {
+		logger.V(consts.LogLevelDebug).Info("Authentication failed", "err", err)
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	items, err := s.list(ctx, user, namespace)
+	if err != nil {
+		logger.Error(err, "Failed to list the expirations", "user", user.Username)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	if within > 0 {
+		deadline := time.Now().Add(within)
+		var filtered []Item
+		for _, item := range items {
+			if !item.ExpiresAt.After(deadline) {
+				filtered = append(filtered, item)
+			}
+		}
+		items = filtered
+	}
+
+	Sort(items)
+	if items == nil {
+		items = []Item{}
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(&Response{Items: items}); err != nil {
+		logger.Error(err, "Failed to write the response")
+	}
+}
+
+// list returns the Items in namespace, all namespaces if empty, that user is
+// allowed to list. For every Kind, all its Items are returned if user may list
+// it in the requested namespace, otherwise only those in the namespaces in
+// which user may list it are.
+func (s *Server) list(ctx context.Context, user *authnv1.UserInfo, namespace string) ([]Item, error) {
+	var ret []Item
+	for _, src := range Sources {
+		allowed, err := s.allowed(ctx, user, src.Resource, namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		items, err := List(ctx, s.Client, src, namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		if allowed {
+			ret = append(ret, items...)
+			continue
+		}
+
+		if namespace != "" {
+			continue
+		}
+
+		namespaces := make(map[string]bool)
+		for _, item := range items {
+			ok, seen := namespaces[item.Namespace]
+			if !seen {
+				if ok, err = s.allowed(ctx, user, src.Resource, item.Namespace); err != nil {
+					return nil, err
+				}
+				namespaces[item.Namespace] = ok
+			}
+			if ok {
+				ret = append(ret, item)
+			}
+		}
+	}
+
+	return ret, nil
+}
+
+// +kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+
+// authenticate returns the UserInfo of the request's bearer token.
+func (s *Server) authenticate(ctx context.Context, req *http.Request) (*authnv1.UserInfo, error) {
+	token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return nil, errors.New("no credentials provided")
+	}
+
+	tr := &authnv1.TokenReview{
+		Spec: authnv1.TokenReviewSpec{
+			Token:     token,
+			Audiences: s.Audiences,
+		},
+	}
+	if err := s.Client.Create(ctx, tr); err != nil {
+		return nil, fmt.Errorf("token review failed: %w", err)
+	}
+
+	if !tr.Status.Authenticated {
+		return nil, fmt.Errorf("token not authenticated: %s", tr.Status.Error)
+	}
+
+	return &tr.Status.User, nil
+}
+
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+// allowed returns true if user may list resource in namespace, all namespaces
+// if empty.
+func (s *Server) allowed(ctx context.Context, user *authnv1.UserInfo, resource, namespace string) (bool, error) {
+	extra := make(map[string]authzv1.ExtraValue, len(user.Extra))
+	for k, v := range user.Extra {
+		extra[k] = authzv1.ExtraValue(v)
+	}
+
+	sar := &authzv1.SubjectAccessReview{
+		Spec: authzv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &authzv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      "list",
+				Group:     secretsv1beta1.GroupVersion.Group,
+				Resource:  resource,
+			},
+			User:   user.Username,
+			Groups: user.Groups,
+			UID:    user.UID,
+			Extra:  extra,
+		},
+	}
+	if err := s.Client.Create(ctx, sar); err != nil {
+		return false, fmt.Errorf("subject access review failed: %w", err)
+	}
+
+	return sar.Status.Allowed, nil
+}
@@ -0,0 +1,153 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package expirations
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authnv1 "k8s.io/api/authentication/v1"
+	authzv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/internal/testutils"
+)
+
+func TestServer_Handler(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().Truncate(time.Second).UTC()
+	newPKISecret := func(namespace, name string, expiresIn time.Duration) *secretsv1beta1.VaultPKISecret {
+		return &secretsv1beta1.VaultPKISecret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Status: secretsv1beta1.VaultPKISecretStatus{
+				Expiration: now.Add(expiresIn).Unix(),
+			},
+		}
+	}
+	newItem := func(namespace, name string, expiresIn time.Duration) Item {
+		return Item{
+			Kind:      "VaultPKISecret",
+			Namespace: namespace,
+			Name:      name,
+			Type:      TypeCertificate,
+			ExpiresAt: now.Add(expiresIn),
+		}
+	}
+
+	// tokens by username, the admin may list everything, the dev only in the
+	// dev namespace.
+	tokens := map[string]string{
+		"admin-token": "admin",
+		"dev-token":   "dev",
+	}
+	c := testutils.NewFakeClientBuilder().
+		WithObjects(
+			newPKISecret("dev", "api", 48*time.Hour),
+			newPKISecret("dev", "web", 30*24*time.Hour),
+			newPKISecret("prod", "api", 24*time.Hour),
+		).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, client ctrlclient.WithWatch, obj ctrlclient.Object, opts ...ctrlclient.CreateOption) error {
+				switch t := obj.(type) {
+				case *authnv1.TokenReview:
+					if user, ok := tokens[t.Spec.Token]; ok {
+						t.Status.Authenticated = true
+						t.Status.User.Username = user
+					}
+				case *authzv1.SubjectAccessReview:
+					t.Status.Allowed = t.Spec.User == "admin" ||
+						(t.Spec.User == "dev" && t.Spec.ResourceAttributes.Namespace == "dev")
+				default:
+					return client.Create(ctx, obj, opts...)
+				}
+				return nil
+			},
+		}).Build()
+
+	tests := []struct {
+		name       string
+		token      string
+		query      string
+		wantStatus int
+		wantItems  []Item
+	}{
+		{
+			name:       "admin",
+			token:      "admin-token",
+			wantStatus: http.StatusOK,
+			wantItems: []Item{
+				newItem("prod", "api", 24*time.Hour),
+				newItem("dev", "api", 48*time.Hour),
+				newItem("dev", "web", 30*24*time.Hour),
+			},
+		},
+		{
+			name:       "admin-within",
+			token:      "admin-token",
+			query:      "?within=168h",
+			wantStatus: http.StatusOK,
+			wantItems: []Item{
+				newItem("prod", "api", 24*time.Hour),
+				newItem("dev", "api", 48*time.Hour),
+			},
+		},
+		{
+			name:       "dev",
+			token:      "dev-token",
+			wantStatus: http.StatusOK,
+			wantItems: []Item{
+				newItem("dev", "api", 48*time.Hour),
+				newItem("dev", "web", 30*24*time.Hour),
+			},
+		},
+		{
+			name:       "dev-denied-namespace",
+			token:      "dev-token",
+			query:      "?namespace=prod",
+			wantStatus: http.StatusOK,
+			wantItems:  []Item{},
+		},
+		{
+			name:       "invalid-within",
+			token:      "dev-token",
+			query:      "?within=week",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "unauthenticated",
+			token:      "invalid",
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := &Server{Client: c}
+			req := httptest.NewRequest(http.MethodGet, Path+tt.query, nil)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			rec := httptest.NewRecorder()
+			s.Handler().ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantStatus, rec.Code, rec.Body.String())
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var resp Response
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			assert.Equal(t, tt.wantItems, resp.Items)
+		})
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package featuregates provides the feature gates of the Operator, such that
+// experimental capabilities can ship disabled, and be enabled per cluster
+// without a separate build. The gates are set from a single flag, e.g.
+// --feature-gates=EventDrivenSync=false, and are exported in the
+// feature_enabled metric.
+package featuregates
+
+import (
+	"flag"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	vsometrics "github.com/hashicorp/vault-secrets-operator/internal/metrics"
+)
+
+// Feature is the name of a feature gate.
+type Feature string
+
+// Stage is the maturity of a feature.
+type Stage string
+
+const (
+	// Alpha features are disabled by default, they may change or be removed
+	// without notice.
+	Alpha Stage = "ALPHA"
+	// Beta features are usually enabled by default, they are well tested, but
+	// may still change in a subsequent release.
+	Beta Stage = "BETA"
+)
+
+// FeatureSpec is the default state and the stage of a feature.
+type FeatureSpec struct {
+	Default bool
+	Stage   Stage
+}
+
+const (
+	// EventDrivenSync enables the syncing of VaultStaticSecrets upon Vault
+	// events, i.e. when SyncConfig.InstantUpdates is set.
+	EventDrivenSync Feature = "EventDrivenSync"
+	// Secretless enables the secretless server, which delivers the data of the
+	// destinations with secretless delivery to the secretless agent, see
+	// --secretless-bind-address.
+	Secretless Feature = "Secretless"
+	// StandbyRenewals enables the renewal of the VaultDynamicSecrets' leases by
+	// the standby replicas.
+	StandbyRenewals Feature = "StandbyRenewals"
+	// DualWrite enables the dual-write of the destination Secrets to a target
+	// cluster, see --dual-write-kubeconfig-secret.
+	DualWrite Feature = "DualWrite"
+	// CSIProvider enables the Secrets Store CSI driver provider mode, see
+	// --csi-provider-socket.
+	CSIProvider Feature = "CSIProvider"
+)
+
+// defaultFeatures are all the features known to the Operator.
+var defaultFeatures = map[Feature]FeatureSpec{
+	EventDrivenSync: {Default: true, Stage: Beta},
+	Secretless:      {Default: false, Stage: Alpha},
+	StandbyRenewals: {Default: false, Stage: Alpha},
+	DualWrite:       {Default: false, Stage: Alpha},
+	CSIProvider:     {Default: false, Stage: Alpha},
+}
+
+// DefaultGates are the feature gates of the Operator.
+var DefaultGates = NewGates(defaultFeatures)
+
+func init() {
+	metrics.Registry.MustRegister(DefaultGates)
+}
+
+// Enabled returns true if the feature is enabled in the DefaultGates.
+func Enabled(f Feature) bool {
+	return DefaultGates.Enabled(f)
+}
+
+var featureEnabledDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(vsometrics.Namespace, "", "feature_enabled"),
+	"Set to 1 when the feature is enabled",
+	[]string{"name", "stage"}, nil,
+)
+
+var (
+	_ prometheus.Collector = (*Gates)(nil)
+	_ flag.Value           = (*Gates)(nil)
+)
+
+// Gates holds the state of a set of known features. It implements flag.Value,
+// and prometheus.Collector.
+type Gates struct {
+	known   map[Feature]FeatureSpec
+	enabled map[Feature]bool
+	mu      sync.RWMutex
+}
+
+// NewGates returns Gates for the known features, all in their default state.
+func NewGates(known map[Feature]FeatureSpec) *Gates {
+	return &Gates{
+		known:   maps.Clone(known),
+		enabled: make(map[Feature]bool),
+	}
+}
+
+// Enabled returns true if the feature is enabled. Unknown features are always
+// disabled.
+func (g *Gates) Enabled(f Feature) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	if v, ok := g.enabled[f]; ok {
+		return v
+	}
+	return g.known[f].Default
+}
+
+// Set parses a comma separated list of Feature=bool pairs, e.g.
+// "EventDrivenSync=false,Foo=true". Nothing is set if any pair is invalid, or
+// if it refers to an unknown feature.
+func (g *Gates) Set(value string) error {
+	enabled := make(map[Feature]bool)
+	for _, s := range strings.Split(value, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+
+		k, v, ok := strings.Cut(s, "=")
+		if !ok {
+			return fmt.Errorf("missing bool value for feature gate %q", s)
+		}
+
+		f := Feature(strings.TrimSpace(k))
+		if _, ok := g.known[f]; !ok {
+			return fmt.Errorf("unknown feature gate %q", f)
+		}
+
+		b, err := strconv.ParseBool(strings.TrimSpace(v))
+		if err != nil {
+			return fmt.Errorf("invalid value %q for feature gate %q: %w", v, f, err)
+		}
+		enabled[f] = b
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	maps.Copy(g.enabled, enabled)
+	return nil
+}
+
+// String returns the features that were explicitly set, in the format accepted
+// by Set, sorted by name.
+func (g *Gates) String() string {
+	if g == nil {
+		return ""
+	}
+
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	var pairs []string
+	for _, f := range slices.Sorted(maps.Keys(g.enabled)) {
+		pairs = append(pairs, fmt.Sprintf("%s=%t", f, g.enabled[f]))
+	}
+	return strings.Join(pairs, ",")
+}
+
+// KnownFeatures returns the description of all the known features, sorted by
+// name, e.g. "EventDrivenSync=true|false (BETA - default=true)".
+func (g *Gates) KnownFeatures() []string {
+	var ret []string
+	for _, f := range slices.Sorted(maps.Keys(g.known)) {
+		spec := g.known[f]
+		ret = append(ret, fmt.Sprintf("%s=true|false (%s - default=%t)", f, spec.Stage, spec.Default))
+	}
+	return ret
+}
+
+// Describe implements prometheus.Collector.
+func (g *Gates) Describe(ch chan<- *prometheus.Desc) {
+	ch <- featureEnabledDesc
+}
+
+// Collect implements prometheus.Collector.
+func (g *Gates) Collect(ch chan<- prometheus.Metric) {
+	for f, spec := range g.known {
+		var v float64
+		if g.Enabled(f) {
+			v = 1
+		}
+		ch <- prometheus.MustNewConstMetric(featureEnabledDesc, prometheus.GaugeValue, v, string(f), string(spec.Stage))
+	}
+}
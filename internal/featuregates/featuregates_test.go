@@ -0,0 +1,127 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package featuregates
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testFeatures = map[Feature]FeatureSpec{
+	"AlphaFeature": {Default: false, Stage: Alpha},
+	"BetaFeature":  {Default: true, Stage: Beta},
+}
+
+func TestGates_Set(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		value      string
+		wantAlpha  bool
+		wantBeta   bool
+		wantString string
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name:     "defaults",
+			value:    "",
+			wantBeta: true,
+			wantErr:  assert.NoError,
+		},
+		{
+			name:       "set",
+			value:      "BetaFeature=false, AlphaFeature=true",
+			wantAlpha:  true,
+			wantString: "AlphaFeature=true,BetaFeature=false",
+			wantErr:    assert.NoError,
+		},
+		{
+			name:     "unknown",
+			value:    "AlphaFeature=true,Foo=true",
+			wantBeta: true,
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.EqualError(t, err, `unknown feature gate "Foo"`, i...)
+			},
+		},
+		{
+			name:     "missing-value",
+			value:    "AlphaFeature",
+			wantBeta: true,
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.EqualError(t, err, `missing bool value for feature gate "AlphaFeature"`, i...)
+			},
+		},
+		{
+			name:     "invalid-value",
+			value:    "AlphaFeature=yes",
+			wantBeta: true,
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.ErrorContains(t, err, `invalid value "yes" for feature gate "AlphaFeature"`, i...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			g := NewGates(testFeatures)
+			tt.wantErr(t, g.Set(tt.value))
+			assert.Equal(t, tt.wantAlpha, g.Enabled("AlphaFeature"))
+			assert.Equal(t, tt.wantBeta, g.Enabled("BetaFeature"))
+			assert.False(t, g.Enabled("Foo"))
+			assert.Equal(t, tt.wantString, g.String())
+		})
+	}
+}
+
+func TestGates_KnownFeatures(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{
+		"AlphaFeature=true|false (ALPHA - default=false)",
+		"BetaFeature=true|false (BETA - default=true)",
+	}, NewGates(testFeatures).KnownFeatures())
+}
+
+func TestGates_Collect(t *testing.T) {
+	t.Parallel()
+
+	g := NewGates(testFeatures)
+	require.NoError(t, g.Set("AlphaFeature=true,BetaFeature=false"))
+
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(g)
+	mfs, err := reg.Gather()
+	require.NoError(t, err)
+	require.Len(t, mfs, 1)
+	assert.Equal(t, "vso_feature_enabled", mfs[0].GetName())
+
+	got := make(map[string]float64)
+	for _, m := range mfs[0].GetMetric() {
+		labels := make(map[string]string)
+		for _, l := range m.GetLabel() {
+			labels[l.GetName()] = l.GetValue()
+		}
+		got[labels["name"]+"/"+labels["stage"]] = m.GetGauge().GetValue()
+	}
+	assert.Equal(t, map[string]float64{
+		"AlphaFeature/ALPHA": 1,
+		"BetaFeature/BETA":   0,
+	}, got)
+}
+
+func Test_defaultFeatures(t *testing.T) {
+	t.Parallel()
+
+	// the experimental features must be opt-in.
+	for _, f := range []Feature{Secretless, StandbyRenewals, DualWrite, CSIProvider} {
+		assert.Equal(t, FeatureSpec{Default: false, Stage: Alpha}, defaultFeatures[f], f)
+		assert.False(t, NewGates(defaultFeatures).Enabled(f), f)
+	}
+	assert.True(t, NewGates(defaultFeatures).Enabled(EventDrivenSync))
+}
@@ -0,0 +1,322 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package injectoradoption helps with migrating workloads from the Vault Agent
+// injector to the Operator. It scans the Pods that carry the injector's
+// annotations, reports the secrets that they consume, and optionally generates
+// the Operator's custom resources that approximate them.
+//
+// The generated resources are a starting point only: the injector's
+// consul-template templates cannot be translated, and the secrets are synced
+// to K8s Secrets rather than rendered to files, so the workloads must be
+// updated to consume them.
+//
+// It is meant to be run with the credentials of the current kubeconfig, the
+// Operator's own role does not grant access to the Pods.
+package injectoradoption
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/consts"
+)
+
+// The Vault Agent injector's annotations.
+const (
+	AnnotationAgentInject     = "vault.hashicorp.com/agent-inject"
+	AnnotationSecretPrefix    = "vault.hashicorp.com/agent-inject-secret-"
+	AnnotationTemplatePrefix  = "vault.hashicorp.com/agent-inject-template-"
+	AnnotationRole            = "vault.hashicorp.com/role"
+	AnnotationAuthPath        = "vault.hashicorp.com/auth-path"
+	AnnotationVaultNamespace  = "vault.hashicorp.com/namespace"
+	AnnotationPrePopulateOnly = "vault.hashicorp.com/agent-pre-populate-only"
+
+	defaultAuthMount = "kubernetes"
+	// generatedNameSuffix is appended to the names of the generated resources.
+	generatedNameSuffix = "-injector"
+)
+
+// Secret injected by the Vault Agent injector.
+type Secret struct {
+	// Name of the secret, i.e. the name of the file it is rendered to.
+	Name string `json:"name"`
+	// Path of the secret in Vault.
+	Path string `json:"path"`
+	// Template that renders the secret, if any.
+	Template string `json:"template,omitempty"`
+}
+
+// Workload whose Pods are injected by the Vault Agent injector.
+type Workload struct {
+	// Kind of the workload, e.g. Deployment, or Pod for Pods without a known
+	// owner.
+	Kind string `json:"kind"`
+	// Namespace of the workload.
+	Namespace string `json:"namespace"`
+	// Name of the workload.
+	Name string `json:"name"`
+	// ServiceAccount of the workload's Pods.
+	ServiceAccount string `json:"serviceAccount"`
+	// Role of the Vault Kubernetes auth method.
+	Role string `json:"role,omitempty"`
+	// AuthMount of the Vault Kubernetes auth method.
+	AuthMount string `json:"authMount"`
+	// VaultNamespace that the Vault Agent authenticates to.
+	VaultNamespace string `json:"vaultNamespace,omitempty"`
+	// Secrets injected in the workload's Pods, sorted by name.
+	Secrets []Secret `json:"secrets"`
+	// Warnings about the parts of the injector's configuration that cannot be
+	// approximated by the Operator.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// Report of the workloads that are injected by the Vault Agent injector.
+type Report struct {
+	// Workloads sorted by namespace, kind, and name.
+	Workloads []Workload `json:"workloads"`
+	// Resources generated for the workloads, if requested.
+	Resources []client.Object `json:"resources,omitempty"`
+}
+
+// Scan returns the Report of the Pods in namespace that are injected by the
+// Vault Agent injector. All namespaces are scanned when namespace is empty.
+// The Pods of a same workload are reported once.
+func Scan(ctx context.Context, c client.Reader, namespace string) (*Report, error) {
+	var pods corev1.PodList
+	if err := c.List(ctx, &pods, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list the Pods: %w", err)
+	}
+
+	workloads := make(map[string]Workload)
+	for _, pod := range pods.Items {
+		w, ok := workloadFromPod(&pod)
+		if !ok {
+			continue
+		}
+		key := w.Namespace + "/" + w.Kind + "/" + w.Name
+		if _, ok := workloads[key]; !ok {
+			workloads[key] = w
+		}
+	}
+
+	report := &Report{}
+	for _, k := range slices.Sorted(maps.Keys(workloads)) {
+		report.Workloads = append(report.Workloads, workloads[k])
+	}
+	return report, nil
+}
+
+// workloadFromPod returns the Workload of pod, it returns false if the pod is
+// not injected.
+func workloadFromPod(pod *corev1.Pod) (Workload, bool) {
+	annotations := pod.GetAnnotations()
+	if annotations[AnnotationAgentInject] != "true" {
+		return Workload{}, false
+	}
+
+	kind, name := podOwner(pod)
+	w := Workload{
+		Kind:           kind,
+		Namespace:      pod.Namespace,
+		Name:           name,
+		ServiceAccount: pod.Spec.ServiceAccountName,
+		Role:           annotations[AnnotationRole],
+		AuthMount:      authMount(annotations[AnnotationAuthPath]),
+		VaultNamespace: annotations[AnnotationVaultNamespace],
+	}
+	if w.ServiceAccount == "" {
+		w.ServiceAccount = "default"
+	}
+
+	for _, k := range slices.Sorted(maps.Keys(annotations)) {
+		secretName, ok := strings.CutPrefix(k, AnnotationSecretPrefix)
+		if !ok || secretName == "" {
+			continue
+		}
+		s := Secret{
+			Name:     secretName,
+			Path:     annotations[k],
+			Template: annotations[AnnotationTemplatePrefix+secretName],
+		}
+		if s.Template != "" {
+			w.Warnings = append(w.Warnings, fmt.Sprintf(
+				"the template of secret %q must be ported to a transformation template", s.Name))
+		}
+		w.Secrets = append(w.Secrets, s)
+	}
+
+	if annotations[AnnotationPrePopulateOnly] != "true" {
+		w.Warnings = append(w.Warnings,
+			"the Vault Agent sidecar keeps the secrets up to date, "+
+				"the workload must consume the synced Secrets, or be restarted on rotation")
+	}
+
+	return w, true
+}
+
+// podOwner returns the kind and name of the workload that owns pod. The
+// Deployment of a ReplicaSet is inferred from the pod-template-hash label.
+func podOwner(pod *corev1.Pod) (string, string) {
+	ref := metav1.GetControllerOf(pod)
+	if ref == nil {
+		return "Pod", pod.Name
+	}
+
+	if hash := pod.GetLabels()["pod-template-hash"]; ref.Kind == "ReplicaSet" && hash != "" {
+		if name, ok := strings.CutSuffix(ref.Name, "-"+hash); ok {
+			return "Deployment", name
+		}
+	}
+	return ref.Kind, ref.Name
+}
+
+// authMount returns the mount of the auth method from the injector's auth
+// path, e.g. auth/kubernetes.
+func authMount(authPath string) string {
+	mount := strings.Trim(strings.TrimPrefix(strings.Trim(authPath, "/"), "auth/"), "/")
+	if mount == "" {
+		return defaultAuthMount
+	}
+	return mount
+}
+
+// Generate returns the resources that approximate the injection of the
+// workloads: a VaultAuth per workload, and a VaultStaticSecret, or a
+// VaultDynamicSecret, per injected secret.
+func Generate(workloads []Workload) []client.Object {
+	var ret []client.Object
+	for _, w := range workloads {
+		authName := resourceName(w.Name)
+		ret = append(ret, &secretsv1beta1.VaultAuth{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: secretsv1beta1.GroupVersion.String(),
+				Kind:       "VaultAuth",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      authName,
+				Namespace: w.Namespace,
+			},
+			Spec: secretsv1beta1.VaultAuthSpec{
+				Method:    "kubernetes",
+				Mount:     w.AuthMount,
+				Namespace: w.VaultNamespace,
+				Kubernetes: &secretsv1beta1.VaultAuthConfigKubernetes{
+					Role:           w.Role,
+					ServiceAccount: w.ServiceAccount,
+				},
+			},
+		})
+
+		var targets []secretsv1beta1.RolloutRestartTarget
+		switch w.Kind {
+		case "Deployment", "StatefulSet", "DaemonSet":
+			targets = []secretsv1beta1.RolloutRestartTarget{
+				{
+					Kind: w.Kind,
+					Name: w.Name,
+				},
+			}
+		}
+
+		for _, s := range w.Secrets {
+			ret = append(ret, generateSecret(w, s, authName, targets))
+		}
+	}
+	return ret
+}
+
+// generateSecret returns the resource that syncs the secret s of workload w.
+// Secrets read from the creds endpoint of a secrets engine are dynamic, KV v2
+// secrets are recognized from the data segment of their path, all others are
+// assumed to be KV v1 secrets.
+func generateSecret(w Workload, s Secret, authName string,
+	targets []secretsv1beta1.RolloutRestartTarget,
+) client.Object {
+	objMeta := metav1.ObjectMeta{
+		Name:      resourceName(w.Name + "-" + s.Name),
+		Namespace: w.Namespace,
+	}
+	dest := secretsv1beta1.Destination{
+		Name:   objMeta.Name,
+		Create: true,
+	}
+
+	p := strings.Trim(s.Path, "/")
+	if mount, path, ok := strings.Cut(p, "/creds/"); ok {
+		return &secretsv1beta1.VaultDynamicSecret{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: secretsv1beta1.GroupVersion.String(),
+				Kind:       "VaultDynamicSecret",
+			},
+			ObjectMeta: objMeta,
+			Spec: secretsv1beta1.VaultDynamicSecretSpec{
+				VaultAuthRef:          authName,
+				Mount:                 mount,
+				Path:                  "creds/" + path,
+				Destination:           dest,
+				RolloutRestartTargets: targets,
+			},
+		}
+	}
+
+	spec := secretsv1beta1.VaultStaticSecretSpec{
+		VaultAuthRef:          authName,
+		Type:                  consts.KVSecretTypeV1,
+		Destination:           dest,
+		RolloutRestartTargets: targets,
+	}
+	if mount, path, ok := strings.Cut(p, "/data/"); ok {
+		spec.Type = consts.KVSecretTypeV2
+		spec.Mount, spec.Path = mount, path
+	} else {
+		spec.Mount, spec.Path, _ = strings.Cut(p, "/")
+	}
+
+	return &secretsv1beta1.VaultStaticSecret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: secretsv1beta1.GroupVersion.String(),
+			Kind:       "VaultStaticSecret",
+		},
+		ObjectMeta: objMeta,
+		Spec:       spec,
+	}
+}
+
+// Run scans the Pods in namespace, and writes the Report to w as YAML. The
+// generated resources are included in the Report if generate is true.
+func Run(ctx context.Context, c client.Reader, namespace string, generate bool, w io.Writer) error {
+	report, err := Scan(ctx, c, namespace)
+	if err != nil {
+		return err
+	}
+	if generate {
+		report.Resources = Generate(report.Workloads)
+	}
+
+	b, err := yaml.Marshal(report)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// resourceName returns a valid resource name derived from name.
+func resourceName(name string) string {
+	name = strings.ToLower(strings.NewReplacer("_", "-", ".", "-").Replace(name))
+	if n := 253 - len(generatedNameSuffix); len(name) > n {
+		name = strings.TrimRight(name[:n], "-")
+	}
+	return name + generatedNameSuffix
+}
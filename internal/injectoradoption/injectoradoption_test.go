@@ -0,0 +1,255 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package injectoradoption
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/consts"
+	"github.com/hashicorp/vault-secrets-operator/internal/testutils"
+)
+
+func newPod(name, namespace string, owner *metav1.OwnerReference, labels, annotations map[string]string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   namespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: corev1.PodSpec{
+			ServiceAccountName: "app",
+		},
+	}
+	if owner != nil {
+		pod.OwnerReferences = []metav1.OwnerReference{*owner}
+	}
+	return pod
+}
+
+func TestScan(t *testing.T) {
+	ctx := context.Background()
+	annotations := map[string]string{
+		AnnotationAgentInject:                "true",
+		AnnotationRole:                       "app",
+		AnnotationAuthPath:                   "auth/k8s",
+		AnnotationSecretPrefix + "db":        "database/creds/app",
+		AnnotationSecretPrefix + "config":    "secret/data/app/config",
+		AnnotationTemplatePrefix + "config":  `{{ with secret "secret/data/app/config" }}{{ .Data.data.key }}{{ end }}`,
+		AnnotationPrePopulateOnly:            "true",
+		"vault.hashicorp.com/agent-inject-x": "ignored",
+	}
+	rsOwner := &metav1.OwnerReference{
+		APIVersion: "apps/v1",
+		Kind:       "ReplicaSet",
+		Name:       "web-5d4f8",
+		Controller: ptr.To(true),
+	}
+	objs := []client.Object{
+		newPod("web-5d4f8-a", "tenant", rsOwner, map[string]string{"pod-template-hash": "5d4f8"}, annotations),
+		newPod("web-5d4f8-b", "tenant", rsOwner, map[string]string{"pod-template-hash": "5d4f8"}, annotations),
+		newPod("job", "tenant", nil, nil, map[string]string{
+			AnnotationAgentInject:         "true",
+			AnnotationSecretPrefix + "kv": "kv/job",
+		}),
+		newPod("plain", "tenant", nil, nil, nil),
+	}
+	c := testutils.NewFakeClientBuilder().WithObjects(objs...).Build()
+
+	report, err := Scan(ctx, c, "tenant")
+	require.NoError(t, err)
+	assert.Equal(t, []Workload{
+		{
+			Kind:           "Deployment",
+			Namespace:      "tenant",
+			Name:           "web",
+			ServiceAccount: "app",
+			Role:           "app",
+			AuthMount:      "k8s",
+			Secrets: []Secret{
+				{
+					Name:     "config",
+					Path:     "secret/data/app/config",
+					Template: `{{ with secret "secret/data/app/config" }}{{ .Data.data.key }}{{ end }}`,
+				},
+				{
+					Name: "db",
+					Path: "database/creds/app",
+				},
+			},
+			Warnings: []string{
+				`the template of secret "config" must be ported to a transformation template`,
+			},
+		},
+		{
+			Kind:           "Pod",
+			Namespace:      "tenant",
+			Name:           "job",
+			ServiceAccount: "app",
+			AuthMount:      "kubernetes",
+			Secrets: []Secret{
+				{
+					Name: "kv",
+					Path: "kv/job",
+				},
+			},
+			Warnings: []string{
+				"the Vault Agent sidecar keeps the secrets up to date, " +
+					"the workload must consume the synced Secrets, or be restarted on rotation",
+			},
+		},
+	}, report.Workloads)
+
+	report, err = Scan(ctx, c, "other")
+	require.NoError(t, err)
+	assert.Empty(t, report.Workloads)
+}
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	w := Workload{
+		Kind:           "Deployment",
+		Namespace:      "tenant",
+		Name:           "web",
+		ServiceAccount: "app",
+		Role:           "app",
+		AuthMount:      "k8s",
+		VaultNamespace: "ns1",
+		Secrets: []Secret{
+			{
+				Name: "db",
+				Path: "database/creds/app",
+			},
+			{
+				Name: "config",
+				Path: "/secret/data/app/config",
+			},
+			{
+				Name: "legacy_kv",
+				Path: "kv/app",
+			},
+		},
+	}
+	targets := []secretsv1beta1.RolloutRestartTarget{
+		{
+			Kind: "Deployment",
+			Name: "web",
+		},
+	}
+	typeMeta := func(kind string) metav1.TypeMeta {
+		return metav1.TypeMeta{
+			APIVersion: secretsv1beta1.GroupVersion.String(),
+			Kind:       kind,
+		}
+	}
+	dest := func(name string) secretsv1beta1.Destination {
+		return secretsv1beta1.Destination{
+			Name:   name,
+			Create: true,
+		}
+	}
+
+	assert.Equal(t, []client.Object{
+		&secretsv1beta1.VaultAuth{
+			TypeMeta: typeMeta("VaultAuth"),
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "web-injector",
+				Namespace: "tenant",
+			},
+			Spec: secretsv1beta1.VaultAuthSpec{
+				Method:    "kubernetes",
+				Mount:     "k8s",
+				Namespace: "ns1",
+				Kubernetes: &secretsv1beta1.VaultAuthConfigKubernetes{
+					Role:           "app",
+					ServiceAccount: "app",
+				},
+			},
+		},
+		&secretsv1beta1.VaultDynamicSecret{
+			TypeMeta: typeMeta("VaultDynamicSecret"),
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "web-db-injector",
+				Namespace: "tenant",
+			},
+			Spec: secretsv1beta1.VaultDynamicSecretSpec{
+				VaultAuthRef:          "web-injector",
+				Mount:                 "database",
+				Path:                  "creds/app",
+				Destination:           dest("web-db-injector"),
+				RolloutRestartTargets: targets,
+			},
+		},
+		&secretsv1beta1.VaultStaticSecret{
+			TypeMeta: typeMeta("VaultStaticSecret"),
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "web-config-injector",
+				Namespace: "tenant",
+			},
+			Spec: secretsv1beta1.VaultStaticSecretSpec{
+				VaultAuthRef:          "web-injector",
+				Mount:                 "secret",
+				Path:                  "app/config",
+				Type:                  consts.KVSecretTypeV2,
+				Destination:           dest("web-config-injector"),
+				RolloutRestartTargets: targets,
+			},
+		},
+		&secretsv1beta1.VaultStaticSecret{
+			TypeMeta: typeMeta("VaultStaticSecret"),
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "web-legacy-kv-injector",
+				Namespace: "tenant",
+			},
+			Spec: secretsv1beta1.VaultStaticSecretSpec{
+				VaultAuthRef:          "web-injector",
+				Mount:                 "kv",
+				Path:                  "app",
+				Type:                  consts.KVSecretTypeV1,
+				Destination:           dest("web-legacy-kv-injector"),
+				RolloutRestartTargets: targets,
+			},
+		},
+	}, Generate([]Workload{w}))
+}
+
+func TestRun(t *testing.T) {
+	ctx := context.Background()
+	c := testutils.NewFakeClientBuilder().WithObjects(
+		newPod("job", "tenant", nil, nil, map[string]string{
+			AnnotationAgentInject:         "true",
+			AnnotationPrePopulateOnly:     "true",
+			AnnotationSecretPrefix + "kv": "kv/job",
+		}),
+	).Build()
+
+	var buf bytes.Buffer
+	require.NoError(t, Run(ctx, c, "", false, &buf))
+	assert.Equal(t, `workloads:
+- authMount: kubernetes
+  kind: Pod
+  name: job
+  namespace: tenant
+  secrets:
+  - name: kv
+    path: kv/job
+  serviceAccount: app
+`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, Run(ctx, c, "", true, &buf))
+	assert.Contains(t, buf.String(), "resources:\n- apiVersion: secrets.hashicorp.com/v1beta1\n  kind: VaultAuth\n")
+	assert.Contains(t, buf.String(), "  kind: VaultStaticSecret\n")
+}
@@ -0,0 +1,203 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package issuance decouples the slow requests to the secret engines, e.g.
+// Azure service principal creation or large PKI issuance, from the reconcile
+// loop. The requests are run by a fixed pool of workers, so that a few slow
+// resources never block the shared workqueue of their controller.
+package issuance
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	// DefaultTimeout bounds a single issuance, if no timeout is configured.
+	DefaultTimeout = 5 * time.Minute
+	// DefaultQueueSize is the number of issuances that can be pending.
+	DefaultQueueSize = 1000
+	// PollInterval is the interval at which a resource should be requeued while
+	// its issuance is in progress.
+	PollInterval = 2 * time.Second
+)
+
+// ErrIssuing is returned by Do while the issuance is in progress.
+var ErrIssuing = errors.New("issuance in progress")
+
+// DefaultQueue runs the issuances of all the controllers. It is nil unless
+// async issuance workers have been configured on the Operator, otherwise all the
+// issuances are run inline.
+var DefaultQueue *Queue
+
+// Do runs fn on the DefaultQueue, see Queue.Do. If there is no DefaultQueue, fn
+// is run inline.
+func Do[T any](ctx context.Context, key string, generation int64, fn func(context.Context) (T, error)) (T, error) {
+	if DefaultQueue == nil {
+		return fn(ctx)
+	}
+
+	v, err := DefaultQueue.Do(ctx, key, generation, func(ctx context.Context) (any, error) {
+		return fn(ctx)
+	})
+	result, _ := v.(T)
+	return result, err
+}
+
+// Forget drops any issuance of key from the DefaultQueue. It should be called
+// when the resource is deleted.
+func Forget(key string) {
+	if DefaultQueue != nil {
+		DefaultQueue.Forget(key)
+	}
+}
+
+type job struct {
+	generation int64
+	fn         func(context.Context) (any, error)
+	ctx        context.Context
+	cancel     context.CancelFunc
+	done       bool
+	result     any
+	err        error
+}
+
+var _ manager.Runnable = (*Queue)(nil)
+
+// Queue runs the issuances in the background, with up to Workers of them
+// running concurrently. The result of an issuance is held until it is
+// collected by the next call to Do for the same key.
+type Queue struct {
+	Workers int
+	// Timeout bounds a single issuance, it defaults to DefaultTimeout.
+	Timeout time.Duration
+	mu      sync.Mutex
+	jobs    map[string]*job
+	pending chan *job
+}
+
+// NewQueue returns a Queue that runs up to workers issuances concurrently.
+func NewQueue(workers int, timeout time.Duration) *Queue {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Queue{
+		Workers: workers,
+		Timeout: timeout,
+		jobs:    make(map[string]*job),
+		pending: make(chan *job, DefaultQueueSize),
+	}
+}
+
+// Do returns the result of the issuance of key for the resource's generation.
+// The first call submits fn to the Queue, and returns ErrIssuing until the
+// issuance has completed, the next call then returns its result. If the
+// resource's generation has changed since the submission, the previous
+// issuance is canceled, and fn is submitted anew. When the Queue is full, fn
+// is run inline.
+func (q *Queue) Do(ctx context.Context, key string, generation int64, fn func(context.Context) (any, error)) (any, error) {
+	q.mu.Lock()
+	if j, ok := q.jobs[key]; ok {
+		if j.generation == generation {
+			if !j.done {
+				q.mu.Unlock()
+				return nil, ErrIssuing
+			}
+			delete(q.jobs, key)
+			q.mu.Unlock()
+			return j.result, j.err
+		}
+		j.cancel()
+		delete(q.jobs, key)
+	}
+
+	jobCtx, cancel := context.WithCancel(context.Background())
+	j := &job{
+		generation: generation,
+		fn:         fn,
+		ctx:        jobCtx,
+		cancel:     cancel,
+	}
+	select {
+	case q.pending <- j:
+		q.jobs[key] = j
+		q.mu.Unlock()
+		return nil, ErrIssuing
+	default:
+		cancel()
+		q.mu.Unlock()
+	}
+
+	log.FromContext(ctx).WithName("issuance").Info(
+		"Issuance queue is full, issuing inline", "key", key)
+	return fn(ctx)
+}
+
+// Forget cancels and drops any issuance of key.
+func (q *Queue) Forget(key string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if j, ok := q.jobs[key]; ok {
+		j.cancel()
+		delete(q.jobs, key)
+	}
+}
+
+// Start the Queue's workers, they are stopped when ctx is done.
+func (q *Queue) Start(ctx context.Context) error {
+	var wg sync.WaitGroup
+	for i := 0; i < q.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			q.work(ctx)
+		}()
+	}
+	<-ctx.Done()
+	wg.Wait()
+
+	return nil
+}
+
+// NeedLeaderElection returns false, since the Queue must be running for the
+// resources to be reconciled, whether leader election is enabled or not.
+func (q *Queue) NeedLeaderElection() bool {
+	return false
+}
+
+func (q *Queue) work(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case j := <-q.pending:
+			q.run(ctx, j)
+		}
+	}
+}
+
+func (q *Queue) run(ctx context.Context, j *job) {
+	// the issuance was canceled while it was pending.
+	if j.ctx.Err() != nil {
+		return
+	}
+
+	jobCtx, cancel := context.WithTimeout(j.ctx, q.Timeout)
+	defer cancel()
+	stop := context.AfterFunc(ctx, cancel)
+	defer stop()
+
+	result, err := j.fn(jobCtx)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	j.done = true
+	j.result = result
+	j.err = err
+}
@@ -0,0 +1,181 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package issuance
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func startQueue(t *testing.T, q *Queue) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go func() {
+		_ = q.Start(ctx)
+	}()
+}
+
+// await calls Do until the issuance has completed.
+func await(t *testing.T, q *Queue, key string, generation int64, fn func(context.Context) (any, error)) (any, error) {
+	t.Helper()
+
+	var result any
+	var err error
+	require.Eventually(t, func() bool {
+		result, err = q.Do(context.Background(), key, generation, fn)
+		return !errors.Is(err, ErrIssuing)
+	}, 10*time.Second, 10*time.Millisecond)
+	return result, err
+}
+
+func TestQueue_Do(t *testing.T) {
+	t.Parallel()
+
+	q := NewQueue(1, 0)
+	startQueue(t, q)
+
+	release := make(chan struct{})
+	var calls int
+	fn := func(ctx context.Context) (any, error) {
+		calls++
+		<-release
+		return "issued", nil
+	}
+
+	_, err := q.Do(context.Background(), "foo/bar", 1, fn)
+	assert.ErrorIs(t, err, ErrIssuing)
+	_, err = q.Do(context.Background(), "foo/bar", 1, fn)
+	assert.ErrorIs(t, err, ErrIssuing)
+
+	close(release)
+	got, err := await(t, q, "foo/bar", 1, fn)
+	require.NoError(t, err)
+	assert.Equal(t, "issued", got)
+	assert.Equal(t, 1, calls)
+
+	// the result is only returned once, the next call issues anew.
+	_, err = q.Do(context.Background(), "foo/bar", 1, fn)
+	assert.ErrorIs(t, err, ErrIssuing)
+	_, _ = await(t, q, "foo/bar", 1, fn)
+	assert.Equal(t, 2, calls)
+}
+
+func TestQueue_Do_error(t *testing.T) {
+	t.Parallel()
+
+	q := NewQueue(1, 0)
+	startQueue(t, q)
+
+	fn := func(ctx context.Context) (any, error) {
+		return nil, errors.New("permission denied")
+	}
+	_, err := q.Do(context.Background(), "foo/bar", 1, fn)
+	assert.ErrorIs(t, err, ErrIssuing)
+	_, err = await(t, q, "foo/bar", 1, fn)
+	assert.EqualError(t, err, "permission denied")
+}
+
+func TestQueue_Do_generationChanged(t *testing.T) {
+	t.Parallel()
+
+	q := NewQueue(1, 0)
+	startQueue(t, q)
+
+	started := make(chan struct{})
+	canceled := make(chan struct{})
+	_, err := q.Do(context.Background(), "foo/bar", 1, func(ctx context.Context) (any, error) {
+		close(started)
+		<-ctx.Done()
+		close(canceled)
+		return nil, ctx.Err()
+	})
+	assert.ErrorIs(t, err, ErrIssuing)
+	<-started
+
+	fn := func(ctx context.Context) (any, error) {
+		return int64(2), nil
+	}
+	_, err = q.Do(context.Background(), "foo/bar", 2, fn)
+	assert.ErrorIs(t, err, ErrIssuing)
+	select {
+	case <-canceled:
+	case <-time.After(10 * time.Second):
+		require.FailNow(t, "timed out waiting for the previous issuance to be canceled")
+	}
+
+	got, err := await(t, q, "foo/bar", 2, fn)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), got)
+}
+
+func TestQueue_Do_timeout(t *testing.T) {
+	t.Parallel()
+
+	q := NewQueue(1, 10*time.Millisecond)
+	startQueue(t, q)
+
+	fn := func(ctx context.Context) (any, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	_, err := q.Do(context.Background(), "foo/bar", 1, fn)
+	assert.ErrorIs(t, err, ErrIssuing)
+	_, err = await(t, q, "foo/bar", 1, fn)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestQueue_Do_queueFull(t *testing.T) {
+	t.Parallel()
+
+	// the workers are never started, so the pending issuances are never run.
+	q := NewQueue(1, 0)
+	q.pending = make(chan *job, 1)
+
+	fn := func(ctx context.Context) (any, error) {
+		return "inline", nil
+	}
+	_, err := q.Do(context.Background(), "foo/bar", 1, fn)
+	assert.ErrorIs(t, err, ErrIssuing)
+
+	got, err := q.Do(context.Background(), "foo/baz", 1, fn)
+	require.NoError(t, err)
+	assert.Equal(t, "inline", got)
+}
+
+func TestQueue_Forget(t *testing.T) {
+	t.Parallel()
+
+	q := NewQueue(1, 0)
+	q.pending = make(chan *job, 1)
+
+	fn := func(ctx context.Context) (any, error) {
+		return nil, nil
+	}
+	_, err := q.Do(context.Background(), "foo/bar", 1, fn)
+	assert.ErrorIs(t, err, ErrIssuing)
+
+	q.Forget("foo/bar")
+	assert.Empty(t, q.jobs)
+	// the forgotten issuance is dropped by the worker.
+	j := <-q.pending
+	assert.Error(t, j.ctx.Err())
+}
+
+func TestDo_disabled(t *testing.T) {
+	t.Parallel()
+
+	// the issuance is run inline without a DefaultQueue.
+	got, err := Do(context.Background(), "foo/bar", 1, func(ctx context.Context) (string, error) {
+		return "inline", nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "inline", got)
+}
@@ -0,0 +1,202 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package keystore
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf16"
+)
+
+const (
+	jksMagic   = 0xfeedfeed
+	jksVersion = 2
+	// jksPrivateKeyEntry and jksTrustedCertEntry are the tags of the entries.
+	jksPrivateKeyEntry  = 1
+	jksTrustedCertEntry = 2
+	// jksWhitener is mixed into the keystore's integrity hash, as done by the
+	// JDK.
+	jksWhitener = "Mighty Aphrodite"
+	// jksSaltLen is the salt length of the JDK's KeyProtector.
+	jksSaltLen = 20
+)
+
+// oidJKSKeyProtector identifies the JDK's proprietary private key protection
+// algorithm.
+var oidJKSKeyProtector = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 42, 2, 17, 1, 1}
+
+// EncodeJKS returns the JKS keystore holding e as a private key entry. Both the
+// private key and the keystore's integrity are protected by password. created
+// is the creation date of the entry.
+func EncodeJKS(rand io.Reader, e *Entry, password string, created time.Time) ([]byte, error) {
+	if e.Certificate == nil || e.PrivateKey == nil {
+		return nil, errors.New("the entry requires a certificate and a private key")
+	}
+
+	key, err := x509.MarshalPKCS8PrivateKey(e.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	protected, err := jksProtectKey(rand, key, password)
+	if err != nil {
+		return nil, err
+	}
+
+	w := newJKSWriter(1)
+	w.writeUint32(jksPrivateKeyEntry)
+	if err := w.writeUTF(strings.ToLower(e.Alias)); err != nil {
+		return nil, err
+	}
+	w.writeTime(created)
+	w.writeBytes(protected)
+	w.writeUint32(uint32(1 + len(e.CACertificates)))
+	for _, c := range append([]*x509.Certificate{e.Certificate}, e.CACertificates...) {
+		if err := w.writeCertificate(c); err != nil {
+			return nil, err
+		}
+	}
+
+	return w.finish(password)
+}
+
+// EncodeJKSTruststore returns the JKS truststore holding each of certs as a
+// trusted certificate entry, whose alias is "ca-<index>". The keystore's
+// integrity is protected by password. created is the creation date of the
+// entries.
+func EncodeJKSTruststore(certs []*x509.Certificate, password string, created time.Time) ([]byte, error) {
+	w := newJKSWriter(len(certs))
+	for i, c := range certs {
+		w.writeUint32(jksTrustedCertEntry)
+		if err := w.writeUTF(fmt.Sprintf("ca-%d", i)); err != nil {
+			return nil, err
+		}
+		w.writeTime(created)
+		if err := w.writeCertificate(c); err != nil {
+			return nil, err
+		}
+	}
+
+	return w.finish(password)
+}
+
+// jksProtectKey encrypts the PKCS#8 encoded key, like the JDK's KeyProtector:
+// the key is XORed with a keystream made of chained SHA-1 digests of the
+// password and a random salt, and is followed by the SHA-1 digest of the
+// password and the key for integrity.
+func jksProtectKey(rand io.Reader, key []byte, password string) ([]byte, error) {
+	passwd := jksPassword(password)
+	salt := make([]byte, jksSaltLen)
+	if _, err := io.ReadFull(rand, salt); err != nil {
+		return nil, err
+	}
+
+	encrypted := make([]byte, 0, jksSaltLen+len(key)+sha1.Size)
+	encrypted = append(encrypted, salt...)
+	digest := salt
+	for i := 0; i < len(key); i += sha1.Size {
+		h := sha1.New()
+		h.Write(passwd)
+		h.Write(digest)
+		digest = h.Sum(nil)
+		for j := 0; j < sha1.Size && i+j < len(key); j++ {
+			encrypted = append(encrypted, key[i+j]^digest[j])
+		}
+	}
+
+	h := sha1.New()
+	h.Write(passwd)
+	h.Write(key)
+	encrypted = h.Sum(encrypted)
+
+	return asn1.Marshal(encryptedPrivateKeyInfo{
+		Algorithm: pkix.AlgorithmIdentifier{
+			Algorithm:  oidJKSKeyProtector,
+			Parameters: asn1.NullRawValue,
+		},
+		EncryptedData: encrypted,
+	})
+}
+
+// jksPassword returns the password's bytes as used by the JDK, i.e. its
+// BMPString without the NUL terminator.
+func jksPassword(password string) []byte {
+	b := bmpString(password)
+	return b[:len(b)-2]
+}
+
+// jksWriter writes the big-endian encoding of a JKS keystore, as done by
+// Java's DataOutputStream.
+type jksWriter struct {
+	buf bytes.Buffer
+}
+
+func newJKSWriter(entries int) *jksWriter {
+	w := &jksWriter{}
+	w.writeUint32(jksMagic)
+	w.writeUint32(jksVersion)
+	w.writeUint32(uint32(entries))
+	return w
+}
+
+func (w *jksWriter) writeUint32(v uint32) {
+	w.buf.Write(binary.BigEndian.AppendUint32(nil, v))
+}
+
+func (w *jksWriter) writeTime(t time.Time) {
+	w.buf.Write(binary.BigEndian.AppendUint64(nil, uint64(t.UnixMilli())))
+}
+
+func (w *jksWriter) writeBytes(b []byte) {
+	w.writeUint32(uint32(len(b)))
+	w.buf.Write(b)
+}
+
+// writeUTF writes s in Java's modified UTF-8, prefixed by its length.
+func (w *jksWriter) writeUTF(s string) error {
+	var b []byte
+	for _, u := range utf16.Encode([]rune(s)) {
+		switch {
+		case u != 0 && u < 0x80:
+			b = append(b, byte(u))
+		case u < 0x800:
+			b = append(b, byte(0xc0|u>>6), byte(0x80|u&0x3f))
+		default:
+			b = append(b, byte(0xe0|u>>12), byte(0x80|(u>>6)&0x3f), byte(0x80|u&0x3f))
+		}
+	}
+	if len(b) > 0xffff {
+		return fmt.Errorf("string too long: %d bytes", len(b))
+	}
+
+	w.buf.Write(binary.BigEndian.AppendUint16(nil, uint16(len(b))))
+	w.buf.Write(b)
+	return nil
+}
+
+func (w *jksWriter) writeCertificate(c *x509.Certificate) error {
+	if err := w.writeUTF("X.509"); err != nil {
+		return err
+	}
+	w.writeBytes(c.Raw)
+	return nil
+}
+
+// finish appends the keystore's integrity hash, returning the keystore.
+func (w *jksWriter) finish(password string) ([]byte, error) {
+	h := sha1.New()
+	h.Write(jksPassword(password))
+	h.Write([]byte(jksWhitener))
+	h.Write(w.buf.Bytes())
+	return h.Sum(w.buf.Bytes()), nil
+}
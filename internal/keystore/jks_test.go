@@ -0,0 +1,178 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package keystore
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// jksReader reads a JKS keystore, failing the test on any error.
+type jksReader struct {
+	t *testing.T
+	r *bytes.Reader
+}
+
+func (r *jksReader) uint32() uint32 {
+	var v uint32
+	require.NoError(r.t, binary.Read(r.r, binary.BigEndian, &v))
+	return v
+}
+
+func (r *jksReader) time() time.Time {
+	var v int64
+	require.NoError(r.t, binary.Read(r.r, binary.BigEndian, &v))
+	return time.UnixMilli(v)
+}
+
+func (r *jksReader) bytes(n int) []byte {
+	b := make([]byte, n)
+	_, err := r.r.Read(b)
+	require.NoError(r.t, err)
+	return b
+}
+
+func (r *jksReader) utf() string {
+	var n uint16
+	require.NoError(r.t, binary.Read(r.r, binary.BigEndian, &n))
+	return string(r.bytes(int(n)))
+}
+
+func (r *jksReader) certificate() *x509.Certificate {
+	assert.Equal(r.t, "X.509", r.utf())
+	c, err := x509.ParseCertificate(r.bytes(int(r.uint32())))
+	require.NoError(r.t, err)
+	return c
+}
+
+// newJKSReader verifies the keystore's header and integrity hash, returning a
+// reader of its entries.
+func newJKSReader(t *testing.T, b []byte, password string, wantEntries int) *jksReader {
+	t.Helper()
+	require.Greater(t, len(b), sha1.Size)
+	body := b[:len(b)-sha1.Size]
+	h := sha1.New()
+	h.Write(jksPassword(password))
+	h.Write([]byte(jksWhitener))
+	h.Write(body)
+	assert.Equal(t, h.Sum(nil), b[len(body):], "integrity hash")
+
+	r := &jksReader{t: t, r: bytes.NewReader(body)}
+	assert.Equal(t, uint32(jksMagic), r.uint32())
+	assert.Equal(t, uint32(jksVersion), r.uint32())
+	assert.Equal(t, uint32(wantEntries), r.uint32())
+	return r
+}
+
+// jksRecoverKey decrypts the key protected by jksProtectKey, like the JDK's
+// KeyProtector.
+func jksRecoverKey(t *testing.T, b []byte, password string) []byte {
+	t.Helper()
+	var info encryptedPrivateKeyInfo
+	_, err := asn1.Unmarshal(b, &info)
+	require.NoError(t, err)
+	require.True(t, info.Algorithm.Algorithm.Equal(oidJKSKeyProtector))
+
+	passwd := jksPassword(password)
+	encrypted := info.EncryptedData
+	salt := encrypted[:jksSaltLen]
+	check := encrypted[len(encrypted)-sha1.Size:]
+	encrypted = encrypted[jksSaltLen : len(encrypted)-sha1.Size]
+
+	key := make([]byte, len(encrypted))
+	digest := salt
+	for i := range encrypted {
+		if i%sha1.Size == 0 {
+			h := sha1.New()
+			h.Write(passwd)
+			h.Write(digest)
+			digest = h.Sum(nil)
+		}
+		key[i] = encrypted[i] ^ digest[i%sha1.Size]
+	}
+
+	h := sha1.New()
+	h.Write(passwd)
+	h.Write(key)
+	assert.Equal(t, h.Sum(nil), check, "key integrity")
+	return key
+}
+
+func TestEncodeJKS(t *testing.T) {
+	t.Parallel()
+
+	ca, caKey := newTestCert(t, "ca", nil, nil)
+	leaf, leafKey := newTestCert(t, "leaf", ca, caKey)
+	password := "s3cr3t-é"
+	created := time.UnixMilli(1767225600123)
+
+	b, err := EncodeJKS(rand.Reader, &Entry{
+		Alias:          "Leaf",
+		PrivateKey:     leafKey,
+		Certificate:    leaf,
+		CACertificates: []*x509.Certificate{ca},
+	}, password, created)
+	require.NoError(t, err)
+
+	r := newJKSReader(t, b, password, 1)
+	assert.Equal(t, uint32(jksPrivateKeyEntry), r.uint32())
+	assert.Equal(t, "leaf", r.utf())
+	assert.True(t, created.Equal(r.time()))
+
+	key, err := x509.ParsePKCS8PrivateKey(jksRecoverKey(t, r.bytes(int(r.uint32())), password))
+	require.NoError(t, err)
+	assert.True(t, leafKey.Equal(key))
+
+	assert.Equal(t, uint32(2), r.uint32())
+	assert.Equal(t, leaf.Raw, r.certificate().Raw)
+	assert.Equal(t, ca.Raw, r.certificate().Raw)
+	assert.Zero(t, r.r.Len())
+
+	_, err = EncodeJKS(rand.Reader, &Entry{Alias: "leaf"}, password, created)
+	assert.EqualError(t, err, "the entry requires a certificate and a private key")
+}
+
+func TestEncodeJKSTruststore(t *testing.T) {
+	t.Parallel()
+
+	root, rootKey := newTestCert(t, "root", nil, nil)
+	intermediate, _ := newTestCert(t, "intermediate", root, rootKey)
+	password := "changeit"
+	created := time.UnixMilli(1767225600000)
+
+	b, err := EncodeJKSTruststore([]*x509.Certificate{intermediate, root}, password, created)
+	require.NoError(t, err)
+
+	r := newJKSReader(t, b, password, 2)
+	for i, want := range []*x509.Certificate{intermediate, root} {
+		assert.Equal(t, uint32(jksTrustedCertEntry), r.uint32())
+		assert.Equal(t, []string{"ca-0", "ca-1"}[i], r.utf())
+		assert.True(t, created.Equal(r.time()))
+		assert.Equal(t, want.Raw, r.certificate().Raw)
+	}
+	assert.Zero(t, r.r.Len())
+}
+
+func Test_jksWriter_writeUTF(t *testing.T) {
+	t.Parallel()
+
+	w := &jksWriter{}
+	require.NoError(t, w.writeUTF("a\x00é😀"))
+	assert.Equal(t, []byte{
+		0, 11,
+		'a',
+		0xc0, 0x80,
+		0xc3, 0xa9,
+		0xed, 0xa0, 0xbd, 0xed, 0xb8, 0x80,
+	}, w.buf.Bytes())
+}
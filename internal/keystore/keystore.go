@@ -0,0 +1,144 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package keystore packages certificates and their private key into the
+// keystore formats of Java and Windows workloads, and certificates into PKCS#7
+// bundles.
+package keystore
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Entry is a private key along with its certificate chain.
+type Entry struct {
+	// Alias of the entry in the keystore.
+	Alias string
+	// PrivateKey of the Certificate.
+	PrivateKey crypto.PrivateKey
+	// Certificate is the leaf certificate.
+	Certificate *x509.Certificate
+	// CACertificates are the certificates of the Certificate's chain.
+	CACertificates []*x509.Certificate
+}
+
+// NewEntry returns the Entry of the certificate, private key, and CA chain, as
+// returned by the Vault PKI secrets engine, either PEM encoded, or base64
+// encoded DER. Any certificate that follows the leaf in certificate, e.g. with
+// the pem_bundle format, is added to the CA certificates, unless it is already
+// part of the CA chain.
+func NewEntry(alias, certificate, privateKey string, caChain []string) (*Entry, error) {
+	certs, err := ParseCertificates(certificate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid certificate: %w", err)
+	}
+
+	key, err := ParsePrivateKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+
+	e := &Entry{
+		Alias:       alias,
+		PrivateKey:  key,
+		Certificate: certs[0],
+	}
+
+	seen := make(map[string]bool)
+	add := func(certs ...*x509.Certificate) {
+		for _, c := range certs {
+			if !seen[string(c.Raw)] {
+				seen[string(c.Raw)] = true
+				e.CACertificates = append(e.CACertificates, c)
+			}
+		}
+	}
+	add(certs[1:]...)
+	for _, s := range caChain {
+		caCerts, err := ParseCertificates(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CA certificate: %w", err)
+		}
+		add(caCerts...)
+	}
+
+	return e, nil
+}
+
+// decodeBlocks returns the DER bytes of all the PEM blocks of the given type
+// suffix found in s, or the base64 decoded s if it is not PEM encoded.
+func decodeBlocks(s, typeSuffix string) ([][]byte, error) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "-----BEGIN") {
+		b, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return nil, err
+		}
+		return [][]byte{b}, nil
+	}
+
+	var ret [][]byte
+	rest := []byte(s)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if strings.HasSuffix(block.Type, typeSuffix) {
+			ret = append(ret, block.Bytes)
+		}
+	}
+	if len(ret) == 0 {
+		return nil, fmt.Errorf("no %s PEM block found", typeSuffix)
+	}
+
+	return ret, nil
+}
+
+// ParseCertificates returns the certificates in s, either PEM encoded, or
+// base64 encoded DER.
+func ParseCertificates(s string) ([]*x509.Certificate, error) {
+	blocks, err := decodeBlocks(s, "CERTIFICATE")
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []*x509.Certificate
+	for _, b := range blocks {
+		c, err := x509.ParseCertificate(b)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, c)
+	}
+
+	return ret, nil
+}
+
+// ParsePrivateKey returns the private key in s, either PEM encoded, or base64
+// encoded DER, in the PKCS#8, PKCS#1, or SEC 1 format.
+func ParsePrivateKey(s string) (crypto.PrivateKey, error) {
+	blocks, err := decodeBlocks(s, "PRIVATE KEY")
+	if err != nil {
+		return nil, err
+	}
+
+	if key, err := x509.ParsePKCS8PrivateKey(blocks[0]); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(blocks[0]); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(blocks[0]); err == nil {
+		return key, nil
+	}
+
+	return nil, errors.New("unsupported private key type")
+}
@@ -0,0 +1,134 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package keystore
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestCert returns a certificate for cn, signed by parent, or self-signed
+// if parent is nil.
+func newTestCert(t *testing.T, cn string, parent *x509.Certificate, parentKey crypto.Signer) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  parent == nil,
+		BasicConstraintsValid: true,
+	}
+	if parent == nil {
+		parent, parentKey = tmpl, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, key.Public(), parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return cert, key
+}
+
+func pemEncode(t *testing.T, typ string, b []byte) string {
+	t.Helper()
+	return string(pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: b}))
+}
+
+func TestNewEntry(t *testing.T) {
+	t.Parallel()
+
+	ca, caKey := newTestCert(t, "ca", nil, nil)
+	leaf, leafKey := newTestCert(t, "leaf", ca, caKey)
+	ecKey, err := x509.MarshalECPrivateKey(leafKey)
+	require.NoError(t, err)
+	pkcs8Key, err := x509.MarshalPKCS8PrivateKey(leafKey)
+	require.NoError(t, err)
+
+	leafPEM := pemEncode(t, "CERTIFICATE", leaf.Raw)
+	caPEM := pemEncode(t, "CERTIFICATE", ca.Raw)
+	keyPEM := pemEncode(t, "EC PRIVATE KEY", ecKey)
+
+	tests := []struct {
+		name        string
+		certificate string
+		privateKey  string
+		caChain     []string
+		wantCAs     []*x509.Certificate
+		wantErr     assert.ErrorAssertionFunc
+	}{
+		{
+			name:        "pem",
+			certificate: leafPEM,
+			privateKey:  keyPEM,
+			caChain:     []string{caPEM},
+			wantCAs:     []*x509.Certificate{ca},
+			wantErr:     assert.NoError,
+		},
+		{
+			name:        "pem-bundle",
+			certificate: leafPEM + keyPEM + caPEM,
+			privateKey:  pemEncode(t, "PRIVATE KEY", pkcs8Key),
+			caChain:     []string{caPEM},
+			wantCAs:     []*x509.Certificate{ca},
+			wantErr:     assert.NoError,
+		},
+		{
+			name:        "der",
+			certificate: base64.StdEncoding.EncodeToString(leaf.Raw),
+			privateKey:  base64.StdEncoding.EncodeToString(ecKey),
+			wantErr:     assert.NoError,
+		},
+		{
+			name:        "invalid-certificate",
+			certificate: "-----BEGIN FOO-----\n-----END FOO-----\n",
+			privateKey:  keyPEM,
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.EqualError(t, err, "invalid certificate: no CERTIFICATE PEM block found", i...)
+			},
+		},
+		{
+			name:        "invalid-private-key",
+			certificate: leafPEM,
+			privateKey:  pemEncode(t, "PRIVATE KEY", []byte("foo")),
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.EqualError(t, err, "invalid private key: unsupported private key type", i...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := NewEntry("alias", tt.certificate, tt.privateKey, tt.caChain)
+			if !tt.wantErr(t, err) {
+				return
+			}
+			if err != nil {
+				return
+			}
+			assert.Equal(t, "alias", got.Alias)
+			assert.Equal(t, leaf, got.Certificate)
+			assert.True(t, leafKey.Equal(got.PrivateKey))
+			assert.Equal(t, tt.wantCAs, got.CACertificates)
+		})
+	}
+}
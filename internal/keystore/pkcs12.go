@@ -0,0 +1,400 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package keystore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+	"hash"
+	"io"
+	"math/big"
+	"unicode/utf16"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// pkcs12Iterations is the iteration count of both the key derivation and the
+// MAC, which is OpenSSL's default.
+const pkcs12Iterations = 2048
+
+var (
+	oidDataContentType     = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 1}
+	oidPKCS8ShroudedKeyBag = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 12, 10, 1, 2}
+	oidCertBag             = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 12, 10, 1, 3}
+	oidCertTypeX509        = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 22, 1}
+	oidFriendlyName        = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 20}
+	oidLocalKeyID          = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 21}
+	oidPBES2               = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidPBKDF2              = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+	oidHMACWithSHA256      = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+	oidAES256CBC           = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 42}
+	oidSHA256              = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 1}
+)
+
+type pfxPdu struct {
+	Version  int
+	AuthSafe contentInfo
+	MacData  macData
+}
+
+type contentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue
+}
+
+type macData struct {
+	Mac        digestInfo
+	MacSalt    []byte
+	Iterations int
+}
+
+type digestInfo struct {
+	Algorithm pkix.AlgorithmIdentifier
+	Digest    []byte
+}
+
+type safeBag struct {
+	ID         asn1.ObjectIdentifier
+	Value      asn1.RawValue
+	Attributes []pkcs12Attribute `asn1:"set,optional"`
+}
+
+type pkcs12Attribute struct {
+	ID    asn1.ObjectIdentifier
+	Value asn1.RawValue
+}
+
+type certBag struct {
+	ID   asn1.ObjectIdentifier
+	Data []byte `asn1:"tag:0,explicit"`
+}
+
+type encryptedPrivateKeyInfo struct {
+	Algorithm     pkix.AlgorithmIdentifier
+	EncryptedData []byte
+}
+
+type pbes2Params struct {
+	KDF              pkix.AlgorithmIdentifier
+	EncryptionScheme pkix.AlgorithmIdentifier
+}
+
+type pbkdf2Params struct {
+	Salt       []byte
+	Iterations int
+	PRF        pkix.AlgorithmIdentifier
+}
+
+// EncodePKCS12 encodes e into a PKCS#12 keystore that is protected by
+// password. Like OpenSSL 3, the private key is encrypted with PBES2, i.e.
+// AES-256-CBC and PBKDF2 with HMAC-SHA256, and the keystore's integrity is
+// protected by a HMAC-SHA256 MAC. The certificates are not encrypted. The
+// keystore can be read by Java 8u301+, and Windows 10 1709+.
+func EncodePKCS12(rand io.Reader, e *Entry, password string) ([]byte, error) {
+	localKeyID := sha1.Sum(e.Certificate.Raw)
+	keyAttrs, err := bagAttributes(e.Alias, localKeyID[:])
+	if err != nil {
+		return nil, err
+	}
+
+	keyBag, err := shroudedKeyBag(rand, e, password, keyAttrs)
+	if err != nil {
+		return nil, err
+	}
+
+	bags := []safeBag{}
+	leaf, err := newCertBag(e.Certificate, keyAttrs)
+	if err != nil {
+		return nil, err
+	}
+	bags = append(bags, leaf)
+	for _, c := range e.CACertificates {
+		bag, err := newCertBag(c, nil)
+		if err != nil {
+			return nil, err
+		}
+		bags = append(bags, bag)
+	}
+
+	var authSafe []contentInfo
+	for _, contents := range [][]safeBag{bags, {keyBag}} {
+		ci, err := dataContentInfo(contents)
+		if err != nil {
+			return nil, err
+		}
+		authSafe = append(authSafe, ci)
+	}
+
+	authSafeBytes, err := asn1.Marshal(authSafe)
+	if err != nil {
+		return nil, err
+	}
+
+	macSalt := make([]byte, 8)
+	if _, err := io.ReadFull(rand, macSalt); err != nil {
+		return nil, err
+	}
+
+	macKey := pkcs12KDF(sha256.New, 32, bmpString(password), macSalt, 3, pkcs12Iterations)
+	mac := hmac.New(sha256.New, macKey)
+	mac.Write(authSafeBytes)
+
+	content, err := asn1.Marshal(authSafeBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return asn1.Marshal(pfxPdu{
+		Version: 3,
+		AuthSafe: contentInfo{
+			ContentType: oidDataContentType,
+			Content:     explicitTag0(content),
+		},
+		MacData: macData{
+			Mac: digestInfo{
+				Algorithm: pkix.AlgorithmIdentifier{
+					Algorithm:  oidSHA256,
+					Parameters: asn1.NullRawValue,
+				},
+				Digest: mac.Sum(nil),
+			},
+			MacSalt:    macSalt,
+			Iterations: pkcs12Iterations,
+		},
+	})
+}
+
+// explicitTag0 wraps the DER encoded b into an EXPLICIT [0] tag. The struct
+// tags do not apply to an asn1.RawValue, whose FullBytes are always encoded as
+// is.
+func explicitTag0(b []byte) asn1.RawValue {
+	return asn1.RawValue{
+		Class:      asn1.ClassContextSpecific,
+		Tag:        0,
+		IsCompound: true,
+		Bytes:      b,
+	}
+}
+
+// dataContentInfo returns the unencrypted data ContentInfo of bags.
+func dataContentInfo(bags []safeBag) (contentInfo, error) {
+	b, err := asn1.Marshal(bags)
+	if err != nil {
+		return contentInfo{}, err
+	}
+
+	content, err := asn1.Marshal(b)
+	if err != nil {
+		return contentInfo{}, err
+	}
+
+	return contentInfo{
+		ContentType: oidDataContentType,
+		Content:     explicitTag0(content),
+	}, nil
+}
+
+// bagAttributes returns the friendlyName, i.e. the alias, and localKeyId
+// attributes that link the private key to its certificate.
+func bagAttributes(alias string, localKeyID []byte) ([]pkcs12Attribute, error) {
+	var attrs []pkcs12Attribute
+	if alias != "" {
+		name, err := asn1.Marshal(asn1.RawValue{
+			Tag:   asn1.TagBMPString,
+			Bytes: bmpString(alias)[:len(alias)*2],
+		})
+		if err != nil {
+			return nil, err
+		}
+		attrs = append(attrs, pkcs12Attribute{
+			ID:    oidFriendlyName,
+			Value: asn1.RawValue{Tag: asn1.TagSet, IsCompound: true, Bytes: name},
+		})
+	}
+
+	id, err := asn1.Marshal(localKeyID)
+	if err != nil {
+		return nil, err
+	}
+	attrs = append(attrs, pkcs12Attribute{
+		ID:    oidLocalKeyID,
+		Value: asn1.RawValue{Tag: asn1.TagSet, IsCompound: true, Bytes: id},
+	})
+
+	return attrs, nil
+}
+
+func newCertBag(c *x509.Certificate, attrs []pkcs12Attribute) (safeBag, error) {
+	b, err := asn1.Marshal(certBag{
+		ID:   oidCertTypeX509,
+		Data: c.Raw,
+	})
+	if err != nil {
+		return safeBag{}, err
+	}
+
+	return safeBag{
+		ID:         oidCertBag,
+		Value:      explicitTag0(b),
+		Attributes: attrs,
+	}, nil
+}
+
+// shroudedKeyBag returns the bag of e's private key, encrypted with PBES2.
+func shroudedKeyBag(rand io.Reader, e *Entry, password string, attrs []pkcs12Attribute) (safeBag, error) {
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(e.PrivateKey)
+	if err != nil {
+		return safeBag{}, err
+	}
+
+	salt := make([]byte, 16)
+	iv := make([]byte, aes.BlockSize)
+	for _, b := range [][]byte{salt, iv} {
+		if _, err := io.ReadFull(rand, b); err != nil {
+			return safeBag{}, err
+		}
+	}
+
+	block, err := aes.NewCipher(pbkdf2.Key([]byte(password), salt, pkcs12Iterations, 32, sha256.New))
+	if err != nil {
+		return safeBag{}, err
+	}
+
+	// PKCS#7 padding
+	padding := aes.BlockSize - len(pkcs8)%aes.BlockSize
+	encrypted := make([]byte, len(pkcs8)+padding)
+	copy(encrypted, pkcs8)
+	for i := len(pkcs8); i < len(encrypted); i++ {
+		encrypted[i] = byte(padding)
+	}
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(encrypted, encrypted)
+
+	kdfParams, err := asn1.Marshal(pbkdf2Params{
+		Salt:       salt,
+		Iterations: pkcs12Iterations,
+		PRF: pkix.AlgorithmIdentifier{
+			Algorithm:  oidHMACWithSHA256,
+			Parameters: asn1.NullRawValue,
+		},
+	})
+	if err != nil {
+		return safeBag{}, err
+	}
+
+	ivParam, err := asn1.Marshal(iv)
+	if err != nil {
+		return safeBag{}, err
+	}
+
+	params, err := asn1.Marshal(pbes2Params{
+		KDF: pkix.AlgorithmIdentifier{
+			Algorithm:  oidPBKDF2,
+			Parameters: asn1.RawValue{FullBytes: kdfParams},
+		},
+		EncryptionScheme: pkix.AlgorithmIdentifier{
+			Algorithm:  oidAES256CBC,
+			Parameters: asn1.RawValue{FullBytes: ivParam},
+		},
+	})
+	if err != nil {
+		return safeBag{}, err
+	}
+
+	b, err := asn1.Marshal(encryptedPrivateKeyInfo{
+		Algorithm: pkix.AlgorithmIdentifier{
+			Algorithm:  oidPBES2,
+			Parameters: asn1.RawValue{FullBytes: params},
+		},
+		EncryptedData: encrypted,
+	})
+	if err != nil {
+		return safeBag{}, fmt.Errorf("failed to encode the private key: %w", err)
+	}
+
+	return safeBag{
+		ID:         oidPKCS8ShroudedKeyBag,
+		Value:      explicitTag0(b),
+		Attributes: attrs,
+	}, nil
+}
+
+// bmpString returns s as a null terminated BMPString, i.e. big-endian UTF-16,
+// as used for the password of the PKCS#12 key derivation function.
+func bmpString(s string) []byte {
+	u := utf16.Encode([]rune(s))
+	ret := make([]byte, 0, 2*len(u)+2)
+	for _, r := range u {
+		ret = append(ret, byte(r>>8), byte(r))
+	}
+	return append(ret, 0, 0)
+}
+
+// pkcs12KDF is the key derivation function of RFC 7292 appendix B.2, which is
+// used to derive the MAC key. It returns size bytes of key material.
+func pkcs12KDF(h func() hash.Hash, size int, password, salt []byte, id byte, iterations int) []byte {
+	v := h().BlockSize()
+
+	fill := func(b []byte) []byte {
+		if len(b) == 0 {
+			return nil
+		}
+		n := v * ((len(b) + v - 1) / v)
+		ret := make([]byte, n)
+		for i := range ret {
+			ret[i] = b[i%len(b)]
+		}
+		return ret
+	}
+
+	d := make([]byte, v)
+	for i := range d {
+		d[i] = id
+	}
+	input := append(fill(salt), fill(password)...)
+
+	one := big.NewInt(1)
+	var ret []byte
+	for len(ret) < size {
+		hh := h()
+		hh.Write(d)
+		hh.Write(input)
+		a := hh.Sum(nil)
+		for i := 1; i < iterations; i++ {
+			hh = h()
+			hh.Write(a)
+			a = hh.Sum(a[:0])
+		}
+		ret = append(ret, a...)
+
+		if len(ret) >= size {
+			break
+		}
+
+		// I_j = (I_j + B + 1) mod 2^(v*8), for each v-byte block of I.
+		b := new(big.Int).SetBytes(fill(a)[:v])
+		b.Add(b, one)
+		for j := 0; j < len(input); j += v {
+			ij := new(big.Int).SetBytes(input[j : j+v])
+			ij.Add(ij, b)
+			bs := ij.Bytes()
+			block := input[j : j+v]
+			for k := range block {
+				block[k] = 0
+			}
+			if len(bs) > v {
+				bs = bs[len(bs)-v:]
+			}
+			copy(block[v-len(bs):], bs)
+		}
+	}
+
+	return ret[:size]
+}
@@ -0,0 +1,126 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package keystore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+func Test_pkcs12KDF(t *testing.T) {
+	t.Parallel()
+
+	// test vectors of golang.org/x/crypto/pkcs12
+	assert.Equal(t,
+		[]byte("\x7c\xd9\xfd\x3e\x2b\x3b\xe7\x69\x1a\x44\xe3\xbe\xf0\xf9\xea\x0f\xb9\xb8\x97\xd4\xe3\x25\xd9\xd1"),
+		pkcs12KDF(sha1.New, 24, bmpString("sesame"), []byte("\xff\xff\xff\xff\xff\xff\xff\xff"), 1, 2048))
+	// I_j ends up with a leading zero byte.
+	assert.Equal(t,
+		[]byte("\x00\xf7\x59\xff\x47\xd1\x4d\xd0\x36\x65\xd5\x94\x3c\xb3\xc4\xa3\x9a\x25\x55\xc0\x2a\xed\x66\xe1"),
+		pkcs12KDF(sha1.New, 24, []byte("\x00\x00"), []byte("\xf3\x7e\x05\xb5\x18\x32\x4b\x4b"), 1, 2048))
+}
+
+func Test_bmpString(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []byte{0, 'a', 0, 0xe9, 0xd8, 0x3d, 0xde, 0x00, 0, 0}, bmpString("aé😀"))
+}
+
+func TestEncodePKCS12(t *testing.T) {
+	t.Parallel()
+
+	ca, caKey := newTestCert(t, "ca", nil, nil)
+	leaf, leafKey := newTestCert(t, "leaf", ca, caKey)
+	password := "s3cr3t"
+
+	b, err := EncodePKCS12(rand.Reader, &Entry{
+		Alias:          "leaf",
+		PrivateKey:     leafKey,
+		Certificate:    leaf,
+		CACertificates: []*x509.Certificate{ca},
+	}, password)
+	require.NoError(t, err)
+
+	var pfx pfxPdu
+	_, err = asn1.Unmarshal(b, &pfx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, pfx.Version)
+	assert.True(t, pfx.MacData.Mac.Algorithm.Algorithm.Equal(oidSHA256))
+
+	var authSafeBytes []byte
+	_, err = asn1.Unmarshal(pfx.AuthSafe.Content.Bytes, &authSafeBytes)
+	require.NoError(t, err)
+
+	macKey := pkcs12KDF(sha256.New, 32, bmpString(password), pfx.MacData.MacSalt, 3, pfx.MacData.Iterations)
+	mac := hmac.New(sha256.New, macKey)
+	mac.Write(authSafeBytes)
+	assert.Equal(t, mac.Sum(nil), pfx.MacData.Mac.Digest)
+
+	var authSafe []contentInfo
+	_, err = asn1.Unmarshal(authSafeBytes, &authSafe)
+	require.NoError(t, err)
+	require.Len(t, authSafe, 2)
+
+	bagsOf := func(ci contentInfo) []safeBag {
+		t.Helper()
+		var data []byte
+		_, err := asn1.Unmarshal(ci.Content.Bytes, &data)
+		require.NoError(t, err)
+		var bags []safeBag
+		_, err = asn1.Unmarshal(data, &bags)
+		require.NoError(t, err)
+		return bags
+	}
+
+	certBags := bagsOf(authSafe[0])
+	require.Len(t, certBags, 2)
+	for i, want := range []*x509.Certificate{leaf, ca} {
+		assert.True(t, certBags[i].ID.Equal(oidCertBag))
+		var cb certBag
+		_, err := asn1.Unmarshal(certBags[i].Value.Bytes, &cb)
+		require.NoError(t, err)
+		assert.Equal(t, want.Raw, cb.Data)
+	}
+	assert.Len(t, certBags[0].Attributes, 2)
+	assert.Empty(t, certBags[1].Attributes)
+
+	keyBags := bagsOf(authSafe[1])
+	require.Len(t, keyBags, 1)
+	assert.True(t, keyBags[0].ID.Equal(oidPKCS8ShroudedKeyBag))
+	assert.Equal(t, certBags[0].Attributes, keyBags[0].Attributes)
+
+	var info encryptedPrivateKeyInfo
+	_, err = asn1.Unmarshal(keyBags[0].Value.Bytes, &info)
+	require.NoError(t, err)
+	var params pbes2Params
+	_, err = asn1.Unmarshal(info.Algorithm.Parameters.FullBytes, &params)
+	require.NoError(t, err)
+	var kdfParams pbkdf2Params
+	_, err = asn1.Unmarshal(params.KDF.Parameters.FullBytes, &kdfParams)
+	require.NoError(t, err)
+	var iv []byte
+	_, err = asn1.Unmarshal(params.EncryptionScheme.Parameters.FullBytes, &iv)
+	require.NoError(t, err)
+
+	block, err := aes.NewCipher(pbkdf2.Key([]byte(password), kdfParams.Salt, kdfParams.Iterations, 32, sha256.New))
+	require.NoError(t, err)
+	decrypted := make([]byte, len(info.EncryptedData))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(decrypted, info.EncryptedData)
+	decrypted = decrypted[:len(decrypted)-int(decrypted[len(decrypted)-1])]
+
+	key, err := x509.ParsePKCS8PrivateKey(decrypted)
+	require.NoError(t, err)
+	assert.True(t, leafKey.Equal(key))
+}
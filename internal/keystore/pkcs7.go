@@ -0,0 +1,60 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package keystore
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+)
+
+var oidSignedDataContentType = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2}
+
+// signedData is the PKCS#7 SignedData, see RFC 2315 section 9.1.
+type signedData struct {
+	Version          int
+	DigestAlgorithms []pkix.AlgorithmIdentifier `asn1:"set"`
+	ContentInfo      dataInfo
+	Certificates     asn1.RawValue
+	SignerInfos      []asn1.RawValue `asn1:"set"`
+}
+
+// dataInfo is a data ContentInfo without content.
+type dataInfo struct {
+	ContentType asn1.ObjectIdentifier
+}
+
+// EncodePKCS7 returns the DER encoded, degenerate "certs-only" PKCS#7
+// SignedData bundle of certs, which has neither content nor signers, as
+// produced by "openssl crl2pkcs7 -nocrl".
+func EncodePKCS7(certs []*x509.Certificate) ([]byte, error) {
+	if len(certs) == 0 {
+		return nil, errors.New("no certificates to encode")
+	}
+
+	var raw []byte
+	for _, c := range certs {
+		raw = append(raw, c.Raw...)
+	}
+
+	sd, err := asn1.Marshal(signedData{
+		Version:          1,
+		DigestAlgorithms: []pkix.AlgorithmIdentifier{},
+		ContentInfo: dataInfo{
+			ContentType: oidDataContentType,
+		},
+		// certificates [0] IMPLICIT SET OF Certificate
+		Certificates: explicitTag0(raw),
+		SignerInfos:  []asn1.RawValue{},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return asn1.Marshal(contentInfo{
+		ContentType: oidSignedDataContentType,
+		Content:     explicitTag0(sd),
+	})
+}
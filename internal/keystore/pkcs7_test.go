@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package keystore
+
+import (
+	"crypto/x509"
+	"encoding/asn1"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodePKCS7(t *testing.T) {
+	t.Parallel()
+
+	ca, caKey := newTestCert(t, "ca", nil, nil)
+	leaf, _ := newTestCert(t, "leaf", ca, caKey)
+
+	b, err := EncodePKCS7([]*x509.Certificate{leaf, ca})
+	require.NoError(t, err)
+
+	var ci contentInfo
+	rest, err := asn1.Unmarshal(b, &ci)
+	require.NoError(t, err)
+	assert.Empty(t, rest)
+	assert.True(t, ci.ContentType.Equal(oidSignedDataContentType))
+
+	var sd signedData
+	_, err = asn1.Unmarshal(ci.Content.Bytes, &sd)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sd.Version)
+	assert.Empty(t, sd.DigestAlgorithms)
+	assert.Empty(t, sd.SignerInfos)
+	assert.True(t, sd.ContentInfo.ContentType.Equal(oidDataContentType))
+	assert.Equal(t, asn1.ClassContextSpecific, sd.Certificates.Class)
+	assert.Equal(t, 0, sd.Certificates.Tag)
+
+	certs, err := x509.ParseCertificates(sd.Certificates.Bytes)
+	require.NoError(t, err)
+	require.Len(t, certs, 2)
+	assert.Equal(t, leaf.Raw, certs[0].Raw)
+	assert.Equal(t, ca.Raw, certs[1].Raw)
+
+	_, err = EncodePKCS7(nil)
+	assert.EqualError(t, err, "no certificates to encode")
+}
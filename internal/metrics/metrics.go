@@ -39,6 +39,13 @@ const (
 	NameRequestsTotal         = "requests_total"
 	NameRequestsErrorsTotal   = "requests_errors_total"
 	NameTaintedClients        = "tainted_clients"
+	NameTokenRenewalsTotal    = "token_renewals_total"
+
+	// ResolutionConflict, ResolutionAdopted, and ResolutionRepaired are the
+	// values of the "resolution" label of DestinationConflicts.
+	ResolutionConflict = "conflict"
+	ResolutionAdopted  = "adopted"
+	ResolutionRepaired = "repaired"
 )
 
 var ResourceStatus = prometheus.NewGaugeVec(prometheus.GaugeOpts{
@@ -50,12 +57,72 @@ var ResourceStatus = prometheus.NewGaugeVec(prometheus.GaugeOpts{
 	"namespace",
 })
 
+// DestinationConflicts counts the destination Secrets that were found to exist,
+// without being owned by the syncing resource. The resolution label denotes
+// whether the Secret was adopted, or the sync failed.
+var DestinationConflicts = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: Namespace,
+	Name:      "destination_conflicts_total",
+	Help:      "Number of destination Secrets that exist, but are not owned by the syncing resource",
+}, []string{
+	"kind",
+	"namespace",
+	"resolution",
+})
+
+// StaleDataSeconds is the duration for which a resource has been serving its
+// last synced data, while Vault is unavailable.
+var StaleDataSeconds = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: Namespace,
+	Name:      "stale_data_seconds",
+	Help:      "Duration for which the resource has been serving its last synced data, while Vault is unavailable",
+}, []string{
+	"kind",
+	"name",
+	"namespace",
+})
+
+// SecretDataReadBytes is the size of the secret data read from Vault, by the
+// namespace of the resources it was read for. The read rate is given by its
+// rate().
+var SecretDataReadBytes = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: Namespace,
+	Name:      "secret_data_read_bytes_total",
+	Help:      "Size of the JSON encoded secret data read from Vault",
+}, []string{
+	"namespace",
+})
+
+// SecretDataWrittenBytes is the size of the data of the K8s Secrets that were
+// created or updated, by the namespace of the Secrets.
+var SecretDataWrittenBytes = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: Namespace,
+	Name:      "secret_data_written_bytes_total",
+	Help:      "Size of the data of the K8s Secrets that were created or updated",
+}, []string{
+	"namespace",
+})
+
 func init() {
 	metrics.Registry.MustRegister(
 		ResourceStatus,
+		DestinationConflicts,
+		StaleDataSeconds,
+		SecretDataReadBytes,
+		SecretDataWrittenBytes,
 	)
 }
 
+// ObserveSecretDataWritten adds the size of data, keys included, to the
+// SecretDataWrittenBytes of namespace.
+func ObserveSecretDataWritten(namespace string, data map[string][]byte) {
+	var n int
+	for k, v := range data {
+		n += len(k) + len(v)
+	}
+	SecretDataWrittenBytes.WithLabelValues(namespace).Add(float64(n))
+}
+
 // SetResourceStatus for the given client.Object. If valid is true, then the
 // ResourceStatus gauge will be set 1, else 0.
 func SetResourceStatus(controller string, o client.Object, valid bool) {
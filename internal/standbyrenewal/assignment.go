@@ -0,0 +1,109 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package standbyrenewal delegates the renewal of the VaultDynamicSecrets'
+// leases to the Operator's standby replicas. The leader assigns each renewable
+// lease in a VaultLeaseAssignment, with a deadline after which the standby
+// replicas take over its renewal, so that the lease does not expire while the
+// leader is restarting or backlogged. The leader adopts the standby renewals on
+// its next reconcile.
+package standbyrenewal
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+)
+
+// RenewAfter returns the time after which the standby replicas take over the
+// renewal of a lease, given the leader's own renewal horizon. It is halfway
+// between the leader's renewal and the lease's expiration, leaving the standby
+// replicas the other half of the remaining lease to renew it.
+func RenewAfter(now time.Time, horizon, leaseDuration time.Duration) time.Time {
+	if leaseDuration < horizon {
+		return now.Add(horizon)
+	}
+	return now.Add(horizon + (leaseDuration-horizon)/2)
+}
+
+// Assign the renewal of the current lease of o to the standby replicas, after
+// renewAfter. The VaultLeaseAssignment has the name of o, and is owned by it.
+func Assign(ctx context.Context, c client.Client, o *secretsv1beta1.VaultDynamicSecret, renewAfter time.Time) error {
+	a := &secretsv1beta1.VaultLeaseAssignment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      o.Name,
+			Namespace: o.Namespace,
+		},
+	}
+	_, err := controllerutil.CreateOrUpdate(ctx, c, a, func() error {
+		a.Spec = secretsv1beta1.VaultLeaseAssignmentSpec{
+			VaultDynamicSecretRef: o.Name,
+			LeaseID:               o.Status.SecretLease.ID,
+			Increment:             o.Status.SecretLease.LeaseDuration,
+			RenewAfter:            metav1.NewTime(renewAfter.Truncate(time.Second)),
+		}
+		return controllerutil.SetControllerReference(o, a, c.Scheme())
+	})
+	if err != nil {
+		return fmt.Errorf("failed to assign the lease renewal: %w", err)
+	}
+	return nil
+}
+
+// Unassign the renewal of the lease of o, if it was assigned.
+func Unassign(ctx context.Context, c client.Client, o *secretsv1beta1.VaultDynamicSecret) error {
+	a := &secretsv1beta1.VaultLeaseAssignment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      o.Name,
+			Namespace: o.Namespace,
+		},
+	}
+	if err := c.Delete(ctx, a); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to unassign the lease renewal: %w", err)
+	}
+	return nil
+}
+
+// Renewal of a lease by a standby replica.
+type Renewal struct {
+	// Holder is the identity of the standby replica.
+	Holder string
+	// LeaseDuration returned by the renewal.
+	LeaseDuration int
+	// RenewedAt is the time of the renewal.
+	RenewedAt time.Time
+}
+
+// Adopt returns the renewal of the current lease of o by a standby replica,
+// if it is more recent than the last renewal recorded in the status of o. It
+// returns nil otherwise.
+func Adopt(ctx context.Context, c client.Reader, o *secretsv1beta1.VaultDynamicSecret) (*Renewal, error) {
+	var a secretsv1beta1.VaultLeaseAssignment
+	if err := c.Get(ctx, client.ObjectKeyFromObject(o), &a); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	status := a.Status
+	if status.RenewedAt == nil || status.LeaseID == "" || status.LeaseID != o.Status.SecretLease.ID {
+		return nil, nil
+	}
+	if status.RenewedAt.Unix() <= o.Status.LastRenewalTime {
+		return nil, nil
+	}
+
+	return &Renewal{
+		Holder:        status.Holder,
+		LeaseDuration: status.LeaseDuration,
+		RenewedAt:     status.RenewedAt.Time,
+	}, nil
+}
@@ -0,0 +1,178 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package standbyrenewal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/vault"
+)
+
+// DefaultInterval between the checks for due lease renewals.
+const DefaultInterval = time.Second * 10
+
+var (
+	_ manager.Runnable               = (*Renewer)(nil)
+	_ manager.LeaderElectionRunnable = (*Renewer)(nil)
+)
+
+// Renewer renews the assigned leases that are past their deadline, on the
+// standby replicas. It stops once its replica is elected leader, since the
+// leader renews the leases itself.
+type Renewer struct {
+	// Client used to read the VaultLeaseAssignments and VaultDynamicSecrets,
+	// and to update the assignments' status.
+	Client client.Client
+	// ClientFactory provides the Vault clients of the VaultDynamicSecrets, it
+	// must not persist the clients, since the leader does.
+	ClientFactory vault.ClientFactory
+	// Elected is closed when the replica is elected leader.
+	Elected <-chan struct{}
+	// Identity of the replica, recorded in the assignments that it renews.
+	Identity string
+	// Interval between the checks for due lease renewals, defaults to
+	// DefaultInterval.
+	Interval time.Duration
+	// now returns the current time, for testing.
+	now func() time.Time
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable.
+func (r *Renewer) NeedLeaderElection() bool {
+	return false
+}
+
+// Start implements manager.Runnable. Renewal errors are recorded in the
+// assignments' status, they never stop the Manager.
+func (r *Renewer) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("standbyrenewal")
+	interval := r.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.Elected:
+			logger.Info("Elected leader, stopping standby renewals")
+			return nil
+		case <-ticker.C:
+			if err := r.RenewDue(ctx); err != nil {
+				logger.Error(err, "Failed to renew the due leases")
+			}
+		}
+	}
+}
+
+// RenewDue renews every assigned lease that is due for renewal.
+func (r *Renewer) RenewDue(ctx context.Context) error {
+	var list secretsv1beta1.VaultLeaseAssignmentList
+	if err := r.Client.List(ctx, &list); err != nil {
+		return fmt.Errorf("failed to list the lease assignments: %w", err)
+	}
+
+	now := r.nowFunc()
+	var errs error
+	for i := range list.Items {
+		a := &list.Items[i]
+		if !isDue(a, now) {
+			continue
+		}
+		if err := r.renew(ctx, a); err != nil {
+			errs = errors.Join(errs, err)
+		}
+	}
+	return errs
+}
+
+// isDue returns true if the lease of a must be renewed at now. Once renewed by
+// a standby replica, the lease is renewed again halfway through its new
+// duration, until the leader reassigns it.
+func isDue(a *secretsv1beta1.VaultLeaseAssignment, now time.Time) bool {
+	if a.Spec.LeaseID == "" {
+		return false
+	}
+
+	due := a.Spec.RenewAfter.Time
+	status := a.Status
+	if status.LeaseID == a.Spec.LeaseID && status.RenewedAt != nil {
+		next := status.RenewedAt.Add(time.Duration(status.LeaseDuration) * time.Second / 2)
+		if next.After(due) {
+			due = next
+		}
+	}
+	return !now.Before(due)
+}
+
+// renew the lease of a, and record the outcome in its status. Assignments of
+// a lease that is no longer current are left to the leader.
+func (r *Renewer) renew(ctx context.Context, a *secretsv1beta1.VaultLeaseAssignment) error {
+	logger := log.FromContext(ctx).WithName("standbyrenewal").WithValues(
+		"assignment", client.ObjectKeyFromObject(a), "leaseID", a.Spec.LeaseID)
+
+	var o secretsv1beta1.VaultDynamicSecret
+	key := client.ObjectKey{Namespace: a.Namespace, Name: a.Spec.VaultDynamicSecretRef}
+	if err := r.Client.Get(ctx, key, &o); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if o.GetDeletionTimestamp() != nil || o.Status.SecretLease.ID != a.Spec.LeaseID {
+		return nil
+	}
+
+	var leaseDuration int
+	c, err := r.ClientFactory.Get(ctx, r.Client, &o)
+	if err == nil {
+		var resp vault.Response
+		resp, err = c.Write(ctx, vault.NewWriteRequest("/sys/leases/renew", map[string]any{
+			"lease_id":  a.Spec.LeaseID,
+			"increment": a.Spec.Increment,
+		}))
+		if err == nil {
+			leaseDuration = resp.Secret().LeaseDuration
+		}
+	}
+
+	a.Status.Holder = r.Identity
+	if err != nil {
+		logger.Error(err, "Failed to renew the lease")
+		a.Status.Error = err.Error()
+	} else {
+		logger.Info("Renewed the lease", "leaseDuration", leaseDuration)
+		renewedAt := metav1.NewTime(r.nowFunc())
+		a.Status.LeaseID = a.Spec.LeaseID
+		a.Status.LeaseDuration = leaseDuration
+		a.Status.RenewedAt = &renewedAt
+		a.Status.Error = ""
+	}
+
+	if updateErr := r.Client.Status().Update(ctx, a); updateErr != nil {
+		return errors.Join(err, fmt.Errorf("failed to update the lease assignment %s: %w",
+			client.ObjectKeyFromObject(a), updateErr))
+	}
+	return err
+}
+
+func (r *Renewer) nowFunc() time.Time {
+	if r.now != nil {
+		return r.now()
+	}
+	return time.Now()
+}
@@ -0,0 +1,316 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package standbyrenewal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/internal/testutils"
+	"github.com/hashicorp/vault-secrets-operator/vault"
+)
+
+type recordingClient struct {
+	vault.Client
+	mock *vault.MockRecordingVaultClient
+}
+
+func (c *recordingClient) Write(ctx context.Context, req vault.WriteRequest) (vault.Response, error) {
+	return c.mock.Write(ctx, req)
+}
+
+type staticClientFactory struct {
+	vault.ClientFactory
+	client vault.Client
+}
+
+func (f *staticClientFactory) Get(context.Context, client.Client, client.Object) (vault.Client, error) {
+	return f.client, nil
+}
+
+func newVDS(leaseID string, leaseDuration int, lastRenewal time.Time) *secretsv1beta1.VaultDynamicSecret {
+	return &secretsv1beta1.VaultDynamicSecret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "db",
+			Namespace: "tenant",
+			UID:       "uid",
+		},
+		Status: secretsv1beta1.VaultDynamicSecretStatus{
+			SecretLease: secretsv1beta1.VaultSecretLease{
+				ID:            leaseID,
+				LeaseDuration: leaseDuration,
+				Renewable:     true,
+			},
+			LastRenewalTime: lastRenewal.Unix(),
+		},
+	}
+}
+
+func TestRenewAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1000, 0)
+	assert.Equal(t, now.Add(80*time.Second), RenewAfter(now, 60*time.Second, 100*time.Second))
+	assert.Equal(t, now.Add(60*time.Second), RenewAfter(now, 60*time.Second, 30*time.Second))
+}
+
+func TestAssign(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1000, 0)
+	o := newVDS("lease/1", 100, now)
+	c := testutils.NewFakeClientBuilder().WithObjects(o).Build()
+
+	require.NoError(t, Assign(ctx, c, o, now.Add(time.Minute)))
+	var got secretsv1beta1.VaultLeaseAssignment
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(o), &got))
+	assert.Equal(t, secretsv1beta1.VaultLeaseAssignmentSpec{
+		VaultDynamicSecretRef: "db",
+		LeaseID:               "lease/1",
+		Increment:             100,
+		RenewAfter:            metav1.NewTime(now.Add(time.Minute)),
+	}, got.Spec)
+	require.Len(t, got.OwnerReferences, 1)
+	assert.Equal(t, o.UID, got.OwnerReferences[0].UID)
+
+	o.Status.SecretLease.ID = "lease/2"
+	require.NoError(t, Assign(ctx, c, o, now.Add(2*time.Minute)))
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(o), &got))
+	assert.Equal(t, "lease/2", got.Spec.LeaseID)
+	assert.True(t, got.Spec.RenewAfter.Equal(&metav1.Time{Time: now.Add(2 * time.Minute)}))
+
+	require.NoError(t, Unassign(ctx, c, o))
+	require.NoError(t, Unassign(ctx, c, o))
+	assert.Error(t, c.Get(ctx, client.ObjectKeyFromObject(o), &got))
+}
+
+func TestAdopt(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1000, 0)
+	renewedAt := metav1.NewTime(now.Add(time.Minute))
+	a := &secretsv1beta1.VaultLeaseAssignment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "db",
+			Namespace: "tenant",
+		},
+		Status: secretsv1beta1.VaultLeaseAssignmentStatus{
+			Holder:        "vso-1",
+			LeaseID:       "lease/1",
+			LeaseDuration: 100,
+			RenewedAt:     &renewedAt,
+		},
+	}
+
+	got, err := Adopt(ctx, testutils.NewFakeClientBuilder().Build(), newVDS("lease/1", 100, now))
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	c := testutils.NewFakeClientBuilder().WithObjects(a).Build()
+	got, err = Adopt(ctx, c, newVDS("lease/1", 100, now))
+	require.NoError(t, err)
+	assert.Equal(t, &Renewal{
+		Holder:        "vso-1",
+		LeaseDuration: 100,
+		RenewedAt:     renewedAt.Time,
+	}, got)
+
+	// renewed by the leader since.
+	got, err = Adopt(ctx, c, newVDS("lease/1", 100, now.Add(2*time.Minute)))
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	// renewal of a previous lease.
+	got, err = Adopt(ctx, c, newVDS("lease/2", 100, now))
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func Test_isDue(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1000, 0)
+	renewedAt := metav1.NewTime(now.Add(-40 * time.Second))
+	tests := []struct {
+		name   string
+		spec   secretsv1beta1.VaultLeaseAssignmentSpec
+		status secretsv1beta1.VaultLeaseAssignmentStatus
+		want   bool
+	}{
+		{
+			name: "before-deadline",
+			spec: secretsv1beta1.VaultLeaseAssignmentSpec{
+				LeaseID:    "lease/1",
+				RenewAfter: metav1.NewTime(now.Add(time.Second)),
+			},
+		},
+		{
+			name: "past-deadline",
+			spec: secretsv1beta1.VaultLeaseAssignmentSpec{
+				LeaseID:    "lease/1",
+				RenewAfter: metav1.NewTime(now),
+			},
+			want: true,
+		},
+		{
+			name: "no-lease",
+			spec: secretsv1beta1.VaultLeaseAssignmentSpec{
+				RenewAfter: metav1.NewTime(now.Add(-time.Second)),
+			},
+		},
+		{
+			name: "recently-renewed",
+			spec: secretsv1beta1.VaultLeaseAssignmentSpec{
+				LeaseID:    "lease/1",
+				RenewAfter: metav1.NewTime(now.Add(-time.Minute)),
+			},
+			status: secretsv1beta1.VaultLeaseAssignmentStatus{
+				LeaseID:       "lease/1",
+				LeaseDuration: 100,
+				RenewedAt:     &renewedAt,
+			},
+		},
+		{
+			name: "renewed-halfway-through",
+			spec: secretsv1beta1.VaultLeaseAssignmentSpec{
+				LeaseID:    "lease/1",
+				RenewAfter: metav1.NewTime(now.Add(-time.Minute)),
+			},
+			status: secretsv1beta1.VaultLeaseAssignmentStatus{
+				LeaseID:       "lease/1",
+				LeaseDuration: 80,
+				RenewedAt:     &renewedAt,
+			},
+			want: true,
+		},
+		{
+			name: "renewed-previous-lease",
+			spec: secretsv1beta1.VaultLeaseAssignmentSpec{
+				LeaseID:    "lease/2",
+				RenewAfter: metav1.NewTime(now.Add(-time.Minute)),
+			},
+			status: secretsv1beta1.VaultLeaseAssignmentStatus{
+				LeaseID:       "lease/1",
+				LeaseDuration: 100,
+				RenewedAt:     &renewedAt,
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			a := &secretsv1beta1.VaultLeaseAssignment{
+				Spec:   tt.spec,
+				Status: tt.status,
+			}
+			assert.Equal(t, tt.want, isDue(a, now))
+		})
+	}
+}
+
+func TestRenewer_RenewDue(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1000, 0)
+	o := newVDS("lease/1", 100, now.Add(-time.Minute))
+	newAssignment := func(name, leaseID string, renewAfter time.Time) *secretsv1beta1.VaultLeaseAssignment {
+		return &secretsv1beta1.VaultLeaseAssignment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "tenant",
+			},
+			Spec: secretsv1beta1.VaultLeaseAssignmentSpec{
+				VaultDynamicSecretRef: "db",
+				LeaseID:               leaseID,
+				Increment:             100,
+				RenewAfter:            metav1.NewTime(renewAfter),
+			},
+		}
+	}
+	due := newAssignment("db", "lease/1", now.Add(-time.Second))
+	notDue := newAssignment("not-due", "lease/1", now.Add(time.Second))
+	stale := newAssignment("stale", "lease/0", now.Add(-time.Second))
+
+	c := testutils.NewFakeClientBuilder().
+		WithObjects(o, due, notDue, stale).
+		WithStatusSubresource(due, notDue, stale).
+		Build()
+	mock := &vault.MockRecordingVaultClient{
+		WriteResponses: map[string][]vault.Response{
+			"/sys/leases/renew": {
+				vault.NewDefaultResponse(&api.Secret{
+					LeaseID:       "lease/1",
+					LeaseDuration: 90,
+					Renewable:     true,
+				}),
+			},
+		},
+	}
+	r := &Renewer{
+		Client: c,
+		ClientFactory: &staticClientFactory{
+			client: &recordingClient{mock: mock},
+		},
+		Identity: "vso-1",
+		now: func() time.Time {
+			return now
+		},
+	}
+
+	require.NoError(t, r.RenewDue(ctx))
+	require.Len(t, mock.Requests, 1)
+	assert.Equal(t, map[string]any{
+		"lease_id":  "lease/1",
+		"increment": 100,
+	}, mock.Requests[0].Params)
+
+	var got secretsv1beta1.VaultLeaseAssignment
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(due), &got))
+	require.NotNil(t, got.Status.RenewedAt)
+	assert.True(t, got.Status.RenewedAt.Equal(&metav1.Time{Time: now}))
+	assert.Equal(t, "vso-1", got.Status.Holder)
+	assert.Equal(t, "lease/1", got.Status.LeaseID)
+	assert.Equal(t, 90, got.Status.LeaseDuration)
+	assert.Empty(t, got.Status.Error)
+
+	for _, a := range []*secretsv1beta1.VaultLeaseAssignment{notDue, stale} {
+		require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(a), &got))
+		assert.Empty(t, got.Status)
+	}
+
+	// not due again until halfway through the renewed lease.
+	require.NoError(t, r.RenewDue(ctx))
+	assert.Len(t, mock.Requests, 1)
+
+	// renewal errors are recorded.
+	r.now = func() time.Time {
+		return now.Add(time.Minute)
+	}
+	mock.WriteResponses["/sys/leases/renew"] = nil
+	assert.Error(t, r.RenewDue(ctx))
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(due), &got))
+	assert.Equal(t, "no more responses for /sys/leases/renew", got.Status.Error)
+	assert.Equal(t, 90, got.Status.LeaseDuration)
+}
+
+func TestRenewer_Start(t *testing.T) {
+	t.Parallel()
+
+	elected := make(chan struct{})
+	close(elected)
+	r := &Renewer{
+		Client:   testutils.NewFakeClientBuilder().Build(),
+		Elected:  elected,
+		Interval: time.Hour,
+	}
+	assert.False(t, r.NeedLeaderElection())
+	assert.NoError(t, r.Start(context.Background()))
+}
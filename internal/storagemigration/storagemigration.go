@@ -0,0 +1,239 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package storagemigration rewrites the stored objects of the Operator's
+// CustomResourceDefinitions in their current storage version. Objects are
+// stored in the storage version that was current when they were last written,
+// so after the storage version changes, older objects would otherwise be
+// converted on every read, and the previous version could never be removed
+// from the CRD.
+//
+// Once all the objects of a CRD are rewritten, its status.storedVersions is
+// reset to only hold the storage version. Only the CRDs whose storedVersions
+// hold another version are migrated, so the objects are rewritten once after
+// each storage version change, rather than on every start.
+//
+// The migration is opt-in, see the Operator's --storage-version-migration
+// flag, since it rewrites the stored objects, and requires the permission to
+// update them, and the CRDs' status.
+package storagemigration
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	vsometrics "github.com/hashicorp/vault-secrets-operator/internal/metrics"
+)
+
+// listLimit is the maximum number of objects listed per request.
+const listLimit = 500
+
+var (
+	_ manager.Runnable               = (*Migrator)(nil)
+	_ manager.LeaderElectionRunnable = (*Migrator)(nil)
+
+	// Objects is the number of stored objects of each resource that are
+	// migrated, it is only known once the objects are listed.
+	Objects = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: vsometrics.Namespace,
+		Name:      "storage_migration_objects",
+		Help:      "Number of stored objects of the resource to migrate to its storage version",
+	}, []string{
+		"resource",
+	})
+	// MigratedObjects is the number of stored objects of each resource that
+	// were rewritten in their storage version.
+	MigratedObjects = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: vsometrics.Namespace,
+		Name:      "storage_migration_migrated_objects",
+		Help:      "Number of stored objects of the resource that were migrated to its storage version",
+	}, []string{
+		"resource",
+	})
+	// Complete is set to 1 once all the stored objects of a resource are in
+	// its storage version.
+	Complete = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: vsometrics.Namespace,
+		Name:      "storage_migration_complete",
+		Help:      "Set to 1 once all the stored objects of the resource are in its storage version",
+	}, []string{
+		"resource",
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(Objects, MigratedObjects, Complete)
+}
+
+// Migrator migrates the stored objects of the Operator's CRDs to their storage
+// version, in the background. It only runs on the leader, and returns once
+// every CRD is migrated.
+type Migrator struct {
+	// Client must not be backed by the Manager's cache, since the CRDs are
+	// only read once.
+	Client client.Client
+	// Group of the CRDs to migrate, defaults to the Operator's API group.
+	Group string
+	// Backoff between failed migration attempts, defaults to
+	// DefaultBackoff.
+	Backoff *wait.Backoff
+}
+
+// DefaultBackoff between failed migration attempts.
+var DefaultBackoff = wait.Backoff{
+	Duration: time.Second * 5,
+	Factor:   2,
+	Jitter:   0.1,
+	Steps:    10,
+	Cap:      time.Minute * 5,
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable.
+func (m *Migrator) NeedLeaderElection() bool {
+	return true
+}
+
+// Start implements manager.Runnable. Migration errors are retried with
+// backoff, they never stop the Manager.
+func (m *Migrator) Start(ctx context.Context) error {
+	logger := ctrl.Log.WithName("storagemigration")
+	backoff := DefaultBackoff
+	if m.Backoff != nil {
+		backoff = *m.Backoff
+	}
+
+	err := wait.ExponentialBackoffWithContext(ctx, backoff, func(ctx context.Context) (bool, error) {
+		if err := m.Migrate(ctx); err != nil {
+			logger.Error(err, "Storage version migration failed, retrying")
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil && ctx.Err() == nil {
+		logger.Error(err, "Storage version migration did not complete")
+	}
+	return nil
+}
+
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions/status,resourceNames=clustervaultauths.secrets.hashicorp.com;hcpauths.secrets.hashicorp.com;hcpvaultsecretsapps.secrets.hashicorp.com;secretsyncledgers.secrets.hashicorp.com;secrettransformations.secrets.hashicorp.com;vaultauthglobals.secrets.hashicorp.com;vaultauths.secrets.hashicorp.com;vaultconnections.secrets.hashicorp.com;vaultconsulsecrets.secrets.hashicorp.com;vaultdynamicsecrets.secrets.hashicorp.com;vaultgenericsecrets.secrets.hashicorp.com;vaultidentitytokens.secrets.hashicorp.com;vaultkubernetessecrets.secrets.hashicorp.com;vaultldapsecrets.secrets.hashicorp.com;vaultleaseassignments.secrets.hashicorp.com;vaultmongodbatlassecrets.secrets.hashicorp.com;vaultnomadsecrets.secrets.hashicorp.com;vaultpkisecrets.secrets.hashicorp.com;vaultrabbitmqsecrets.secrets.hashicorp.com;vaultsecretgroups.secrets.hashicorp.com;vaultstaticsecrets.secrets.hashicorp.com;vaultterraformcloudsecrets.secrets.hashicorp.com;vaulttransitsecrets.secrets.hashicorp.com;vaultwrappedsecrets.secrets.hashicorp.com,verbs=update
+// +kubebuilder:rbac:groups=secrets.hashicorp.com,resources=clustervaultauths;hcpauths;hcpvaultsecretsapps;secretsyncledgers;secrettransformations;vaultauthglobals;vaultauths;vaultconnections;vaultconsulsecrets;vaultdynamicsecrets;vaultgenericsecrets;vaultidentitytokens;vaultkubernetessecrets;vaultldapsecrets;vaultleaseassignments;vaultmongodbatlassecrets;vaultnomadsecrets;vaultpkisecrets;vaultrabbitmqsecrets;vaultsecretgroups;vaultstaticsecrets;vaultterraformcloudsecrets;vaulttransitsecrets;vaultwrappedsecrets,verbs=list;update
+
+// Migrate migrates the stored objects of all the CRDs of m.Group that have
+// objects stored in a version other than their storage version.
+func (m *Migrator) Migrate(ctx context.Context) error {
+	group := m.Group
+	if group == "" {
+		group = secretsv1beta1.GroupVersion.Group
+	}
+
+	var crds apiextensionsv1.CustomResourceDefinitionList
+	if err := m.Client.List(ctx, &crds); err != nil {
+		return fmt.Errorf("failed to list the CustomResourceDefinitions: %w", err)
+	}
+
+	for _, crd := range crds.Items {
+		if crd.Spec.Group != group {
+			continue
+		}
+		if err := m.migrateCRD(ctx, &crd); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateCRD rewrites all the stored objects of crd in its storage version,
+// then resets its stored versions.
+func (m *Migrator) migrateCRD(ctx context.Context, crd *apiextensionsv1.CustomResourceDefinition) error {
+	version := storageVersion(crd)
+	if version == "" {
+		return fmt.Errorf("CustomResourceDefinition %s has no storage version", crd.Name)
+	}
+
+	resource := crd.Name
+	if slices.Equal(crd.Status.StoredVersions, []string{version}) {
+		Complete.WithLabelValues(resource).Set(1)
+		return nil
+	}
+
+	logger := ctrl.Log.WithName("storagemigration").WithValues(
+		"resource", resource, "storageVersion", version, "storedVersions", crd.Status.StoredVersions)
+	logger.Info("Migrating the stored objects to the storage version")
+	Complete.WithLabelValues(resource).Set(0)
+	MigratedObjects.WithLabelValues(resource).Set(0)
+
+	gvk := schema.GroupVersionKind{
+		Group:   crd.Spec.Group,
+		Version: version,
+		Kind:    crd.Spec.Names.ListKind,
+	}
+	var migrated int
+	var cont string
+	for {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk)
+		if err := m.Client.List(ctx, list, client.Limit(listLimit), client.Continue(cont)); err != nil {
+			return fmt.Errorf("failed to list %s: %w", resource, err)
+		}
+
+		total := migrated + len(list.Items)
+		if remaining := list.GetRemainingItemCount(); remaining != nil {
+			total += int(*remaining)
+		}
+		Objects.WithLabelValues(resource).Set(float64(total))
+
+		for _, obj := range list.Items {
+			// an unchanged object is still written, since its encoding in the
+			// storage version differs from the stored one.
+			if err := m.Client.Update(ctx, &obj); err != nil {
+				// objects that were written since they were listed are
+				// already stored in the storage version.
+				if !apierrors.IsConflict(err) && !apierrors.IsNotFound(err) {
+					return fmt.Errorf("failed to migrate %s %s: %w",
+						resource, client.ObjectKeyFromObject(&obj), err)
+				}
+			}
+			migrated++
+			MigratedObjects.WithLabelValues(resource).Set(float64(migrated))
+		}
+
+		cont = list.GetContinue()
+		if cont == "" {
+			break
+		}
+	}
+
+	crd.Status.StoredVersions = []string{version}
+	if err := m.Client.Status().Update(ctx, crd); err != nil {
+		return fmt.Errorf("failed to update the stored versions of %s: %w", resource, err)
+	}
+
+	Complete.WithLabelValues(resource).Set(1)
+	logger.Info("Migrated the stored objects to the storage version", "objects", migrated)
+	return nil
+}
+
+// storageVersion returns the name of the storage version of crd.
+func storageVersion(crd *apiextensionsv1.CustomResourceDefinition) string {
+	for _, v := range crd.Spec.Versions {
+		if v.Storage {
+			return v.Name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,145 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package storagemigration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+)
+
+func newCRD(group, plural, kind string, storedVersions ...string) *apiextensionsv1.CustomResourceDefinition {
+	return &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: plural + "." + group,
+		},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: group,
+			Names: apiextensionsv1.CustomResourceDefinitionNames{
+				Plural:   plural,
+				Kind:     kind,
+				ListKind: kind + "List",
+			},
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+				{
+					Name:   "v1alpha1",
+					Served: true,
+				},
+				{
+					Name:    "v1beta1",
+					Served:  true,
+					Storage: true,
+				},
+			},
+		},
+		Status: apiextensionsv1.CustomResourceDefinitionStatus{
+			StoredVersions: storedVersions,
+		},
+	}
+}
+
+// gather returns the value of the metric c by resource.
+func gather(t *testing.T, c prometheus.Collector) map[string]float64 {
+	t.Helper()
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(c)
+	mfs, err := reg.Gather()
+	require.NoError(t, err)
+
+	ret := make(map[string]float64)
+	for _, mf := range mfs {
+		for _, m := range mf.GetMetric() {
+			ret[m.GetLabel()[0].GetValue()] = m.GetGauge().GetValue()
+		}
+	}
+	return ret
+}
+
+func TestMigrator_Migrate(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	utilruntime.Must(apiextensionsv1.AddToScheme(scheme))
+	utilruntime.Must(secretsv1beta1.AddToScheme(scheme))
+
+	vssCRD := newCRD(secretsv1beta1.GroupVersion.Group, "vaultstaticsecrets", "VaultStaticSecret",
+		"v1alpha1", "v1beta1")
+	vdsCRD := newCRD(secretsv1beta1.GroupVersion.Group, "vaultdynamicsecrets", "VaultDynamicSecret",
+		"v1beta1")
+	otherCRD := newCRD("example.com", "others", "Other", "v1alpha1", "v1beta1")
+	objs := []client.Object{vssCRD, vdsCRD, otherCRD}
+	for _, name := range []string{"foo", "bar"} {
+		objs = append(objs, &secretsv1beta1.VaultStaticSecret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+			},
+		})
+	}
+	objs = append(objs, &secretsv1beta1.VaultDynamicSecret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "baz",
+			Namespace: "default",
+		},
+	})
+
+	var updated []string
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(vssCRD, vdsCRD, otherCRD).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				updated = append(updated, obj.GetObjectKind().GroupVersionKind().Kind+"/"+obj.GetName())
+				return c.Update(ctx, obj, opts...)
+			},
+		}).
+		Build()
+
+	m := &Migrator{
+		Client: c,
+	}
+	require.NoError(t, m.Migrate(ctx))
+	assert.ElementsMatch(t, []string{"VaultStaticSecret/foo", "VaultStaticSecret/bar"}, updated)
+
+	getStoredVersions := func(crd *apiextensionsv1.CustomResourceDefinition) []string {
+		t.Helper()
+		var got apiextensionsv1.CustomResourceDefinition
+		require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(crd), &got))
+		return got.Status.StoredVersions
+	}
+	assert.Equal(t, []string{"v1beta1"}, getStoredVersions(vssCRD))
+	assert.Equal(t, []string{"v1beta1"}, getStoredVersions(vdsCRD))
+	assert.Equal(t, []string{"v1alpha1", "v1beta1"}, getStoredVersions(otherCRD))
+
+	assert.Equal(t, map[string]float64{vssCRD.Name: 2}, gather(t, Objects))
+	assert.Equal(t, map[string]float64{vssCRD.Name: 2}, gather(t, MigratedObjects))
+	assert.Equal(t, map[string]float64{vssCRD.Name: 1, vdsCRD.Name: 1}, gather(t, Complete))
+
+	// once migrated, nothing is rewritten anymore.
+	updated = nil
+	require.NoError(t, m.Migrate(ctx))
+	assert.Empty(t, updated)
+}
+
+func Test_storageVersion(t *testing.T) {
+	t.Parallel()
+
+	crd := newCRD("example.com", "others", "Other")
+	assert.Equal(t, "v1beta1", storageVersion(crd))
+
+	crd.Spec.Versions[1].Storage = false
+	assert.Equal(t, "", storageVersion(crd))
+}
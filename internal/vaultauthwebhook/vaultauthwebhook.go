@@ -0,0 +1,75 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package vaultauthwebhook validates the VaultAuths and the ClusterVaultAuths
+// upon admission, with a validating webhook. Only the templates of their params are validated, since
+// the CRD's schema cannot, the remaining fields are validated by the schema and
+// by the VaultAuth controller.
+package vaultauthwebhook
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/common"
+	"github.com/hashicorp/vault-secrets-operator/vault"
+)
+
+var _ admission.CustomValidator = (*Validator)(nil)
+
+// Validator rejects the VaultAuths and the ClusterVaultAuths whose params or
+// loginParams templates cannot be rendered.
+type Validator struct{}
+
+// SetupWebhookWithManager registers the validating webhook of every validated
+// kind with mgr.
+func (v *Validator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	for _, obj := range []client.Object{
+		&secretsv1beta1.VaultAuth{},
+		&secretsv1beta1.ClusterVaultAuth{},
+	} {
+		if err := ctrl.NewWebhookManagedBy(mgr).For(obj).WithValidator(v).Complete(); err != nil {
+			return fmt.Errorf("failed to register the %T webhook: %w", obj, err)
+		}
+	}
+	return nil
+}
+
+// ValidateCreate implements admission.CustomValidator.
+func (v *Validator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, v.validate(obj)
+}
+
+// ValidateUpdate implements admission.CustomValidator.
+func (v *Validator) ValidateUpdate(_ context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	return nil, v.validate(newObj)
+}
+
+// ValidateDelete implements admission.CustomValidator, deletions are always
+// allowed.
+func (v *Validator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *Validator) validate(obj runtime.Object) error {
+	var o *secretsv1beta1.VaultAuth
+	switch t := obj.(type) {
+	case *secretsv1beta1.VaultAuth:
+		o = t
+	case *secretsv1beta1.ClusterVaultAuth:
+		o = common.VaultAuthFromClusterVaultAuth(t)
+	default:
+		return fmt.Errorf("unsupported type %T", obj)
+	}
+
+	// the templates are rendered with the VaultAuth's namespace, the
+	// namespace of the resources that authenticate with it is only known upon
+	// login.
+	return vault.ValidateLoginParams(o)
+}
@@ -0,0 +1,97 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package vaultauthwebhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+)
+
+func TestValidator(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	tests := []struct {
+		name    string
+		params  map[string]string
+		wantErr string
+	}{
+		{
+			name: "no-params",
+		},
+		{
+			name: "valid",
+			params: map[string]string{
+				"role":      "app-{{ .Namespace }}",
+				"workspace": `{{ index .Labels "team" }}`,
+			},
+		},
+		{
+			name: "invalid-template",
+			params: map[string]string{
+				"role": "app-{{ .Namespace",
+			},
+			wantErr: `invalid params template "role"`,
+		},
+		{
+			name: "unknown-field",
+			params: map[string]string{
+				"role": "app-{{ .Unknown }}",
+			},
+			wantErr: `failed to render params template "role"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			o := &secretsv1beta1.VaultAuth{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo",
+					Namespace: "baz",
+					Labels: map[string]string{
+						"team": "qux",
+					},
+				},
+				Spec: secretsv1beta1.VaultAuthSpec{
+					Method: "custom",
+					Mount:  "custom",
+					Params: tt.params,
+				},
+			}
+
+			v := &Validator{}
+			_, errCreate := v.ValidateCreate(ctx, o)
+			_, errUpdate := v.ValidateUpdate(ctx, &secretsv1beta1.VaultAuth{}, o)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, errCreate, tt.wantErr)
+				assert.ErrorContains(t, errUpdate, tt.wantErr)
+			} else {
+				assert.NoError(t, errCreate)
+				assert.NoError(t, errUpdate)
+			}
+
+			_, err := v.ValidateDelete(ctx, o)
+			assert.NoError(t, err)
+
+			_, err = v.ValidateCreate(ctx, &secretsv1beta1.ClusterVaultAuth{
+				ObjectMeta: o.ObjectMeta,
+				Spec:       o.Spec,
+			})
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	_, err := (&Validator{}).ValidateCreate(ctx, &secretsv1beta1.VaultConnection{})
+	assert.EqualError(t, err, "unsupported type *v1beta1.VaultConnection")
+}
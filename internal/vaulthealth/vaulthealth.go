@@ -0,0 +1,219 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package vaulthealth exports the Vault-side state of every VaultConnection as
+// metrics, i.e. whether Vault is sealed, whether the node that served the
+// request is a standby, and the lag of its replication from the primary
+// cluster. This allows the sync latencies to be correlated with the state of
+// Vault.
+package vaulthealth
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/consts"
+	vsometrics "github.com/hashicorp/vault-secrets-operator/internal/metrics"
+	"github.com/hashicorp/vault-secrets-operator/vault"
+)
+
+const (
+	// ReplicationTypePerformance and ReplicationTypeDR are the values of the
+	// "type" label of ReplicationLagSeconds.
+	ReplicationTypePerformance = "performance"
+	ReplicationTypeDR          = "dr"
+
+	replicationModeSecondary = "secondary"
+)
+
+var (
+	// Sealed is set to 1 when Vault is sealed.
+	Sealed = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: vsometrics.Namespace,
+		Subsystem: "vault",
+		Name:      "sealed",
+		Help:      "Set to 1 when Vault is sealed",
+	}, []string{
+		vsometrics.LabelVaultConnection,
+	})
+	// Standby is set to 1 when the Vault node serving the VaultConnection is a
+	// standby, or a performance standby.
+	Standby = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: vsometrics.Namespace,
+		Subsystem: "vault",
+		Name:      "standby",
+		Help:      "Set to 1 when the Vault node is a standby, or a performance standby",
+	}, []string{
+		vsometrics.LabelVaultConnection,
+	})
+	// ReplicationLagSeconds is the age of the last replication canary of the
+	// primary cluster that was seen by the secondary cluster. It is only set
+	// when the Vault cluster is a secondary of the replication type.
+	ReplicationLagSeconds = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: vsometrics.Namespace,
+		Subsystem: "vault",
+		Name:      "replication_lag_seconds",
+		Help:      "Age of the last replication canary of the primary cluster seen by the secondary cluster",
+	}, []string{
+		vsometrics.LabelVaultConnection,
+		"type",
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		Sealed,
+		Standby,
+		ReplicationLagSeconds,
+	)
+}
+
+var _ manager.Runnable = (*Poller)(nil)
+
+// Poller polls the health and the replication status of the Vault server of
+// every VaultConnection.
+type Poller struct {
+	Client   ctrlclient.Client
+	Interval time.Duration
+	// connections holds the VaultConnections whose metrics were set on the
+	// previous poll, so that the metrics of the deleted ones are removed.
+	connections map[string]bool
+}
+
+// Start polling, blocking until ctx is done.
+func (p *Poller) Start(ctx context.Context) error {
+	ticker := time.NewTicker(p.Interval)
+	defer ticker.Stop()
+
+	for {
+		p.Poll(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection returns true, so that the metrics are only exported by the
+// leader.
+func (p *Poller) NeedLeaderElection() bool {
+	return true
+}
+
+// Poll the Vault server of every VaultConnection once.
+func (p *Poller) Poll(ctx context.Context) {
+	logger := log.FromContext(ctx).WithName("vaulthealth")
+
+	var list secretsv1beta1.VaultConnectionList
+	if err := p.Client.List(ctx, &list); err != nil {
+		logger.Error(err, "Failed to list the VaultConnections")
+		return
+	}
+
+	connections := make(map[string]bool, len(list.Items))
+	for _, o := range list.Items {
+		key := ctrlclient.ObjectKeyFromObject(&o).String()
+		connections[key] = true
+
+		cfg, err := vault.NewClientConfigFromConnObj(&o, "")
+		if err != nil {
+			logger.Error(err, "Invalid VaultConnection", "connection", key)
+			continue
+		}
+		c, err := vault.MakeVaultClient(ctx, cfg, p.Client)
+		if err != nil {
+			logger.Error(err, "Failed to construct the Vault client", "connection", key)
+			continue
+		}
+		p.poll(ctx, c, key)
+	}
+
+	for key := range p.connections {
+		if !connections[key] {
+			deleteMetrics(key)
+		}
+	}
+	p.connections = connections
+}
+
+func (p *Poller) poll(ctx context.Context, c *api.Client, key string) {
+	logger := log.FromContext(ctx).WithName("vaulthealth").WithValues("connection", key)
+
+	health, err := c.Sys().HealthWithContext(ctx)
+	if err != nil {
+		logger.Error(err, "Failed to get the health of Vault")
+		return
+	}
+	Sealed.WithLabelValues(key).Set(boolValue(health.Sealed))
+	Standby.WithLabelValues(key).Set(boolValue(health.Standby || health.PerformanceStandby))
+
+	// the replication status is only available on Vault Enterprise, and it may
+	// be denied by the policies.
+	status, err := c.Sys().ReplicationStatusWithContext(ctx, "")
+	if err != nil {
+		logger.V(consts.LogLevelDebug).Info("Failed to get the replication status of Vault", "err", err)
+		ReplicationLagSeconds.DeletePartialMatch(prometheus.Labels{
+			vsometrics.LabelVaultConnection: key,
+		})
+		return
+	}
+	setReplicationLag(key, ReplicationTypePerformance, status.Performance)
+	setReplicationLag(key, ReplicationTypeDR, status.DR)
+}
+
+// setReplicationLag sets the ReplicationLagSeconds of the replication type
+// from the largest canary age of the primaries. It is removed if the cluster
+// is not a secondary, or if the lag is not reported, e.g. by older versions of
+// Vault.
+func setReplicationLag(key, typ string, status api.ReplicationStatusGenericResponse) {
+	lag, ok := replicationLag(status)
+	if !ok {
+		ReplicationLagSeconds.DeleteLabelValues(key, typ)
+		return
+	}
+	ReplicationLagSeconds.WithLabelValues(key, typ).Set(lag.Seconds())
+}
+
+func replicationLag(status api.ReplicationStatusGenericResponse) (time.Duration, bool) {
+	if status.Mode != replicationModeSecondary {
+		return 0, false
+	}
+
+	var lag time.Duration
+	var ok bool
+	for _, primary := range status.Primaries {
+		ms, err := strconv.ParseInt(primary.ReplicationPrimaryCanaryAgeMillis, 10, 64)
+		if err != nil {
+			continue
+		}
+		ok = true
+		lag = max(lag, time.Duration(ms)*time.Millisecond)
+	}
+
+	return lag, ok
+}
+
+func deleteMetrics(key string) {
+	Sealed.DeleteLabelValues(key)
+	Standby.DeleteLabelValues(key)
+	ReplicationLagSeconds.DeletePartialMatch(prometheus.Labels{
+		vsometrics.LabelVaultConnection: key,
+	})
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,170 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package vaulthealth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/internal/testutils"
+)
+
+// gather returns the values of the metrics by name, and by their joined label
+// values, sorted by label name.
+func gather(t *testing.T) map[string]map[string]float64 {
+	t.Helper()
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(Sealed, Standby, ReplicationLagSeconds)
+	mfs, err := reg.Gather()
+	require.NoError(t, err)
+
+	ret := make(map[string]map[string]float64)
+	for _, mf := range mfs {
+		values := make(map[string]float64)
+		for _, m := range mf.GetMetric() {
+			var labels []string
+			for _, l := range m.GetLabel() {
+				labels = append(labels, l.GetValue())
+			}
+			values[strings.Join(labels, ",")] = m.GetGauge().GetValue()
+		}
+		ret[mf.GetName()] = values
+	}
+	return ret
+}
+
+func TestPoller_Poll(t *testing.T) {
+	var replicationStatus string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/v1/sys/health":
+			_, _ = w.Write([]byte(`{"initialized":true,"sealed":false,"standby":false,"performance_standby":true}`))
+		case "/v1/sys/replication/status":
+			if replicationStatus == "" {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+				return
+			}
+			_, _ = w.Write([]byte(replicationStatus))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	conn := &secretsv1beta1.VaultConnection{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "default",
+			Namespace: "vso",
+		},
+		Spec: secretsv1beta1.VaultConnectionSpec{
+			Address: srv.URL,
+		},
+	}
+	client := testutils.NewFakeClientBuilder().WithObjects(conn).Build()
+	p := &Poller{
+		Client:   client,
+		Interval: time.Minute,
+	}
+	t.Cleanup(func() {
+		deleteMetrics("vso/default")
+	})
+
+	ctx := context.Background()
+	replicationStatus = `{"data":{` +
+		`"performance":{"mode":"secondary","primaries":[` +
+		`{"replication_primary_canary_age_ms":"1500"},{"replication_primary_canary_age_ms":"2500"}]},` +
+		`"dr":{"mode":"primary"}}}`
+	p.Poll(ctx)
+	assert.Equal(t, map[string]map[string]float64{
+		"vso_vault_sealed": {
+			"vso/default": 0,
+		},
+		"vso_vault_standby": {
+			"vso/default": 1,
+		},
+		"vso_vault_replication_lag_seconds": {
+			"performance,vso/default": 2.5,
+		},
+	}, gather(t))
+
+	// the replication status is denied.
+	replicationStatus = ""
+	p.Poll(ctx)
+	assert.Equal(t, map[string]map[string]float64{
+		"vso_vault_sealed": {
+			"vso/default": 0,
+		},
+		"vso_vault_standby": {
+			"vso/default": 1,
+		},
+	}, gather(t))
+
+	// the metrics of the deleted VaultConnections are removed.
+	require.NoError(t, client.Delete(ctx, conn))
+	p.Poll(ctx)
+	assert.Empty(t, gather(t))
+}
+
+func Test_replicationLag(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		status  api.ReplicationStatusGenericResponse
+		want    time.Duration
+		wantSet bool
+	}{
+		{
+			name: "secondary",
+			status: api.ReplicationStatusGenericResponse{
+				Mode: "secondary",
+				Primaries: []api.ClusterInfo{
+					{ReplicationPrimaryCanaryAgeMillis: "250"},
+				},
+			},
+			want:    250 * time.Millisecond,
+			wantSet: true,
+		},
+		{
+			name: "secondary-not-reported",
+			status: api.ReplicationStatusGenericResponse{
+				Mode: "secondary",
+				Primaries: []api.ClusterInfo{
+					{},
+				},
+			},
+		},
+		{
+			name: "primary",
+			status: api.ReplicationStatusGenericResponse{
+				Mode: "primary",
+			},
+		},
+		{
+			name: "disabled",
+			status: api.ReplicationStatusGenericResponse{
+				Mode: "disabled",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := replicationLag(tt.status)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantSet, ok)
+		})
+	}
+}
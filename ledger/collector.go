@@ -0,0 +1,123 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package ledger
+
+import (
+	"context"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+)
+
+// DefaultRetention is the period for which a SecretSyncLedger is retained after
+// its owner has been deleted, if none is configured.
+const DefaultRetention = 90 * 24 * time.Hour
+
+var _ manager.Runnable = (*Collector)(nil)
+
+// Collector deletes the SecretSyncLedgers of the deleted syncable secret
+// resources. The ledgers have no ownerReference, since they must outlive their
+// owner, so a ledger is only deleted once its owner no longer exists, and its
+// last entry is older than the Retention.
+type Collector struct {
+	// Client should not be cached, since the owners are looked up by kind.
+	Client ctrlclient.Client
+	// Retention is the period for which a ledger is retained after its last
+	// entry, once its owner has been deleted.
+	Retention time.Duration
+	// Interval between two collections.
+	Interval time.Duration
+	// now is used for testing.
+	now func() time.Time
+}
+
+// Start collecting, blocking until ctx is done.
+func (c *Collector) Start(ctx context.Context) error {
+	ticker := time.NewTicker(c.Interval)
+	defer ticker.Stop()
+
+	for {
+		c.Collect(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection returns true, so that the ledgers are only collected by
+// the leader.
+func (c *Collector) NeedLeaderElection() bool {
+	return true
+}
+
+// Collect deletes the ledgers that are past their retention, once.
+func (c *Collector) Collect(ctx context.Context) {
+	logger := log.FromContext(ctx).WithName("ledger")
+
+	var list secretsv1beta1.SecretSyncLedgerList
+	if err := c.Client.List(ctx, &list); err != nil {
+		logger.Error(err, "Failed to list the SecretSyncLedgers")
+		return
+	}
+
+	now := time.Now
+	if c.now != nil {
+		now = c.now
+	}
+
+	for i := range list.Items {
+		o := &list.Items[i]
+		if l := len(o.Spec.Entries); l > 0 && now().Sub(o.Spec.Entries[l-1].Time.Time) < c.Retention {
+			continue
+		}
+
+		exists, err := c.ownerExists(ctx, o)
+		if err != nil {
+			logger.Error(err, "Failed to get the owner of the SecretSyncLedger",
+				"ledger", ctrlclient.ObjectKeyFromObject(o))
+			continue
+		}
+		if exists {
+			continue
+		}
+
+		logger.Info("Deleting the SecretSyncLedger of a deleted resource",
+			"ledger", ctrlclient.ObjectKeyFromObject(o), "owner", o.Spec.Owner)
+		if err := c.Client.Delete(ctx, o); ctrlclient.IgnoreNotFound(err) != nil {
+			logger.Error(err, "Failed to delete the SecretSyncLedger",
+				"ledger", ctrlclient.ObjectKeyFromObject(o))
+		}
+	}
+}
+
+// ownerExists returns true if the ledger's owner, with the same UID, exists.
+func (c *Collector) ownerExists(ctx context.Context, o *secretsv1beta1.SecretSyncLedger) (bool, error) {
+	gv, err := schema.ParseGroupVersion(o.Spec.Owner.APIVersion)
+	if err != nil {
+		return false, err
+	}
+
+	owner := &metav1.PartialObjectMetadata{}
+	owner.SetGroupVersionKind(gv.WithKind(o.Spec.Owner.Kind))
+	if err := c.Client.Get(ctx, ctrlclient.ObjectKey{
+		Namespace: o.Namespace,
+		Name:      o.Spec.Owner.Name,
+	}, owner); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return owner.GetUID() == o.Spec.Owner.UID, nil
+}
@@ -0,0 +1,98 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package ledger
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/internal/testutils"
+)
+
+func TestCollector_Collect(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	now := time.Unix(1700000000, 0)
+	newLedger := func(name, ownerName string, lastEntry time.Duration) *secretsv1beta1.SecretSyncLedger {
+		return &secretsv1beta1.SecretSyncLedger{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "baz",
+			},
+			Spec: secretsv1beta1.SecretSyncLedgerSpec{
+				Owner: secretsv1beta1.SyncLedgerOwner{
+					APIVersion: secretsv1beta1.GroupVersion.String(),
+					Kind:       "VaultStaticSecret",
+					Name:       ownerName,
+					UID:        types.UID("uid-" + ownerName),
+				},
+				Entries: []secretsv1beta1.SyncLedgerEntry{
+					{
+						Sequence: 1,
+						Time:     metav1.NewTime(now.Add(-lastEntry)),
+					},
+				},
+			},
+		}
+	}
+
+	owner := &secretsv1beta1.VaultStaticSecret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "exists",
+			Namespace: "baz",
+			UID:       "uid-exists",
+		},
+	}
+	recreated := &secretsv1beta1.VaultStaticSecret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "recreated",
+			Namespace: "baz",
+			UID:       "uid-new",
+		},
+	}
+	client := testutils.NewFakeClientBuilder().
+		WithObjects(
+			owner,
+			recreated,
+			newLedger("uid-exists", "exists", 2*time.Hour),
+			newLedger("uid-recreated", "recreated", 2*time.Hour),
+			newLedger("uid-deleted", "deleted", 2*time.Hour),
+			newLedger("uid-deleted-recently", "deleted-recently", time.Minute),
+		).
+		Build()
+
+	c := &Collector{
+		Client:    client,
+		Retention: time.Hour,
+		now: func() time.Time {
+			return now
+		},
+	}
+	c.Collect(ctx)
+
+	for name, want := range map[string]bool{
+		"uid-exists":           true,
+		"uid-recreated":        false,
+		"uid-deleted":          false,
+		"uid-deleted-recently": true,
+	} {
+		err := client.Get(ctx, ctrlclient.ObjectKey{Namespace: "baz", Name: name}, &secretsv1beta1.SecretSyncLedger{})
+		if want {
+			assert.NoError(t, err, name)
+		} else {
+			require.Error(t, err, name)
+			assert.True(t, apierrors.IsNotFound(err), name)
+		}
+	}
+}
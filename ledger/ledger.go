@@ -0,0 +1,165 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package ledger
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+)
+
+// DefaultRecorder records every successful sync of a syncable secret resource.
+// It is nil unless the sync ledger has been enabled on the Operator.
+var DefaultRecorder *Recorder
+
+// Sink stores the entries of a ledger. Implementations are responsible for
+// chaining each new entry to its predecessor with Seal.
+type Sink interface {
+	// Append entry to the ledger of owner. The entry is not appended when it
+	// records the same sync as the ledger's last entry.
+	Append(ctx context.Context, namespace string, owner secretsv1beta1.SyncLedgerOwner,
+		entry secretsv1beta1.SyncLedgerEntry) error
+}
+
+// MACFunc returns the MAC of message.
+type MACFunc func(ctx context.Context, message []byte) ([]byte, error)
+
+// Recorder builds the ledger entries and appends them to its Sink.
+type Recorder struct {
+	// Sink the entries are appended to.
+	Sink Sink
+	// MAC is used to identify the synced data. It must be keyed, so that the
+	// entries do not leak anything about the data.
+	MAC MACFunc
+	// now is used for testing.
+	now func() time.Time
+}
+
+// Record a successful sync of data to destination for the resource identified
+// by owner.
+func (r *Recorder) Record(ctx context.Context, namespace string, owner secretsv1beta1.SyncLedgerOwner,
+	generation int64, destination string, data map[string][]byte,
+) error {
+	message, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	mac, err := r.MAC(ctx, message)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now
+	if r.now != nil {
+		now = r.now
+	}
+
+	return r.Sink.Append(ctx, namespace, owner, secretsv1beta1.SyncLedgerEntry{
+		Time:        metav1.NewTime(now().UTC().Truncate(time.Second)),
+		Generation:  generation,
+		Destination: destination,
+		DataMAC:     base64.StdEncoding.EncodeToString(mac),
+	})
+}
+
+// Seal sets the Sequence, PreviousHash and Hash of entry, chaining it to prev.
+// prev is nil for the very first entry of a ledger. The Hash is computed with
+// mac, see ComputeHash.
+func Seal(ctx context.Context, mac MACFunc, entry *secretsv1beta1.SyncLedgerEntry,
+	prev *secretsv1beta1.SyncLedgerEntry,
+) error {
+	entry.Sequence = 1
+	entry.PreviousHash = ""
+	if prev != nil {
+		entry.Sequence = prev.Sequence + 1
+		entry.PreviousHash = prev.Hash
+	}
+
+	hash, err := ComputeHash(ctx, mac, entry)
+	if err != nil {
+		return err
+	}
+	entry.Hash = hash
+	return nil
+}
+
+// IsDuplicate returns true if entry records the same sync as prev.
+func IsDuplicate(entry *secretsv1beta1.SyncLedgerEntry, prev *secretsv1beta1.SyncLedgerEntry) bool {
+	return prev != nil &&
+		prev.DataMAC == entry.DataMAC &&
+		prev.Destination == entry.Destination &&
+		prev.Generation == entry.Generation
+}
+
+// ComputeHash returns the hex encoded MAC of all the entry's fields, other than
+// Hash. mac must be keyed with a secret that is only known to the Operator, the
+// HMAC key, so that an entry cannot be forged or resealed by anyone who can
+// only write the ledger.
+func ComputeHash(ctx context.Context, mac MACFunc, entry *secretsv1beta1.SyncLedgerEntry) (string, error) {
+	var message []byte
+	for _, v := range []string{
+		strconv.FormatInt(entry.Sequence, 10),
+		entry.Time.UTC().Format(time.RFC3339),
+		strconv.FormatInt(entry.Generation, 10),
+		entry.Destination,
+		entry.DataMAC,
+		entry.PreviousHash,
+	} {
+		// length prefix every field, so that no two distinct entries share the
+		// same input.
+		message = fmt.Appendf(message, "%d:%s", len(v), v)
+	}
+
+	sum, err := mac(ctx, message)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(sum), nil
+}
+
+// Verify that the ledger's entries form an unbroken chain, and that none of them
+// have been modified. mac must be the one that the entries were sealed with.
+func Verify(ctx context.Context, mac MACFunc, spec *secretsv1beta1.SecretSyncLedgerSpec) error {
+	var prev *secretsv1beta1.SyncLedgerEntry
+	for i := range spec.Entries {
+		entry := &spec.Entries[i]
+		want, err := ComputeHash(ctx, mac, entry)
+		if err != nil {
+			return err
+		}
+		if entry.Hash != want {
+			return fmt.Errorf("entry %d has been modified, hash=%s, computed=%s",
+				entry.Sequence, entry.Hash, want)
+		}
+
+		if prev == nil {
+			if want := spec.TrimmedEntries + 1; entry.Sequence != want {
+				return fmt.Errorf("first entry has sequence %d, expected %d", entry.Sequence, want)
+			}
+			if spec.TrimmedEntries == 0 && entry.PreviousHash != "" {
+				return fmt.Errorf("first entry %d has a previous hash", entry.Sequence)
+			}
+		} else {
+			if entry.Sequence != prev.Sequence+1 {
+				return fmt.Errorf("entry %d follows entry %d", entry.Sequence, prev.Sequence)
+			}
+			if entry.PreviousHash != prev.Hash {
+				return fmt.Errorf("entry %d is not chained to entry %d", entry.Sequence, prev.Sequence)
+			}
+		}
+		prev = entry
+	}
+
+	return nil
+}
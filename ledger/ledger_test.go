@@ -0,0 +1,191 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package ledger
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/internal/testutils"
+)
+
+func testMAC(_ context.Context, message []byte) ([]byte, error) {
+	h := hmac.New(sha256.New, []byte("key"))
+	h.Write(message)
+	return h.Sum(nil), nil
+}
+
+func TestRecorder_Record(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := testutils.NewFakeClientBuilder().Build()
+	now := time.Unix(1700000000, 0)
+	r := &Recorder{
+		Sink: &CustomResourceSink{
+			Client:     client,
+			MAC:        testMAC,
+			MaxEntries: 3,
+		},
+		MAC: testMAC,
+		now: func() time.Time {
+			return now
+		},
+	}
+	owner := secretsv1beta1.SyncLedgerOwner{
+		APIVersion: secretsv1beta1.GroupVersion.String(),
+		Kind:       "VaultStaticSecret",
+		Name:       "foo",
+		UID:        "uid-foo",
+	}
+	key := ctrlclient.ObjectKey{Namespace: "baz", Name: "uid-foo"}
+	getLedger := func() *secretsv1beta1.SecretSyncLedger {
+		t.Helper()
+		var o secretsv1beta1.SecretSyncLedger
+		require.NoError(t, client.Get(ctx, key, &o))
+		return &o
+	}
+
+	data := map[string][]byte{"password": []byte("secret")}
+	require.NoError(t, r.Record(ctx, "baz", owner, 1, "creds", data))
+	o := getLedger()
+	assert.Equal(t, owner, o.Spec.Owner)
+	assert.Equal(t, "VaultStaticSecret", o.Labels[LabelOwnerKind])
+	require.Len(t, o.Spec.Entries, 1)
+	first := o.Spec.Entries[0]
+	assert.Equal(t, int64(1), first.Sequence)
+	assert.Equal(t, now.UTC(), first.Time.UTC())
+	assert.Equal(t, "creds", first.Destination)
+	assert.Empty(t, first.PreviousHash)
+	assert.NotEmpty(t, first.DataMAC)
+	assert.NotContains(t, first.DataMAC, "secret")
+	require.NoError(t, Verify(ctx, testMAC, &o.Spec))
+
+	// the same sync is only recorded once.
+	require.NoError(t, r.Record(ctx, "baz", owner, 1, "creds", data))
+	assert.Len(t, getLedger().Spec.Entries, 1)
+
+	for i, v := range []string{"rotated-1", "rotated-2", "rotated-3"} {
+		now = now.Add(time.Minute)
+		require.NoError(t, r.Record(ctx, "baz", owner, int64(i+1), "creds",
+			map[string][]byte{"password": []byte(v)}))
+	}
+	o = getLedger()
+	require.Len(t, o.Spec.Entries, 3)
+	assert.Equal(t, int64(1), o.Spec.TrimmedEntries)
+	assert.Equal(t, int64(2), o.Spec.Entries[0].Sequence)
+	assert.Equal(t, first.Hash, o.Spec.Entries[0].PreviousHash)
+	assert.NotEqual(t, first.DataMAC, o.Spec.Entries[0].DataMAC)
+	require.NoError(t, Verify(ctx, testMAC, &o.Spec))
+}
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	newSpec := func() *secretsv1beta1.SecretSyncLedgerSpec {
+		spec := &secretsv1beta1.SecretSyncLedgerSpec{}
+		var prev *secretsv1beta1.SyncLedgerEntry
+		for i := 0; i < 3; i++ {
+			entry := secretsv1beta1.SyncLedgerEntry{
+				Generation:  1,
+				Destination: "creds",
+				DataMAC:     string(rune('a' + i)),
+			}
+			require.NoError(t, Seal(ctx, testMAC, &entry, prev))
+			spec.Entries = append(spec.Entries, entry)
+			prev = &spec.Entries[len(spec.Entries)-1]
+		}
+		return spec
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(spec *secretsv1beta1.SecretSyncLedgerSpec)
+		wantErr string
+	}{
+		{
+			name:   "valid",
+			modify: func(spec *secretsv1beta1.SecretSyncLedgerSpec) {},
+		},
+		{
+			name: "modified-entry",
+			modify: func(spec *secretsv1beta1.SecretSyncLedgerSpec) {
+				spec.Entries[1].DataMAC = "x"
+			},
+			wantErr: "entry 2 has been modified",
+		},
+		{
+			name: "removed-entry",
+			modify: func(spec *secretsv1beta1.SecretSyncLedgerSpec) {
+				spec.Entries = append(spec.Entries[:1], spec.Entries[2:]...)
+			},
+			wantErr: "entry 3 follows entry 1",
+		},
+		{
+			name: "removed-first-entry",
+			modify: func(spec *secretsv1beta1.SecretSyncLedgerSpec) {
+				spec.Entries = spec.Entries[1:]
+			},
+			wantErr: "first entry has sequence 2, expected 1",
+		},
+		{
+			name: "trimmed",
+			modify: func(spec *secretsv1beta1.SecretSyncLedgerSpec) {
+				spec.Entries = spec.Entries[1:]
+				spec.TrimmedEntries = 1
+			},
+		},
+		{
+			name: "resealed-entry",
+			modify: func(spec *secretsv1beta1.SecretSyncLedgerSpec) {
+				spec.Entries[1].DataMAC = "x"
+				hash, err := ComputeHash(ctx, testMAC, &spec.Entries[1])
+				require.NoError(t, err)
+				spec.Entries[1].Hash = hash
+			},
+			wantErr: "entry 3 is not chained to entry 2",
+		},
+		{
+			name: "forged-entries",
+			modify: func(spec *secretsv1beta1.SecretSyncLedgerSpec) {
+				// without the key, the entries cannot be resealed.
+				otherMAC := func(_ context.Context, message []byte) ([]byte, error) {
+					h := hmac.New(sha256.New, []byte("other"))
+					h.Write(message)
+					return h.Sum(nil), nil
+				}
+				var prev *secretsv1beta1.SyncLedgerEntry
+				for i := range spec.Entries {
+					spec.Entries[i].DataMAC = "x"
+					require.NoError(t, Seal(ctx, otherMAC, &spec.Entries[i], prev))
+					prev = &spec.Entries[i]
+				}
+			},
+			wantErr: "entry 1 has been modified",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			spec := newSpec()
+			tt.modify(spec)
+			err := Verify(ctx, testMAC, spec)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
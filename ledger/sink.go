@@ -0,0 +1,121 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package ledger
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+)
+
+// DefaultMaxEntries is the number of entries a SecretSyncLedger retains, if
+// none is configured.
+const DefaultMaxEntries = 500
+
+// MaxEntriesLimit is the maximum number of entries a SecretSyncLedger can
+// retain, it is enforced by the CRD.
+const MaxEntriesLimit = 1000
+
+// LabelOwnerKind is set on every SecretSyncLedger, so that the ledgers of a
+// given kind of resource can be selected.
+const LabelOwnerKind = "secrets.hashicorp.com/ledger-owner-kind"
+
+var _ Sink = (*CustomResourceSink)(nil)
+
+// CustomResourceSink stores the entries in a SecretSyncLedger per syncable
+// secret resource, in the resource's namespace. The ledger is named after the
+// resource's UID, so a recreated resource gets a new ledger. The ledgers of the
+// deleted resources are removed by the Collector.
+type CustomResourceSink struct {
+	Client ctrlclient.Client
+	// MAC is used to seal the entries, it must be keyed, see ComputeHash.
+	MAC MACFunc
+	// MaxEntries is the number of entries retained in a ledger, the oldest
+	// entries are trimmed once it is exceeded.
+	MaxEntries int
+}
+
+// +kubebuilder:rbac:groups=secrets.hashicorp.com,resources=secretsyncledgers,verbs=get;list;watch;create;update;delete
+
+func (s *CustomResourceSink) Append(ctx context.Context, namespace string, owner secretsv1beta1.SyncLedgerOwner,
+	entry secretsv1beta1.SyncLedgerEntry,
+) error {
+	key := ctrlclient.ObjectKey{
+		Namespace: namespace,
+		Name:      string(owner.UID),
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var o secretsv1beta1.SecretSyncLedger
+		if err := s.Client.Get(ctx, key, &o); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return err
+			}
+
+			o = secretsv1beta1.SecretSyncLedger{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+					Labels: map[string]string{
+						LabelOwnerKind: owner.Kind,
+					},
+				},
+				Spec: secretsv1beta1.SecretSyncLedgerSpec{
+					Owner: owner,
+				},
+			}
+			if _, err := s.append(ctx, &o.Spec, entry); err != nil {
+				return err
+			}
+			err := s.Client.Create(ctx, &o)
+			if apierrors.IsAlreadyExists(err) {
+				// lost the race against another writer, retry as an update.
+				return apierrors.NewConflict(secretsv1beta1.GroupVersion.WithResource(
+					"secretsyncledgers").GroupResource(), key.Name, err)
+			}
+			return err
+		}
+
+		if ok, err := s.append(ctx, &o.Spec, entry); err != nil || !ok {
+			return err
+		}
+
+		return s.Client.Update(ctx, &o)
+	})
+}
+
+// append entry to spec, trimming the oldest entries if needed. Returns false if
+// entry is a duplicate of the last entry.
+func (s *CustomResourceSink) append(ctx context.Context, spec *secretsv1beta1.SecretSyncLedgerSpec,
+	entry secretsv1beta1.SyncLedgerEntry,
+) (bool, error) {
+	var prev *secretsv1beta1.SyncLedgerEntry
+	if l := len(spec.Entries); l > 0 {
+		prev = &spec.Entries[l-1]
+	}
+	if IsDuplicate(&entry, prev) {
+		return false, nil
+	}
+
+	if err := Seal(ctx, s.MAC, &entry, prev); err != nil {
+		return false, err
+	}
+	spec.Entries = append(spec.Entries, entry)
+
+	maxEntries := s.MaxEntries
+	if maxEntries <= 0 {
+		maxEntries = DefaultMaxEntries
+	}
+	if trim := len(spec.Entries) - maxEntries; trim > 0 {
+		spec.Entries = spec.Entries[trim:]
+		spec.TrimmedEntries += int64(trim)
+	}
+
+	return true, nil
+}
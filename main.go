@@ -5,12 +5,14 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -25,6 +27,7 @@ import (
 	"k8s.io/apimachinery/pkg/selection"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -40,13 +43,29 @@ import (
 
 	"github.com/hashicorp/vault-secrets-operator/common"
 	"github.com/hashicorp/vault-secrets-operator/helpers"
+	"github.com/hashicorp/vault-secrets-operator/ledger"
+	"github.com/hashicorp/vault-secrets-operator/secretless"
 	"github.com/hashicorp/vault-secrets-operator/utils"
 	vclient "github.com/hashicorp/vault-secrets-operator/vault"
 
 	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
 	"github.com/hashicorp/vault-secrets-operator/controllers"
+	"github.com/hashicorp/vault-secrets-operator/internal/admissiondefaults"
+	"github.com/hashicorp/vault-secrets-operator/internal/clockskew"
+	"github.com/hashicorp/vault-secrets-operator/internal/cloudevents"
+	"github.com/hashicorp/vault-secrets-operator/internal/configdrift"
+	"github.com/hashicorp/vault-secrets-operator/internal/diagnostics"
+	"github.com/hashicorp/vault-secrets-operator/internal/dualwrite"
+	"github.com/hashicorp/vault-secrets-operator/internal/expirations"
+	"github.com/hashicorp/vault-secrets-operator/internal/featuregates"
+	"github.com/hashicorp/vault-secrets-operator/internal/injectoradoption"
+	"github.com/hashicorp/vault-secrets-operator/internal/issuance"
 	"github.com/hashicorp/vault-secrets-operator/internal/metrics"
 	"github.com/hashicorp/vault-secrets-operator/internal/options"
+	"github.com/hashicorp/vault-secrets-operator/internal/standbyrenewal"
+	"github.com/hashicorp/vault-secrets-operator/internal/storagemigration"
+	"github.com/hashicorp/vault-secrets-operator/internal/vaultauthwebhook"
+	"github.com/hashicorp/vault-secrets-operator/internal/vaulthealth"
 	"github.com/hashicorp/vault-secrets-operator/internal/version"
 	// +kubebuilder:scaffold:imports
 )
@@ -70,6 +89,8 @@ func init() {
 	utilruntime.Must(secretsv1beta1.AddToScheme(scheme))
 
 	utilruntime.Must(argorolloutsv1alpha1.AddToScheme(scheme))
+
+	utilruntime.Must(apiextensionsv1.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
 }
 
@@ -124,6 +145,7 @@ func main() {
 	persistenceModelNone := "none"
 	persistenceModelDirectUnencrypted := "direct-unencrypted"
 	persistenceModelDirectEncrypted := "direct-encrypted"
+	persistenceModelDirectLocalEncrypted := "direct-local-encrypted"
 	defaultPersistenceModel := persistenceModelNone
 	controllerOptions := controller.Options{}
 	vdsOptions := controller.Options{}
@@ -138,9 +160,13 @@ func main() {
 	var printVersion bool
 	var outputFormat string
 	var uninstall bool
+	var injectorAdoption bool
+	var injectorAdoptionNamespace string
+	var injectorAdoptionGenerate bool
 	var preDeleteHookTimeoutSeconds int
 	var minRefreshAfterHVSA time.Duration
 	var globalTransformationOpts string
+	var operatorMetadata string
 	var globalVaultAuthOpts string
 	var backoffInitialInterval time.Duration
 	var backoffMaxInterval time.Duration
@@ -149,6 +175,36 @@ func main() {
 	var backoffMaxElapsedTime time.Duration
 	var kubeClientQPS float64
 	var kubeClientBurst uint
+	var secretlessBindAddr string
+	var secretlessCertDir string
+	var secretlessClientCAFile string
+	var secretlessSPIFFETrustDomain string
+	var secretlessTokenAudience string
+	var csiProviderSocket string
+	var csiProviderServerURL string
+	var csiProviderCAFile string
+	var expirationsBindAddr string
+	var checksumAlgorithm string
+	var minLeaseDuration time.Duration
+	var expirationsCertDir string
+	var syncLedger bool
+	var storageVersionMigration bool
+	var admissionDefaultsConfig string
+	var vaultAuthParamsWebhook bool
+	var cloudEventsSinkURL string
+	var cloudEventsKafkaTopic string
+	var cloudEventsSource string
+	var rolloutRestartCoalesceWindow time.Duration
+	var asyncIssuanceWorkers int
+	var vaultHealthPollInterval time.Duration
+	var diagnosticsFailureThreshold int
+	var dualWriteKubeconfigSecret string
+	var dualWriteUntil string
+	var userAgentOptions vclient.UserAgentOptions
+	var syncLedgerMaxEntries int
+	var syncLedgerRetention time.Duration
+	var clockSkewThreshold time.Duration
+	var desiredConfigConfigMap string
 
 	// command-line args and flags
 	flag.BoolVar(&printVersion, "version", false, "Print the operator version information")
@@ -169,11 +225,29 @@ func main() {
 			"Increasing this value may improve performance during Vault client creation, but requires more memory. "+
 			"When the value is <= 0 the number of locks will be set to the number of logical CPUs of the run host. "+
 			"Also set from environment variable VSO_CLIENT_CACHE_NUM_LOCKS.")
+	// update chart/values.yaml if changing the default value
+	flag.StringVar(&checksumAlgorithm, "checksum-algorithm", helpers.ChecksumAlgorithmSHA256,
+		fmt.Sprintf("The hash algorithm of the checksum annotation of the destination Secrets "+
+			"that enable it. Valid values are: %v", helpers.ChecksumAlgorithms))
+	// update chart/values.yaml if changing the default value
+	flag.IntVar(&cfc.MaxConcurrentLogins, "max-concurrent-logins", 0,
+		"The maximum number of simultaneous logins to Vault, across all VaultAuths. "+
+			"Limiting it avoids login bursts, e.g. upon restarts in large clusters, that trip Vault's rate limits. "+
+			"Each VaultAuth may further limit its own logins with spec.maxConcurrentLogins. "+
+			"No limit is applied when it is 0.")
+	// update chart/values.yaml if changing the default value
+	flag.Float64Var(&cfc.TokenRenewFraction, "client-token-renew-fraction", vclient.DefaultTokenRenewFraction,
+		"The fraction of the TTL of the Vault client tokens after which they are renewed in the background. "+
+			"A client re-authenticates when its token can no longer be renewed. "+
+			"Valid values are greater than 0 and less than 1.")
 	flag.StringVar(&clientCachePersistenceModel, "client-cache-persistence-model", defaultPersistenceModel,
 		fmt.Sprintf(
 			"The type of client cache persistence model that should be employed. "+
 				"Also set from environment variable VSO_CLIENT_CACHE_PERSISTENCE_MODEL. "+
-				"choices=%v", []string{persistenceModelDirectUnencrypted, persistenceModelDirectEncrypted, persistenceModelNone}))
+				"choices=%v", []string{
+				persistenceModelDirectUnencrypted, persistenceModelDirectEncrypted,
+				persistenceModelDirectLocalEncrypted, persistenceModelNone,
+			}))
 	flag.IntVar(&vdsOptions.MaxConcurrentReconciles, "max-concurrent-reconciles-vds", defaultVaultDynamicSecretsConcurrency,
 		"Maximum number of concurrent reconciles for the VaultDynamicSecrets controller. Deprecated in favor of -max-concurrent-reconciles.")
 	flag.IntVar(&controllerOptions.MaxConcurrentReconciles, "max-concurrent-reconciles", defaultSyncableSecretsConcurrency,
@@ -182,12 +256,30 @@ func main() {
 	flag.BoolVar(&uninstall, "uninstall", false, "Run in uninstall mode")
 	flag.IntVar(&preDeleteHookTimeoutSeconds, "pre-delete-hook-timeout-seconds", 60,
 		"Pre-delete hook timeout in seconds")
+	flag.BoolVar(&injectorAdoption, "injector-adoption", false,
+		"Run in injector adoption mode: report the workloads whose Pods are injected by the Vault Agent injector, "+
+			"and the secrets that they consume, as YAML on stdout, then exit.")
+	flag.StringVar(&injectorAdoptionNamespace, "injector-adoption-namespace", "",
+		"The namespace of the Pods to scan in injector adoption mode, all namespaces are scanned when empty.")
+	flag.BoolVar(&injectorAdoptionGenerate, "injector-adoption-generate", false,
+		"Include the VaultAuth, VaultStaticSecret, and VaultDynamicSecret resources that approximate "+
+			"the injected secrets in the injector adoption report.")
 	flag.DurationVar(&minRefreshAfterHVSA, "min-refresh-after-hvsa", time.Second*30,
 		"Minimum duration between HCPVaultSecretsApp resource reconciliation.")
+	flag.DurationVar(&minLeaseDuration, "min-lease-duration", 0,
+		"Minimum lease duration of the secrets synced by VaultDynamicSecrets. "+
+			"Secrets with a shorter lease would expire before they could be renewed, "+
+			"so they are revoked, never synced, and the LeaseTooShort condition is set on the resource. "+
+			"No minimum is enforced when it is 0.")
 	flag.StringVar(&globalTransformationOpts, "global-transformation-options", "",
 		fmt.Sprintf("Set global secret transformation options as a comma delimited string. "+
 			"Also set from environment variable VSO_GLOBAL_TRANSFORMATION_OPTIONS. "+
 			"Valid values are: %v", []string{"exclude-raw"}))
+	flag.StringVar(&operatorMetadata, "operator-metadata", "",
+		"A comma separated list of key=value pairs that describe the Operator instance, "+
+			"e.g. clusterName=prod-eu,region=eu-west-1. They are available as .Operator in the "+
+			"secret transformation templates, along with the namespace's labels and annotations "+
+			"as .Namespace.Labels and .Namespace.Annotations.")
 	flag.StringVar(&globalVaultAuthOpts, "global-vault-auth-options", "allow-default-globals",
 		fmt.Sprintf("Set global vault auth options as a comma delimited string. "+
 			"Also set from environment variable VSO_GLOBAL_VAULT_AUTH_OPTIONS. "+
@@ -227,6 +319,122 @@ func main() {
 			"When the value is 0, the kubernetes client's default is used. "+
 			"Also set from environment variable VSO_KUBE_CLIENT_BURST.")
 
+	flag.StringVar(&secretlessBindAddr, "secretless-bind-address", "",
+		"The address the secretless server binds to. Setting it enables the experimental "+
+			"secretless mode, where the data for destinations configured with secretless "+
+			"delivery is served to the secretless agent, and never stored in a Kubernetes Secret. "+
+			"Requires --feature-gates=Secretless=true.")
+	flag.StringVar(&secretlessCertDir, "secretless-cert-dir", "/etc/vso/secretless/tls",
+		"The directory containing the secretless server's tls.crt and tls.key files.")
+	flag.StringVar(&secretlessClientCAFile, "secretless-client-ca-file", "",
+		"The CA certificate file used to verify the secretless agent's client certificate. "+
+			"Requires --secretless-spiffe-trust-domain.")
+	flag.StringVar(&secretlessSPIFFETrustDomain, "secretless-spiffe-trust-domain", "",
+		"The SPIFFE trust domain of the secretless agent's client certificate.")
+	flag.StringVar(&secretlessTokenAudience, "secretless-token-audience", secretless.DefaultTokenAudience,
+		"The audience of the ServiceAccount token presented by the secretless agent.")
+	flag.StringVar(&csiProviderSocket, "csi-provider-socket", "",
+		"Run in Secrets Store CSI driver provider mode: serve the data of the destinations configured "+
+			"with secretless delivery to the CSI driver on this Unix domain socket, e.g. "+
+			"/etc/kubernetes/secrets-store-csi-providers/vault-secrets-operator.sock. The data is fetched "+
+			"from the secretless server with the Pod's ServiceAccount token, whose audience is "+
+			"--secretless-token-audience. It is meant to be run as a DaemonSet, alongside the CSI driver. "+
+			"Requires --feature-gates=CSIProvider=true.")
+	flag.StringVar(&csiProviderServerURL, "csi-provider-server-url", "",
+		"The base URL of the Operator's secretless server in CSI provider mode.")
+	flag.StringVar(&csiProviderCAFile, "csi-provider-ca-file", "",
+		"The CA certificate file used to verify the secretless server's certificate in CSI provider mode.")
+	flag.StringVar(&expirationsBindAddr, "expirations-bind-address", "",
+		"The address the expirations server binds to. Setting it enables the "+
+			expirations.Path+" endpoint, listing the expirations of all the certificates "+
+			"and leases that the requester is allowed to list, soonest first.")
+	flag.StringVar(&expirationsCertDir, "expirations-cert-dir", "/etc/vso/expirations/tls",
+		"The directory containing the expirations server's tls.crt and tls.key files.")
+	flag.BoolVar(&syncLedger, "sync-ledger", false,
+		"Record every successful sync in a SecretSyncLedger, in the synced resource's namespace. "+
+			"Each entry carries the HMAC of the synced data, and is chained to the previous entry "+
+			"by its hash, so that the ledger is tamper-evident.")
+	flag.BoolVar(&storageVersionMigration, "storage-version-migration", false,
+		"Migrate the stored objects of the Operator's CRDs to their storage version in the background, "+
+			"when some are stored in a previous version, then reset the CRDs' status.storedVersions. "+
+			"The objects are only rewritten once after each storage version change. "+
+			"Progress is reported in the vso_storage_migration_* metrics.")
+	flag.StringVar(&admissionDefaultsConfig, "admission-defaults-config", "",
+		"The path to a YAML file of the defaults that are set upon admission of the "+
+			"VaultStaticSecrets, VaultDynamicSecrets, VaultPKISecrets, and HCPVaultSecretsApps, "+
+			"by the Operator's mutating webhook: refreshAfter by engine type, destination.type by kind, "+
+			"and excludeRaw. The webhook is not served when it is empty.")
+	flag.BoolVar(&vaultAuthParamsWebhook, "vault-auth-params-webhook", false,
+		"Serve the validating webhook that rejects the VaultAuths and ClusterVaultAuths whose "+
+			"params or loginParams templates cannot be rendered. Otherwise, they are only reported "+
+			"as invalid in their status.")
+	flag.StringVar(&cloudEventsSinkURL, "cloudevents-sink-url", "",
+		"The URL of the sink the CloudEvents of the lease lifecycle transitions (issued, renewed, "+
+			"expired, revoked) and of the secret rotations are sent to, in the structured content mode. "+
+			"No events are sent when it is empty.")
+	flag.StringVar(&cloudEventsKafkaTopic, "cloudevents-kafka-topic", "",
+		"Produce the CloudEvents to this Kafka topic, in which case --cloudevents-sink-url "+
+			"is the URL of a Kafka REST proxy, e.g. the Confluent REST Proxy or the Strimzi Kafka Bridge.")
+	flag.StringVar(&cloudEventsSource, "cloudevents-source", cloudevents.DefaultSource,
+		"The source of the CloudEvents, it should identify the Operator install.")
+	flag.DurationVar(&rolloutRestartCoalesceWindow, "rollout-restart-coalesce-window", 0,
+		"Restart each rolloutRestartTarget at most once per window, across all the syncable secrets "+
+			"that reference it. A restart requested within the window of the target's previous restart "+
+			"is deferred to the end of the window, and any further request is coalesced into it. "+
+			"A deferred restart is recorded on the target, so that it survives an Operator restart. "+
+			"The restarts are never coalesced when it is 0.")
+	flag.IntVar(&asyncIssuanceWorkers, "async-issuance-workers", 0,
+		"The number of workers that issue the secrets of the VaultDynamicSecrets, VaultPKISecrets, "+
+			"and the other leased secrets asynchronously from their reconciliation, so that slow secret "+
+			"engines do not block the other resources. The resources report the Issuing condition while "+
+			"their secret is being issued. The secrets are issued inline when it is 0.")
+	flag.DurationVar(&vaultHealthPollInterval, "vault-health-poll-interval", 0,
+		"The interval at which the health and the replication status of the Vault server of every "+
+			"VaultConnection are polled, and exported as metrics. The replication status requires "+
+			"Vault Enterprise. Vault is not polled when it is 0.")
+	flag.IntVar(&diagnosticsFailureThreshold, "diagnostics-failure-threshold", 0,
+		"The number of consecutive sync failures of a resource after which an anonymized diagnostic "+
+			"snapshot is captured in its "+diagnostics.AnnotationDiagnostics+" annotation. It holds the "+
+			"paths and status codes of the failed Vault requests, the failure timings, the auth method, "+
+			"and the condition history, but never any secret data. No snapshot is captured when it is 0.")
+	flag.StringVar(&dualWriteKubeconfigSecret, "dual-write-kubeconfig-secret", "",
+		"The name of a Secret, optionally prefixed with its namespace, that holds the kubeconfig of a "+
+			"cluster migration's target cluster in its "+dualwrite.KubeconfigKey+" key. Every destination "+
+			"Secret is then written into both clusters, until --dual-write-until. The Secrets that are already "+
+			"in sync are written into the target cluster upon startup. The Secret's namespace "+
+			"defaults to the Operator's. Requires --feature-gates=DualWrite=true.")
+	flag.StringVar(&dualWriteUntil, "dual-write-until", "",
+		"The end of the dual-write migration window, in RFC3339 format. "+
+			"Required with --dual-write-kubeconfig-secret.")
+	flag.StringVar(&userAgentOptions.ClusterID, "user-agent-cluster-id", "",
+		"An identifier of the Kubernetes cluster that is included in the User-Agent of the requests to Vault, "+
+			"so that the traffic of multiple Operator installs sharing one Vault can be told apart.")
+	flag.StringVar(&userAgentOptions.InstanceID, "user-agent-instance-id", "",
+		"An identifier of the Operator install that is included in the User-Agent of the requests to Vault.")
+	flag.StringVar(&userAgentOptions.Suffix, "user-agent-suffix", "",
+		"A suffix that is appended to the User-Agent of the requests to Vault. "+
+			"The User-Agent may still be overridden by the headers of a VaultConnection.")
+	flag.IntVar(&syncLedgerMaxEntries, "sync-ledger-max-entries", ledger.DefaultMaxEntries,
+		fmt.Sprintf("The maximum number of entries retained per SecretSyncLedger, the oldest entries are trimmed first. "+
+			"The value must be between 1 and %d.", ledger.MaxEntriesLimit))
+	flag.DurationVar(&syncLedgerRetention, "sync-ledger-retention", ledger.DefaultRetention,
+		"The period for which a SecretSyncLedger is retained after its last entry, once the synced resource "+
+			"has been deleted. Setting it to 0 retains the ledgers forever.")
+	flag.DurationVar(&clockSkewThreshold, "clock-skew-threshold", clockskew.DefaultThreshold,
+		"The clock skew between the Operator and Vault, or the Kubernetes API server, above which a "+
+			"ClockSkewDetected warning is emitted, and certificate renewals are brought forward by the skew. "+
+			"The skew is estimated from the Date header of each response.")
+	flag.StringVar(&desiredConfigConfigMap, "desired-config-configmap", "",
+		"The name of a ConfigMap, in the Operator's namespace, that declares the desired runtime configuration. "+
+			"Its keys are flag names, or environment variable names prefixed with 'env.'. "+
+			"Any drift from the effective configuration is reported at startup in a ConfigDriftDetected "+
+			"warning Event, and in the config_drift metric.")
+	flag.Var(featuregates.DefaultGates, "feature-gates",
+		"A comma separated list of key=value pairs that enable or disable features. "+
+			"Alpha features are disabled by default, beta features are enabled by default. "+
+			"The state of each feature is exported in the feature_enabled metric. Options are:\n"+
+			strings.Join(featuregates.DefaultGates.KnownFeatures(), "\n"))
+
 	opts := zap.Options{
 		Development: os.Getenv("VSO_LOGGER_DEVELOPMENT_MODE") != "",
 	}
@@ -324,6 +532,13 @@ func main() {
 		os.Exit(1)
 	}
 
+	if syncLedgerMaxEntries < 1 || syncLedgerMaxEntries > ledger.MaxEntriesLimit {
+		setupLog.Error(errors.New("invalid option"),
+			fmt.Sprintf("Invalid sync ledger max entries %d, must be between 1 and %d",
+				syncLedgerMaxEntries, ledger.MaxEntriesLimit))
+		os.Exit(1)
+	}
+
 	backoffOpts := []backoff.ExponentialBackOffOpts{
 		backoff.WithInitialInterval(backoffInitialInterval),
 		backoff.WithMaxInterval(backoffMaxInterval),
@@ -343,6 +558,52 @@ func main() {
 			os.Exit(1)
 		}
 	}
+	if operatorMetadata != "" {
+		globalTransOptions.OperatorMetadata = make(map[string]string)
+		for _, kv := range strings.Split(operatorMetadata, ",") {
+			k, v, ok := strings.Cut(kv, "=")
+			if !ok || k == "" {
+				setupLog.Error(fmt.Errorf("invalid key=value pair %q", kv),
+					"Invalid argument for --operator-metadata")
+				os.Exit(1)
+			}
+			globalTransOptions.OperatorMetadata[k] = v
+		}
+	}
+
+	if cfc.TokenRenewFraction <= 0 || cfc.TokenRenewFraction >= 1 {
+		setupLog.Error(errors.New("invalid option"),
+			fmt.Sprintf("Invalid client token renew fraction %f, must be greater than 0 and less than 1",
+				cfc.TokenRenewFraction))
+		os.Exit(1)
+	}
+
+	// the experimental features are only set up when their feature gate is
+	// enabled, their options are rejected otherwise, rather than ignored.
+	if secretlessBindAddr != "" && !featuregates.Enabled(featuregates.Secretless) {
+		setupLog.Error(errors.New("invalid option"),
+			fmt.Sprintf("--secretless-bind-address requires --feature-gates=%s=true", featuregates.Secretless))
+		os.Exit(1)
+	}
+	if dualWriteKubeconfigSecret != "" && !featuregates.Enabled(featuregates.DualWrite) {
+		setupLog.Error(errors.New("invalid option"),
+			fmt.Sprintf("--dual-write-kubeconfig-secret requires --feature-gates=%s=true", featuregates.DualWrite))
+		os.Exit(1)
+	}
+
+	if !slices.Contains(helpers.ChecksumAlgorithms, checksumAlgorithm) {
+		setupLog.Error(fmt.Errorf("unsupported checksum algorithm %q", checksumAlgorithm),
+			"Invalid argument for --checksum-algorithm")
+		os.Exit(1)
+	}
+	helpers.DefaultChecksumAlgorithm = checksumAlgorithm
+
+	userAgent, err := vclient.MakeUserAgent(userAgentOptions)
+	if err != nil {
+		setupLog.Error(err, "Invalid User-Agent options")
+		os.Exit(1)
+	}
+	vclient.DefaultUserAgent = userAgent
 
 	globalVaultAuthOptions := &common.GlobalVaultAuthOptions{}
 	for _, v := range globalVaultAuthOptsSet {
@@ -357,7 +618,27 @@ func main() {
 	}
 	cfc.GlobalVaultAuthOptions = globalVaultAuthOptions
 
+	if csiProviderSocket != "" {
+		if !featuregates.Enabled(featuregates.CSIProvider) {
+			setupLog.Error(errors.New("invalid option"),
+				fmt.Sprintf("--csi-provider-socket requires --feature-gates=%s=true", featuregates.CSIProvider))
+			os.Exit(1)
+		}
+		if err := runCSIProvider(ctrl.SetupSignalHandler(), &secretless.CSIProvider{
+			SocketPath:     csiProviderSocket,
+			ServerURL:      csiProviderServerURL,
+			TokenAudience:  secretlessTokenAudience,
+			RuntimeVersion: versionInfo.GitVersion,
+		}, csiProviderCAFile); err != nil {
+			setupLog.Error(err, "CSI provider failed")
+			os.Exit(1)
+		}
+		return
+	}
+
+	clockskew.DefaultTracker.SetThreshold(clockSkewThreshold)
 	config := ctrl.GetConfigOrDie()
+	config.Wrap(clockskew.DefaultTracker.WrapTransport(clockskew.SourceKubernetes))
 	// set the Kube Client QPS and Burst config if they are set
 	if kubeClientQPS != 0 {
 		config.QPS = float32(kubeClientQPS)
@@ -393,6 +674,15 @@ func main() {
 		os.Exit(0)
 	}
 
+	if injectorAdoption {
+		if err := injectoradoption.Run(context.Background(), defaultClient, injectorAdoptionNamespace,
+			injectorAdoptionGenerate, os.Stdout); err != nil {
+			setupLog.Error(err, "Failed to report the injected workloads")
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	collectMetrics := metricsAddr != ""
 	if collectMetrics {
 		cfc.MetricsRegistry.MustRegister(
@@ -460,6 +750,16 @@ func main() {
 	}
 	ctx := ctrl.SetupSignalHandler()
 
+	if desiredConfigConfigMap != "" {
+		// the runtime configuration cannot change without restarting the
+		// Operator, so checking it once at startup is enough.
+		if _, err := configdrift.Check(ctx, defaultClient, mgr.GetEventRecorderFor("configdrift"),
+			client.ObjectKey{Namespace: common.OperatorNamespace, Name: desiredConfigConfigMap},
+			configdrift.Effective(flag.CommandLine, os.Environ())); err != nil {
+			setupLog.Error(err, "Failed to check the runtime configuration for drift")
+		}
+	}
+
 	var requirements []labels.Requirement
 	for _, k := range []string{helpers.ManagedByLabel, helpers.AppNameLabel} {
 		val, ok := helpers.OwnerLabels[k]
@@ -497,6 +797,9 @@ func main() {
 		case persistenceModelDirectEncrypted:
 			cfc.Persist = true
 			cfc.StorageConfig.EnforceEncryption = true
+		case persistenceModelDirectLocalEncrypted:
+			cfc.Persist = true
+			cfc.StorageConfig.LocalEncryption = true
 		case persistenceModelNone:
 			cfc.Persist = false
 		default:
@@ -512,8 +815,15 @@ func main() {
 			setupLog.Error(err, "Failed to setup the Vault ClientFactory")
 			os.Exit(1)
 		}
+		if rotator := vclient.NewLocalEncryptionKeyRotator(clientFactory, defaultClient); rotator != nil {
+			if err := mgr.Add(rotator); err != nil {
+				setupLog.Error(err, "Unable to add the local encryption key rotator")
+				os.Exit(1)
+			}
+		}
 	}
 
+	helpers.OwnershipRepairRecorder = mgr.GetEventRecorderFor("ownershipRepair")
 	hmacValidator := helpers.NewHMACValidator(cfc.StorageConfig.HMACSecretObjKey)
 	secretDataBuilder := helpers.NewSecretsDataBuilder()
 	if err = (&controllers.VaultStaticSecretReconciler{
@@ -523,6 +833,7 @@ func main() {
 		SecretDataBuilder:           secretDataBuilder,
 		SecretsClient:               secretsClient,
 		HMACValidator:               hmacValidator,
+		MACStore:                    helpers.NewSecretMACStore(secretsClient),
 		ClientFactory:               clientFactory,
 		BackOffRegistry:             controllers.NewBackOffRegistry(backoffOpts...),
 		GlobalTransformationOptions: globalTransOptions,
@@ -554,6 +865,16 @@ func main() {
 		setupLog.Error(err, "Unable to create controller", "controller", "VaultAuth")
 		os.Exit(1)
 	}
+	if err = (&controllers.ClusterVaultAuthReconciler{
+		Client:                 mgr.GetClient(),
+		Scheme:                 mgr.GetScheme(),
+		Recorder:               mgr.GetEventRecorderFor("ClusterVaultAuth"),
+		ClientFactory:          clientFactory,
+		GlobalVaultAuthOptions: globalVaultAuthOptions,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "Unable to create controller", "controller", "ClusterVaultAuth")
+		os.Exit(1)
+	}
 	if err = (&controllers.VaultConnectionReconciler{
 		Client:        mgr.GetClient(),
 		Scheme:        mgr.GetScheme(),
@@ -585,6 +906,8 @@ func main() {
 		SyncRegistry:                controllers.NewSyncRegistry(),
 		BackOffRegistry:             controllers.NewBackOffRegistry(backoffOpts...),
 		GlobalTransformationOptions: globalTransOptions,
+		MinLeaseDuration:            minLeaseDuration,
+		StandbyRenewals:             featuregates.Enabled(featuregates.StandbyRenewals),
 	}
 	if err = vdsReconciler.SetupWithManager(mgr, vdsOverrideOpts); err != nil {
 		setupLog.Error(err, "Unable to create controller", "controller", "VaultDynamicSecret")
@@ -632,8 +955,396 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "VaultAuthGlobal")
 		os.Exit(1)
 	}
+	if err = (&controllers.VaultConsulSecretReconciler{
+		Client:                      mgr.GetClient(),
+		Scheme:                      mgr.GetScheme(),
+		Recorder:                    mgr.GetEventRecorderFor("VaultConsulSecret"),
+		ClientFactory:               clientFactory,
+		SyncRegistry:                controllers.NewSyncRegistry(),
+		BackOffRegistry:             controllers.NewBackOffRegistry(backoffOpts...),
+		GlobalTransformationOptions: globalTransOptions,
+	}).SetupWithManager(mgr, controllerOptions); err != nil {
+		setupLog.Error(err, "Unable to create controller", "controller", "VaultConsulSecret")
+		os.Exit(1)
+	}
+	if err = (&controllers.VaultNomadSecretReconciler{
+		Client:                      mgr.GetClient(),
+		Scheme:                      mgr.GetScheme(),
+		Recorder:                    mgr.GetEventRecorderFor("VaultNomadSecret"),
+		ClientFactory:               clientFactory,
+		SyncRegistry:                controllers.NewSyncRegistry(),
+		BackOffRegistry:             controllers.NewBackOffRegistry(backoffOpts...),
+		GlobalTransformationOptions: globalTransOptions,
+	}).SetupWithManager(mgr, controllerOptions); err != nil {
+		setupLog.Error(err, "Unable to create controller", "controller", "VaultNomadSecret")
+		os.Exit(1)
+	}
+	if err = (&controllers.VaultLDAPSecretReconciler{
+		Client:                      mgr.GetClient(),
+		Scheme:                      mgr.GetScheme(),
+		Recorder:                    mgr.GetEventRecorderFor("VaultLDAPSecret"),
+		ClientFactory:               clientFactory,
+		SyncRegistry:                controllers.NewSyncRegistry(),
+		BackOffRegistry:             controllers.NewBackOffRegistry(backoffOpts...),
+		GlobalTransformationOptions: globalTransOptions,
+	}).SetupWithManager(mgr, controllerOptions); err != nil {
+		setupLog.Error(err, "Unable to create controller", "controller", "VaultLDAPSecret")
+		os.Exit(1)
+	}
+	if err = (&controllers.VaultRabbitMQSecretReconciler{
+		Client:                      mgr.GetClient(),
+		Scheme:                      mgr.GetScheme(),
+		Recorder:                    mgr.GetEventRecorderFor("VaultRabbitMQSecret"),
+		ClientFactory:               clientFactory,
+		SyncRegistry:                controllers.NewSyncRegistry(),
+		BackOffRegistry:             controllers.NewBackOffRegistry(backoffOpts...),
+		GlobalTransformationOptions: globalTransOptions,
+	}).SetupWithManager(mgr, controllerOptions); err != nil {
+		setupLog.Error(err, "Unable to create controller", "controller", "VaultRabbitMQSecret")
+		os.Exit(1)
+	}
+	if err = (&controllers.VaultTransitSecretReconciler{
+		Client:                      mgr.GetClient(),
+		Scheme:                      mgr.GetScheme(),
+		Recorder:                    mgr.GetEventRecorderFor("VaultTransitSecret"),
+		SecretDataBuilder:           secretDataBuilder,
+		SecretsClient:               secretsClient,
+		HMACValidator:               hmacValidator,
+		ClientFactory:               clientFactory,
+		BackOffRegistry:             controllers.NewBackOffRegistry(backoffOpts...),
+		GlobalTransformationOptions: globalTransOptions,
+	}).SetupWithManager(mgr, controllerOptions); err != nil {
+		setupLog.Error(err, "Unable to create controller", "controller", "VaultTransitSecret")
+		os.Exit(1)
+	}
+	if err = (&controllers.VaultKubernetesSecretReconciler{
+		Client:                      mgr.GetClient(),
+		Scheme:                      mgr.GetScheme(),
+		Recorder:                    mgr.GetEventRecorderFor("VaultKubernetesSecret"),
+		ClientFactory:               clientFactory,
+		SyncRegistry:                controllers.NewSyncRegistry(),
+		BackOffRegistry:             controllers.NewBackOffRegistry(backoffOpts...),
+		GlobalTransformationOptions: globalTransOptions,
+	}).SetupWithManager(mgr, controllerOptions); err != nil {
+		setupLog.Error(err, "Unable to create controller", "controller", "VaultKubernetesSecret")
+		os.Exit(1)
+	}
+	if err = (&controllers.VaultTerraformCloudSecretReconciler{
+		Client:                      mgr.GetClient(),
+		Scheme:                      mgr.GetScheme(),
+		Recorder:                    mgr.GetEventRecorderFor("VaultTerraformCloudSecret"),
+		ClientFactory:               clientFactory,
+		SyncRegistry:                controllers.NewSyncRegistry(),
+		BackOffRegistry:             controllers.NewBackOffRegistry(backoffOpts...),
+		GlobalTransformationOptions: globalTransOptions,
+	}).SetupWithManager(mgr, controllerOptions); err != nil {
+		setupLog.Error(err, "Unable to create controller", "controller", "VaultTerraformCloudSecret")
+		os.Exit(1)
+	}
+	if err = (&controllers.VaultGenericSecretReconciler{
+		Client:                      mgr.GetClient(),
+		Scheme:                      mgr.GetScheme(),
+		Recorder:                    mgr.GetEventRecorderFor("VaultGenericSecret"),
+		ClientFactory:               clientFactory,
+		SyncRegistry:                controllers.NewSyncRegistry(),
+		BackOffRegistry:             controllers.NewBackOffRegistry(backoffOpts...),
+		GlobalTransformationOptions: globalTransOptions,
+	}).SetupWithManager(mgr, controllerOptions); err != nil {
+		setupLog.Error(err, "Unable to create controller", "controller", "VaultGenericSecret")
+		os.Exit(1)
+	}
+	if err = (&controllers.VaultWrappedSecretReconciler{
+		Client:                      mgr.GetClient(),
+		Scheme:                      mgr.GetScheme(),
+		Recorder:                    mgr.GetEventRecorderFor("VaultWrappedSecret"),
+		SecretDataBuilder:           secretDataBuilder,
+		SecretsClient:               secretsClient,
+		HMACValidator:               hmacValidator,
+		ClientFactory:               clientFactory,
+		BackOffRegistry:             controllers.NewBackOffRegistry(backoffOpts...),
+		GlobalTransformationOptions: globalTransOptions,
+	}).SetupWithManager(mgr, controllerOptions); err != nil {
+		setupLog.Error(err, "Unable to create controller", "controller", "VaultWrappedSecret")
+		os.Exit(1)
+	}
+	if err = (&controllers.VaultIdentityTokenReconciler{
+		Client:                      mgr.GetClient(),
+		Scheme:                      mgr.GetScheme(),
+		Recorder:                    mgr.GetEventRecorderFor("VaultIdentityToken"),
+		ClientFactory:               clientFactory,
+		SyncRegistry:                controllers.NewSyncRegistry(),
+		BackOffRegistry:             controllers.NewBackOffRegistry(backoffOpts...),
+		GlobalTransformationOptions: globalTransOptions,
+	}).SetupWithManager(mgr, controllerOptions); err != nil {
+		setupLog.Error(err, "Unable to create controller", "controller", "VaultIdentityToken")
+		os.Exit(1)
+	}
+	if err = (&controllers.VaultMongoDBAtlasSecretReconciler{
+		Client:                      mgr.GetClient(),
+		Scheme:                      mgr.GetScheme(),
+		Recorder:                    mgr.GetEventRecorderFor("VaultMongoDBAtlasSecret"),
+		ClientFactory:               clientFactory,
+		SyncRegistry:                controllers.NewSyncRegistry(),
+		BackOffRegistry:             controllers.NewBackOffRegistry(backoffOpts...),
+		GlobalTransformationOptions: globalTransOptions,
+	}).SetupWithManager(mgr, controllerOptions); err != nil {
+		setupLog.Error(err, "Unable to create controller", "controller", "VaultMongoDBAtlasSecret")
+		os.Exit(1)
+	}
+	if err = (&controllers.VaultSecretGroupReconciler{
+		Client:                      mgr.GetClient(),
+		Scheme:                      mgr.GetScheme(),
+		Recorder:                    mgr.GetEventRecorderFor("VaultSecretGroup"),
+		SecretDataBuilder:           secretDataBuilder,
+		SecretsClient:               secretsClient,
+		HMACValidator:               hmacValidator,
+		GlobalTransformationOptions: globalTransOptions,
+	}).SetupWithManager(mgr, controllerOptions); err != nil {
+		setupLog.Error(err, "Unable to create controller", "controller", "VaultSecretGroup")
+		os.Exit(1)
+	}
 	// +kubebuilder:scaffold:builder
 
+	if featuregates.Enabled(featuregates.Secretless) && secretlessBindAddr != "" {
+		if (secretlessClientCAFile == "") != (secretlessSPIFFETrustDomain == "") {
+			setupLog.Error(errors.New("invalid option"),
+				"--secretless-client-ca-file and --secretless-spiffe-trust-domain must be set together")
+			os.Exit(1)
+		}
+
+		certWatcher, err := certwatcher.New(
+			filepath.Join(secretlessCertDir, "tls.crt"),
+			filepath.Join(secretlessCertDir, "tls.key"),
+		)
+		if err != nil {
+			setupLog.Error(err, "Unable to load the secretless server certificate")
+			os.Exit(1)
+		}
+		if err := mgr.Add(certWatcher); err != nil {
+			setupLog.Error(err, "Unable to add the secretless certificate watcher")
+			os.Exit(1)
+		}
+
+		tlsConfig, err := secretless.NewTLSConfig(certWatcher.GetCertificate, secretlessClientCAFile)
+		if err != nil {
+			setupLog.Error(err, "Unable to setup the secretless server's TLS config")
+			os.Exit(1)
+		}
+
+		var authenticators []secretless.Authenticator
+		if secretlessSPIFFETrustDomain != "" {
+			authenticators = append(authenticators, &secretless.SPIFFEAuthenticator{
+				TrustDomain: secretlessSPIFFETrustDomain,
+			})
+		}
+		authenticators = append(authenticators, &secretless.TokenReviewAuthenticator{
+			Client:    mgr.GetClient(),
+			Audiences: []string{secretlessTokenAudience},
+		})
+
+		secretless.DefaultStore = secretless.NewStore()
+		if err := mgr.Add(&secretless.Server{
+			Addr:           secretlessBindAddr,
+			Store:          secretless.DefaultStore,
+			Authenticators: authenticators,
+			TLSConfig:      tlsConfig,
+		}); err != nil {
+			setupLog.Error(err, "Unable to add the secretless server")
+			os.Exit(1)
+		}
+	}
+
+	if expirationsBindAddr != "" {
+		certWatcher, err := certwatcher.New(
+			filepath.Join(expirationsCertDir, "tls.crt"),
+			filepath.Join(expirationsCertDir, "tls.key"),
+		)
+		if err != nil {
+			setupLog.Error(err, "Unable to load the expirations server certificate")
+			os.Exit(1)
+		}
+		if err := mgr.Add(certWatcher); err != nil {
+			setupLog.Error(err, "Unable to add the expirations certificate watcher")
+			os.Exit(1)
+		}
+
+		if err := mgr.Add(&expirations.Server{
+			Addr:   expirationsBindAddr,
+			Client: mgr.GetClient(),
+			TLSConfig: &tls.Config{
+				MinVersion:     tls.VersionTLS12,
+				GetCertificate: certWatcher.GetCertificate,
+			},
+		}); err != nil {
+			setupLog.Error(err, "Unable to add the expirations server")
+			os.Exit(1)
+		}
+	}
+
+	if syncLedger {
+		// the entries are sealed with the Operator's HMAC key, so that the
+		// ledgers cannot be forged by anyone who can write them.
+		ledgerMAC := func(ctx context.Context, message []byte) ([]byte, error) {
+			return hmacValidator.HMAC(ctx, secretsClient, message)
+		}
+		ledger.DefaultRecorder = &ledger.Recorder{
+			Sink: &ledger.CustomResourceSink{
+				Client:     mgr.GetClient(),
+				MAC:        ledgerMAC,
+				MaxEntries: syncLedgerMaxEntries,
+			},
+			MAC: ledgerMAC,
+		}
+
+		if syncLedgerRetention > 0 {
+			if err := mgr.Add(&ledger.Collector{
+				Client:    defaultClient,
+				Retention: syncLedgerRetention,
+				Interval:  time.Hour,
+			}); err != nil {
+				setupLog.Error(err, "Unable to add the sync ledger collector")
+				os.Exit(1)
+			}
+		}
+	}
+
+	if storageVersionMigration {
+		if err := mgr.Add(&storagemigration.Migrator{
+			Client: defaultClient,
+		}); err != nil {
+			setupLog.Error(err, "Unable to add the storage version migrator")
+			os.Exit(1)
+		}
+	}
+
+	if cloudEventsSinkURL != "" {
+		var sink cloudevents.Sink = &cloudevents.HTTPSink{
+			URL: cloudEventsSinkURL,
+		}
+		if cloudEventsKafkaTopic != "" {
+			sink = &cloudevents.KafkaRESTSink{
+				URL:   cloudEventsSinkURL,
+				Topic: cloudEventsKafkaTopic,
+			}
+		}
+		cloudevents.DefaultEmitter = cloudevents.NewEmitter(sink, cloudEventsSource, mgr.GetScheme(),
+			cloudevents.DefaultQueueSize)
+		if err := mgr.Add(cloudevents.DefaultEmitter); err != nil {
+			setupLog.Error(err, "Unable to add the CloudEvents emitter")
+			os.Exit(1)
+		}
+	}
+
+	if rolloutRestartCoalesceWindow > 0 {
+		helpers.DefaultRolloutRestartCoalescer = helpers.NewRolloutRestartCoalescer(
+			mgr.GetClient(), rolloutRestartCoalesceWindow)
+		if err := mgr.Add(helpers.DefaultRolloutRestartCoalescer); err != nil {
+			setupLog.Error(err, "Unable to add the rollout restart coalescer")
+			os.Exit(1)
+		}
+	}
+
+	if asyncIssuanceWorkers > 0 {
+		issuance.DefaultQueue = issuance.NewQueue(asyncIssuanceWorkers, issuance.DefaultTimeout)
+		if err := mgr.Add(issuance.DefaultQueue); err != nil {
+			setupLog.Error(err, "Unable to add the async issuance queue")
+			os.Exit(1)
+		}
+	}
+
+	if vaultHealthPollInterval > 0 {
+		if err := mgr.Add(&vaulthealth.Poller{
+			Client:   mgr.GetClient(),
+			Interval: vaultHealthPollInterval,
+		}); err != nil {
+			setupLog.Error(err, "Unable to add the Vault health poller")
+			os.Exit(1)
+		}
+	}
+
+	if diagnosticsFailureThreshold > 0 {
+		diagnostics.DefaultCapturer = diagnostics.NewCapturer(diagnosticsFailureThreshold, mgr.GetScheme())
+	}
+
+	if featuregates.Enabled(featuregates.DualWrite) && dualWriteKubeconfigSecret != "" {
+		until, err := time.Parse(time.RFC3339, dualWriteUntil)
+		if err != nil {
+			setupLog.Error(err, "Invalid argument for --dual-write-until, an RFC3339 time is required")
+			os.Exit(1)
+		}
+		key := client.ObjectKey{
+			Namespace: common.OperatorNamespace,
+			Name:      dualWriteKubeconfigSecret,
+		}
+		if ns, name, ok := strings.Cut(dualWriteKubeconfigSecret, "/"); ok {
+			key = client.ObjectKey{
+				Namespace: ns,
+				Name:      name,
+			}
+		}
+		dualwrite.DefaultWriter, err = dualwrite.NewWriter(ctx, defaultClient, key, until, mgr.GetScheme())
+		if err != nil {
+			setupLog.Error(err, "Unable to set up the dual-write to the target cluster")
+			os.Exit(1)
+		}
+		if !dualwrite.DefaultWriter.Active() {
+			setupLog.Info("Warning: the dual-write migration window has already ended", "until", until)
+		} else if err := mgr.Add(&dualwrite.Backfiller{
+			Writer: dualwrite.DefaultWriter,
+			List: func(ctx context.Context) ([]*corev1.Secret, error) {
+				return helpers.DualWriteSecrets(ctx, secretsClient)
+			},
+			Interval: time.Minute,
+		}); err != nil {
+			setupLog.Error(err, "Unable to add the dual-write backfiller")
+			os.Exit(1)
+		}
+	}
+
+	if admissionDefaultsConfig != "" {
+		cfg, err := admissiondefaults.LoadConfig(admissionDefaultsConfig)
+		if err != nil {
+			setupLog.Error(err, "Invalid argument for --admission-defaults-config")
+			os.Exit(1)
+		}
+		if err := (&admissiondefaults.Defaulter{
+			Config: cfg,
+		}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "Unable to set up the admission defaults webhook")
+			os.Exit(1)
+		}
+	}
+
+	if vaultAuthParamsWebhook {
+		if err := (&vaultauthwebhook.Validator{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "Unable to set up the VaultAuth params webhook")
+			os.Exit(1)
+		}
+	}
+
+	if featuregates.Enabled(featuregates.StandbyRenewals) {
+		// the standby replicas' clients are never persisted, the leader's are.
+		standbyCfc := *cfc
+		standbyCfc.Persist = false
+		standbyCfc.CollectClientCacheMetrics = false
+		standbyCfc.MetricsRegistry = nil
+		standbyCfc.Recorder = mgr.GetEventRecorderFor("standbyVaultClientFactory")
+		standbyClientFactory, err := vclient.NewCachingClientFactory(ctx, defaultClient, nil, &standbyCfc)
+		if err != nil {
+			setupLog.Error(err, "Failed to setup the standby Vault ClientFactory")
+			os.Exit(1)
+		}
+		if err := mgr.Add(&standbyrenewal.Renewer{
+			Client:        mgr.GetClient(),
+			ClientFactory: standbyClientFactory,
+			Elected:       mgr.Elected(),
+			Identity:      os.Getenv("OPERATOR_POD_NAME"),
+		}); err != nil {
+			setupLog.Error(err, "Unable to add the standby lease renewer")
+			os.Exit(1)
+		}
+	}
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "Unable to set up health check")
 		os.Exit(1)
@@ -658,7 +1369,27 @@ func main() {
 		"backoffInitialInterval", backoffInitialInterval,
 		"backoffRandomizationFactor", backoffRandomizationFactor,
 		"globalTransformationOptions", globalTransformationOpts,
+		"operatorMetadata", operatorMetadata,
 		"globalVaultAuthOptions", globalVaultAuthOpts,
+		"secretlessBindAddress", secretlessBindAddr,
+		"expirationsBindAddress", expirationsBindAddr,
+		"checksumAlgorithm", checksumAlgorithm,
+		"minLeaseDuration", minLeaseDuration,
+		"syncLedger", syncLedger,
+		"storageVersionMigration", storageVersionMigration,
+		"admissionDefaultsConfig", admissionDefaultsConfig,
+		"vaultAuthParamsWebhook", vaultAuthParamsWebhook,
+		"cloudEventsSinkURL", cloudEventsSinkURL,
+		"cloudEventsKafkaTopic", cloudEventsKafkaTopic,
+		"cloudEventsSource", cloudEventsSource,
+		"rolloutRestartCoalesceWindow", rolloutRestartCoalesceWindow,
+		"asyncIssuanceWorkers", asyncIssuanceWorkers,
+		"vaultHealthPollInterval", vaultHealthPollInterval,
+		"diagnosticsFailureThreshold", diagnosticsFailureThreshold,
+		"dualWriteKubeconfigSecret", dualWriteKubeconfigSecret,
+		"dualWriteUntil", dualWriteUntil,
+		"userAgent", vclient.DefaultUserAgent,
+		"featureGates", featuregates.DefaultGates.String(),
 	)
 
 	mgr.GetCache()
@@ -669,6 +1400,22 @@ func main() {
 	}
 }
 
+// runCSIProvider runs the Operator in CSI provider mode, until ctx is done.
+// The provider's Client is set up with the CA certificate in caFile.
+func runCSIProvider(ctx context.Context, p *secretless.CSIProvider, caFile string) error {
+	if p.ServerURL == "" {
+		return errors.New("--csi-provider-server-url is required with --csi-provider-socket")
+	}
+
+	httpClient, err := secretless.NewCSIProviderClient(caFile)
+	if err != nil {
+		return fmt.Errorf("invalid argument for --csi-provider-ca-file: %w", err)
+	}
+	p.Client = httpClient
+
+	return p.Start(ctx)
+}
+
 func shutDownOperator(ctx context.Context, c client.Client, mode vclient.ShutDownMode) error {
 	cm, err := vclient.GetManagerConfigMap(ctx, c)
 	if err != nil {
@@ -0,0 +1,198 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package secretless
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// Agent runs in the workload's Pod, it fetches the data for a single resource
+// from the Server and writes each key to a file in Dir. It is meant to be run
+// as an init container, by calling Sync once, and optionally as a sidecar, by
+// calling Run, to pick up rotations.
+type Agent struct {
+	// ServerURL is the base URL of the Server,
+	// e.g. https://vso-secretless.vault-secrets-operator.svc:9444
+	ServerURL string
+	// Namespace of the syncable secret resource.
+	Namespace string
+	// Name of the resource's destination.
+	Name string
+	// Dir that the data files are written to.
+	Dir string
+	// TokenFile containing the projected ServiceAccount token. It is read on
+	// every request, since the kubelet rotates it. When empty, the agent must
+	// authenticate with a client certificate configured on Client.
+	TokenFile string
+	// Client is the HTTP client used to talk to the Server.
+	Client *http.Client
+	// Interval between syncs in Run.
+	Interval time.Duration
+	Logger   logr.Logger
+
+	version string
+	keys    []string
+}
+
+// Sync fetches the data from the Server, and writes it out to Dir if it has
+// changed since the last call. Returns true if the data was written.
+func (a *Agent) Sync(ctx context.Context) (bool, error) {
+	var token string
+	if a.TokenFile != "" {
+		b, err := os.ReadFile(a.TokenFile)
+		if err != nil {
+			return false, err
+		}
+		token = strings.TrimSpace(string(b))
+	}
+
+	r, err := fetch(ctx, a.Client, a.ServerURL, a.Namespace, a.Name, token, a.version)
+	if err != nil || r == nil {
+		return false, err
+	}
+
+	if err := a.writeFiles(r.Data); err != nil {
+		return false, err
+	}
+	a.version = r.Version
+
+	return true, nil
+}
+
+// fetch returns the data for the resource's destination name in namespace from
+// the Server at serverURL. The request is authenticated with the ServiceAccount
+// token, when it is not empty. If version is not empty, nil is returned when the
+// data has not changed since.
+func fetch(ctx context.Context, client *http.Client, serverURL, namespace, name, token, version string) (*Response, error) {
+	u, err := url.JoinPath(serverURL, secretsPathPrefix, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	if version != "" {
+		req.Header.Set("If-None-Match", fmt.Sprintf("%q", version))
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNotModified:
+		return nil, nil
+	case http.StatusOK:
+	default:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("unexpected response from %s, status=%d, body=%q",
+			u, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var r Response
+	if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
+
+// Run calls Sync every Interval until ctx is done. Sync errors are logged, the
+// previously written data is left in place.
+func (a *Agent) Run(ctx context.Context) error {
+	if a.Interval <= 0 {
+		return errors.New("interval must be greater than zero")
+	}
+
+	for {
+		if ok, err := a.Sync(ctx); err != nil {
+			a.Logger.Error(err, "Sync failed")
+		} else if ok {
+			a.Logger.Info("Synced", "version", a.version)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(a.Interval):
+		}
+	}
+}
+
+// writeFiles writes each key in data to its own file in Dir. Each file is
+// replaced atomically, and files for keys that are no longer present are
+// removed.
+func (a *Agent) writeFiles(data map[string][]byte) error {
+	keys := make([]string, 0, len(data))
+	for k, v := range data {
+		if err := validateKey(k); err != nil {
+			return err
+		}
+
+		tmp, err := os.CreateTemp(a.Dir, ".tmp-")
+		if err != nil {
+			return err
+		}
+		if _, err := tmp.Write(v); err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+			return err
+		}
+		if err := tmp.Close(); err != nil {
+			os.Remove(tmp.Name())
+			return err
+		}
+		if err := os.Chmod(tmp.Name(), 0o440); err != nil {
+			os.Remove(tmp.Name())
+			return err
+		}
+		if err := os.Rename(tmp.Name(), filepath.Join(a.Dir, k)); err != nil {
+			os.Remove(tmp.Name())
+			return err
+		}
+		keys = append(keys, k)
+	}
+
+	for _, k := range a.keys {
+		if !slices.Contains(keys, k) {
+			if err := os.Remove(filepath.Join(a.Dir, k)); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+	}
+	a.keys = keys
+
+	return nil
+}
+
+// validateKey ensures that k can be safely used as a file name in Dir.
+func validateKey(k string) error {
+	if k == "" || k == "." || k == ".." || strings.HasPrefix(k, ".tmp-") ||
+		strings.ContainsAny(k, `/\`) {
+		return fmt.Errorf("invalid data key %q", k)
+	}
+	return nil
+}
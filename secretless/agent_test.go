@@ -0,0 +1,108 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package secretless
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+type tokenAuthenticator struct {
+	token string
+}
+
+func (a *tokenAuthenticator) Authenticate(_ context.Context, req *http.Request) (*Identity, error) {
+	if req.Header.Get("Authorization") != "Bearer "+a.token {
+		return nil, nil
+	}
+	return &Identity{Namespace: "baz", ServiceAccount: "app"}, nil
+}
+
+func TestAgent_Sync(t *testing.T) {
+	t.Parallel()
+
+	store := NewStore()
+	key := types.NamespacedName{Namespace: "baz", Name: "foo"}
+	srv := httptest.NewServer((&Server{
+		Store:          store,
+		Authenticators: []Authenticator{&tokenAuthenticator{token: "token"}},
+	}).Handler())
+	t.Cleanup(srv.Close)
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("token\n"), 0o600))
+
+	dir := t.TempDir()
+	a := &Agent{
+		ServerURL: srv.URL,
+		Namespace: key.Namespace,
+		Name:      key.Name,
+		Dir:       dir,
+		TokenFile: tokenFile,
+		Client:    srv.Client(),
+	}
+
+	ctx := context.Background()
+	// not synced by the Operator yet
+	_, err := a.Sync(ctx)
+	assert.Error(t, err)
+
+	assertFiles := func(t *testing.T, want map[string]string) {
+		t.Helper()
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		got := make(map[string]string)
+		for _, e := range entries {
+			b, err := os.ReadFile(filepath.Join(dir, e.Name()))
+			require.NoError(t, err)
+			got[e.Name()] = string(b)
+		}
+		assert.Equal(t, want, got)
+	}
+
+	require.NoError(t, store.Set(key, "uid-1", []string{"app"}, false, map[string][]byte{
+		"username": []byte("user"),
+		"password": []byte("secret"),
+	}))
+	changed, err := a.Sync(ctx)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assertFiles(t, map[string]string{
+		"username": "user",
+		"password": "secret",
+	})
+
+	changed, err = a.Sync(ctx)
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	// rotation removes the files of keys that are no longer present
+	require.NoError(t, store.Set(key, "uid-1", []string{"app"}, false, map[string][]byte{
+		"password": []byte("rotated"),
+	}))
+	changed, err = a.Sync(ctx)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assertFiles(t, map[string]string{
+		"password": "rotated",
+	})
+
+	// invalid keys are never written
+	require.NoError(t, store.Set(key, "uid-1", []string{"app"}, false, map[string][]byte{
+		"../password": []byte("escaped"),
+	}))
+	_, err = a.Sync(ctx)
+	assert.EqualError(t, err, `invalid data key "../password"`)
+	assertFiles(t, map[string]string{
+		"password": "rotated",
+	})
+}
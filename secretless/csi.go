@@ -0,0 +1,247 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package secretless
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"net/http"
+	"os"
+	"slices"
+	"time"
+
+	"google.golang.org/grpc"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	// CSIParameterName is the SecretProviderClass parameter that holds the
+	// destination name of the syncable secret resource, it must be in the
+	// Pod's namespace.
+	CSIParameterName = "name"
+	// csiAPIVersion is the version of the CSIDriverProvider service.
+	csiAPIVersion = "v1alpha1"
+	// csiRuntimeName is the runtime name reported to the CSI driver.
+	csiRuntimeName = "vault-secrets-operator"
+	// csiAttributePodNamespace and csiAttributeServiceAccountTokens are the
+	// attributes that the CSI driver sets on every MountRequest.
+	csiAttributePodNamespace         = "csi.storage.k8s.io/pod.namespace"
+	csiAttributeServiceAccountTokens = "csi.storage.k8s.io/serviceAccount.tokens"
+	// csiDefaultFileMode is the mode of the files, if the CSI driver does not
+	// request one.
+	csiDefaultFileMode = os.FileMode(0o644)
+)
+
+var (
+	_ manager.Runnable        = (*CSIProvider)(nil)
+	_ csiDriverProviderServer = (*CSIProvider)(nil)
+)
+
+// csiDriverProviderServer is the server API of the v1alpha1.CSIDriverProvider
+// service.
+type csiDriverProviderServer interface {
+	version(ctx context.Context, req *csiVersionRequest) (*csiVersionResponse, error)
+	mount(ctx context.Context, req *csiMountRequest) (*csiMountResponse, error)
+}
+
+// CSIProvider is a Secrets Store CSI driver provider, it serves the data held
+// by the Server to the Pods mounting a CSI volume of a SecretProviderClass
+// whose provider is vault-secrets-operator, so that it is mounted as files
+// without ever being stored in a Kubernetes Secret. It runs on every node,
+// alongside the CSI driver, and fetches the data from the Server on behalf of
+// the Pod, with the Pod's own ServiceAccount token. The CSIDriver must request
+// a token for TokenAudience, see its spec.tokenRequests.
+type CSIProvider struct {
+	// SocketPath of the Unix domain socket that the provider listens on, the CSI
+	// driver expects it in /etc/kubernetes/secrets-store-csi-providers.
+	SocketPath string
+	// ServerURL is the base URL of the Server,
+	// e.g. https://vso-secretless.vault-secrets-operator.svc:9444
+	ServerURL string
+	// Client is the HTTP client used to talk to the Server.
+	Client *http.Client
+	// TokenAudience of the Pod's ServiceAccount token, it must match the
+	// audience accepted by the Server.
+	TokenAudience string
+	// RuntimeVersion is the version reported to the CSI driver.
+	RuntimeVersion string
+}
+
+// Start the provider, blocking until ctx is done.
+func (p *CSIProvider) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("csi-provider")
+
+	// the socket of a previous run must be removed before listening again.
+	if err := os.Remove(p.SocketPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	ln, err := net.Listen("unix", p.SocketPath)
+	if err != nil {
+		return err
+	}
+
+	srv := grpc.NewServer(grpc.ForceServerCodec(csiCodec{}))
+	srv.RegisterService(&csiServiceDesc, p)
+	go func() {
+		<-ctx.Done()
+		srv.GracefulStop()
+	}()
+
+	logger.Info("Starting the CSI provider", "socket", p.SocketPath)
+	return srv.Serve(ln)
+}
+
+// NeedLeaderElection returns false, since the provider must run on every node.
+func (p *CSIProvider) NeedLeaderElection() bool {
+	return false
+}
+
+func (p *CSIProvider) version(_ context.Context, _ *csiVersionRequest) (*csiVersionResponse, error) {
+	return &csiVersionResponse{
+		Version:        csiAPIVersion,
+		RuntimeName:    csiRuntimeName,
+		RuntimeVersion: p.RuntimeVersion,
+	}, nil
+}
+
+func (p *CSIProvider) mount(ctx context.Context, req *csiMountRequest) (*csiMountResponse, error) {
+	var attributes map[string]string
+	if err := json.Unmarshal([]byte(req.Attributes), &attributes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the attributes: %w", err)
+	}
+
+	name := attributes[CSIParameterName]
+	if name == "" {
+		return nil, fmt.Errorf("the SecretProviderClass parameter %q is required", CSIParameterName)
+	}
+	namespace := attributes[csiAttributePodNamespace]
+	if namespace == "" {
+		return nil, fmt.Errorf("the attribute %q is required", csiAttributePodNamespace)
+	}
+
+	token, err := csiServiceAccountToken(attributes, p.TokenAudience)
+	if err != nil {
+		return nil, err
+	}
+
+	mode := csiDefaultFileMode
+	if req.Permission != "" {
+		if err := json.Unmarshal([]byte(req.Permission), &mode); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal the permission: %w", err)
+		}
+	}
+
+	logger := log.FromContext(ctx).WithName("csi-provider").WithValues(
+		"namespace", namespace, "name", name)
+	r, err := fetch(ctx, p.Client, p.ServerURL, namespace, name, token, "")
+	if err != nil {
+		logger.Error(err, "Failed to fetch the data")
+		return nil, err
+	}
+
+	resp := &csiMountResponse{
+		ObjectVersion: []csiObjectVersion{
+			{
+				ID:      "secret/" + name,
+				Version: r.Version,
+			},
+		},
+	}
+	for _, k := range slices.Sorted(maps.Keys(r.Data)) {
+		if err := validateKey(k); err != nil {
+			return nil, err
+		}
+		resp.Files = append(resp.Files, csiFile{
+			Path:     k,
+			Mode:     int32(mode),
+			Contents: r.Data[k],
+		})
+	}
+
+	return resp, nil
+}
+
+// NewCSIProviderClient returns the HTTP client of the CSIProvider. The Server's
+// certificate is verified with the CA certificates in caFile, or with the
+// system's when it is empty.
+func NewCSIProviderClient(caFile string) (*http.Client, error) {
+	cfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+	if caFile != "" {
+		b, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(b) {
+			return nil, fmt.Errorf("no CA certificates found in %s", caFile)
+		}
+		cfg.RootCAs = pool
+	}
+
+	return &http.Client{
+		Timeout: 30 * time.Second,
+		Transport: &http.Transport{
+			TLSClientConfig: cfg,
+		},
+	}, nil
+}
+
+// csiServiceAccountToken returns the Pod's ServiceAccount token for audience,
+// from the tokens that the CSI driver requested for the Pod.
+func csiServiceAccountToken(attributes map[string]string, audience string) (string, error) {
+	var tokens map[string]struct {
+		Token string `json:"token"`
+	}
+	if v := attributes[csiAttributeServiceAccountTokens]; v != "" {
+		if err := json.Unmarshal([]byte(v), &tokens); err != nil {
+			return "", fmt.Errorf("failed to unmarshal the ServiceAccount tokens: %w", err)
+		}
+	}
+
+	t, ok := tokens[audience]
+	if !ok || t.Token == "" {
+		return "", fmt.Errorf("no ServiceAccount token for audience %q, "+
+			"it must be requested by the CSIDriver's tokenRequests", audience)
+	}
+
+	return t.Token, nil
+}
+
+// csiServiceDesc describes the v1alpha1.CSIDriverProvider service.
+var csiServiceDesc = grpc.ServiceDesc{
+	ServiceName: "v1alpha1.CSIDriverProvider",
+	HandlerType: (*csiDriverProviderServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Version",
+			Handler: func(srv any, ctx context.Context, dec func(any) error, _ grpc.UnaryServerInterceptor) (any, error) {
+				var req csiVersionRequest
+				if err := dec(&req); err != nil {
+					return nil, err
+				}
+				return srv.(csiDriverProviderServer).version(ctx, &req)
+			},
+		},
+		{
+			MethodName: "Mount",
+			Handler: func(srv any, ctx context.Context, dec func(any) error, _ grpc.UnaryServerInterceptor) (any, error) {
+				var req csiMountRequest
+				if err := dec(&req); err != nil {
+					return nil, err
+				}
+				return srv.(csiDriverProviderServer).mount(ctx, &req)
+			},
+		},
+	},
+	Metadata: "provider/v1alpha1/service.proto",
+}
@@ -0,0 +1,333 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package secretless
+
+import (
+	"fmt"
+
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// The messages of the v1alpha1.CSIDriverProvider service of the Secrets Store
+// CSI driver, see
+// https://github.com/kubernetes-sigs/secrets-store-csi-driver/blob/main/provider/v1alpha1/service.proto
+// They are encoded by hand, since only a handful of fields are needed.
+
+// csiMessage is implemented by all the messages of the CSIDriverProvider
+// service.
+type csiMessage interface {
+	marshal() []byte
+	unmarshal(b []byte) error
+}
+
+var (
+	_ csiMessage     = (*csiVersionRequest)(nil)
+	_ csiMessage     = (*csiVersionResponse)(nil)
+	_ csiMessage     = (*csiMountRequest)(nil)
+	_ csiMessage     = (*csiMountResponse)(nil)
+	_ encoding.Codec = csiCodec{}
+)
+
+type csiVersionRequest struct {
+	Version string
+}
+
+type csiVersionResponse struct {
+	Version        string
+	RuntimeName    string
+	RuntimeVersion string
+}
+
+type csiMountRequest struct {
+	// Attributes is the JSON encoded SecretProviderClass parameters, along with
+	// the Pod's information.
+	Attributes string
+	// Secrets is the JSON encoded node publish secret.
+	Secrets    string
+	TargetPath string
+	// Permission is the JSON encoded file mode of the files.
+	Permission           string
+	CurrentObjectVersion []csiObjectVersion
+}
+
+type csiMountResponse struct {
+	ObjectVersion []csiObjectVersion
+	Error         *csiError
+	Files         []csiFile
+}
+
+type csiObjectVersion struct {
+	ID      string
+	Version string
+}
+
+type csiError struct {
+	Code string
+}
+
+type csiFile struct {
+	Path     string
+	Mode     int32
+	Contents []byte
+}
+
+func (m *csiVersionRequest) marshal() []byte {
+	return appendString(nil, 1, m.Version)
+}
+
+func (m *csiVersionRequest) unmarshal(b []byte) error {
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, v []byte) error {
+		if num == 1 && typ == protowire.BytesType {
+			m.Version = string(v)
+		}
+		return nil
+	})
+}
+
+func (m *csiVersionResponse) marshal() []byte {
+	b := appendString(nil, 1, m.Version)
+	b = appendString(b, 2, m.RuntimeName)
+	return appendString(b, 3, m.RuntimeVersion)
+}
+
+func (m *csiVersionResponse) unmarshal(b []byte) error {
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, v []byte) error {
+		if typ != protowire.BytesType {
+			return nil
+		}
+		switch num {
+		case 1:
+			m.Version = string(v)
+		case 2:
+			m.RuntimeName = string(v)
+		case 3:
+			m.RuntimeVersion = string(v)
+		}
+		return nil
+	})
+}
+
+func (m *csiMountRequest) marshal() []byte {
+	b := appendString(nil, 1, m.Attributes)
+	b = appendString(b, 2, m.Secrets)
+	b = appendString(b, 3, m.TargetPath)
+	b = appendString(b, 4, m.Permission)
+	for _, v := range m.CurrentObjectVersion {
+		b = appendMessage(b, 5, v.marshal())
+	}
+	return b
+}
+
+func (m *csiMountRequest) unmarshal(b []byte) error {
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, v []byte) error {
+		if typ != protowire.BytesType {
+			return nil
+		}
+		switch num {
+		case 1:
+			m.Attributes = string(v)
+		case 2:
+			m.Secrets = string(v)
+		case 3:
+			m.TargetPath = string(v)
+		case 4:
+			m.Permission = string(v)
+		case 5:
+			var ov csiObjectVersion
+			if err := ov.unmarshal(v); err != nil {
+				return err
+			}
+			m.CurrentObjectVersion = append(m.CurrentObjectVersion, ov)
+		}
+		return nil
+	})
+}
+
+func (m *csiMountResponse) marshal() []byte {
+	var b []byte
+	for _, v := range m.ObjectVersion {
+		b = appendMessage(b, 1, v.marshal())
+	}
+	if m.Error != nil {
+		b = appendMessage(b, 2, appendString(nil, 1, m.Error.Code))
+	}
+	for _, f := range m.Files {
+		b = appendMessage(b, 3, f.marshal())
+	}
+	return b
+}
+
+func (m *csiMountResponse) unmarshal(b []byte) error {
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, v []byte) error {
+		if typ != protowire.BytesType {
+			return nil
+		}
+		switch num {
+		case 1:
+			var ov csiObjectVersion
+			if err := ov.unmarshal(v); err != nil {
+				return err
+			}
+			m.ObjectVersion = append(m.ObjectVersion, ov)
+		case 2:
+			m.Error = &csiError{}
+			return consumeFields(v, func(num protowire.Number, typ protowire.Type, v []byte) error {
+				if num == 1 && typ == protowire.BytesType {
+					m.Error.Code = string(v)
+				}
+				return nil
+			})
+		case 3:
+			var f csiFile
+			if err := f.unmarshal(v); err != nil {
+				return err
+			}
+			m.Files = append(m.Files, f)
+		}
+		return nil
+	})
+}
+
+func (m *csiObjectVersion) marshal() []byte {
+	b := appendString(nil, 1, m.ID)
+	return appendString(b, 2, m.Version)
+}
+
+func (m *csiObjectVersion) unmarshal(b []byte) error {
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, v []byte) error {
+		if typ != protowire.BytesType {
+			return nil
+		}
+		switch num {
+		case 1:
+			m.ID = string(v)
+		case 2:
+			m.Version = string(v)
+		}
+		return nil
+	})
+}
+
+func (m *csiFile) marshal() []byte {
+	b := appendString(nil, 1, m.Path)
+	if m.Mode != 0 {
+		b = protowire.AppendTag(b, 2, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(m.Mode))
+	}
+	if len(m.Contents) > 0 {
+		b = protowire.AppendTag(b, 3, protowire.BytesType)
+		b = protowire.AppendBytes(b, m.Contents)
+	}
+	return b
+}
+
+func (m *csiFile) unmarshal(b []byte) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+		if num == 2 && typ == protowire.VarintType {
+			v, n := protowire.ConsumeVarint(b)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			m.Mode = int32(v)
+			b = b[n:]
+			continue
+		}
+		if typ == protowire.BytesType {
+			v, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			switch num {
+			case 1:
+				m.Path = string(v)
+			case 3:
+				m.Contents = append([]byte(nil), v...)
+			}
+			b = b[n:]
+			continue
+		}
+		n = protowire.ConsumeFieldValue(num, typ, b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+// appendString appends the string field num to b, unless v is empty, which is
+// the default value of proto3 strings.
+func appendString(b []byte, num protowire.Number, v string) []byte {
+	if v == "" {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, v)
+}
+
+func appendMessage(b []byte, num protowire.Number, v []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, v)
+}
+
+// consumeFields calls fn with the value of each length-delimited field of b,
+// all the other fields are skipped.
+func consumeFields(b []byte, fn func(num protowire.Number, typ protowire.Type, v []byte) error) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+		if typ != protowire.BytesType {
+			n = protowire.ConsumeFieldValue(num, typ, b)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			b = b[n:]
+			continue
+		}
+
+		v, n := protowire.ConsumeBytes(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		if err := fn(num, typ, v); err != nil {
+			return err
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+// csiCodec encodes the csiMessages in the protobuf wire format.
+type csiCodec struct{}
+
+func (csiCodec) Marshal(v any) ([]byte, error) {
+	m, ok := v.(csiMessage)
+	if !ok {
+		return nil, fmt.Errorf("unsupported message type %T", v)
+	}
+	return m.marshal(), nil
+}
+
+func (csiCodec) Unmarshal(data []byte, v any) error {
+	m, ok := v.(csiMessage)
+	if !ok {
+		return fmt.Errorf("unsupported message type %T", v)
+	}
+	return m.unmarshal(data)
+}
+
+// Name returns "proto", since the messages are wire compatible with the
+// generated ones.
+func (csiCodec) Name() string {
+	return "proto"
+}
@@ -0,0 +1,237 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package secretless
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func newCSIProviderConn(t *testing.T, p *CSIProvider) *grpc.ClientConn {
+	t.Helper()
+
+	// the socket path must be short, so the test's temp dir cannot be used.
+	dir, err := os.MkdirTemp("", "vso-csi-")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = os.RemoveAll(dir)
+	})
+	p.SocketPath = filepath.Join(dir, "provider.sock")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go func() {
+		_ = p.Start(ctx)
+	}()
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(p.SocketPath)
+		return err == nil
+	}, 10*time.Second, 10*time.Millisecond)
+
+	conn, err := grpc.NewClient("unix://"+p.SocketPath,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(csiCodec{})))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	return conn
+}
+
+func csiAttributes(t *testing.T, name, token string) string {
+	t.Helper()
+
+	tokens, err := json.Marshal(map[string]any{
+		DefaultTokenAudience: map[string]string{
+			"token":               token,
+			"expirationTimestamp": "2024-01-02T03:04:05Z",
+		},
+	})
+	require.NoError(t, err)
+	b, err := json.Marshal(map[string]string{
+		CSIParameterName:                 name,
+		csiAttributePodNamespace:         "baz",
+		csiAttributeServiceAccountTokens: string(tokens),
+	})
+	require.NoError(t, err)
+	return string(b)
+}
+
+func TestCSIProvider(t *testing.T) {
+	t.Parallel()
+
+	store := NewStore()
+	key := types.NamespacedName{Namespace: "baz", Name: "foo"}
+	require.NoError(t, store.Set(key, "uid", []string{"app"}, false, map[string][]byte{
+		"username": []byte("user"),
+		"password": []byte("pass"),
+	}))
+	entry, _ := store.Get(key)
+
+	srv := httptest.NewServer((&Server{
+		Store:          store,
+		Authenticators: []Authenticator{&tokenAuthenticator{token: "token"}},
+	}).Handler())
+	t.Cleanup(srv.Close)
+
+	conn := newCSIProviderConn(t, &CSIProvider{
+		ServerURL:      srv.URL,
+		Client:         srv.Client(),
+		TokenAudience:  DefaultTokenAudience,
+		RuntimeVersion: "v1.0.0",
+	})
+	ctx := context.Background()
+
+	var version csiVersionResponse
+	require.NoError(t, conn.Invoke(ctx, "/v1alpha1.CSIDriverProvider/Version",
+		&csiVersionRequest{Version: "v1alpha1"}, &version))
+	assert.Equal(t, csiVersionResponse{
+		Version:        "v1alpha1",
+		RuntimeName:    "vault-secrets-operator",
+		RuntimeVersion: "v1.0.0",
+	}, version)
+
+	var resp csiMountResponse
+	require.NoError(t, conn.Invoke(ctx, "/v1alpha1.CSIDriverProvider/Mount", &csiMountRequest{
+		Attributes: csiAttributes(t, "foo", "token"),
+		TargetPath: "/var/lib/kubelet/pods/uid/volumes/kubernetes.io~csi/secrets/mount",
+		Permission: "420",
+	}, &resp))
+	assert.Equal(t, csiMountResponse{
+		ObjectVersion: []csiObjectVersion{
+			{
+				ID:      "secret/foo",
+				Version: entry.Version,
+			},
+		},
+		Files: []csiFile{
+			{
+				Path:     "password",
+				Mode:     0o644,
+				Contents: []byte("pass"),
+			},
+			{
+				Path:     "username",
+				Mode:     0o644,
+				Contents: []byte("user"),
+			},
+		},
+	}, resp)
+
+	// the Pod's ServiceAccount is not authenticated.
+	err := conn.Invoke(ctx, "/v1alpha1.CSIDriverProvider/Mount", &csiMountRequest{
+		Attributes: csiAttributes(t, "foo", "other"),
+	}, &csiMountResponse{})
+	assert.ErrorContains(t, err, "status=401")
+}
+
+func TestCSIProvider_mount_invalid(t *testing.T) {
+	t.Parallel()
+
+	p := &CSIProvider{
+		TokenAudience: DefaultTokenAudience,
+	}
+
+	tests := []struct {
+		name    string
+		req     *csiMountRequest
+		wantErr string
+	}{
+		{
+			name: "invalid-attributes",
+			req: &csiMountRequest{
+				Attributes: "{",
+			},
+			wantErr: "failed to unmarshal the attributes",
+		},
+		{
+			name: "no-name",
+			req: &csiMountRequest{
+				Attributes: csiAttributes(t, "", "token"),
+			},
+			wantErr: `the SecretProviderClass parameter "name" is required`,
+		},
+		{
+			name: "no-token",
+			req: &csiMountRequest{
+				Attributes: `{"name":"foo","csi.storage.k8s.io/pod.namespace":"baz"}`,
+			},
+			wantErr: `no ServiceAccount token for audience "vault-secrets-operator-secretless"`,
+		},
+		{
+			name: "invalid-permission",
+			req: &csiMountRequest{
+				Attributes: csiAttributes(t, "foo", "token"),
+				Permission: "rw",
+			},
+			wantErr: "failed to unmarshal the permission",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := p.mount(context.Background(), tt.req)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestCSIMessages(t *testing.T) {
+	t.Parallel()
+
+	req := &csiMountRequest{
+		Attributes: `{"name":"foo"}`,
+		Secrets:    "{}",
+		TargetPath: "/mnt",
+		Permission: "420",
+		CurrentObjectVersion: []csiObjectVersion{
+			{
+				ID:      "secret/foo",
+				Version: "1",
+			},
+		},
+	}
+	var gotReq csiMountRequest
+	require.NoError(t, gotReq.unmarshal(req.marshal()))
+	assert.Equal(t, *req, gotReq)
+
+	resp := &csiMountResponse{
+		ObjectVersion: []csiObjectVersion{
+			{
+				ID:      "secret/foo",
+				Version: "1",
+			},
+		},
+		Error: &csiError{
+			Code: "Unauthorized",
+		},
+		Files: []csiFile{
+			{
+				Path:     "password",
+				Mode:     0o600,
+				Contents: []byte("pass"),
+			},
+		},
+	}
+	var gotResp csiMountResponse
+	require.NoError(t, gotResp.unmarshal(resp.marshal()))
+	assert.Equal(t, *resp, gotResp)
+
+	// the wire format of a File, with the field numbers of service.proto.
+	assert.Equal(t, []byte{
+		0x0a, 0x01, 'a',
+		0x10, 0x80, 0x03,
+		0x1a, 0x01, 'b',
+	}, (&csiFile{Path: "a", Mode: 0o600, Contents: []byte("b")}).marshal())
+}
@@ -0,0 +1,354 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package secretless
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	authv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/hashicorp/vault-secrets-operator/consts"
+)
+
+const (
+	// DefaultTokenAudience is the audience that the agent's projected
+	// ServiceAccount token must be issued for.
+	DefaultTokenAudience = "vault-secrets-operator-secretless"
+	// secretsPathPrefix is the prefix of the data endpoint. The full path is
+	// /v1/secrets/<namespace>/<name>.
+	secretsPathPrefix = "/v1/secrets"
+	// serviceAccountUsernamePrefix is the prefix of the username that
+	// Kubernetes assigns to a ServiceAccount.
+	serviceAccountUsernamePrefix = "system:serviceaccount:"
+	// extraPodName and extraPodUID are the extra user info keys that identify
+	// the Pod a ServiceAccount token is bound to.
+	extraPodName = "authentication.kubernetes.io/pod-name"
+	extraPodUID  = "authentication.kubernetes.io/pod-uid"
+)
+
+var (
+	_ manager.Runnable               = (*Server)(nil)
+	_ manager.LeaderElectionRunnable = (*Server)(nil)
+	_ Authenticator                  = (*TokenReviewAuthenticator)(nil)
+	_ Authenticator                  = (*SPIFFEAuthenticator)(nil)
+)
+
+// Response is the body returned by the data endpoint.
+type Response struct {
+	// Data is the rendered secret data.
+	Data map[string][]byte `json:"data"`
+	// Version of Data, it changes every time Data changes.
+	Version string `json:"version"`
+}
+
+// Identity of an authenticated agent. Agents are always identified by their
+// Kubernetes ServiceAccount.
+type Identity struct {
+	Namespace      string
+	ServiceAccount string
+	// PodName and PodUID identify the Pod the agent's credentials are bound
+	// to, they are empty if they are not bound to a Pod.
+	PodName string
+	PodUID  types.UID
+}
+
+// Authenticator authenticates agent requests.
+type Authenticator interface {
+	// Authenticate returns the Identity of the request's sender. It returns
+	// nil, nil when the request does not carry any credentials that are
+	// supported by the Authenticator.
+	Authenticate(ctx context.Context, req *http.Request) (*Identity, error)
+}
+
+// TokenReviewAuthenticator authenticates requests carrying a ServiceAccount
+// token in the Authorization header by way of the Kubernetes TokenReview API.
+type TokenReviewAuthenticator struct {
+	Client ctrlclient.Client
+	// Audiences that the token must be issued for.
+	Audiences []string
+}
+
+// +kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+
+// Authenticate the request's bearer token.
+func (a *TokenReviewAuthenticator) Authenticate(ctx context.Context, req *http.Request) (*Identity, error) {
+	token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return nil, nil
+	}
+
+	tr := &authv1.TokenReview{
+		Spec: authv1.TokenReviewSpec{
+			Token:     token,
+			Audiences: a.Audiences,
+		},
+	}
+	if err := a.Client.Create(ctx, tr); err != nil {
+		return nil, fmt.Errorf("token review failed: %w", err)
+	}
+
+	if !tr.Status.Authenticated {
+		return nil, fmt.Errorf("token not authenticated: %s", tr.Status.Error)
+	}
+
+	id, err := parseServiceAccountUsername(tr.Status.User.Username)
+	if err != nil {
+		return nil, err
+	}
+	if v := tr.Status.User.Extra[extraPodName]; len(v) == 1 {
+		id.PodName = v[0]
+	}
+	if v := tr.Status.User.Extra[extraPodUID]; len(v) == 1 {
+		id.PodUID = types.UID(v[0])
+	}
+
+	return id, nil
+}
+
+// SPIFFEAuthenticator authenticates requests from agents presenting an X.509
+// SVID as their TLS client certificate. The SPIFFE ID must be of the form
+// spiffe://<trust-domain>/ns/<namespace>/sa/<service-account>. The certificate
+// chain is verified by the TLS server, see NewTLSConfig.
+type SPIFFEAuthenticator struct {
+	TrustDomain string
+}
+
+// Authenticate the request's verified client certificate.
+func (a *SPIFFEAuthenticator) Authenticate(_ context.Context, req *http.Request) (*Identity, error) {
+	if req.TLS == nil || len(req.TLS.VerifiedChains) == 0 || len(req.TLS.VerifiedChains[0]) == 0 {
+		return nil, nil
+	}
+
+	for _, u := range req.TLS.VerifiedChains[0][0].URIs {
+		if u.Scheme == "spiffe" {
+			return parseSPIFFEID(u, a.TrustDomain)
+		}
+	}
+
+	return nil, errors.New("client certificate does not contain a SPIFFE ID")
+}
+
+// Server serves the data held in its Store to authenticated agents. It is meant
+// to be added to the controller manager, and only runs on the leader, since
+// only the leader syncs data to the Store.
+type Server struct {
+	// Addr is the TCP address to listen on.
+	Addr string
+	// Store holds the data served.
+	Store *Store
+	// Authenticators are tried in order, the first one that returns an Identity
+	// wins.
+	Authenticators []Authenticator
+	// TLSConfig of the server, it is required.
+	TLSConfig *tls.Config
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable.
+func (s *Server) NeedLeaderElection() bool {
+	return true
+}
+
+// Start the server, blocking until ctx is done.
+func (s *Server) Start(ctx context.Context) error {
+	if s.TLSConfig == nil {
+		return errors.New("secretless server requires a TLS config")
+	}
+
+	logger := log.FromContext(ctx).WithName("secretless")
+	ln, err := tls.Listen("tcp", s.Addr, s.TLSConfig)
+	if err != nil {
+		return err
+	}
+
+	srv := &http.Server{
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext: func(net.Listener) context.Context {
+			return ctx
+		},
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			logger.Error(err, "Failed to shutdown the secretless server")
+		}
+	}()
+
+	logger.Info("Starting the secretless server", "addr", ln.Addr().String())
+	if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+// Handler returns the server's http.Handler.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(fmt.Sprintf("GET %s/{namespace}/{name}", secretsPathPrefix), s.handleGet)
+	return mux
+}
+
+func (s *Server) handleGet(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	key := types.NamespacedName{
+		Namespace: req.PathValue("namespace"),
+		Name:      req.PathValue("name"),
+	}
+	logger := log.FromContext(ctx).WithName("secretless").WithValues("key", key)
+
+	id, err := s.authenticate(ctx, req)
+	if err != nil {
+		logger.V(consts.LogLevelDebug).Info("Authentication failed", "err", err)
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	// agents may only ever fetch data from their own namespace.
+	if id.Namespace != key.Namespace {
+		logger.V(consts.LogLevelDebug).Info("Cross namespace request denied",
+			"namespace", id.Namespace, "serviceAccount", id.ServiceAccount)
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+
+	entry, ok := s.Store.Get(key)
+	if !ok {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+
+	if !entry.Allowed(id.ServiceAccount) {
+		logger.V(consts.LogLevelDebug).Info("Request denied",
+			"serviceAccount", id.ServiceAccount)
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+
+	if entry.OneShot {
+		// one-shot data is fetched once per Pod, so the Pod must be known.
+		if id.PodUID == "" {
+			logger.V(consts.LogLevelDebug).Info("One-shot request denied, the credentials are not bound to a Pod",
+				"serviceAccount", id.ServiceAccount)
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+		if !s.Store.Claim(key, id.PodUID) {
+			logger.Info("One-shot request denied, the data was already fetched",
+				"serviceAccount", id.ServiceAccount, "pod", id.PodName, "podUID", id.PodUID)
+			http.Error(w, http.StatusText(http.StatusGone), http.StatusGone)
+			return
+		}
+	}
+
+	etag := fmt.Sprintf("%q", entry.Version)
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("ETag", etag)
+	if !entry.OneShot && req.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	// every delivery of the data is audited.
+	logger.Info("Served the secret data",
+		"serviceAccount", id.ServiceAccount, "pod", id.PodName, "podUID", id.PodUID,
+		"version", entry.Version, "oneShot", entry.OneShot)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(&Response{
+		Data:    entry.Data,
+		Version: entry.Version,
+	}); err != nil {
+		logger.Error(err, "Failed to write the response")
+	}
+}
+
+func (s *Server) authenticate(ctx context.Context, req *http.Request) (*Identity, error) {
+	for _, a := range s.Authenticators {
+		id, err := a.Authenticate(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		if id != nil {
+			return id, nil
+		}
+	}
+
+	return nil, errors.New("no credentials provided")
+}
+
+// NewTLSConfig returns the TLS config for the Server. Client certificates are
+// only requested when clientCAFile is set, in which case they are verified
+// against the CA certificates it contains.
+func NewTLSConfig(getCertificate func(*tls.ClientHelloInfo) (*tls.Certificate, error), clientCAFile string) (*tls.Config, error) {
+	cfg := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: getCertificate,
+		ClientAuth:     tls.NoClientCert,
+	}
+
+	if clientCAFile != "" {
+		b, err := os.ReadFile(clientCAFile)
+		if err != nil {
+			return nil, err
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(b) {
+			return nil, fmt.Errorf("no CA certificates found in %s", clientCAFile)
+		}
+		cfg.ClientCAs = pool
+		// clients may still authenticate with a ServiceAccount token.
+		cfg.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	return cfg, nil
+}
+
+func parseServiceAccountUsername(username string) (*Identity, error) {
+	parts := strings.Split(strings.TrimPrefix(username, serviceAccountUsernamePrefix), ":")
+	if !strings.HasPrefix(username, serviceAccountUsernamePrefix) ||
+		len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("%q is not a ServiceAccount", username)
+	}
+
+	return &Identity{
+		Namespace:      parts[0],
+		ServiceAccount: parts[1],
+	}, nil
+}
+
+func parseSPIFFEID(u *url.URL, trustDomain string) (*Identity, error) {
+	if u.Host != trustDomain {
+		return nil, fmt.Errorf("SPIFFE ID %q is not in the trust domain %q", u, trustDomain)
+	}
+
+	parts := strings.Split(strings.TrimPrefix(u.Path, "/"), "/")
+	if len(parts) != 4 || parts[0] != "ns" || parts[2] != "sa" || parts[1] == "" || parts[3] == "" {
+		return nil, fmt.Errorf("SPIFFE ID %q does not identify a ServiceAccount", u)
+	}
+
+	return &Identity{
+		Namespace:      parts[1],
+		ServiceAccount: parts[3],
+	}, nil
+}
@@ -0,0 +1,340 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package secretless
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/hashicorp/vault-secrets-operator/internal/testutils"
+)
+
+type fakeAuthenticator struct {
+	id  *Identity
+	err error
+}
+
+func (a *fakeAuthenticator) Authenticate(_ context.Context, _ *http.Request) (*Identity, error) {
+	return a.id, a.err
+}
+
+func TestServer_Handler(t *testing.T) {
+	t.Parallel()
+
+	store := NewStore()
+	key := types.NamespacedName{Namespace: "baz", Name: "foo"}
+	data := map[string][]byte{"password": []byte("secret")}
+	require.NoError(t, store.Set(key, "uid-1", []string{"app"}, false, data))
+	entry, _ := store.Get(key)
+
+	tests := []struct {
+		name           string
+		authenticators []Authenticator
+		path           string
+		ifNoneMatch    string
+		wantStatus     int
+		wantResponse   *Response
+	}{
+		{
+			name: "ok",
+			authenticators: []Authenticator{
+				&fakeAuthenticator{},
+				&fakeAuthenticator{id: &Identity{Namespace: "baz", ServiceAccount: "app"}},
+			},
+			path:       "/v1/secrets/baz/foo",
+			wantStatus: http.StatusOK,
+			wantResponse: &Response{
+				Data:    data,
+				Version: entry.Version,
+			},
+		},
+		{
+			name: "not-modified",
+			authenticators: []Authenticator{
+				&fakeAuthenticator{id: &Identity{Namespace: "baz", ServiceAccount: "app"}},
+			},
+			path:        "/v1/secrets/baz/foo",
+			ifNoneMatch: `"` + entry.Version + `"`,
+			wantStatus:  http.StatusNotModified,
+		},
+		{
+			name:       "no-credentials",
+			path:       "/v1/secrets/baz/foo",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "authentication-error",
+			authenticators: []Authenticator{
+				&fakeAuthenticator{err: errors.New("invalid token")},
+				&fakeAuthenticator{id: &Identity{Namespace: "baz", ServiceAccount: "app"}},
+			},
+			path:       "/v1/secrets/baz/foo",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "cross-namespace",
+			authenticators: []Authenticator{
+				&fakeAuthenticator{id: &Identity{Namespace: "other", ServiceAccount: "app"}},
+			},
+			path:       "/v1/secrets/baz/foo",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "service-account-not-allowed",
+			authenticators: []Authenticator{
+				&fakeAuthenticator{id: &Identity{Namespace: "baz", ServiceAccount: "default"}},
+			},
+			path:       "/v1/secrets/baz/foo",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "not-found",
+			authenticators: []Authenticator{
+				&fakeAuthenticator{id: &Identity{Namespace: "baz", ServiceAccount: "app"}},
+			},
+			path:       "/v1/secrets/baz/bar",
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Server{
+				Store:          store,
+				Authenticators: tt.authenticators,
+			}
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			w := httptest.NewRecorder()
+			s.Handler().ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantResponse != nil {
+				var got Response
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&got))
+				assert.Equal(t, tt.wantResponse, &got)
+				assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+			}
+		})
+	}
+}
+
+func TestServer_Handler_oneShot(t *testing.T) {
+	t.Parallel()
+
+	store := NewStore()
+	key := types.NamespacedName{Namespace: "baz", Name: "foo"}
+	data := map[string][]byte{"password": []byte("secret")}
+	require.NoError(t, store.Set(key, "uid-1", []string{"job"}, true, data))
+
+	get := func(id *Identity) int {
+		t.Helper()
+		s := &Server{
+			Store:          store,
+			Authenticators: []Authenticator{&fakeAuthenticator{id: id}},
+		}
+		w := httptest.NewRecorder()
+		s.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/secrets/baz/foo", nil))
+		return w.Code
+	}
+
+	pod1 := &Identity{Namespace: "baz", ServiceAccount: "job", PodName: "job-1", PodUID: "pod-1"}
+	assert.Equal(t, http.StatusOK, get(pod1))
+	assert.Equal(t, http.StatusGone, get(pod1))
+	assert.Equal(t, http.StatusOK, get(&Identity{Namespace: "baz", ServiceAccount: "job", PodUID: "pod-2"}))
+	// the Pod must be known.
+	assert.Equal(t, http.StatusForbidden, get(&Identity{Namespace: "baz", ServiceAccount: "job"}))
+}
+
+func TestTokenReviewAuthenticator_Authenticate(t *testing.T) {
+	t.Parallel()
+
+	c := testutils.NewFakeClientBuilder().
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, client ctrlclient.WithWatch, obj ctrlclient.Object, opts ...ctrlclient.CreateOption) error {
+				tr, ok := obj.(*authv1.TokenReview)
+				if !ok {
+					return client.Create(ctx, obj, opts...)
+				}
+				switch tr.Spec.Token {
+				case "bound":
+					tr.Status.Authenticated = true
+					tr.Status.User = authv1.UserInfo{
+						Username: "system:serviceaccount:baz:job",
+						Extra: map[string]authv1.ExtraValue{
+							extraPodName: {"job-1"},
+							extraPodUID:  {"pod-1"},
+						},
+					}
+				case "unbound":
+					tr.Status.Authenticated = true
+					tr.Status.User.Username = "system:serviceaccount:baz:app"
+				}
+				return nil
+			},
+		}).Build()
+
+	tests := []struct {
+		name    string
+		token   string
+		want    *Identity
+		wantErr bool
+	}{
+		{
+			name:  "bound",
+			token: "bound",
+			want: &Identity{
+				Namespace:      "baz",
+				ServiceAccount: "job",
+				PodName:        "job-1",
+				PodUID:         "pod-1",
+			},
+		},
+		{
+			name:  "unbound",
+			token: "unbound",
+			want:  &Identity{Namespace: "baz", ServiceAccount: "app"},
+		},
+		{
+			name:    "invalid",
+			token:   "invalid",
+			wantErr: true,
+		},
+		{
+			name: "no-token",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			a := &TokenReviewAuthenticator{Client: c}
+			got, err := a.Authenticate(context.Background(), req)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSPIFFEAuthenticator_Authenticate(t *testing.T) {
+	t.Parallel()
+
+	newRequest := func(uris ...string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		cert := &x509.Certificate{}
+		for _, u := range uris {
+			parsed, err := url.Parse(u)
+			require.NoError(t, err)
+			cert.URIs = append(cert.URIs, parsed)
+		}
+		req.TLS = &tls.ConnectionState{
+			VerifiedChains: [][]*x509.Certificate{{cert}},
+		}
+		return req
+	}
+
+	tests := []struct {
+		name    string
+		req     *http.Request
+		want    *Identity
+		wantErr bool
+	}{
+		{
+			name: "no-tls",
+			req:  httptest.NewRequest(http.MethodGet, "/", nil),
+		},
+		{
+			name: "valid",
+			req:  newRequest("spiffe://cluster.local/ns/baz/sa/app"),
+			want: &Identity{Namespace: "baz", ServiceAccount: "app"},
+		},
+		{
+			name:    "other-trust-domain",
+			req:     newRequest("spiffe://example.com/ns/baz/sa/app"),
+			wantErr: true,
+		},
+		{
+			name:    "not-a-service-account",
+			req:     newRequest("spiffe://cluster.local/workload/app"),
+			wantErr: true,
+		},
+		{
+			name:    "no-spiffe-id",
+			req:     newRequest("https://cluster.local/ns/baz/sa/app"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &SPIFFEAuthenticator{TrustDomain: "cluster.local"}
+			got, err := a.Authenticate(context.Background(), tt.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_parseServiceAccountUsername(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		username string
+		want     *Identity
+		wantErr  bool
+	}{
+		{
+			username: "system:serviceaccount:baz:app",
+			want:     &Identity{Namespace: "baz", ServiceAccount: "app"},
+		},
+		{
+			username: "system:serviceaccount:baz",
+			wantErr:  true,
+		},
+		{
+			username: "system:serviceaccount::app",
+			wantErr:  true,
+		},
+		{
+			username: "jane",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.username, func(t *testing.T) {
+			got, err := parseServiceAccountUsername(tt.username)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
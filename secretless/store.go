@@ -0,0 +1,151 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package secretless
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"slices"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// DefaultStore holds the data that is delivered in secretless mode. It is nil
+// unless secretless mode has been enabled on the Operator.
+var DefaultStore *Store
+
+// Entry is the rendered secret data for a single syncable secret resource.
+type Entry struct {
+	// Data is the rendered secret data.
+	Data map[string][]byte
+	// ServiceAccounts that are allowed to fetch Data, they are always in the
+	// Entry's namespace.
+	ServiceAccounts []string
+	// OwnerUID is the UID of the syncable secret resource that owns the Entry.
+	OwnerUID types.UID
+	// Version changes every time Data changes. It is safe to use as an HTTP
+	// entity tag.
+	Version string
+	// OneShot entries can only be fetched once per Pod, see Store.Claim.
+	OneShot bool
+}
+
+// Allowed returns true if the ServiceAccount is allowed to fetch the Entry.
+func (e *Entry) Allowed(serviceAccount string) bool {
+	return slices.Contains(e.ServiceAccounts, serviceAccount)
+}
+
+// Store is an in-memory, concurrency safe, store of secret data. Nothing is
+// ever persisted, so the data must be re-synced after the Operator restarts.
+type Store struct {
+	mu      sync.RWMutex
+	entries map[types.NamespacedName]*Entry
+	// claims holds the UIDs of the Pods that have fetched each one-shot entry.
+	claims map[types.NamespacedName]map[types.UID]struct{}
+}
+
+// Set the data for key. Any other entries owned by ownerUID are removed, since
+// they refer to a previous destination of the same resource. The Pods that
+// have claimed a one-shot entry cannot claim it again after its data changes.
+func (s *Store) Set(key types.NamespacedName, ownerUID types.UID, serviceAccounts []string,
+	oneShot bool, data map[string][]byte,
+) error {
+	version, err := computeVersion(data)
+	if err != nil {
+		return err
+	}
+
+	entry := &Entry{
+		Data:            make(map[string][]byte, len(data)),
+		ServiceAccounts: slices.Clone(serviceAccounts),
+		OwnerUID:        ownerUID,
+		Version:         version,
+		OneShot:         oneShot,
+	}
+	for k, v := range data {
+		entry.Data[k] = slices.Clone(v)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for k, v := range s.entries {
+		if k != key && v.OwnerUID == ownerUID {
+			delete(s.entries, k)
+			delete(s.claims, k)
+		}
+	}
+	if prev, ok := s.entries[key]; ok && prev.OwnerUID != ownerUID {
+		delete(s.claims, key)
+	}
+	s.entries[key] = entry
+
+	return nil
+}
+
+// Get returns the Entry for key. The returned Entry must not be modified.
+func (s *Store) Get(key types.NamespacedName) (*Entry, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entry, ok := s.entries[key]
+	return entry, ok
+}
+
+// DeleteOwnedBy removes all entries owned by ownerUID, returning the number of
+// entries removed.
+func (s *Store) DeleteOwnedBy(ownerUID types.UID) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var count int
+	for k, v := range s.entries {
+		if v.OwnerUID == ownerUID {
+			delete(s.entries, k)
+			delete(s.claims, k)
+			count++
+		}
+	}
+	return count
+}
+
+// Claim records that the Pod podUID fetches the entry for key. It returns false
+// if the Pod has already claimed it.
+func (s *Store) Claim(key types.NamespacedName, podUID types.UID) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.claims[key][podUID]; ok {
+		return false
+	}
+	if s.claims[key] == nil {
+		s.claims[key] = make(map[types.UID]struct{})
+	}
+	s.claims[key][podUID] = struct{}{}
+	return true
+}
+
+// Len returns the number of entries in the Store.
+func (s *Store) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.entries)
+}
+
+// computeVersion returns the hex encoded SHA256 sum of the JSON encoded data.
+// Map keys are sorted by encoding/json, so the result is stable.
+func computeVersion(data map[string][]byte) (string, error) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// NewStore returns an empty Store.
+func NewStore() *Store {
+	return &Store{
+		entries: make(map[types.NamespacedName]*Entry),
+		claims:  make(map[types.NamespacedName]map[types.UID]struct{}),
+	}
+}
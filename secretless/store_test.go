@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package secretless
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestStore(t *testing.T) {
+	t.Parallel()
+
+	s := NewStore()
+	key := types.NamespacedName{Namespace: "baz", Name: "foo"}
+	data := map[string][]byte{"password": []byte("secret")}
+
+	require.NoError(t, s.Set(key, "uid-1", []string{"app"}, false, data))
+	entry, ok := s.Get(key)
+	require.True(t, ok)
+	assert.Equal(t, data, entry.Data)
+	assert.Equal(t, types.UID("uid-1"), entry.OwnerUID)
+	assert.True(t, entry.Allowed("app"))
+	assert.False(t, entry.Allowed("other"))
+	version := entry.Version
+	assert.NotEmpty(t, version)
+
+	// the stored data must not alias the caller's data
+	data["password"][0] = 'S'
+	entry, _ = s.Get(key)
+	assert.Equal(t, []byte("secret"), entry.Data["password"])
+
+	// same data, same version
+	require.NoError(t, s.Set(key, "uid-1", []string{"app"}, false, map[string][]byte{"password": []byte("secret")}))
+	entry, _ = s.Get(key)
+	assert.Equal(t, version, entry.Version)
+
+	// new data, new version
+	require.NoError(t, s.Set(key, "uid-1", []string{"app"}, false, map[string][]byte{"password": []byte("rotated")}))
+	entry, _ = s.Get(key)
+	assert.NotEqual(t, version, entry.Version)
+
+	// a new destination for the same owner replaces the previous one
+	otherOwner := types.NamespacedName{Namespace: "baz", Name: "qux"}
+	require.NoError(t, s.Set(otherOwner, "uid-2", []string{"app"}, false, nil))
+	newKey := types.NamespacedName{Namespace: "baz", Name: "bar"}
+	require.NoError(t, s.Set(newKey, "uid-1", []string{"app"}, false, nil))
+	_, ok = s.Get(key)
+	assert.False(t, ok)
+	_, ok = s.Get(newKey)
+	assert.True(t, ok)
+	assert.Equal(t, 2, s.Len())
+
+	assert.Equal(t, 1, s.DeleteOwnedBy("uid-1"))
+	assert.Equal(t, 0, s.DeleteOwnedBy("uid-1"))
+	assert.Equal(t, 1, s.Len())
+}
+
+func TestStore_Claim(t *testing.T) {
+	t.Parallel()
+
+	s := NewStore()
+	key := types.NamespacedName{Namespace: "baz", Name: "foo"}
+	require.NoError(t, s.Set(key, "uid-1", []string{"job"}, true, map[string][]byte{"password": []byte("secret")}))
+	entry, _ := s.Get(key)
+	assert.True(t, entry.OneShot)
+
+	assert.True(t, s.Claim(key, "pod-1"))
+	assert.False(t, s.Claim(key, "pod-1"))
+	assert.True(t, s.Claim(key, "pod-2"))
+
+	// the claims survive a rotation of the data.
+	require.NoError(t, s.Set(key, "uid-1", []string{"job"}, true, map[string][]byte{"password": []byte("rotated")}))
+	assert.False(t, s.Claim(key, "pod-1"))
+
+	// but not a new owner.
+	require.NoError(t, s.Set(key, "uid-2", []string{"job"}, true, nil))
+	assert.True(t, s.Claim(key, "pod-1"))
+
+	assert.Equal(t, 1, s.DeleteOwnedBy("uid-2"))
+	assert.True(t, s.Claim(key, "pod-2"))
+}
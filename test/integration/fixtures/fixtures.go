@@ -0,0 +1,217 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package fixtures provides the helpers used by the Operator's end-to-end
+// integration tests. They are exported so that downstream distributions and
+// forks can run the same conformance checks against their own changes. See
+// VaultBackend for running the Vault side of the tests against either a real
+// Vault server or an in-memory mock.
+//
+// The package is part of the Operator's module, it is not a separate Go
+// module, so importing it requires the Operator's module at the version under
+// test.
+package fixtures
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/k8s"
+	"github.com/gruntwork-io/terratest/modules/retry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/common"
+	"github.com/hashicorp/vault-secrets-operator/helpers"
+)
+
+// WaitForSecretData waits for the destination Secret name in namespace to hold
+// exactly expectedData, both as top-level fields and in its _raw data.
+func WaitForSecretData(t *testing.T, ctx context.Context, crdClient ctrlclient.Client, maxRetries int, delay time.Duration,
+	name, namespace string, expectedData map[string]interface{},
+) (*corev1.Secret, error) {
+	t.Helper()
+	var validSecret corev1.Secret
+	secObjKey := ctrlclient.ObjectKey{Namespace: namespace, Name: name}
+	_, err := retry.DoWithRetryE(t,
+		fmt.Sprintf("wait for k8s Secret data to be synced by the operator, objKey=%s", secObjKey),
+		maxRetries, delay, func() (string, error) {
+			var err error
+			var destSecret corev1.Secret
+			if err := crdClient.Get(ctx, secObjKey, &destSecret); err != nil {
+				return "", err
+			}
+
+			if _, ok := destSecret.Data[helpers.SecretDataKeyRaw]; !ok {
+				return "", fmt.Errorf("secret hasn't been synced yet, missing '%s' field", helpers.SecretDataKeyRaw)
+			}
+
+			var rawSecret map[string]interface{}
+			err = json.Unmarshal(destSecret.Data[helpers.SecretDataKeyRaw], &rawSecret)
+			require.NoError(t, err)
+			if _, ok := rawSecret["data"]; ok {
+				rawSecret = rawSecret["data"].(map[string]interface{})
+			}
+			for k, v := range expectedData {
+				// compare expected secret data to _raw in the k8s secret
+				if !reflect.DeepEqual(v, rawSecret[k]) {
+					err = errors.Join(err,
+						fmt.Errorf("expected data '%s:%s' missing from %s: %#v", k, v, helpers.SecretDataKeyRaw, rawSecret))
+				}
+				// compare expected secret k/v to the top level items in the k8s secret
+				if !reflect.DeepEqual(v, string(destSecret.Data[k])) {
+					err = errors.Join(err, fmt.Errorf("expected '%s:%s', actual '%s:%s'", k, v, k, string(destSecret.Data[k])))
+				}
+			}
+			if len(expectedData) != len(rawSecret) {
+				err = errors.Join(err,
+					fmt.Errorf("expected data length %d does not match %s length %d",
+						len(expectedData), helpers.SecretDataKeyRaw, len(rawSecret)))
+			}
+			// the k8s secret has an extra key because of the "_raw" item
+			if len(expectedData) != len(destSecret.Data)-1 {
+				err = errors.Join(err, fmt.Errorf("expected data length %d does not match k8s secret data length %d", len(expectedData), len(destSecret.Data)-1))
+			}
+
+			if err == nil {
+				validSecret = destSecret
+			}
+
+			return "", err
+		})
+
+	return &validSecret, err
+}
+
+// AssertDynamicSecret waits for the destination Secret of vdsObj to be synced,
+// and asserts the length of each of its fields against expected. The fields in
+// expectedPresentOnly are only asserted to be non-empty.
+func AssertDynamicSecret(t *testing.T, client ctrlclient.Client, maxRetries int,
+	delay time.Duration, vdsObj *secretsv1beta1.VaultDynamicSecret, expected map[string]int,
+	expectedPresentOnly ...string,
+) {
+	t.Helper()
+
+	namespace := vdsObj.GetNamespace()
+	name := vdsObj.Spec.Destination.Name
+	opts := &k8s.KubectlOptions{
+		Namespace: namespace,
+	}
+
+	presentOnly := make(map[string]int)
+	for _, v := range expectedPresentOnly {
+		presentOnly[v] = 1
+	}
+
+	retry.DoWithRetry(t,
+		"wait for dynamic secret sync", maxRetries, delay,
+		func() (string, error) {
+			sec, err := k8s.GetSecretE(t, opts, name)
+			if err != nil {
+				return "", err
+			}
+			if len(sec.Data) == 0 {
+				return "", fmt.Errorf("empty data for secret %s: %#v", sec, sec)
+			}
+
+			actualPresentOnly := make(map[string]int)
+			actual := make(map[string]int)
+			for f, b := range sec.Data {
+				if v, ok := presentOnly[f]; ok {
+					if len(b) > 0 {
+						actualPresentOnly[f] = v
+					}
+					continue
+				}
+				actual[f] = len(b)
+			}
+
+			assert.Equal(t, presentOnly, actualPresentOnly)
+			assert.Equal(t, expected, actual, "actual %#v, expected %#v", actual, expected)
+
+			AssertSyncableSecret(t, client, vdsObj, sec)
+
+			return "", nil
+		})
+}
+
+// AssertSyncableSecret asserts that sec has the owner labels and references of
+// the syncable secret obj, when obj created it, and none otherwise.
+func AssertSyncableSecret(t *testing.T, client ctrlclient.Client, obj ctrlclient.Object, sec *corev1.Secret) {
+	t.Helper()
+
+	meta, err := common.NewSyncableSecretMetaData(obj)
+	require.NoError(t, err)
+
+	if meta.Destination.Create {
+		expectedOwnerLabels, err := helpers.OwnerLabelsForObj(obj)
+		if assert.NoError(t, err) {
+			return
+		}
+
+		assert.Equal(t, expectedOwnerLabels, sec.Labels,
+			"expected owner labels not set on %s",
+			ctrlclient.ObjectKeyFromObject(sec))
+
+		gvk, err := apiutil.GVKForObject(obj, client.Scheme())
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		expectedAPIVersion, expectedKind := gvk.ToAPIVersionAndKind()
+		// check the OwnerReferences
+		expectedOwnerRefs := []v1.OwnerReference{
+			{
+				APIVersion: expectedAPIVersion,
+				Kind:       expectedKind,
+				Name:       obj.GetName(),
+				UID:        obj.GetUID(),
+			},
+		}
+		assert.Equal(t, expectedOwnerRefs, sec.OwnerReferences,
+			"expected owner references not set on %s",
+			ctrlclient.ObjectKeyFromObject(sec))
+	} else {
+		assert.Nil(t, sec.Labels,
+			"expected no labels set on %s",
+			ctrlclient.ObjectKeyFromObject(sec))
+		assert.Nil(t, sec.OwnerReferences,
+			"expected no OwnerReferences set on %s",
+			ctrlclient.ObjectKeyFromObject(sec))
+	}
+}
+
+// DeployOperatorWithKustomize deploys the Operator from kustomizeConfigPath,
+// and waits for its Pod to be ready. It fails t on error.
+func DeployOperatorWithKustomize(t *testing.T, k8sOpts *k8s.KubectlOptions, kustomizeConfigPath string) {
+	// deploy the Operator with Kustomize
+	t.Helper()
+	if err := DeployOperatorWithKustomizeE(t, k8sOpts, kustomizeConfigPath); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// DeployOperatorWithKustomizeE deploys the Operator from kustomizeConfigPath,
+// and waits for its Pod to be ready.
+func DeployOperatorWithKustomizeE(t *testing.T, k8sOpts *k8s.KubectlOptions, kustomizeConfigPath string) error {
+	// deploy the Operator with Kustomize
+	t.Helper()
+	k8s.KubectlApplyFromKustomize(t, k8sOpts, kustomizeConfigPath)
+	_, err := retry.DoWithRetryE(t, "waitOperatorPodReady", 30, time.Millisecond*500, func() (string, error) {
+		return "", k8s.RunKubectlE(t, k8sOpts,
+			"wait", "--for=condition=Ready",
+			"--timeout=2m", "pod", "-l", "control-plane=controller-manager")
+	},
+	)
+	return err
+}
@@ -0,0 +1,190 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package fixtures
+
+import (
+	"encoding/json"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/vault/api"
+)
+
+// VaultBackend is the Vault server that the tests talk to directly, e.g. to
+// seed the secrets that the Operator is expected to sync.
+type VaultBackend interface {
+	// Address of the Vault server. The Vault client's default is used when
+	// empty.
+	Address() string
+	// Token used to authenticate to the Vault server. The Vault client's
+	// default is used when empty.
+	Token() string
+}
+
+var _ VaultBackend = EnvVaultBackend{}
+
+// EnvVaultBackend is a real Vault server that is configured from the standard
+// VAULT_ADDR and VAULT_TOKEN environment variables.
+type EnvVaultBackend struct{}
+
+// Address implements VaultBackend.
+func (EnvVaultBackend) Address() string {
+	return os.Getenv(api.EnvVaultAddress)
+}
+
+// Token implements VaultBackend.
+func (EnvVaultBackend) Token() string {
+	return os.Getenv(api.EnvVaultToken)
+}
+
+// NewVaultClient returns a Vault client for backend. If namespace is set, all
+// requests are sent to that Vault namespace.
+func NewVaultClient(t *testing.T, backend VaultBackend, namespace string) *api.Client {
+	t.Helper()
+	client, err := api.NewClient(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if addr := backend.Address(); addr != "" {
+		if err := client.SetAddress(addr); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if token := backend.Token(); token != "" {
+		client.SetToken(token)
+	}
+	if namespace != "" {
+		client.SetNamespace(namespace)
+	}
+	return client
+}
+
+var _ VaultBackend = (*MockVaultBackend)(nil)
+
+// MockVaultBackend is an in-memory stand-in for a Vault server. It stores the
+// request data written to any path as is, and returns it as the response data
+// of subsequent reads, which is enough for seeding and reading back both KV v1
+// and KV v2 secrets. It also supports listing and deleting paths. Requests
+// that do not carry its token are denied.
+type MockVaultBackend struct {
+	server *httptest.Server
+	token  string
+	data   map[string]map[string]any
+	mu     sync.RWMutex
+}
+
+// NewMockVaultBackend starts a MockVaultBackend that is stopped when t
+// completes.
+func NewMockVaultBackend(t *testing.T) *MockVaultBackend {
+	t.Helper()
+	b := &MockVaultBackend{
+		token: "mock-root",
+		data:  make(map[string]map[string]any),
+	}
+	b.server = httptest.NewServer(http.HandlerFunc(b.serveHTTP))
+	t.Cleanup(b.server.Close)
+	return b
+}
+
+// Address implements VaultBackend.
+func (b *MockVaultBackend) Address() string {
+	return b.server.URL
+}
+
+// Token implements VaultBackend.
+func (b *MockVaultBackend) Token() string {
+	return b.token
+}
+
+// SetData stores data at path, as if it was written by a Vault client.
+func (b *MockVaultBackend) SetData(path string, data map[string]any) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.data[strings.Trim(path, "/")] = data
+}
+
+// Data returns the data stored at path, or nil if there is none.
+func (b *MockVaultBackend) Data(path string) map[string]any {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.data[strings.Trim(path, "/")]
+}
+
+func (b *MockVaultBackend) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("X-Vault-Token") != b.token {
+		writeVaultResponse(w, http.StatusForbidden, map[string]any{
+			"errors": []string{"permission denied"},
+		})
+		return
+	}
+
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/v1/"), "/")
+	switch {
+	case r.Method == "LIST" || (r.Method == http.MethodGet && r.URL.Query().Get("list") == "true"):
+		b.mu.RLock()
+		keys := map[string]bool{}
+		for k := range b.data {
+			if rest, ok := strings.CutPrefix(k, path+"/"); ok {
+				// only the direct children are listed, folders end with a slash.
+				if i := strings.Index(rest, "/"); i >= 0 {
+					rest = rest[:i+1]
+				}
+				keys[rest] = true
+			}
+		}
+		b.mu.RUnlock()
+		if len(keys) == 0 {
+			writeVaultResponse(w, http.StatusNotFound, map[string]any{"errors": []string{}})
+			return
+		}
+		writeVaultResponse(w, http.StatusOK, map[string]any{
+			"data": map[string]any{
+				"keys": slices.Sorted(maps.Keys(keys)),
+			},
+		})
+	case r.Method == http.MethodGet:
+		data := b.Data(path)
+		if data == nil {
+			writeVaultResponse(w, http.StatusNotFound, map[string]any{"errors": []string{}})
+			return
+		}
+		writeVaultResponse(w, http.StatusOK, map[string]any{
+			"data": data,
+		})
+	case r.Method == http.MethodPut || r.Method == http.MethodPost:
+		var data map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+			writeVaultResponse(w, http.StatusBadRequest, map[string]any{
+				"errors": []string{err.Error()},
+			})
+			return
+		}
+		b.SetData(path, data)
+		// some clients, e.g. the KV v2 one, expect the written data back.
+		writeVaultResponse(w, http.StatusOK, map[string]any{
+			"data": data,
+		})
+	case r.Method == http.MethodDelete:
+		b.mu.Lock()
+		delete(b.data, path)
+		b.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeVaultResponse(w, http.StatusMethodNotAllowed, map[string]any{
+			"errors": []string{"unsupported method " + r.Method},
+		})
+	}
+}
+
+func writeVaultResponse(w http.ResponseWriter, status int, body map[string]any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
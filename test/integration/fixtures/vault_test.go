@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package fixtures
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockVaultBackend(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	b := NewMockVaultBackend(t)
+	c := NewVaultClient(t, b, "")
+
+	// KV v2
+	_, err := c.KVv2("kvv2").Put(ctx, "app/db", map[string]any{
+		"password": "s3cr3t",
+	})
+	require.NoError(t, err)
+	secret, err := c.KVv2("kvv2").Get(ctx, "app/db")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"password": "s3cr3t"}, secret.Data)
+
+	// KV v1
+	b.SetData("kv/app/api", map[string]any{
+		"key": "abc",
+	})
+	resp, err := c.Logical().ReadWithContext(ctx, "kv/app/api")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"key": "abc"}, resp.Data)
+
+	resp, err = c.Logical().ListWithContext(ctx, "kvv2/data")
+	require.NoError(t, err)
+	assert.Equal(t, []any{"app/"}, resp.Data["keys"])
+
+	_, err = c.Logical().DeleteWithContext(ctx, "kv/app/api")
+	require.NoError(t, err)
+	assert.Nil(t, b.Data("kv/app/api"))
+	resp, err = c.Logical().ReadWithContext(ctx, "kv/app/api")
+	require.NoError(t, err)
+	assert.Nil(t, resp)
+
+	c.SetToken("invalid")
+	_, err = c.Logical().ReadWithContext(ctx, "kvv2/data/app/db")
+	var respErr *api.ResponseError
+	if assert.ErrorAs(t, err, &respErr) {
+		assert.Equal(t, 403, respErr.StatusCode)
+	}
+}
+
+func TestEnvVaultBackend(t *testing.T) {
+	t.Setenv(api.EnvVaultAddress, "http://vault.example.com:8200")
+	t.Setenv(api.EnvVaultToken, "root")
+
+	c := NewVaultClient(t, EnvVaultBackend{}, "ns1")
+	assert.Equal(t, "http://vault.example.com:8200", c.Address())
+	assert.Equal(t, "root", c.Token())
+	assert.Equal(t, "ns1", c.Namespace())
+}
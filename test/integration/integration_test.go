@@ -48,7 +48,6 @@ import (
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 
 	"github.com/hashicorp/vault-secrets-operator/common"
 	"github.com/hashicorp/vault-secrets-operator/helpers"
@@ -56,6 +55,7 @@ import (
 	"github.com/hashicorp/vault-secrets-operator/credentials/vault/consts"
 
 	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/test/integration/fixtures"
 )
 
 var (
@@ -82,6 +82,8 @@ var (
 	scheme = ctrlruntime.NewScheme()
 	// set in TestMain
 	restConfig = rest.Config{}
+	// the Vault server that the tests talk to directly.
+	vaultBackend fixtures.VaultBackend = fixtures.EnvVaultBackend{}
 )
 
 func init() {
@@ -261,8 +263,8 @@ func TestMain(m *testing.M) {
 
 	var result int
 	if !testWithHelm {
-		if err := deployOperatorWithKustomizeE(t, k8sOpts, kustomizeConfigPath); err != nil {
-			log.Printf("Failed to deployOperatorWithKustomizeE(t, k8sOpts, kustomizeConfigPath), err=%s", err)
+		if err := fixtures.DeployOperatorWithKustomizeE(t, k8sOpts, kustomizeConfigPath); err != nil {
+			log.Printf("Failed to DeployOperatorWithKustomizeE(t, k8sOpts, kustomizeConfigPath), err=%s", err)
 			result = 1
 		}
 	} else {
@@ -312,14 +314,7 @@ func setCommonTFOptions(t *testing.T, opts *terraform.Options) *terraform.Option
 
 func getVaultClient(t *testing.T, namespace string) *api.Client {
 	t.Helper()
-	client, err := api.NewClient(nil)
-	if err != nil {
-		t.Fatal(err)
-	}
-	if namespace != "" {
-		client.SetNamespace(namespace)
-	}
-	return client
+	return fixtures.NewVaultClient(t, vaultBackend, namespace)
 }
 
 func getCRDClient(t *testing.T) ctrlclient.Client {
@@ -332,62 +327,6 @@ func getCRDClient(t *testing.T) ctrlclient.Client {
 	return k8sClient
 }
 
-func waitForSecretData(t *testing.T, ctx context.Context, crdClient ctrlclient.Client, maxRetries int, delay time.Duration,
-	name, namespace string, expectedData map[string]interface{},
-) (*corev1.Secret, error) {
-	t.Helper()
-	var validSecret corev1.Secret
-	secObjKey := ctrlclient.ObjectKey{Namespace: namespace, Name: name}
-	_, err := retry.DoWithRetryE(t,
-		fmt.Sprintf("wait for k8s Secret data to be synced by the operator, objKey=%s", secObjKey),
-		maxRetries, delay, func() (string, error) {
-			var err error
-			var destSecret corev1.Secret
-			if err := crdClient.Get(ctx, secObjKey, &destSecret); err != nil {
-				return "", err
-			}
-
-			if _, ok := destSecret.Data[helpers.SecretDataKeyRaw]; !ok {
-				return "", fmt.Errorf("secret hasn't been synced yet, missing '%s' field", helpers.SecretDataKeyRaw)
-			}
-
-			var rawSecret map[string]interface{}
-			err = json.Unmarshal(destSecret.Data[helpers.SecretDataKeyRaw], &rawSecret)
-			require.NoError(t, err)
-			if _, ok := rawSecret["data"]; ok {
-				rawSecret = rawSecret["data"].(map[string]interface{})
-			}
-			for k, v := range expectedData {
-				// compare expected secret data to _raw in the k8s secret
-				if !reflect.DeepEqual(v, rawSecret[k]) {
-					err = errors.Join(err,
-						fmt.Errorf("expected data '%s:%s' missing from %s: %#v", k, v, helpers.SecretDataKeyRaw, rawSecret))
-				}
-				// compare expected secret k/v to the top level items in the k8s secret
-				if !reflect.DeepEqual(v, string(destSecret.Data[k])) {
-					err = errors.Join(err, fmt.Errorf("expected '%s:%s', actual '%s:%s'", k, v, k, string(destSecret.Data[k])))
-				}
-			}
-			if len(expectedData) != len(rawSecret) {
-				err = errors.Join(err,
-					fmt.Errorf("expected data length %d does not match %s length %d",
-						len(expectedData), helpers.SecretDataKeyRaw, len(rawSecret)))
-			}
-			// the k8s secret has an extra key because of the "_raw" item
-			if len(expectedData) != len(destSecret.Data)-1 {
-				err = errors.Join(err, fmt.Errorf("expected data length %d does not match k8s secret data length %d", len(expectedData), len(destSecret.Data)-1))
-			}
-
-			if err == nil {
-				validSecret = destSecret
-			}
-
-			return "", err
-		})
-
-	return &validSecret, err
-}
-
 func waitForPKIData(t *testing.T, maxRetries int, delay time.Duration, vpsObj *secretsv1beta1.VaultPKISecret, previousSerialNumber string) (string, *corev1.Secret, error) {
 	t.Helper()
 	destSecret := &corev1.Secret{}
@@ -480,120 +419,6 @@ type authMethodsK8sOutputs struct {
 	VaultPolicy   string `json:"vault_policy"`
 }
 
-func assertDynamicSecret(t *testing.T, client ctrlclient.Client, maxRetries int,
-	delay time.Duration, vdsObj *secretsv1beta1.VaultDynamicSecret, expected map[string]int,
-	expectedPresentOnly ...string,
-) {
-	t.Helper()
-
-	namespace := vdsObj.GetNamespace()
-	name := vdsObj.Spec.Destination.Name
-	opts := &k8s.KubectlOptions{
-		Namespace: namespace,
-	}
-
-	presentOnly := make(map[string]int)
-	for _, v := range expectedPresentOnly {
-		presentOnly[v] = 1
-	}
-
-	retry.DoWithRetry(t,
-		"wait for dynamic secret sync", maxRetries, delay,
-		func() (string, error) {
-			sec, err := k8s.GetSecretE(t, opts, name)
-			if err != nil {
-				return "", err
-			}
-			if len(sec.Data) == 0 {
-				return "", fmt.Errorf("empty data for secret %s: %#v", sec, sec)
-			}
-
-			actualPresentOnly := make(map[string]int)
-			actual := make(map[string]int)
-			for f, b := range sec.Data {
-				if v, ok := presentOnly[f]; ok {
-					if len(b) > 0 {
-						actualPresentOnly[f] = v
-					}
-					continue
-				}
-				actual[f] = len(b)
-			}
-
-			assert.Equal(t, presentOnly, actualPresentOnly)
-			assert.Equal(t, expected, actual, "actual %#v, expected %#v", actual, expected)
-
-			assertSyncableSecret(t, client, vdsObj, sec)
-
-			return "", nil
-		})
-}
-
-func assertSyncableSecret(t *testing.T, client ctrlclient.Client, obj ctrlclient.Object, sec *corev1.Secret) {
-	t.Helper()
-
-	meta, err := common.NewSyncableSecretMetaData(obj)
-	require.NoError(t, err)
-
-	if meta.Destination.Create {
-		expectedOwnerLabels, err := helpers.OwnerLabelsForObj(obj)
-		if assert.NoError(t, err) {
-			return
-		}
-
-		assert.Equal(t, expectedOwnerLabels, sec.Labels,
-			"expected owner labels not set on %s",
-			ctrlclient.ObjectKeyFromObject(sec))
-
-		gvk, err := apiutil.GVKForObject(obj, client.Scheme())
-		if !assert.NoError(t, err) {
-			return
-		}
-
-		expectedAPIVersion, expectedKind := gvk.ToAPIVersionAndKind()
-		// check the OwnerReferences
-		expectedOwnerRefs := []v1.OwnerReference{
-			{
-				APIVersion: expectedAPIVersion,
-				Kind:       expectedKind,
-				Name:       obj.GetName(),
-				UID:        obj.GetUID(),
-			},
-		}
-		assert.Equal(t, expectedOwnerRefs, sec.OwnerReferences,
-			"expected owner references not set on %s",
-			ctrlclient.ObjectKeyFromObject(sec))
-	} else {
-		assert.Nil(t, sec.Labels,
-			"expected no labels set on %s",
-			ctrlclient.ObjectKeyFromObject(sec))
-		assert.Nil(t, sec.OwnerReferences,
-			"expected no OwnerReferences set on %s",
-			ctrlclient.ObjectKeyFromObject(sec))
-	}
-}
-
-func deployOperatorWithKustomize(t *testing.T, k8sOpts *k8s.KubectlOptions, kustomizeConfigPath string) {
-	// deploy the Operator with Kustomize
-	t.Helper()
-	if err := deployOperatorWithKustomizeE(t, k8sOpts, kustomizeConfigPath); err != nil {
-		t.Fatal(err)
-	}
-}
-
-func deployOperatorWithKustomizeE(t *testing.T, k8sOpts *k8s.KubectlOptions, kustomizeConfigPath string) error {
-	// deploy the Operator with Kustomize
-	t.Helper()
-	k8s.KubectlApplyFromKustomize(t, k8sOpts, kustomizeConfigPath)
-	_, err := retry.DoWithRetryE(t, "waitOperatorPodReady", 30, time.Millisecond*500, func() (string, error) {
-		return "", k8s.RunKubectlE(t, k8sOpts,
-			"wait", "--for=condition=Ready",
-			"--timeout=2m", "pod", "-l", "control-plane=controller-manager")
-	},
-	)
-	return err
-}
-
 // exportKindLogsT exports the kind logs for t if exportKindLogsRoot is not empty.
 // All logs are stored under exportKindLogsRoot. Every test should call this before
 // undeploying the Operator from Kubernetes.
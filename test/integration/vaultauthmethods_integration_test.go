@@ -28,6 +28,7 @@ import (
 	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
 	"github.com/hashicorp/vault-secrets-operator/consts"
 	"github.com/hashicorp/vault-secrets-operator/helpers"
+	"github.com/hashicorp/vault-secrets-operator/test/integration/fixtures"
 
 	"github.com/hashicorp/vault-secrets-operator/credentials/vault"
 )
@@ -477,12 +478,12 @@ func TestVaultAuthMethods(t *testing.T) {
 	}
 
 	assertSync := func(t *testing.T, obj *secretsv1beta1.VaultStaticSecret) {
-		secret, err := waitForSecretData(t, ctx, crdClient, 30, 1*time.Second, obj.Spec.Destination.Name,
+		secret, err := fixtures.WaitForSecretData(t, ctx, crdClient, 30, 1*time.Second, obj.Spec.Destination.Name,
 			obj.ObjectMeta.Namespace, expectedData)
 		if !assert.NoError(t, err) {
 			return
 		}
-		assertSyncableSecret(t, crdClient, obj, secret)
+		fixtures.AssertSyncableSecret(t, crdClient, obj, secret)
 	}
 
 	logEvents := func(t *testing.T, vss *secretsv1beta1.VaultStaticSecret) {
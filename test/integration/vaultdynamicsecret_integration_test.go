@@ -32,6 +32,7 @@ import (
 	"github.com/hashicorp/vault-secrets-operator/common"
 	"github.com/hashicorp/vault-secrets-operator/consts"
 	"github.com/hashicorp/vault-secrets-operator/helpers"
+	"github.com/hashicorp/vault-secrets-operator/test/integration/fixtures"
 )
 
 type dynamicK8SOutputs struct {
@@ -533,7 +534,7 @@ func TestVaultDynamicSecret(t *testing.T) {
 					expectedPresentOnly := expectedPresentOnly
 					t.Parallel()
 
-					assertDynamicSecret(t, nil, tfOptions.MaxRetries, tfOptions.TimeBetweenRetries, obj,
+					fixtures.AssertDynamicSecret(t, nil, tfOptions.MaxRetries, tfOptions.TimeBetweenRetries, obj,
 						expected, expectedPresentOnly...)
 					if t.Failed() {
 						return
@@ -21,6 +21,7 @@ import (
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/test/integration/fixtures"
 )
 
 type vpsK8SOutputs struct {
@@ -292,7 +293,7 @@ func TestVaultPKISecret(t *testing.T) {
 					require.NoError(t, err)
 					assert.NotEmpty(t, serialNumber)
 
-					assertSyncableSecret(t, crdClient, vpsObj, secret)
+					fixtures.AssertSyncableSecret(t, crdClient, vpsObj, secret)
 
 					if vpsObj.Spec.Destination.Create {
 						expectedType := vpsObj.Spec.Destination.Type
@@ -310,7 +311,7 @@ func TestVaultPKISecret(t *testing.T) {
 					require.NoError(t, err)
 					assert.NotEmpty(t, newSerialNumber)
 
-					assertSyncableSecret(t, crdClient, vpsObj, secret)
+					fixtures.AssertSyncableSecret(t, crdClient, vpsObj, secret)
 
 					if len(vpsObj.Spec.RolloutRestartTargets) > 0 {
 						awaitRolloutRestarts(t, ctx, crdClient, vpsObj, vpsObj.Spec.RolloutRestartTargets)
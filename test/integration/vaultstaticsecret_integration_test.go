@@ -28,6 +28,7 @@ import (
 	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
 	"github.com/hashicorp/vault-secrets-operator/consts"
 	"github.com/hashicorp/vault-secrets-operator/helpers"
+	"github.com/hashicorp/vault-secrets-operator/test/integration/fixtures"
 
 	"github.com/hashicorp/vault-secrets-operator/vault"
 )
@@ -409,10 +410,10 @@ func TestVaultStaticSecret(t *testing.T) {
 			}
 		}
 
-		secret, err := waitForSecretData(t, ctx, crdClient, 30, time.Millisecond*500, obj.Spec.Destination.Name,
+		secret, err := fixtures.WaitForSecretData(t, ctx, crdClient, 30, time.Millisecond*500, obj.Spec.Destination.Name,
 			obj.ObjectMeta.Namespace, data)
 		if assert.NoError(t, err) {
-			assertSyncableSecret(t, crdClient, obj, secret)
+			fixtures.AssertSyncableSecret(t, crdClient, obj, secret)
 			if obj.Spec.HMACSecretData != nil && *obj.Spec.HMACSecretData {
 				assertHMAC(t, ctx, crdClient, obj, expectInitial)
 			} else {
@@ -424,7 +425,7 @@ func TestVaultStaticSecret(t *testing.T) {
 				if assert.NoError(t, err) {
 					// ensure that a Secret deleted out-of-band is properly restored
 					if assert.NoError(t, crdClient.Delete(ctx, sec)) {
-						_, err := waitForSecretData(t, ctx, crdClient, 30, time.Millisecond*500, obj.Spec.Destination.Name,
+						_, err := fixtures.WaitForSecretData(t, ctx, crdClient, 30, time.Millisecond*500, obj.Spec.Destination.Name,
 							obj.ObjectMeta.Namespace, data)
 						assert.NoError(t, err)
 					}
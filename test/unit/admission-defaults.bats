@@ -0,0 +1,117 @@
+#!/usr/bin/env bats
+
+load _helpers
+
+@test "admissionDefaults: disabled by default" {
+  cd `chart_dir`
+  local actual=$(helm template \
+      . | tee /dev/stderr |
+      yq 'select(.kind == "MutatingWebhookConfiguration") | documentIndex' | tee /dev/stderr)
+  [ "${actual}" = "" ]
+
+  actual=$(helm template \
+      -s templates/deployment.yaml  \
+      . | tee /dev/stderr |
+      yq 'select(.kind == "Deployment" and .metadata.labels."control-plane" == "controller-manager") | .spec.template.spec.containers[] | select(.name == "manager") | .args | contains(["--admission-defaults-config=/var/run/admission-defaults/config.yaml"])' | tee /dev/stderr)
+  [ "${actual}" = "false" ]
+}
+
+@test "admissionDefaults: declares the config" {
+  cd `chart_dir`
+  local object=$(helm template \
+      -s templates/admission-defaults.yaml  \
+      --set 'controller.manager.admissionDefaults.enabled=true' \
+      --set 'controller.manager.admissionDefaults.config.refreshAfter.kv-v2=1h' \
+      --set 'controller.manager.admissionDefaults.config.excludeRaw=true' \
+      . | tee /dev/stderr |
+      yq 'select(.kind == "ConfigMap") | .data."config.yaml"' | tee /dev/stderr)
+
+  local actual=$(echo "$object" | yq '.refreshAfter."kv-v2"' | tee /dev/stderr)
+  [ "${actual}" = "1h" ]
+  actual=$(echo "$object" | yq '.excludeRaw' | tee /dev/stderr)
+  [ "${actual}" = "true" ]
+}
+
+@test "admissionDefaults: declares the webhooks" {
+  cd `chart_dir`
+  local object=$(helm template \
+      -s templates/admission-defaults.yaml  \
+      --set 'controller.manager.admissionDefaults.enabled=true' \
+      --set 'controller.manager.admissionDefaults.failurePolicy=Fail' \
+      . | tee /dev/stderr |
+      yq 'select(.kind == "MutatingWebhookConfiguration") | .webhooks' | tee /dev/stderr)
+
+  local actual=$(echo "$object" | yq 'length' | tee /dev/stderr)
+  [ "${actual}" = "4" ]
+  actual=$(echo "$object" | yq '.[0].clientConfig.service.path' | tee /dev/stderr)
+  [ "${actual}" = "/mutate-secrets-hashicorp-com-v1beta1-vaultstaticsecret" ]
+  actual=$(echo "$object" | yq '.[0].clientConfig.service.name' | tee /dev/stderr)
+  [ "${actual}" = "release-name-vault-secrets-operator-webhook-service" ]
+  actual=$(echo "$object" | yq '.[0].failurePolicy' | tee /dev/stderr)
+  [ "${actual}" = "Fail" ]
+  actual=$(echo "$object" | yq '.[3].rules[0].resources[0]' | tee /dev/stderr)
+  [ "${actual}" = "hcpvaultsecretsapps" ]
+}
+
+@test "admissionDefaults: mounts the config and the serving certificate" {
+  cd `chart_dir`
+  local object=$(helm template \
+      -s templates/deployment.yaml  \
+      --set 'controller.manager.admissionDefaults.enabled=true' \
+      . | tee /dev/stderr |
+      yq 'select(.kind == "Deployment" and .metadata.labels."control-plane" == "controller-manager") | .spec.template.spec' | tee /dev/stderr)
+
+  local actual=$(echo "$object" | yq '.containers[] | select(.name == "manager") | .args | contains(["--admission-defaults-config=/var/run/admission-defaults/config.yaml"])' | tee /dev/stderr)
+  [ "${actual}" = "true" ]
+  actual=$(echo "$object" | yq '.containers[] | select(.name == "manager") | .volumeMounts[] | select(.name == "webhook-certs") | .mountPath' | tee /dev/stderr)
+  [ "${actual}" = "/tmp/k8s-webhook-server/serving-certs" ]
+  actual=$(echo "$object" | yq '.volumes[] | select(.name == "webhook-certs") | .secret.secretName' | tee /dev/stderr)
+  [ "${actual}" = "release-name-vault-secrets-operator-webhook-cert" ]
+  actual=$(echo "$object" | yq '.volumes[] | select(.name == "admission-defaults") | .configMap.name' | tee /dev/stderr)
+  [ "${actual}" = "release-name-vault-secrets-operator-admission-defaults" ]
+}
+
+@test "admissionDefaults: the webhook CA signs the serving certificate" {
+  cd `chart_dir`
+  local object=$(helm template \
+      -s templates/admission-defaults.yaml  \
+      --set 'controller.manager.admissionDefaults.enabled=true' \
+      . | tee /dev/stderr)
+
+  local ca=$(echo "$object" | yq 'select(.kind == "Secret") | .data."ca.crt"' | tee /dev/stderr)
+  [ "${ca}" != "" ]
+  local actual=$(echo "$object" | yq 'select(.kind == "MutatingWebhookConfiguration") | .webhooks[0].clientConfig.caBundle' | tee /dev/stderr)
+  [ "${actual}" = "${ca}" ]
+}
+
+@test "admissionDefaults: declares the VaultAuth params webhook" {
+  cd `chart_dir`
+  local object=$(helm template \
+      -s templates/admission-defaults.yaml  \
+      --set 'controller.manager.admissionDefaults.enabled=true' \
+      . | tee /dev/stderr |
+      yq 'select(.kind == "ValidatingWebhookConfiguration") | .webhooks[0]' | tee /dev/stderr)
+
+  local actual=$(echo "$object" | yq '.clientConfig.service.path' | tee /dev/stderr)
+  [ "${actual}" = "/validate-secrets-hashicorp-com-v1beta1-vaultauth" ]
+  actual=$(echo "$object" | yq '.rules[0].resources[0]' | tee /dev/stderr)
+  [ "${actual}" = "vaultauths" ]
+
+  actual=$(helm template \
+      -s templates/deployment.yaml  \
+      --set 'controller.manager.admissionDefaults.enabled=true' \
+      . | tee /dev/stderr |
+      yq 'select(.kind == "Deployment" and .metadata.labels."control-plane" == "controller-manager") | .spec.template.spec.containers[] | select(.name == "manager") | .args | contains(["--vault-auth-params-webhook"])' | tee /dev/stderr)
+  [ "${actual}" = "true" ]
+}
+
+@test "admissionDefaults: the VaultAuth params webhook can be disabled" {
+  cd `chart_dir`
+  local actual=$(helm template \
+      -s templates/admission-defaults.yaml  \
+      --set 'controller.manager.admissionDefaults.enabled=true' \
+      --set 'controller.manager.admissionDefaults.validateVaultAuthParams=false' \
+      . | tee /dev/stderr |
+      yq 'select(.kind == "ValidatingWebhookConfiguration") | documentIndex' | tee /dev/stderr)
+  [ "${actual}" = "" ]
+}
@@ -404,6 +404,137 @@ load _helpers
     [ "${actual}" = "my-project" ]
 }
 
+@test "defaultAuthMethod/CR: settings can be modified for azure auth method - minimum" {
+    cd `chart_dir`
+    local object=$(helm template \
+        -s templates/default-vault-auth-method.yaml  \
+        --set 'defaultAuthMethod.enabled=true' \
+        --set 'defaultAuthMethod.namespace=tenant-2' \
+        --set 'defaultAuthMethod.method=azure' \
+        --set 'defaultAuthMethod.mount=foo' \
+        --set 'defaultAuthMethod.azure.role=role-1' \
+        . | tee /dev/stderr)
+
+    local actual=$(echo "$object" | yq '.spec.method' | tee /dev/stderr)
+    [ "${actual}" = "azure" ]
+    actual=$(echo "$object" | yq '.spec.mount' | tee /dev/stderr)
+    [ "${actual}" = "foo" ]
+    actual=$(echo "$object" | yq '.spec.azure.role' | tee /dev/stderr)
+    [ "${actual}" = "role-1" ]
+
+    # the rest should not be set
+    actual=$(echo "$object" | yq '.spec.azure.workloadIdentityServiceAccount' | tee /dev/stderr)
+    [ "${actual}" = null ]
+    actual=$(echo "$object" | yq '.spec.azure.resource' | tee /dev/stderr)
+    [ "${actual}" = null ]
+    actual=$(echo "$object" | yq '.spec.azure.tenantID' | tee /dev/stderr)
+    [ "${actual}" = null ]
+}
+
+@test "defaultAuthMethod/CR: settings can be modified for azure auth method - everything" {
+    cd `chart_dir`
+    local object=$(helm template \
+        -s templates/default-vault-auth-method.yaml  \
+        --set 'defaultAuthMethod.enabled=true' \
+        --set 'defaultAuthMethod.method=azure' \
+        --set 'defaultAuthMethod.azure.role=role-1' \
+        --set 'defaultAuthMethod.azure.workloadIdentityServiceAccount=my-identity-sa' \
+        --set 'defaultAuthMethod.azure.resource=https://vault.example.com/' \
+        --set 'defaultAuthMethod.azure.tenantID=my-tenant' \
+        --set 'defaultAuthMethod.azure.clientID=my-client' \
+        --set 'defaultAuthMethod.azure.subscriptionID=my-subscription' \
+        --set 'defaultAuthMethod.azure.resourceGroupName=my-rg' \
+        --set 'defaultAuthMethod.azure.vmssName=my-vmss' \
+        . | tee /dev/stderr)
+
+    local actual=$(echo "$object" | yq '.spec.azure.workloadIdentityServiceAccount' | tee /dev/stderr)
+    [ "${actual}" = "my-identity-sa" ]
+    actual=$(echo "$object" | yq '.spec.azure.resource' | tee /dev/stderr)
+    [ "${actual}" = "https://vault.example.com/" ]
+    actual=$(echo "$object" | yq '.spec.azure.tenantID' | tee /dev/stderr)
+    [ "${actual}" = "my-tenant" ]
+    actual=$(echo "$object" | yq '.spec.azure.clientID' | tee /dev/stderr)
+    [ "${actual}" = "my-client" ]
+    actual=$(echo "$object" | yq '.spec.azure.subscriptionID' | tee /dev/stderr)
+    [ "${actual}" = "my-subscription" ]
+    actual=$(echo "$object" | yq '.spec.azure.resourceGroupName' | tee /dev/stderr)
+    [ "${actual}" = "my-rg" ]
+    actual=$(echo "$object" | yq '.spec.azure.vmssName' | tee /dev/stderr)
+    [ "${actual}" = "my-vmss" ]
+}
+
+@test "defaultAuthMethod/CR: settings can be modified for cert auth method - minimum" {
+    cd `chart_dir`
+    local object=$(helm template \
+        -s templates/default-vault-auth-method.yaml  \
+        --set 'defaultAuthMethod.enabled=true' \
+        --set 'defaultAuthMethod.method=cert' \
+        --set 'defaultAuthMethod.mount=cert' \
+        --set 'defaultAuthMethod.cert.secretRef=client-cert' \
+        . | tee /dev/stderr)
+
+    local actual=$(echo "$object" | yq '.spec.method' | tee /dev/stderr)
+    [ "${actual}" = "cert" ]
+    actual=$(echo "$object" | yq '.spec.cert.secretRef' | tee /dev/stderr)
+    [ "${actual}" = "client-cert" ]
+
+    # the rest should not be set
+    actual=$(echo "$object" | yq '.spec.cert.name' | tee /dev/stderr)
+    [ "${actual}" = null ]
+}
+
+@test "defaultAuthMethod/CR: settings can be modified for cert auth method - everything" {
+    cd `chart_dir`
+    local object=$(helm template \
+        -s templates/default-vault-auth-method.yaml  \
+        --set 'defaultAuthMethod.enabled=true' \
+        --set 'defaultAuthMethod.method=cert' \
+        --set 'defaultAuthMethod.cert.secretRef=client-cert' \
+        --set 'defaultAuthMethod.cert.name=web' \
+        . | tee /dev/stderr)
+
+    local actual=$(echo "$object" | yq '.spec.cert.secretRef' | tee /dev/stderr)
+    [ "${actual}" = "client-cert" ]
+    actual=$(echo "$object" | yq '.spec.cert.name' | tee /dev/stderr)
+    [ "${actual}" = "web" ]
+}
+
+@test "defaultAuthMethod/CR: settings can be modified for token auth method" {
+    cd `chart_dir`
+    local object=$(helm template \
+        -s templates/default-vault-auth-method.yaml  \
+        --set 'defaultAuthMethod.enabled=true' \
+        --set 'defaultAuthMethod.method=token' \
+        --set 'defaultAuthMethod.token.secretRef=vault-token' \
+        . | tee /dev/stderr)
+
+    local actual=$(echo "$object" | yq '.spec.method' | tee /dev/stderr)
+    [ "${actual}" = "token" ]
+    actual=$(echo "$object" | yq '.spec.token.secretRef' | tee /dev/stderr)
+    [ "${actual}" = "vault-token" ]
+}
+
+@test "defaultAuthMethod/CR: settings can be modified for username and password auth methods" {
+    cd `chart_dir`
+    for method in ldap userpass okta; do
+        local object=$(helm template \
+            -s templates/default-vault-auth-method.yaml  \
+            --set 'defaultAuthMethod.enabled=true' \
+            --set "defaultAuthMethod.method=${method}" \
+            --set "defaultAuthMethod.mount=${method}" \
+            --set "defaultAuthMethod.${method}.secretRef=creds" \
+            --set "defaultAuthMethod.${method}.username=alice" \
+            . | tee /dev/stderr)
+
+        local actual=$(echo "$object" | yq '.spec.method' | tee /dev/stderr)
+        [ "${actual}" = "${method}" ]
+        actual=$(echo "$object" | yq ".spec.${method}.secretRef" | tee /dev/stderr)
+        [ "${actual}" = "creds" ]
+        actual=$(echo "$object" | yq ".spec.${method}.username" | tee /dev/stderr)
+        [ "${actual}" = "alice" ]
+    done
+}
+
 @test "defaultAuthMethod/CR: with vaultAuthGlobalRef/default" {
     cd "$(chart_dir)"
     local actual
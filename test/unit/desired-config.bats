@@ -0,0 +1,45 @@
+#!/usr/bin/env bats
+
+load _helpers
+
+@test "desiredConfig: disabled by default" {
+  cd `chart_dir`
+  local actual=$(helm template \
+      . | tee /dev/stderr |
+      yq 'select(.kind == "ConfigMap" and .metadata.name == "release-name-vault-secrets-operator-desired-config") | documentIndex' | tee /dev/stderr)
+  [ "${actual}" = "" ]
+
+  actual=$(helm template \
+      -s templates/deployment.yaml  \
+      . | tee /dev/stderr |
+      yq 'select(.kind == "Deployment" and .metadata.labels."control-plane" == "controller-manager") | .spec.template.spec.containers[] | select(.name == "manager") | .args | contains(["--desired-config-configmap=release-name-vault-secrets-operator-desired-config"])' | tee /dev/stderr)
+  [ "${actual}" = "false" ]
+}
+
+@test "desiredConfig: declares the manager's args and env" {
+  cd `chart_dir`
+  local object=$(helm template \
+      -s templates/desired-config.yaml  \
+      --set 'controller.manager.desiredConfig.enabled=true' \
+      --set 'controller.manager.clientCache.cacheSize=100' \
+      --set 'controller.manager.extraEnv[0].name=VSO_OUTPUT_FORMAT' \
+      --set 'controller.manager.extraEnv[0].value=json' \
+      . | tee /dev/stderr |
+      yq '.data' | tee /dev/stderr)
+
+  local actual=$(echo "$object" | yq '."client-cache-size"' | tee /dev/stderr)
+  [ "${actual}" = "100" ]
+  actual=$(echo "$object" | yq '."leader-elect"' | tee /dev/stderr)
+  [ "${actual}" = "true" ]
+  actual=$(echo "$object" | yq '."desired-config-configmap"' | tee /dev/stderr)
+  [ "${actual}" = "release-name-vault-secrets-operator-desired-config" ]
+  actual=$(echo "$object" | yq '."env.VSO_OUTPUT_FORMAT"' | tee /dev/stderr)
+  [ "${actual}" = "json" ]
+
+  actual=$(helm template \
+      -s templates/deployment.yaml  \
+      --set 'controller.manager.desiredConfig.enabled=true' \
+      . | tee /dev/stderr |
+      yq 'select(.kind == "Deployment" and .metadata.labels."control-plane" == "controller-manager") | .spec.template.spec.containers[] | select(.name == "manager") | .args | contains(["--desired-config-configmap=release-name-vault-secrets-operator-desired-config"])' | tee /dev/stderr)
+  [ "${actual}" = "true" ]
+}
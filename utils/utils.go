@@ -6,6 +6,7 @@ package utils
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"io"
@@ -47,6 +48,43 @@ func GetCurrentNamespace() (string, error) {
 	return string(bytes.Trim(b, " ")), nil
 }
 
+// GetServiceAccountUID returns the UID of the ServiceAccount that the
+// Operator runs as, from the claims of its mounted ServiceAccount token. The
+// token's signature is not verified, since it is read from the local file
+// system.
+func GetServiceAccountUID() (string, error) {
+	filename := filepath.Join(saRootDir, "token")
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the service account token: %w", err)
+	}
+
+	parts := strings.Split(string(bytes.TrimSpace(b)), ".")
+	if len(parts) != 3 {
+		return "", fmt.Errorf("invalid service account token")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", fmt.Errorf("invalid service account token payload: %w", err)
+	}
+
+	var claims struct {
+		Kubernetes struct {
+			ServiceAccount struct {
+				UID string `json:"uid"`
+			} `json:"serviceaccount"`
+		} `json:"kubernetes.io"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return "", fmt.Errorf("invalid service account token claims: %w", err)
+	}
+	if claims.Kubernetes.ServiceAccount.UID == "" {
+		return "", fmt.Errorf("service account token is missing the service account UID claim")
+	}
+
+	return claims.Kubernetes.ServiceAccount.UID, nil
+}
+
 func GetOwnerRefFromObj(owner ctrlclient.Object, scheme *runtime.Scheme) (metav1.OwnerReference, error) {
 	ownerRef := metav1.OwnerReference{
 		Name: owner.GetName(),
@@ -5,6 +5,7 @@ package utils
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -83,6 +84,53 @@ func TestGetCurrentNamespace(t *testing.T) {
 	}
 }
 
+func TestGetServiceAccountUID(t *testing.T) {
+	token := func(claims string) string {
+		return "header." + base64.RawURLEncoding.EncodeToString([]byte(claims)) + ".signature"
+	}
+	tests := []struct {
+		name    string
+		token   string
+		want    string
+		wantErr string
+	}{
+		{
+			name:  "basic",
+			token: token(`{"kubernetes.io":{"namespace":"vso","serviceaccount":{"name":"vso","uid":"sa-uid"}}}`) + "\n",
+			want:  "sa-uid",
+		},
+		{
+			name:    "error-missing-uid",
+			token:   token(`{"sub":"system:serviceaccount:vso:vso"}`),
+			wantErr: "service account token is missing the service account UID claim",
+		},
+		{
+			name:    "error-invalid",
+			token:   "invalid",
+			wantErr: "invalid service account token",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			origSARootDir := saRootDir
+			t.Cleanup(func() {
+				saRootDir = origSARootDir
+			})
+			saRootDir = dir
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "token"), []byte(tt.token), 0o600))
+
+			got, err := GetServiceAccountUID()
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestUpgradeCRDs(t *testing.T) {
 	t.Parallel()
 
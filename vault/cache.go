@@ -22,6 +22,7 @@ type ClientCache interface {
 	Remove(ClientCacheKey) bool
 	Len() int
 	Prune(filterFunc ClientCachePruneFilterFunc) []Client
+	Values() []Client
 	Contains(key ClientCacheKey) bool
 	Purge() []ClientCacheKey
 }
@@ -144,6 +145,11 @@ func (c *clientCache) Prune(filterFunc ClientCachePruneFilterFunc) []Client {
 	return pruned
 }
 
+// Values returns all the cached Clients, excluding the clones.
+func (c *clientCache) Values() []Client {
+	return c.cache.Values()
+}
+
 func (c *clientCache) remove(key ClientCacheKey, peek bool) bool {
 	remove := true
 	if key.IsClone() {
@@ -24,12 +24,14 @@ import (
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
 	"github.com/hashicorp/vault-secrets-operator/common"
 	"github.com/hashicorp/vault-secrets-operator/consts"
 	"github.com/hashicorp/vault-secrets-operator/helpers"
 	"github.com/hashicorp/vault-secrets-operator/internal/metrics"
+	"github.com/hashicorp/vault-secrets-operator/utils"
 )
 
 const (
@@ -122,6 +124,10 @@ type clientCacheStorageEntry struct {
 	// ProviderNamespace is the k8s namespace of the CredentialProvider that
 	// was used to create the cached Client.
 	ProviderNamespace string
+	// Rekey is true if the entry was encrypted with the local encryption key
+	// of the previous Operator start, it should be stored again with the
+	// current one.
+	Rekey bool
 }
 
 func (c ClientCacheStorageStoreRequest) Validate() error {
@@ -152,6 +158,9 @@ type ClientCacheStorage interface {
 type defaultClientCacheStorage struct {
 	hmacKey                  []byte
 	enforceEncryption        bool
+	localKeyring             *localStorageKeyring
+	localKeyObjKey           ctrlclient.ObjectKey
+	localKeyBinding          []byte
 	logger                   logr.Logger
 	requestCounterVec        *prometheus.CounterVec
 	requestErrorCounterVec   *prometheus.CounterVec
@@ -261,6 +270,15 @@ func (c *defaultClientCacheStorage) Store(ctx context.Context, client ctrlclient
 			return nil, err
 		}
 		b = encBytes
+	} else if c.localKeyring != nil {
+		s.ObjectMeta.Labels[labelEncrypted] = "true"
+		s.ObjectMeta.Labels[labelLocalKeyID] = c.localKeyring.current.id
+		var encBytes []byte
+		encBytes, err = c.localKeyring.current.encrypt(b, []byte(s.Name))
+		if err != nil {
+			return nil, err
+		}
+		b = encBytes
 	}
 
 	s.Data = map[string][]byte{
@@ -368,9 +386,33 @@ func (c *defaultClientCacheStorage) restore(ctx context.Context, client ctrlclie
 		return nil, err
 	}
 
+	var rekey bool
 	if b, ok := s.Data[fieldCachedSecret]; ok {
 		transitRef := s.Labels["vaultTransitRef"]
-		if transitRef != "" {
+		localKeyID := s.Labels[labelLocalKeyID]
+		if localKeyID != "" {
+			if c.localKeyring == nil {
+				err = fmt.Errorf("local encryption is not enabled")
+				return nil, err
+			}
+
+			var key *localStorageKey
+			key, rekey, err = c.localKeyring.get(localKeyID)
+			if err != nil {
+				return nil, err
+			}
+
+			var decBytes []byte
+			decBytes, err = key.decrypt(b, []byte(s.Name))
+			if err != nil {
+				return nil, err
+			}
+
+			b = decBytes
+		} else if c.localKeyring != nil {
+			err = fmt.Errorf("entry is not encrypted with the local encryption key")
+			return nil, err
+		} else if transitRef != "" {
 			if req.DecryptionClient == nil || req.DecryptionVaultAuth == nil {
 				err = fmt.Errorf("request is invalid for decryption")
 				return nil, err
@@ -407,6 +449,7 @@ func (c *defaultClientCacheStorage) restore(ctx context.Context, client ctrlclie
 		VaultConnectionNamespace: s.Labels[labelConnectionNamespace],
 		ProviderUID:              types.UID(s.Labels[labelProviderUID]),
 		ProviderNamespace:        s.Labels[labelProviderNamespace],
+		Rekey:                    rekey,
 	}
 
 	if v, ok := s.Labels[labelAuthGeneration]; ok && v != "" {
@@ -606,11 +649,24 @@ type ClientCacheStorageConfig struct {
 	// EnforceEncryption for persisting Clients i.e. the controller must have VaultTransitRef
 	// configured before it will persist the Client to storage. This option requires Persist to be true.
 	EnforceEncryption bool
-	HMACSecretObjKey  ctrlclient.ObjectKey
-	OwnerRefs         []metav1.OwnerReference
+	// LocalEncryption for persisting Clients encrypted with a key that is local
+	// to the Operator, for when the Transit engine cannot be used. The key is
+	// derived from the key material stored in the LocalEncryptionKeyObjKey
+	// Secret, and from the UID of the Operator's ServiceAccount. The UID is not
+	// secret, access to the Secret must be restricted. The key material is
+	// rotated every time an Operator instance is elected leader, only the
+	// Clients that were stored with the current or previous key material can be
+	// restored. This option is mutually exclusive with EnforceEncryption.
+	LocalEncryption          bool
+	LocalEncryptionKeyObjKey ctrlclient.ObjectKey
+	HMACSecretObjKey         ctrlclient.ObjectKey
+	OwnerRefs                []metav1.OwnerReference
 	// skipHMACSecret is used for unit tests, which need to control various aspects
 	// of HMAC secret creation.
 	skipHMACSecret bool
+	// localEncryptionBinding is used for unit tests, in place of the UID of
+	// the Operator's ServiceAccount.
+	localEncryptionBinding []byte
 }
 
 func DefaultClientCacheStorageConfig() *ClientCacheStorageConfig {
@@ -620,6 +676,10 @@ func DefaultClientCacheStorageConfig() *ClientCacheStorageConfig {
 			Name:      NamePrefixVCC + "storage-hmac-key",
 			Namespace: common.OperatorNamespace,
 		},
+		LocalEncryptionKeyObjKey: ctrlclient.ObjectKey{
+			Name:      NamePrefixVCC + "storage-local-key",
+			Namespace: common.OperatorNamespace,
+		},
 	}
 }
 
@@ -695,6 +755,34 @@ func newDefaultClientCacheStorage(ctx context.Context, client ctrlclient.Client,
 		cacheStorage.hmacKey = s.Data[helpers.HMACKeyName]
 	}
 
+	if config.LocalEncryption {
+		if config.EnforceEncryption {
+			return nil, fmt.Errorf("local encryption and enforced encryption are mutually exclusive")
+		}
+		if err := common.ValidateObjectKey(config.LocalEncryptionKeyObjKey); err != nil {
+			return nil, err
+		}
+
+		binding := config.localEncryptionBinding
+		if len(binding) == 0 {
+			uid, err := utils.GetServiceAccountUID()
+			if err != nil {
+				return nil, err
+			}
+			binding = []byte(uid)
+		}
+
+		// the key is only rotated by the leader, see
+		// rotateLocalEncryptionKey.
+		keyring, err := loadLocalStorageKeyring(ctx, client, config.LocalEncryptionKeyObjKey, binding)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the local encryption key: %w", err)
+		}
+		cacheStorage.localKeyring = keyring
+		cacheStorage.localKeyObjKey = config.LocalEncryptionKeyObjKey
+		cacheStorage.localKeyBinding = binding
+	}
+
 	if metricsRegistry != nil {
 		// metric for exporting the storage cache configuration
 		configGauge := prometheus.NewGauge(prometheus.GaugeOpts{
@@ -717,3 +805,87 @@ func newDefaultClientCacheStorage(ctx context.Context, client ctrlclient.Client,
 
 	return cacheStorage, nil
 }
+
+// rotateLocalEncryptionKey rotates the local encryption key material, and
+// prunes the Clients that can no longer be decrypted. The Clients that were
+// stored with the replaced key are re-encrypted upon restoration.
+func (c *defaultClientCacheStorage) rotateLocalEncryptionKey(ctx context.Context, client ctrlclient.Client) error {
+	keyring, err := rotateLocalStorageKeyring(ctx, client, c.localKeyObjKey, c.localKeyBinding)
+	if err != nil {
+		return fmt.Errorf("failed to rotate the local encryption key: %w", err)
+	}
+
+	c.mu.Lock()
+	c.localKeyring = keyring
+	c.mu.Unlock()
+
+	if _, err := c.Prune(ctx, client, ClientCacheStoragePruneRequest{
+		MatchingLabels: commonMatchingLabels,
+		Filter: func(s corev1.Secret) bool {
+			_, _, err := keyring.get(s.Labels[labelLocalKeyID])
+			return err == nil
+		},
+	}); err != nil {
+		c.logger.Error(err, "Failed to prune the client cache storage")
+	}
+
+	return nil
+}
+
+var _ manager.Runnable = (*localEncryptionKeyRotator)(nil)
+
+// localEncryptionKeyRotator rotates the local encryption key once the Operator
+// instance is elected leader. The key is only rotated by the leader, since all
+// the Operator instances share it.
+type localEncryptionKeyRotator struct {
+	storage *defaultClientCacheStorage
+	client  ctrlclient.Client
+	// retryInterval is the delay before a failed rotation is retried.
+	retryInterval time.Duration
+}
+
+// NewLocalEncryptionKeyRotator returns the manager.Runnable that rotates the
+// local encryption key of factory's ClientCacheStorage. It is nil if the
+// storage is not encrypted with a local key, see
+// ClientCacheStorageConfig.LocalEncryption.
+func NewLocalEncryptionKeyRotator(factory CachingClientFactory, client ctrlclient.Client) manager.Runnable {
+	m, ok := factory.(*cachingClientFactory)
+	if !ok || !m.storageEnabled() {
+		return nil
+	}
+	storage, ok := m.storage.(*defaultClientCacheStorage)
+	if !ok || storage.localKeyring == nil {
+		return nil
+	}
+
+	return &localEncryptionKeyRotator{
+		storage:       storage,
+		client:        client,
+		retryInterval: 30 * time.Second,
+	}
+}
+
+// Start rotates the key, the rotation is retried until it succeeds, or ctx is
+// done.
+func (r *localEncryptionKeyRotator) Start(ctx context.Context) error {
+	for {
+		err := r.storage.rotateLocalEncryptionKey(ctx, r.client)
+		if err == nil {
+			return nil
+		}
+
+		r.storage.logger.Error(err, "Local encryption key rotation failed, retrying",
+			"retryAfter", r.retryInterval)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(r.retryInterval):
+		}
+	}
+}
+
+// NeedLeaderElection returns true, so that the key is only rotated by the
+// leader.
+func (r *localEncryptionKeyRotator) NeedLeaderElection() bool {
+	return true
+}
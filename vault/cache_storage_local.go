@@ -0,0 +1,219 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package vault
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	labelLocalKeyID = "localKeyID"
+
+	fieldLocalKeyCurrent  = "current"
+	fieldLocalKeyPrevious = "previous"
+
+	localKeySize = 32
+	localKeyInfo = "vso-client-cache-storage"
+)
+
+var localKeySecretLabels = map[string]string{
+	"app.kubernetes.io/name":       "vault-secrets-operator",
+	"app.kubernetes.io/managed-by": "hashicorp-vso",
+	"app.kubernetes.io/component":  "client-cache-storage-encryption",
+}
+
+// localStorageKey encrypts the client cache storage entries with AES-GCM,
+// without requiring Vault's Transit engine.
+type localStorageKey struct {
+	id   string
+	aead cipher.AEAD
+}
+
+// encrypt plaintext, the result is bound to aad, which must be provided on
+// decryption.
+func (k *localStorageKey) encrypt(plaintext, aad []byte) ([]byte, error) {
+	nonce := make([]byte, k.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return k.aead.Seal(nonce, nonce, plaintext, aad), nil
+}
+
+func (k *localStorageKey) decrypt(ciphertext, aad []byte) ([]byte, error) {
+	size := k.aead.NonceSize()
+	if len(ciphertext) < size {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+
+	return k.aead.Open(nil, ciphertext[:size], ciphertext[size:], aad)
+}
+
+// localStorageKeyring holds the key that the client cache storage entries are
+// encrypted with, and the key it was rotated from, which is only used for
+// decryption.
+type localStorageKeyring struct {
+	current  *localStorageKey
+	previous *localStorageKey
+}
+
+// get returns the key for id, and whether it is the previous key.
+func (r *localStorageKeyring) get(id string) (*localStorageKey, bool, error) {
+	switch {
+	case r.current.id == id:
+		return r.current, false, nil
+	case r.previous != nil && r.previous.id == id:
+		return r.previous, true, nil
+	default:
+		return nil, false, fmt.Errorf("unknown local encryption key %q", id)
+	}
+}
+
+// loadLocalStorageKeyring returns the keyring for the key material stored in
+// the Secret for objKey, the Secret is created with new key material if it
+// does not exist.
+func loadLocalStorageKeyring(ctx context.Context, client ctrlclient.Client, objKey ctrlclient.ObjectKey, binding []byte) (*localStorageKeyring, error) {
+	s, err := getOrCreateLocalKeySecret(ctx, client, objKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return newLocalStorageKeyring(s.Data[fieldLocalKeyCurrent], s.Data[fieldLocalKeyPrevious], binding)
+}
+
+// rotateLocalStorageKeyring rotates the key material stored in the Secret for
+// objKey, and returns the resulting keyring. The Secret holds the current and
+// the previous key material, the keys themselves are derived from it and from
+// binding, e.g. the UID of the Operator's ServiceAccount, so that the entries of
+// another ServiceAccount, one that was recreated with the same name, cannot be
+// restored. The binding is not secret, the entries are only as secure as the
+// Secret. The rotation is retried on conflict, the keyring is always derived
+// from the key material that was written.
+func rotateLocalStorageKeyring(ctx context.Context, client ctrlclient.Client, objKey ctrlclient.ObjectKey, binding []byte) (*localStorageKeyring, error) {
+	if len(binding) == 0 {
+		return nil, fmt.Errorf("empty local encryption key binding")
+	}
+
+	var current, previous []byte
+	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		s, err := getOrCreateLocalKeySecret(ctx, client, objKey)
+		if err != nil {
+			return err
+		}
+
+		material := make([]byte, localKeySize)
+		if _, err := io.ReadFull(rand.Reader, material); err != nil {
+			return err
+		}
+
+		current, previous = material, s.Data[fieldLocalKeyCurrent]
+		s.Data = map[string][]byte{
+			fieldLocalKeyCurrent:  current,
+			fieldLocalKeyPrevious: previous,
+		}
+		return client.Update(ctx, s)
+	}); err != nil {
+		return nil, err
+	}
+
+	return newLocalStorageKeyring(current, previous, binding)
+}
+
+// getOrCreateLocalKeySecret returns the Secret for objKey, it is created with
+// new key material if it does not exist.
+func getOrCreateLocalKeySecret(ctx context.Context, client ctrlclient.Client, objKey ctrlclient.ObjectKey) (*corev1.Secret, error) {
+	s := &corev1.Secret{}
+	err := client.Get(ctx, objKey, s)
+	if err == nil || !apierrors.IsNotFound(err) {
+		return s, err
+	}
+
+	material := make([]byte, localKeySize)
+	if _, err := io.ReadFull(rand.Reader, material); err != nil {
+		return nil, err
+	}
+	s = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      objKey.Name,
+			Namespace: objKey.Namespace,
+			Labels:    localKeySecretLabels,
+		},
+		Data: map[string][]byte{
+			fieldLocalKeyCurrent: material,
+		},
+	}
+	if err := client.Create(ctx, s); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			return nil, err
+		}
+		// created concurrently by another Operator instance.
+		s = &corev1.Secret{}
+		if err := client.Get(ctx, objKey, s); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+func newLocalStorageKeyring(current, previous, binding []byte) (*localStorageKeyring, error) {
+	if len(binding) == 0 {
+		return nil, fmt.Errorf("empty local encryption key binding")
+	}
+
+	key, err := newLocalStorageKey(current, binding)
+	if err != nil {
+		return nil, err
+	}
+	keyring := &localStorageKeyring{
+		current: key,
+	}
+	if len(previous) > 0 {
+		keyring.previous, err = newLocalStorageKey(previous, binding)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return keyring, nil
+}
+
+func newLocalStorageKey(material, binding []byte) (*localStorageKey, error) {
+	if len(material) != localKeySize {
+		return nil, fmt.Errorf("invalid local encryption key size %d", len(material))
+	}
+
+	key := make([]byte, localKeySize)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, material, binding, []byte(localKeyInfo)), key); err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(key)
+	return &localStorageKey{
+		id:   hex.EncodeToString(sum[:8]),
+		aead: aead,
+	}, nil
+}
@@ -11,10 +11,13 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	"github.com/hashicorp/vault-secrets-operator/common"
 )
@@ -115,3 +118,116 @@ func assertCacheSecretLen(t *testing.T, ctx context.Context, client ctrlclient.C
 	require.NoError(t, client.List(ctx, &so, listOptions...))
 	return assert.Len(t, so.Items, length, i...)
 }
+
+func Test_defaultClientCacheStorage_localEncryption(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewClientBuilder().Build()
+
+	newStorage := func(t *testing.T, binding string) *defaultClientCacheStorage {
+		t.Helper()
+		config := DefaultClientCacheStorageConfig()
+		config.LocalEncryption = true
+		config.localEncryptionBinding = []byte(binding)
+		c, err := newDefaultClientCacheStorage(ctx, client, config, nil)
+		require.NoError(t, err)
+		return c
+	}
+	restore := func(t *testing.T, c *defaultClientCacheStorage, s *corev1.Secret) (*clientCacheStorageEntry, error) {
+		t.Helper()
+		return c.Restore(ctx, client, ClientCacheStorageRestoreRequest{
+			SecretObjKey:   ctrlclient.ObjectKeyFromObject(s),
+			CacheKey:       ClientCacheKey(s.Labels[labelCacheKey]),
+			NoPruneOnError: true,
+		})
+	}
+
+	// the entries of the unencrypted persistence model are pruned, once the
+	// key is rotated by the leader.
+	require.NoError(t, client.Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "unencrypted",
+			Namespace: common.OperatorNamespace,
+			Labels:    commonMatchingLabels,
+		},
+	}))
+	first := newStorage(t, "sa-uid")
+	assertCacheSecretLen(t, ctx, client, 1)
+	require.NoError(t, (&localEncryptionKeyRotator{storage: first, client: client}).Start(ctx))
+	assertCacheSecretLen(t, ctx, client, 0)
+
+	s := storeSecret(t, ctx, client, first, 0)
+	assert.Equal(t, "true", s.Labels[labelEncrypted])
+	assert.Equal(t, first.localKeyring.current.id, s.Labels[labelLocalKeyID])
+	assert.NotContains(t, string(s.Data[fieldCachedSecret]), "null")
+
+	entry, err := restore(t, first, s)
+	require.NoError(t, err)
+	assert.Nil(t, entry.VaultSecret)
+	assert.False(t, entry.Rekey)
+
+	// the key is not rotated by the start of another replica.
+	second := newStorage(t, "sa-uid")
+	assert.Equal(t, first.localKeyring.current.id, second.localKeyring.current.id)
+	entry, err = restore(t, second, s)
+	require.NoError(t, err)
+	assert.False(t, entry.Rekey)
+
+	// the key is rotated when the replica is elected leader, the entry must
+	// be stored again.
+	require.NoError(t, second.rotateLocalEncryptionKey(ctx, client))
+	assert.NotEqual(t, first.localKeyring.current.id, second.localKeyring.current.id)
+	assert.Equal(t, first.localKeyring.current.id, second.localKeyring.previous.id)
+	entry, err = restore(t, second, s)
+	require.NoError(t, err)
+	assert.True(t, entry.Rekey)
+
+	// the entry is bound to the ServiceAccount's UID.
+	otherKey, err := newLocalStorageKey(
+		getLocalKeyMaterial(t, ctx, client, fieldLocalKeyPrevious), []byte("other-uid"))
+	require.NoError(t, err)
+	_, err = otherKey.decrypt(s.Data[fieldCachedSecret], []byte(s.Name))
+	assert.Error(t, err)
+
+	// the entries that were not stored again are pruned on the next rotation.
+	require.NoError(t, newStorage(t, "sa-uid").rotateLocalEncryptionKey(ctx, client))
+	assertCacheSecretLen(t, ctx, client, 0)
+}
+
+func Test_rotateLocalStorageKeyring_conflict(t *testing.T) {
+	ctx := context.Background()
+	objKey := DefaultClientCacheStorageConfig().LocalEncryptionKeyObjKey
+	var updates int
+	client := fake.NewClientBuilder().
+		WithInterceptorFuncs(interceptor.Funcs{
+			Update: func(ctx context.Context, client ctrlclient.WithWatch, obj ctrlclient.Object, opts ...ctrlclient.UpdateOption) error {
+				updates++
+				if updates == 1 {
+					// another instance rotated the key concurrently.
+					return apierrors.NewConflict(schema.GroupResource{Resource: "secrets"}, obj.GetName(), nil)
+				}
+				return client.Update(ctx, obj, opts...)
+			},
+		}).Build()
+
+	before, err := loadLocalStorageKeyring(ctx, client, objKey, []byte("sa-uid"))
+	require.NoError(t, err)
+
+	keyring, err := rotateLocalStorageKeyring(ctx, client, objKey, []byte("sa-uid"))
+	require.NoError(t, err)
+	assert.Equal(t, 2, updates)
+	assert.Equal(t, before.current.id, keyring.previous.id)
+
+	// the keyring matches the key material that was written.
+	after, err := loadLocalStorageKeyring(ctx, client, objKey, []byte("sa-uid"))
+	require.NoError(t, err)
+	assert.Equal(t, keyring.current.id, after.current.id)
+	assert.Equal(t, keyring.previous.id, after.previous.id)
+}
+
+func getLocalKeyMaterial(t *testing.T, ctx context.Context, client ctrlclient.Client, field string) []byte {
+	t.Helper()
+
+	var s corev1.Secret
+	require.NoError(t, client.Get(ctx, DefaultClientCacheStorageConfig().LocalEncryptionKeyObjKey, &s))
+	return s.Data[field]
+}
@@ -5,6 +5,8 @@ package vault
 
 import (
 	"context"
+	"crypto/tls"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -31,6 +33,13 @@ type ClientOptions struct {
 	WatcherDoneCh             chan<- *ClientCallbackHandlerRequest
 	GlobalVaultAuthOptions    *common.GlobalVaultAuthOptions
 	CredentialProviderFactory credentials.CredentialProviderFactory
+	// LoginLimiter limits the number of simultaneous logins, no limit is
+	// applied when nil.
+	LoginLimiter *LoginLimiter
+	// TokenRenewFraction is the fraction of the token's TTL after which it is
+	// renewed in the background, DefaultTokenRenewFraction is used when it is
+	// not within (0, 1).
+	TokenRenewFraction float64
 }
 
 func defaultClientOptions() *ClientOptions {
@@ -78,6 +87,9 @@ func NewClient(ctx context.Context, client ctrlclient.Client, obj ctrlclient.Obj
 	if err != nil {
 		return nil, err
 	}
+	if err := common.ValidateVaultConnectionNamespace(ctx, client, connObj, authObj.Namespace); err != nil {
+		return nil, err
+	}
 	c := &defaultClient{}
 	if err := c.Init(ctx, client, authObj, connObj, providerNamespace, opts); err != nil {
 		return nil, err
@@ -195,11 +207,14 @@ type defaultClient struct {
 	lastRenewal        int64
 	targetNamespace    string
 	credentialProvider provider.CredentialProviderBase
-	watcher            *api.LifetimeWatcher
+	watcher            *lifetimeWatcher
+	renewFraction      float64
+	ctrlClient         ctrlclient.Client
 	inClosing          bool
 	closed             bool
 	lastWatcherErr     error
 	watcherDoneCh      chan<- *ClientCallbackHandlerRequest
+	loginLimiter       *LoginLimiter
 	tainted            bool
 	once               sync.Once
 	mu                 sync.RWMutex
@@ -418,6 +433,13 @@ func (c *defaultClient) checkExpiry(offset int64) (bool, error) {
 		return false, err
 	}
 
+	if ttl == 0 {
+		// the static tokens, e.g. root tokens, may never expire.
+		if _, ok := c.credentialProvider.(provider.StaticTokenProvider); ok {
+			return false, nil
+		}
+	}
+
 	horizon := ttl - time.Second*time.Duration(offset)
 	if horizon < 1 {
 		// will always result in expiry
@@ -452,6 +474,10 @@ func (c *defaultClient) Close(revoke bool) {
 		c.watcher.Stop()
 	}
 
+	// the static tokens are owned by the user, they are never revoked.
+	if _, ok := c.credentialProvider.(provider.StaticTokenProvider); ok {
+		revoke = false
+	}
 	if revoke && c.client != nil {
 		if err := c.client.Auth().Token().RevokeSelf(""); err != nil {
 			logger.V(consts.LogLevelWarning).Info(
@@ -462,9 +488,10 @@ func (c *defaultClient) Close(revoke bool) {
 	c.closed = true
 }
 
-// startLifetimeWatcher starts an api.LifetimeWatcher in a Go routine for this Client.
-// This will ensure that the auth token is periodically renewed.
-// If the Client's token is not renewable an error will be returned.
+// startLifetimeWatcher starts a lifetimeWatcher in a Go routine for this Client.
+// This will ensure that the auth token is periodically renewed in the
+// background, and that the Client re-authenticates when its token can no longer
+// be renewed. If the Client's token is not renewable an error will be returned.
 func (c *defaultClient) startLifetimeWatcher(ctx context.Context) error {
 	if c.skipRenewal {
 		return nil
@@ -483,18 +510,13 @@ func (c *defaultClient) startLifetimeWatcher(ctx context.Context) error {
 		return fmt.Errorf("lifetimeWatcher already started")
 	}
 
-	watcher, err := c.client.NewLifetimeWatcher(&api.LifetimeWatcherInput{
-		Secret: c.authSecret,
-	})
-	if err != nil {
-		return err
-	}
-
+	watcher := newLifetimeWatcher(c.renewFraction)
+	ttl := time.Duration(c.authSecret.Auth.LeaseDuration) * time.Second
 	cacheKey, _ := c.getCacheKey()
 	watcherID := uuid.NewString()
 	wg := sync.WaitGroup{}
 	wg.Add(1)
-	go func(ctx context.Context, c *defaultClient, watcher *api.LifetimeWatcher) {
+	go func(ctx context.Context, c *defaultClient, watcher *lifetimeWatcher) {
 		logger := log.FromContext(nil).WithName("lifetimeWatcher").WithValues(
 			"id", watcherID, "entityID", c.authSecret.Auth.EntityID,
 			"clientID", c.id, "cacheKey", cacheKey)
@@ -504,7 +526,9 @@ func (c *defaultClient) startLifetimeWatcher(ctx context.Context) error {
 			watcher.Stop()
 		}()
 
-		go watcher.Start()
+		go watcher.run(ttl, func(reauth bool) (time.Duration, bool, error) {
+			return c.renewInBackground(ctx, watcher, reauth)
+		})
 		c.watcher = watcher
 		wg.Done()
 		logger.V(consts.LogLevelDebug).Info("Started")
@@ -534,11 +558,6 @@ func (c *defaultClient) startLifetimeWatcher(ctx context.Context) error {
 				}
 
 				return
-			case renewal := <-watcher.RenewCh():
-				logger.V(consts.LogLevelDebug).Info("Successfully renewed the client")
-
-				c.authSecret = renewal.Secret
-				c.lastRenewal = renewal.RenewedAt.Unix()
 			}
 		}
 	}(ctx, c, watcher)
@@ -547,9 +566,154 @@ func (c *defaultClient) startLifetimeWatcher(ctx context.Context) error {
 	return nil
 }
 
+// renewInBackground renews the Client's token on behalf of the lifetimeWatcher
+// w. The Client re-authenticates instead when reauth is true, or when the
+// renewal fails. The next renewal is a re-authentication when the token was not
+// extended by its full TTL, since it is nearing its max TTL.
+func (c *defaultClient) renewInBackground(ctx context.Context, w *lifetimeWatcher, reauth bool) (time.Duration, bool, error) {
+	logger := log.FromContext(ctx).WithName("lifetimeWatcher")
+	var vaultConn string
+	if c.connObj != nil {
+		vaultConn = ctrlclient.ObjectKeyFromObject(c.connObj).String()
+	}
+
+	if !reauth {
+		c.mu.Lock()
+		if w.stopped() || c.closed {
+			c.mu.Unlock()
+			return 0, false, errLifetimeWatcherStopped
+		}
+
+		var prevTTL int
+		if c.authSecret != nil && c.authSecret.Auth != nil {
+			prevTTL = c.authSecret.Auth.LeaseDuration
+		}
+		err := c.renew(ctx)
+		if err == nil && c.authSecret != nil && c.authSecret.Auth != nil {
+			auth := c.authSecret.Auth
+			c.mu.Unlock()
+			tokenRenewals.WithLabelValues(tokenRenewalResultRenewed, vaultConn).Inc()
+			logger.V(consts.LogLevelDebug).Info("Successfully renewed the client")
+			return time.Duration(auth.LeaseDuration) * time.Second,
+				!auth.Renewable || auth.LeaseDuration < prevTTL, nil
+		}
+		c.mu.Unlock()
+		logger.V(consts.LogLevelWarning).Info(
+			"Failed to renew the client token, re-authenticating", "err", err)
+	}
+
+	// wait for a login slot before taking the lock, like Login() does.
+	release, err := c.loginLimiter.Acquire(ctx, c.authObj)
+	if err != nil {
+		tokenRenewals.WithLabelValues(tokenRenewalResultFailed, vaultConn).Inc()
+		return 0, false, fmt.Errorf("failed to wait for a login slot: %w", err)
+	}
+	defer release()
+
+	c.mu.Lock()
+	if w.stopped() || c.closed {
+		c.mu.Unlock()
+		return 0, false, errLifetimeWatcherStopped
+	}
+
+	// a failed renewal clears the authSecret, the token is still set on the client.
+	prevToken := c.client.Token()
+	startTS := time.Now()
+	err = c.authenticate(ctx, c.ctrlClient)
+	c.observeTime(startTS, metrics.OperationLogin)
+	c.incrementOperationCounter(metrics.OperationLogin, err)
+	if err != nil {
+		c.mu.Unlock()
+		tokenRenewals.WithLabelValues(tokenRenewalResultFailed, vaultConn).Inc()
+		return 0, false, fmt.Errorf("failed to re-authenticate: %w", err)
+	}
+
+	tokenRenewals.WithLabelValues(tokenRenewalResultReauthenticated, vaultConn).Inc()
+	logger.Info("Successfully re-authenticated the client", "clientID", c.id)
+	auth := c.authSecret.Auth
+	// the static tokens are owned by the user, they are never revoked.
+	_, static := c.credentialProvider.(provider.StaticTokenProvider)
+	c.mu.Unlock()
+
+	if !static && prevToken != "" && prevToken != auth.ClientToken {
+		c.revokeToken(ctx, prevToken)
+	}
+	// the Client's token was replaced, the factory must store it again.
+	c.notifyWatcherDoneCh(ctx, ClientCallbackOnReauthenticated)
+
+	return time.Duration(auth.LeaseDuration) * time.Second, !auth.Renewable, nil
+}
+
+// revokeToken revokes token, which is no longer the Client's token. A failure is
+// only logged, the token expires at the end of its TTL anyway.
+func (c *defaultClient) revokeToken(ctx context.Context, token string) {
+	logger := log.FromContext(ctx).WithName("lifetimeWatcher")
+
+	client, err := c.client.CloneWithHeaders()
+	if err == nil {
+		client.SetToken(token)
+		err = client.Auth().Token().RevokeSelfWithContext(ctx, "")
+	}
+	if err != nil {
+		logger.V(consts.LogLevelWarning).Info(
+			"Failed to revoke the replaced Vault client token", "err", err)
+	}
+}
+
+// notifyWatcherDoneCh sends a ClientCallbackHandlerRequest for on to the
+// Client's factory, unless the Client is closing. c.mu must not be held, since
+// the factory's handler may call the Client.
+func (c *defaultClient) notifyWatcherDoneCh(ctx context.Context, on ClientCallbackOn) {
+	if c.watcherDoneCh == nil || c.inClosing {
+		return
+	}
+
+	select {
+	case c.watcherDoneCh <- &ClientCallbackHandlerRequest{
+		Client: c,
+		On:     on,
+	}:
+	case <-ctx.Done():
+	}
+}
+
+// unwrap the response-wrapping token, the request is authenticated with the
+// token itself, since the Client is not logged in yet.
+func (c *defaultClient) unwrap(ctx context.Context, token string) (map[string]interface{}, error) {
+	client, err := c.client.CloneWithHeaders()
+	if err != nil {
+		return nil, err
+	}
+	client.SetToken(token)
+
+	secret, err := client.Logical().UnwrapWithContext(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	if secret != nil && secret.Data == nil && secret.Auth != nil {
+		// a wrapped auth response, e.g. from a token creation.
+		return map[string]interface{}{
+			"client_token": secret.Auth.ClientToken,
+		}, nil
+	}
+	if secret == nil || secret.Data == nil {
+		return nil, fmt.Errorf("empty response from Vault, path=%q", "sys/wrapping/unwrap")
+	}
+
+	return secret.Data, nil
+}
+
 // Login the Client to Vault. Upon success, if the auth token is renewable,
-// an api.LifetimeWatcher will be started to ensure that the token is periodically renewed.
+// a lifetimeWatcher will be started to ensure that the token is periodically renewed.
 func (c *defaultClient) Login(ctx context.Context, client ctrlclient.Client) error {
+	// wait for a login slot before taking the lock, so that the Client is not
+	// locked while its login is throttled.
+	release, err := c.loginLimiter.Acquire(ctx, c.authObj)
+	if err != nil {
+		return fmt.Errorf("failed to wait for a login slot: %w", err)
+	}
+	defer release()
+
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -567,12 +731,80 @@ func (c *defaultClient) Login(ctx context.Context, client ctrlclient.Client) err
 		c.watcher.Stop()
 	}
 
-	creds, err := c.credentialProvider.GetCreds(ctx, client)
-	if err != nil {
+	if err := c.authenticate(ctx, client); err != nil {
 		errs = err
 		return errs
 	}
 
+	if c.authSecret.Auth.Renewable {
+		if err := c.startLifetimeWatcher(ctx); err != nil {
+			errs = err
+			return errs
+		}
+	}
+
+	c.inClosing = false
+	c.closed = false
+
+	return nil
+}
+
+// authenticate the Client to Vault with the credentials of its provider,
+// setting its token, and its ID. It should be called from a write locked
+// method only.
+func (c *defaultClient) authenticate(ctx context.Context, client ctrlclient.Client) error {
+	if client == nil {
+		return errors.New("cannot authenticate, kubernetes client not set")
+	}
+
+	if p, ok := c.credentialProvider.(provider.UnwrappingCredentialProvider); ok {
+		if err := p.Unwrap(ctx, client, c.unwrap); err != nil {
+			return err
+		}
+	}
+
+	creds, err := c.credentialProvider.GetCreds(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	var secret *api.Secret
+	if p, ok := c.credentialProvider.(provider.StaticTokenProvider); ok {
+		secret, err = c.lookupStaticToken(ctx, p.StaticToken())
+	} else {
+		secret, err = c.login(ctx, client, creds)
+	}
+	if err != nil {
+		return err
+	}
+
+	c.client.SetToken(secret.Auth.ClientToken)
+
+	c.authSecret = secret
+	c.lastRenewal = time.Now().Unix()
+
+	id, err := c.hashAccessor()
+	if err != nil {
+		return err
+	}
+
+	c.id = id
+
+	return nil
+}
+
+// login to the auth method with creds, returning the auth secret.
+func (c *defaultClient) login(ctx context.Context, client ctrlclient.Client, creds map[string]any) (*api.Secret, error) {
+	params, err := RenderLoginParams(c.authObj, c.credentialProvider.GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+
+	creds, err = mergeLoginParams(creds, params)
+	if err != nil {
+		return nil, err
+	}
+
 	if len(c.authObj.Spec.Headers) > 0 {
 		defer c.client.SetHeaders(c.client.Headers())
 		headers := c.client.Headers()
@@ -583,49 +815,103 @@ func (c *defaultClient) Login(ctx context.Context, client ctrlclient.Client) err
 	}
 
 	path := fmt.Sprintf("auth/%s/login", c.authObj.Spec.Mount)
-	resp, err := c.Write(ctx, &defaultWriteRequest{
-		path:   path,
-		params: creds,
-	})
+	if p, ok := c.credentialProvider.(provider.LoginPathProvider); ok {
+		path = fmt.Sprintf("auth/%s/%s", c.authObj.Spec.Mount, p.LoginPath())
+	}
+	var resp Response
+	if p, ok := c.credentialProvider.(provider.ClientCertificateProvider); ok {
+		resp, err = c.writeWithClientCertificate(ctx, p.ClientCertificate(), &defaultWriteRequest{
+			path:   path,
+			params: creds,
+		})
+	} else {
+		resp, err = c.Write(ctx, &defaultWriteRequest{
+			path:   path,
+			params: creds,
+		})
+	}
 	if err != nil {
-		errs = err
-		return errs
+		return nil, err
 	}
 
 	secret := resp.Secret()
 	if secret == nil {
-		errs = fmt.Errorf("empty response from Vault, path=%q", path)
-		return errs
+		return nil, fmt.Errorf("empty response from Vault, path=%q", path)
 	}
 
 	if secret.Auth == nil {
-		errs = fmt.Errorf("auth secret is nil")
-		return errs
+		return nil, fmt.Errorf("auth secret is nil")
 	}
 
-	c.client.SetToken(secret.Auth.ClientToken)
+	if secret.Auth.MFARequirement != nil && secret.Auth.ClientToken == "" {
+		return c.validateMFA(ctx, client, secret.Auth.MFARequirement)
+	}
 
-	c.authSecret = secret
-	c.lastRenewal = time.Now().Unix()
+	return secret, nil
+}
 
-	id, err := c.hashAccessor()
+// lookupStaticToken looks up the static token, returning an auth secret for
+// it, as if it had been returned from a login. The lookup fails if the token
+// has expired, or has been revoked.
+func (c *defaultClient) lookupStaticToken(ctx context.Context, token string) (*api.Secret, error) {
+	client, err := c.client.CloneWithHeaders()
 	if err != nil {
-		return err
+		return nil, err
 	}
-
-	c.id = id
-
-	if resp.Secret().Auth.Renewable {
-		if err := c.startLifetimeWatcher(ctx); err != nil {
-			errs = err
-			return errs
+	client.SetToken(token)
+	if len(c.authObj.Spec.Headers) > 0 {
+		headers := client.Headers()
+		for k, v := range c.authObj.Spec.Headers {
+			headers[k] = []string{v}
 		}
+		client.SetHeaders(headers)
 	}
 
-	c.inClosing = false
-	c.closed = false
+	path := "auth/token/lookup-self"
+	resp, err := client.Auth().Token().LookupSelfWithContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("the static token is invalid, it may have expired: %w", err)
+	}
+	if resp == nil || resp.Data == nil {
+		return nil, fmt.Errorf("empty response from Vault, path=%q", path)
+	}
 
-	return nil
+	accessor, err := resp.TokenAccessor()
+	if err != nil {
+		return nil, err
+	}
+	ttl, err := resp.TokenTTL()
+	if err != nil {
+		return nil, err
+	}
+	renewable, err := resp.TokenIsRenewable()
+	if err != nil {
+		return nil, err
+	}
+	policies, err := resp.TokenPolicies()
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := resp.TokenMetadata()
+	if err != nil {
+		return nil, err
+	}
+	entityID, _ := resp.Data["entity_id"].(string)
+	orphan, _ := resp.Data["orphan"].(bool)
+
+	return &api.Secret{
+		Auth: &api.SecretAuth{
+			ClientToken:   token,
+			Accessor:      accessor,
+			Policies:      policies,
+			TokenPolicies: policies,
+			Metadata:      metadata,
+			Orphan:        orphan,
+			EntityID:      entityID,
+			LeaseDuration: int(ttl.Seconds()),
+			Renewable:     renewable,
+		},
+	}, nil
 }
 
 func (c *defaultClient) hashAccessor() (string, error) {
@@ -707,9 +993,11 @@ func (c *defaultClient) Read(ctx context.Context, request ReadRequest) (Response
 	}
 
 	if secret == nil {
-		return nil, fmt.Errorf("empty response from Vault, path=%q", path)
+		return nil, &SecretNotFoundError{Path: path}
 	}
 
+	c.observeBytesRead(secret)
+
 	return respFunc(secret), nil
 }
 
@@ -727,6 +1015,59 @@ func (c *defaultClient) Write(ctx context.Context, req WriteRequest) (Response,
 	return &defaultResponse{secret: secret}, err
 }
 
+// writeWithClientCertificate sends req with a copy of the Client that presents
+// certificate on its TLS connections. The copy has its own connection pool,
+// so that the certificate is only presented for req.
+func (c *defaultClient) writeWithClientCertificate(ctx context.Context, certificate *tls.Certificate, req WriteRequest) (Response, error) {
+	var err error
+	startTS := time.Now()
+	defer func() {
+		c.observeTime(startTS, metrics.OperationWrite)
+		c.incrementOperationCounter(metrics.OperationWrite, err)
+	}()
+
+	if certificate == nil {
+		err = fmt.Errorf("no client certificate loaded")
+		return nil, err
+	}
+
+	config := c.client.CloneConfig()
+	base := config.HttpClient.Transport
+	if t, ok := base.(*hedgedTransport); ok {
+		// only reads are hedged.
+		base = t.base
+	}
+	transport, ok := base.(*http.Transport)
+	if !ok {
+		err = fmt.Errorf("unsupported transport %T for client certificate auth", base)
+		return nil, err
+	}
+	transport = transport.Clone()
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+	transport.TLSClientConfig.Certificates = []tls.Certificate{*certificate}
+	httpClient := *config.HttpClient
+	httpClient.Transport = transport
+	config.HttpClient = &httpClient
+
+	var client *api.Client
+	client, err = api.NewClient(config)
+	if err != nil {
+		return nil, err
+	}
+	defer transport.CloseIdleConnections()
+
+	client.ClearToken()
+	client.SetHeaders(c.client.Headers())
+	client.SetNamespace(c.client.Namespace())
+
+	var secret *api.Secret
+	secret, err = client.Logical().WriteWithContext(ctx, req.Path(), req.Params())
+
+	return &defaultResponse{secret: secret}, err
+}
+
 func (c *defaultClient) renew(ctx context.Context) error {
 	// should be called from a write locked method only
 	var errs error
@@ -792,6 +1133,9 @@ func (c *defaultClient) init(ctx context.Context, client ctrlclient.Client,
 	c.authObj = authObj
 	c.connObj = connObj
 	c.watcherDoneCh = opts.WatcherDoneCh
+	c.loginLimiter = opts.LoginLimiter
+	c.renewFraction = opts.TokenRenewFraction
+	c.ctrlClient = client
 
 	return nil
 }
@@ -807,6 +1151,21 @@ func (c *defaultClient) observeTime(ts time.Time, operation string) {
 	)
 }
 
+// observeBytesRead adds the size of the JSON encoded data of secret to the
+// SecretDataReadBytes of the Client's provider namespace.
+func (c *defaultClient) observeBytesRead(secret *api.Secret) {
+	if c.credentialProvider == nil {
+		// should not happen on a properly initialized Client
+		return
+	}
+
+	b, err := json.Marshal(secret.Data)
+	if err != nil {
+		return
+	}
+	metrics.SecretDataReadBytes.WithLabelValues(c.credentialProvider.GetNamespace()).Add(float64(len(b)))
+}
+
 func (c *defaultClient) incrementOperationCounter(operation string, err error) {
 	if c.connObj == nil {
 		// should not happen on a properly initialized Client
@@ -897,13 +1256,14 @@ func NewClientConfigFromConnObj(connObj *secretsv1beta1.VaultConnection, vaultNS
 	}
 
 	cfg := &ClientConfig{
-		Address:         connObj.Spec.Address,
-		SkipTLSVerify:   connObj.Spec.SkipTLSVerify,
-		TLSServerName:   connObj.Spec.TLSServerName,
-		K8sNamespace:    connObj.Namespace,
-		CACertSecretRef: connObj.Spec.CACertSecretRef,
-		Headers:         connObj.Spec.Headers,
-		VaultNamespace:  vaultNS,
+		Address:            connObj.Spec.Address,
+		SkipTLSVerify:      connObj.Spec.SkipTLSVerify,
+		TLSServerName:      connObj.Spec.TLSServerName,
+		K8sNamespace:       connObj.Namespace,
+		CACertSecretRef:    connObj.Spec.CACertSecretRef,
+		CACertConfigMapRef: connObj.Spec.CACertConfigMapRef,
+		Headers:            connObj.Spec.Headers,
+		VaultNamespace:     vaultNS,
 	}
 
 	if connObj.Spec.Timeout != "" {
@@ -913,5 +1273,22 @@ func NewClientConfigFromConnObj(connObj *secretsv1beta1.VaultConnection, vaultNS
 		}
 		cfg.Timeout = &d
 	}
+
+	if connObj.Spec.HedgedReads != nil {
+		if len(connObj.Spec.AlternateAddresses) == 0 {
+			return nil, errors.New("hedged reads require at least one alternate address")
+		}
+
+		d := DefaultHedgeDelay
+		if connObj.Spec.HedgedReads.Delay != "" {
+			var err error
+			d, err = time.ParseDuration(connObj.Spec.HedgedReads.Delay)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse hedged reads delay: %w", err)
+			}
+		}
+		cfg.HedgeDelay = &d
+		cfg.AlternateAddresses = connObj.Spec.AlternateAddresses
+	}
 	return cfg, nil
 }
@@ -26,6 +26,7 @@ import (
 	"github.com/hashicorp/vault-secrets-operator/common"
 	"github.com/hashicorp/vault-secrets-operator/consts"
 	"github.com/hashicorp/vault-secrets-operator/credentials"
+	"github.com/hashicorp/vault-secrets-operator/credentials/provider"
 	"github.com/hashicorp/vault-secrets-operator/internal/metrics"
 )
 
@@ -42,6 +43,9 @@ const (
 	ClientCallbackOnLifetimeWatcherDone ClientCallbackOn = 1 << iota
 	// ClientCallbackOnCacheRemoval is a ClientCallbackOn that handles client cache removal events.
 	ClientCallbackOnCacheRemoval
+	// ClientCallbackOnReauthenticated is a ClientCallbackOn that handles the
+	// re-authentication of a client by its lifetime watcher.
+	ClientCallbackOnReauthenticated
 )
 
 func (o ClientCallbackOn) String() string {
@@ -50,6 +54,8 @@ func (o ClientCallbackOn) String() string {
 		return "LifetimeWatcherDone"
 	case ClientCallbackOnCacheRemoval:
 		return "CacheRemoval"
+	case ClientCallbackOnReauthenticated:
+		return "Reauthenticated"
 	default:
 		return "Unknown"
 	}
@@ -107,6 +113,7 @@ type CachingClientFactory interface {
 	ClientFactory
 	Restore(context.Context, ctrlclient.Client, ctrlclient.Object) (Client, error)
 	Prune(context.Context, ctrlclient.Client, ctrlclient.Object, CachingClientFactoryPruneRequest) (int, error)
+	Clients(*secretsv1beta1.VaultAuth) []Client
 	Start(context.Context)
 	Stop()
 	ShutDown(CachingClientFactoryShutDownRequest)
@@ -148,6 +155,12 @@ type cachingClientFactory struct {
 	GlobalVaultAuthOptions *common.GlobalVaultAuthOptions
 	// credentialProviderFactory is a function that returns a CredentialProvider.
 	credentialProviderFactory credentials.CredentialProviderFactory
+	// loginLimiter limits the number of simultaneous logins of the factory's
+	// Clients.
+	loginLimiter *LoginLimiter
+	// tokenRenewFraction is the fraction of the TTL of the Clients' tokens after
+	// which they are renewed in the background.
+	tokenRenewFraction float64
 }
 
 // Start method for cachingClientFactory starts the lifetime watcher handler.
@@ -179,6 +192,21 @@ func (m *cachingClientFactory) RegisterClientCallbackHandler(cb ClientCallbackHa
 	m.clientCallbacks = append(m.clientCallbacks, cb)
 }
 
+// Clients returns the cached Clients that were created from the VaultAuth, for
+// any of its generations.
+func (m *cachingClientFactory) Clients(obj *secretsv1beta1.VaultAuth) []Client {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var ret []Client
+	for _, c := range m.cache.Values() {
+		if authObj := c.GetVaultAuthObj(); authObj != nil && authObj.GetUID() == obj.GetUID() {
+			ret = append(ret, c)
+		}
+	}
+
+	return ret
+}
+
 // Prune the storage for the requesting object and CachingClientFactoryPruneRequest.
 // Supported, requesting client.Object(s), are: v1beta1.VaultAuth, v1beta1.VaultConnection.
 // Then number of pruned storage Secrets will be returned, along with any errors encountered.
@@ -196,6 +224,9 @@ func (m *cachingClientFactory) Prune(ctx context.Context, client ctrlclient.Clie
 			other := c.GetVaultAuthObj()
 			return req.FilterFunc(cur, other)
 		}
+		if cur.GetDeletionTimestamp() != nil {
+			m.loginLimiter.Remove(cur.GetUID())
+		}
 	case *secretsv1beta1.VaultConnection:
 		filter = func(c Client) bool {
 			other := c.GetVaultConnectionObj()
@@ -319,6 +350,31 @@ func (m *cachingClientFactory) ShutDown(req CachingClientFactoryShutDownRequest)
 	m.logger.Info("Completed ClientFactory shutdown")
 }
 
+// validateCredentialRotation returns an error if the client certificate that c
+// logged in with, or the static token that it uses, has been rotated since, so
+// that the Client is replaced by one that uses the new credentials.
+func validateCredentialRotation(ctx context.Context, client ctrlclient.Client, c Client) error {
+	switch p := c.GetCredentialProvider().(type) {
+	case provider.ClientCertificateProvider:
+		rotated, err := p.ClientCertificateRotated(ctx, client)
+		if err != nil {
+			return fmt.Errorf("failed to check the client certificate: %w", err)
+		}
+		if rotated {
+			return fmt.Errorf("the client certificate has been rotated")
+		}
+	case provider.StaticTokenProvider:
+		rotated, err := p.StaticTokenRotated(ctx, client)
+		if err != nil {
+			return fmt.Errorf("failed to check the static token: %w", err)
+		}
+		if rotated {
+			return fmt.Errorf("the static token has been replaced")
+		}
+	}
+	return nil
+}
+
 func (m *cachingClientFactory) storageEnabled() bool {
 	return m.persist && m.storage != nil
 }
@@ -387,7 +443,9 @@ func (m *cachingClientFactory) Get(ctx context.Context, client ctrlclient.Client
 				return nil, err
 			}
 
-			if clone, ok := m.cache.Get(cacheKeyClone); ok {
+			// the cached clone is stale when the "root" Client re-authenticated
+			// since it was cloned, since it still holds the previous token.
+			if clone, ok := m.cache.Get(cacheKeyClone); ok && clone.ID() == c.ID() {
 				return clone, nil
 			}
 
@@ -414,7 +472,11 @@ func (m *cachingClientFactory) Get(ctx context.Context, client ctrlclient.Client
 		tainted := c.Tainted()
 		logger.V(consts.LogLevelTrace).Info("Got client from cache",
 			"clientID", c.ID(), "tainted", tainted)
-		if err := c.Validate(ctx); err != nil {
+		err := c.Validate(ctx)
+		if err == nil {
+			err = validateCredentialRotation(ctx, client, c)
+		}
+		if err != nil {
 			logger.V(consts.LogLevelDebug).Error(err, "Invalid client",
 				"tainted", tainted)
 			m.cache.Remove(cacheKey)
@@ -556,7 +618,9 @@ func (m *cachingClientFactory) restoreClient(ctx context.Context, client ctrlcli
 		return nil, err
 	}
 
-	if _, err := m.cacheClient(ctx, c, false); err != nil {
+	// store the Client again if its entry is only encrypted with the local
+	// encryption key of the previous start.
+	if _, err := m.cacheClient(ctx, c, entry.Rekey); err != nil {
 		return nil, err
 	}
 
@@ -568,6 +632,8 @@ func (m *cachingClientFactory) clientOptions() *ClientOptions {
 		WatcherDoneCh:             m.callbackHandlerCh,
 		GlobalVaultAuthOptions:    m.GlobalVaultAuthOptions,
 		CredentialProviderFactory: m.credentialProviderFactory,
+		LoginLimiter:              m.loginLimiter,
+		TokenRenewFraction:        m.tokenRenewFraction,
 	}
 }
 
@@ -809,10 +875,19 @@ func (m *cachingClientFactory) startClientCallbackHandler(ctx context.Context) {
 					cacheKey = parentCacheKey
 				}
 
+				// persist the client's new token, so that it is the one that gets
+				// restored.
+				if req.On&ClientCallbackOnReauthenticated != 0 && m.storageEnabled() {
+					logger.V(consts.LogLevelDebug).Info("Storing re-authenticated client", "cacheKey", cacheKey)
+					if err := m.storeClient(ctx, m.ctrlClient, req.Client); err != nil {
+						logger.Error(err, "Failed to store the re-authenticated client", "cacheKey", cacheKey)
+					}
+				}
+
 				// remove the client from the cache, it will be recreated when a reconciler
 				// requests it.
-				logger.V(consts.LogLevelDebug).Info("Removing client from cache", "cacheKey", cacheKey)
 				if req.On&ClientCallbackOnLifetimeWatcherDone != 0 {
+					logger.V(consts.LogLevelDebug).Info("Removing client from cache", "cacheKey", cacheKey)
 					m.cache.Remove(cacheKey)
 					if m.storageEnabled() {
 						if _, err := m.pruneStorage(ctx, m.ctrlClient, cacheKey); err != nil {
@@ -883,6 +958,8 @@ func NewCachingClientFactory(ctx context.Context, client ctrlclient.Client, cach
 		clientMutex:               keymutex.NewHashed(config.ClientCacheNumLocks),
 		GlobalVaultAuthOptions:    config.GlobalVaultAuthOptions,
 		credentialProviderFactory: config.CredentialProviderFactory,
+		loginLimiter:              NewLoginLimiter(config.MaxConcurrentLogins),
+		tokenRenewFraction:        config.TokenRenewFraction,
 		logger: zap.New().WithName("clientCacheFactory").WithValues(
 			"persist", config.Persist,
 			"enforceEncryption", config.StorageConfig.EnforceEncryption,
@@ -953,6 +1030,13 @@ type CachingClientFactoryConfig struct {
 	// operations. A higher number of locks will reduce contention but increase
 	// memory usage.
 	ClientCacheNumLocks int
+	// MaxConcurrentLogins is the maximum number of simultaneous logins to Vault,
+	// across all VaultAuths. No limit is applied when it is not positive.
+	MaxConcurrentLogins int
+	// TokenRenewFraction is the fraction of the TTL of the Clients' tokens after
+	// which they are renewed in the background. DefaultTokenRenewFraction is
+	// used when it is not within (0, 1).
+	TokenRenewFraction float64
 }
 
 // DefaultCachingClientFactoryConfig provides the default configuration for a CachingClientFactory instance.
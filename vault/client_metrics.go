@@ -43,6 +43,15 @@ var (
 		Help:        "Vault Client operation errors",
 		ConstLabels: nil,
 	}, []string{metrics.LabelOperation, metrics.LabelVaultConnection})
+
+	// tokenRenewals counts the background renewals of the Client tokens, by
+	// result: renewed, reauthenticated, or failed.
+	tokenRenewals = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: subsystemClient,
+		Name:      metrics.NameTokenRenewalsTotal,
+		Help:      "Vault Client background token renewals",
+	}, []string{"result", metrics.LabelVaultConnection})
 )
 
 // MustRegisterClientMetrics to register the global Client Prometheus metrics.
@@ -51,5 +60,6 @@ func MustRegisterClientMetrics(registry prometheus.Registerer) {
 		clientOperationTimes,
 		clientOperations,
 		clientOperationErrors,
+		tokenRenewals,
 	)
 }
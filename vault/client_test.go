@@ -5,14 +5,17 @@ package vault
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"testing"
 	"time"
 
 	"github.com/hashicorp/vault/api"
+	io_prometheus_client "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/crypto/blake2b"
@@ -27,6 +30,7 @@ import (
 	"github.com/hashicorp/vault-secrets-operator/credentials/provider"
 	"github.com/hashicorp/vault-secrets-operator/credentials/vault"
 	vaultcredsconsts "github.com/hashicorp/vault-secrets-operator/credentials/vault/consts"
+	"github.com/hashicorp/vault-secrets-operator/internal/metrics"
 )
 
 func Test_defaultClient_CheckExpiry(t *testing.T) {
@@ -427,7 +431,7 @@ func Test_defaultClient_Validate(t *testing.T) {
 		authSecret     *api.Secret
 		skipRenewal    bool
 		lastRenewal    int64
-		watcher        *api.LifetimeWatcher
+		watcher        *lifetimeWatcher
 		tainted        bool
 		lastWatcherErr error
 		wantErr        assert.ErrorAssertionFunc
@@ -441,7 +445,7 @@ func Test_defaultClient_Validate(t *testing.T) {
 			},
 			skipRenewal:    false,
 			lastRenewal:    time.Now().Unix() - 5,
-			watcher:        &api.LifetimeWatcher{},
+			watcher:        &lifetimeWatcher{},
 			lastWatcherErr: nil,
 			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
 				return assert.EqualError(t, err, "client token expired", i...)
@@ -472,7 +476,7 @@ func Test_defaultClient_Validate(t *testing.T) {
 			},
 			skipRenewal:    false,
 			lastRenewal:    time.Now().Unix() - 5,
-			watcher:        &api.LifetimeWatcher{},
+			watcher:        &lifetimeWatcher{},
 			lastWatcherErr: nil,
 			wantErr:        assert.NoError,
 		},
@@ -563,7 +567,7 @@ func Test_defaultClient_Validate(t *testing.T) {
 			},
 			skipRenewal:    false,
 			lastRenewal:    time.Now().Unix() - 5,
-			watcher:        &api.LifetimeWatcher{},
+			watcher:        &lifetimeWatcher{},
 			lastWatcherErr: nil,
 			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
 				return assert.EqualError(t, err, "client not set", i...)
@@ -578,7 +582,7 @@ func Test_defaultClient_Validate(t *testing.T) {
 			},
 			skipRenewal:    false,
 			lastRenewal:    time.Now().Unix() - 5,
-			watcher:        &api.LifetimeWatcher{},
+			watcher:        &lifetimeWatcher{},
 			lastWatcherErr: nil,
 			tainted:        true,
 			handler: &testHandler{
@@ -853,6 +857,85 @@ func Test_defaultClient_Read(t *testing.T) {
 	}
 }
 
+func Test_defaultClient_Read_bytesRead(t *testing.T) {
+	t.Parallel()
+
+	handler := &testHandler{
+		handlerFunc: func(t *testHandler, w http.ResponseWriter, req *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"data":{"foo":"bar"}}`))
+		},
+	}
+	config, l := NewTestHTTPServer(t, handler.handler())
+	t.Cleanup(func() {
+		l.Close()
+	})
+
+	client, err := api.NewClient(config)
+	require.NoError(t, err)
+
+	namespace := "bytes-read"
+	c := &defaultClient{
+		client:             client,
+		credentialProvider: vault.NewKubernetesCredentialProvider(nil, namespace, ""),
+	}
+	_, err = c.Read(context.Background(), NewReadRequest("foo/bar", nil))
+	require.NoError(t, err)
+
+	var m io_prometheus_client.Metric
+	require.NoError(t, metrics.SecretDataReadBytes.WithLabelValues(namespace).Write(&m))
+	assert.Equal(t, float64(len(`{"foo":"bar"}`)), m.GetCounter().GetValue())
+}
+
+func Test_defaultClient_writeWithClientCertificate(t *testing.T) {
+	t.Parallel()
+
+	var peerCerts []int
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		peerCerts = append(peerCerts, len(req.TLS.PeerCertificates))
+		if req.URL.Path == "/v1/auth/cert/login" {
+			assert.Empty(t, req.Header.Get(api.AuthHeaderName))
+		}
+		assert.Equal(t, "ns1", req.Header.Get(api.NamespaceHeaderName))
+		_, _ = w.Write([]byte(`{"auth":{"client_token":"token"}}`))
+	}))
+	srv.TLS = &tls.Config{ClientAuth: tls.RequestClientCert}
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+
+	for name, hedged := range map[string]bool{"default": false, "hedged": true} {
+		t.Run(name, func(t *testing.T) {
+			peerCerts = nil
+			config := api.DefaultConfig()
+			config.Address = srv.URL
+			require.NoError(t, config.ConfigureTLS(&api.TLSConfig{Insecure: true}))
+			if hedged {
+				transport, err := newHedgedTransport(config.HttpClient.Transport, time.Second, []string{srv.URL})
+				require.NoError(t, err)
+				config.HttpClient.Transport = transport
+			}
+			client, err := api.NewClient(config)
+			require.NoError(t, err)
+			client.SetToken("old")
+			client.SetNamespace("ns1")
+
+			c := &defaultClient{
+				client: client,
+			}
+			// the server's own certificate is used as the client certificate.
+			resp, err := c.writeWithClientCertificate(context.Background(), &srv.TLS.Certificates[0],
+				&defaultWriteRequest{path: "auth/cert/login"})
+			require.NoError(t, err)
+			assert.Equal(t, "token", resp.Secret().Auth.ClientToken)
+			// the Client's own requests do not present the certificate.
+			_, err = client.Logical().Read("sys/foo")
+			require.NoError(t, err)
+			assert.Equal(t, []int{1, 0}, peerCerts)
+			assert.Equal(t, "old", client.Token())
+		})
+	}
+}
+
 func Test_defaultClient_Close(t *testing.T) {
 	t.Parallel()
 
@@ -1160,6 +1243,32 @@ func Test_defaultClient_Clone(t *testing.T) {
 	}
 }
 
+func Test_defaultClient_Clone_namespaces(t *testing.T) {
+	t.Parallel()
+
+	// the login occurs in the auth namespace, while the requests made by the
+	// Clone should be sent to the secret's namespace using the same token.
+	// The config mirrors the one set up in MakeVaultClient().
+	vc, err := api.NewClient(&api.Config{
+		CloneToken:   true,
+		CloneHeaders: true,
+	})
+	require.NoError(t, err)
+	vc.SetNamespace("auth-ns")
+	vc.SetToken("token")
+
+	c := &defaultClient{
+		client: vc,
+	}
+	clone, err := c.Clone("secret-ns")
+	require.NoError(t, err)
+
+	assert.Equal(t, "secret-ns", clone.Namespace())
+	assert.Equal(t, "token", clone.(*defaultClient).client.Token())
+	assert.Equal(t, "auth-ns", c.Namespace())
+	assert.Equal(t, "token", c.client.Token())
+}
+
 func TestNewClientConfigFromConnObj(t *testing.T) {
 	t.Parallel()
 	connObjBase := &secretsv1beta1.VaultConnection{
@@ -1176,6 +1285,18 @@ func TestNewClientConfigFromConnObj(t *testing.T) {
 	connObjEmptyTimeout := connObjBase.DeepCopy()
 	connObjEmptyTimeout.Spec.Timeout = ""
 
+	connObjHedged := connObjBase.DeepCopy()
+	connObjHedged.Spec.AlternateAddresses = []string{"https://vault-1.example.com"}
+	connObjHedged.Spec.HedgedReads = &secretsv1beta1.HedgedReads{
+		Delay: "100ms",
+	}
+
+	connObjHedgedDefaultDelay := connObjHedged.DeepCopy()
+	connObjHedgedDefaultDelay.Spec.HedgedReads.Delay = ""
+
+	connObjHedgedNoAlternates := connObjHedged.DeepCopy()
+	connObjHedgedNoAlternates.Spec.AlternateAddresses = nil
+
 	tests := []struct {
 		name    string
 		connObj *secretsv1beta1.VaultConnection
@@ -1208,6 +1329,41 @@ func TestNewClientConfigFromConnObj(t *testing.T) {
 			},
 			wantErr: assert.NoError,
 		},
+		{
+			name:    "hedged-reads",
+			connObj: connObjHedged,
+			want: &ClientConfig{
+				Address:            "https://vault.example.com",
+				Headers:            map[string]string{"foo": "bar"},
+				TLSServerName:      "baz.biff",
+				CACertSecretRef:    "ca.crt",
+				SkipTLSVerify:      true,
+				Timeout:            ptr.To[time.Duration](10 * time.Second),
+				AlternateAddresses: []string{"https://vault-1.example.com"},
+				HedgeDelay:         ptr.To[time.Duration](100 * time.Millisecond),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "hedged-reads-default-delay",
+			connObj: connObjHedgedDefaultDelay,
+			want: &ClientConfig{
+				Address:            "https://vault.example.com",
+				Headers:            map[string]string{"foo": "bar"},
+				TLSServerName:      "baz.biff",
+				CACertSecretRef:    "ca.crt",
+				SkipTLSVerify:      true,
+				Timeout:            ptr.To[time.Duration](10 * time.Second),
+				AlternateAddresses: []string{"https://vault-1.example.com"},
+				HedgeDelay:         ptr.To(DefaultHedgeDelay),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "hedged-reads-no-alternates",
+			connObj: connObjHedgedNoAlternates,
+			wantErr: assert.Error,
+		},
 		{
 			name:    "nil-connObj",
 			wantErr: assert.Error,
@@ -1224,3 +1380,81 @@ func TestNewClientConfigFromConnObj(t *testing.T) {
 		})
 	}
 }
+
+func Test_defaultClient_Login_staticToken(t *testing.T) {
+	t.Parallel()
+
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		paths = append(paths, req.URL.Path)
+		if req.Header.Get(api.AuthHeaderName) != "static" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		assert.Equal(t, "bar", req.Header.Get("X-Foo"))
+		_, _ = w.Write([]byte(`{"data":{"accessor":"accessor","ttl":0,"renewable":false,"policies":["root"],"entity_id":"entity"}}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	config := api.DefaultConfig()
+	config.Address = srv.URL
+	client, err := api.NewClient(config)
+	require.NoError(t, err)
+	client.ClearToken()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "vault-token",
+			Namespace: "foo",
+		},
+		Data: map[string][]byte{
+			"token": []byte("static"),
+		},
+	}
+	authObj := &secretsv1beta1.VaultAuth{
+		Spec: secretsv1beta1.VaultAuthSpec{
+			Method:  vaultcredsconsts.ProviderMethodToken,
+			Headers: map[string]string{"X-Foo": "bar"},
+			Token: &secretsv1beta1.VaultAuthConfigToken{
+				SecretRef: "vault-token",
+			},
+		},
+	}
+	ctx := context.Background()
+	k8sClient := fake.NewClientBuilder().WithObjects(secret).Build()
+	p := &vault.TokenCredentialProvider{}
+	require.NoError(t, p.Init(ctx, k8sClient, authObj, "foo"))
+
+	c := &defaultClient{
+		client:             client,
+		authObj:            authObj,
+		credentialProvider: p,
+	}
+	require.NoError(t, c.Login(ctx, k8sClient))
+	assert.Equal(t, []string{"/v1/auth/token/lookup-self"}, paths)
+	assert.Equal(t, "static", client.Token())
+	assert.Equal(t, &api.SecretAuth{
+		ClientToken:   "static",
+		Accessor:      "accessor",
+		Policies:      []string{"root"},
+		TokenPolicies: []string{"root"},
+		EntityID:      "entity",
+	}, c.GetTokenSecret().Auth)
+	// the token does not expire.
+	require.NoError(t, c.Validate(ctx))
+
+	// the token is not revoked.
+	c.Close(true)
+	assert.Equal(t, []string{"/v1/auth/token/lookup-self"}, paths)
+
+	// an expired token fails the login.
+	secret.Data["token"] = []byte("expired")
+	require.NoError(t, k8sClient.Update(ctx, secret))
+	c = &defaultClient{
+		client:             client,
+		authObj:            authObj,
+		credentialProvider: p,
+	}
+	assert.ErrorContains(t, c.Login(ctx, k8sClient), "the static token is invalid, it may have expired")
+}
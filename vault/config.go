@@ -4,9 +4,13 @@
 package vault
 
 import (
+	"bytes"
 	"context"
 	"crypto/x509"
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/vault/api"
@@ -16,6 +20,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/hashicorp/vault-secrets-operator/consts"
+	"github.com/hashicorp/vault-secrets-operator/internal/clockskew"
 )
 
 // ClientConfig contains the connection and auth information to construct a
@@ -25,7 +30,12 @@ type ClientConfig struct {
 	// "ca.crt" that holds a CA cert that can be used to validate the
 	// certificate presented by the Vault server
 	CACertSecretRef string
-	// K8sNamespace the namespace of the CACertSecretRef secret
+	// CACertConfigMapRef is the name of a k8s ConfigMap that contains a data key
+	// "ca.crt" that holds a CA cert that can be used to validate the
+	// certificate presented by the Vault server
+	CACertConfigMapRef string
+	// K8sNamespace the namespace of the CACertSecretRef secret, and of the
+	// CACertConfigMapRef ConfigMap
 	K8sNamespace string
 	// Address is the URL of the Vault server
 	Address string
@@ -42,6 +52,12 @@ type ClientConfig struct {
 	// Timeout applied to all Vault requests. If not set, the default timeout from
 	// the Vault API client config is used.
 	Timeout *time.Duration
+	// AlternateAddresses of the same Vault cluster that read requests are
+	// hedged to.
+	AlternateAddresses []string
+	// HedgeDelay enables hedged reads, see hedgedTransport for more details.
+	// Requires AlternateAddresses.
+	HedgeDelay *time.Duration
 }
 
 // MakeVaultClient creates a Vault api.Client from a ClientConfig.
@@ -55,30 +71,9 @@ func MakeVaultClient(ctx context.Context, cfg *ClientConfig, client ctrlclient.C
 		return nil, fmt.Errorf("ctrl-runtime Client was nil")
 	}
 
-	var b []byte
-	if cfg.CACertSecretRef != "" {
-		objKey := ctrlclient.ObjectKey{
-			Namespace: cfg.K8sNamespace,
-			Name:      cfg.CACertSecretRef,
-		}
-		s := &v1.Secret{}
-		if err := client.Get(ctx, objKey, s); err != nil {
-			return nil, err
-		}
-
-		var ok bool
-		key := consts.TLSSecretCAKey
-		if b, ok = s.Data[key]; !ok {
-			return nil, fmt.Errorf(`%q not present in the CA secret %q`, key, objKey)
-		}
-
-		if !cfg.SkipTLSVerify {
-			// only validate CA cert chain when SkipTLSVerify is false.
-			certPool := x509.NewCertPool()
-			if ok := certPool.AppendCertsFromPEM(b); !ok {
-				return nil, fmt.Errorf("no valid certificates found for key %q in CA secret %q", key, objKey)
-			}
-		}
+	b, err := LoadCACertBundle(ctx, client, cfg)
+	if err != nil {
+		return nil, err
 	}
 
 	config := api.DefaultConfig()
@@ -96,6 +91,15 @@ func MakeVaultClient(ctx context.Context, cfg *ClientConfig, client ctrlclient.C
 		config.Timeout = *cfg.Timeout
 	}
 
+	if cfg.HedgeDelay != nil && len(cfg.AlternateAddresses) > 0 {
+		transport, err := newHedgedTransport(config.HttpClient.Transport,
+			*cfg.HedgeDelay, cfg.AlternateAddresses)
+		if err != nil {
+			return nil, err
+		}
+		config.HttpClient.Transport = transport
+	}
+
 	config.CloneToken = true
 	config.CloneHeaders = true
 
@@ -107,6 +111,11 @@ func MakeVaultClient(ctx context.Context, cfg *ClientConfig, client ctrlclient.C
 	if _, exists := cfg.Headers[vconsts.NamespaceHeaderName]; exists {
 		return nil, fmt.Errorf("setting header %q on VaultConnection is not permitted", vconsts.NamespaceHeaderName)
 	}
+	if !slices.ContainsFunc(slices.Collect(maps.Keys(cfg.Headers)), func(k string) bool {
+		return strings.EqualFold(k, HeaderUserAgent)
+	}) {
+		c.AddHeader(HeaderUserAgent, DefaultUserAgent)
+	}
 	for k, v := range cfg.Headers {
 		c.AddHeader(k, v)
 	}
@@ -114,5 +123,86 @@ func MakeVaultClient(ctx context.Context, cfg *ClientConfig, client ctrlclient.C
 		c.SetNamespace(cfg.VaultNamespace)
 	}
 
-	return c, nil
+	return withClockSkewObserver(c), nil
+}
+
+// LoadCACertBundle returns the PEM encoded CA certificates of cfg, from its
+// CACertSecretRef Secret, followed by those of its CACertConfigMapRef
+// ConfigMap. It returns nil if neither is set.
+func LoadCACertBundle(ctx context.Context, client ctrlclient.Client, cfg *ClientConfig) ([]byte, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("ClientConfig was nil")
+	}
+
+	key := consts.TLSSecretCAKey
+	var bundle []byte
+	validate := func(b []byte, source string, objKey ctrlclient.ObjectKey) error {
+		if !cfg.SkipTLSVerify {
+			// only validate CA cert chain when SkipTLSVerify is false.
+			certPool := x509.NewCertPool()
+			if ok := certPool.AppendCertsFromPEM(b); !ok {
+				return fmt.Errorf("no valid certificates found for key %q in CA %s %q", key, source, objKey)
+			}
+		}
+		if len(bundle) > 0 && !bytes.HasSuffix(bundle, []byte("\n")) {
+			bundle = append(bundle, '\n')
+		}
+		bundle = append(bundle, b...)
+		return nil
+	}
+
+	if cfg.CACertSecretRef != "" {
+		objKey := ctrlclient.ObjectKey{
+			Namespace: cfg.K8sNamespace,
+			Name:      cfg.CACertSecretRef,
+		}
+		s := &v1.Secret{}
+		if err := client.Get(ctx, objKey, s); err != nil {
+			return nil, err
+		}
+
+		b, ok := s.Data[key]
+		if !ok {
+			return nil, fmt.Errorf(`%q not present in the CA secret %q`, key, objKey)
+		}
+		if err := validate(b, "secret", objKey); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.CACertConfigMapRef != "" {
+		objKey := ctrlclient.ObjectKey{
+			Namespace: cfg.K8sNamespace,
+			Name:      cfg.CACertConfigMapRef,
+		}
+		cm := &v1.ConfigMap{}
+		if err := client.Get(ctx, objKey, cm); err != nil {
+			return nil, err
+		}
+
+		var b []byte
+		if v, ok := cm.Data[key]; ok {
+			b = []byte(v)
+		} else if b, ok = cm.BinaryData[key]; !ok {
+			return nil, fmt.Errorf(`%q not present in the CA configmap %q`, key, objKey)
+		}
+		if err := validate(b, "configmap", objKey); err != nil {
+			return nil, err
+		}
+	}
+
+	return bundle, nil
+}
+
+// withClockSkewObserver returns a shallow clone of c that observes the clock
+// skew between the Operator and Vault from every response. The response
+// callbacks are not inherited by namespaced clones, the skew is still observed
+// from the parent client's login and token renewal requests.
+func withClockSkewObserver(c *api.Client) *api.Client {
+	source := clockskew.SourceVaultPrefix + c.Address()
+	return c.WithResponseCallbacks(func(resp *api.Response) {
+		// the request's start time is unknown, the round-trip time is
+		// covered by the tracker's threshold.
+		clockskew.DefaultTracker.ObserveResponse(source, time.Now(), resp.Response)
+	})
 }
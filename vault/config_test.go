@@ -98,6 +98,15 @@ func TestMakeVaultClient(t *testing.T) {
 			CACert:        nil,
 			expectedError: nil,
 		},
+		"headers can override the User-Agent": {
+			vaultConfig: &ClientConfig{
+				Headers: map[string]string{
+					"user-agent": "custom",
+				},
+			},
+			CACert:        nil,
+			expectedError: nil,
+		},
 		"headers can't override namespace": {
 			vaultConfig: &ClientConfig{
 				Headers: map[string]string{
@@ -175,10 +184,112 @@ func TestMakeVaultClient(t *testing.T) {
 	}
 }
 
+func TestLoadCACertBundle(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	caSecret, err := generateCA()
+	require.NoError(t, err)
+	caConfigMap, err := generateCA()
+	require.NoError(t, err)
+
+	secret := &corev1.Secret{
+		ObjectMeta: v1.ObjectMeta{Name: "vault-cert", Namespace: "vault"},
+		Data:       map[string][]byte{consts.TLSSecretCAKey: caSecret},
+	}
+	tests := []struct {
+		name      string
+		cfg       *ClientConfig
+		configMap *corev1.ConfigMap
+		want      []byte
+		wantErr   string
+	}{
+		{
+			name: "none",
+			cfg:  &ClientConfig{K8sNamespace: "vault"},
+		},
+		{
+			name: "secret",
+			cfg:  &ClientConfig{K8sNamespace: "vault", CACertSecretRef: "vault-cert"},
+			want: caSecret,
+		},
+		{
+			name: "configmap",
+			cfg:  &ClientConfig{K8sNamespace: "vault", CACertConfigMapRef: "vault-ca"},
+			configMap: &corev1.ConfigMap{
+				ObjectMeta: v1.ObjectMeta{Name: "vault-ca", Namespace: "vault"},
+				Data:       map[string]string{consts.TLSSecretCAKey: string(caConfigMap)},
+			},
+			want: caConfigMap,
+		},
+		{
+			name: "configmap-binary-data",
+			cfg:  &ClientConfig{K8sNamespace: "vault", CACertConfigMapRef: "vault-ca"},
+			configMap: &corev1.ConfigMap{
+				ObjectMeta: v1.ObjectMeta{Name: "vault-ca", Namespace: "vault"},
+				BinaryData: map[string][]byte{consts.TLSSecretCAKey: caConfigMap},
+			},
+			want: caConfigMap,
+		},
+		{
+			name: "secret-and-configmap",
+			cfg: &ClientConfig{
+				K8sNamespace:       "vault",
+				CACertSecretRef:    "vault-cert",
+				CACertConfigMapRef: "vault-ca",
+			},
+			configMap: &corev1.ConfigMap{
+				ObjectMeta: v1.ObjectMeta{Name: "vault-ca", Namespace: "vault"},
+				Data:       map[string]string{consts.TLSSecretCAKey: string(caConfigMap)},
+			},
+			want: append(append([]byte{}, caSecret...), caConfigMap...),
+		},
+		{
+			name: "configmap-missing-key",
+			cfg:  &ClientConfig{K8sNamespace: "vault", CACertConfigMapRef: "vault-ca"},
+			configMap: &corev1.ConfigMap{
+				ObjectMeta: v1.ObjectMeta{Name: "vault-ca", Namespace: "vault"},
+			},
+			wantErr: `"ca.crt" not present in the CA configmap "vault/vault-ca"`,
+		},
+		{
+			name: "configmap-invalid",
+			cfg:  &ClientConfig{K8sNamespace: "vault", CACertConfigMapRef: "vault-ca"},
+			configMap: &corev1.ConfigMap{
+				ObjectMeta: v1.ObjectMeta{Name: "vault-ca", Namespace: "vault"},
+				Data:       map[string]string{consts.TLSSecretCAKey: "invalid"},
+			},
+			wantErr: `no valid certificates found for key "ca.crt" in CA configmap "vault/vault-ca"`,
+		},
+		{
+			name:    "configmap-not-found",
+			cfg:     &ClientConfig{K8sNamespace: "vault", CACertConfigMapRef: "vault-ca"},
+			wantErr: `configmaps "vault-ca" not found`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := fake.NewClientBuilder().WithObjects(secret.DeepCopy())
+			if tt.configMap != nil {
+				builder = builder.WithObjects(tt.configMap)
+			}
+
+			got, err := LoadCACertBundle(ctx, builder.Build(), tt.cfg)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func makeVaultHttpHeaders(t *testing.T, namespace string, headers map[string]string) http.Header {
 	t.Helper()
 
 	h := make(http.Header)
+	h.Set(HeaderUserAgent, DefaultUserAgent)
 	for k, v := range headers {
 		h.Set(k, v)
 	}
@@ -0,0 +1,206 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package vault
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// DefaultHedgeDelay is the delay after which a read request is hedged, if
+// none is configured.
+const DefaultHedgeDelay = 250 * time.Millisecond
+
+var _ http.RoundTripper = (*hedgedTransport)(nil)
+
+// hedgedTransport is an http.RoundTripper that hedges idempotent GET requests.
+// The request is first sent to its original address. If no response has been
+// received after delay, or the request failed, the same request is sent to the
+// next alternate address. The first successful response wins, and all other
+// in-flight requests are canceled. All other requests are passed to base as
+// is.
+type hedgedTransport struct {
+	base       http.RoundTripper
+	delay      time.Duration
+	alternates []*url.URL
+}
+
+type hedgedResult struct {
+	idx  int
+	resp *http.Response
+	err  error
+}
+
+func (r *hedgedResult) ok() bool {
+	return r.err == nil && r.resp.StatusCode < http.StatusInternalServerError
+}
+
+func (r *hedgedResult) discard() {
+	if r.resp != nil {
+		_, _ = io.Copy(io.Discard, r.resp.Body)
+		_ = r.resp.Body.Close()
+	}
+}
+
+// cancelOnCloseBody cancels the context of the winning request once its
+// response body has been closed.
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnCloseBody) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
+
+func newHedgedTransport(base http.RoundTripper, delay time.Duration, addresses []string) (*hedgedTransport, error) {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if delay <= 0 {
+		delay = DefaultHedgeDelay
+	}
+
+	t := &hedgedTransport{
+		base:  base,
+		delay: delay,
+	}
+	for _, addr := range addresses {
+		u, err := url.Parse(addr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid alternate address %q: %w", addr, err)
+		}
+		if u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("invalid alternate address %q: scheme and host are required", addr)
+		}
+		t.alternates = append(t.alternates, u)
+	}
+
+	return t, nil
+}
+
+// hedgeable returns true if req is safe to be sent multiple times.
+func (t *hedgedTransport) hedgeable(req *http.Request) bool {
+	if req.Method != http.MethodGet || len(t.alternates) == 0 {
+		return false
+	}
+	if req.Body != nil && req.Body != http.NoBody {
+		return false
+	}
+	// never hedge protocol upgrades, e.g. the Vault event stream's websocket.
+	return req.Header.Get("Upgrade") == ""
+}
+
+func (t *hedgedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.hedgeable(req) {
+		return t.base.RoundTrip(req)
+	}
+
+	ctx := req.Context()
+	results := make(chan *hedgedResult, len(t.alternates)+1)
+	var cancels []context.CancelFunc
+	send := func(r *http.Request) {
+		attemptCtx, cancel := context.WithCancel(ctx)
+		idx := len(cancels)
+		cancels = append(cancels, cancel)
+		go func() {
+			resp, err := t.base.RoundTrip(r.WithContext(attemptCtx))
+			results <- &hedgedResult{idx: idx, resp: resp, err: err}
+		}()
+	}
+	// finish cancels all the attempts other than the winner, and discards their
+	// responses in the background.
+	finish := func(winner, pending int) {
+		for i, cancel := range cancels {
+			if i != winner {
+				cancel()
+			}
+		}
+		if pending > 0 {
+			go func() {
+				for i := 0; i < pending; i++ {
+					(<-results).discard()
+				}
+			}()
+		}
+	}
+
+	send(req)
+	pending := 1
+	next := 0
+	hedge := func() {
+		if next < len(t.alternates) {
+			send(t.alternateRequest(req, t.alternates[next]))
+			next++
+			pending++
+		}
+	}
+
+	timer := time.NewTimer(t.delay)
+	defer timer.Stop()
+
+	var last *hedgedResult
+	for {
+		select {
+		case <-ctx.Done():
+			if last != nil {
+				last.discard()
+			}
+			finish(-1, pending)
+			return nil, ctx.Err()
+		case <-timer.C:
+			hedge()
+			if next < len(t.alternates) {
+				timer.Reset(t.delay)
+			}
+		case res := <-results:
+			pending--
+			if res.ok() {
+				if last != nil {
+					last.discard()
+				}
+				finish(res.idx, pending)
+				res.resp.Body = &cancelOnCloseBody{
+					ReadCloser: res.resp.Body,
+					cancel:     cancels[res.idx],
+				}
+				return res.resp, res.err
+			}
+
+			if last != nil {
+				last.discard()
+				cancels[last.idx]()
+			}
+			last = res
+			// hedge right away, rather than waiting for the delay to expire.
+			hedge()
+			if pending == 0 {
+				finish(res.idx, pending)
+				if res.resp != nil {
+					res.resp.Body = &cancelOnCloseBody{
+						ReadCloser: res.resp.Body,
+						cancel:     cancels[res.idx],
+					}
+				} else {
+					cancels[res.idx]()
+				}
+				return res.resp, res.err
+			}
+		}
+	}
+}
+
+// alternateRequest returns a copy of req that is sent to the alternate address.
+func (t *hedgedTransport) alternateRequest(req *http.Request, alternate *url.URL) *http.Request {
+	r := req.Clone(req.Context())
+	r.URL.Scheme = alternate.Scheme
+	r.URL.Host = alternate.Host
+	r.Host = ""
+	return r
+}
@@ -0,0 +1,124 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package vault
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_hedgedTransport_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	newServer := func(t *testing.T, delay time.Duration, status int, body string) (*httptest.Server, *atomic.Int32) {
+		t.Helper()
+		var count atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			count.Add(1)
+			select {
+			case <-time.After(delay):
+			case <-r.Context().Done():
+				return
+			}
+			w.WriteHeader(status)
+			_, _ = io.WriteString(w, body)
+		}))
+		t.Cleanup(srv.Close)
+		return srv, &count
+	}
+
+	tests := []struct {
+		name           string
+		method         string
+		body           io.Reader
+		primaryDelay   time.Duration
+		primaryStatus  int
+		wantStatus     int
+		wantBody       string
+		wantAlternates int32
+	}{
+		{
+			name:          "primary-fast",
+			method:        http.MethodGet,
+			primaryStatus: http.StatusOK,
+			wantStatus:    http.StatusOK,
+			wantBody:      "primary",
+		},
+		{
+			name:           "primary-slow",
+			method:         http.MethodGet,
+			primaryDelay:   5 * time.Second,
+			primaryStatus:  http.StatusOK,
+			wantStatus:     http.StatusOK,
+			wantBody:       "alternate",
+			wantAlternates: 1,
+		},
+		{
+			name:           "primary-error",
+			method:         http.MethodGet,
+			primaryStatus:  http.StatusServiceUnavailable,
+			wantStatus:     http.StatusOK,
+			wantBody:       "alternate",
+			wantAlternates: 1,
+		},
+		{
+			name:          "not-hedgeable",
+			method:        http.MethodPut,
+			body:          strings.NewReader("{}"),
+			primaryDelay:  200 * time.Millisecond,
+			primaryStatus: http.StatusOK,
+			wantStatus:    http.StatusOK,
+			wantBody:      "primary",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			primary, _ := newServer(t, tt.primaryDelay, tt.primaryStatus, "primary")
+			alternate, alternateCount := newServer(t, 0, http.StatusOK, "alternate")
+
+			transport, err := newHedgedTransport(http.DefaultTransport.(*http.Transport).Clone(),
+				10*time.Millisecond, []string{alternate.URL})
+			require.NoError(t, err)
+
+			req, err := http.NewRequestWithContext(context.Background(),
+				tt.method, primary.URL+"/v1/secret/foo", tt.body)
+			require.NoError(t, err)
+
+			resp, err := transport.RoundTrip(req)
+			require.NoError(t, err)
+			b, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			assert.Equal(t, tt.wantBody, string(b))
+			assert.Equal(t, tt.wantAlternates, alternateCount.Load())
+		})
+	}
+}
+
+func Test_newHedgedTransport(t *testing.T) {
+	t.Parallel()
+
+	_, err := newHedgedTransport(nil, 0, []string{"vault.example.com"})
+	assert.EqualError(t, err,
+		`invalid alternate address "vault.example.com": scheme and host are required`)
+
+	transport, err := newHedgedTransport(nil, 0, []string{"https://vault.example.com:8200"})
+	require.NoError(t, err)
+	assert.Equal(t, DefaultHedgeDelay, transport.delay)
+	assert.Equal(t, http.DefaultTransport, transport.base)
+	assert.Equal(t, "vault.example.com:8200", transport.alternates[0].Host)
+}
@@ -0,0 +1,118 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package vault
+
+import (
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// DefaultTokenRenewFraction is the fraction of a Client token's TTL after which
+// it is renewed by default, it is the same as that of the api.LifetimeWatcher.
+const DefaultTokenRenewFraction = 2.0 / 3.0
+
+const (
+	tokenRenewalResultRenewed         = "renewed"
+	tokenRenewalResultReauthenticated = "reauthenticated"
+	tokenRenewalResultFailed          = "failed"
+)
+
+var errLifetimeWatcherStopped = errors.New("lifetime watcher stopped")
+
+// lifetimeRenewFunc renews the token of the lifetimeWatcher, or re-authenticates
+// when reauth is true. It returns the TTL of the resulting token, and whether
+// the next renewal must be a re-authentication, e.g. since the token could not
+// be extended any further.
+type lifetimeRenewFunc func(reauth bool) (ttl time.Duration, reauthNext bool, err error)
+
+// lifetimeWatcher manages the lifetime of a Client's token in the background.
+// The token is renewed once a fraction of its TTL has elapsed, rather than when
+// the Client is next requested. The watcher is done when a renewal fails for
+// good, or when it is stopped.
+type lifetimeWatcher struct {
+	fraction float64
+	stopCh   chan struct{}
+	doneCh   chan error
+	stopOnce sync.Once
+}
+
+func newLifetimeWatcher(fraction float64) *lifetimeWatcher {
+	if fraction <= 0 || fraction >= 1 {
+		fraction = DefaultTokenRenewFraction
+	}
+
+	return &lifetimeWatcher{
+		fraction: fraction,
+		stopCh:   make(chan struct{}),
+		doneCh:   make(chan error, 1),
+	}
+}
+
+// Stop the watcher, it is safe to be called multiple times.
+func (w *lifetimeWatcher) Stop() {
+	w.stopOnce.Do(func() {
+		if w.stopCh != nil {
+			close(w.stopCh)
+		}
+	})
+}
+
+// DoneCh returns the channel that receives the watcher's final error, it
+// receives nil when the watcher was stopped.
+func (w *lifetimeWatcher) DoneCh() <-chan error {
+	return w.doneCh
+}
+
+func (w *lifetimeWatcher) stopped() bool {
+	select {
+	case <-w.stopCh:
+		return true
+	default:
+		return false
+	}
+}
+
+// sleepDuration returns the duration to wait before renewing a token with ttl.
+// Up to 10% of jitter is subtracted, so that the tokens that were issued
+// together, e.g. upon a restart, are not all renewed at once.
+func (w *lifetimeWatcher) sleepDuration(ttl time.Duration) time.Duration {
+	d := time.Duration(float64(ttl) * w.fraction)
+	return d - time.Duration(rand.Int63n(int64(d)/10+1))
+}
+
+// run the watcher until it is stopped, or until renew fails. It blocks, so it
+// should be called in a Go routine.
+func (w *lifetimeWatcher) run(ttl time.Duration, renew lifetimeRenewFunc) {
+	var reauth bool
+	for {
+		if ttl <= 0 {
+			// the token never expires, nothing left to manage.
+			<-w.stopCh
+			w.doneCh <- nil
+			return
+		}
+
+		timer := time.NewTimer(w.sleepDuration(ttl))
+		select {
+		case <-w.stopCh:
+			timer.Stop()
+			w.doneCh <- nil
+			return
+		case <-timer.C:
+		}
+
+		var err error
+		ttl, reauth, err = renew(reauth)
+		if errors.Is(err, errLifetimeWatcherStopped) {
+			w.doneCh <- nil
+			return
+		}
+		if err != nil {
+			w.doneCh <- err
+			return
+		}
+	}
+}
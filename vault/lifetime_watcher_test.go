@@ -0,0 +1,219 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package vault
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/credentials/vault"
+	vaultcredsconsts "github.com/hashicorp/vault-secrets-operator/credentials/vault/consts"
+)
+
+func Test_lifetimeWatcher_sleepDuration(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		fraction float64
+		ttl      time.Duration
+		wantMin  time.Duration
+		wantMax  time.Duration
+	}{
+		{
+			name:     "default",
+			fraction: 0,
+			ttl:      30 * time.Minute,
+			wantMin:  18 * time.Minute,
+			wantMax:  20 * time.Minute,
+		},
+		{
+			name:     "half",
+			fraction: .5,
+			ttl:      30 * time.Minute,
+			wantMin:  13*time.Minute + 30*time.Second,
+			wantMax:  15 * time.Minute,
+		},
+		{
+			name:     "invalid",
+			fraction: 1,
+			ttl:      30 * time.Minute,
+			wantMin:  18 * time.Minute,
+			wantMax:  20 * time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := newLifetimeWatcher(tt.fraction)
+			for i := 0; i < 100; i++ {
+				got := w.sleepDuration(tt.ttl)
+				assert.GreaterOrEqual(t, got, tt.wantMin)
+				assert.LessOrEqual(t, got, tt.wantMax)
+			}
+		})
+	}
+}
+
+func Test_lifetimeWatcher_run(t *testing.T) {
+	t.Parallel()
+
+	t.Run("renew-until-failure", func(t *testing.T) {
+		var calls []bool
+		w := newLifetimeWatcher(.5)
+		go w.run(10*time.Millisecond, func(reauth bool) (time.Duration, bool, error) {
+			calls = append(calls, reauth)
+			switch len(calls) {
+			case 1:
+				// the token was not fully extended.
+				return 5 * time.Millisecond, true, nil
+			case 2:
+				return 10 * time.Millisecond, false, nil
+			default:
+				return 0, false, errors.New("login failed")
+			}
+		})
+
+		select {
+		case err := <-w.DoneCh():
+			assert.EqualError(t, err, "login failed")
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for the watcher")
+		}
+		assert.Equal(t, []bool{false, true, false}, calls)
+	})
+
+	t.Run("stopped", func(t *testing.T) {
+		w := newLifetimeWatcher(.5)
+		go w.run(time.Hour, func(bool) (time.Duration, bool, error) {
+			t.Error("unexpected renewal")
+			return 0, false, nil
+		})
+		w.Stop()
+		w.Stop()
+
+		select {
+		case err := <-w.DoneCh():
+			assert.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for the watcher")
+		}
+	})
+}
+
+func Test_defaultClient_renewInBackground(t *testing.T) {
+	t.Parallel()
+
+	var renewals, logins int
+	var revoked []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/v1/auth/token/revoke-self":
+			revoked = append(revoked, req.Header.Get("X-Vault-Token"))
+			w.WriteHeader(http.StatusNoContent)
+		case "/v1/auth/token/renew-self":
+			renewals++
+			if renewals > 1 {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+				return
+			}
+			// the token is nearing its max TTL.
+			_, _ = w.Write([]byte(`{"auth":{"client_token":"token","accessor":"accessor","lease_duration":30,"renewable":true}}`))
+		case "/v1/auth/userpass/login/alice":
+			logins++
+			_, _ = w.Write([]byte(`{"auth":{"client_token":"token2","accessor":"accessor2","lease_duration":60,"renewable":true}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	config := api.DefaultConfig()
+	config.Address = srv.URL
+	client, err := api.NewClient(config)
+	require.NoError(t, err)
+	client.SetToken("token")
+
+	authObj := &secretsv1beta1.VaultAuth{
+		Spec: secretsv1beta1.VaultAuthSpec{
+			Method: vaultcredsconsts.ProviderMethodUserPass,
+			Mount:  "userpass",
+			UserPass: &secretsv1beta1.VaultAuthConfigUserPass{
+				Username:  "alice",
+				SecretRef: "userpass",
+			},
+		},
+	}
+	ctx := context.Background()
+	k8sClient := fake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "userpass",
+			Namespace: "foo",
+		},
+		Data: map[string][]byte{
+			"password": []byte("secret"),
+		},
+	}).Build()
+	p := &vault.UserPassCredentialProvider{}
+	require.NoError(t, p.Init(ctx, k8sClient, authObj, "foo"))
+
+	watcherDoneCh := make(chan *ClientCallbackHandlerRequest, 1)
+	c := &defaultClient{
+		client:             client,
+		authObj:            authObj,
+		credentialProvider: p,
+		ctrlClient:         k8sClient,
+		watcherDoneCh:      watcherDoneCh,
+		authSecret: &api.Secret{
+			Auth: &api.SecretAuth{
+				ClientToken:   "token",
+				Accessor:      "accessor",
+				LeaseDuration: 60,
+				Renewable:     true,
+			},
+		},
+	}
+	w := newLifetimeWatcher(0)
+
+	ttl, reauth, err := c.renewInBackground(ctx, w, false)
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Second, ttl)
+	assert.True(t, reauth, "expected a re-authentication, the TTL was not fully extended")
+	assert.Equal(t, "token", client.Token())
+	assert.Empty(t, revoked)
+	assert.Empty(t, watcherDoneCh)
+
+	ttl, reauth, err = c.renewInBackground(ctx, w, false)
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, ttl)
+	assert.False(t, reauth)
+	assert.Equal(t, 2, renewals)
+	assert.Equal(t, 1, logins)
+	assert.Equal(t, "token2", client.Token())
+	assert.Equal(t, "token2", c.GetTokenSecret().Auth.ClientToken)
+	// the replaced token is revoked, and the factory is notified.
+	assert.Equal(t, []string{"token"}, revoked)
+	if assert.Len(t, watcherDoneCh, 1) {
+		req := <-watcherDoneCh
+		assert.Equal(t, ClientCallbackOnReauthenticated, req.On)
+		assert.Equal(t, c, req.Client)
+	}
+
+	w.Stop()
+	_, _, err = c.renewInBackground(ctx, w, true)
+	assert.ErrorIs(t, err, errLifetimeWatcherStopped)
+	assert.Equal(t, 1, logins)
+}
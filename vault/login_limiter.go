@@ -0,0 +1,118 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package vault
+
+import (
+	"context"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+)
+
+// loginSemaphore is a counting semaphore of a fixed size.
+type loginSemaphore chan struct{}
+
+func (s loginSemaphore) acquire(ctx context.Context) error {
+	select {
+	case s <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s loginSemaphore) release() {
+	<-s
+}
+
+// LoginLimiter limits the number of simultaneous logins to Vault, both
+// globally, and per VaultAuth as set in its Spec.MaxConcurrentLogins.
+type LoginLimiter struct {
+	global  loginSemaphore
+	perAuth map[types.UID]loginSemaphore
+	mu      sync.Mutex
+}
+
+// NewLoginLimiter returns a LoginLimiter that allows at most maxLogins
+// simultaneous logins, across all VaultAuths. No global limit is applied when
+// maxLogins is not positive.
+func NewLoginLimiter(maxLogins int) *LoginLimiter {
+	l := &LoginLimiter{
+		perAuth: make(map[types.UID]loginSemaphore),
+	}
+	if maxLogins > 0 {
+		l.global = make(loginSemaphore, maxLogins)
+	}
+	return l
+}
+
+// Acquire blocks until a login with authObj is allowed, or until ctx is done.
+// Upon success, the returned func must be called once the login completes.
+func (l *LoginLimiter) Acquire(ctx context.Context, authObj *secretsv1beta1.VaultAuth) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	sems := make([]loginSemaphore, 0, 2)
+	if s := l.authSemaphore(authObj); s != nil {
+		sems = append(sems, s)
+	}
+	if l.global != nil {
+		sems = append(sems, l.global)
+	}
+
+	// the VaultAuth's semaphore is always acquired first, such that logins that
+	// are waiting on their VaultAuth's limit do not hold a global slot.
+	for i, s := range sems {
+		if err := s.acquire(ctx); err != nil {
+			for _, acquired := range sems[:i] {
+				acquired.release()
+			}
+			return nil, err
+		}
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			for _, s := range sems {
+				s.release()
+			}
+		})
+	}, nil
+}
+
+// authSemaphore returns the semaphore of authObj, or nil if its logins are not
+// limited. The semaphore is replaced whenever the VaultAuth's limit changes,
+// logins that hold a slot of the previous one release it as usual.
+func (l *LoginLimiter) authSemaphore(authObj *secretsv1beta1.VaultAuth) loginSemaphore {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	size := authObj.Spec.MaxConcurrentLogins
+	if size <= 0 {
+		delete(l.perAuth, authObj.UID)
+		return nil
+	}
+
+	s, ok := l.perAuth[authObj.UID]
+	if !ok || cap(s) != size {
+		s = make(loginSemaphore, size)
+		l.perAuth[authObj.UID] = s
+	}
+	return s
+}
+
+// Remove the semaphore of the VaultAuth with uid, e.g. after it was deleted.
+func (l *LoginLimiter) Remove(uid types.UID) {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.perAuth, uid)
+}
@@ -0,0 +1,114 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package vault
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+)
+
+func newLimitedVaultAuth(uid types.UID, maxLogins int) *secretsv1beta1.VaultAuth {
+	return &secretsv1beta1.VaultAuth{
+		ObjectMeta: metav1.ObjectMeta{
+			UID: uid,
+		},
+		Spec: secretsv1beta1.VaultAuthSpec{
+			MaxConcurrentLogins: maxLogins,
+		},
+	}
+}
+
+// assertBlocked asserts that acquiring a login slot for authObj blocks.
+func assertBlocked(t *testing.T, l *LoginLimiter, authObj *secretsv1beta1.VaultAuth) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := l.Acquire(ctx, authObj)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestLoginLimiter_Acquire(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	t.Run("nil", func(t *testing.T) {
+		t.Parallel()
+
+		var l *LoginLimiter
+		release, err := l.Acquire(ctx, newLimitedVaultAuth("a", 1))
+		require.NoError(t, err)
+		release()
+	})
+
+	t.Run("per-auth", func(t *testing.T) {
+		t.Parallel()
+
+		l := NewLoginLimiter(0)
+		a := newLimitedVaultAuth("a", 1)
+		release, err := l.Acquire(ctx, a)
+		require.NoError(t, err)
+		assertBlocked(t, l, a)
+
+		// other VaultAuths are not limited by a's semaphore.
+		for i := 0; i < 2; i++ {
+			_, err := l.Acquire(ctx, newLimitedVaultAuth("b", 0))
+			require.NoError(t, err)
+		}
+
+		release()
+		// releasing twice is a no-op.
+		release()
+		release, err = l.Acquire(ctx, a)
+		require.NoError(t, err)
+		assertBlocked(t, l, a)
+
+		// the semaphore is replaced when the limit changes.
+		a.Spec.MaxConcurrentLogins = 2
+		_, err = l.Acquire(ctx, a)
+		require.NoError(t, err)
+		release()
+	})
+
+	t.Run("global", func(t *testing.T) {
+		t.Parallel()
+
+		l := NewLoginLimiter(1)
+		a := newLimitedVaultAuth("a", 0)
+		b := newLimitedVaultAuth("b", 1)
+		release, err := l.Acquire(ctx, a)
+		require.NoError(t, err)
+		assertBlocked(t, l, a)
+		assertBlocked(t, l, b)
+
+		// the failed acquisition did not leak b's slot.
+		release()
+		release, err = l.Acquire(ctx, b)
+		require.NoError(t, err)
+		assertBlocked(t, l, a)
+		release()
+	})
+}
+
+func TestLoginLimiter_Remove(t *testing.T) {
+	t.Parallel()
+
+	l := NewLoginLimiter(0)
+	a := newLimitedVaultAuth("a", 1)
+	_, err := l.Acquire(context.Background(), a)
+	require.NoError(t, err)
+	assert.Len(t, l.perAuth, 1)
+
+	l.Remove(a.UID)
+	assert.Empty(t, l.perAuth)
+	_, err = l.Acquire(context.Background(), a)
+	require.NoError(t, err)
+}
@@ -0,0 +1,192 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package vault
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/template"
+)
+
+// loginParamsInput is the input of the VaultAuth's Params templates.
+type loginParamsInput struct {
+	// Namespace of the Kubernetes resources that authenticate with the
+	// VaultAuth, e.g. the namespace of its ServiceAccount.
+	Namespace string
+	// ServiceAccount configured in the VaultAuth's method, if any.
+	ServiceAccount string
+	// Method of the VaultAuth.
+	Method string
+	// Mount of the VaultAuth's method.
+	Mount string
+	// Labels of the VaultAuth.
+	Labels map[string]string
+	// Annotations of the VaultAuth.
+	Annotations map[string]string
+}
+
+// authServiceAccount returns the name of the ServiceAccount that is configured
+// in the auth method of authObj, if any.
+func authServiceAccount(authObj *secretsv1beta1.VaultAuth) string {
+	switch {
+	case authObj.Spec.Kubernetes != nil:
+		return authObj.Spec.Kubernetes.ServiceAccount
+	case authObj.Spec.JWT != nil:
+		return authObj.Spec.JWT.ServiceAccount
+	case authObj.Spec.AWS != nil:
+		return authObj.Spec.AWS.IRSAServiceAccount
+	case authObj.Spec.GCP != nil:
+		return authObj.Spec.GCP.WorkloadIdentityServiceAccount
+	case authObj.Spec.Azure != nil:
+		return authObj.Spec.Azure.WorkloadIdentityServiceAccount
+	default:
+		return ""
+	}
+}
+
+const (
+	loginParamAudience      = "audience"
+	loginParamTokenTTL      = "token_ttl"
+	loginParamTokenPolicies = "token_policies"
+)
+
+// RenderLoginParams renders the Params and the LoginParams templates of
+// authObj. The templates have access to the providerNamespace, and to the
+// ServiceAccount and the metadata of authObj.
+func RenderLoginParams(authObj *secretsv1beta1.VaultAuth, providerNamespace string) (map[string]any, error) {
+	if len(authObj.Spec.Params) == 0 && authObj.Spec.LoginParams == nil {
+		return nil, nil
+	}
+
+	input := &loginParamsInput{
+		Namespace:      providerNamespace,
+		ServiceAccount: authServiceAccount(authObj),
+		Method:         authObj.Spec.Method,
+		Mount:          authObj.Spec.Mount,
+		Labels:         authObj.GetLabels(),
+		Annotations:    authObj.GetAnnotations(),
+	}
+
+	ret := make(map[string]any, len(authObj.Spec.Params))
+	for _, k := range slices.Sorted(maps.Keys(authObj.Spec.Params)) {
+		if k == "" {
+			return nil, fmt.Errorf("invalid empty params key")
+		}
+
+		v, err := renderLoginParam(k, authObj.Spec.Params[k], input)
+		if err != nil {
+			return nil, err
+		}
+		ret[k] = v
+	}
+
+	if p := authObj.Spec.LoginParams; p != nil {
+		set := func(k string, v any) error {
+			if _, ok := ret[k]; ok {
+				return fmt.Errorf("params %q is already set by loginParams", k)
+			}
+			ret[k] = v
+			return nil
+		}
+
+		if len(p.Audience) > 0 {
+			v, err := renderLoginParamList(loginParamAudience, p.Audience, input)
+			if err != nil {
+				return nil, err
+			}
+			if len(v) > 0 {
+				if err := set(loginParamAudience, v); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if p.TokenTTL != "" {
+			v, err := renderLoginParam(loginParamTokenTTL, p.TokenTTL, input)
+			if err != nil {
+				return nil, err
+			}
+			ttl, err := time.ParseDuration(v)
+			if err != nil || ttl <= 0 {
+				return nil, fmt.Errorf("invalid loginParams tokenTTL %q", v)
+			}
+			if err := set(loginParamTokenTTL, int64(ttl.Seconds())); err != nil {
+				return nil, err
+			}
+		}
+		if len(p.TokenPolicies) > 0 {
+			v, err := renderLoginParamList(loginParamTokenPolicies, p.TokenPolicies, input)
+			if err != nil {
+				return nil, err
+			}
+			if len(v) > 0 {
+				if err := set(loginParamTokenPolicies, v); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	return ret, nil
+}
+
+func renderLoginParam(k, text string, input *loginParamsInput) (string, error) {
+	t := template.NewSecretTemplate(k)
+	if err := t.Parse(k, text); err != nil {
+		return "", fmt.Errorf("invalid params template %q: %w", k, err)
+	}
+
+	b, err := t.ExecuteTemplate(k, input)
+	if err != nil {
+		return "", fmt.Errorf("failed to render params template %q: %w", k, err)
+	}
+	return string(b), nil
+}
+
+// renderLoginParamList renders each of the templates in texts, the empty
+// values are omitted.
+func renderLoginParamList(k string, texts []string, input *loginParamsInput) ([]string, error) {
+	var ret []string
+	for _, text := range texts {
+		v, err := renderLoginParam(k, text, input)
+		if err != nil {
+			return nil, err
+		}
+		if v != "" {
+			ret = append(ret, v)
+		}
+	}
+	return ret, nil
+}
+
+// ValidateLoginParams returns an error if any of the Params templates of
+// authObj cannot be rendered.
+func ValidateLoginParams(authObj *secretsv1beta1.VaultAuth) error {
+	_, err := RenderLoginParams(authObj, authObj.Namespace)
+	return err
+}
+
+// mergeLoginParams returns the login request parameters made of the
+// credentials of the auth method, and of the extra params. The params may not
+// override any of the credentials.
+func mergeLoginParams(creds, params map[string]any) (map[string]any, error) {
+	if len(params) == 0 {
+		return creds, nil
+	}
+
+	ret := maps.Clone(creds)
+	if ret == nil {
+		ret = make(map[string]any, len(params))
+	}
+	for _, k := range slices.Sorted(maps.Keys(params)) {
+		if _, ok := ret[k]; ok {
+			return nil, fmt.Errorf("params key %q conflicts with a login parameter of the auth method", k)
+		}
+		ret[k] = params[k]
+	}
+	return ret, nil
+}
@@ -0,0 +1,166 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package vault
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+)
+
+func TestRenderLoginParams(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		spec    secretsv1beta1.VaultAuthSpec
+		want    map[string]any
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "no-params",
+			wantErr: assert.NoError,
+		},
+		{
+			name: "kubernetes",
+			spec: secretsv1beta1.VaultAuthSpec{
+				Method: "kubernetes",
+				Mount:  "k8s",
+				Kubernetes: &secretsv1beta1.VaultAuthConfigKubernetes{
+					ServiceAccount: "app",
+				},
+				Params: map[string]string{
+					"static":  "value",
+					"subject": "{{ .Namespace }}/{{ .ServiceAccount }}",
+					"team":    `{{ index .Labels "team" }}@{{ .Method }}/{{ .Mount }}`,
+				},
+			},
+			want: map[string]any{
+				"static":  "value",
+				"subject": "tenant/app",
+				"team":    "payments@kubernetes/k8s",
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "invalid-template",
+			spec: secretsv1beta1.VaultAuthSpec{
+				Params: map[string]string{
+					"subject": "{{ .Namespace ",
+				},
+			},
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.ErrorContains(t, err, `invalid params template "subject"`, i...)
+			},
+		},
+		{
+			name: "login-params",
+			spec: secretsv1beta1.VaultAuthSpec{
+				Params: map[string]string{
+					"static": "value",
+				},
+				LoginParams: &secretsv1beta1.VaultAuthLoginParams{
+					Audience:      []string{"vault", "{{ .Namespace }}"},
+					TokenTTL:      "5m",
+					TokenPolicies: []string{"{{ .Namespace }}-read", `{{ index .Labels "missing" }}`},
+				},
+			},
+			want: map[string]any{
+				"static":         "value",
+				"audience":       []string{"vault", "tenant"},
+				"token_ttl":      int64(300),
+				"token_policies": []string{"tenant-read"},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "login-params-invalid-ttl",
+			spec: secretsv1beta1.VaultAuthSpec{
+				LoginParams: &secretsv1beta1.VaultAuthLoginParams{
+					TokenTTL: "forever",
+				},
+			},
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.EqualError(t, err, `invalid loginParams tokenTTL "forever"`, i...)
+			},
+		},
+		{
+			name: "login-params-conflict",
+			spec: secretsv1beta1.VaultAuthSpec{
+				Params: map[string]string{
+					"token_policies": "admin",
+				},
+				LoginParams: &secretsv1beta1.VaultAuthLoginParams{
+					TokenPolicies: []string{"read"},
+				},
+			},
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.EqualError(t, err, `params "token_policies" is already set by loginParams`, i...)
+			},
+		},
+		{
+			name: "empty-key",
+			spec: secretsv1beta1.VaultAuthSpec{
+				Params: map[string]string{
+					"": "value",
+				},
+			},
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.EqualError(t, err, "invalid empty params key", i...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			authObj := &secretsv1beta1.VaultAuth{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "auth",
+					Namespace: "vso",
+					Labels: map[string]string{
+						"team": "payments",
+					},
+				},
+				Spec: tt.spec,
+			}
+			got, err := RenderLoginParams(authObj, "tenant")
+			if !tt.wantErr(t, err) {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_mergeLoginParams(t *testing.T) {
+	t.Parallel()
+
+	creds := map[string]any{
+		"role": "app",
+		"jwt":  "token",
+	}
+	got, err := mergeLoginParams(creds, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, creds, got)
+
+	got, err = mergeLoginParams(creds, map[string]any{
+		"tenant": "payments",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"role":   "app",
+		"jwt":    "token",
+		"tenant": "payments",
+	}, got)
+	assert.NotContains(t, creds, "tenant")
+
+	_, err = mergeLoginParams(creds, map[string]any{
+		"role": "admin",
+	})
+	assert.EqualError(t, err, `params key "role" conflicts with a login parameter of the auth method`)
+}
@@ -0,0 +1,161 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package vault
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/vault/api"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/hashicorp/vault-secrets-operator/consts"
+	"github.com/hashicorp/vault-secrets-operator/helpers"
+)
+
+const defaultMFAPushTimeout = 2 * time.Minute
+
+// validateMFA validates the Login MFA requirement of a login, with the MFA
+// configuration of the VaultAuth, and returns the auth secret of the login.
+// The validation is retried with backoff until the PushTimeout has elapsed when
+// only push methods are validated, since they may still await their approval.
+func (c *defaultClient) validateMFA(ctx context.Context, client ctrlclient.Client, req *api.MFARequirement) (*api.Secret, error) {
+	cfg := c.authObj.Spec.MFA
+	if cfg == nil {
+		return nil, fmt.Errorf("the login requires MFA, but mfa is not configured")
+	}
+
+	var passcodes map[string][]byte
+	if cfg.SecretRef != "" {
+		key := ctrlclient.ObjectKey{
+			Namespace: c.credentialProvider.GetNamespace(),
+			Name:      cfg.SecretRef,
+		}
+		s, err := helpers.GetSecret(ctx, client, key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the MFA secret %s: %w", key, err)
+		}
+		passcodes = s.Data
+	}
+
+	payload, push, err := mfaPayload(req, passcodes)
+	if err != nil {
+		return nil, err
+	}
+
+	pushTimeout := defaultMFAPushTimeout
+	if cfg.PushTimeout != "" {
+		pushTimeout, err = time.ParseDuration(cfg.PushTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid mfa pushTimeout %q: %w", cfg.PushTimeout, err)
+		}
+	}
+
+	path := "sys/mfa/validate"
+	var secret *api.Secret
+	bo := backoff.NewExponentialBackOff()
+	bo.MaxElapsedTime = pushTimeout
+	if err := backoff.Retry(func() error {
+		resp, err := c.Write(ctx, &defaultWriteRequest{
+			path: path,
+			params: map[string]any{
+				"mfa_request_id": req.MFARequestID,
+				"mfa_payload":    payload,
+			},
+		})
+		if err != nil {
+			if !push {
+				// passcodes are not retried, they would only fail again.
+				return backoff.Permanent(err)
+			}
+			log.FromContext(ctx).V(consts.LogLevelDebug).Info(
+				"MFA validation failed, waiting for the push approval", "err", err)
+			return err
+		}
+
+		secret = resp.Secret()
+		return nil
+	}, backoff.WithContext(bo, ctx)); err != nil {
+		return nil, fmt.Errorf("failed to validate MFA: %w", err)
+	}
+
+	if secret == nil || secret.Auth == nil {
+		return nil, fmt.Errorf("empty response from Vault, path=%q", path)
+	}
+
+	return secret, nil
+}
+
+// mfaPayload returns the payload that validates req, with one method for each
+// of its constraints. A passcode method is picked if its passcode is found in
+// passcodes, keyed by the method's name or ID, otherwise a push method is
+// picked. It returns true if any push method was picked, since the validation
+// then fails until the push is approved, even if passcodes were also picked.
+func mfaPayload(req *api.MFARequirement, passcodes map[string][]byte) (map[string][]string, bool, error) {
+	if req == nil || req.MFARequestID == "" {
+		return nil, false, fmt.Errorf("invalid MFA requirement, empty request ID")
+	}
+
+	payload := make(map[string][]string, len(req.MFAConstraints))
+	var push bool
+	for _, name := range slices.Sorted(maps.Keys(req.MFAConstraints)) {
+		constraint := req.MFAConstraints[name]
+		if constraint == nil {
+			continue
+		}
+
+		var pushMethod *api.MFAMethodID
+		var found bool
+		for _, m := range constraint.Any {
+			if m == nil {
+				continue
+			}
+			if !m.UsesPasscode {
+				if pushMethod == nil {
+					pushMethod = m
+				}
+				continue
+			}
+
+			if passcode := mfaPasscode(m, passcodes); passcode != "" {
+				payload[m.ID] = []string{passcode}
+				found = true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+		if pushMethod == nil {
+			return nil, false, fmt.Errorf(
+				"no passcode found for any of the methods of the MFA constraint %q", name)
+		}
+		payload[pushMethod.ID] = []string{""}
+		push = true
+	}
+
+	if len(payload) == 0 {
+		return nil, false, fmt.Errorf("invalid MFA requirement, no MFA methods")
+	}
+
+	return payload, push, nil
+}
+
+func mfaPasscode(m *api.MFAMethodID, passcodes map[string][]byte) string {
+	for _, k := range []string{m.Name, m.ID} {
+		if k == "" {
+			continue
+		}
+		if v := strings.TrimSpace(string(passcodes[k])); v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,225 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	secretsv1beta1 "github.com/hashicorp/vault-secrets-operator/api/v1beta1"
+	"github.com/hashicorp/vault-secrets-operator/credentials/vault"
+	vaultcredsconsts "github.com/hashicorp/vault-secrets-operator/credentials/vault/consts"
+)
+
+func Test_mfaPayload(t *testing.T) {
+	t.Parallel()
+
+	totp := &api.MFAMethodID{Type: "totp", ID: "totp-id", Name: "totp", UsesPasscode: true}
+	duo := &api.MFAMethodID{Type: "duo", ID: "duo-id", UsesPasscode: false}
+	tests := []struct {
+		name      string
+		req       *api.MFARequirement
+		passcodes map[string][]byte
+		want      map[string][]string
+		wantPush  bool
+		wantErr   string
+	}{
+		{
+			name: "passcode-by-name",
+			req: &api.MFARequirement{
+				MFARequestID: "req",
+				MFAConstraints: map[string]*api.MFAConstraintAny{
+					"enforcement": {Any: []*api.MFAMethodID{duo, totp}},
+				},
+			},
+			passcodes: map[string][]byte{"totp": []byte("123456\n")},
+			want:      map[string][]string{"totp-id": {"123456"}},
+		},
+		{
+			name: "passcode-by-id",
+			req: &api.MFARequirement{
+				MFARequestID: "req",
+				MFAConstraints: map[string]*api.MFAConstraintAny{
+					"enforcement": {Any: []*api.MFAMethodID{totp}},
+				},
+			},
+			passcodes: map[string][]byte{"totp-id": []byte("123456")},
+			want:      map[string][]string{"totp-id": {"123456"}},
+		},
+		{
+			name: "push",
+			req: &api.MFARequirement{
+				MFARequestID: "req",
+				MFAConstraints: map[string]*api.MFAConstraintAny{
+					"enforcement": {Any: []*api.MFAMethodID{totp, duo}},
+				},
+			},
+			want:     map[string][]string{"duo-id": {""}},
+			wantPush: true,
+		},
+		{
+			// the validation is retried for the push method.
+			name: "passcode-and-push",
+			req: &api.MFARequirement{
+				MFARequestID: "req",
+				MFAConstraints: map[string]*api.MFAConstraintAny{
+					"a": {Any: []*api.MFAMethodID{totp}},
+					"b": {Any: []*api.MFAMethodID{duo}},
+				},
+			},
+			passcodes: map[string][]byte{"totp": []byte("123456")},
+			want:      map[string][]string{"totp-id": {"123456"}, "duo-id": {""}},
+			wantPush:  true,
+		},
+		{
+			name: "no-passcode",
+			req: &api.MFARequirement{
+				MFARequestID: "req",
+				MFAConstraints: map[string]*api.MFAConstraintAny{
+					"enforcement": {Any: []*api.MFAMethodID{totp}},
+				},
+			},
+			wantErr: `no passcode found for any of the methods of the MFA constraint "enforcement"`,
+		},
+		{
+			name:    "empty-request-id",
+			req:     &api.MFARequirement{},
+			wantErr: "invalid MFA requirement, empty request ID",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, push, err := mfaPayload(tt.req, tt.passcodes)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantPush, push)
+		})
+	}
+}
+
+func Test_defaultClient_Login_mfaPush(t *testing.T) {
+	t.Parallel()
+
+	var validations int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/v1/auth/userpass/login/alice":
+			_, _ = w.Write([]byte(`{"auth":{"mfa_requirement":{"mfa_request_id":"req","mfa_constraints":{"enforcement":{"any":[{"type":"duo","id":"duo-id"}]}}}}}`))
+		case "/v1/sys/mfa/validate":
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+			assert.Equal(t, "req", body["mfa_request_id"])
+			assert.Equal(t, map[string]any{"duo-id": []any{""}}, body["mfa_payload"])
+			validations++
+			if validations == 1 {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"errors":["push not approved yet"]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"auth":{"client_token":"token","accessor":"accessor","lease_duration":60,"renewable":false}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	config := api.DefaultConfig()
+	config.Address = srv.URL
+	client, err := api.NewClient(config)
+	require.NoError(t, err)
+	client.ClearToken()
+
+	authObj := &secretsv1beta1.VaultAuth{
+		Spec: secretsv1beta1.VaultAuthSpec{
+			Method: vaultcredsconsts.ProviderMethodUserPass,
+			Mount:  "userpass",
+			UserPass: &secretsv1beta1.VaultAuthConfigUserPass{
+				Username:  "alice",
+				SecretRef: "userpass",
+			},
+			MFA: &secretsv1beta1.VaultAuthMFA{
+				PushTimeout: "30s",
+			},
+		},
+	}
+	ctx := context.Background()
+	k8sClient := fake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "userpass",
+			Namespace: "foo",
+		},
+		Data: map[string][]byte{
+			"password": []byte("secret"),
+		},
+	}).Build()
+	p := &vault.UserPassCredentialProvider{}
+	require.NoError(t, p.Init(ctx, k8sClient, authObj, "foo"))
+
+	c := &defaultClient{
+		client:             client,
+		authObj:            authObj,
+		credentialProvider: p,
+	}
+	require.NoError(t, c.Login(ctx, k8sClient))
+	assert.Equal(t, 2, validations)
+	assert.Equal(t, "token", client.Token())
+}
+
+func Test_defaultClient_Login_mfaNotConfigured(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		_, _ = w.Write([]byte(`{"auth":{"mfa_requirement":{"mfa_request_id":"req","mfa_constraints":{"enforcement":{"any":[{"type":"duo","id":"duo-id"}]}}}}}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	config := api.DefaultConfig()
+	config.Address = srv.URL
+	client, err := api.NewClient(config)
+	require.NoError(t, err)
+
+	authObj := &secretsv1beta1.VaultAuth{
+		Spec: secretsv1beta1.VaultAuthSpec{
+			Method: vaultcredsconsts.ProviderMethodUserPass,
+			Mount:  "userpass",
+			UserPass: &secretsv1beta1.VaultAuthConfigUserPass{
+				Username:  "alice",
+				SecretRef: "userpass",
+			},
+		},
+	}
+	ctx := context.Background()
+	k8sClient := fake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "userpass",
+			Namespace: "foo",
+		},
+		Data: map[string][]byte{
+			"password": []byte("secret"),
+		},
+	}).Build()
+	p := &vault.UserPassCredentialProvider{}
+	require.NoError(t, p.Init(ctx, k8sClient, authObj, "foo"))
+
+	c := &defaultClient{
+		client:             client,
+		authObj:            authObj,
+		credentialProvider: p,
+	}
+	assert.EqualError(t, c.Login(ctx, k8sClient), "the login requires MFA, but mfa is not configured")
+}
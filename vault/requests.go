@@ -23,6 +23,7 @@ var (
 	_ ReadRequest  = (*kvReadRequestV2)(nil)
 	_ ReadRequest  = (*defaultReadRequest)(nil)
 	_ WriteRequest = (*defaultWriteRequest)(nil)
+	_ WriteRequest = (*kvWriteRequestV2)(nil)
 )
 
 type defaultWriteRequest struct {
@@ -89,6 +90,28 @@ func (r *kvReadRequestV2) Values() url.Values {
 	return vals
 }
 
+// kvWriteRequestV2 can be used in ClientBase.Write to write KV version 2
+// secrets to Vault, with check-and-set.
+type kvWriteRequestV2 struct {
+	mount string
+	path  string
+	data  map[string]any
+	cas   int
+}
+
+func (r *kvWriteRequestV2) Path() string {
+	return JoinPath(r.mount, "data", r.path)
+}
+
+func (r *kvWriteRequestV2) Params() map[string]any {
+	return map[string]any{
+		"options": map[string]any{
+			"cas": r.cas,
+		},
+		"data": r.data,
+	}
+}
+
 func NewKVReadRequestV1(mount, path string) ReadRequest {
 	return &kvReadRequestV1{
 		mount: mount,
@@ -104,6 +127,46 @@ func NewKVReadRequestV2(mount, path string, version int) ReadRequest {
 	}
 }
 
+// NewKVListRequestV1 returns a ReadRequest that lists the KV version 1 secrets
+// and folders under path.
+// NewKVWriteRequestV1 returns a WriteRequest that writes data to the KV version
+// 1 secret at path. KV version 1 has no check-and-set, the secret is always
+// overwritten.
+func NewKVWriteRequestV1(mount, path string, data map[string]any) WriteRequest {
+	return NewWriteRequest(JoinPath(mount, path), data)
+}
+
+// NewKVWriteRequestV2 returns a WriteRequest that writes data to the KV version
+// 2 secret at path, with check-and-set. The write is only accepted by Vault if
+// cas is the current version of the secret, or if it is 0 and the secret does
+// not exist. Otherwise, Vault rejects it with an error for which
+// IsCheckAndSetError returns true, so that concurrent writes are never
+// overwritten.
+func NewKVWriteRequestV2(mount, path string, data map[string]any, cas int) WriteRequest {
+	return &kvWriteRequestV2{
+		mount: mount,
+		path:  path,
+		data:  data,
+		cas:   cas,
+	}
+}
+
+func NewKVListRequestV1(mount, path string) ReadRequest {
+	return NewReadRequest(JoinPath(mount, path), listValues())
+}
+
+// NewKVListRequestV2 returns a ReadRequest that lists the KV version 2 secrets
+// and folders under path.
+func NewKVListRequestV2(mount, path string) ReadRequest {
+	return NewReadRequest(JoinPath(mount, "metadata", path), listValues())
+}
+
+func listValues() url.Values {
+	return map[string][]string{
+		"list": {"true"},
+	}
+}
+
 func NewReadRequest(path string, values url.Values) ReadRequest {
 	return &defaultReadRequest{
 		path:   path,
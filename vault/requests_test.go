@@ -199,3 +199,28 @@ func Test_defaultWriteRequest_Params(t *testing.T) {
 		})
 	}
 }
+
+func TestNewKVListRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		req  ReadRequest
+		want string
+	}{
+		{
+			name: "kv-v1",
+			req:  NewKVListRequestV1("foo", "baz"),
+			want: "foo/baz",
+		},
+		{
+			name: "kv-v2",
+			req:  NewKVListRequestV2("foo", "baz"),
+			want: "foo/metadata/baz",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equalf(t, tt.want, tt.req.Path(), "Path()")
+			assert.Equalf(t, url.Values{"list": {"true"}}, tt.req.Values(), "Values()")
+		})
+	}
+}
@@ -6,7 +6,9 @@ package vault
 import (
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"strings"
 
 	"github.com/hashicorp/vault/api"
 
@@ -133,6 +135,23 @@ func NewDefaultResponse(secret *api.Secret) Response {
 	}
 }
 
+// SecretNotFoundError is returned by Client.Read when Vault has no secret at
+// the path.
+type SecretNotFoundError struct {
+	Path string
+}
+
+func (e *SecretNotFoundError) Error() string {
+	return fmt.Sprintf("empty response from Vault, path=%q", e.Path)
+}
+
+// IsSecretNotFoundError returns true if Vault has no secret at the path that
+// was read.
+func IsSecretNotFoundError(err error) bool {
+	var notFoundErr *SecretNotFoundError
+	return errors.As(err, &notFoundErr)
+}
+
 // IsLeaseNotFoundError returns true if a lease not found error is returned from Vault.
 func IsLeaseNotFoundError(err error) bool {
 	var respErr *api.ResponseError
@@ -144,6 +163,53 @@ func IsLeaseNotFoundError(err error) bool {
 	return false
 }
 
+// IsInvalidWrappingTokenError returns true if Vault rejected a response-wrapping
+// token, because it has expired, or because it has already been unwrapped.
+func IsInvalidWrappingTokenError(err error) bool {
+	var respErr *api.ResponseError
+	if errors.As(err, &respErr) && respErr != nil {
+		if respErr.StatusCode == http.StatusBadRequest {
+			for _, e := range respErr.Errors {
+				if strings.Contains(e, "wrapping token is not valid or does not exist") {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// IsCheckAndSetError returns true if Vault rejected a KV version 2 write,
+// since its check-and-set version did not match the current version of the
+// secret, i.e. the secret was written concurrently.
+func IsCheckAndSetError(err error) bool {
+	var respErr *api.ResponseError
+	if errors.As(err, &respErr) && respErr != nil {
+		if respErr.StatusCode == http.StatusBadRequest {
+			for _, e := range respErr.Errors {
+				if strings.Contains(e, "check-and-set parameter did not match the current version") {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// IsUnavailableError returns true if err denotes that Vault is unavailable,
+// i.e. it could not be reached, or it responded with a server error, e.g. when
+// it is sealed, or with a rate limit error.
+func IsUnavailableError(err error) bool {
+	var respErr *api.ResponseError
+	if errors.As(err, &respErr) && respErr != nil {
+		return respErr.StatusCode >= http.StatusInternalServerError ||
+			respErr.StatusCode == http.StatusTooManyRequests
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
 // IsForbiddenError returns true if a forbidden error is returned from Vault.
 func IsForbiddenError(err error) bool {
 	var respErr *api.ResponseError
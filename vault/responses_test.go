@@ -4,8 +4,11 @@
 package vault
 
 import (
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"net/url"
 	"testing"
 
 	"github.com/hashicorp/vault/api"
@@ -767,6 +770,101 @@ func TestIsForbiddenError(t *testing.T) {
 	}
 }
 
+func TestIsCheckAndSetError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  *api.ResponseError
+		want bool
+	}{
+		{
+			name: "nil",
+			err:  nil,
+			want: false,
+		},
+		{
+			name: "cas-mismatch",
+			err: &api.ResponseError{
+				StatusCode: http.StatusBadRequest,
+				Errors:     []string{"check-and-set parameter did not match the current version"},
+			},
+			want: true,
+		},
+		{
+			name: "cas-required",
+			err: &api.ResponseError{
+				StatusCode: http.StatusBadRequest,
+				Errors:     []string{"check-and-set parameter required for this call"},
+			},
+			want: false,
+		},
+		{
+			name: "wrong-status-code",
+			err: &api.ResponseError{
+				StatusCode: http.StatusForbidden,
+				Errors:     []string{"check-and-set parameter did not match the current version"},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equalf(t, tt.want, IsCheckAndSetError(tt.err), "IsCheckAndSetError(%v)", tt.err)
+		})
+	}
+}
+
+func TestIsUnavailableError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "nil",
+			err:  nil,
+			want: false,
+		},
+		{
+			name: "forbidden",
+			err:  &api.ResponseError{StatusCode: http.StatusForbidden},
+			want: false,
+		},
+		{
+			name: "sealed",
+			err:  fmt.Errorf("read failed: %w", &api.ResponseError{StatusCode: http.StatusServiceUnavailable}),
+			want: true,
+		},
+		{
+			name: "rate-limited",
+			err:  &api.ResponseError{StatusCode: http.StatusTooManyRequests},
+			want: true,
+		},
+		{
+			name: "unreachable",
+			err: &url.Error{
+				Op:  http.MethodGet,
+				URL: "https://vault.example.com:8200/v1/kv/data/foo",
+				Err: &net.OpError{Op: "dial", Err: errors.New("connection refused")},
+			},
+			want: true,
+		},
+		{
+			name: "other",
+			err:  errors.New(`empty response from Vault, path="kv/data/foo"`),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equalf(t, tt.want, IsUnavailableError(tt.err), "IsUnavailableError(%v)", tt.err)
+		})
+	}
+}
+
 func assertResponseData(t *testing.T, tt testResponseData) {
 	t.Helper()
 	resp := tt.respFunc(tt)
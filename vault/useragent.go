@@ -0,0 +1,73 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package vault
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/vault-secrets-operator/internal/version"
+)
+
+// HeaderUserAgent is the name of the User-Agent header.
+const HeaderUserAgent = "User-Agent"
+
+// DefaultUserAgent is the User-Agent of all the requests to Vault. It may be
+// overridden by the Headers of a VaultConnection.
+var DefaultUserAgent = fmt.Sprintf("vso/%s", version.Version().String())
+
+// UserAgentOptions configure the attribution of the requests to Vault, so
+// that the traffic of multiple Operator installs sharing one Vault can be
+// told apart.
+type UserAgentOptions struct {
+	// ClusterID identifies the K8s cluster the Operator runs in.
+	ClusterID string
+	// InstanceID identifies the Operator install within the cluster.
+	InstanceID string
+	// Suffix is appended to the User-Agent as is.
+	Suffix string
+}
+
+// MakeUserAgent returns the User-Agent made of the Operator's product token,
+// followed by the identifiers, and the suffix of opts, e.g.
+// "vso/0.9.0 (cluster=prod-eu; instance=vso) team-a".
+func MakeUserAgent(opts UserAgentOptions) (string, error) {
+	var comments []string
+	for _, attr := range []struct {
+		name  string
+		value string
+	}{
+		{"cluster", opts.ClusterID},
+		{"instance", opts.InstanceID},
+	} {
+		if attr.value == "" {
+			continue
+		}
+		if strings.ContainsAny(attr.value, "();\\") || !isPrintableASCII(attr.value) {
+			return "", fmt.Errorf("invalid %s identifier %q", attr.name, attr.value)
+		}
+		comments = append(comments, attr.name+"="+attr.value)
+	}
+	if !isPrintableASCII(opts.Suffix) {
+		return "", fmt.Errorf("invalid User-Agent suffix %q", opts.Suffix)
+	}
+
+	ua := fmt.Sprintf("vso/%s", version.Version().String())
+	if len(comments) > 0 {
+		ua += " (" + strings.Join(comments, "; ") + ")"
+	}
+	if suffix := strings.TrimSpace(opts.Suffix); suffix != "" {
+		ua += " " + suffix
+	}
+	return ua, nil
+}
+
+func isPrintableASCII(s string) bool {
+	for _, r := range s {
+		if r < ' ' || r > '~' {
+			return false
+		}
+	}
+	return true
+}
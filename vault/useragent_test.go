@@ -0,0 +1,77 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package vault
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hashicorp/vault-secrets-operator/internal/version"
+)
+
+func TestMakeUserAgent(t *testing.T) {
+	t.Parallel()
+
+	product := "vso/" + version.Version().String()
+	tests := []struct {
+		name    string
+		opts    UserAgentOptions
+		want    string
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "default",
+			want:    product,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "all",
+			opts: UserAgentOptions{
+				ClusterID:  "prod-eu",
+				InstanceID: "vso/team-a",
+				Suffix:     " acme-platform/1.2 ",
+			},
+			want:    product + " (cluster=prod-eu; instance=vso/team-a) acme-platform/1.2",
+			wantErr: assert.NoError,
+		},
+		{
+			name: "suffix-only",
+			opts: UserAgentOptions{
+				Suffix: "acme-platform",
+			},
+			want:    product + " acme-platform",
+			wantErr: assert.NoError,
+		},
+		{
+			name: "invalid-cluster-id",
+			opts: UserAgentOptions{
+				ClusterID: "prod;eu",
+			},
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.EqualError(t, err, `invalid cluster identifier "prod;eu"`, i...)
+			},
+		},
+		{
+			name: "invalid-suffix",
+			opts: UserAgentOptions{
+				Suffix: "acme\r\nX-Injected: true",
+			},
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.EqualError(t, err, `invalid User-Agent suffix "acme\r\nX-Injected: true"`, i...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := MakeUserAgent(tt.opts)
+			if !tt.wantErr(t, err) {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}